@@ -1,8 +1,11 @@
 package accessor
 
 import (
+	"bufio"
 	"context"
+	"net"
 	"net/http"
+	"sync"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc/auditor"
@@ -60,8 +63,89 @@ func (h *accessorHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	ctx := context.WithValue(r.Context(), "accessor", acc)
 
-	h.auditor.Audit(h.action, claims.UserName, r)
-	h.handler.ServeHTTP(w, r.WithContext(ctx))
+	// parse the form before the handler gets to consume the body, keeping only
+	// the body's values so that the form is rebuilt from the query once any
+	// route params have been added to it
+	_ = r.ParseForm()
+	r.Form = nil
+
+	// the request is audited as soon as its status is known, so that streaming
+	// and hijacked requests are audited when they start rather than end
+	var audited sync.Once
+	audit := func(status int) {
+		audited.Do(func() {
+			h.auditor.Audit(h.action, claims.UserName, r, status)
+		})
+	}
+
+	recorder := &statusRecorder{ResponseWriter: w, onStatus: audit}
+
+	defer func() {
+		if p := recover(); p != nil {
+			audit(http.StatusInternalServerError)
+			panic(p)
+		}
+
+		audit(recorder.Status())
+	}()
+
+	h.handler.ServeHTTP(recorder, r.WithContext(ctx))
+}
+
+type statusRecorder struct {
+	http.ResponseWriter
+	status   int
+	onStatus func(int)
+}
+
+func (s *statusRecorder) WriteHeader(status int) {
+	s.ResponseWriter.WriteHeader(status)
+	s.record(status)
+}
+
+func (s *statusRecorder) Write(p []byte) (int, error) {
+	n, err := s.ResponseWriter.Write(p)
+	s.record(http.StatusOK)
+	return n, err
+}
+
+func (s *statusRecorder) record(status int) {
+	if s.status != 0 {
+		return
+	}
+
+	s.status = status
+	s.onStatus(status)
+}
+
+func (s *statusRecorder) Flush() {
+	if flusher, ok := s.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+func (s *statusRecorder) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := s.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+
+	conn, rw, err := hijacker.Hijack()
+	if err == nil {
+		s.record(http.StatusSwitchingProtocols)
+	}
+
+	return conn, rw, err
+}
+
+// Status returns the status written by the handler, defaulting to 200 as
+// net/http does when nothing was written at all.
+func (s *statusRecorder) Status() int {
+	if s.status == 0 {
+		return http.StatusOK
+	}
+
+	return s.status
 }
 
 func GetAccessor(r *http.Request) Access {
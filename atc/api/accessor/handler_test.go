@@ -2,8 +2,10 @@ package accessor_test
 
 import (
 	"errors"
+	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
@@ -30,6 +32,8 @@ var _ = Describe("Handler", func() {
 
 		r *http.Request
 		w *httptest.ResponseRecorder
+
+		recovered interface{}
 	)
 
 	BeforeEach(func() {
@@ -61,7 +65,13 @@ var _ = Describe("Handler", func() {
 			customRoles,
 		)
 
-		handler.ServeHTTP(w, r)
+		func() {
+			defer func() {
+				recovered = recover()
+			}()
+
+			handler.ServeHTTP(w, r)
+		}()
 	})
 
 	Describe("Accessor Handler", func() {
@@ -127,10 +137,84 @@ var _ = Describe("Handler", func() {
 
 			It("audits the event", func() {
 				Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
-				action, userName, req := fakeAuditor.AuditArgsForCall(0)
+				action, userName, req, status := fakeAuditor.AuditArgsForCall(0)
 				Expect(action).To(Equal("some-action"))
 				Expect(userName).To(Equal("some-user"))
 				Expect(req).To(Equal(r))
+				Expect(status).To(Equal(http.StatusOK))
+			})
+
+			Context("when the handler responds with an error", func() {
+				BeforeEach(func() {
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusForbidden)
+					}
+				})
+
+				It("audits the outcome of the request", func() {
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+					_, _, _, status := fakeAuditor.AuditArgsForCall(0)
+					Expect(status).To(Equal(http.StatusForbidden))
+				})
+
+				It("passes the status through", func() {
+					Expect(w.Code).To(Equal(http.StatusForbidden))
+				})
+			})
+
+			Context("when the handler streams its response", func() {
+				var auditedWhileStreaming int
+
+				BeforeEach(func() {
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						w.WriteHeader(http.StatusOK)
+						w.(http.Flusher).Flush()
+						auditedWhileStreaming = fakeAuditor.AuditCallCount()
+					}
+				})
+
+				It("audits the request as soon as the status is written", func() {
+					Expect(auditedWhileStreaming).To(Equal(1))
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the handler consumes a form body", func() {
+				BeforeEach(func() {
+					var err error
+					r, err = http.NewRequest("POST", "localhost:8080", strings.NewReader("some-param=some-value"))
+					Expect(err).NotTo(HaveOccurred())
+					r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						_, _ = ioutil.ReadAll(r.Body)
+					}
+				})
+
+				It("audits the form parameters", func() {
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+					_, _, req, _ := fakeAuditor.AuditArgsForCall(0)
+					Expect(req.ParseForm()).To(Succeed())
+					Expect(req.Form.Get("some-param")).To(Equal("some-value"))
+				})
+			})
+
+			Context("when the handler panics", func() {
+				BeforeEach(func() {
+					fakeHandler.ServeHTTPStub = func(w http.ResponseWriter, r *http.Request) {
+						panic("something went wrong")
+					}
+				})
+
+				It("audits the request as a server error", func() {
+					Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
+					_, _, _, status := fakeAuditor.AuditArgsForCall(0)
+					Expect(status).To(Equal(http.StatusInternalServerError))
+				})
+
+				It("propagates the panic", func() {
+					Expect(recovered).To(Equal("something went wrong"))
+				})
 			})
 
 			It("invokes the handler", func() {
@@ -148,7 +232,7 @@ var _ = Describe("Handler", func() {
 
 			It("audits the anonymous request", func() {
 				Expect(fakeAuditor.AuditCallCount()).To(Equal(1))
-				action, userName, req := fakeAuditor.AuditArgsForCall(0)
+				action, userName, req, _ := fakeAuditor.AuditArgsForCall(0)
 				Expect(action).To(Equal("some-action"))
 				Expect(userName).To(Equal(""))
 				Expect(req).To(Equal(r))
@@ -14,6 +14,8 @@ const (
 var DefaultRoles = map[string]string{
 	atc.SaveConfig:                    MemberRole,
 	atc.GetConfig:                     ViewerRole,
+	atc.ListPipelineConfigVersions:    ViewerRole,
+	atc.GetPipelineConfigVersion:      ViewerRole,
 	atc.GetCC:                         ViewerRole,
 	atc.GetBuild:                      ViewerRole,
 	atc.GetBuildPlan:                  ViewerRole,
@@ -22,6 +24,8 @@ var DefaultRoles = map[string]string{
 	atc.BuildEvents:                   ViewerRole,
 	atc.BuildResources:                ViewerRole,
 	atc.AbortBuild:                    OperatorRole,
+	atc.ApproveBuild:                  MemberRole,
+	atc.RejectBuild:                   MemberRole,
 	atc.GetBuildPreparation:           ViewerRole,
 	atc.GetJob:                        ViewerRole,
 	atc.CreateJobBuild:                OperatorRole,
@@ -94,9 +98,16 @@ var DefaultRoles = map[string]string{
 	atc.SetTeam:                       OwnerRole,
 	atc.RenameTeam:                    OwnerRole,
 	atc.DestroyTeam:                   OwnerRole,
+	atc.ListAuditEvents:               OwnerRole,
+	atc.ListWebhooks:                  MemberRole,
+	atc.SetWebhook:                    MemberRole,
+	atc.DestroyWebhook:                MemberRole,
+	atc.ListWebhookDeliveries:         MemberRole,
 	atc.ListTeamBuilds:                ViewerRole,
 	atc.CreateArtifact:                MemberRole,
 	atc.GetArtifact:                   MemberRole,
 	atc.ListBuildArtifacts:            ViewerRole,
+	atc.ListBuildStoredArtifacts:      ViewerRole,
+	atc.GetBuildStoredArtifact:        ViewerRole,
 	atc.GetWall:                       ViewerRole,
 }
@@ -21,6 +21,7 @@ import (
 	"github.com/concourse/concourse/atc/api/containerserver/containerserverfakes"
 	"github.com/concourse/concourse/atc/api/policychecker/policycheckerfakes"
 	"github.com/concourse/concourse/atc/auditor/auditorfakes"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/creds/credsfakes"
 	"github.com/concourse/concourse/atc/db"
@@ -61,6 +62,9 @@ var (
 	dbCheckFactory          *dbfakes.FakeCheckFactory
 	dbTeam                  *dbfakes.FakeTeam
 	dbWall                  *dbfakes.FakeWall
+	dbAuditEventFactory     *dbfakes.FakeAuditEventFactory
+	dbWebhookFactory        *dbfakes.FakeWebhookFactory
+	fakeArtifactStore       *blobstorefakes.FakeStore
 	fakeSecretManager       *credsfakes.FakeSecrets
 	fakeVarSourcePool       *credsfakes.FakeVarSourcePool
 	fakePolicyChecker       *policycheckerfakes.FakePolicyChecker
@@ -109,6 +113,9 @@ var _ = BeforeEach(func() {
 	dbUserFactory = new(dbfakes.FakeUserFactory)
 	dbCheckFactory = new(dbfakes.FakeCheckFactory)
 	dbWall = new(dbfakes.FakeWall)
+	dbAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
+	dbWebhookFactory = new(dbfakes.FakeWebhookFactory)
+	fakeArtifactStore = new(blobstorefakes.FakeStore)
 
 	interceptTimeoutFactory = new(containerserverfakes.FakeInterceptTimeoutFactory)
 	interceptTimeout = new(containerserverfakes.FakeInterceptTimeout)
@@ -218,6 +225,9 @@ var _ = BeforeEach(func() {
 		time.Second,
 		dbWall,
 		fakeClock,
+		fakeArtifactStore,
+		dbAuditEventFactory,
+		dbWebhookFactory,
 	)
 
 	atc.EnablePipelineInstances = true
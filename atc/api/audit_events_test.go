@@ -0,0 +1,252 @@
+package api_test
+
+import (
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Audit Events API", func() {
+	Describe("GET /api/v1/teams/:team_name/audit_events", func() {
+		var (
+			query    string
+			response *http.Response
+		)
+
+		BeforeEach(func() {
+			query = ""
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/some-team/audit_events"+query, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+
+			It("does not look up any events", func() {
+				Expect(dbAuditEventFactory.TeamAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the events works", func() {
+				BeforeEach(func() {
+					dbAuditEventFactory.TeamAuditEventsReturns([]db.AuditEvent{
+						{
+							ID:           3,
+							Action:       "PausePipeline",
+							UserName:     "some-user",
+							TeamName:     "some-team",
+							PipelineName: "some-pipeline",
+							SourceIP:     "1.2.3.4",
+							Status:       200,
+							CreatedAt:    time.Unix(100, 0),
+						},
+					}, db.Pagination{
+						Older: &db.Page{To: db.NewIntPtr(2), Limit: 1},
+						Newer: &db.Page{From: db.NewIntPtr(4), Limit: 1},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns Content-Type 'application/json'", func() {
+					expectedHeaderEntries := map[string]string{
+						"Content-Type": "application/json",
+					}
+					Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
+				})
+
+				It("returns the events", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 3,
+							"action": "PausePipeline",
+							"user_name": "some-user",
+							"team_name": "some-team",
+							"pipeline_name": "some-pipeline",
+							"source_ip": "1.2.3.4",
+							"status": 200,
+							"created_at": 100
+						}
+					]`))
+				})
+
+				It("looks up the team's events with the default page", func() {
+					Expect(dbAuditEventFactory.TeamAuditEventsCallCount()).To(Equal(1))
+					teamName, filter, page := dbAuditEventFactory.TeamAuditEventsArgsForCall(0)
+					Expect(teamName).To(Equal("some-team"))
+					Expect(filter).To(Equal(db.AuditEventFilter{}))
+					Expect(page).To(Equal(db.Page{Limit: 100}))
+				})
+
+				Context("when filters and a page are given", func() {
+					BeforeEach(func() {
+						query = "?action=PausePipeline&user=some-user&pipeline=some-pipeline&since=100&until=200&to=10&limit=1"
+					})
+
+					It("passes them along", func() {
+						Expect(dbAuditEventFactory.TeamAuditEventsCallCount()).To(Equal(1))
+						_, filter, page := dbAuditEventFactory.TeamAuditEventsArgsForCall(0)
+						Expect(filter).To(Equal(db.AuditEventFilter{
+							Action:       "PausePipeline",
+							UserName:     "some-user",
+							PipelineName: "some-pipeline",
+							Since:        time.Unix(100, 0),
+							Until:        time.Unix(200, 0),
+						}))
+						Expect(page).To(Equal(db.Page{To: db.NewIntPtr(10), Limit: 1}))
+					})
+
+					It("includes the filters in the pagination links", func() {
+						Expect(response.Header["Link"]).To(ConsistOf([]string{
+							`<https://example.com/api/v1/teams/some-team/audit_events?action=PausePipeline&limit=1&pipeline=some-pipeline&since=100&to=2&until=200&user=some-user>; rel="next"`,
+							`<https://example.com/api/v1/teams/some-team/audit_events?action=PausePipeline&from=4&limit=1&pipeline=some-pipeline&since=100&until=200&user=some-user>; rel="previous"`,
+						}))
+					})
+				})
+			})
+
+			Context("when the since filter is invalid", func() {
+				BeforeEach(func() {
+					query = "?since=yesterday"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when getting the events fails", func() {
+				BeforeEach(func() {
+					dbAuditEventFactory.TeamAuditEventsReturns(nil, db.Pagination{}, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/audit_events", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/audit_events?user=some-user&limit=1", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+
+			It("does not look up any events", func() {
+				Expect(dbAuditEventFactory.AllAuditEventsCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when an admin", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAdminReturns(true)
+
+				dbAuditEventFactory.AllAuditEventsReturns([]db.AuditEvent{
+					{
+						ID:        3,
+						Action:    "LandWorker",
+						UserName:  "some-user",
+						Object:    "worker:some-worker",
+						Status:    200,
+						CreatedAt: time.Unix(100, 0),
+					},
+				}, db.Pagination{
+					Older: &db.Page{To: db.NewIntPtr(2), Limit: 1},
+				}, nil)
+			})
+
+			It("returns the events of every team and those without one", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+				body, err := ioutil.ReadAll(response.Body)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(body).To(MatchJSON(`[
+					{
+						"id": 3,
+						"action": "LandWorker",
+						"user_name": "some-user",
+						"object": "worker:some-worker",
+						"status": 200,
+						"created_at": 100
+					}
+				]`))
+			})
+
+			It("passes the filters and page along", func() {
+				Expect(dbAuditEventFactory.AllAuditEventsCallCount()).To(Equal(1))
+				filter, page := dbAuditEventFactory.AllAuditEventsArgsForCall(0)
+				Expect(filter).To(Equal(db.AuditEventFilter{UserName: "some-user"}))
+				Expect(page).To(Equal(db.Page{Limit: 1}))
+			})
+
+			It("links to the next page", func() {
+				Expect(response.Header["Link"]).To(ConsistOf([]string{
+					`<https://example.com/api/v1/audit_events?limit=1&to=2&user=some-user>; rel="next"`,
+				}))
+			})
+		})
+	})
+})
@@ -0,0 +1,156 @@
+package auditserver
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-audit-events")
+
+	teamName := r.FormValue(":team_name")
+
+	s.listAuditEvents(logger, w, r, "/api/v1/teams/"+teamName+"/audit_events", func(filter db.AuditEventFilter, page db.Page) ([]db.AuditEvent, db.Pagination, error) {
+		return s.auditEventFactory.TeamAuditEvents(teamName, filter, page)
+	})
+}
+
+// ListAllAuditEvents lists the events of every team, along with those of
+// requests which did not target a team, e.g. requests made by workers.
+func (s *Server) ListAllAuditEvents(w http.ResponseWriter, r *http.Request) {
+	logger := s.logger.Session("list-all-audit-events")
+
+	s.listAuditEvents(logger, w, r, "/api/v1/audit_events", s.auditEventFactory.AllAuditEvents)
+}
+
+func (s *Server) listAuditEvents(
+	logger lager.Logger,
+	w http.ResponseWriter,
+	r *http.Request,
+	path string,
+	auditEvents func(db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error),
+) {
+	page := db.Page{}
+
+	page.Limit, _ = strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+	if page.Limit == 0 {
+		page.Limit = atc.PaginationAPIDefaultLimit
+	}
+
+	if urlFrom := r.FormValue(atc.PaginationQueryFrom); urlFrom != "" {
+		from, _ := strconv.Atoi(urlFrom)
+		page.From = db.NewIntPtr(from)
+	}
+
+	if urlTo := r.FormValue(atc.PaginationQueryTo); urlTo != "" {
+		to, _ := strconv.Atoi(urlTo)
+		page.To = db.NewIntPtr(to)
+	}
+
+	filter := db.AuditEventFilter{
+		Action:       r.FormValue(atc.AuditEventQueryAction),
+		UserName:     r.FormValue(atc.AuditEventQueryUser),
+		PipelineName: r.FormValue(atc.AuditEventQueryPipeline),
+	}
+
+	var err error
+	filter.Since, err = parseTimestamp(r.FormValue(atc.AuditEventQuerySince))
+	if err != nil {
+		logger.Info("invalid-since", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	filter.Until, err = parseTimestamp(r.FormValue(atc.AuditEventQueryUntil))
+	if err != nil {
+		logger.Info("invalid-until", lager.Data{"error": err.Error()})
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	events, pagination, err := auditEvents(filter, page)
+	if err != nil {
+		logger.Error("failed-to-get-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if pagination.Older != nil {
+		s.addLink(w, path, filter, atc.PaginationQueryTo, *pagination.Older.To, page.Limit, atc.LinkRelNext)
+	}
+
+	if pagination.Newer != nil {
+		s.addLink(w, path, filter, atc.PaginationQueryFrom, *pagination.Newer.From, page.Limit, atc.LinkRelPrevious)
+	}
+
+	presented := []atc.AuditEvent{}
+	for _, event := range events {
+		presented = append(presented, present.AuditEvent(event))
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusOK)
+
+	err = json.NewEncoder(w).Encode(presented)
+	if err != nil {
+		logger.Error("failed-to-encode-audit-events", err)
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+}
+
+func (s *Server) addLink(w http.ResponseWriter, path string, filter db.AuditEventFilter, boundary string, id int, limit int, rel string) {
+	query := url.Values{}
+	query.Set(boundary, strconv.Itoa(id))
+	query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+
+	if filter.Action != "" {
+		query.Set(atc.AuditEventQueryAction, filter.Action)
+	}
+
+	if filter.UserName != "" {
+		query.Set(atc.AuditEventQueryUser, filter.UserName)
+	}
+
+	if filter.PipelineName != "" {
+		query.Set(atc.AuditEventQueryPipeline, filter.PipelineName)
+	}
+
+	if !filter.Since.IsZero() {
+		query.Set(atc.AuditEventQuerySince, strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		query.Set(atc.AuditEventQueryUntil, strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	w.Header().Add("Link", fmt.Sprintf(
+		`<%s%s?%s>; rel="%s"`,
+		s.externalURL,
+		path,
+		query.Encode(),
+		rel,
+	))
+}
+
+func parseTimestamp(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	seconds, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return time.Unix(seconds, 0), nil
+}
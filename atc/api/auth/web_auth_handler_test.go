@@ -140,7 +140,7 @@ var _ = Describe("WebAuthHandler", func() {
 					server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 						defer GinkgoRecover()
 						auth.WebAuthHandler{
-							Handler:    buildserver.NewEventHandler(lager.NewLogger("test"), build),
+							Handler:    buildserver.NewEventHandler(lager.NewLogger("test"), db.NewPostgresBuildEventStore(), build),
 							Middleware: fakeMiddleware,
 						}.ServeHTTP(w, r)
 					}))
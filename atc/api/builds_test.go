@@ -10,6 +10,8 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
@@ -636,6 +638,43 @@ var _ = Describe("Builds API", func() {
 						"reap_time": 200
 					}`))
 						})
+
+						Context("when the build has recorded resource usage", func() {
+							BeforeEach(func() {
+								build.ResourceUsageReturns([]atc.StepResourceUsage{
+									{
+										PlanID:          "some-plan-id",
+										StepName:        "some-task",
+										StepType:        "task",
+										CPUSeconds:      1.5,
+										PeakMemoryBytes: 1024,
+										DiskBytes:       2048,
+										WallSeconds:     10,
+									},
+								})
+							})
+
+							It("includes the resource usage of each step", func() {
+								body, err := ioutil.ReadAll(response.Body)
+								Expect(err).NotTo(HaveOccurred())
+
+								var result map[string]interface{}
+								err = json.Unmarshal(body, &result)
+								Expect(err).NotTo(HaveOccurred())
+
+								Expect(result["resource_usage"]).To(Equal([]interface{}{
+									map[string]interface{}{
+										"plan_id":           "some-plan-id",
+										"step_name":         "some-task",
+										"step_type":         "task",
+										"cpu_seconds":       1.5,
+										"peak_memory_bytes": float64(1024),
+										"disk_bytes":        float64(2048),
+										"wall_seconds":      float64(10),
+									},
+								}))
+							})
+						})
 					})
 				})
 			})
@@ -1113,6 +1152,119 @@ var _ = Describe("Builds API", func() {
 		})
 	})
 
+	for _, decision := range []struct {
+		action   string
+		approved bool
+	}{
+		{"approve", true},
+		{"reject", false},
+	} {
+		decision := decision
+
+		Describe("PUT /api/v1/builds/:build_id/"+decision.action, func() {
+			var (
+				response *http.Response
+			)
+
+			JustBeforeEach(func() {
+				var err error
+
+				req, err := http.NewRequest("PUT", server.URL+"/api/v1/builds/128/"+decision.action, nil)
+				Expect(err).NotTo(HaveOccurred())
+
+				response, err = client.Do(req)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when not authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(false)
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				})
+			})
+
+			Context("when authenticated", func() {
+				BeforeEach(func() {
+					fakeAccess.IsAuthenticatedReturns(true)
+					fakeAccess.ClaimsReturns(accessor.Claims{UserName: "some-user"})
+				})
+
+				Context("when the build can not be found", func() {
+					BeforeEach(func() {
+						dbBuildFactory.BuildReturns(nil, false, nil)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when the build is found", func() {
+					BeforeEach(func() {
+						build.TeamNameReturns("some-team")
+						dbBuildFactory.BuildReturns(build, true, nil)
+					})
+
+					Context("when not authorized", func() {
+						BeforeEach(func() {
+							fakeAccess.IsAuthorizedReturns(false)
+						})
+
+						It("returns 403", func() {
+							Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+						})
+					})
+
+					Context("when authorized", func() {
+						BeforeEach(func() {
+							fakeAccess.IsAuthorizedReturns(true)
+						})
+
+						Context("when deciding fails", func() {
+							BeforeEach(func() {
+								build.DecideApprovalReturns(false, errors.New("nope"))
+							})
+
+							It("returns 500", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+							})
+						})
+
+						Context("when the build is not waiting for approval", func() {
+							BeforeEach(func() {
+								build.DecideApprovalReturns(false, nil)
+							})
+
+							It("returns 409", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusConflict))
+							})
+						})
+
+						Context("when the decision is made", func() {
+							BeforeEach(func() {
+								build.DecideApprovalReturns(true, nil)
+							})
+
+							It("returns 204", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+							})
+
+							It("records the decision and who made it", func() {
+								Expect(build.DecideApprovalCallCount()).To(Equal(1))
+								approved, decidedBy := build.DecideApprovalArgsForCall(0)
+								Expect(approved).To(Equal(decision.approved))
+								Expect(decidedBy).To(Equal("some-user"))
+							})
+						})
+					})
+				})
+			})
+		})
+	}
+
 	Describe("GET /api/v1/builds/:build_id/preparation", func() {
 		var response *http.Response
 
@@ -1530,4 +1682,159 @@ var _ = Describe("Builds API", func() {
 			})
 		})
 	})
+
+	Describe("GET /api/v1/builds/:build_id/stored_artifacts", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/stored_artifacts")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				build.PipelineIDReturns(0)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the build has stored artifacts", func() {
+				BeforeEach(func() {
+					build.StoredArtifactsReturns([]db.StoredArtifact{
+						{ID: 1, Name: "some-output/app.tgz", Key: "some-key", Size: 1024, CreatedAt: time.Unix(42, 0)},
+					}, nil)
+				})
+
+				It("returns 200 with the artifacts", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response).To(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/json",
+					}))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{"id": 1, "name": "some-output/app.tgz", "size": 1024, "created_at": 42}
+					]`))
+				})
+			})
+
+			Context("when fetching the artifacts fails", func() {
+				BeforeEach(func() {
+					build.StoredArtifactsReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when the build is not found", func() {
+			BeforeEach(func() {
+				dbBuildFactory.BuildReturns(nil, false, nil)
+			})
+
+			It("returns 404", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/builds/:build_id/stored_artifacts/:artifact_id", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+			response, err = http.Get(server.URL + "/api/v1/builds/42/stored_artifacts/1")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+				build.PipelineIDReturns(0)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbBuildFactory.BuildReturns(build, true, nil)
+			})
+
+			Context("when the artifact belongs to the build", func() {
+				BeforeEach(func() {
+					build.StoredArtifactReturns(db.StoredArtifact{ID: 1, Key: "some-key"}, true, nil)
+					fakeArtifactStore.GetReturns(ioutil.NopCloser(bytes.NewBufferString("some-tarball")), nil)
+				})
+
+				It("streams the artifact from the artifact store", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response).To(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/octet-stream",
+					}))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(Equal("some-tarball"))
+
+					Expect(build.StoredArtifactArgsForCall(0)).To(Equal(1))
+
+					_, key := fakeArtifactStore.GetArgsForCall(0)
+					Expect(key).To(Equal("some-key"))
+				})
+
+				Context("when the blob is gone from the artifact store", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, blobstore.ErrNotFound)
+					})
+
+					It("returns 404", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					})
+				})
+
+				Context("when getting the blob fails", func() {
+					BeforeEach(func() {
+						fakeArtifactStore.GetReturns(nil, errors.New("nope"))
+					})
+
+					It("returns 500", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+					})
+				})
+			})
+
+			Context("when the artifact does not belong to the build", func() {
+				BeforeEach(func() {
+					build.StoredArtifactReturns(db.StoredArtifact{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(fakeArtifactStore.GetCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
 })
@@ -0,0 +1,38 @@
+package buildserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ApproveBuild(build db.Build) http.Handler {
+	return s.decideApproval(build, true)
+}
+
+func (s *Server) RejectBuild(build db.Build) http.Handler {
+	return s.decideApproval(build, false)
+}
+
+func (s *Server) decideApproval(build db.Build, approved bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		dLog := s.logger.Session("decide-approval", build.LagerData())
+
+		acc := accessor.GetAccessor(r)
+
+		decided, err := build.DecideApproval(approved, acc.Claims().UserName)
+		if err != nil {
+			dLog.Error("failed-to-decide-approval", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !decided {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
@@ -14,7 +14,7 @@ import (
 const ProtocolVersionHeader = "X-ATC-Stream-Version"
 const CurrentProtocolVersion = "2.0"
 
-func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
+func NewEventHandler(logger lager.Logger, eventStore db.BuildEventStore, build db.Build) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var eventID uint = 0
 		if r.Header.Get("Last-Event-ID") != "" {
@@ -39,7 +39,7 @@ func NewEventHandler(logger lager.Logger, build db.Build) http.Handler {
 			responseFlusher: w.(http.Flusher),
 		}
 
-		events, err := build.Events(eventID)
+		events, err := eventStore.Events(build, eventID)
 		if err != nil {
 			logger.Error("failed-to-get-build-events", err, lager.Data{"build-id": build.ID(), "start": eventID})
 			w.WriteHeader(http.StatusInternalServerError)
@@ -39,7 +39,7 @@ var _ = Describe("Handler", func() {
 	BeforeEach(func() {
 		build = new(dbfakes.FakeBuild)
 
-		server = httptest.NewServer(NewEventHandler(lagertest.NewTestLogger("test"), build))
+		server = httptest.NewServer(NewEventHandler(lagertest.NewTestLogger("test"), db.NewPostgresBuildEventStore(), build))
 	})
 
 	Describe("GET", func() {
@@ -0,0 +1,89 @@
+package buildserver
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListBuildStoredArtifacts(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("list-build-stored-artifacts")
+
+		artifacts, err := build.StoredArtifacts()
+		if err != nil {
+			logger.Error("failed-to-fetch-build-stored-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(present.StoredArtifacts(artifacts))
+		if err != nil {
+			logger.Error("failed-to-encode-build-stored-artifacts", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetBuildStoredArtifact(build db.Build) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logger := s.logger.Session("get-build-stored-artifact")
+
+		artifactID, err := strconv.Atoi(r.FormValue(":artifact_id"))
+		if err != nil {
+			logger.Error("failed-to-parse-artifact-id", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		if s.artifactStore == nil {
+			logger.Info("no-artifact-store-configured")
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		artifact, found, err := build.StoredArtifact(artifactID)
+		if err != nil {
+			logger.Error("failed-to-find-stored-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		content, err := s.artifactStore.Get(r.Context(), artifact.Key)
+		if err != nil {
+			if err == blobstore.ErrNotFound {
+				logger.Info("stored-artifact-blob-missing", lager.Data{"key": artifact.Key})
+				w.WriteHeader(http.StatusNotFound)
+				return
+			}
+
+			logger.Error("failed-to-get-stored-artifact", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		defer content.Close()
+
+		w.Header().Set("Content-Type", "application/octet-stream")
+		w.WriteHeader(http.StatusOK)
+
+		_, err = io.Copy(w, content)
+		if err != nil {
+			logger.Error("failed-to-stream-stored-artifact", err)
+		}
+	})
+}
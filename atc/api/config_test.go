@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
 	"github.com/concourse/concourse/atc/creds/noop"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
@@ -347,9 +348,14 @@ var _ = Describe("Config API", func() {
 		})
 
 		Context("when authorized", func() {
+			var savedPipeline *dbfakes.FakePipeline
+
 			BeforeEach(func() {
 				fakeAccess.IsAuthenticatedReturns(true)
 				fakeAccess.IsAuthorizedReturns(true)
+
+				savedPipeline = new(dbfakes.FakePipeline)
+				dbTeam.SavePipelineWithOptionsReturns(savedPipeline, false, nil)
 			})
 
 			Context("when an identifier is invalid", func() {
@@ -420,7 +426,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 						})
 					})
 
@@ -451,7 +457,7 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("does not save anything", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+							Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 						})
 					})
 				})
@@ -483,18 +489,31 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
 							Expect(initiallyPaused).To(BeTrue())
 						})
 
+						Context("when the request is made by a user", func() {
+							BeforeEach(func() {
+								fakeAccess.ClaimsReturns(accessor.Claims{UserName: "some-user"})
+							})
+
+							It("records who set the config", func() {
+								Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
+
+								_, _, _, _, opts := dbTeam.SavePipelineWithOptionsArgsForCall(0)
+								Expect(opts.SetBy).To(Equal("some-user"))
+							})
+						})
+
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineWithOptionsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -509,7 +528,7 @@ var _ = Describe("Config API", func() {
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineWithOptionsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -521,6 +540,32 @@ var _ = Describe("Config API", func() {
 							})
 						})
 
+						Context("when a job has a matrix", func() {
+							BeforeEach(func() {
+								pipelineConfig.Jobs[0].Matrix = []atc.JobMatrixVar{
+									{Var: "os", Values: []string{"linux", "windows"}},
+								}
+								payload, err := json.Marshal(pipelineConfig)
+								Expect(err).NotTo(HaveOccurred())
+								request.Body = gbytes.BufferWithBytes(payload)
+							})
+
+							It("saves the generated jobs", func() {
+								Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
+
+								_, savedConfig, _, _, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
+
+								var names []string
+								for _, job := range savedConfig.Jobs {
+									Expect(job.Matrix).To(BeEmpty())
+									names = append(names, job.Name)
+								}
+								Expect(names).To(ContainElement("some-job-linux"))
+								Expect(names).To(ContainElement("some-job-windows"))
+								Expect(names).ToNot(ContainElement("some-job"))
+							})
+						})
+
 						Context("when the config is invalid", func() {
 							BeforeEach(func() {
 								pipelineConfig.Groups[0].Resources = []string{"missing-resource"}
@@ -550,7 +595,7 @@ var _ = Describe("Config API", func() {
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+								Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 							})
 						})
 					})
@@ -581,9 +626,9 @@ var _ = Describe("Config API", func() {
 						})
 
 						It("saves it initially paused", func() {
-							Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+							Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-							ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+							ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 							Expect(ref.Name).To(Equal("a-pipeline"))
 							Expect(savedConfig).To(Equal(pipelineConfig))
 							Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -627,10 +672,15 @@ jobs:
 								Expect(response).Should(IncludeHeaderEntries(expectedHeaderEntries))
 							})
 
+							It("clears the policy warnings of the previous config", func() {
+								_, _, _, _, opts := dbTeam.SavePipelineWithOptionsArgsForCall(0)
+								Expect(opts.PolicyWarnings).To(BeEmpty())
+							})
+
 							It("saves it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+								Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-								ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+								ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 								Expect(ref.Name).To(Equal("a-pipeline"))
 								Expect(savedConfig).To(Equal(atc.Config{
 									Resources: []atc.ResourceConfig{
@@ -696,7 +746,7 @@ jobs:
 									})
 
 									It("passes validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 									})
 
 									It("returns 200 ok", func() {
@@ -712,7 +762,7 @@ jobs:
 									})
 
 									It("fail validation", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+										Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 									})
 
 									It("returns 400", func() {
@@ -937,9 +987,9 @@ jobs:
 									})
 
 									It("passes validation and saves it un-interpolated", func() {
-										Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+										Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-										ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+										ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 										Expect(ref.Name).To(Equal("a-pipeline"))
 										Expect(savedConfig).To(Equal(payloadAsConfig))
 										Expect(id).To(Equal(db.ConfigVersion(42)))
@@ -987,7 +1037,7 @@ jobs:
 						Context("when it's the first time the pipeline has been created", func() {
 							BeforeEach(func() {
 								returnedPipeline := new(dbfakes.FakePipeline)
-								dbTeam.SavePipelineReturns(returnedPipeline, true, nil)
+								dbTeam.SavePipelineWithOptionsReturns(returnedPipeline, true, nil)
 							})
 
 							It("returns 201", func() {
@@ -1001,7 +1051,7 @@ jobs:
 
 						Context("and saving it fails", func() {
 							BeforeEach(func() {
-								dbTeam.SavePipelineReturns(nil, false, errors.New("oh no!"))
+								dbTeam.SavePipelineWithOptionsReturns(nil, false, errors.New("oh no!"))
 							})
 
 							It("returns 500", func() {
@@ -1042,7 +1092,7 @@ jobs:
 							})
 
 							It("does not save it", func() {
-								Expect(dbTeam.SavePipelineCallCount()).To(BeZero())
+								Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(BeZero())
 							})
 						})
 
@@ -1075,7 +1125,7 @@ jobs:
 								})
 
 								It("does not save anything", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+									Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 								})
 							})
 
@@ -1087,9 +1137,9 @@ jobs:
 								})
 
 								It("saves an instanced pipeline", func() {
-									Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+									Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-									ref, _, _, _ := dbTeam.SavePipelineArgsForCall(0)
+									ref, _, _, _, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 									Expect(ref).To(Equal(atc.PipelineRef{
 										Name:         "a-pipeline",
 										InstanceVars: atc.InstanceVars{"branch": "feature"},
@@ -1147,7 +1197,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1187,9 +1237,9 @@ jobs:
 					})
 
 					It("saves it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(1))
+						Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(1))
 
-						ref, savedConfig, id, initiallyPaused := dbTeam.SavePipelineArgsForCall(0)
+						ref, savedConfig, id, initiallyPaused, _ := dbTeam.SavePipelineWithOptionsArgsForCall(0)
 						Expect(ref.Name).To(Equal("a-pipeline"))
 						Expect(savedConfig).To(Equal(atc.Config{
 							Jobs: atc.JobConfigs{
@@ -1241,7 +1291,7 @@ jobs:
 					})
 
 					It("does not save it", func() {
-						Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+						Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 					})
 				})
 			})
@@ -1272,7 +1322,7 @@ jobs:
 				})
 
 				It("does not save it", func() {
-					Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+					Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 				})
 			})
 		})
@@ -1287,7 +1337,7 @@ jobs:
 			})
 
 			It("does not save the config", func() {
-				Expect(dbTeam.SavePipelineCallCount()).To(Equal(0))
+				Expect(dbTeam.SavePipelineWithOptionsCallCount()).To(Equal(0))
 			})
 		})
 	})
@@ -9,6 +9,8 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/accessor"
+	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/configvalidate"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
@@ -68,6 +70,12 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	config, err := config.ExpandJobMatrices()
+	if err != nil {
+		s.handleBadRequest(w, fmt.Sprintf("malformed config: %s", err))
+		return
+	}
+
 	pipelineName := rata.Param(r, "pipeline_name")
 	warning := atc.ValidateIdentifier(pipelineName, "pipeline")
 	if warning != nil {
@@ -120,7 +128,20 @@ func (s *Server) SaveConfig(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	_, created, err := team.SavePipeline(pipelineRef, config, version, true)
+	acc := accessor.GetAccessor(r)
+
+	policyWarnings := policychecker.Warnings(r)
+	for _, policyWarning := range policyWarnings {
+		warnings = append(warnings, atc.ConfigWarning{
+			Type:    "policy",
+			Message: policyWarning,
+		})
+	}
+
+	_, created, err := team.SavePipelineWithOptions(pipelineRef, config, version, true, db.SavePipelineOptions{
+		SetBy:          acc.Claims().UserName,
+		PolicyWarnings: policyWarnings,
+	})
 	if err != nil {
 		session.Error("failed-to-save-config", err)
 		w.WriteHeader(http.StatusInternalServerError)
@@ -168,10 +189,18 @@ func validateCredParams(credMgrVars vars.Variables, config atc.Config, session l
 		if err != nil {
 			errs = multierror.Append(errs, err)
 		}
+
+		if resource.Webhook != nil {
+			_, err = creds.NewString(credMgrVars, resource.Webhook.Secret).Evaluate()
+			if err != nil {
+				errs = multierror.Append(errs, err)
+			}
+		}
 	}
 
 	for _, job := range config.Jobs {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
+			Templates: config.Templates,
 			OnTask: func(step *atc.TaskStep) error {
 				err := creds.NewTaskEnvValidator(credMgrVars, step.Params).Validate()
 				if err != nil {
@@ -1526,7 +1526,7 @@ var _ = Describe("Jobs API", func() {
 					})
 
 					It("does not trigger the build", func() {
-						Expect(fakeJob.CreateBuildCallCount()).To(Equal(0))
+						Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(0))
 					})
 				})
 
@@ -1537,7 +1537,7 @@ var _ = Describe("Jobs API", func() {
 
 					Context("when triggering the build fails", func() {
 						BeforeEach(func() {
-							fakeJob.CreateBuildReturns(nil, errors.New("nopers"))
+							fakeJob.CreateBuildWithPriorityReturns(nil, errors.New("nopers"))
 						})
 						It("returns a 500", func() {
 							Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
@@ -1556,11 +1556,38 @@ var _ = Describe("Jobs API", func() {
 							build.StartTimeReturns(time.Unix(1, 0))
 							build.EndTimeReturns(time.Unix(100, 0))
 
-							fakeJob.CreateBuildReturns(build, nil)
+							fakeJob.PriorityReturns(5)
+							fakeJob.CreateBuildWithPriorityReturns(build, nil)
 						})
 
-						It("triggers the build", func() {
-							Expect(fakeJob.CreateBuildCallCount()).To(Equal(1))
+						It("triggers the build with the priority of the job", func() {
+							Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(1))
+							Expect(fakeJob.CreateBuildWithPriorityArgsForCall(0)).To(Equal(5))
+						})
+
+						Context("when a priority is given", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=100"
+							})
+
+							It("triggers the build with the given priority", func() {
+								Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(1))
+								Expect(fakeJob.CreateBuildWithPriorityArgsForCall(0)).To(Equal(100))
+							})
+						})
+
+						Context("when the priority is invalid", func() {
+							BeforeEach(func() {
+								request.URL.RawQuery = "priority=urgent"
+							})
+
+							It("returns a 400", func() {
+								Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+							})
+
+							It("does not trigger the build", func() {
+								Expect(fakeJob.CreateBuildWithPriorityCallCount()).To(Equal(0))
+							})
 						})
 
 						Context("when finding the pipeline resources fails", func() {
@@ -53,7 +53,14 @@ func (s *Server) ListJobInputs(pipeline db.Pipeline) http.Handler {
 			return
 		}
 
-		jobInputs := jobConfig.Inputs()
+		templates, err := pipeline.Templates()
+		if err != nil {
+			logger.Error("failed-to-get-templates", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		jobInputs := jobConfig.Inputs(templates)
 
 		inputs := make([]atc.BuildInput, len(buildInputs))
 
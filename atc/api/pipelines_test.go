@@ -1570,6 +1570,175 @@ var _ = Describe("Pipelines API", func() {
 		})
 	})
 
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", func() {
+		var response *http.Response
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/config/versions", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Context("when getting the config history works", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigHistoryReturns([]db.PipelineConfigVersion{
+						{
+							Version:   2,
+							SetVia:    db.PipelineConfigSetViaSetPipeline,
+							BuildID:   42,
+							CreatedAt: time.Unix(200, 0),
+						},
+						{
+							Version:   1,
+							SetBy:     "some-user",
+							SetVia:    db.PipelineConfigSetViaFly,
+							CreatedAt: time.Unix(100, 0),
+						},
+					}, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+
+				It("returns the versions without their configs", func() {
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(body).To(MatchJSON(`[
+						{
+							"version": 2,
+							"set_via": "set_pipeline",
+							"build_id": 42,
+							"created_at": 200
+						},
+						{
+							"version": 1,
+							"set_by": "some-user",
+							"set_via": "fly",
+							"created_at": 100
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the config history fails", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigHistoryReturns(nil, errors.New("nope"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401 Unauthorized", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", func() {
+		var (
+			response      *http.Response
+			configVersion string
+		)
+
+		BeforeEach(func() {
+			configVersion = "1"
+		})
+
+		JustBeforeEach(func() {
+			var err error
+
+			request, err := http.NewRequest("GET", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/config/versions/"+configVersion, nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+				dbTeamFactory.FindTeamReturns(fakeTeam, true, nil)
+				fakeTeam.PipelineReturns(dbPipeline, true, nil)
+			})
+
+			Context("when the version exists", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigHistoryVersionReturns(db.PipelineConfigVersion{
+						Version:   1,
+						SetBy:     "some-user",
+						SetVia:    db.PipelineConfigSetViaFly,
+						CreatedAt: time.Unix(100, 0),
+						Config: atc.Config{
+							Jobs: atc.JobConfigs{{Name: "some-job"}},
+						},
+					}, true, nil)
+				})
+
+				It("looks up the requested version", func() {
+					Expect(dbPipeline.ConfigHistoryVersionCallCount()).To(Equal(1))
+					Expect(dbPipeline.ConfigHistoryVersionArgsForCall(0)).To(Equal(1))
+				})
+
+				It("returns the version with its config", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					var version atc.PipelineConfigVersion
+					err := json.NewDecoder(response.Body).Decode(&version)
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(version.Version).To(Equal(1))
+					Expect(version.SetBy).To(Equal("some-user"))
+					Expect(version.Config).ToNot(BeNil())
+					Expect(version.Config.Jobs).To(HaveLen(1))
+					Expect(version.Config.Jobs[0].Name).To(Equal("some-job"))
+				})
+			})
+
+			Context("when the version does not exist", func() {
+				BeforeEach(func() {
+					dbPipeline.ConfigHistoryVersionReturns(db.PipelineConfigVersion{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+
+			Context("when the version is not a number", func() {
+				BeforeEach(func() {
+					configVersion = "latest"
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+		})
+	})
+
 	Describe("PUT /api/v1/teams/:team_name/pipelines/:pipeline_name/rename", func() {
 		var response *http.Response
 		var requestBody string
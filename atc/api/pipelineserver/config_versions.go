@@ -0,0 +1,69 @@
+package pipelineserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListPipelineConfigVersions(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("list-pipeline-config-versions")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		versions, err := pipelineDB.ConfigHistory()
+		if err != nil {
+			logger.Error("failed-to-get-config-history", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(present.PipelineConfigVersions(versions))
+		if err != nil {
+			logger.Error("failed-to-encode-config-versions", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
+
+func (s *Server) GetPipelineConfigVersion(pipelineDB db.Pipeline) http.Handler {
+	logger := s.logger.Session("get-pipeline-config-version")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version, err := strconv.Atoi(r.FormValue(":config_version"))
+		if err != nil {
+			logger.Error("failed-to-parse-config-version", err)
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		configVersion, found, err := pipelineDB.ConfigHistoryVersion(version)
+		if err != nil {
+			logger.Error("failed-to-get-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			logger.Debug("config-version-not-found", lager.Data{"version": version})
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		presented := present.PipelineConfigVersion(configVersion)
+		presented.Config = &configVersion.Config
+
+		w.Header().Set("Content-Type", "application/json")
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-config-version", err)
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+	})
+}
@@ -1,6 +1,7 @@
 package policychecker
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -10,6 +11,15 @@ import (
 	"github.com/concourse/concourse/atc/policy"
 )
 
+const WarningsContextKey = "policy-warnings"
+
+// Warnings returns the warnings of the policy check which let the request
+// through, if any.
+func Warnings(r *http.Request) []string {
+	warnings, _ := r.Context().Value(WarningsContextKey).([]string)
+	return warnings
+}
+
 func NewHandler(
 	logger lager.Logger,
 	handler http.Handler,
@@ -50,5 +60,14 @@ func (h policyCheckingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if len(result.Warnings) > 0 {
+		h.logger.Info("policy-check-warned", lager.Data{
+			"action":   h.action,
+			"warnings": result.Warnings,
+		})
+
+		r = r.WithContext(context.WithValue(r.Context(), WarningsContextKey, result.Warnings))
+	}
+
 	h.handler.ServeHTTP(w, r)
 }
@@ -19,6 +19,7 @@ import (
 var _ = Describe("Handler", func() {
 	var (
 		innerHandlerCalled   bool
+		innerWarnings        []string
 		dummyHandler         http.HandlerFunc
 		policyCheckerHandler http.Handler
 		req                  *http.Request
@@ -32,8 +33,10 @@ var _ = Describe("Handler", func() {
 		fakePolicyChecker = new(policycheckerfakes.FakePolicyChecker)
 
 		innerHandlerCalled = false
+		innerWarnings = nil
 		dummyHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			innerHandlerCalled = true
+			innerWarnings = policychecker.Warnings(r)
 		})
 
 		responseWriter = httptest.NewRecorder()
@@ -58,6 +61,21 @@ var _ = Describe("Handler", func() {
 
 		It("calls the inner handler", func() {
 			Expect(innerHandlerCalled).To(BeTrue())
+			Expect(innerWarnings).To(BeEmpty())
+		})
+	})
+
+	Context("policy check passes with warnings", func() {
+		BeforeEach(func() {
+			fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+				Allowed:  true,
+				Warnings: []string{"a policy would rather you didn't do that"},
+			}, nil)
+		})
+
+		It("calls the inner handler with the warnings", func() {
+			Expect(innerHandlerCalled).To(BeTrue())
+			Expect(innerWarnings).To(ConsistOf("a policy would rather you didn't do that"))
 		})
 	})
 
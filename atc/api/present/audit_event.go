@@ -0,0 +1,20 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func AuditEvent(event db.AuditEvent) atc.AuditEvent {
+	return atc.AuditEvent{
+		ID:           event.ID,
+		Action:       event.Action,
+		UserName:     event.UserName,
+		TeamName:     event.TeamName,
+		PipelineName: event.PipelineName,
+		Object:       event.Object,
+		SourceIP:     event.SourceIP,
+		Status:       event.Status,
+		CreatedAt:    event.CreatedAt.Unix(),
+	}
+}
@@ -29,6 +29,7 @@ func Build(build db.Build) atc.Build {
 		TeamName:             build.TeamName(),
 		Status:               atc.BuildStatus(build.Status()),
 		APIURL:               apiURL,
+		ResourceUsage:        build.ResourceUsage(),
 	}
 
 	if build.RerunOf() != 0 {
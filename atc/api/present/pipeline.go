@@ -17,5 +17,7 @@ func Pipeline(savedPipeline db.Pipeline) atc.Pipeline {
 		Groups:       savedPipeline.Groups(),
 		Display:      savedPipeline.Display(),
 		LastUpdated:  savedPipeline.LastUpdated().Unix(),
+
+		PolicyWarnings: savedPipeline.PolicyWarnings(),
 	}
 }
@@ -0,0 +1,24 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func PipelineConfigVersions(versions []db.PipelineConfigVersion) []atc.PipelineConfigVersion {
+	presented := []atc.PipelineConfigVersion{}
+	for _, v := range versions {
+		presented = append(presented, PipelineConfigVersion(v))
+	}
+	return presented
+}
+
+func PipelineConfigVersion(version db.PipelineConfigVersion) atc.PipelineConfigVersion {
+	return atc.PipelineConfigVersion{
+		Version:   version.Version,
+		SetBy:     version.SetBy,
+		SetVia:    version.SetVia,
+		BuildID:   version.BuildID,
+		CreatedAt: version.CreatedAt.Unix(),
+	}
+}
@@ -0,0 +1,23 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func StoredArtifacts(artifacts []db.StoredArtifact) []atc.StoredArtifact {
+	presented := []atc.StoredArtifact{}
+	for _, a := range artifacts {
+		presented = append(presented, StoredArtifact(a))
+	}
+	return presented
+}
+
+func StoredArtifact(artifact db.StoredArtifact) atc.StoredArtifact {
+	return atc.StoredArtifact{
+		ID:        artifact.ID,
+		Name:      artifact.Name,
+		Size:      artifact.Size,
+		CreatedAt: artifact.CreatedAt.Unix(),
+	}
+}
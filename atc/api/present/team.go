@@ -10,5 +10,8 @@ func Team(team db.Team) atc.Team {
 		ID:   team.ID(),
 		Name: team.Name(),
 		Auth: team.Auth(),
+
+		MaxInFlight: team.MaxInFlight(),
+		Weight:      team.Weight(),
 	}
 }
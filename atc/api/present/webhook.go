@@ -0,0 +1,45 @@
+package present
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Webhook presents a webhook without its secret, which is write-only.
+func Webhook(webhook db.Webhook) atc.Webhook {
+	events := make([]atc.BuildStatus, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = atc.BuildStatus(event)
+	}
+
+	return atc.Webhook{
+		Name:      webhook.Name,
+		URL:       webhook.URL,
+		Pipeline:  webhook.PipelineName,
+		Events:    events,
+		CreatedAt: webhook.CreatedAt.Unix(),
+	}
+}
+
+func WebhookDelivery(delivery db.WebhookDelivery) atc.WebhookDelivery {
+	presented := atc.WebhookDelivery{
+		ID:             delivery.ID,
+		BuildID:        delivery.BuildID,
+		Status:         atc.BuildStatus(delivery.Status),
+		State:          delivery.State,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		Error:          delivery.Error,
+		CreatedAt:      delivery.CreatedAt.Unix(),
+	}
+
+	if delivery.State == atc.WebhookDeliveryPending {
+		presented.NextAttemptAt = delivery.NextAttemptAt.Unix()
+	}
+
+	if !delivery.DeliveredAt.IsZero() {
+		presented.DeliveredAt = delivery.DeliveredAt.Unix()
+	}
+
+	return presented
+}
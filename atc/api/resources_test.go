@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	. "github.com/concourse/concourse/atc/testhelpers"
+	"github.com/concourse/concourse/atc/webhooks"
 	"github.com/concourse/concourse/vars"
 )
 
@@ -1448,10 +1449,16 @@ var _ = Describe("Resources API", func() {
 			checkRequestBody atc.CheckRequestBody
 			response         *http.Response
 			fakeResource     *dbfakes.FakeResource
+			query            string
+			requestHeader    http.Header
+			signWith         string
 		)
 
 		BeforeEach(func() {
 			checkRequestBody = atc.CheckRequestBody{}
+			query = "?webhook_token=fake-token"
+			requestHeader = http.Header{}
+			signWith = ""
 
 			fakeResource = new(dbfakes.FakeResource)
 			fakeResource.NameReturns("resource-name")
@@ -1462,10 +1469,15 @@ var _ = Describe("Resources API", func() {
 			reqPayload, err := json.Marshal(checkRequestBody)
 			Expect(err).NotTo(HaveOccurred())
 
-			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook?webhook_token=fake-token", bytes.NewBuffer(reqPayload))
+			request, err := http.NewRequest("POST", server.URL+"/api/v1/teams/a-team/pipelines/a-pipeline/resources/resource-name/check/webhook"+query, bytes.NewBuffer(reqPayload))
 			Expect(err).NotTo(HaveOccurred())
+			request.Header = requestHeader
 			request.Header.Set("Content-Type", "application/json")
 
+			if signWith != "" {
+				request.Header.Set("X-Hub-Signature-256", webhooks.Sign([]byte(signWith), reqPayload))
+			}
+
 			response, err = client.Do(request)
 			Expect(err).NotTo(HaveOccurred())
 		})
@@ -1607,5 +1619,89 @@ var _ = Describe("Resources API", func() {
 				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
 			})
 		})
+
+		Context("when the webhook token is missing", func() {
+			BeforeEach(func() {
+				query = ""
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+			})
+
+			It("returns 400", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+			})
+		})
+
+		Context("when the resource verifies webhooks by signature", func() {
+			BeforeEach(func() {
+				query = ""
+
+				fakePipeline.VariablesReturns(vars.StaticVariables{
+					"webhook-secret": "some-secret",
+				}, nil)
+
+				fakeResource.ConfigReturns(atc.ResourceConfig{
+					Name: "resource-name",
+					Webhook: &atc.ResourceWebhook{
+						Provider: atc.WebhookProviderGitHub,
+						Secret:   "((webhook-secret))",
+						Filter: &atc.ResourceWebhookFilter{
+							Events: []string{"push"},
+						},
+					},
+				})
+				fakePipeline.ResourceReturns(fakeResource, true, nil)
+
+				fakeBuild := new(dbfakes.FakeBuild)
+				fakeBuild.IDReturns(10)
+				dbCheckFactory.TryCreateCheckReturns(fakeBuild, true, nil)
+
+				requestHeader.Set("X-GitHub-Event", "push")
+			})
+
+			Context("when the signature is valid", func() {
+				BeforeEach(func() {
+					signWith = "some-secret"
+				})
+
+				It("creates a check", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(Equal(1))
+				})
+
+				Context("when the event is filtered out", func() {
+					BeforeEach(func() {
+						requestHeader.Set("X-GitHub-Event", "ping")
+					})
+
+					It("acknowledges the call without creating a check", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when the signature is made with another secret", func() {
+				BeforeEach(func() {
+					signWith = "other-secret"
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when only a webhook token is given", func() {
+				BeforeEach(func() {
+					query = "?webhook_token=fake-token"
+					fakeResource.WebhookTokenReturns("fake-token")
+				})
+
+				It("returns 401", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+					Expect(dbCheckFactory.TryCreateCheckCallCount()).To(BeZero())
+				})
+			})
+		})
 	})
 })
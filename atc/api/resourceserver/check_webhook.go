@@ -3,6 +3,8 @@ package resourceserver
 import (
 	"context"
 	"encoding/json"
+	"io"
+	"io/ioutil"
 	"net/http"
 
 	"code.cloudfoundry.org/lager"
@@ -10,9 +12,13 @@ import (
 	"github.com/concourse/concourse/atc/api/present"
 	"github.com/concourse/concourse/atc/creds"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/webhooks"
 	"github.com/tedsuo/rata"
 )
 
+// maxWebhookPayloadSize matches the largest payload GitHub sends.
+const maxWebhookPayloadSize = 25 << 20
+
 // CheckResourceWebHook defines a handler for process a check resource request via an access token.
 func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -23,12 +29,6 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			"resource": resourceName,
 		})
 
-		if webhookToken == "" {
-			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
-			w.WriteHeader(http.StatusBadRequest)
-			return
-		}
-
 		dbResource, found, err := dbPipeline.Resource(resourceName)
 		if err != nil {
 			logger.Error("failed-to-get-resource", err)
@@ -42,17 +42,67 @@ func (s *Server) CheckResourceWebHook(dbPipeline db.Pipeline) http.Handler {
 			return
 		}
 
+		webhookConfig := dbResource.Config().Webhook
+		if webhookConfig == nil && webhookToken == "" {
+			logger.Info("no-webhook-token", lager.Data{"error": "missing webhook_token"})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
 		variables, err := dbPipeline.Variables(logger, s.secretManager, s.varSourcePool)
 		if err != nil {
 			logger.Error("failed-to-create-var-sources", err)
 			w.WriteHeader(http.StatusInternalServerError)
 			return
 		}
-		token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
-		if token != webhookToken {
-			logger.Info("invalid-token", lager.Data{"token": webhookToken})
-			w.WriteHeader(http.StatusUnauthorized)
-			return
+
+		if webhookConfig != nil {
+			secret, err := creds.NewString(variables, webhookConfig.Secret).Evaluate()
+			if err != nil {
+				logger.Error("failed-to-evaluate-webhook-secret", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			body, err := ioutil.ReadAll(io.LimitReader(r.Body, maxWebhookPayloadSize))
+			if err != nil {
+				logger.Info("failed-to-read-payload", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			event, err := webhooks.Verify(*webhookConfig, secret, r.Header, body)
+			if err != nil {
+				if err == webhooks.ErrInvalidSignature {
+					logger.Info("invalid-signature")
+					w.WriteHeader(http.StatusUnauthorized)
+					return
+				}
+
+				logger.Info("malformed-payload", lager.Data{"error": err.Error()})
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+
+			matched, err := event.Matches(webhookConfig.Filter)
+			if err != nil {
+				logger.Error("failed-to-filter-event", err)
+				w.WriteHeader(http.StatusInternalServerError)
+				return
+			}
+
+			if !matched {
+				logger.Debug("filtered-out", lager.Data{"event": event.Name, "branches": event.Branches})
+				w.WriteHeader(http.StatusOK)
+				return
+			}
+		} else {
+			token, err := creds.NewString(variables, dbResource.WebhookToken()).Evaluate()
+			if err != nil || token != webhookToken {
+				logger.Info("invalid-token", lager.Data{"token": webhookToken})
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
 		}
 
 		dbResourceTypes, err := dbPipeline.ResourceTypes()
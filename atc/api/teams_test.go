@@ -296,21 +296,50 @@ var _ = Describe("Teams API", func() {
 
 				It("updates provider auth", func() {
 					Expect(response.StatusCode).To(Equal(http.StatusOK))
-					Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(1))
+					Expect(fakeTeam.UpdateCallCount()).To(Equal(1))
 
-					updatedProviderAuth := fakeTeam.UpdateProviderAuthArgsForCall(0)
-					Expect(updatedProviderAuth).To(Equal(atcTeam.Auth))
+					updatedTeam := fakeTeam.UpdateArgsForCall(0)
+					Expect(updatedTeam.Auth).To(Equal(atcTeam.Auth))
 				})
 
-				Context("when updating provider auth fails", func() {
+				Context("when updating the team fails", func() {
 					BeforeEach(func() {
-						fakeTeam.UpdateProviderAuthReturns(errors.New("stop trying to make fetch happen"))
+						fakeTeam.UpdateReturns(errors.New("stop trying to make fetch happen"))
 					})
 
 					It("returns 500 Internal Server error", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
 					})
 				})
+
+				Context("when a fair share is configured", func() {
+					BeforeEach(func() {
+						atcTeam.MaxInFlight = 10
+						atcTeam.Weight = 2
+					})
+
+					It("updates the fair share along with the auth", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusOK))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(1))
+
+						updatedTeam := fakeTeam.UpdateArgsForCall(0)
+						Expect(updatedTeam.Auth).To(Equal(atcTeam.Auth))
+						Expect(updatedTeam.MaxInFlight).To(Equal(10))
+						Expect(updatedTeam.Weight).To(Equal(2))
+					})
+				})
+
+				Context("when the fair share is invalid", func() {
+					BeforeEach(func() {
+						atcTeam.MaxInFlight = -1
+					})
+
+					It("returns 400 Bad Request", func() {
+						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
+					})
+				})
+
 				Context("when provider auth is empty", func() {
 					BeforeEach(func() {
 						atcTeam = atc.Team{}
@@ -319,7 +348,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
 					})
 				})
 
@@ -338,7 +367,7 @@ var _ = Describe("Teams API", func() {
 
 					It("does not update provider auth", func() {
 						Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
-						Expect(fakeTeam.UpdateProviderAuthCallCount()).To(Equal(0))
+						Expect(fakeTeam.UpdateCallCount()).To(Equal(0))
 					})
 				})
 			})
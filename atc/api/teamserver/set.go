@@ -56,8 +56,8 @@ func (s *Server) SetTeam(w http.ResponseWriter, r *http.Request) {
 
 	response := SetTeamResponse{}
 	if found {
-		hLog.Debug("updating-credentials")
-		err = team.UpdateProviderAuth(atcTeam.Auth)
+		hLog.Debug("updating-team")
+		err = team.Update(atcTeam)
 		if err != nil {
 			hLog.Error("failed-to-update-team", err, lager.Data{"teamName": teamName})
 			w.WriteHeader(http.StatusInternalServerError)
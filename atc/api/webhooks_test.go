@@ -0,0 +1,381 @@
+package api_test
+
+import (
+	"bytes"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/concourse/concourse/atc/testhelpers"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhooks API", func() {
+	var response *http.Response
+
+	Describe("GET /api/v1/teams/:team_name/webhooks", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/webhooks")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authenticated", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(false)
+			})
+
+			It("returns 401", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusUnauthorized))
+				Expect(dbWebhookFactory.WebhooksCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWebhookFactory.WebhooksCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when getting the webhooks works", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.WebhooksReturns([]db.Webhook{
+						{
+							ID:           1,
+							TeamID:       734,
+							Name:         "some-webhook",
+							URL:          "https://example.com/hook",
+							Secret:       "super-secret",
+							PipelineName: "some-pipeline",
+							Events:       []db.BuildStatus{db.BuildStatusFailed, db.BuildStatusErrored},
+							CreatedAt:    time.Unix(100, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the team's webhooks without their secrets", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+					Expect(response).Should(IncludeHeaderEntries(map[string]string{
+						"Content-Type": "application/json",
+					}))
+
+					Expect(dbWebhookFactory.WebhooksArgsForCall(0)).To(Equal(734))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{
+							"name": "some-webhook",
+							"url": "https://example.com/hook",
+							"pipeline": "some-pipeline",
+							"events": ["failed", "errored"],
+							"created_at": 100
+						}
+					]`))
+				})
+			})
+
+			Context("when getting the webhooks fails", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.WebhooksReturns(nil, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("PUT /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		var body string
+
+		BeforeEach(func() {
+			body = `{"url":"https://example.com/hook","secret":"super-secret","events":["failed"]}`
+		})
+
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("PUT", server.URL+"/api/v1/teams/some-team/webhooks/some-webhook", bytes.NewBufferString(body))
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWebhookFactory.SaveWebhookCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+
+				dbWebhookFactory.WebhookReturns(db.Webhook{
+					Name:      "some-webhook",
+					URL:       "https://example.com/hook",
+					Secret:    "super-secret",
+					Events:    []db.BuildStatus{db.BuildStatusFailed},
+					CreatedAt: time.Unix(100, 0),
+				}, true, nil)
+			})
+
+			Context("when the webhook is new", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.SaveWebhookReturns(true, nil)
+				})
+
+				It("saves the webhook", func() {
+					Expect(dbWebhookFactory.SaveWebhookCallCount()).To(Equal(1))
+					Expect(dbWebhookFactory.SaveWebhookArgsForCall(0)).To(Equal(db.Webhook{
+						TeamID: 734,
+						Name:   "some-webhook",
+						URL:    "https://example.com/hook",
+						Secret: "super-secret",
+						Events: []db.BuildStatus{db.BuildStatusFailed},
+					}))
+				})
+
+				It("returns 201 with the webhook", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusCreated))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`{
+						"name": "some-webhook",
+						"url": "https://example.com/hook",
+						"events": ["failed"],
+						"created_at": 100
+					}`))
+				})
+			})
+
+			Context("when the webhook already exists", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.SaveWebhookReturns(false, nil)
+				})
+
+				It("returns 200", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+				})
+			})
+
+			Context("when the webhook is invalid", func() {
+				BeforeEach(func() {
+					body = `{"url":"ftp://example.com/hook"}`
+				})
+
+				It("returns 400 with the reason", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(string(body)).To(ContainSubstring("must be an absolute http or https url"))
+
+					Expect(dbWebhookFactory.SaveWebhookCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when the request is malformed", func() {
+				BeforeEach(func() {
+					body = `{`
+				})
+
+				It("returns 400", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusBadRequest))
+				})
+			})
+
+			Context("when saving fails", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.SaveWebhookReturns(false, errors.New("disaster"))
+				})
+
+				It("returns 500", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusInternalServerError))
+				})
+			})
+		})
+	})
+
+	Describe("DELETE /api/v1/teams/:team_name/webhooks/:webhook_name", func() {
+		JustBeforeEach(func() {
+			request, err := http.NewRequest("DELETE", server.URL+"/api/v1/teams/some-team/webhooks/some-webhook", nil)
+			Expect(err).NotTo(HaveOccurred())
+
+			response, err = client.Do(request)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+				Expect(dbWebhookFactory.DestroyWebhookCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.DestroyWebhookReturns(true, nil)
+				})
+
+				It("destroys it", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNoContent))
+
+					teamID, name := dbWebhookFactory.DestroyWebhookArgsForCall(0)
+					Expect(teamID).To(Equal(734))
+					Expect(name).To(Equal("some-webhook"))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.DestroyWebhookReturns(false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+				})
+			})
+		})
+	})
+
+	Describe("GET /api/v1/teams/:team_name/webhooks/:webhook_name/deliveries", func() {
+		JustBeforeEach(func() {
+			var err error
+			response, err = client.Get(server.URL + "/api/v1/teams/some-team/webhooks/some-webhook/deliveries?limit=2")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("when not authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(false)
+			})
+
+			It("returns 403", func() {
+				Expect(response.StatusCode).To(Equal(http.StatusForbidden))
+			})
+		})
+
+		Context("when authorized", func() {
+			BeforeEach(func() {
+				fakeAccess.IsAuthenticatedReturns(true)
+				fakeAccess.IsAuthorizedReturns(true)
+			})
+
+			Context("when the webhook exists", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.WebhookReturns(db.Webhook{ID: 12, Name: "some-webhook"}, true, nil)
+					dbWebhookFactory.WebhookDeliveriesReturns([]db.WebhookDelivery{
+						{
+							ID:             2,
+							WebhookID:      12,
+							BuildID:        42,
+							Status:         db.BuildStatusFailed,
+							State:          atc.WebhookDeliveryPending,
+							Attempts:       1,
+							ResponseStatus: 502,
+							Error:          "bad gateway",
+							CreatedAt:      time.Unix(100, 0),
+							NextAttemptAt:  time.Unix(130, 0),
+						},
+						{
+							ID:             1,
+							WebhookID:      12,
+							BuildID:        42,
+							Status:         db.BuildStatusStarted,
+							State:          atc.WebhookDeliveryDelivered,
+							Attempts:       1,
+							ResponseStatus: 200,
+							CreatedAt:      time.Unix(90, 0),
+							NextAttemptAt:  time.Unix(90, 0),
+							DeliveredAt:    time.Unix(91, 0),
+						},
+					}, nil)
+				})
+
+				It("returns the webhook's deliveries", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusOK))
+
+					webhookID, limit := dbWebhookFactory.WebhookDeliveriesArgsForCall(0)
+					Expect(webhookID).To(Equal(12))
+					Expect(limit).To(Equal(2))
+
+					body, err := ioutil.ReadAll(response.Body)
+					Expect(err).NotTo(HaveOccurred())
+					Expect(body).To(MatchJSON(`[
+						{
+							"id": 2,
+							"build_id": 42,
+							"status": "failed",
+							"state": "pending",
+							"attempts": 1,
+							"response_status": 502,
+							"error": "bad gateway",
+							"created_at": 100,
+							"next_attempt_at": 130
+						},
+						{
+							"id": 1,
+							"build_id": 42,
+							"status": "started",
+							"state": "delivered",
+							"attempts": 1,
+							"response_status": 200,
+							"created_at": 90,
+							"delivered_at": 91
+						}
+					]`))
+				})
+			})
+
+			Context("when the webhook does not exist", func() {
+				BeforeEach(func() {
+					dbWebhookFactory.WebhookReturns(db.Webhook{}, false, nil)
+				})
+
+				It("returns 404", func() {
+					Expect(response.StatusCode).To(Equal(http.StatusNotFound))
+					Expect(dbWebhookFactory.WebhookDeliveriesCallCount()).To(BeZero())
+				})
+			})
+		})
+	})
+})
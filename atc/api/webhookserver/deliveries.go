@@ -0,0 +1,54 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListWebhookDeliveries(team db.Team) http.Handler {
+	logger := s.logger.Session("list-webhook-deliveries")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhook, found, err := s.webhookFactory.Webhook(team.ID(), r.FormValue(":webhook_name"))
+		if err != nil {
+			logger.Error("failed-to-get-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		limit, _ := strconv.Atoi(r.FormValue(atc.PaginationQueryLimit))
+		if limit <= 0 {
+			limit = atc.PaginationAPIDefaultLimit
+		}
+
+		deliveries, err := s.webhookFactory.WebhookDeliveries(webhook.ID, limit)
+		if err != nil {
+			logger.Error("failed-to-get-deliveries", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.WebhookDelivery, len(deliveries))
+		for i, delivery := range deliveries {
+			presented[i] = present.WebhookDelivery(delivery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-deliveries", err)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package webhookserver
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) DestroyWebhook(team db.Team) http.Handler {
+	logger := s.logger.Session("destroy-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		destroyed, err := s.webhookFactory.DestroyWebhook(team.ID(), r.FormValue(":webhook_name"))
+		if err != nil {
+			logger.Error("failed-to-destroy-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !destroyed {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+}
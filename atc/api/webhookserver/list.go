@@ -0,0 +1,36 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) ListWebhooks(team db.Team) http.Handler {
+	logger := s.logger.Session("list-webhooks")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		webhooks, err := s.webhookFactory.Webhooks(team.ID())
+		if err != nil {
+			logger.Error("failed-to-get-webhooks", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		presented := make([]atc.Webhook, len(webhooks))
+		for i, webhook := range webhooks {
+			presented[i] = present.Webhook(webhook)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		err = json.NewEncoder(w).Encode(presented)
+		if err != nil {
+			logger.Error("failed-to-encode-webhooks", err)
+		}
+	})
+}
@@ -0,0 +1,79 @@
+package webhookserver
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+func (s *Server) SetWebhook(team db.Team) http.Handler {
+	logger := s.logger.Session("set-webhook")
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var webhook atc.Webhook
+		err := json.NewDecoder(r.Body).Decode(&webhook)
+		if err != nil {
+			logger.Info("malformed-request", lager.Data{"error": err.Error()})
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		webhook.Name = r.FormValue(":webhook_name")
+
+		err = webhook.Validate()
+		if err != nil {
+			logger.Info("invalid-webhook", lager.Data{"error": err.Error()})
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		events := make([]db.BuildStatus, len(webhook.Events))
+		for i, event := range webhook.Events {
+			events[i] = db.BuildStatus(event)
+		}
+
+		created, err := s.webhookFactory.SaveWebhook(db.Webhook{
+			TeamID:       team.ID(),
+			Name:         webhook.Name,
+			URL:          webhook.URL,
+			Secret:       webhook.Secret,
+			PipelineName: webhook.Pipeline,
+			Events:       events,
+		})
+		if err != nil {
+			logger.Error("failed-to-save-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		saved, found, err := s.webhookFactory.Webhook(team.ID(), webhook.Name)
+		if err != nil {
+			logger.Error("failed-to-get-webhook", err)
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		if !found {
+			// destroyed concurrently
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+
+		if created {
+			w.WriteHeader(http.StatusCreated)
+		} else {
+			w.WriteHeader(http.StatusOK)
+		}
+
+		err = json.NewEncoder(w).Encode(present.Webhook(saved))
+		if err != nil {
+			logger.Error("failed-to-encode-webhook", err)
+		}
+	})
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/concourse/concourse/atc/api/pipelineserver"
 	"github.com/concourse/concourse/atc/api/policychecker"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/builds"
 	"github.com/concourse/concourse/atc/component"
 	"github.com/concourse/concourse/atc/compression"
@@ -41,12 +42,14 @@ import (
 	"github.com/concourse/concourse/atc/engine"
 	"github.com/concourse/concourse/atc/gc"
 	"github.com/concourse/concourse/atc/lidar"
+	"github.com/concourse/concourse/atc/logdrain"
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/algorithm"
 	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/webhooks"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/image"
 	"github.com/concourse/concourse/atc/wrappa"
@@ -81,11 +84,14 @@ import (
 
 	// dynamically registered policy checkers
 	_ "github.com/concourse/concourse/atc/policy/opa"
+	_ "github.com/concourse/concourse/atc/policy/rules"
 
 	// dynamically registered credential managers
 	_ "github.com/concourse/concourse/atc/creds/conjur"
 	_ "github.com/concourse/concourse/atc/creds/credhub"
 	_ "github.com/concourse/concourse/atc/creds/dummy"
+	_ "github.com/concourse/concourse/atc/creds/external"
+	_ "github.com/concourse/concourse/atc/creds/file"
 	_ "github.com/concourse/concourse/atc/creds/kubernetes"
 	_ "github.com/concourse/concourse/atc/creds/secretsmanager"
 	_ "github.com/concourse/concourse/atc/creds/ssm"
@@ -173,8 +179,13 @@ type RunCommand struct {
 
 	Tracing tracing.Config `group:"Tracing" namespace:"tracing"`
 
+	ArtifactStore blobstore.Config `group:"Artifact Store" namespace:"artifact-store"`
+
+	BuildEventStore blobstore.Config `group:"Build Event Store" namespace:"build-event-store"`
+
 	PolicyCheckers struct {
-		Filter policy.Filter
+		Filter    policy.Filter
+		AuditOnly bool `long:"policy-check-audit-only" description:"Log actions that fail policy checks instead of rejecting them."`
 	} `group:"Policy Checking"`
 
 	Server struct {
@@ -195,6 +206,10 @@ type RunCommand struct {
 		HijackGracePeriod      time.Duration `long:"hijack-grace-period" default:"5m" description:"Period after which hijacked containers will be garbage collected"`
 		FailedGracePeriod      time.Duration `long:"failed-grace-period" default:"120h" description:"Period after which failed containers will be garbage collected"`
 		CheckRecyclePeriod     time.Duration `long:"check-recycle-period" default:"1m" description:"Period after which to reap checks that are completed."`
+		KeyedCacheUnusedPeriod time.Duration `long:"keyed-cache-unused-period" default:"168h" description:"Period after which task caches stored under a key which have not been used will be garbage collected."`
+
+		StoredArtifactRetention time.Duration `long:"stored-artifact-retention" description:"Period after which artifacts uploaded by tasks will be removed from the artifact store. 0 keeps them for as long as their build exists."`
+		AuditEventRetention     time.Duration `long:"audit-event-retention" description:"Period after which recorded audit events will be removed. 0 keeps them forever."`
 	} `group:"Garbage Collection" namespace:"gc"`
 
 	BuildTrackerInterval time.Duration `long:"build-tracker-interval" default:"10s" description:"Interval on which to run build tracking."`
@@ -208,6 +223,7 @@ type RunCommand struct {
 	MaxDaysToRetainBuildLogs     uint64 `long:"max-days-to-retain-build-logs" description:"Maximum days to retain build logs, 0 means not specified. Will override values configured in jobs"`
 
 	JobSchedulingMaxInFlight uint64 `long:"job-scheduling-max-in-flight" default:"32" description:"Maximum number of jobs to be scheduling at the same time"`
+	BuildsMaxInFlight        int    `long:"builds-max-in-flight" default:"0" description:"Maximum number of job builds running at the same time across all teams, shared between teams with pending builds according to their weight. 0 means no limit."`
 
 	DefaultCpuLimit    *int    `long:"default-task-cpu-limit" description:"Default max number of cpu shares per task, 0 means unlimited"`
 	DefaultMemoryLimit *string `long:"default-task-memory-limit" description:"Default maximum memory per task, 0 means unlimited"`
@@ -222,6 +238,8 @@ type RunCommand struct {
 		EnableTeamAuditLog      bool `long:"enable-team-auditing" description:"Enable auditing for all api requests connected to teams."`
 		EnableWorkerAuditLog    bool `long:"enable-worker-auditing" description:"Enable auditing for all api requests connected to workers."`
 		EnableVolumeAuditLog    bool `long:"enable-volume-auditing" description:"Enable auditing for all api requests connected to volumes."`
+
+		TrustedProxies []string `long:"audit-trusted-proxy" description:"CIDR of a proxy in front of the web node. The source address of audited requests coming through it is taken from X-Forwarded-For. Can be specified multiple times."`
 	}
 
 	Syslog struct {
@@ -232,6 +250,8 @@ type RunCommand struct {
 		CACerts       []string      `long:"syslog-ca-cert"              description:"Paths to PEM-encoded CA cert files to use to verify the Syslog server SSL cert."`
 	} ` group:"Syslog Drainer Configuration"`
 
+	LogDrain logdrain.Config `group:"Log Drain" namespace:"log-drain"`
+
 	Auth struct {
 		AuthFlags     skycmd.AuthFlags
 		MainTeamFlags skycmd.AuthTeamFlags `group:"Authentication (Main Team)" namespace:"main-team"`
@@ -639,22 +659,32 @@ func (cmd *RunCommand) constructMembers(
 		}()
 	}
 
-	policyChecker, err := policy.Initialize(logger, cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter)
+	policyChecker, err := policy.Initialize(logger, cmd.Server.ClusterName, concourse.Version, cmd.PolicyCheckers.Filter, cmd.PolicyCheckers.AuditOnly)
 	if err != nil {
 		return nil, err
 	}
 
-	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, workerConn, storage, lockFactory, secretManager, policyChecker)
+	artifactStore, err := cmd.ArtifactStore.Store()
+	if err != nil {
+		return nil, fmt.Errorf("artifact store: %w", err)
+	}
+
+	buildEventBlobs, err := cmd.BuildEventStore.Store()
+	if err != nil {
+		return nil, fmt.Errorf("build event store: %w", err)
+	}
+
+	apiMembers, err := cmd.constructAPIMembers(logger, reconfigurableSink, apiConn, workerConn, storage, lockFactory, secretManager, policyChecker, artifactStore, buildEventBlobs)
 	if err != nil {
 		return nil, err
 	}
 
-	backendComponents, err := cmd.backendComponents(logger, backendConn, lockFactory, secretManager, policyChecker)
+	backendComponents, err := cmd.backendComponents(logger, backendConn, lockFactory, secretManager, policyChecker, artifactStore, buildEventBlobs)
 	if err != nil {
 		return nil, err
 	}
 
-	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory)
+	gcComponents, err := cmd.gcComponents(logger, gcConn, lockFactory, artifactStore, buildEventBlobs)
 	if err != nil {
 		return nil, err
 	}
@@ -712,6 +742,8 @@ func (cmd *RunCommand) constructAPIMembers(
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
 	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
+	buildEventBlobs blobstore.Store,
 ) ([]grouper.Member, error) {
 
 	httpClient, err := cmd.skyHttpClient()
@@ -789,6 +821,8 @@ func (cmd *RunCommand) constructAPIMembers(
 	dbAccessTokenFactory := db.NewAccessTokenFactory(dbConn)
 	dbClock := db.NewClock()
 	dbWall := db.NewWall(dbConn, &dbClock)
+	dbAuditEventFactory := db.NewAuditEventFactory(dbConn)
+	dbWebhookFactory := db.NewWebhookFactory(dbConn)
 
 	tokenVerifier := cmd.constructTokenVerifier(dbAccessTokenFactory)
 
@@ -831,6 +865,10 @@ func (cmd *RunCommand) constructAPIMembers(
 		accessFactory,
 		dbWall,
 		policyChecker,
+		artifactStore,
+		dbAuditEventFactory,
+		cmd.buildEventStore(dbConn, buildEventBlobs),
+		dbWebhookFactory,
 	)
 	if err != nil {
 		return nil, err
@@ -958,26 +996,51 @@ func (cmd *RunCommand) backendComponents(
 	lockFactory lock.LockFactory,
 	secretManager creds.Secrets,
 	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
+	buildEventBlobs blobstore.Store,
 ) ([]RunnableComponent, error) {
 
 	if cmd.Syslog.Address != "" && cmd.Syslog.Transport == "" {
 		return nil, fmt.Errorf("syslog Drainer is misconfigured, cannot configure a drainer without a transport")
 	}
 
-	syslogDrainConfigured := true
-	if cmd.Syslog.Address == "" {
-		syslogDrainConfigured = false
+	logDrainSinks := cmd.LogDrain.Sinks()
+
+	var syslogSink logdrain.Sink
+	if cmd.Syslog.Address != "" {
+		syslogSink = syslog.NewSink(
+			cmd.Syslog.Transport,
+			cmd.Syslog.Address,
+			cmd.Syslog.Hostname,
+			cmd.Syslog.CACerts,
+		)
+	}
+
+	// the syslog sink and the other sinks are drained by separate components
+	// so that each is drained on its own interval
+	var drainSinks []string
+	for _, sink := range logDrainSinks {
+		drainSinks = append(drainSinks, sink.Name())
 	}
 
+	if syslogSink != nil {
+		drainSinks = append(drainSinks, syslogSink.Name())
+	}
+
+	drainConfigured := len(drainSinks) > 0
+
 	teamFactory := db.NewTeamFactory(dbConn, lockFactory)
 
 	resourceFactory := resource.NewResourceFactory()
 	dbResourceCacheFactory := db.NewResourceCacheFactory(dbConn, lockFactory)
+	dbKeyedCacheFactory := db.NewKeyedCacheFactory(dbConn)
+	dbStoredArtifactFactory := db.NewStoredArtifactFactory(dbConn)
 	fetchSourceFactory := worker.NewFetchSourceFactory(dbResourceCacheFactory)
 	resourceFetcher := worker.NewFetcher(clock.NewClock(), lockFactory, fetchSourceFactory)
 	dbResourceConfigFactory := db.NewResourceConfigFactory(dbConn, lockFactory)
 
 	dbBuildFactory := db.NewBuildFactory(dbConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
+	dbBuildEventStore := cmd.buildEventStore(dbConn, buildEventBlobs)
 	dbCheckFactory := db.NewCheckFactory(dbConn, lockFactory, secretManager, cmd.varSourcePool, db.CheckDurations{
 		Interval:            cmd.ResourceCheckingInterval,
 		IntervalWithWebhook: cmd.ResourceWithWebhookCheckingInterval,
@@ -1059,6 +1122,9 @@ func (cmd *RunCommand) backendComponents(
 		dbBuildFactory,
 		dbResourceCacheFactory,
 		dbResourceConfigFactory,
+		dbKeyedCacheFactory,
+		artifactStore,
+		dbStoredArtifactFactory,
 		secretManager,
 		defaultLimits,
 		buildContainerStrategy,
@@ -1103,7 +1169,9 @@ func (cmd *RunCommand) backendComponents(
 						builds.NewPlanner(
 							atc.NewPlanFactory(time.Now().Unix()),
 						),
-						alg),
+						alg,
+						db.NewFairShare(dbConn, lockFactory, cmd.BuildsMaxInFlight),
+					),
 				},
 				cmd.JobSchedulingMaxInFlight,
 			),
@@ -1123,6 +1191,7 @@ func (cmd *RunCommand) backendComponents(
 			Runnable: gc.NewBuildLogCollector(
 				dbPipelineFactory,
 				dbPipelineLifecycle,
+				dbBuildEventStore,
 				500,
 				gc.NewBuildLogRetentionCalculator(
 					cmd.DefaultBuildLogsToRetain,
@@ -1130,23 +1199,49 @@ func (cmd *RunCommand) backendComponents(
 					cmd.DefaultDaysToRetainBuildLogs,
 					cmd.MaxDaysToRetainBuildLogs,
 				),
-				syslogDrainConfigured,
+				drainConfigured,
+			),
+		},
+		{
+			Component: atc.Component{
+				Name:     atc.ComponentWebhookDispatcher,
+				Interval: 30 * time.Second,
+			},
+			Runnable: webhooks.NewDispatcher(
+				db.NewWebhookDeliveryRepository(dbConn),
+				dbBuildFactory,
+				cmd.ExternalURL.String(),
+				webhooks.NewClient(10*time.Second),
 			),
 		},
 	}
 
-	if syslogDrainConfigured {
+	if syslogSink != nil {
 		components = append(components, RunnableComponent{
 			Component: atc.Component{
 				Name:     atc.ComponentSyslogDrainer,
 				Interval: cmd.Syslog.DrainInterval,
 			},
-			Runnable: syslog.NewDrainer(
-				cmd.Syslog.Transport,
-				cmd.Syslog.Address,
-				cmd.Syslog.Hostname,
-				cmd.Syslog.CACerts,
+			Runnable: logdrain.NewDrainer(
+				dbBuildFactory,
+				dbBuildEventStore,
+				[]logdrain.Sink{syslogSink},
+				drainSinks,
+			),
+		})
+	}
+
+	if len(logDrainSinks) > 0 {
+		components = append(components, RunnableComponent{
+			Component: atc.Component{
+				Name:     atc.ComponentLogDrainer,
+				Interval: cmd.LogDrain.Interval,
+			},
+			Runnable: logdrain.NewDrainer(
 				dbBuildFactory,
+				dbBuildEventStore,
+				logDrainSinks,
+				drainSinks,
 			),
 		})
 	}
@@ -1158,11 +1253,14 @@ func (cmd *RunCommand) gcComponents(
 	logger lager.Logger,
 	gcConn db.Conn,
 	lockFactory lock.LockFactory,
+	artifactStore blobstore.Store,
+	buildEventBlobs blobstore.Store,
 ) ([]RunnableComponent, error) {
 	dbWorkerLifecycle := db.NewWorkerLifecycle(gcConn)
 	dbResourceCacheLifecycle := db.NewResourceCacheLifecycle(gcConn)
 	dbContainerRepository := db.NewContainerRepository(gcConn)
 	dbArtifactLifecycle := db.NewArtifactLifecycle(gcConn)
+	dbKeyedCacheLifecycle := db.NewKeyedCacheLifecycle(gcConn)
 	dbAccessTokenLifecycle := db.NewAccessTokenLifecycle(gcConn)
 	resourceConfigCheckSessionLifecycle := db.NewResourceConfigCheckSessionLifecycle(gcConn)
 	dbBuildFactory := db.NewBuildFactory(gcConn, lockFactory, cmd.GC.OneOffBuildGracePeriod, cmd.GC.FailedGracePeriod)
@@ -1187,6 +1285,7 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorResourceCaches:    gc.NewResourceCacheCollector(dbResourceCacheLifecycle),
 		atc.ComponentCollectorResourceCacheUses: gc.NewResourceCacheUseCollector(dbResourceCacheLifecycle),
 		atc.ComponentCollectorArtifacts:         gc.NewArtifactCollector(dbArtifactLifecycle),
+		atc.ComponentCollectorKeyedCaches:       gc.NewKeyedCacheCollector(dbKeyedCacheLifecycle, cmd.GC.KeyedCacheUnusedPeriod),
 		atc.ComponentCollectorVolumes:           gc.NewVolumeCollector(dbVolumeRepository, cmd.GC.MissingGracePeriod),
 		atc.ComponentCollectorContainers:        gc.NewContainerCollector(dbContainerRepository, cmd.GC.MissingGracePeriod, cmd.GC.HijackGracePeriod),
 		atc.ComponentCollectorCheckSessions:     gc.NewResourceConfigCheckSessionCollector(resourceConfigCheckSessionLifecycle),
@@ -1194,6 +1293,28 @@ func (cmd *RunCommand) gcComponents(
 		atc.ComponentCollectorAccessTokens:      gc.NewAccessTokensCollector(dbAccessTokenLifecycle, jwt.DefaultLeeway),
 	}
 
+	if cmd.GC.AuditEventRetention > 0 {
+		collectors[atc.ComponentCollectorAuditEvents] = gc.NewAuditEventsCollector(
+			db.NewAuditEventLifecycle(gcConn),
+			cmd.GC.AuditEventRetention,
+		)
+	}
+
+	if artifactStore != nil {
+		collectors[atc.ComponentCollectorStoredArtifacts] = gc.NewStoredArtifactCollector(
+			db.NewStoredArtifactLifecycle(gcConn),
+			artifactStore,
+			cmd.GC.StoredArtifactRetention,
+		)
+	}
+
+	if buildEventBlobs != nil {
+		collectors[atc.ComponentBuildEventArchiver] = gc.NewBuildEventArchiver(
+			db.NewObjectBuildEventStore(gcConn, buildEventBlobs),
+			100,
+		)
+	}
+
 	var components []RunnableComponent
 	for collectorName, collector := range collectors {
 		components = append(components, RunnableComponent{
@@ -1208,6 +1329,16 @@ func (cmd *RunCommand) gcComponents(
 	return components, nil
 }
 
+// buildEventStore returns the store from which build events are read and
+// reaped, falling back to Postgres if no blob store is configured.
+func (cmd *RunCommand) buildEventStore(conn db.Conn, blobs blobstore.Store) db.BuildEventStore {
+	if blobs == nil {
+		return db.NewPostgresBuildEventStore()
+	}
+
+	return db.NewObjectBuildEventStore(conn, blobs)
+}
+
 func (cmd *RunCommand) validateCustomRoles() error {
 	path := cmd.ConfigRBAC.Path()
 	if path == "" {
@@ -1244,6 +1375,20 @@ func (cmd *RunCommand) validateCustomRoles() error {
 	return nil
 }
 
+func (cmd *RunCommand) parseTrustedProxies() ([]*net.IPNet, error) {
+	var proxies []*net.IPNet
+	for _, cidr := range cmd.Auditor.TrustedProxies {
+		_, proxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			return nil, fmt.Errorf("invalid audit trusted proxy: %w", err)
+		}
+
+		proxies = append(proxies, proxy)
+	}
+
+	return proxies, nil
+}
+
 func (cmd *RunCommand) parseCustomRoles() (map[string]string, error) {
 	mapping := map[string]string{}
 
@@ -1622,6 +1767,9 @@ func (cmd *RunCommand) constructEngine(
 	buildFactory db.BuildFactory,
 	resourceCacheFactory db.ResourceCacheFactory,
 	resourceConfigFactory db.ResourceConfigFactory,
+	keyedCacheFactory db.KeyedCacheFactory,
+	artifactStore blobstore.Store,
+	storedArtifactFactory db.StoredArtifactFactory,
 	secretManager creds.Secrets,
 	defaultLimits atc.ContainerLimits,
 	strategy worker.ContainerPlacementStrategy,
@@ -1643,6 +1791,9 @@ func (cmd *RunCommand) constructEngine(
 				strategy,
 				lockFactory,
 				cmd.GlobalResourceCheckTimeout,
+				keyedCacheFactory,
+				artifactStore,
+				storedArtifactFactory,
 			),
 			cmd.ExternalURL.String(),
 			rateLimiter,
@@ -1821,6 +1972,10 @@ func (cmd *RunCommand) constructAPIHandler(
 	accessFactory accessor.AccessFactory,
 	dbWall db.Wall,
 	policyChecker policy.Checker,
+	artifactStore blobstore.Store,
+	auditEventFactory db.AuditEventFactory,
+	buildEventStore db.BuildEventStore,
+	webhookFactory db.WebhookFactory,
 ) (http.Handler, error) {
 
 	checkPipelineAccessHandlerFactory := auth.NewCheckPipelineAccessHandlerFactory(teamFactory)
@@ -1830,6 +1985,11 @@ func (cmd *RunCommand) constructAPIHandler(
 
 	rejectArchivedHandlerFactory := pipelineserver.NewRejectArchivedHandlerFactory(teamFactory)
 
+	trustedProxies, err := cmd.parseTrustedProxies()
+	if err != nil {
+		return nil, err
+	}
+
 	aud := auditor.NewAuditor(
 		cmd.Auditor.EnableBuildAuditLog,
 		cmd.Auditor.EnableContainerAuditLog,
@@ -1840,6 +2000,8 @@ func (cmd *RunCommand) constructAPIHandler(
 		cmd.Auditor.EnableTeamAuditLog,
 		cmd.Auditor.EnableWorkerAuditLog,
 		cmd.Auditor.EnableVolumeAuditLog,
+		trustedProxies,
+		auditEventFactory,
 		logger,
 	)
 
@@ -1892,7 +2054,9 @@ func (cmd *RunCommand) constructAPIHandler(
 		resourceConfigFactory,
 		dbUserFactory,
 
-		buildserver.NewEventHandler,
+		func(logger lager.Logger, build db.Build) http.Handler {
+			return buildserver.NewEventHandler(logger, buildEventStore, build)
+		},
 
 		workerClient,
 
@@ -1910,6 +2074,9 @@ func (cmd *RunCommand) constructAPIHandler(
 		time.Minute,
 		dbWall,
 		clock.NewClock(),
+		artifactStore,
+		auditEventFactory,
+		webhookFactory,
 	)
 }
 
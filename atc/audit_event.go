@@ -0,0 +1,23 @@
+package atc
+
+// AuditEvent is a record of an API request made against the ATC, stored when
+// auditing is enabled for the category of the request's action.
+type AuditEvent struct {
+	ID           int    `json:"id"`
+	Action       string `json:"action"`
+	UserName     string `json:"user_name,omitempty"`
+	TeamName     string `json:"team_name,omitempty"`
+	PipelineName string `json:"pipeline_name,omitempty"`
+	Object       string `json:"object,omitempty"`
+	SourceIP     string `json:"source_ip,omitempty"`
+	Status       int    `json:"status"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
+const (
+	AuditEventQueryAction   = "action"
+	AuditEventQueryUser     = "user"
+	AuditEventQueryPipeline = "pipeline"
+	AuditEventQuerySince    = "since"
+	AuditEventQueryUntil    = "until"
+)
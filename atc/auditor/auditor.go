@@ -2,10 +2,14 @@ package auditor
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/tedsuo/rata"
 )
 
 //go:generate counterfeiter . Auditor
@@ -20,6 +24,8 @@ func NewAuditor(
 	EnableTeamAuditLog bool,
 	EnableWorkerAuditLog bool,
 	EnableVolumeAuditLog bool,
+	trustedProxies []*net.IPNet,
+	auditEventFactory db.AuditEventFactory,
 	logger lager.Logger,
 ) *auditor {
 	return &auditor{
@@ -32,12 +38,14 @@ func NewAuditor(
 		EnableTeamAuditLog:      EnableTeamAuditLog,
 		EnableWorkerAuditLog:    EnableWorkerAuditLog,
 		EnableVolumeAuditLog:    EnableVolumeAuditLog,
+		trustedProxies:          trustedProxies,
+		auditEventFactory:       auditEventFactory,
 		logger:                  logger,
 	}
 }
 
 type Auditor interface {
-	Audit(action string, userName string, r *http.Request)
+	Audit(action string, userName string, r *http.Request, status int)
 }
 
 type auditor struct {
@@ -50,6 +58,8 @@ type auditor struct {
 	EnableTeamAuditLog      bool
 	EnableWorkerAuditLog    bool
 	EnableVolumeAuditLog    bool
+	trustedProxies          []*net.IPNet
+	auditEventFactory       db.AuditEventFactory
 	logger                  lager.Logger
 }
 
@@ -63,12 +73,16 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.BuildEvents,
 		atc.BuildResources,
 		atc.AbortBuild,
+		atc.ApproveBuild,
+		atc.RejectBuild,
 		atc.GetBuildPreparation,
 		atc.ListBuildsWithVersionAsInput,
 		atc.ListBuildsWithVersionAsOutput,
 		atc.CreateArtifact,
 		atc.GetArtifact,
-		atc.ListBuildArtifacts:
+		atc.ListBuildArtifacts,
+		atc.ListBuildStoredArtifacts,
+		atc.GetBuildStoredArtifact:
 		return a.EnableBuildAuditLog
 	case atc.ListContainers,
 		atc.GetContainer,
@@ -123,6 +137,8 @@ func (a *auditor) ValidateAction(action string) bool {
 	case
 		atc.SaveConfig,
 		atc.GetConfig,
+		atc.ListPipelineConfigVersions,
+		atc.GetPipelineConfigVersion,
 		atc.GetCC,
 		atc.GetVersionsDB,
 		atc.ClearTaskCache,
@@ -132,6 +148,7 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.GetInfo,
 		atc.GetInfoCreds,
 		atc.ListActiveUsersSince,
+		atc.ListAllAuditEvents,
 		atc.GetUser,
 		atc.GetWall,
 		atc.SetWall,
@@ -142,6 +159,11 @@ func (a *auditor) ValidateAction(action string) bool {
 		atc.RenameTeam,
 		atc.DestroyTeam,
 		atc.ListTeamBuilds,
+		atc.ListAuditEvents,
+		atc.ListWebhooks,
+		atc.SetWebhook,
+		atc.DestroyWebhook,
+		atc.ListWebhookDeliveries,
 		atc.GetTeam:
 		return a.EnableTeamAuditLog
 	case atc.RegisterWorker,
@@ -161,9 +183,104 @@ func (a *auditor) ValidateAction(action string) bool {
 	}
 }
 
-func (a *auditor) Audit(action string, userName string, r *http.Request) {
-	err := r.ParseForm()
-	if err == nil && a.ValidateAction(action) {
-		a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form})
+func (a *auditor) Audit(action string, userName string, r *http.Request, status int) {
+	if !a.ValidateAction(action) {
+		return
 	}
+
+	// the form is parsed before the request is handled, in which case this is
+	// a no-op
+	_ = r.ParseForm()
+
+	a.logger.Info("audit", lager.Data{"action": action, "user": userName, "parameters": r.Form, "status": status})
+
+	err := a.auditEventFactory.CreateAuditEvent(db.AuditEvent{
+		Action:       action,
+		UserName:     userName,
+		TeamName:     rata.Param(r, "team_name"),
+		PipelineName: rata.Param(r, "pipeline_name"),
+		Object:       auditObject(r),
+		SourceIP:     a.sourceIP(r),
+		Status:       status,
+	})
+	if err != nil {
+		a.logger.Error("failed-to-record-audit-event", err, lager.Data{"action": action})
+	}
+}
+
+// auditObject describes the object targeted by the request, as identified by
+// its route parameters, e.g. "job:some-job" or "build:42", falling back to the
+// team for requests targeting the team itself.
+func auditObject(r *http.Request) string {
+	if job := rata.Param(r, "job_name"); job != "" {
+		if build := rata.Param(r, "build_name"); build != "" {
+			return "build:" + job + "/" + build
+		}
+
+		return "job:" + job
+	}
+
+	for _, param := range []struct {
+		name string
+		kind string
+	}{
+		{"resource_name", "resource"},
+		{"resource_type_name", "resource-type"},
+		{"build_id", "build"},
+		{"worker_name", "worker"},
+		{"id", "container"},
+		{"artifact_id", "artifact"},
+		{"webhook_name", "webhook"},
+	} {
+		if value := rata.Param(r, param.name); value != "" {
+			return param.kind + ":" + value
+		}
+	}
+
+	if rata.Param(r, "pipeline_name") == "" {
+		if team := rata.Param(r, "team_name"); team != "" {
+			return "team:" + team
+		}
+	}
+
+	return ""
+}
+
+// sourceIP returns the address the request came from. Requests coming through
+// a trusted proxy are traced back through the addresses the proxies appended
+// to X-Forwarded-For, up to the first one which isn't trusted, as anything
+// before it may have been made up by the client.
+func (a *auditor) sourceIP(r *http.Request) string {
+	source, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		source = r.RemoteAddr
+	}
+
+	var forwarded []string
+	for _, header := range r.Header["X-Forwarded-For"] {
+		for _, addr := range strings.Split(header, ",") {
+			forwarded = append(forwarded, strings.TrimSpace(addr))
+		}
+	}
+
+	for i := len(forwarded) - 1; i >= 0 && a.isTrustedProxy(source); i-- {
+		source = forwarded[i]
+	}
+
+	return source
+}
+
+func (a *auditor) isTrustedProxy(addr string) bool {
+	ip := net.ParseIP(addr)
+	if ip == nil {
+		return false
+	}
+
+	for _, proxy := range a.trustedProxies {
+		if proxy.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
 }
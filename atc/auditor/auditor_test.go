@@ -1,12 +1,16 @@
 package auditor_test
 
 import (
+	"errors"
+	"net"
 	"net/http"
 
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/auditor"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -18,6 +22,7 @@ var _ = Describe("Audit", func() {
 		dummyAction             string
 		userName                string
 		logger                  *lagertest.TestLogger
+		fakeAuditEventFactory   *dbfakes.FakeAuditEventFactory
 		req                     *http.Request
 		EnableBuildAuditLog     bool
 		EnableContainerAuditLog bool
@@ -28,10 +33,12 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog      bool
 		EnableWorkerAuditLog    bool
 		EnableVolumeAuditLog    bool
+		trustedProxies          []*net.IPNet
 	)
 
 	BeforeEach(func() {
 		userName = "test"
+		fakeAuditEventFactory = new(dbfakes.FakeAuditEventFactory)
 
 		var err error
 		req, err = http.NewRequest("GET", "localhost:8080", nil)
@@ -51,6 +58,8 @@ var _ = Describe("Audit", func() {
 			EnableTeamAuditLog,
 			EnableWorkerAuditLog,
 			EnableVolumeAuditLog,
+			trustedProxies,
+			fakeAuditEventFactory,
 			logger,
 		)
 	})
@@ -65,6 +74,7 @@ var _ = Describe("Audit", func() {
 		EnableTeamAuditLog = false
 		EnableWorkerAuditLog = false
 		EnableVolumeAuditLog = false
+		trustedProxies = nil
 	})
 	Context("when audit is called", func() {
 		BeforeEach(func() {
@@ -80,13 +90,123 @@ var _ = Describe("Audit", func() {
 		})
 		It("all routes are handled and does not panic", func() {
 			for _, route := range atc.Routes {
-				aud.Audit(route.Name, userName, req)
+				aud.Audit(route.Name, userName, req, http.StatusOK)
 			}
 			logs := logger.Logs()
 			Expect(len(logs)).ToNot(Equal(0))
 		})
 	})
 
+	Describe("recording audit events", func() {
+		BeforeEach(func() {
+			EnablePipelineAuditLog = true
+			EnableJobAuditLog = true
+
+			var err error
+			req, err = http.NewRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/pause?:team_name=some-team&:pipeline_name=some-pipeline", http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			req.RemoteAddr = "1.2.3.4:5678"
+		})
+
+		It("records the event with its outcome", func() {
+			aud.Audit(atc.PausePipeline, userName, req, http.StatusForbidden)
+
+			Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0)).To(Equal(db.AuditEvent{
+				Action:       atc.PausePipeline,
+				UserName:     userName,
+				TeamName:     "some-team",
+				PipelineName: "some-pipeline",
+				SourceIP:     "1.2.3.4",
+				Status:       http.StatusForbidden,
+			}))
+		})
+
+		It("records the object the request targets", func() {
+			var err error
+			req, err = http.NewRequest("PUT", "/api/v1/teams/some-team/pipelines/some-pipeline/jobs/some-job/pause?:team_name=some-team&:pipeline_name=some-pipeline&:job_name=some-job", http.NoBody)
+			Expect(err).NotTo(HaveOccurred())
+
+			aud.Audit(atc.PauseJob, userName, req, http.StatusOK)
+
+			Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+			Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0).Object).To(Equal("job:some-job"))
+		})
+
+		Context("when the request targets a team", func() {
+			BeforeEach(func() {
+				EnableTeamAuditLog = true
+
+				var err error
+				req, err = http.NewRequest("DELETE", "/api/v1/teams/some-team?:team_name=some-team", http.NoBody)
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("records the team as the object", func() {
+				aud.Audit(atc.DestroyTeam, userName, req, http.StatusNoContent)
+
+				Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(Equal(1))
+				Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0).Object).To(Equal("team:some-team"))
+			})
+		})
+
+		Context("when the request was forwarded", func() {
+			BeforeEach(func() {
+				req.Header.Add("X-Forwarded-For", "5.6.7.8, 10.0.0.2")
+				req.Header.Add("X-Forwarded-For", "10.0.0.1")
+				req.RemoteAddr = "10.0.0.3:5678"
+			})
+
+			It("records the address the request came from", func() {
+				aud.Audit(atc.PausePipeline, userName, req, http.StatusOK)
+
+				Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0).SourceIP).To(Equal("10.0.0.3"))
+			})
+
+			Context("through trusted proxies", func() {
+				BeforeEach(func() {
+					_, proxies, err := net.ParseCIDR("10.0.0.0/24")
+					Expect(err).NotTo(HaveOccurred())
+
+					trustedProxies = []*net.IPNet{proxies}
+				})
+
+				It("records the address the first trusted proxy was forwarded from", func() {
+					aud.Audit(atc.PausePipeline, userName, req, http.StatusOK)
+
+					Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0).SourceIP).To(Equal("5.6.7.8"))
+				})
+
+				It("does not trust the addresses the client made up", func() {
+					req.Header.Set("X-Forwarded-For", "10.0.0.4, 5.6.7.8, 10.0.0.2")
+
+					aud.Audit(atc.PausePipeline, userName, req, http.StatusOK)
+
+					Expect(fakeAuditEventFactory.CreateAuditEventArgsForCall(0).SourceIP).To(Equal("5.6.7.8"))
+				})
+			})
+		})
+
+		It("does not record events for actions which are not audited", func() {
+			aud.Audit(atc.GetBuild, userName, req, http.StatusOK)
+
+			Expect(fakeAuditEventFactory.CreateAuditEventCallCount()).To(BeZero())
+		})
+
+		Context("when recording the event fails", func() {
+			BeforeEach(func() {
+				fakeAuditEventFactory.CreateAuditEventReturns(errors.New("nope"))
+			})
+
+			It("logs the failure", func() {
+				aud.Audit(atc.PausePipeline, userName, req, http.StatusOK)
+
+				Expect(logger.LogMessages()).To(ContainElement("access_handler.failed-to-record-audit-event"))
+			})
+		})
+	})
+
 	Describe("EnableBuildAuditLog", func() {
 
 		Context("When EnableBuildAudit is false with a Build action", func() {
@@ -96,7 +216,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -110,7 +230,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -123,7 +243,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -136,7 +256,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -152,7 +272,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -166,7 +286,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -179,7 +299,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -192,7 +312,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -208,7 +328,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -222,7 +342,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -235,7 +355,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -248,7 +368,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -264,7 +384,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -278,7 +398,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -291,7 +411,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -304,7 +424,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -320,7 +440,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -334,7 +454,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -347,7 +467,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -360,7 +480,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -376,7 +496,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -390,7 +510,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -403,7 +523,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -416,7 +536,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -432,7 +552,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -446,7 +566,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -459,7 +579,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -472,7 +592,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -488,7 +608,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -502,7 +622,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -515,7 +635,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -528,7 +648,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -544,7 +664,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -558,7 +678,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Create a log including the action", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(logs[0].Data["action"]).To(Equal(dummyAction))
 			})
@@ -571,7 +691,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
@@ -584,7 +704,7 @@ var _ = Describe("Audit", func() {
 			})
 
 			It("Doesn't create a log", func() {
-				aud.Audit(dummyAction, userName, req)
+				aud.Audit(dummyAction, userName, req, http.StatusOK)
 				logs := logger.Logs()
 				Expect(len(logs)).To(Equal(0))
 			})
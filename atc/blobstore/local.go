@@ -0,0 +1,123 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Local stores blobs as files beneath a directory on the web node. It is only
+// suitable for single-node deployments or a directory on shared storage.
+type Local struct {
+	Path string `long:"local-path" description:"Directory in which to store blobs."`
+}
+
+// IsConfigured identifies if a directory has been set
+func (l Local) IsConfigured() bool {
+	return l.Path != ""
+}
+
+// Store returns a Store writing to the configured directory, creating it if
+// necessary.
+func (l Local) Store() (Store, error) {
+	err := os.MkdirAll(l.Path, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("create local store directory: %w", err)
+	}
+
+	return NewLocalStore(l.Path), nil
+}
+
+type localStore struct {
+	root string
+}
+
+func NewLocalStore(root string) Store {
+	return &localStore{root: root}
+}
+
+func (store *localStore) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return 0, err
+	}
+
+	err = os.MkdirAll(filepath.Dir(path), 0755)
+	if err != nil {
+		return 0, err
+	}
+
+	// write to a temporary file first so that readers never observe a
+	// partially written blob
+	tmp, err := ioutil.TempFile(filepath.Dir(path), ".upload-")
+	if err != nil {
+		return 0, err
+	}
+
+	defer os.Remove(tmp.Name())
+
+	size, err := io.Copy(tmp, content)
+	if err != nil {
+		tmp.Close()
+		return 0, err
+	}
+
+	err = tmp.Close()
+	if err != nil {
+		return 0, err
+	}
+
+	err = os.Rename(tmp.Name(), path)
+	if err != nil {
+		return 0, err
+	}
+
+	return size, nil
+}
+
+func (store *localStore) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	path, err := store.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return file, nil
+}
+
+func (store *localStore) Delete(ctx context.Context, key string) error {
+	path, err := store.path(key)
+	if err != nil {
+		return err
+	}
+
+	err = os.Remove(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+
+	return nil
+}
+
+func (store *localStore) path(key string) (string, error) {
+	path := filepath.Join(store.root, filepath.FromSlash(key))
+
+	rel, err := filepath.Rel(store.root, path)
+	if err != nil || rel == "." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) || rel == ".." {
+		return "", fmt.Errorf("invalid key: %s", key)
+	}
+
+	return path, nil
+}
@@ -0,0 +1,94 @@
+package blobstore_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/concourse/concourse/atc/blobstore"
+)
+
+var _ = Describe("Local", func() {
+	var (
+		ctx   context.Context
+		root  string
+		store blobstore.Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+
+		var err error
+		root, err = ioutil.TempDir("", "blobstore")
+		Expect(err).ToNot(HaveOccurred())
+
+		store, err = blobstore.Local{Path: filepath.Join(root, "blobs")}.Store()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(root)
+	})
+
+	It("stores and retrieves blobs", func() {
+		size, err := store.Put(ctx, "builds/1/some-artifact.tgz", strings.NewReader("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal(int64(len("some-content"))))
+
+		blob, err := store.Get(ctx, "builds/1/some-artifact.tgz")
+		Expect(err).ToNot(HaveOccurred())
+
+		content, err := ioutil.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(blob.Close()).To(Succeed())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("replaces existing blobs", func() {
+		_, err := store.Put(ctx, "some-key", strings.NewReader("old"))
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = store.Put(ctx, "some-key", strings.NewReader("new"))
+		Expect(err).ToNot(HaveOccurred())
+
+		blob, err := store.Get(ctx, "some-key")
+		Expect(err).ToNot(HaveOccurred())
+		defer blob.Close()
+
+		content, err := ioutil.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("new"))
+	})
+
+	It("deletes blobs", func() {
+		_, err := store.Put(ctx, "some-key", strings.NewReader("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+
+		Expect(store.Delete(ctx, "some-key")).To(Succeed())
+
+		_, err = store.Get(ctx, "some-key")
+		Expect(err).To(Equal(blobstore.ErrNotFound))
+	})
+
+	It("does not fail to delete missing blobs", func() {
+		Expect(store.Delete(ctx, "bogus")).To(Succeed())
+	})
+
+	It("returns ErrNotFound for missing blobs", func() {
+		_, err := store.Get(ctx, "bogus")
+		Expect(err).To(Equal(blobstore.ErrNotFound))
+	})
+
+	It("refuses keys outside of its directory", func() {
+		_, err := store.Put(ctx, "../escaped", strings.NewReader("some-content"))
+		Expect(err).To(HaveOccurred())
+
+		_, err = os.Stat(filepath.Join(root, "escaped"))
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,120 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
+	"github.com/aws/aws-sdk-go/aws/credentials"
+	"github.com/aws/aws-sdk-go/aws/session"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/aws/aws-sdk-go/service/s3/s3iface"
+	"github.com/aws/aws-sdk-go/service/s3/s3manager"
+)
+
+// S3 stores blobs in a bucket of S3 or any S3-compatible object store, such
+// as MinIO.
+type S3 struct {
+	Bucket          string `long:"s3-bucket"            description:"Bucket in which to store blobs."`
+	Prefix          string `long:"s3-prefix"            description:"Prefix to prepend to the key of every stored blob."`
+	Region          string `long:"s3-region"            description:"Region of the bucket." default:"us-east-1"`
+	Endpoint        string `long:"s3-endpoint"          description:"URL of an S3-compatible API to use instead of AWS."`
+	ForcePathStyle  bool   `long:"s3-force-path-style"  description:"Address the bucket as part of the path rather than the host name. Usually required by S3-compatible APIs."`
+	AccessKeyID     string `long:"s3-access-key-id"     description:"Access key ID. If not set, credentials are discovered from the environment."`
+	SecretAccessKey string `long:"s3-secret-access-key" description:"Secret access key."`
+	SessionToken    string `long:"s3-session-token"     description:"Session token."`
+}
+
+// IsConfigured identifies if a bucket has been set
+func (s S3) IsConfigured() bool {
+	return s.Bucket != ""
+}
+
+// Store returns a Store writing to the configured bucket.
+func (s S3) Store() (Store, error) {
+	config := &aws.Config{
+		Region:           aws.String(s.Region),
+		S3ForcePathStyle: aws.Bool(s.ForcePathStyle),
+	}
+
+	if s.Endpoint != "" {
+		config.Endpoint = aws.String(s.Endpoint)
+	}
+
+	if s.AccessKeyID != "" {
+		config.Credentials = credentials.NewStaticCredentials(s.AccessKeyID, s.SecretAccessKey, s.SessionToken)
+	}
+
+	sess, err := session.NewSession(config)
+	if err != nil {
+		return nil, fmt.Errorf("create s3 session: %w", err)
+	}
+
+	return NewS3Store(s3.New(sess), s.Bucket, s.Prefix), nil
+}
+
+type s3Store struct {
+	client   s3iface.S3API
+	uploader *s3manager.Uploader
+	bucket   string
+	prefix   string
+}
+
+func NewS3Store(client s3iface.S3API, bucket string, prefix string) Store {
+	return &s3Store{
+		client:   client,
+		uploader: s3manager.NewUploaderWithClient(client),
+		bucket:   bucket,
+		prefix:   prefix,
+	}
+}
+
+func (store *s3Store) Put(ctx context.Context, key string, content io.Reader) (int64, error) {
+	counter := &countingReader{Reader: content}
+
+	_, err := store.uploader.UploadWithContext(ctx, &s3manager.UploadInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.key(key)),
+		Body:   counter,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return counter.count, nil
+}
+
+func (store *s3Store) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := store.client.GetObjectWithContext(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.key(key)),
+	})
+	if err != nil {
+		if awsErr, ok := err.(awserr.Error); ok && awsErr.Code() == s3.ErrCodeNoSuchKey {
+			return nil, ErrNotFound
+		}
+
+		return nil, err
+	}
+
+	return output.Body, nil
+}
+
+func (store *s3Store) Delete(ctx context.Context, key string) error {
+	_, err := store.client.DeleteObjectWithContext(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(store.bucket),
+		Key:    aws.String(store.key(key)),
+	})
+	return err
+}
+
+func (store *s3Store) key(key string) string {
+	if store.prefix == "" {
+		return key
+	}
+
+	return path.Join(store.prefix, key)
+}
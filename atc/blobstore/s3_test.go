@@ -0,0 +1,98 @@
+package blobstore_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc/blobstore"
+)
+
+var _ = Describe("S3", func() {
+	var (
+		ctx    context.Context
+		server *ghttp.Server
+		store  blobstore.Store
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		server = ghttp.NewServer()
+
+		var err error
+		store, err = blobstore.S3{
+			Bucket:          "some-bucket",
+			Prefix:          "some-prefix",
+			Endpoint:        server.URL(),
+			Region:          "us-east-1",
+			ForcePathStyle:  true,
+			AccessKeyID:     "some-access-key",
+			SecretAccessKey: "some-secret-key",
+		}.Store()
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("uploads blobs beneath the prefix", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("PUT", "/some-bucket/some-prefix/builds/1/some-artifact.tgz"),
+				ghttp.VerifyBody([]byte("some-content")),
+				ghttp.RespondWith(http.StatusOK, ""),
+			),
+		)
+
+		size, err := store.Put(ctx, "builds/1/some-artifact.tgz", strings.NewReader("some-content"))
+		Expect(err).ToNot(HaveOccurred())
+		Expect(size).To(Equal(int64(len("some-content"))))
+	})
+
+	It("downloads blobs", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/some-bucket/some-prefix/some-key"),
+				ghttp.RespondWith(http.StatusOK, "some-content"),
+			),
+		)
+
+		blob, err := store.Get(ctx, "some-key")
+		Expect(err).ToNot(HaveOccurred())
+		defer blob.Close()
+
+		content, err := ioutil.ReadAll(blob)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(string(content)).To(Equal("some-content"))
+	})
+
+	It("returns ErrNotFound for missing blobs", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/some-bucket/some-prefix/bogus"),
+				ghttp.RespondWith(http.StatusNotFound, `<?xml version="1.0" encoding="UTF-8"?><Error><Code>NoSuchKey</Code><Message>The specified key does not exist.</Message></Error>`),
+			),
+		)
+
+		_, err := store.Get(ctx, "bogus")
+		Expect(err).To(Equal(blobstore.ErrNotFound))
+	})
+
+	It("deletes blobs", func() {
+		server.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("DELETE", "/some-bucket/some-prefix/some-key"),
+				ghttp.RespondWith(http.StatusNoContent, ""),
+			),
+		)
+
+		Expect(store.Delete(ctx, "some-key")).To(Succeed())
+		Expect(server.ReceivedRequests()).To(HaveLen(1))
+	})
+})
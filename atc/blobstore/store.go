@@ -0,0 +1,58 @@
+package blobstore
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// ErrNotFound is returned when a blob does not exist in the store.
+var ErrNotFound = errors.New("blob not found")
+
+//go:generate counterfeiter . Store
+
+// Store is a durable home for blobs, such as artifacts uploaded by tasks,
+// which need to outlive the volumes they were produced in.
+type Store interface {
+	// Put writes the content to the given key, replacing any existing blob, and
+	// returns the number of bytes written.
+	Put(ctx context.Context, key string, content io.Reader) (int64, error)
+
+	// Get returns the content of the blob stored under the given key. It
+	// returns ErrNotFound if there is no such blob.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+
+	// Delete removes the blob stored under the given key. Deleting a blob which
+	// does not exist is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects the backend in which to store blobs. At most one backend is
+// expected to be configured.
+type Config struct {
+	Local Local
+	S3    S3
+}
+
+// Store returns the configured store, or nil if no backend is configured.
+func (c Config) Store() (Store, error) {
+	switch {
+	case c.Local.IsConfigured():
+		return c.Local.Store()
+	case c.S3.IsConfigured():
+		return c.S3.Store()
+	default:
+		return nil, nil
+	}
+}
+
+type countingReader struct {
+	io.Reader
+	count int64
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += int64(n)
+	return n, err
+}
@@ -3,12 +3,13 @@ package atc
 type BuildStatus string
 
 const (
-	StatusStarted   BuildStatus = "started"
-	StatusPending   BuildStatus = "pending"
-	StatusSucceeded BuildStatus = "succeeded"
-	StatusFailed    BuildStatus = "failed"
-	StatusErrored   BuildStatus = "errored"
-	StatusAborted   BuildStatus = "aborted"
+	StatusStarted         BuildStatus = "started"
+	StatusPending         BuildStatus = "pending"
+	StatusWaitingApproval BuildStatus = "waiting-approval"
+	StatusSucceeded       BuildStatus = "succeeded"
+	StatusFailed          BuildStatus = "failed"
+	StatusErrored         BuildStatus = "errored"
+	StatusAborted         BuildStatus = "aborted"
 )
 
 func (status BuildStatus) String() string {
@@ -31,6 +32,8 @@ type Build struct {
 	ReapTime             int64         `json:"reap_time,omitempty"`
 	RerunNumber          int           `json:"rerun_number,omitempty"`
 	RerunOf              *RerunOfBuild `json:"rerun_of,omitempty"`
+
+	ResourceUsage []StepResourceUsage `json:"resource_usage,omitempty"`
 }
 
 type RerunOfBuild struct {
@@ -40,7 +43,7 @@ type RerunOfBuild struct {
 
 func (b Build) IsRunning() bool {
 	switch BuildStatus(b.Status) {
-	case StatusPending, StatusStarted:
+	case StatusPending, StatusStarted, StatusWaitingApproval:
 		return true
 	default:
 		return false
@@ -1,6 +1,8 @@
 package builds
 
 import (
+	"fmt"
+
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
@@ -19,6 +21,7 @@ func (planner Planner) Create(
 	planConfig atc.StepConfig,
 	resources db.SchedulerResources,
 	resourceTypes atc.VersionedResourceTypes,
+	templates atc.StepTemplateConfigs,
 	inputs []db.BuildInput,
 ) (atc.Plan, error) {
 	visitor := &planVisitor{
@@ -26,6 +29,7 @@ func (planner Planner) Create(
 
 		resources:     resources,
 		resourceTypes: resourceTypes,
+		templates:     templates,
 		inputs:        inputs,
 	}
 
@@ -42,8 +46,11 @@ type planVisitor struct {
 
 	resources     db.SchedulerResources
 	resourceTypes atc.VersionedResourceTypes
+	templates     atc.StepTemplateConfigs
 	inputs        []db.BuildInput
 
+	usingTemplates []string
+
 	plan atc.Plan
 }
 
@@ -59,6 +66,7 @@ func (visitor *planVisitor) VisitTask(step *atc.TaskStep) error {
 		InputMapping:      step.InputMapping,
 		OutputMapping:     step.OutputMapping,
 		ImageArtifactName: step.ImageArtifactName,
+		Artifacts:         step.Artifacts,
 
 		VersionedResourceTypes: visitor.resourceTypes,
 	})
@@ -281,6 +289,34 @@ func (visitor *planVisitor) VisitLoadVar(step *atc.LoadVarStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitApproval(step *atc.ApprovalStep) error {
+	visitor.plan = visitor.planFactory.NewPlan(atc.ApprovalPlan{
+		Name: step.Name,
+	})
+
+	return nil
+}
+
+func (visitor *planVisitor) VisitUse(step *atc.UseStep) error {
+	for _, name := range visitor.usingTemplates {
+		if name == step.Name {
+			return fmt.Errorf("template '%s' uses itself", step.Name)
+		}
+	}
+
+	instance, err := visitor.templates.Instantiate(step)
+	if err != nil {
+		return err
+	}
+
+	visitor.usingTemplates = append(visitor.usingTemplates, step.Name)
+	defer func() {
+		visitor.usingTemplates = visitor.usingTemplates[:len(visitor.usingTemplates)-1]
+	}()
+
+	return instance.Config.Visit(visitor)
+}
+
 func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	err := step.Step.Config.Visit(visitor)
 	if err != nil {
@@ -294,6 +330,20 @@ func (visitor *planVisitor) VisitTry(step *atc.TryStep) error {
 	return nil
 }
 
+func (visitor *planVisitor) VisitIf(step *atc.IfStep) error {
+	err := step.Step.Visit(visitor)
+	if err != nil {
+		return err
+	}
+
+	visitor.plan = visitor.planFactory.NewPlan(atc.IfPlan{
+		Condition: step.Condition,
+		Step:      visitor.plan,
+	})
+
+	return nil
+}
+
 func (visitor *planVisitor) VisitTimeout(step *atc.TimeoutStep) error {
 	err := step.Step.Visit(visitor)
 	if err != nil {
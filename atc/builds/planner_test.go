@@ -2,6 +2,7 @@ package builds_test
 
 import (
 	"encoding/json"
+	"errors"
 	"testing"
 
 	"github.com/concourse/concourse/atc"
@@ -58,6 +59,31 @@ var resourceTypes = atc.VersionedResourceTypes{
 	},
 }
 
+var templates = atc.StepTemplateConfigs{
+	{
+		Name: "load-some-var",
+		Params: []atc.StepTemplateParam{
+			{Name: "file"},
+			{Name: "format", Default: "json"},
+		},
+		Step: atc.Step{
+			Config: &atc.LoadVarStep{
+				Name:   "some-var",
+				File:   "((.:file))",
+				Format: "((.:format))",
+			},
+		},
+	},
+	{
+		Name: "recursive",
+		Step: atc.Step{
+			Config: &atc.UseStep{
+				Name: "recursive",
+			},
+		},
+	},
+}
+
 var baseResourceTypeDefaults = map[string]atc.Source{
 	"some-base-resource-type": {"default-key": "default-value"},
 }
@@ -237,6 +263,7 @@ var factoryTests = []PlannerTest{
 			InputMapping:      map[string]string{"generic": "specific"},
 			OutputMapping:     map[string]string{"specific": "generic"},
 			ImageArtifactName: "some-image",
+			Artifacts:         []string{"generic/app.tgz"},
 		},
 
 		PlanJSON: `{
@@ -255,6 +282,7 @@ var factoryTests = []PlannerTest{
 				"input_mapping": {"generic": "specific"},
 				"output_mapping": {"specific": "generic"},
 				"image": "some-image",
+				"artifacts": ["generic/app.tgz"],
 				"resource_types": [
 					{
 						"name": "some-resource-type",
@@ -289,6 +317,20 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "approval step",
+
+		Config: &atc.ApprovalStep{
+			Name: "deploy",
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"approval": {
+				"name": "deploy"
+			}
+		}`,
+	},
 	{
 		Title: "load_var step",
 
@@ -752,6 +794,41 @@ var factoryTests = []PlannerTest{
 			}
 		}`,
 	},
+	{
+		Title: "use step",
+
+		Config: &atc.UseStep{
+			Name: "load-some-var",
+			Args: map[string]interface{}{"file": "some-file"},
+		},
+
+		PlanJSON: `{
+			"id": "(unique)",
+			"load_var": {
+				"name": "some-var",
+				"file": "some-file",
+				"format": "json"
+			}
+		}`,
+	},
+	{
+		Title: "use step with unknown template",
+
+		Config: &atc.UseStep{
+			Name: "bogus",
+		},
+
+		Err: errors.New("unknown template 'bogus'"),
+	},
+	{
+		Title: "use step with recursive template",
+
+		Config: &atc.UseStep{
+			Name: "recursive",
+		},
+
+		Err: errors.New("template 'recursive' uses itself"),
+	},
 	{
 		Title: "ensure step",
 
@@ -793,7 +870,7 @@ var factoryTests = []PlannerTest{
 func (test PlannerTest) Run(s *PlannerSuite) {
 	factory := builds.NewPlanner(atc.NewPlanFactory(0))
 
-	actualPlan, actualErr := factory.Create(test.Config, resources, resourceTypes, test.Inputs)
+	actualPlan, actualErr := factory.Create(test.Config, resources, resourceTypes, templates, test.Inputs)
 
 	if test.Err != nil {
 		s.Equal(test.Err, actualErr)
@@ -8,15 +8,21 @@ const (
 	ComponentLidarScanner               = "scanner"
 	ComponentBuildReaper                = "reaper"
 	ComponentSyslogDrainer              = "drainer"
+	ComponentLogDrainer                 = "log_drainer"
+	ComponentBuildEventArchiver         = "build_event_archiver"
+	ComponentWebhookDispatcher          = "webhook_dispatcher"
 	ComponentCollectorAccessTokens      = "collector_access_tokens"
 	ComponentCollectorArtifacts         = "collector_artifacts"
+	ComponentCollectorAuditEvents       = "collector_audit_events"
 	ComponentCollectorBuilds            = "collector_builds"
 	ComponentCollectorCheckSessions     = "collector_check_sessions"
 	ComponentCollectorChecks            = "collector_checks"
 	ComponentCollectorContainers        = "collector_containers"
+	ComponentCollectorKeyedCaches       = "collector_keyed_caches"
 	ComponentCollectorResourceCacheUses = "collector_resource_cache_uses"
 	ComponentCollectorResourceCaches    = "collector_resource_caches"
 	ComponentCollectorResourceConfigs   = "collector_resource_configs"
+	ComponentCollectorStoredArtifacts   = "collector_stored_artifacts"
 	ComponentCollectorVolumes           = "collector_volumes"
 	ComponentCollectorWorkers           = "collector_workers"
 	ComponentCollectorPipelines         = "collector_pipelines"
@@ -17,12 +17,13 @@ const DefaultTeamName = "main"
 type Tags []string
 
 type Config struct {
-	Groups        GroupConfigs     `json:"groups,omitempty"`
-	VarSources    VarSourceConfigs `json:"var_sources,omitempty"`
-	Resources     ResourceConfigs  `json:"resources,omitempty"`
-	ResourceTypes ResourceTypes    `json:"resource_types,omitempty"`
-	Jobs          JobConfigs       `json:"jobs,omitempty"`
-	Display       *DisplayConfig   `json:"display,omitempty"`
+	Groups        GroupConfigs        `json:"groups,omitempty"`
+	VarSources    VarSourceConfigs    `json:"var_sources,omitempty"`
+	Resources     ResourceConfigs     `json:"resources,omitempty"`
+	ResourceTypes ResourceTypes       `json:"resource_types,omitempty"`
+	Jobs          JobConfigs          `json:"jobs,omitempty"`
+	Templates     StepTemplateConfigs `json:"templates,omitempty"`
+	Display       *DisplayConfig      `json:"display,omitempty"`
 }
 
 func UnmarshalConfig(payload []byte, config interface{}) error {
@@ -33,6 +34,7 @@ func UnmarshalConfig(payload []byte, config interface{}) error {
 		Resources     interface{} `json:"resources,omitempty"`
 		ResourceTypes interface{} `json:"resource_types,omitempty"`
 		Jobs          interface{} `json:"jobs,omitempty"`
+		Templates     interface{} `json:"templates,omitempty"`
 		Display       interface{} `json:"display,omitempty"`
 	}
 
@@ -184,6 +186,33 @@ type ResourceConfig struct {
 	Tags         Tags    `json:"tags,omitempty"`
 	Version      Version `json:"version,omitempty"`
 	Icon         string  `json:"icon,omitempty"`
+
+	Webhook *ResourceWebhook `json:"webhook,omitempty"`
+}
+
+const (
+	WebhookProviderGitHub    = "github"
+	WebhookProviderGitLab    = "gitlab"
+	WebhookProviderBitbucket = "bitbucket"
+)
+
+// ResourceWebhook configures how calls to a resource's check webhook are
+// verified, as an alternative to a webhook_token in the URL. Without a
+// provider, the request must carry an HMAC-SHA256 of its body in the
+// signature header.
+type ResourceWebhook struct {
+	Provider        string                 `json:"provider,omitempty"`
+	Secret          string                 `json:"secret"`
+	SignatureHeader string                 `json:"signature_header,omitempty"`
+	Filter          *ResourceWebhookFilter `json:"filter,omitempty"`
+}
+
+// ResourceWebhookFilter narrows down which of a provider's webhook calls
+// result in a check. Branches and paths are glob patterns.
+type ResourceWebhookFilter struct {
+	Events   []string `json:"events,omitempty"`
+	Branches []string `json:"branches,omitempty"`
+	Paths    []string `json:"paths,omitempty"`
 }
 
 type ResourceType struct {
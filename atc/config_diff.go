@@ -151,6 +151,21 @@ func (index ResourceTypeIndex) FindEquivalent(obj interface{}) (interface{}, boo
 	return ResourceTypes(index).Lookup(name(obj))
 }
 
+type StepTemplateIndex StepTemplateConfigs
+
+func (index StepTemplateIndex) Slice() []interface{} {
+	slice := make([]interface{}, len(index))
+	for i, object := range index {
+		slice[i] = object
+	}
+
+	return slice
+}
+
+func (index StepTemplateIndex) FindEquivalent(obj interface{}) (interface{}, bool) {
+	return StepTemplateConfigs(index).Lookup(name(obj))
+}
+
 func groupDiffIndices(oldIndex GroupIndex, newIndex GroupIndex) Diffs {
 	diffs := Diffs{}
 
@@ -329,6 +344,16 @@ func (c Config) Diff(out io.Writer, newConfig Config) bool {
 		}
 	}
 
+	templateDiffs := diffIndices(StepTemplateIndex(c.Templates), StepTemplateIndex(newConfig.Templates))
+	if len(templateDiffs) > 0 {
+		diffExists = true
+		fmt.Fprintln(out, "templates:")
+
+		for _, diff := range templateDiffs {
+			diff.Render(indent, "template")
+		}
+	}
+
 	displayDiff, diff := diffDisplay(c.Display, newConfig.Display)
 	if diff {
 		diffExists = true
@@ -216,4 +216,54 @@ var _ = Describe("Config diff", func() {
 			})
 		})
 	})
+
+	Describe("templates", func() {
+		var template StepTemplateConfig
+		BeforeEach(func() {
+			template = StepTemplateConfig{
+				Name:   "some-template",
+				Params: []StepTemplateParam{{Name: "file"}},
+				Step: Step{
+					Config: &LoadVarStep{
+						Name: "some-var",
+						File: "((.:file))",
+					},
+				},
+			}
+		})
+
+		Context("when a template is added", func() {
+			It("says the template has been added", func() {
+				buffer := NewBuffer()
+				diff := Config{}.Diff(buffer, Config{
+					Templates: StepTemplateConfigs{template},
+				})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("templates:"))
+				Eventually(buffer).Should(Say("template some-template has been added:"))
+				Eventually(buffer).Should(Say(`\+.*load_var: some-var`))
+			})
+		})
+
+		Context("when a template changes", func() {
+			It("says the template has changed", func() {
+				changed := template
+				changed.Step = Step{
+					Config: &LoadVarStep{
+						Name: "some-other-var",
+						File: "((.:file))",
+					},
+				}
+
+				buffer := NewBuffer()
+				diff := Config{Templates: StepTemplateConfigs{template}}.Diff(buffer, Config{
+					Templates: StepTemplateConfigs{changed},
+				})
+				Expect(diff).To(BeTrue())
+				Eventually(buffer).Should(Say("template some-template has changed:"))
+				Eventually(buffer).Should(Say("-.*load_var: some-var"))
+				Eventually(buffer).Should(Say(`\+.*load_var: some-other-var`))
+			})
+		})
+	})
 })
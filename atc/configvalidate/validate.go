@@ -27,6 +27,13 @@ func Validate(c Config) ([]ConfigWarning, []string) {
 	warnings := []ConfigWarning{}
 	errorMessages := []string{}
 
+	// jobs with a matrix are validated as the jobs they are saved as
+	c, err := c.ExpandJobMatrices()
+	if err != nil {
+		errorMessages = append(errorMessages, formatErr("jobs", err))
+		return warnings, errorMessages
+	}
+
 	groupsWarnings, groupsErr := validateGroups(c)
 	if groupsErr != nil {
 		errorMessages = append(errorMessages, formatErr("groups", groupsErr))
@@ -51,6 +58,12 @@ func Validate(c Config) ([]ConfigWarning, []string) {
 	}
 	warnings = append(warnings, varSourcesWarnings...)
 
+	templatesWarnings, templatesErr := validateTemplates(c)
+	if templatesErr != nil {
+		errorMessages = append(errorMessages, formatErr("templates", templatesErr))
+	}
+	warnings = append(warnings, templatesWarnings...)
+
 	jobWarnings, jobsErr := validateJobs(c)
 	if jobsErr != nil {
 		errorMessages = append(errorMessages, formatErr("jobs", jobsErr))
@@ -179,6 +192,10 @@ func validateResources(c Config) ([]ConfigWarning, error) {
 		if resource.Type == "" {
 			errorMessages = append(errorMessages, identifier+" has no type")
 		}
+
+		if resource.Webhook != nil {
+			errorMessages = append(errorMessages, validateResourceWebhook(identifier, resource)...)
+		}
 	}
 
 	errorMessages = append(errorMessages, validateResourcesUnused(c)...)
@@ -186,6 +203,60 @@ func validateResources(c Config) ([]ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+func validateResourceWebhook(identifier string, resource ResourceConfig) []string {
+	var errorMessages []string
+
+	webhook := resource.Webhook
+	identifier += ".webhook"
+
+	if resource.WebhookToken != "" {
+		errorMessages = append(errorMessages, identifier+" cannot be used along with webhook_token")
+	}
+
+	if webhook.Secret == "" {
+		errorMessages = append(errorMessages, identifier+" has no secret")
+	}
+
+	switch webhook.Provider {
+	case "":
+		if webhook.Filter != nil {
+			errorMessages = append(errorMessages, identifier+" must have a provider in order to use a filter")
+		}
+	case WebhookProviderGitHub, WebhookProviderGitLab, WebhookProviderBitbucket:
+		if webhook.SignatureHeader != "" {
+			errorMessages = append(errorMessages, identifier+" cannot have a signature_header along with a provider")
+		}
+	default:
+		errorMessages = append(errorMessages, fmt.Sprintf(
+			"%s has an unknown provider '%s' (supported: %s, %s, %s)",
+			identifier,
+			webhook.Provider,
+			WebhookProviderGitHub,
+			WebhookProviderGitLab,
+			WebhookProviderBitbucket,
+		))
+	}
+
+	if webhook.Filter == nil {
+		return errorMessages
+	}
+
+	if webhook.Provider == WebhookProviderBitbucket && len(webhook.Filter.Paths) > 0 {
+		errorMessages = append(errorMessages, identifier+".filter.paths is not supported by the bitbucket provider")
+	}
+
+	for _, patterns := range [][]string{webhook.Filter.Branches, webhook.Filter.Paths} {
+		for _, pattern := range patterns {
+			_, err := glob.Compile(pattern, '/')
+			if err != nil {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.filter has an invalid pattern '%s': %s", identifier, pattern, err))
+			}
+		}
+	}
+
+	return errorMessages
+}
+
 func validateResourceTypes(c Config) ([]ConfigWarning, error) {
 	var warnings []ConfigWarning
 	var errorMessages []string
@@ -245,6 +316,7 @@ func usedResources(c Config) map[string]bool {
 
 	for _, job := range c.Jobs {
 		_ = job.StepConfig().Visit(atc.StepRecursor{
+			Templates: c.Templates,
 			OnGet: func(step *GetStep) error {
 				usedResources[step.ResourceName()] = true
 				return nil
@@ -259,6 +331,88 @@ func usedResources(c Config) map[string]bool {
 	return usedResources
 }
 
+// validateTemplates validates the templates themselves. The steps they define
+// are validated wherever they are used, once their params are known.
+func validateTemplates(c Config) ([]ConfigWarning, error) {
+	var warnings []ConfigWarning
+	var errorMessages []string
+
+	names := map[string]int{}
+
+	for i, template := range c.Templates {
+		var identifier string
+		if template.Name == "" {
+			identifier = fmt.Sprintf("templates[%d]", i)
+		} else {
+			identifier = fmt.Sprintf("templates.%s", template.Name)
+		}
+
+		warning := ValidateIdentifier(template.Name, identifier)
+		if warning != nil {
+			warnings = append(warnings, *warning)
+		}
+
+		if other, exists := names[template.Name]; exists {
+			errorMessages = append(errorMessages,
+				fmt.Sprintf(
+					"templates[%d] and templates[%d] have the same name ('%s')",
+					other, i, template.Name))
+		} else if template.Name != "" {
+			names[template.Name] = i
+		}
+
+		if template.Name == "" {
+			errorMessages = append(errorMessages, identifier+" has no name")
+		}
+
+		if template.Step.Config == nil {
+			errorMessages = append(errorMessages, identifier+" has no step")
+		}
+
+		params := map[string]bool{}
+		for j, param := range template.Params {
+			if param.Name == "" {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s.params[%d] has no name", identifier, j))
+				continue
+			}
+
+			if params[param.Name] {
+				errorMessages = append(errorMessages, fmt.Sprintf("%s declares param '%s' more than once", identifier, param.Name))
+			}
+
+			params[param.Name] = true
+		}
+	}
+
+	usedTemplates := usedTemplates(c)
+	for _, template := range c.Templates {
+		if !usedTemplates[template.Name] {
+			warnings = append(warnings, ConfigWarning{
+				Type:    "pipeline",
+				Message: fmt.Sprintf("template '%s' is not used", template.Name),
+			})
+		}
+	}
+
+	return warnings, compositeErr(errorMessages)
+}
+
+func usedTemplates(c Config) map[string]bool {
+	usedTemplates := make(map[string]bool)
+
+	for _, job := range c.Jobs {
+		_ = job.StepConfig().Visit(atc.StepRecursor{
+			Templates: c.Templates,
+			OnUse: func(step *UseStep) error {
+				usedTemplates[step.Name] = true
+				return nil
+			},
+		})
+	}
+
+	return usedTemplates
+}
+
 func validateJobs(c Config) ([]ConfigWarning, error) {
 	var errorMessages []string
 	var warnings []ConfigWarning
@@ -335,6 +489,8 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 			}
 		}
 
+		errorMessages = append(errorMessages, validateJobDependencies(c, job, identifier)...)
+
 		step := job.Step()
 
 		validator := atc.NewStepValidator(c, []string{identifier, ".plan"})
@@ -349,6 +505,36 @@ func validateJobs(c Config) ([]ConfigWarning, error) {
 	return warnings, compositeErr(errorMessages)
 }
 
+// validateJobDependencies checks that every job listed in depends_on exists.
+//
+// A dependency sharing an input with the job restricts that input to the
+// versions the upstream job succeeded with. Otherwise, the job is triggered
+// whenever the upstream job succeeds after the job's latest build.
+func validateJobDependencies(c Config, job atc.JobConfig, identifier string) []string {
+	var errorMessages []string
+
+	seen := map[string]bool{}
+	for _, dependency := range job.DependsOn {
+		if dependency == job.Name {
+			errorMessages = append(errorMessages, identifier+" depends on itself")
+			continue
+		}
+
+		if seen[dependency] {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s depends on job '%s' more than once", identifier, dependency))
+			continue
+		}
+
+		seen[dependency] = true
+
+		if _, found := c.Jobs.Lookup(dependency); !found {
+			errorMessages = append(errorMessages, fmt.Sprintf("%s depends on unknown job '%s'", identifier, dependency))
+		}
+	}
+
+	return errorMessages
+}
+
 func compositeErr(errorMessages []string) error {
 	if len(errorMessages) == 0 {
 		return nil
@@ -380,7 +566,7 @@ func validateVarSources(c Config) ([]ConfigWarning, error) {
 			// TODO: this check should eventually be removed once all credential managers
 			// are supported in pipeline. - @evanchaoli
 			switch cm.Type {
-			case "vault", "dummy", "ssm":
+			case "vault", "dummy", "ssm", "external", "file":
 			default:
 				errorMessages = append(errorMessages, fmt.Sprintf("credential manager type %s is not supported in pipeline yet", cm.Type))
 			}
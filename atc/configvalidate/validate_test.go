@@ -513,6 +513,101 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a resource has a webhook", func() {
+			BeforeEach(func() {
+				config.Resources[0].Webhook = &atc.ResourceWebhook{
+					Provider: atc.WebhookProviderGitHub,
+					Secret:   "((webhook-secret))",
+					Filter: &atc.ResourceWebhookFilter{
+						Events:   []string{"push"},
+						Branches: []string{"main", "release/*"},
+						Paths:    []string{"src/**"},
+					},
+				}
+			})
+
+			It("is valid", func() {
+				Expect(errorMessages).To(BeEmpty())
+			})
+
+			Context("along with a webhook_token", func() {
+				BeforeEach(func() {
+					config.Resources[0].WebhookToken = "some-token"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook cannot be used along with webhook_token"))
+				})
+			})
+
+			Context("without a secret", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.Secret = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has no secret"))
+				})
+			})
+
+			Context("with an unknown provider", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.Provider = "sourceforge"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook has an unknown provider 'sourceforge'"))
+				})
+			})
+
+			Context("with a filter but no provider", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.Provider = ""
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook must have a provider in order to use a filter"))
+				})
+			})
+
+			Context("with a signature header along with a provider", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.SignatureHeader = "X-Signature"
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook cannot have a signature_header along with a provider"))
+				})
+			})
+
+			Context("with a path filter for bitbucket", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.Provider = atc.WebhookProviderBitbucket
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook.filter.paths is not supported by the bitbucket provider"))
+				})
+			})
+
+			Context("with an invalid pattern", func() {
+				BeforeEach(func() {
+					config.Resources[0].Webhook.Filter.Branches = []string{"release/["}
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("resources.some-resource.webhook.filter has an invalid pattern 'release/['"))
+				})
+			})
+		})
+
 		Context("when two resources have the same name", func() {
 			BeforeEach(func() {
 				config.Resources = append(config.Resources, config.Resources...)
@@ -773,6 +868,140 @@ var _ = Describe("ValidateConfig", func() {
 		})
 	})
 
+	Describe("templates", func() {
+		BeforeEach(func() {
+			config.Templates = atc.StepTemplateConfigs{
+				{
+					Name:   "get-some-resource",
+					Params: []atc.StepTemplateParam{{Name: "name"}},
+					Step: atc.Step{
+						Config: &atc.GetStep{
+							Name:     "((.:name))",
+							Resource: "some-resource",
+						},
+					},
+				},
+			}
+
+			config.Jobs[1].PlanSequence = []atc.Step{
+				{
+					Config: &atc.UseStep{
+						Name: "get-some-resource",
+						Args: map[string]interface{}{"name": "some-other-input"},
+					},
+				},
+			}
+		})
+
+		Context("when the templates are used correctly", func() {
+			It("returns no error", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(BeEmpty())
+			})
+		})
+
+		Context("when a template has no name", func() {
+			BeforeEach(func() {
+				config.Templates = append(config.Templates, atc.StepTemplateConfig{
+					Step: atc.Step{Config: &atc.LoadVarStep{Name: "some-var"}},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid templates:"))
+				Expect(errorMessages[0]).To(ContainSubstring("templates[1] has no name"))
+			})
+		})
+
+		Context("when two templates have the same name", func() {
+			BeforeEach(func() {
+				config.Templates = append(config.Templates, config.Templates[0])
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("templates[0] and templates[1] have the same name ('get-some-resource')"))
+			})
+		})
+
+		Context("when a template declares a param twice", func() {
+			BeforeEach(func() {
+				config.Templates[0].Params = append(config.Templates[0].Params, atc.StepTemplateParam{Name: "name"})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("templates.get-some-resource declares param 'name' more than once"))
+			})
+		})
+
+		Context("when a template is not used", func() {
+			BeforeEach(func() {
+				config.Jobs[1].PlanSequence = nil
+			})
+
+			It("returns a warning", func() {
+				Expect(errorMessages).To(BeEmpty())
+				Expect(warnings).To(ContainElement(atc.ConfigWarning{
+					Type:    "pipeline",
+					Message: "template 'get-some-resource' is not used",
+				}))
+			})
+		})
+
+		Context("when a step uses an unknown template", func() {
+			BeforeEach(func() {
+				config.Jobs[1].PlanSequence[0].Config.(*atc.UseStep).Name = "bogus"
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.plan.do[0].use(bogus): unknown template 'bogus'"))
+			})
+		})
+
+		Context("when a step does not give a required arg", func() {
+			BeforeEach(func() {
+				config.Jobs[1].PlanSequence[0].Config.(*atc.UseStep).Args = nil
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.plan.do[0].use(get-some-resource): missing arg 'name' for template 'get-some-resource'"))
+			})
+		})
+
+		Context("when the instantiated step is invalid", func() {
+			BeforeEach(func() {
+				config.Templates[0].Step.Config.(*atc.GetStep).Resource = "bogus-resource"
+			})
+
+			It("returns an error in the context of the use", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.plan.do[0].use(get-some-resource).get(some-other-input): unknown resource 'bogus-resource'"))
+			})
+		})
+
+		Context("when a template uses itself", func() {
+			BeforeEach(func() {
+				config.Templates = append(config.Templates, atc.StepTemplateConfig{
+					Name: "recursive",
+					Step: atc.Step{Config: &atc.UseStep{Name: "recursive"}},
+				})
+
+				config.Jobs[1].PlanSequence = append(config.Jobs[1].PlanSequence, atc.Step{
+					Config: &atc.UseStep{Name: "recursive"},
+				})
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-empty-job.plan.do[1].use(recursive).use(recursive): template 'recursive' uses itself"))
+			})
+		})
+	})
+
 	Describe("invalid resource types", func() {
 		Context("when a resource type has no name", func() {
 			BeforeEach(func() {
@@ -855,6 +1084,33 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job has a matrix var with no values", func() {
+			BeforeEach(func() {
+				job.Matrix = []atc.JobMatrixVar{{Var: "go"}}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("job 'some-other-job': matrix var 'go' has no values"))
+			})
+		})
+
+		Context("when a job's matrix generates a job with the name of another job", func() {
+			BeforeEach(func() {
+				job.Name = "some"
+				job.Matrix = []atc.JobMatrixVar{{Var: "suffix", Values: []string{"job"}}}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs[0] and jobs[2] have the same name ('some-job')"))
+			})
+		})
+
 		Context("when a job has a negative build_logs_to_retain", func() {
 			BeforeEach(func() {
 				job.BuildLogsToRetain = -1
@@ -868,6 +1124,77 @@ var _ = Describe("ValidateConfig", func() {
 			})
 		})
 
+		Context("when a job depends on another job", func() {
+			BeforeEach(func() {
+				job.PlanSequence = append(job.PlanSequence, atc.Step{
+					Config: &atc.GetStep{
+						Name: "some-resource",
+					},
+				})
+			})
+
+			Context("which interacts with one of its inputs", func() {
+				BeforeEach(func() {
+					job.DependsOn = []string{"some-job"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("which does not interact with any of its inputs", func() {
+				BeforeEach(func() {
+					job.DependsOn = []string{"some-empty-job"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("does not return an error", func() {
+					Expect(errorMessages).To(HaveLen(0))
+				})
+			})
+
+			Context("which does not exist", func() {
+				BeforeEach(func() {
+					job.DependsOn = []string{"bogus-job"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job depends on unknown job 'bogus-job'"))
+				})
+			})
+
+			Context("more than once", func() {
+				BeforeEach(func() {
+					job.DependsOn = []string{"some-job", "some-job"}
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job depends on job 'some-job' more than once"))
+				})
+			})
+		})
+
+		Context("when a job depends on itself", func() {
+			BeforeEach(func() {
+				job.DependsOn = []string{"some-other-job"}
+				config.Jobs = append(config.Jobs, job)
+			})
+
+			It("returns an error", func() {
+				Expect(errorMessages).To(HaveLen(1))
+				Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+				Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job depends on itself"))
+			})
+		})
+
 		Context("when a job has duplicate inputs", func() {
 			BeforeEach(func() {
 				job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -995,6 +1322,29 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a task plan has invalid artifact paths", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.TaskStep{
+							Name:       "lol",
+							ConfigPath: "task.yml",
+							Artifacts:  []string{"binaries/app", "/etc/passwd", "binaries/../../secret", ""},
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("returns an error for each invalid path", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("invalid jobs:"))
+					Expect(errorMessages[0]).ToNot(ContainSubstring("artifacts[0]"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).artifacts[1]: must be relative to an output"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).artifacts[2]: must not contain '..'"))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].task(lol).artifacts[3]: must specify a path"))
+				})
+			})
+
 			Context("when a task plan is invalid", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -1637,6 +1987,26 @@ var _ = Describe("ValidateConfig", func() {
 				})
 			})
 
+			Context("when a plan has an invalid condition in a step", func() {
+				BeforeEach(func() {
+					job.PlanSequence = append(job.PlanSequence, atc.Step{
+						Config: &atc.IfStep{
+							Step: &atc.GetStep{
+								Name: "some-resource",
+							},
+							Condition: "((.:flag)) ==",
+						},
+					})
+
+					config.Jobs = append(config.Jobs, job)
+				})
+
+				It("throws a validation error", func() {
+					Expect(errorMessages).To(HaveLen(1))
+					Expect(errorMessages[0]).To(ContainSubstring("jobs.some-other-job.plan.do[0].if: invalid condition: unexpected end of condition"))
+				})
+			})
+
 			Context("when a retry plan has a negative attempts number", func() {
 				BeforeEach(func() {
 					job.PlanSequence = append(job.PlanSequence, atc.Step{
@@ -28,6 +28,23 @@ type FakeVarSourcePool struct {
 		result1 creds.Secrets
 		result2 error
 	}
+	FindOrCreateForPipelineStub        func(lager.Logger, map[string]interface{}, creds.ManagerFactory, string, string) (creds.Secrets, error)
+	findOrCreateForPipelineMutex       sync.RWMutex
+	findOrCreateForPipelineArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 map[string]interface{}
+		arg3 creds.ManagerFactory
+		arg4 string
+		arg5 string
+	}
+	findOrCreateForPipelineReturns struct {
+		result1 creds.Secrets
+		result2 error
+	}
+	findOrCreateForPipelineReturnsOnCall map[int]struct {
+		result1 creds.Secrets
+		result2 error
+	}
 	SizeStub        func() int
 	sizeMutex       sync.RWMutex
 	sizeArgsForCall []struct {
@@ -130,6 +147,73 @@ func (fake *FakeVarSourcePool) FindOrCreateReturnsOnCall(i int, result1 creds.Se
 	}{result1, result2}
 }
 
+func (fake *FakeVarSourcePool) FindOrCreateForPipeline(arg1 lager.Logger, arg2 map[string]interface{}, arg3 creds.ManagerFactory, arg4 string, arg5 string) (creds.Secrets, error) {
+	fake.findOrCreateForPipelineMutex.Lock()
+	ret, specificReturn := fake.findOrCreateForPipelineReturnsOnCall[len(fake.findOrCreateForPipelineArgsForCall)]
+	fake.findOrCreateForPipelineArgsForCall = append(fake.findOrCreateForPipelineArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 map[string]interface{}
+		arg3 creds.ManagerFactory
+		arg4 string
+		arg5 string
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("FindOrCreateForPipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.findOrCreateForPipelineMutex.Unlock()
+	if fake.FindOrCreateForPipelineStub != nil {
+		return fake.FindOrCreateForPipelineStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.findOrCreateForPipelineReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateForPipelineCallCount() int {
+	fake.findOrCreateForPipelineMutex.RLock()
+	defer fake.findOrCreateForPipelineMutex.RUnlock()
+	return len(fake.findOrCreateForPipelineArgsForCall)
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateForPipelineCalls(stub func(lager.Logger, map[string]interface{}, creds.ManagerFactory, string, string) (creds.Secrets, error)) {
+	fake.findOrCreateForPipelineMutex.Lock()
+	defer fake.findOrCreateForPipelineMutex.Unlock()
+	fake.FindOrCreateForPipelineStub = stub
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateForPipelineArgsForCall(i int) (lager.Logger, map[string]interface{}, creds.ManagerFactory, string, string) {
+	fake.findOrCreateForPipelineMutex.RLock()
+	defer fake.findOrCreateForPipelineMutex.RUnlock()
+	argsForCall := fake.findOrCreateForPipelineArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateForPipelineReturns(result1 creds.Secrets, result2 error) {
+	fake.findOrCreateForPipelineMutex.Lock()
+	defer fake.findOrCreateForPipelineMutex.Unlock()
+	fake.FindOrCreateForPipelineStub = nil
+	fake.findOrCreateForPipelineReturns = struct {
+		result1 creds.Secrets
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeVarSourcePool) FindOrCreateForPipelineReturnsOnCall(i int, result1 creds.Secrets, result2 error) {
+	fake.findOrCreateForPipelineMutex.Lock()
+	defer fake.findOrCreateForPipelineMutex.Unlock()
+	fake.FindOrCreateForPipelineStub = nil
+	if fake.findOrCreateForPipelineReturnsOnCall == nil {
+		fake.findOrCreateForPipelineReturnsOnCall = make(map[int]struct {
+			result1 creds.Secrets
+			result2 error
+		})
+	}
+	fake.findOrCreateForPipelineReturnsOnCall[i] = struct {
+		result1 creds.Secrets
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeVarSourcePool) Size() int {
 	fake.sizeMutex.Lock()
 	ret, specificReturn := fake.sizeReturnsOnCall[len(fake.sizeArgsForCall)]
@@ -189,6 +273,8 @@ func (fake *FakeVarSourcePool) Invocations() map[string][][]interface{} {
 	defer fake.closeMutex.RUnlock()
 	fake.findOrCreateMutex.RLock()
 	defer fake.findOrCreateMutex.RUnlock()
+	fake.findOrCreateForPipelineMutex.RLock()
+	defer fake.findOrCreateForPipelineMutex.RUnlock()
 	fake.sizeMutex.RLock()
 	defer fake.sizeMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
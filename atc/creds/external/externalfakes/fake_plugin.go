@@ -0,0 +1,118 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package externalfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/creds/external"
+)
+
+type FakePlugin struct {
+	CallStub        func(context.Context, external.Request) (external.Response, error)
+	callMutex       sync.RWMutex
+	callArgsForCall []struct {
+		arg1 context.Context
+		arg2 external.Request
+	}
+	callReturns struct {
+		result1 external.Response
+		result2 error
+	}
+	callReturnsOnCall map[int]struct {
+		result1 external.Response
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakePlugin) Call(arg1 context.Context, arg2 external.Request) (external.Response, error) {
+	fake.callMutex.Lock()
+	ret, specificReturn := fake.callReturnsOnCall[len(fake.callArgsForCall)]
+	fake.callArgsForCall = append(fake.callArgsForCall, struct {
+		arg1 context.Context
+		arg2 external.Request
+	}{arg1, arg2})
+	fake.recordInvocation("Call", []interface{}{arg1, arg2})
+	fake.callMutex.Unlock()
+	if fake.CallStub != nil {
+		return fake.CallStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.callReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePlugin) CallCallCount() int {
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	return len(fake.callArgsForCall)
+}
+
+func (fake *FakePlugin) CallCalls(stub func(context.Context, external.Request) (external.Response, error)) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = stub
+}
+
+func (fake *FakePlugin) CallArgsForCall(i int) (context.Context, external.Request) {
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	argsForCall := fake.callArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePlugin) CallReturns(result1 external.Response, result2 error) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = nil
+	fake.callReturns = struct {
+		result1 external.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlugin) CallReturnsOnCall(i int, result1 external.Response, result2 error) {
+	fake.callMutex.Lock()
+	defer fake.callMutex.Unlock()
+	fake.CallStub = nil
+	if fake.callReturnsOnCall == nil {
+		fake.callReturnsOnCall = make(map[int]struct {
+			result1 external.Response
+			result2 error
+		})
+	}
+	fake.callReturnsOnCall[i] = struct {
+		result1 external.Response
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePlugin) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.callMutex.RLock()
+	defer fake.callMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakePlugin) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ external.Plugin = new(FakePlugin)
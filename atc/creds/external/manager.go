@@ -0,0 +1,192 @@
+package external
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const DefaultPipelineSecretTemplate = "/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}"
+const DefaultTeamSecretTemplate = "/concourse/{{.Team}}/{{.Secret}}"
+const DefaultTimeout = 10 * time.Second
+
+type Manager struct {
+	Command                string            `mapstructure:"command" long:"command" description:"Path to an executable plugin to run for every secret lookup."`
+	Args                   []string          `mapstructure:"args" long:"arg" description:"Argument to pass to the executable plugin. Can be specified multiple times."`
+	URL                    string            `mapstructure:"url" long:"url" description:"URL of an HTTP plugin to send secret lookups to. Use unix:///path/to/socket for a plugin listening on a unix socket."`
+	Config                 map[string]string `mapstructure:"config" long:"config" value-name:"KEY:VALUE" description:"Plugin specific configuration, sent along with every request. Can be specified multiple times."`
+	Timeout                time.Duration     `mapstructure:"timeout" long:"timeout" default:"10s" description:"Time to wait for the plugin to answer a request."`
+	PipelineSecretTemplate string            `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" default:"/concourse/{{.Team}}/{{.Pipeline}}/{{.Secret}}" description:"Secret path template used for pipeline specific secrets."`
+	TeamSecretTemplate     string            `mapstructure:"team_secret_template" long:"team-secret-template" default:"/concourse/{{.Team}}/{{.Secret}}" description:"Secret path template used for team specific secrets."`
+
+	// varSource is set for managers configured through a pipeline's
+	// var_sources, whose requests are scoped to the team and pipeline.
+	varSource bool
+	team      string
+	pipeline  string
+
+	plugin Plugin
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"command":                  manager.Command,
+		"url":                      manager.URL,
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	if manager.Command != "" {
+		manager.plugin = NewExecPlugin(manager.Command, manager.Args)
+		return nil
+	}
+
+	if manager.varSource {
+		plugin, err := newVarSourceHTTPPlugin(manager.URL)
+		if err != nil {
+			log.Error("failed-to-create-plugin", err)
+			return err
+		}
+
+		manager.plugin = &scopedPlugin{
+			plugin:   plugin,
+			team:     manager.team,
+			pipeline: manager.pipeline,
+		}
+
+		return nil
+	}
+
+	plugin, err := NewHTTPPlugin(manager.URL)
+	if err != nil {
+		log.Error("failed-to-create-plugin", err)
+		return err
+	}
+
+	manager.plugin = plugin
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: OperationHealth,
+	}
+
+	if manager.plugin == nil {
+		health.Error = "plugin not initialized"
+		return health, nil
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), manager.Timeout)
+	defer cancel()
+
+	res, err := manager.plugin.Call(ctx, Request{
+		Version:   ProtocolVersion,
+		Operation: OperationHealth,
+		Config:    manager.Config,
+	})
+	if err != nil {
+		health.Error = err.Error()
+		return health, nil
+	}
+
+	if res.Value != nil {
+		health.Response = res.Value
+	} else {
+		health.Response = map[string]string{
+			"status": "UP",
+		}
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Command != "" || manager.URL != ""
+}
+
+func (manager *Manager) Validate() error {
+	if manager.Command != "" && manager.URL != "" {
+		return errors.New("must provide either a plugin command or url, not both")
+	}
+
+	if manager.Command == "" && manager.URL == "" {
+		return errors.New("must provide a plugin command or url")
+	}
+
+	if manager.URL != "" {
+		u, err := url.Parse(manager.URL)
+		if err != nil {
+			return fmt.Errorf("invalid plugin url: %w", err)
+		}
+
+		switch u.Scheme {
+		case "http", "https":
+			if u.Host == "" {
+				return fmt.Errorf("invalid plugin url '%s': missing host", manager.URL)
+			}
+		case "unix":
+			if u.Path == "" {
+				return fmt.Errorf("invalid plugin url '%s': missing socket path", manager.URL)
+			}
+		default:
+			return fmt.Errorf("invalid plugin url '%s': must be an http, https or unix url", manager.URL)
+		}
+	}
+
+	if manager.Timeout <= 0 {
+		return errors.New("plugin timeout must be positive")
+	}
+
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	if manager.plugin == nil {
+		return nil, errors.New("plugin not initialized")
+	}
+
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretsFactory(
+		log,
+		manager.plugin,
+		manager.Config,
+		manager.Timeout,
+		[]*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate},
+	), nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+}
@@ -0,0 +1,127 @@
+package external
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strings"
+
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
+)
+
+type managerFactory struct{}
+
+func init() {
+	creds.Register("external", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("External Plugin Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "external-creds"
+
+	return manager
+}
+
+// NewInstance configures the plugin for a pipeline var_source. Only HTTP
+// plugins are allowed there, as running executables of the pipeline's
+// choosing on the web node would hand it to anyone who can set a pipeline.
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &Manager{
+		Timeout:                DefaultTimeout,
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused:      true,
+		WeaklyTypedInput: true,
+		DecodeHook:       mapstructure.StringToTimeDurationHookFunc(),
+		Result:           &manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if manager.Command != "" || len(manager.Args) > 0 {
+		return nil, errors.New("plugin commands can only be configured cluster-wide; use a url instead")
+	}
+
+	// the templates are what confine a pipeline to its own team's secrets
+	if manager.TeamSecretTemplate != DefaultTeamSecretTemplate || manager.PipelineSecretTemplate != DefaultPipelineSecretTemplate {
+		return nil, errors.New("secret templates can only be configured cluster-wide")
+	}
+
+	err = checkVarSourceURL(manager.URL)
+	if err != nil {
+		return nil, err
+	}
+
+	manager.varSource = true
+
+	return manager, nil
+}
+
+// NewScopedInstance configures the plugin for a var_source of the given
+// pipeline. The team and pipeline are sent along with every request so that
+// the plugin can decide what they may look up.
+func (factory *managerFactory) NewScopedInstance(config interface{}, teamName string, pipelineName string) (creds.Manager, error) {
+	instance, err := factory.NewInstance(config)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := instance.(*Manager)
+	manager.team = teamName
+	manager.pipeline = pipelineName
+
+	return manager, nil
+}
+
+// checkVarSourceURL rejects plugin locations on the web node itself, which a
+// pipeline must not be able to reach.
+func checkVarSourceURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return fmt.Errorf("invalid plugin url: %w", err)
+	}
+
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("var_source plugins must be given an http or https url")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return fmt.Errorf("plugin url '%s' must not be a loopback address", rawURL)
+	}
+
+	if ip := net.ParseIP(host); ip != nil && !allowedVarSourceIP(ip) {
+		return fmt.Errorf("plugin url '%s' must not be a loopback or link-local address", rawURL)
+	}
+
+	return nil
+}
+
+func allowedVarSourceIP(ip net.IP) bool {
+	return !ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast() &&
+		!ip.IsLinkLocalMulticast() &&
+		!ip.IsUnspecified()
+}
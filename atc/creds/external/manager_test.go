@@ -0,0 +1,241 @@
+package external_test
+
+import (
+	"net"
+	"net/http"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/external"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+func nonLoopbackIP() net.IP {
+	addrs, err := net.InterfaceAddrs()
+	if err != nil {
+		return nil
+	}
+
+	for _, addr := range addrs {
+		if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil && !ipNet.IP.IsLoopback() && !ipNet.IP.IsLinkLocalUnicast() {
+			return ipNet.IP
+		}
+	}
+
+	return nil
+}
+
+var _ = Describe("Manager", func() {
+	var manager external.Manager
+
+	BeforeEach(func() {
+		manager = external.Manager{}
+		_, err := flags.ParseArgs(&manager, []string{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	Describe("IsConfigured()", func() {
+		It("is not configured by default", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+		})
+
+		It("is configured with a command", func() {
+			manager.Command = "/usr/local/bin/secrets-plugin"
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+
+		It("is configured with a url", func() {
+			manager.URL = "unix:///run/secrets.sock"
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		It("defaults the timeout and templates", func() {
+			Expect(manager.Timeout).To(Equal(external.DefaultTimeout))
+			Expect(manager.PipelineSecretTemplate).To(Equal(external.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(external.DefaultTeamSecretTemplate))
+		})
+
+		DescribeTable("accepts plugin locations",
+			func(command, url string) {
+				manager.Command = command
+				manager.URL = url
+				Expect(manager.Validate()).To(Succeed())
+			},
+			Entry("a command", "/usr/local/bin/secrets-plugin", ""),
+			Entry("an http url", "", "http://127.0.0.1:8200/secrets"),
+			Entry("an https url", "", "https://secrets.example.com"),
+			Entry("a unix socket", "", "unix:///run/secrets.sock"),
+		)
+
+		DescribeTable("rejects invalid plugin locations",
+			func(command, url, message string) {
+				manager.Command = command
+				manager.URL = url
+				Expect(manager.Validate()).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("neither", "", "", "must provide a plugin command or url"),
+			Entry("both", "/usr/local/bin/secrets-plugin", "https://secrets.example.com", "not both"),
+			Entry("an unsupported scheme", "", "ftp://secrets.example.com", "must be an http, https or unix url"),
+			Entry("an http url without a host", "", "http:///secrets", "missing host"),
+			Entry("a unix url without a path", "", "unix://", "missing socket path"),
+		)
+
+		It("rejects a non-positive timeout", func() {
+			manager.URL = "https://secrets.example.com"
+			manager.Timeout = 0
+			Expect(manager.Validate()).To(MatchError("plugin timeout must be positive"))
+		})
+
+		It("rejects invalid secret templates", func() {
+			manager.URL = "https://secrets.example.com"
+			manager.TeamSecretTemplate = "{{.Nope}}"
+			Expect(manager.Validate()).NotTo(Succeed())
+		})
+	})
+
+	Describe("NewInstance()", func() {
+		var factory creds.ManagerFactory
+
+		BeforeEach(func() {
+			factory = creds.ManagerFactories()["external"]
+			Expect(factory).NotTo(BeNil())
+		})
+
+		It("configures an http plugin with defaults", func() {
+			instance, err := factory.NewInstance(map[string]interface{}{
+				"url":     "https://secrets.example.com",
+				"timeout": "3s",
+				"config":  map[string]interface{}{"region": "eu", "replicas": 3},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Validate()).To(Succeed())
+
+			manager := instance.(*external.Manager)
+			Expect(manager.URL).To(Equal("https://secrets.example.com"))
+			Expect(manager.Timeout).To(Equal(3 * time.Second))
+			Expect(manager.Config).To(Equal(map[string]string{"region": "eu", "replicas": "3"}))
+			Expect(manager.PipelineSecretTemplate).To(Equal(external.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(external.DefaultTeamSecretTemplate))
+		})
+
+		It("refuses to run commands", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"command": "/bin/sh",
+				"args":    []interface{}{"-c", "env"},
+			})
+			Expect(err).To(MatchError(ContainSubstring("can only be configured cluster-wide")))
+		})
+
+		It("rejects unknown keys", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"url":  "https://secrets.example.com",
+				"nope": true,
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("refuses custom secret templates", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"url":                  "https://secrets.example.com",
+				"team_secret_template": "/concourse/other-team/{{.Secret}}",
+			})
+			Expect(err).To(MatchError("secret templates can only be configured cluster-wide"))
+		})
+
+		DescribeTable("refuses plugins on the web node",
+			func(url string) {
+				_, err := factory.NewInstance(map[string]interface{}{"url": url})
+				Expect(err).To(HaveOccurred())
+			},
+			Entry("a unix socket", "unix:///run/secrets.sock"),
+			Entry("localhost", "http://localhost:8200"),
+			Entry("a fully qualified localhost", "http://LOCALHOST.:8200"),
+			Entry("a loopback address", "http://127.0.0.1:8200"),
+			Entry("an ipv6 loopback address", "http://[::1]:8200"),
+			Entry("a link-local address", "http://169.254.169.254/latest"),
+			Entry("an unspecified address", "http://0.0.0.0:8200"),
+		)
+
+		Describe("NewScopedInstance()", func() {
+			var server *ghttp.Server
+
+			BeforeEach(func() {
+				server = ghttp.NewUnstartedServer()
+			})
+
+			AfterEach(func() {
+				server.Close()
+			})
+
+			It("sends the team and pipeline with every request", func() {
+				ip := nonLoopbackIP()
+				if ip == nil {
+					Skip("no non-loopback address to listen on")
+				}
+
+				listener, err := net.Listen("tcp", net.JoinHostPort(ip.String(), "0"))
+				Expect(err).NotTo(HaveOccurred())
+				server.HTTPTestServer.Listener = listener
+				server.Start()
+
+				server.AppendHandlers(ghttp.CombineHandlers(
+					ghttp.VerifyJSONRepresenting(external.Request{
+						Version:   external.ProtocolVersion,
+						Operation: external.OperationHealth,
+						Team:      "some-team",
+						Pipeline:  "some-pipeline",
+					}),
+					ghttp.RespondWith(http.StatusOK, `{}`),
+				))
+
+				instance, err := factory.(creds.ScopedManagerFactory).NewScopedInstance(map[string]interface{}{
+					"url": server.URL(),
+				}, "some-team", "some-pipeline")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(instance.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+
+				health, err := instance.Health()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(health.Error).To(BeEmpty())
+				Expect(server.ReceivedRequests()).To(HaveLen(1))
+			})
+		})
+	})
+
+	Describe("Health()", func() {
+		It("reports that the plugin is not initialized", func() {
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Error).To(Equal("plugin not initialized"))
+		})
+
+		It("asks the plugin about its health", func() {
+			manager.Command = "/bin/sh"
+			manager.Args = []string{"-c", `echo '{"value":{"vault":"sealed"}}'`}
+			Expect(manager.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Method).To(Equal("health"))
+			Expect(health.Error).To(BeEmpty())
+			Expect(health.Response).To(Equal(map[string]interface{}{"vault": "sealed"}))
+		})
+
+		It("reports plugin failures as unhealthy", func() {
+			manager.Command = "/bin/sh"
+			manager.Args = []string{"-c", `echo '{"error":"sealed"}'`}
+			Expect(manager.Init(lagertest.NewTestLogger("test"))).To(Succeed())
+
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Error).To(Equal("sealed"))
+		})
+	})
+})
@@ -0,0 +1,290 @@
+// Package external implements a credential manager which delegates secret
+// lookups to a plugin running outside of Concourse, so that secret stores
+// without a built-in credential manager can be supported without forking.
+//
+// A plugin is either an executable or an HTTP server, optionally listening on
+// a unix socket. Both speak the same JSON protocol: for every operation the
+// ATC sends a Request and expects a Response in return.
+//
+// Executables are run once per operation with the request written to their
+// stdin. They must write the response to stdout and exit 0; a non-zero exit
+// status is treated as an error and whatever was written to stderr is
+// included in it.
+//
+// HTTP plugins receive the request as the body of a POST to the configured
+// URL (e.g. https://secrets.example.com/concourse or unix:///run/plugin.sock)
+// and must reply with a 200 and the response as the body.
+//
+// The operations are:
+//
+//	get:    look up the secret at Request.Path. A missing secret is reported
+//	        with "found": false rather than an error.
+//	health: report the health of the plugin. Any value returned is shown as
+//	        the health response of the credential manager.
+//
+// Requests made for a pipeline's var_source also carry the team and pipeline
+// which configured it, so that the plugin can decide what they may look up.
+// They are absent for the cluster-wide credential manager.
+//
+// For example, looking up ((password)) in pipeline 'pipe' of team 'main':
+//
+//	{"version":"1","operation":"get","path":"/concourse/main/pipe/password","config":{"region":"eu"}}
+//
+// or, through a var_source of that pipeline:
+//
+//	{"version":"1","operation":"get","path":"/concourse/main/pipe/password","team":"main","pipeline":"pipe"}
+//
+//	{"found":true,"value":"hunter2","expires_at":"2021-01-01T00:00:00Z"}
+//
+// Plugins report failures by setting "error" in the response.
+package external
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os/exec"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ProtocolVersion is sent with every request so that plugins can detect
+// incompatible changes to the protocol.
+const ProtocolVersion = "1"
+
+const (
+	OperationGet    = "get"
+	OperationHealth = "health"
+)
+
+// Request is sent to the plugin for every operation.
+type Request struct {
+	Version   string            `json:"version"`
+	Operation string            `json:"operation"`
+	Path      string            `json:"path,omitempty"`
+	Config    map[string]string `json:"config,omitempty"`
+	Team      string            `json:"team,omitempty"`
+	Pipeline  string            `json:"pipeline,omitempty"`
+}
+
+// Response is expected back from the plugin for every operation.
+type Response struct {
+	Found     bool        `json:"found"`
+	Value     interface{} `json:"value,omitempty"`
+	ExpiresAt *time.Time  `json:"expires_at,omitempty"`
+	Error     string      `json:"error,omitempty"`
+}
+
+//go:generate counterfeiter . Plugin
+
+// Plugin carries a request to an external plugin and returns its response.
+type Plugin interface {
+	Call(context.Context, Request) (Response, error)
+}
+
+// maxResponseSize bounds how much of a plugin's output is read, so that a
+// misbehaving plugin cannot exhaust the memory of the web node.
+const maxResponseSize = 10 << 20
+
+type execPlugin struct {
+	path string
+	args []string
+}
+
+// NewExecPlugin returns a Plugin which runs the executable at path with the
+// given arguments for every request.
+func NewExecPlugin(path string, args []string) Plugin {
+	return &execPlugin{
+		path: path,
+		args: args,
+	}
+}
+
+func (plugin *execPlugin) Call(ctx context.Context, req Request) (Response, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	stdout := new(bytes.Buffer)
+	stderr := new(bytes.Buffer)
+
+	cmd := exec.CommandContext(ctx, plugin.path, plugin.args...)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &limitedWriter{w: stdout, n: maxResponseSize}
+	cmd.Stderr = &limitedWriter{w: stderr, n: 4096}
+
+	err = cmd.Run()
+	if err != nil {
+		if ctx.Err() != nil {
+			return Response{}, fmt.Errorf("plugin %s timed out: %w", plugin.path, ctx.Err())
+		}
+
+		msg := strings.TrimSpace(stderr.String())
+		if msg == "" {
+			return Response{}, fmt.Errorf("plugin %s failed: %w", plugin.path, err)
+		}
+
+		return Response{}, fmt.Errorf("plugin %s failed: %w: %s", plugin.path, err, msg)
+	}
+
+	return decodeResponse(stdout)
+}
+
+type httpPlugin struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPPlugin returns a Plugin which POSTs every request to the given
+// http(s) URL, or to the root of the HTTP server listening on the socket of a
+// unix:// URL.
+func NewHTTPPlugin(rawURL string) (Plugin, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, err
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return &httpPlugin{
+			url:    rawURL,
+			client: &http.Client{Transport: http.DefaultTransport},
+		}, nil
+
+	case "unix":
+		socket := u.Path
+		dialer := &net.Dialer{}
+
+		return &httpPlugin{
+			url: "http://unix/",
+			client: &http.Client{
+				Transport: &http.Transport{
+					DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+						return dialer.DialContext(ctx, "unix", socket)
+					},
+				},
+			},
+		}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported plugin url scheme '%s'", u.Scheme)
+	}
+}
+
+// newVarSourceHTTPPlugin returns a Plugin for a pipeline's var_source, which
+// refuses to connect to loopback or link-local addresses however the URL's
+// host resolves.
+func newVarSourceHTTPPlugin(rawURL string) (Plugin, error) {
+	dialer := &net.Dialer{
+		Control: func(_ string, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+
+			ip := net.ParseIP(host)
+			if ip == nil || !allowedVarSourceIP(ip) {
+				return fmt.Errorf("plugin address %s is not allowed", host)
+			}
+
+			return nil
+		},
+	}
+
+	return &httpPlugin{
+		url: rawURL,
+		client: &http.Client{
+			Transport: &http.Transport{
+				DialContext: dialer.DialContext,
+			},
+		},
+	}, nil
+}
+
+func (plugin *httpPlugin) Call(ctx context.Context, req Request) (Response, error) {
+	payload, err := json.Marshal(req)
+	if err != nil {
+		return Response{}, err
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, plugin.url, bytes.NewReader(payload))
+	if err != nil {
+		return Response{}, err
+	}
+
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	res, err := plugin.client.Do(httpReq)
+	if err != nil {
+		return Response{}, err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+		return Response{}, fmt.Errorf("unexpected response from plugin: %s: %s", res.Status, bytes.TrimSpace(body))
+	}
+
+	return decodeResponse(io.LimitReader(res.Body, maxResponseSize))
+}
+
+// scopedPlugin adds the team and pipeline of a var_source to its requests.
+type scopedPlugin struct {
+	plugin   Plugin
+	team     string
+	pipeline string
+}
+
+func (plugin *scopedPlugin) Call(ctx context.Context, req Request) (Response, error) {
+	req.Team = plugin.team
+	req.Pipeline = plugin.pipeline
+	return plugin.plugin.Call(ctx, req)
+}
+
+func decodeResponse(r io.Reader) (Response, error) {
+	var res Response
+	err := json.NewDecoder(r).Decode(&res)
+	if err != nil {
+		return Response{}, fmt.Errorf("invalid plugin response: %w", err)
+	}
+
+	if res.Error != "" {
+		return Response{}, errors.New(res.Error)
+	}
+
+	return res, nil
+}
+
+type limitedWriter struct {
+	w io.Writer
+	n int
+}
+
+// Write discards anything past the limit rather than failing, so that a
+// chatty plugin is not killed by a broken pipe.
+func (lw *limitedWriter) Write(p []byte) (int, error) {
+	written := len(p)
+	if len(p) > lw.n {
+		p = p[:lw.n]
+	}
+
+	if len(p) > 0 {
+		n, err := lw.w.Write(p)
+		lw.n -= n
+		if err != nil {
+			return n, err
+		}
+	}
+
+	return written, nil
+}
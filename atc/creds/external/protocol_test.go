@@ -0,0 +1,199 @@
+package external_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/concourse/atc/creds/external"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Plugins", func() {
+	var request external.Request
+
+	BeforeEach(func() {
+		request = external.Request{
+			Version:   external.ProtocolVersion,
+			Operation: external.OperationGet,
+			Path:      "/concourse/main/some-secret",
+			Config:    map[string]string{"region": "eu"},
+		}
+	})
+
+	Describe("exec plugins", func() {
+		var (
+			dir    string
+			script string
+		)
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "external-plugin")
+			Expect(err).NotTo(HaveOccurred())
+
+			script = filepath.Join(dir, "plugin")
+		})
+
+		AfterEach(func() {
+			os.RemoveAll(dir)
+		})
+
+		writePlugin := func(body string) {
+			err := ioutil.WriteFile(script, []byte("#!/bin/sh\n"+body), 0755)
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("writes the request to stdin and reads the response from stdout", func() {
+			writePlugin(`cat > "$1"; echo '{"found":true,"value":"s3cr3t","expires_at":"2021-01-01T00:00:00Z"}'`)
+
+			requestFile := filepath.Join(dir, "request.json")
+			plugin := external.NewExecPlugin(script, []string{requestFile})
+
+			res, err := plugin.Call(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Found).To(BeTrue())
+			Expect(res.Value).To(Equal("s3cr3t"))
+			Expect(*res.ExpiresAt).To(BeTemporally("==", time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+			payload, err := ioutil.ReadFile(requestFile)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(payload).To(MatchJSON(`{
+				"version": "1",
+				"operation": "get",
+				"path": "/concourse/main/some-secret",
+				"config": {"region": "eu"}
+			}`))
+		})
+
+		It("returns errors reported by the plugin", func() {
+			writePlugin(`echo '{"error":"permission denied"}'`)
+
+			_, err := external.NewExecPlugin(script, nil).Call(context.Background(), request)
+			Expect(err).To(MatchError("permission denied"))
+		})
+
+		It("includes stderr when the plugin exits non-zero", func() {
+			writePlugin(`echo 'backend unavailable' >&2; exit 3`)
+
+			_, err := external.NewExecPlugin(script, nil).Call(context.Background(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("exit status 3"))
+			Expect(err.Error()).To(ContainSubstring("backend unavailable"))
+		})
+
+		It("fails on malformed responses", func() {
+			writePlugin(`echo 'not json'`)
+
+			_, err := external.NewExecPlugin(script, nil).Call(context.Background(), request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("invalid plugin response"))
+		})
+
+		It("kills the plugin once the context expires", func() {
+			writePlugin(`exec sleep 10`)
+
+			ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+			defer cancel()
+
+			_, err := external.NewExecPlugin(script, nil).Call(ctx, request)
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("timed out"))
+		})
+	})
+
+	Describe("http plugins", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("posts the request and reads the response", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/secrets"),
+				ghttp.VerifyContentType("application/json"),
+				ghttp.VerifyJSONRepresenting(request),
+				ghttp.RespondWith(http.StatusOK, `{"found":true,"value":{"username":"admin"}}`),
+			))
+
+			plugin, err := external.NewHTTPPlugin(server.URL() + "/secrets")
+			Expect(err).NotTo(HaveOccurred())
+
+			res, err := plugin.Call(context.Background(), request)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(res.Found).To(BeTrue())
+			Expect(res.Value).To(Equal(map[string]interface{}{"username": "admin"}))
+			Expect(res.ExpiresAt).To(BeNil())
+		})
+
+		It("fails on unexpected statuses", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusInternalServerError, "oops"))
+
+			plugin, err := external.NewHTTPPlugin(server.URL())
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = plugin.Call(context.Background(), request)
+			Expect(err).To(MatchError(ContainSubstring("500 Internal Server Error: oops")))
+		})
+
+		It("rejects unsupported schemes", func() {
+			_, err := external.NewHTTPPlugin("ftp://example.com")
+			Expect(err).To(MatchError("unsupported plugin url scheme 'ftp'"))
+		})
+
+		Context("when listening on a unix socket", func() {
+			var (
+				dir      string
+				listener net.Listener
+				received chan external.Request
+			)
+
+			BeforeEach(func() {
+				var err error
+				dir, err = ioutil.TempDir("", "external-plugin")
+				Expect(err).NotTo(HaveOccurred())
+
+				listener, err = net.Listen("unix", filepath.Join(dir, "plugin.sock"))
+				Expect(err).NotTo(HaveOccurred())
+
+				received = make(chan external.Request, 1)
+
+				go http.Serve(listener, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					var req external.Request
+					json.NewDecoder(r.Body).Decode(&req)
+					received <- req
+
+					w.Write([]byte(`{"found":false}`))
+				}))
+			})
+
+			AfterEach(func() {
+				listener.Close()
+				os.RemoveAll(dir)
+			})
+
+			It("talks to the plugin over the socket", func() {
+				plugin, err := external.NewHTTPPlugin("unix://" + filepath.Join(dir, "plugin.sock"))
+				Expect(err).NotTo(HaveOccurred())
+
+				res, err := plugin.Call(context.Background(), request)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(res.Found).To(BeFalse())
+
+				Expect(<-received).To(Equal(request))
+			})
+		})
+	})
+})
@@ -0,0 +1,85 @@
+package external
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type Secrets struct {
+	log             lager.Logger
+	plugin          Plugin
+	config          map[string]string
+	timeout         time.Duration
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewSecrets(log lager.Logger, plugin Plugin, config map[string]string, timeout time.Duration, secretTemplates []*creds.SecretTemplate) *Secrets {
+	return &Secrets{
+		log:             log,
+		plugin:          plugin,
+		config:          config,
+		timeout:         timeout,
+		secretTemplates: secretTemplates,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (s *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range s.secretTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, lPath)
+		}
+	}
+	return lookupPaths
+}
+
+// Get retrieves the value and expiration of an individual secret
+func (s *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), s.timeout)
+	defer cancel()
+
+	res, err := s.plugin.Call(ctx, Request{
+		Version:   ProtocolVersion,
+		Operation: OperationGet,
+		Path:      secretPath,
+		Config:    s.config,
+	})
+	if err != nil {
+		s.log.Error("failed-to-fetch-secret", err, lager.Data{
+			"secret-path": secretPath,
+		})
+		return nil, nil, false, err
+	}
+
+	if !res.Found {
+		return nil, nil, false, nil
+	}
+
+	return res.Value, res.ExpiresAt, true, nil
+}
+
+type secretsFactory struct {
+	log             lager.Logger
+	plugin          Plugin
+	config          map[string]string
+	timeout         time.Duration
+	secretTemplates []*creds.SecretTemplate
+}
+
+func NewSecretsFactory(log lager.Logger, plugin Plugin, config map[string]string, timeout time.Duration, secretTemplates []*creds.SecretTemplate) creds.SecretsFactory {
+	return &secretsFactory{
+		log:             log,
+		plugin:          plugin,
+		config:          config,
+		timeout:         timeout,
+		secretTemplates: secretTemplates,
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return NewSecrets(factory.log, factory.plugin, factory.config, factory.timeout, factory.secretTemplates)
+}
@@ -0,0 +1,83 @@
+package external_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/external"
+	"github.com/concourse/concourse/atc/creds/external/externalfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Secrets", func() {
+	var (
+		fakePlugin *externalfakes.FakePlugin
+		variables  vars.Variables
+	)
+
+	BeforeEach(func() {
+		fakePlugin = new(externalfakes.FakePlugin)
+
+		pipelineTemplate, err := creds.BuildSecretTemplate("pipeline", external.DefaultPipelineSecretTemplate)
+		Expect(err).NotTo(HaveOccurred())
+
+		teamTemplate, err := creds.BuildSecretTemplate("team", external.DefaultTeamSecretTemplate)
+		Expect(err).NotTo(HaveOccurred())
+
+		factory := external.NewSecretsFactory(
+			lagertest.NewTestLogger("test"),
+			fakePlugin,
+			map[string]string{"region": "eu"},
+			time.Second,
+			[]*creds.SecretTemplate{pipelineTemplate, teamTemplate},
+		)
+
+		variables = creds.NewVariables(factory.NewSecrets(), "some-team", "some-pipeline", false)
+	})
+
+	It("looks up pipeline secrets before team secrets", func() {
+		fakePlugin.CallStub = func(ctx context.Context, req external.Request) (external.Response, error) {
+			if req.Path == "/concourse/some-team/some-secret" {
+				return external.Response{Found: true, Value: "team-value"}, nil
+			}
+			return external.Response{}, nil
+		}
+
+		value, found, err := variables.Get(vars.Reference{Path: "some-secret"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeTrue())
+		Expect(value).To(Equal("team-value"))
+
+		Expect(fakePlugin.CallCallCount()).To(Equal(2))
+
+		ctx, req := fakePlugin.CallArgsForCall(0)
+		_, hasDeadline := ctx.Deadline()
+		Expect(hasDeadline).To(BeTrue())
+		Expect(req).To(Equal(external.Request{
+			Version:   external.ProtocolVersion,
+			Operation: external.OperationGet,
+			Path:      "/concourse/some-team/some-pipeline/some-secret",
+			Config:    map[string]string{"region": "eu"},
+		}))
+	})
+
+	It("reports missing secrets as not found", func() {
+		fakePlugin.CallReturns(external.Response{Found: false}, nil)
+
+		_, found, err := variables.Get(vars.Reference{Path: "some-secret"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	It("returns plugin errors", func() {
+		fakePlugin.CallReturns(external.Response{}, errors.New("sealed"))
+
+		_, _, err := variables.Get(vars.Reference{Path: "some-secret"})
+		Expect(err).To(MatchError(ContainSubstring("sealed")))
+	})
+})
@@ -0,0 +1,209 @@
+package file
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"code.cloudfoundry.org/lager"
+	"filippo.io/age"
+	"github.com/concourse/concourse/atc/creds"
+)
+
+const DefaultPipelineSecretTemplate = "/{{.Team}}/{{.Pipeline}}/{{.Secret}}"
+const DefaultTeamSecretTemplate = "/{{.Team}}/{{.Secret}}"
+
+type Manager struct {
+	Dir                    string `mapstructure:"dir" long:"dir" description:"Directory of YAML files to read secrets from. Files ending in .age and SOPS encrypted files are decrypted with the age identities."`
+	AgeIdentityFile        string `mapstructure:"-" long:"age-identity-file" description:"File holding the age identities to decrypt secret files with."`
+	VarSourcesDir          string `mapstructure:"-" long:"var-sources-dir" description:"Directory under which the dir of a pipeline's var_sources of type file is resolved, within a subdirectory named after the pipeline's team. Pipelines cannot use file var_sources unless this is set."`
+	SharedPath             string `mapstructure:"shared_path" long:"shared-path" description:"Path under which to lookup shared credentials."`
+	PipelineSecretTemplate string `mapstructure:"pipeline_secret_template" long:"pipeline-secret-template" default:"/{{.Team}}/{{.Pipeline}}/{{.Secret}}" description:"Secret path template used for pipeline specific secrets."`
+	TeamSecretTemplate     string `mapstructure:"team_secret_template" long:"team-secret-template" default:"/{{.Team}}/{{.Secret}}" description:"Secret path template used for team specific secrets."`
+
+	// varSource is set for managers configured through a pipeline's
+	// var_sources, whose dir is relative to the team's dir within
+	// VarSourcesDir.
+	varSource bool
+	team      string
+
+	store *Store
+}
+
+func (manager *Manager) MarshalJSON() ([]byte, error) {
+	health, err := manager.Health()
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(&map[string]interface{}{
+		"dir":                      manager.Dir,
+		"shared_path":              manager.SharedPath,
+		"pipeline_secret_template": manager.PipelineSecretTemplate,
+		"team_secret_template":     manager.TeamSecretTemplate,
+		"health":                   health,
+	})
+}
+
+func (manager *Manager) Init(log lager.Logger) error {
+	dir := manager.Dir
+	if manager.varSource {
+		var err error
+		dir, err = manager.varSourceDir()
+		if err != nil {
+			return err
+		}
+	}
+
+	var identities []age.Identity
+	if manager.AgeIdentityFile != "" {
+		file, err := os.Open(manager.AgeIdentityFile)
+		if err != nil {
+			log.Error("failed-to-open-age-identity-file", err)
+			return err
+		}
+
+		defer file.Close()
+
+		identities, err = age.ParseIdentities(file)
+		if err != nil {
+			log.Error("failed-to-parse-age-identity-file", err)
+			return fmt.Errorf("invalid age identity file: %w", err)
+		}
+	}
+
+	store := NewStore(log.Session("store", lager.Data{"dir": dir}), dir, identities)
+
+	err := store.Load()
+	if err != nil {
+		log.Error("failed-to-load-secrets", err)
+		return err
+	}
+
+	err = store.Watch()
+	if err != nil {
+		log.Error("failed-to-watch-secrets", err)
+		return err
+	}
+
+	manager.store = store
+
+	return nil
+}
+
+func (manager *Manager) Health() (*creds.HealthResponse, error) {
+	health := &creds.HealthResponse{
+		Method: "load",
+	}
+
+	if manager.store == nil {
+		health.Error = "secrets not loaded"
+		return health, nil
+	}
+
+	files, loadedAt, err := manager.store.Status()
+	if err != nil {
+		health.Error = err.Error()
+	}
+
+	health.Response = map[string]interface{}{
+		"files":     files,
+		"loaded_at": loadedAt.Unix(),
+	}
+
+	return health, nil
+}
+
+func (manager *Manager) IsConfigured() bool {
+	return manager.Dir != ""
+}
+
+func (manager *Manager) Validate() error {
+	if manager.Dir == "" {
+		return errors.New("must provide a secrets dir")
+	}
+
+	if manager.varSource {
+		clean := filepath.Clean(manager.Dir)
+		if filepath.IsAbs(clean) || clean == "." || !within(".", clean) {
+			return fmt.Errorf("dir '%s' must be a relative path within the team's var sources dir", manager.Dir)
+		}
+	}
+
+	if _, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate); err != nil {
+		return err
+	}
+
+	if _, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// varSourceDir resolves the dir of a var_source within its team's dir, so
+// that a pipeline can only ever read its own team's secrets.
+func (manager *Manager) varSourceDir() (string, error) {
+	if manager.VarSourcesDir == "" {
+		return "", errors.New("file var_sources are not enabled on this cluster")
+	}
+
+	if manager.team == "" {
+		return "", errors.New("file var_sources must belong to a team")
+	}
+
+	teamDir := filepath.Join(manager.VarSourcesDir, manager.team)
+	if !within(manager.VarSourcesDir, teamDir) || teamDir == filepath.Clean(manager.VarSourcesDir) {
+		return "", fmt.Errorf("team '%s' has no dir within the var sources dir", manager.team)
+	}
+
+	dir := filepath.Join(teamDir, manager.Dir)
+	if filepath.IsAbs(manager.Dir) || dir == teamDir || !within(teamDir, dir) {
+		return "", fmt.Errorf("dir '%s' must be a relative path within the team's var sources dir", manager.Dir)
+	}
+
+	return dir, nil
+}
+
+// within reports whether path is root or lies below it, once both are
+// cleaned.
+func within(root string, path string) bool {
+	rel, err := filepath.Rel(root, path)
+	if err != nil {
+		return false
+	}
+
+	return rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+func (manager *Manager) NewSecretsFactory(log lager.Logger) (creds.SecretsFactory, error) {
+	if manager.store == nil {
+		return nil, errors.New("secrets not loaded")
+	}
+
+	pipelineSecretTemplate, err := creds.BuildSecretTemplate("pipeline-secret-template", manager.PipelineSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	teamSecretTemplate, err := creds.BuildSecretTemplate("team-secret-template", manager.TeamSecretTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSecretsFactory(
+		manager.store,
+		[]*creds.SecretTemplate{pipelineSecretTemplate, teamSecretTemplate},
+		manager.SharedPath,
+	), nil
+}
+
+func (manager *Manager) Close(logger lager.Logger) {
+	if manager.store != nil {
+		manager.store.Close()
+		manager.store = nil
+	}
+}
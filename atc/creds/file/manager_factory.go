@@ -0,0 +1,78 @@
+package file
+
+import (
+	"github.com/concourse/concourse/atc/creds"
+	flags "github.com/jessevdk/go-flags"
+	"github.com/mitchellh/mapstructure"
+)
+
+type managerFactory struct {
+	// cluster is the cluster-wide manager, whose var sources dir and age
+	// identities are shared with the managers of pipeline var_sources.
+	cluster *Manager
+}
+
+func init() {
+	creds.Register("file", NewManagerFactory())
+}
+
+func NewManagerFactory() creds.ManagerFactory {
+	return &managerFactory{}
+}
+
+func (factory *managerFactory) AddConfig(group *flags.Group) creds.Manager {
+	manager := &Manager{}
+
+	subGroup, err := group.AddGroup("File Credential Management", "", manager)
+	if err != nil {
+		panic(err)
+	}
+
+	subGroup.Namespace = "file-creds"
+
+	factory.cluster = manager
+
+	return manager
+}
+
+func (factory *managerFactory) NewInstance(config interface{}) (creds.Manager, error) {
+	manager := &Manager{
+		TeamSecretTemplate:     DefaultTeamSecretTemplate,
+		PipelineSecretTemplate: DefaultPipelineSecretTemplate,
+		varSource:              true,
+	}
+
+	decoder, err := mapstructure.NewDecoder(&mapstructure.DecoderConfig{
+		ErrorUnused: true,
+		Result:      &manager,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	err = decoder.Decode(config)
+	if err != nil {
+		return nil, err
+	}
+
+	if factory.cluster != nil {
+		manager.VarSourcesDir = factory.cluster.VarSourcesDir
+		manager.AgeIdentityFile = factory.cluster.AgeIdentityFile
+	}
+
+	return manager, nil
+}
+
+// NewScopedInstance configures a var_source of the given team's pipeline,
+// whose dir is resolved within the team's own dir in the var sources dir.
+func (factory *managerFactory) NewScopedInstance(config interface{}, teamName string, pipelineName string) (creds.Manager, error) {
+	instance, err := factory.NewInstance(config)
+	if err != nil {
+		return nil, err
+	}
+
+	manager := instance.(*Manager)
+	manager.team = teamName
+
+	return manager, nil
+}
@@ -0,0 +1,196 @@
+package file_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/creds"
+	"github.com/concourse/concourse/atc/creds/file"
+	"github.com/concourse/concourse/vars"
+	flags "github.com/jessevdk/go-flags"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Manager", func() {
+	var (
+		dir     string
+		manager *file.Manager
+		logger  *lagertest.TestLogger
+	)
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "file-creds")
+		Expect(err).NotTo(HaveOccurred())
+
+		logger = lagertest.NewTestLogger("test")
+
+		manager = &file.Manager{}
+		_, err = flags.ParseArgs(manager, []string{})
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		manager.Close(logger)
+		os.RemoveAll(dir)
+	})
+
+	writeFile := func(name string, content string) {
+		p := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(p), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(p, []byte(content), 0644)).To(Succeed())
+	}
+
+	Describe("IsConfigured()", func() {
+		It("is configured once a dir is set", func() {
+			Expect(manager.IsConfigured()).To(BeFalse())
+
+			manager.Dir = dir
+			Expect(manager.IsConfigured()).To(BeTrue())
+		})
+	})
+
+	Describe("Validate()", func() {
+		It("requires a dir", func() {
+			Expect(manager.Validate()).To(MatchError("must provide a secrets dir"))
+		})
+
+		It("passes with the default templates", func() {
+			manager.Dir = dir
+			Expect(manager.PipelineSecretTemplate).To(Equal(file.DefaultPipelineSecretTemplate))
+			Expect(manager.TeamSecretTemplate).To(Equal(file.DefaultTeamSecretTemplate))
+			Expect(manager.Validate()).To(Succeed())
+		})
+
+		It("rejects invalid templates", func() {
+			manager.Dir = dir
+			manager.PipelineSecretTemplate = "{{.Nope}}"
+			Expect(manager.Validate()).NotTo(Succeed())
+		})
+	})
+
+	Describe("secrets", func() {
+		var variables vars.Variables
+
+		BeforeEach(func() {
+			writeFile("main/some-pipeline.yml", "password: pipeline-password\n")
+			writeFile("main.yml", "password: team-password\ntoken: team-token\n")
+			writeFile("shared.yml", "url: https://example.com\n")
+
+			manager.Dir = dir
+			manager.SharedPath = "shared"
+		})
+
+		JustBeforeEach(func() {
+			Expect(manager.Validate()).To(Succeed())
+			Expect(manager.Init(logger)).To(Succeed())
+
+			factory, err := manager.NewSecretsFactory(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			variables = creds.NewVariables(factory.NewSecrets(), "main", "some-pipeline", false)
+		})
+
+		expectSecret := func(name string, expected interface{}) {
+			value, found, err := variables.Get(vars.Reference{Path: name})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(expected))
+		}
+
+		It("looks up pipeline, team and shared secrets in turn", func() {
+			expectSecret("password", "pipeline-password")
+			expectSecret("token", "team-token")
+			expectSecret("url", "https://example.com")
+
+			_, found, err := variables.Get(vars.Reference{Path: "missing"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		It("reports its health", func() {
+			health, err := manager.Health()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(health.Error).To(BeEmpty())
+			Expect(health.Response).To(HaveKeyWithValue("files", 3))
+		})
+	})
+
+	Describe("as a var source", func() {
+		var (
+			factory creds.ManagerFactory
+			cluster *file.Manager
+		)
+
+		BeforeEach(func() {
+			factory = file.NewManagerFactory()
+
+			parser := flags.NewParser(nil, flags.Default)
+			cluster = factory.AddConfig(parser.Command.Group).(*file.Manager)
+			cluster.VarSourcesDir = dir
+
+			writeFile("main/team-secrets/main/some-pipeline.yml", "password: var-source-password\n")
+			writeFile("other-team/team-secrets/other-team/some-pipeline.yml", "password: other-team-password\n")
+		})
+
+		newInstance := func(config map[string]interface{}) creds.Manager {
+			instance, err := factory.(creds.ScopedManagerFactory).NewScopedInstance(config, "main", "some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			return instance
+		}
+
+		It("reads secrets from a dir within the team's dir in the var sources dir", func() {
+			instance := newInstance(map[string]interface{}{"dir": "team-secrets"})
+			defer instance.Close(logger)
+
+			Expect(instance.Validate()).To(Succeed())
+			Expect(instance.Init(logger)).To(Succeed())
+
+			factory, err := instance.NewSecretsFactory(logger)
+			Expect(err).NotTo(HaveOccurred())
+
+			value, _, found, err := factory.NewSecrets().Get("/main/some-pipeline/password")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("var-source-password"))
+		})
+
+		It("does not allow escaping the team's dir", func() {
+			for _, escape := range []string{"/etc", ".", "..", "../other-team", "team-secrets/../../other-team", "./"} {
+				instance := newInstance(map[string]interface{}{"dir": escape})
+				Expect(instance.Validate()).To(MatchError(ContainSubstring("must be a relative path")), escape)
+				Expect(instance.Init(logger)).To(MatchError(ContainSubstring("must be a relative path")), escape)
+			}
+		})
+
+		It("does not allow a team to escape the var sources dir", func() {
+			instance, err := factory.(creds.ScopedManagerFactory).NewScopedInstance(map[string]interface{}{"dir": "team-secrets"}, "..", "some-pipeline")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Init(logger)).To(MatchError(ContainSubstring("has no dir within the var sources dir")))
+		})
+
+		It("cannot be loaded without a team", func() {
+			instance, err := factory.NewInstance(map[string]interface{}{"dir": "team-secrets"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(instance.Init(logger)).To(MatchError("file var_sources must belong to a team"))
+		})
+
+		It("does not allow choosing the age identity file", func() {
+			_, err := factory.NewInstance(map[string]interface{}{
+				"dir":               "team-secrets",
+				"age_identity_file": "/etc/shadow",
+			})
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("is disabled unless the var sources dir is configured", func() {
+			cluster.VarSourcesDir = ""
+
+			instance := newInstance(map[string]interface{}{"dir": "team-secrets"})
+			Expect(instance.Init(logger)).To(MatchError("file var_sources are not enabled on this cluster"))
+		})
+	})
+})
@@ -0,0 +1,67 @@
+package file
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/creds"
+)
+
+type Secrets struct {
+	store           *Store
+	secretTemplates []*creds.SecretTemplate
+	sharedPath      string
+}
+
+func NewSecrets(store *Store, secretTemplates []*creds.SecretTemplate, sharedPath string) *Secrets {
+	return &Secrets{
+		store:           store,
+		secretTemplates: secretTemplates,
+		sharedPath:      sharedPath,
+	}
+}
+
+// NewSecretLookupPaths defines how variables will be searched in the underlying secret manager
+func (s *Secrets) NewSecretLookupPaths(teamName string, pipelineName string, allowRootPath bool) []creds.SecretLookupPath {
+	lookupPaths := []creds.SecretLookupPath{}
+	for _, tmpl := range s.secretTemplates {
+		if lPath := creds.NewSecretLookupWithTemplate(tmpl, teamName, pipelineName); lPath != nil {
+			lookupPaths = append(lookupPaths, lPath)
+		}
+	}
+	if s.sharedPath != "" {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix("/"+s.sharedPath+"/"))
+	}
+	if allowRootPath {
+		lookupPaths = append(lookupPaths, creds.NewSecretLookupWithPrefix("/"))
+	}
+	return lookupPaths
+}
+
+// Get retrieves the value of an individual secret. Secrets read from files
+// never expire; changes to the files are picked up by reloading them.
+func (s *Secrets) Get(secretPath string) (interface{}, *time.Time, bool, error) {
+	value, found := s.store.Get(secretPath)
+	if !found {
+		return nil, nil, false, nil
+	}
+
+	return value, nil, true, nil
+}
+
+type secretsFactory struct {
+	store           *Store
+	secretTemplates []*creds.SecretTemplate
+	sharedPath      string
+}
+
+func NewSecretsFactory(store *Store, secretTemplates []*creds.SecretTemplate, sharedPath string) creds.SecretsFactory {
+	return &secretsFactory{
+		store:           store,
+		secretTemplates: secretTemplates,
+		sharedPath:      sharedPath,
+	}
+}
+
+func (factory *secretsFactory) NewSecrets() creds.Secrets {
+	return NewSecrets(factory.store, factory.secretTemplates, factory.sharedPath)
+}
@@ -0,0 +1,315 @@
+package file
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha512"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"hash"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"gopkg.in/yaml.v3"
+)
+
+// sopsMetadata is the subset of the 'sops' key of a SOPS encrypted file
+// needed to decrypt it with age.
+type sopsMetadata struct {
+	Age []struct {
+		Recipient string `yaml:"recipient"`
+		Enc       string `yaml:"enc"`
+	} `yaml:"age"`
+
+	LastModified     string `yaml:"lastmodified"`
+	MAC              string `yaml:"mac"`
+	MACOnlyEncrypted bool   `yaml:"mac_only_encrypted"`
+
+	UnencryptedSuffix string `yaml:"unencrypted_suffix"`
+	EncryptedSuffix   string `yaml:"encrypted_suffix"`
+	UnencryptedRegex  string `yaml:"unencrypted_regex"`
+	EncryptedRegex    string `yaml:"encrypted_regex"`
+}
+
+var sopsValueRegexp = regexp.MustCompile(`^ENC\[AES256_GCM,data:(.+),iv:(.+),tag:(.+),type:(.+)\]`)
+
+// isSOPS reports whether the document is a SOPS encrypted file, i.e. a
+// mapping with a 'sops' key.
+func isSOPS(root *yaml.Node) bool {
+	if root.Kind != yaml.MappingNode {
+		return false
+	}
+
+	for i := 0; i < len(root.Content); i += 2 {
+		if root.Content[i].Value == "sops" {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decryptSOPS decrypts a SOPS encrypted document in place, using the age
+// identities to unwrap its data key, and verifies its MAC. Documents
+// encrypted only for KMS or PGP keys are not supported.
+func decryptSOPS(root *yaml.Node, identities []age.Identity) error {
+	var metadataNode *yaml.Node
+	for i := 0; i < len(root.Content); i += 2 {
+		if root.Content[i].Value == "sops" {
+			metadataNode = root.Content[i+1]
+			root.Content = append(root.Content[:i:i], root.Content[i+2:]...)
+			break
+		}
+	}
+
+	var metadata sopsMetadata
+	err := metadataNode.Decode(&metadata)
+	if err != nil {
+		return fmt.Errorf("invalid sops metadata: %w", err)
+	}
+
+	key, err := metadata.dataKey(identities)
+	if err != nil {
+		return err
+	}
+
+	mac := sha512.New()
+	err = metadata.decryptNode(root, nil, key, mac)
+	if err != nil {
+		return err
+	}
+
+	return metadata.verifyMAC(key, mac)
+}
+
+func (metadata sopsMetadata) dataKey(identities []age.Identity) ([]byte, error) {
+	if len(metadata.Age) == 0 {
+		return nil, errors.New("sops file is not encrypted for any age recipient")
+	}
+
+	if len(identities) == 0 {
+		return nil, errors.New("no age identities configured to decrypt sops file with")
+	}
+
+	for _, recipient := range metadata.Age {
+		r, err := age.Decrypt(armor.NewReader(strings.NewReader(recipient.Enc)), identities...)
+		if err != nil {
+			continue
+		}
+
+		return ioutil.ReadAll(r)
+	}
+
+	return nil, errors.New("none of the configured age identities can decrypt the sops file")
+}
+
+func (metadata sopsMetadata) decryptNode(node *yaml.Node, path []string, key []byte, mac hash.Hash) error {
+	switch node.Kind {
+	case yaml.MappingNode:
+		for i := 0; i < len(node.Content); i += 2 {
+			keyPath := append(append([]string{}, path...), node.Content[i].Value)
+
+			err := metadata.decryptNode(node.Content[i+1], keyPath, key, mac)
+			if err != nil {
+				return err
+			}
+		}
+
+	case yaml.SequenceNode:
+		for _, child := range node.Content {
+			err := metadata.decryptNode(child, path, key, mac)
+			if err != nil {
+				return err
+			}
+		}
+
+	case yaml.ScalarNode:
+		if node.Tag == "!!null" {
+			return nil
+		}
+
+		if !metadata.isEncrypted(path) {
+			if metadata.MACOnlyEncrypted {
+				return nil
+			}
+
+			var value interface{}
+			err := node.Decode(&value)
+			if err != nil {
+				return err
+			}
+
+			b, err := sopsBytes(value)
+			if err != nil {
+				return fmt.Errorf("%s: %w", strings.Join(path, "."), err)
+			}
+
+			mac.Write(b)
+			return nil
+		}
+
+		plaintext, tag, err := decryptSOPSValue(node.Value, key, strings.Join(path, ":")+":")
+		if err != nil {
+			return fmt.Errorf("failed to decrypt %s: %w", strings.Join(path, "."), err)
+		}
+
+		node.Value = plaintext
+		node.Tag = tag
+		node.Style = 0
+
+		mac.Write([]byte(plaintext))
+	}
+
+	return nil
+}
+
+// isEncrypted mirrors how SOPS decides which values it encrypted.
+func (metadata sopsMetadata) isEncrypted(path []string) bool {
+	encrypted := true
+
+	if metadata.UnencryptedSuffix != "" {
+		for _, p := range path {
+			if strings.HasSuffix(p, metadata.UnencryptedSuffix) {
+				encrypted = false
+				break
+			}
+		}
+	}
+
+	if metadata.EncryptedSuffix != "" {
+		encrypted = false
+		for _, p := range path {
+			if strings.HasSuffix(p, metadata.EncryptedSuffix) {
+				encrypted = true
+				break
+			}
+		}
+	}
+
+	if metadata.UnencryptedRegex != "" {
+		for _, p := range path {
+			if matched, _ := regexp.MatchString(metadata.UnencryptedRegex, p); matched {
+				encrypted = false
+				break
+			}
+		}
+	}
+
+	if metadata.EncryptedRegex != "" {
+		encrypted = false
+		for _, p := range path {
+			if matched, _ := regexp.MatchString(metadata.EncryptedRegex, p); matched {
+				encrypted = true
+				break
+			}
+		}
+	}
+
+	return encrypted
+}
+
+func (metadata sopsMetadata) verifyMAC(key []byte, mac hash.Hash) error {
+	lastModified, err := time.Parse(time.RFC3339, metadata.LastModified)
+	if err != nil {
+		return fmt.Errorf("invalid sops lastmodified: %w", err)
+	}
+
+	expected, _, err := decryptSOPSValue(metadata.MAC, key, lastModified.Format(time.RFC3339))
+	if err != nil {
+		return fmt.Errorf("failed to decrypt sops mac: %w", err)
+	}
+
+	if fmt.Sprintf("%X", mac.Sum(nil)) != expected {
+		return errors.New("sops mac mismatch: the file has been tampered with")
+	}
+
+	return nil
+}
+
+// decryptSOPSValue decrypts an ENC[AES256_GCM,...] value, returning its
+// plaintext in the canonical form SOPS hashes it in along with the YAML tag
+// of its original type.
+func decryptSOPSValue(value string, key []byte, additionalData string) (string, string, error) {
+	if value == "" {
+		return "", "!!str", nil
+	}
+
+	matches := sopsValueRegexp.FindStringSubmatch(value)
+	if matches == nil {
+		return "", "", errors.New("value is not encrypted by sops")
+	}
+
+	var parts [3][]byte
+	for i := range parts {
+		decoded, err := base64.StdEncoding.DecodeString(matches[i+1])
+		if err != nil {
+			return "", "", err
+		}
+
+		parts[i] = decoded
+	}
+
+	data, iv, tag := parts[0], parts[1], parts[2]
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return "", "", err
+	}
+
+	gcm, err := cipher.NewGCMWithNonceSize(block, len(iv))
+	if err != nil {
+		return "", "", err
+	}
+
+	plaintext, err := gcm.Open(nil, iv, append(data, tag...), []byte(additionalData))
+	if err != nil {
+		return "", "", err
+	}
+
+	switch matches[4] {
+	case "str", "bytes", "comment":
+		return string(plaintext), "!!str", nil
+	case "int":
+		i, err := strconv.Atoi(string(plaintext))
+		if err != nil {
+			return "", "", err
+		}
+		return strconv.Itoa(i), "!!int", nil
+	case "float":
+		f, err := strconv.ParseFloat(string(plaintext), 64)
+		if err != nil {
+			return "", "", err
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64), "!!float", nil
+	case "bool":
+		b, err := strconv.ParseBool(string(plaintext))
+		if err != nil {
+			return "", "", err
+		}
+		return strings.Title(strconv.FormatBool(b)), "!!bool", nil
+	default:
+		return "", "", fmt.Errorf("unknown sops value type '%s'", matches[4])
+	}
+}
+
+// sopsBytes converts an unencrypted value into the bytes SOPS hashes it as.
+func sopsBytes(value interface{}) ([]byte, error) {
+	switch v := value.(type) {
+	case string:
+		return []byte(v), nil
+	case int:
+		return []byte(strconv.Itoa(v)), nil
+	case float64:
+		return []byte(strconv.FormatFloat(v, 'f', -1, 64)), nil
+	case bool:
+		return []byte(strings.Title(strconv.FormatBool(v))), nil
+	default:
+		return nil, fmt.Errorf("unsupported value of type %T", value)
+	}
+}
@@ -0,0 +1,366 @@
+package file
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/fsnotify/fsnotify"
+	"gopkg.in/yaml.v3"
+)
+
+// reloadDelay coalesces the burst of events produced by a single change,
+// e.g. an editor writing a file or Kubernetes swapping a mounted secret.
+const reloadDelay = 100 * time.Millisecond
+
+// Store holds the secrets of a directory of YAML files, keyed by their path
+// within the directory followed by their path within the file. For example
+// both 'main/deploy.yml' holding 'password: x' and 'main.yml' holding
+// 'deploy: {password: x}' define the secret 'main/deploy/password'.
+//
+// Files ending in '.age' are decrypted with the age identities first, and
+// files with a top-level 'sops' key are decrypted as SOPS files encrypted
+// for age recipients. Hidden files and directories are ignored.
+type Store struct {
+	logger     lager.Logger
+	dir        string
+	identities []age.Identity
+
+	lock     sync.RWMutex
+	tree     map[string]interface{}
+	files    int
+	loadedAt time.Time
+	loadErr  error
+
+	watcher *fsnotify.Watcher
+	dirs    []string
+	done    chan struct{}
+	wg      sync.WaitGroup
+}
+
+func NewStore(logger lager.Logger, dir string, identities []age.Identity) *Store {
+	return &Store{
+		logger:     logger,
+		dir:        dir,
+		identities: identities,
+		done:       make(chan struct{}),
+	}
+}
+
+// Load reads every secret file in the directory, replacing the secrets
+// previously loaded only if all of them could be read.
+func (s *Store) Load() error {
+	tree := map[string]interface{}{}
+	files := 0
+	dirs := []string{}
+
+	err := filepath.Walk(s.dir, func(p string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if p != s.dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		if info.IsDir() {
+			dirs = append(dirs, p)
+			return nil
+		}
+
+		if info.Mode()&os.ModeSymlink != 0 {
+			info, err = os.Stat(p)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, p)
+		if err != nil {
+			return err
+		}
+
+		secretPath, encrypted, ok := secretFilePath(filepath.ToSlash(rel))
+		if !ok {
+			return nil
+		}
+
+		value, found, err := s.readFile(p, encrypted)
+		if err != nil {
+			return fmt.Errorf("%s: %w", rel, err)
+		}
+
+		if !found {
+			return nil
+		}
+
+		err = insert(tree, strings.Split(secretPath, "/"), value, secretPath)
+		if err != nil {
+			return err
+		}
+
+		files++
+
+		return nil
+	})
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	s.loadErr = err
+	if err != nil {
+		return err
+	}
+
+	s.tree = tree
+	s.files = files
+	s.loadedAt = time.Now()
+	s.dirs = dirs
+
+	return nil
+}
+
+// Watch reloads the secrets whenever a file in the directory changes, until
+// Close is called. Failed reloads are logged and keep the secrets previously
+// loaded.
+func (s *Store) Watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	s.watcher = watcher
+	s.watchDirs()
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		var reload <-chan time.Time
+
+		for {
+			select {
+			case <-s.done:
+				return
+
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				s.logger.Debug("file-changed", lager.Data{"file": event.Name, "op": event.Op.String()})
+				reload = time.After(reloadDelay)
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				s.logger.Error("failed-to-watch", err)
+
+			case <-reload:
+				reload = nil
+
+				err := s.Load()
+				if err != nil {
+					s.logger.Error("failed-to-reload", err)
+					continue
+				}
+
+				s.logger.Info("reloaded")
+				s.watchDirs()
+			}
+		}
+	}()
+
+	return nil
+}
+
+func (s *Store) watchDirs() {
+	s.lock.RLock()
+	dirs := s.dirs
+	s.lock.RUnlock()
+
+	for _, dir := range dirs {
+		err := s.watcher.Add(dir)
+		if err != nil {
+			s.logger.Error("failed-to-watch-dir", err, lager.Data{"dir": dir})
+		}
+	}
+}
+
+func (s *Store) Close() {
+	close(s.done)
+
+	if s.watcher != nil {
+		s.watcher.Close()
+	}
+
+	s.wg.Wait()
+}
+
+// Get returns the value at the given slash separated path.
+func (s *Store) Get(secretPath string) (interface{}, bool) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	var value interface{} = s.tree
+	for _, segment := range strings.Split(strings.Trim(path.Clean("/"+secretPath), "/"), "/") {
+		m, ok := value.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+
+		value, ok = m[segment]
+		if !ok {
+			return nil, false
+		}
+	}
+
+	return value, true
+}
+
+// Status returns the number of files last loaded, when they were loaded and
+// the error of the last attempt to load them, if it failed.
+func (s *Store) Status() (int, time.Time, error) {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+
+	return s.files, s.loadedAt, s.loadErr
+}
+
+func (s *Store) readFile(p string, encrypted bool) (interface{}, bool, error) {
+	data, err := ioutil.ReadFile(p)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if encrypted {
+		data, err = decryptAge(data, s.identities)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var doc yaml.Node
+	err = yaml.Unmarshal(data, &doc)
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(doc.Content) == 0 {
+		return nil, false, nil
+	}
+
+	root := doc.Content[0]
+	if isSOPS(root) {
+		err = decryptSOPS(root, s.identities)
+		if err != nil {
+			return nil, false, err
+		}
+	}
+
+	var value interface{}
+	err = root.Decode(&value)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return value, true, nil
+}
+
+func decryptAge(data []byte, identities []age.Identity) ([]byte, error) {
+	if len(identities) == 0 {
+		return nil, errors.New("no age identities configured to decrypt file with")
+	}
+
+	var src io.Reader = bytes.NewReader(data)
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte(armor.Header)) {
+		src = armor.NewReader(bytes.NewReader(bytes.TrimSpace(data)))
+	}
+
+	r, err := age.Decrypt(src, identities...)
+	if err != nil {
+		return nil, err
+	}
+
+	return ioutil.ReadAll(r)
+}
+
+// secretFilePath maps the path of a file within the directory to the path of
+// the secrets it defines, reporting whether it is age encrypted and whether
+// it is a secret file at all.
+func secretFilePath(rel string) (string, bool, bool) {
+	encrypted := strings.HasSuffix(rel, ".age")
+	rel = strings.TrimSuffix(rel, ".age")
+
+	for _, ext := range []string{".yml", ".yaml"} {
+		if strings.HasSuffix(rel, ext) {
+			return strings.TrimSuffix(rel, ext), encrypted, true
+		}
+	}
+
+	return "", false, false
+}
+
+func insert(tree map[string]interface{}, segments []string, value interface{}, secretPath string) error {
+	for _, segment := range segments[:len(segments)-1] {
+		next, found := tree[segment]
+		if !found {
+			next = map[string]interface{}{}
+			tree[segment] = next
+		}
+
+		m, ok := next.(map[string]interface{})
+		if !ok {
+			return fmt.Errorf("secret '%s' is defined more than once", secretPath)
+		}
+
+		tree = m
+	}
+
+	last := segments[len(segments)-1]
+
+	existing, found := tree[last]
+	if !found {
+		tree[last] = value
+		return nil
+	}
+
+	existingMap, ok := existing.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("secret '%s' is defined more than once", secretPath)
+	}
+
+	valueMap, ok := value.(map[string]interface{})
+	if !ok {
+		return fmt.Errorf("secret '%s' is defined more than once", secretPath)
+	}
+
+	for k, v := range valueMap {
+		err := insert(existingMap, []string{k}, v, secretPath+"/"+k)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,301 @@
+package file_test
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/concourse/concourse/atc/creds/file"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+type nopCloser struct {
+	io.Writer
+}
+
+func (nopCloser) Close() error { return nil }
+
+var _ = Describe("Store", func() {
+	var (
+		dir        string
+		identity   *age.X25519Identity
+		identities []age.Identity
+		store      *file.Store
+		loadErr    error
+	)
+
+	writeFile := func(name string, content []byte) {
+		p := filepath.Join(dir, name)
+		Expect(os.MkdirAll(filepath.Dir(p), 0755)).To(Succeed())
+		Expect(ioutil.WriteFile(p, content, 0644)).To(Succeed())
+	}
+
+	encrypt := func(plaintext string, armored bool) []byte {
+		buf := new(bytes.Buffer)
+
+		var dst io.WriteCloser = nopCloser{buf}
+		if armored {
+			dst = armor.NewWriter(buf)
+		}
+
+		w, err := age.Encrypt(dst, identity.Recipient())
+		Expect(err).NotTo(HaveOccurred())
+
+		_, err = io.WriteString(w, plaintext)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(w.Close()).To(Succeed())
+		Expect(dst.Close()).To(Succeed())
+
+		return buf.Bytes()
+	}
+
+	BeforeEach(func() {
+		var err error
+		dir, err = ioutil.TempDir("", "file-creds")
+		Expect(err).NotTo(HaveOccurred())
+
+		identity, err = age.GenerateX25519Identity()
+		Expect(err).NotTo(HaveOccurred())
+
+		identities = []age.Identity{identity}
+	})
+
+	JustBeforeEach(func() {
+		store = file.NewStore(lagertest.NewTestLogger("test"), dir, identities)
+		loadErr = store.Load()
+	})
+
+	AfterEach(func() {
+		store.Close()
+		os.RemoveAll(dir)
+	})
+
+	Context("with plain YAML files", func() {
+		BeforeEach(func() {
+			writeFile("shared.yml", []byte("token: abc\n"))
+			writeFile("main.yml", []byte("deploy:\n  password: hunter2\n"))
+			writeFile("main/deploy/aws.yaml", []byte("access_key: AKIA\nsecret_key: s3cr3t\n"))
+			writeFile("main/deploy/ssh_key.yml", []byte("|\n  -----BEGIN KEY-----\n"))
+			writeFile("main/README.md", []byte("not a secret\n"))
+			writeFile("main/.hidden.yml", []byte("nope: true\n"))
+			writeFile(".git/config.yml", []byte("nope: true\n"))
+			writeFile("empty.yml", []byte(""))
+		})
+
+		It("loads secrets by the path of their file followed by their path in it", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+
+			value, found := store.Get("/shared/token")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("abc"))
+
+			value, found = store.Get("/main/deploy/password")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("hunter2"))
+
+			value, found = store.Get("/main/deploy/ssh_key")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("-----BEGIN KEY-----\n"))
+
+			value, found = store.Get("main/deploy/aws")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal(map[string]interface{}{
+				"access_key": "AKIA",
+				"secret_key": "s3cr3t",
+			}))
+		})
+
+		It("ignores hidden and non-YAML files", func() {
+			_, found := store.Get("/main/README")
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get("/main/.hidden/nope")
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get("/.git/config/nope")
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get("/empty")
+			Expect(found).To(BeFalse())
+
+			files, _, err := store.Status()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(files).To(Equal(4))
+		})
+
+		It("does not find missing secrets", func() {
+			_, found := store.Get("/main/deploy/password/nope")
+			Expect(found).To(BeFalse())
+
+			_, found = store.Get("/other/password")
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when a secret is defined more than once", func() {
+			BeforeEach(func() {
+				writeFile("main/deploy.yml", []byte("password: other\n"))
+			})
+
+			It("fails to load", func() {
+				Expect(loadErr).To(MatchError("secret 'main/deploy/password' is defined more than once"))
+			})
+		})
+
+		Context("when a file is malformed", func() {
+			BeforeEach(func() {
+				writeFile("broken.yml", []byte("foo: [\n"))
+			})
+
+			It("fails to load, naming the file", func() {
+				Expect(loadErr).To(HaveOccurred())
+				Expect(loadErr.Error()).To(HavePrefix("broken.yml: "))
+			})
+		})
+	})
+
+	Context("with age encrypted files", func() {
+		BeforeEach(func() {
+			writeFile("main/binary.yml.age", encrypt("password: binary\n", false))
+			writeFile("main/armored.yaml.age", encrypt("password: armored\n", true))
+		})
+
+		It("decrypts them with the identities", func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+
+			value, found := store.Get("/main/binary/password")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("binary"))
+
+			value, found = store.Get("/main/armored/password")
+			Expect(found).To(BeTrue())
+			Expect(value).To(Equal("armored"))
+		})
+
+		Context("without identities", func() {
+			BeforeEach(func() {
+				identities = nil
+			})
+
+			It("fails to load", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("no age identities configured")))
+			})
+		})
+	})
+
+	Context("with SOPS encrypted files", func() {
+		var fixture []byte
+
+		BeforeEach(func() {
+			var err error
+			fixture, err = ioutil.ReadFile("testdata/sops.yml")
+			Expect(err).NotTo(HaveOccurred())
+
+			keyFile, err := os.Open("testdata/age.key")
+			Expect(err).NotTo(HaveOccurred())
+			defer keyFile.Close()
+
+			identities, err = age.ParseIdentities(keyFile)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		Context("that are intact", func() {
+			BeforeEach(func() {
+				writeFile("main/pipeline.yml", fixture)
+			})
+
+			It("decrypts them and drops the sops metadata", func() {
+				Expect(loadErr).NotTo(HaveOccurred())
+
+				value, found := store.Get("/main/pipeline")
+				Expect(found).To(BeTrue())
+				Expect(value).To(Equal(map[string]interface{}{
+					"password": "hunter2",
+					"db": map[string]interface{}{
+						"user":             "admin",
+						"port":             5432,
+						"ratio":            1.5,
+						"tls":              true,
+						"host_unencrypted": "db.example.com",
+						"empty":            "",
+						"nothing":          nil,
+					},
+					"hosts": []interface{}{
+						"a.example.com",
+						map[string]interface{}{"name": "b", "weight": 2},
+					},
+				}))
+			})
+		})
+
+		Context("that are encrypted for other recipients", func() {
+			BeforeEach(func() {
+				writeFile("main/pipeline.yml", fixture)
+				identities = []age.Identity{identity}
+			})
+
+			It("fails to load", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("none of the configured age identities can decrypt the sops file")))
+			})
+		})
+
+		Context("that have been tampered with", func() {
+			BeforeEach(func() {
+				writeFile("main/pipeline.yml", []byte(strings.Replace(string(fixture), "db.example.com", "evil.example.com", 1)))
+			})
+
+			It("refuses to load them", func() {
+				Expect(loadErr).To(MatchError(ContainSubstring("sops mac mismatch")))
+			})
+		})
+	})
+
+	Describe("Watch", func() {
+		BeforeEach(func() {
+			writeFile("main/deploy.yml", []byte("password: old\n"))
+		})
+
+		JustBeforeEach(func() {
+			Expect(loadErr).NotTo(HaveOccurred())
+			Expect(store.Watch()).To(Succeed())
+		})
+
+		get := func(path string) func() interface{} {
+			return func() interface{} {
+				value, _ := store.Get(path)
+				return value
+			}
+		}
+
+		It("reloads changed files", func() {
+			writeFile("main/deploy.yml", []byte("password: new\n"))
+			Eventually(get("/main/deploy/password")).Should(Equal("new"))
+		})
+
+		It("picks up files in new directories", func() {
+			writeFile("other/team.yml", []byte("token: abc\n"))
+			Eventually(get("/other/team/token")).Should(Equal("abc"))
+
+			writeFile("other/team/more.yml", []byte("token: def\n"))
+			Eventually(get("/other/team/more/token")).Should(Equal("def"))
+		})
+
+		It("keeps the previous secrets when a reload fails", func() {
+			writeFile("main/deploy.yml", []byte("password: [\n"))
+
+			Eventually(func() error {
+				_, _, err := store.Status()
+				return err
+			}).Should(HaveOccurred())
+
+			Consistently(get("/main/deploy/password"), 200*time.Millisecond).Should(Equal("old"))
+		})
+	})
+})
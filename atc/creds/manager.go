@@ -20,6 +20,13 @@ type ManagerFactory interface {
 	NewInstance(interface{}) (Manager, error)
 }
 
+// ScopedManagerFactory is implemented by factories whose var_source managers
+// depend on the team and pipeline configuring them, e.g. to confine them to
+// the team's own secrets. Such managers are not shared between pipelines.
+type ScopedManagerFactory interface {
+	NewScopedInstance(config interface{}, teamName string, pipelineName string) (Manager, error)
+}
+
 type Managers map[string]Manager
 
 type CredentialManagementConfig struct {
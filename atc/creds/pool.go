@@ -14,6 +14,7 @@ import (
 
 type VarSourcePool interface {
 	FindOrCreate(lager.Logger, map[string]interface{}, ManagerFactory) (Secrets, error)
+	FindOrCreateForPipeline(lager.Logger, map[string]interface{}, ManagerFactory, string, string) (Secrets, error)
 	Size() int
 	Close()
 }
@@ -79,7 +80,23 @@ func (pool *varSourcePool) Size() int {
 }
 
 func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]interface{}, factory ManagerFactory) (Secrets, error) {
-	b, err := json.Marshal(config)
+	return pool.FindOrCreateForPipeline(logger, config, factory, "", "")
+}
+
+// FindOrCreateForPipeline returns the secrets of a var_source configured by
+// the given pipeline. Managers of a ScopedManagerFactory are created for, and
+// only shared within, the pipeline; other managers are shared by every
+// pipeline with the same config.
+func (pool *varSourcePool) FindOrCreateForPipeline(logger lager.Logger, config map[string]interface{}, factory ManagerFactory, teamName string, pipelineName string) (Secrets, error) {
+	scoped, isScoped := factory.(ScopedManagerFactory)
+
+	var b []byte
+	var err error
+	if isScoped {
+		b, err = json.Marshal([]interface{}{teamName, pipelineName, config})
+	} else {
+		b, err = json.Marshal(config)
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +107,12 @@ func (pool *varSourcePool) FindOrCreate(logger lager.Logger, config map[string]i
 	defer pool.lock.Unlock()
 
 	if _, ok := pool.pool[key]; !ok {
-		manager, err := factory.NewInstance(config)
+		var manager Manager
+		if isScoped {
+			manager, err = scoped.NewScopedInstance(config, teamName, pipelineName)
+		} else {
+			manager, err = factory.NewInstance(config)
+		}
 		if err != nil {
 			return nil, err
 		}
@@ -11,6 +11,9 @@ import (
 	// load dummy credential manager
 	_ "github.com/concourse/concourse/atc/creds/dummy"
 
+	// load external credential manager, whose var_sources are scoped
+	_ "github.com/concourse/concourse/atc/creds/external"
+
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 )
@@ -180,6 +183,36 @@ var _ = Context("pool", func() {
 				Expect(varSourcePool.Size()).To(Equal(2))
 			})
 		})
+
+		Context("add a config for multiple pipelines", func() {
+			var config map[string]interface{}
+
+			BeforeEach(func() {
+				config = map[string]interface{}{"url": "https://secrets.example.com"}
+			})
+
+			It("shares managers between pipelines", func() {
+				_, err := varSourcePool.FindOrCreateForPipeline(logger, config1, factory, "some-team", "some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = varSourcePool.FindOrCreateForPipeline(logger, config1, factory, "other-team", "other-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(varSourcePool.Size()).To(Equal(1))
+			})
+
+			It("creates a manager per pipeline for scoped managers", func() {
+				scopedFactory := creds.ManagerFactories()["external"]
+
+				_, err := varSourcePool.FindOrCreateForPipeline(logger, config, scopedFactory, "some-team", "some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = varSourcePool.FindOrCreateForPipeline(logger, config, scopedFactory, "some-team", "some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+				_, err = varSourcePool.FindOrCreateForPipeline(logger, config, scopedFactory, "other-team", "some-pipeline")
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(varSourcePool.Size()).To(Equal(2))
+			})
+		})
 	})
 
 	Describe("Close", func() {
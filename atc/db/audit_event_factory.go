@@ -0,0 +1,245 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// AuditEvent is a record of an API request. Events are stored against the ID
+// of the team the request targeted, as found by its name once the request has
+// been handled, and are deleted along with the team. Events of requests which
+// did not target a team, or which destroyed it, are kept without one and are
+// only listed to admins.
+type AuditEvent struct {
+	ID           int
+	Action       string
+	UserName     string
+	TeamName     string
+	PipelineName string
+	Object       string
+	SourceIP     string
+	Status       int
+	CreatedAt    time.Time
+}
+
+// AuditEventFilter narrows down the audit events returned. Zero values are
+// ignored.
+type AuditEventFilter struct {
+	Action       string
+	UserName     string
+	PipelineName string
+	Since        time.Time
+	Until        time.Time
+}
+
+//go:generate counterfeiter . AuditEventFactory
+
+type AuditEventFactory interface {
+	CreateAuditEvent(AuditEvent) error
+	TeamAuditEvents(teamName string, filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error)
+	AllAuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error)
+}
+
+type auditEventFactory struct {
+	conn Conn
+}
+
+func NewAuditEventFactory(conn Conn) AuditEventFactory {
+	return &auditEventFactory{
+		conn: conn,
+	}
+}
+
+func (f *auditEventFactory) CreateAuditEvent(event AuditEvent) error {
+	_, err := psql.Insert("audit_events").
+		SetMap(map[string]interface{}{
+			"action":        event.Action,
+			"user_name":     sql.NullString{String: event.UserName, Valid: event.UserName != ""},
+			"team_id":       sq.Expr("(SELECT id FROM teams WHERE name = ?)", event.TeamName),
+			"pipeline_name": sql.NullString{String: event.PipelineName, Valid: event.PipelineName != ""},
+			"object":        sql.NullString{String: event.Object, Valid: event.Object != ""},
+			"source_ip":     sql.NullString{String: event.SourceIP, Valid: event.SourceIP != ""},
+			"status":        event.Status,
+		}).
+		RunWith(f.conn).
+		Exec()
+	return err
+}
+
+var auditEventsQuery = psql.Select(
+	"e.id",
+	"e.action",
+	"COALESCE(e.user_name, '')",
+	"COALESCE(t.name, '')",
+	"COALESCE(e.pipeline_name, '')",
+	"COALESCE(e.object, '')",
+	"COALESCE(e.source_ip, '')",
+	"e.status",
+	"e.created_at",
+).
+	From("audit_events e").
+	LeftJoin("teams t ON t.id = e.team_id")
+
+func (f *auditEventFactory) TeamAuditEvents(teamName string, filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	query := filterAuditEvents(auditEventsQuery.Where(sq.Eq{"t.name": teamName}), filter)
+
+	return getAuditEventsWithPagination(query, page, f.conn)
+}
+
+func (f *auditEventFactory) AllAuditEvents(filter AuditEventFilter, page Page) ([]AuditEvent, Pagination, error) {
+	query := filterAuditEvents(auditEventsQuery, filter)
+
+	return getAuditEventsWithPagination(query, page, f.conn)
+}
+
+func filterAuditEvents(query sq.SelectBuilder, filter AuditEventFilter) sq.SelectBuilder {
+	if filter.Action != "" {
+		query = query.Where(sq.Eq{"e.action": filter.Action})
+	}
+
+	if filter.UserName != "" {
+		query = query.Where(sq.Eq{"e.user_name": filter.UserName})
+	}
+
+	if filter.PipelineName != "" {
+		query = query.Where(sq.Eq{"e.pipeline_name": filter.PipelineName})
+	}
+
+	if !filter.Since.IsZero() {
+		query = query.Where(sq.GtOrEq{"e.created_at": filter.Since})
+	}
+
+	if !filter.Until.IsZero() {
+		query = query.Where(sq.LtOrEq{"e.created_at": filter.Until})
+	}
+
+	return query
+}
+
+func getAuditEventsWithPagination(eventsQuery sq.SelectBuilder, page Page, conn Conn) ([]AuditEvent, Pagination, error) {
+	origEventsQuery := eventsQuery
+
+	tx, err := conn.Begin()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Rollback(tx)
+
+	var reverse bool
+
+	eventsQuery = eventsQuery.Limit(uint64(page.Limit))
+
+	if page.From == nil && page.To == nil {
+		eventsQuery = eventsQuery.OrderBy("e.id DESC")
+	} else if page.From != nil && page.To == nil {
+		eventsQuery = eventsQuery.
+			Where(sq.GtOrEq{"e.id": *page.From}).
+			OrderBy("e.id ASC")
+		reverse = true
+	} else if page.From == nil && page.To != nil {
+		eventsQuery = eventsQuery.
+			Where(sq.LtOrEq{"e.id": *page.To}).
+			OrderBy("e.id DESC")
+	} else {
+		if *page.From > *page.To {
+			return nil, Pagination{}, fmt.Errorf("Invalid range boundaries")
+		}
+
+		eventsQuery = eventsQuery.
+			Where(sq.And{
+				sq.GtOrEq{"e.id": *page.From},
+				sq.LtOrEq{"e.id": *page.To},
+			}).
+			OrderBy("e.id ASC")
+		reverse = true
+	}
+
+	rows, err := eventsQuery.RunWith(tx).Query()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	defer Close(rows)
+
+	events := []AuditEvent{}
+	for rows.Next() {
+		event, err := scanAuditEvent(rows)
+		if err != nil {
+			return nil, Pagination{}, err
+		}
+
+		events = append(events, event)
+	}
+
+	if reverse {
+		for i, j := 0, len(events)-1; i < j; i, j = i+1, j-1 {
+			events[i], events[j] = events[j], events[i]
+		}
+	}
+
+	if len(events) == 0 {
+		return events, Pagination{}, nil
+	}
+
+	newestEvent := events[0]
+	oldestEvent := events[len(events)-1]
+
+	var pagination Pagination
+
+	older, err := scanAuditEvent(origEventsQuery.
+		Where(sq.Lt{"e.id": oldestEvent.ID}).
+		OrderBy("e.id DESC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow())
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Older = &Page{
+			To:    &older.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	newer, err := scanAuditEvent(origEventsQuery.
+		Where(sq.Gt{"e.id": newestEvent.ID}).
+		OrderBy("e.id ASC").
+		Limit(1).
+		RunWith(tx).
+		QueryRow())
+	if err != nil && err != sql.ErrNoRows {
+		return nil, Pagination{}, err
+	} else if err == nil {
+		pagination.Newer = &Page{
+			From:  &newer.ID,
+			Limit: page.Limit,
+		}
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
+
+func scanAuditEvent(row scannable) (AuditEvent, error) {
+	var event AuditEvent
+	err := row.Scan(
+		&event.ID,
+		&event.Action,
+		&event.UserName,
+		&event.TeamName,
+		&event.PipelineName,
+		&event.Object,
+		&event.SourceIP,
+		&event.Status,
+		&event.CreatedAt,
+	)
+	return event, err
+}
@@ -0,0 +1,197 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventFactory", func() {
+	var factory db.AuditEventFactory
+
+	BeforeEach(func() {
+		factory = db.NewAuditEventFactory(dbConn)
+	})
+
+	Describe("TeamAuditEvents", func() {
+		BeforeEach(func() {
+			_, err := teamFactory.CreateTeam(atc.Team{Name: "some-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			for _, event := range []db.AuditEvent{
+				{Action: "PausePipeline", UserName: "some-user", TeamName: "some-team", PipelineName: "some-pipeline", SourceIP: "1.2.3.4", Status: 200},
+				{Action: "PauseJob", UserName: "other-user", TeamName: "some-team", PipelineName: "some-pipeline", Object: "job:some-job", Status: 200},
+				{Action: "PausePipeline", UserName: "some-user", TeamName: "other-team", PipelineName: "some-pipeline", Status: 200},
+				{Action: "UnpausePipeline", UserName: "some-user", TeamName: "some-team", PipelineName: "other-pipeline", Status: 403},
+				{Action: "LandWorker", UserName: "some-user", Object: "worker:some-worker", Status: 200},
+			} {
+				err := factory.CreateAuditEvent(event)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns the team's events, newest first", func() {
+			events, _, err := factory.TeamAuditEvents("some-team", db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+
+			Expect(events[0].Action).To(Equal("UnpausePipeline"))
+			Expect(events[0].Status).To(Equal(403))
+
+			Expect(events[1].Action).To(Equal("PauseJob"))
+			Expect(events[1].Object).To(Equal("job:some-job"))
+
+			Expect(events[2].Action).To(Equal("PausePipeline"))
+			Expect(events[2].UserName).To(Equal("some-user"))
+			Expect(events[2].TeamName).To(Equal("some-team"))
+			Expect(events[2].PipelineName).To(Equal("some-pipeline"))
+			Expect(events[2].SourceIP).To(Equal("1.2.3.4"))
+			Expect(events[2].CreatedAt).ToNot(BeZero())
+		})
+
+		It("filters the events", func() {
+			events, _, err := factory.TeamAuditEvents("some-team", db.AuditEventFilter{
+				UserName:     "some-user",
+				PipelineName: "some-pipeline",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("PausePipeline"))
+
+			events, _, err = factory.TeamAuditEvents("some-team", db.AuditEventFilter{
+				Action: "PauseJob",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].UserName).To(Equal("other-user"))
+
+			events, _, err = factory.TeamAuditEvents("some-team", db.AuditEventFilter{
+				Since: time.Now().Add(time.Hour),
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(BeEmpty())
+		})
+
+		It("paginates the events", func() {
+			page, pagination, err := factory.TeamAuditEvents("some-team", db.AuditEventFilter{}, db.Page{Limit: 2})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(page).To(HaveLen(2))
+			Expect(pagination.Newer).To(BeNil())
+			Expect(pagination.Older).ToNot(BeNil())
+
+			older, pagination, err := factory.TeamAuditEvents("some-team", db.AuditEventFilter{}, *pagination.Older)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(older).To(HaveLen(1))
+			Expect(older[0].Action).To(Equal("PausePipeline"))
+			Expect(pagination.Older).To(BeNil())
+			Expect(pagination.Newer).ToNot(BeNil())
+			Expect(*pagination.Newer.From).To(Equal(page[1].ID))
+		})
+
+		It("keeps the team's events when it is renamed", func() {
+			team, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = team.Rename("renamed-team")
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := factory.TeamAuditEvents("renamed-team", db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+			Expect(events[0].TeamName).To(Equal("renamed-team"))
+		})
+
+		It("deletes the team's events when it is destroyed", func() {
+			team, found, err := teamFactory.FindTeam("some-team")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			err = team.Delete()
+			Expect(err).ToNot(HaveOccurred())
+
+			events, _, err := factory.AllAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(2))
+			Expect(events[0].Action).To(Equal("LandWorker"))
+			Expect(events[1].TeamName).To(Equal("other-team"))
+		})
+	})
+
+	Describe("AllAuditEvents", func() {
+		BeforeEach(func() {
+			for _, event := range []db.AuditEvent{
+				{Action: "PausePipeline", UserName: "some-user", TeamName: defaultTeam.Name(), PipelineName: "some-pipeline", Status: 200},
+				{Action: "LandWorker", UserName: "some-user", Object: "worker:some-worker", Status: 200},
+				{Action: "DestroyTeam", UserName: "other-user", TeamName: "destroyed-team", Object: "team:destroyed-team", Status: 204},
+			} {
+				err := factory.CreateAuditEvent(event)
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns every event, including those without a team", func() {
+			events, _, err := factory.AllAuditEvents(db.AuditEventFilter{}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(3))
+
+			Expect(events[0].Action).To(Equal("DestroyTeam"))
+			Expect(events[0].TeamName).To(BeEmpty())
+			Expect(events[0].Object).To(Equal("team:destroyed-team"))
+
+			Expect(events[1].Action).To(Equal("LandWorker"))
+			Expect(events[1].TeamName).To(BeEmpty())
+
+			Expect(events[2].Action).To(Equal("PausePipeline"))
+			Expect(events[2].TeamName).To(Equal(defaultTeam.Name()))
+		})
+
+		It("filters the events", func() {
+			events, _, err := factory.AllAuditEvents(db.AuditEventFilter{
+				UserName: "other-user",
+			}, db.Page{Limit: 10})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(events).To(HaveLen(1))
+			Expect(events[0].Action).To(Equal("DestroyTeam"))
+		})
+	})
+})
+
+var _ = Describe("AuditEventLifecycle", func() {
+	var (
+		factory   db.AuditEventFactory
+		lifecycle db.AuditEventLifecycle
+	)
+
+	BeforeEach(func() {
+		factory = db.NewAuditEventFactory(dbConn)
+		lifecycle = db.NewAuditEventLifecycle(dbConn)
+	})
+
+	It("removes events older than the retention period", func() {
+		err := factory.CreateAuditEvent(db.AuditEvent{Action: "PausePipeline", TeamName: defaultTeam.Name(), Status: 200})
+		Expect(err).ToNot(HaveOccurred())
+
+		err = factory.CreateAuditEvent(db.AuditEvent{Action: "PauseJob", TeamName: defaultTeam.Name(), Status: 200})
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec(`UPDATE audit_events SET created_at = NOW() - '48 hours'::interval WHERE action = 'PausePipeline'`)
+		Expect(err).ToNot(HaveOccurred())
+
+		n, err := lifecycle.RemoveExpiredAuditEvents(24 * time.Hour)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(n).To(Equal(1))
+
+		events, _, err := factory.TeamAuditEvents(defaultTeam.Name(), db.AuditEventFilter{}, db.Page{Limit: 10})
+		Expect(err).ToNot(HaveOccurred())
+		Expect(events).To(HaveLen(1))
+		Expect(events[0].Action).To(Equal("PauseJob"))
+	})
+})
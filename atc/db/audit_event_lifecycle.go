@@ -0,0 +1,36 @@
+package db
+
+import (
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . AuditEventLifecycle
+
+type AuditEventLifecycle interface {
+	RemoveExpiredAuditEvents(retention time.Duration) (int, error)
+}
+
+type auditEventLifecycle struct {
+	conn Conn
+}
+
+func NewAuditEventLifecycle(conn Conn) AuditEventLifecycle {
+	return &auditEventLifecycle{conn}
+}
+
+func (a auditEventLifecycle) RemoveExpiredAuditEvents(retention time.Duration) (int, error) {
+	res, err := psql.Delete("audit_events").
+		Where(sq.Lt{"created_at": time.Now().Add(-retention)}).
+		RunWith(a.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return int(n), nil
+}
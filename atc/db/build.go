@@ -51,12 +51,13 @@ type BuildOutput struct {
 type BuildStatus string
 
 const (
-	BuildStatusPending   BuildStatus = "pending"
-	BuildStatusStarted   BuildStatus = "started"
-	BuildStatusAborted   BuildStatus = "aborted"
-	BuildStatusSucceeded BuildStatus = "succeeded"
-	BuildStatusFailed    BuildStatus = "failed"
-	BuildStatusErrored   BuildStatus = "errored"
+	BuildStatusPending         BuildStatus = "pending"
+	BuildStatusStarted         BuildStatus = "started"
+	BuildStatusWaitingApproval BuildStatus = "waiting-approval"
+	BuildStatusAborted         BuildStatus = "aborted"
+	BuildStatusSucceeded       BuildStatus = "succeeded"
+	BuildStatusFailed          BuildStatus = "failed"
+	BuildStatusErrored         BuildStatus = "errored"
 )
 
 func (status BuildStatus) String() string {
@@ -95,7 +96,9 @@ var buildsQuery = psql.Select(`
 		b.rerun_of,
 		rb.name,
 		b.rerun_number,
-		b.span_context
+		b.span_context,
+		b.priority,
+		b.resource_usage
 	`).
 	From("builds b").
 	JoinClause("LEFT OUTER JOIN jobs j ON b.job_id = j.id").
@@ -110,7 +113,7 @@ var minMaxIdQuery = psql.Select("COALESCE(MAX(b.id), 0)", "COALESCE(MIN(b.id), 0
 
 var latestCompletedBuildQuery = psql.Select("max(id)").
 	From("builds").
-	Where(sq.Expr(`status NOT IN ('pending', 'started')`))
+	Where(sq.Expr(`status NOT IN ('pending', 'started', 'waiting-approval')`))
 
 //go:generate counterfeiter . Build
 
@@ -149,6 +152,7 @@ type Build interface {
 	RerunOf() int
 	RerunOfName() string
 	RerunNumber() int
+	Priority() int
 
 	LagerData() lager.Data
 	TracingAttrs() tracing.Attrs
@@ -177,7 +181,7 @@ type Build interface {
 	Artifacts() ([]WorkerArtifact, error)
 	Artifact(artifactID int) (WorkerArtifact, error)
 
-	SaveOutput(string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
+	SaveOutput(SpanContext, string, atc.Source, atc.VersionedResourceTypes, atc.Version, ResourceConfigMetadataFields, string, string) error
 	AdoptInputsAndPipes() ([]BuildInput, bool, error)
 	AdoptRerunInputsAndPipes() ([]BuildInput, bool, error)
 
@@ -189,10 +193,24 @@ type Build interface {
 	IsAborted() bool
 	AbortNotifier() (Notifier, error)
 
+	RequestApproval(atc.PlanID, string) error
+	Approval(atc.PlanID) (BuildApproval, bool, error)
+	ApprovalNotifier(atc.PlanID) (Notifier, error)
+	DecideApproval(approved bool, decidedBy string) (bool, error)
+
+	StoredArtifacts() ([]StoredArtifact, error)
+
+	ResourceUsage() []atc.StepResourceUsage
+	SaveStepResourceUsage(atc.StepResourceUsage) error
+	StoredArtifact(int) (StoredArtifact, bool, error)
+
 	IsDrained() bool
 	SetDrained(bool) error
+	DrainedSinks() ([]string, error)
+	SetDrainedSink(string) error
 
 	SpanContext() propagation.HTTPSupplier
+	SpanLinks() ([]propagation.HTTPSupplier, error)
 
 	SavePipeline(
 		pipelineRef atc.PipelineRef,
@@ -200,6 +218,7 @@ type Build interface {
 		config atc.Config,
 		from ConfigVersion,
 		initiallyPaused bool,
+		policyWarnings []string,
 	) (Pipeline, bool, error)
 }
 
@@ -230,6 +249,8 @@ type build struct {
 	rerunOfName string
 	rerunNumber int
 
+	priority int
+
 	schema      string
 	privatePlan atc.Plan
 	publicPlan  *json.RawMessage
@@ -244,6 +265,8 @@ type build struct {
 	completed bool
 
 	spanContext SpanContext
+
+	resourceUsage []atc.StepResourceUsage
 }
 
 func newEmptyBuild(conn Conn, lockFactory lock.LockFactory) *build {
@@ -374,6 +397,9 @@ func (b *build) InputsReady() bool    { return b.inputsReady }
 func (b *build) RerunOf() int         { return b.rerunOf }
 func (b *build) RerunOfName() string  { return b.rerunOfName }
 func (b *build) RerunNumber() int     { return b.rerunNumber }
+func (b *build) Priority() int        { return b.priority }
+
+func (b *build) ResourceUsage() []atc.StepResourceUsage { return b.resourceUsage }
 
 func (b *build) Reload() (bool, error) {
 	row := buildsQuery.Where(sq.Eq{"b.id": b.id}).
@@ -534,6 +560,11 @@ func (b *build) start(tx Tx, plan atc.Plan) (bool, error) {
 		return false, err
 	}
 
+	err = queueWebhookDeliveries(tx, b, BuildStatusStarted)
+	if err != nil {
+		return false, err
+	}
+
 	return true, nil
 }
 
@@ -570,6 +601,11 @@ func (b *build) Finish(status BuildStatus) error {
 		return err
 	}
 
+	err = queueWebhookDeliveries(tx, b, status)
+	if err != nil {
+		return err
+	}
+
 	_, err = tx.Exec(fmt.Sprintf(`
 		DROP SEQUENCE %s
 	`, buildEventSeq(b.id)))
@@ -782,6 +818,46 @@ func (b *build) SetDrained(drained bool) error {
 	return err
 }
 
+// DrainedSinks returns the names of the log drain sinks to which the build's
+// events have been shipped.
+func (b *build) DrainedSinks() ([]string, error) {
+	rows, err := psql.Select("sink").
+		From("build_drains").
+		Where(sq.Eq{"build_id": b.id}).
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var sinks []string
+	for rows.Next() {
+		var sink string
+		err = rows.Scan(&sink)
+		if err != nil {
+			return nil, err
+		}
+
+		sinks = append(sinks, sink)
+	}
+
+	return sinks, rows.Err()
+}
+
+// SetDrainedSink records that the build's events have been shipped to the
+// given log drain sink.
+func (b *build) SetDrainedSink(sink string) error {
+	_, err := psql.Insert("build_drains").
+		Columns("build_id", "sink").
+		Values(b.id, sink).
+		Suffix("ON CONFLICT DO NOTHING").
+		RunWith(b.conn).
+		Exec()
+	return err
+}
+
 func (b *build) Delete() (bool, error) {
 	rows, err := psql.Delete("builds").
 		Where(sq.Eq{
@@ -860,6 +936,274 @@ func (b *build) AbortNotifier() (Notifier, error) {
 	})
 }
 
+// BuildApproval is the decision made on an approval step of a build.
+type BuildApproval struct {
+	Name      string
+	Approved  bool
+	DecidedBy string
+	DecidedAt time.Time
+}
+
+// RequestApproval records that the approval step with the given plan ID is
+// waiting for a decision and moves the build into the waiting-approval state.
+// Requesting an approval which has already been requested is a no-op, so
+// that a build resumed by another ATC keeps its decision.
+func (b *build) RequestApproval(planID atc.PlanID, name string) error {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Insert("build_approvals").
+		Columns("build_id", "plan_id", "name").
+		Values(b.id, string(planID), name).
+		Suffix("ON CONFLICT (build_id, plan_id) DO NOTHING").
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return tx.Commit()
+	}
+
+	err = b.setStatus(tx, BuildStatusStarted, BuildStatusWaitingApproval)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Approval returns the decision made on the approval step with the given plan
+// ID, if one has been made.
+func (b *build) Approval(planID atc.PlanID) (BuildApproval, bool, error) {
+	var approval BuildApproval
+	var approved sql.NullBool
+	var decidedBy sql.NullString
+	var decidedAt pq.NullTime
+
+	err := psql.Select("name", "approved", "decided_by", "decided_at").
+		From("build_approvals").
+		Where(sq.Eq{
+			"build_id": b.id,
+			"plan_id":  string(planID),
+		}).
+		RunWith(b.conn).
+		QueryRow().
+		Scan(&approval.Name, &approved, &decidedBy, &decidedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return BuildApproval{}, false, nil
+		}
+
+		return BuildApproval{}, false, err
+	}
+
+	if !approved.Valid {
+		return BuildApproval{}, false, nil
+	}
+
+	approval.Approved = approved.Bool
+	approval.DecidedBy = decidedBy.String
+	approval.DecidedAt = decidedAt.Time
+
+	return approval, true, nil
+}
+
+// ApprovalNotifier returns a Notifier that can be watched for when a decision
+// is made on the approval step with the given plan ID.
+func (b *build) ApprovalNotifier(planID atc.PlanID) (Notifier, error) {
+	return newConditionNotifier(b.conn.Bus(), buildApprovalChannel(b.id), func() (bool, error) {
+		var decided bool
+		err := psql.Select("approved IS NOT NULL").
+			From("build_approvals").
+			Where(sq.Eq{
+				"build_id": b.id,
+				"plan_id":  string(planID),
+			}).
+			RunWith(b.conn).
+			QueryRow().
+			Scan(&decided)
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+
+		return decided, err
+	})
+}
+
+// DecideApproval approves or rejects every approval step of the build which
+// is waiting for a decision, and moves the build back into the started state.
+// It returns false if the build is not waiting for approval.
+func (b *build) DecideApproval(approved bool, decidedBy string) (bool, error) {
+	tx, err := b.conn.Begin()
+	if err != nil {
+		return false, err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("build_approvals").
+		Set("approved", approved).
+		Set("decided_by", decidedBy).
+		Set("decided_at", sq.Expr("now()")).
+		Where(sq.Eq{
+			"build_id": b.id,
+			"approved": nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	if affected == 0 {
+		return false, nil
+	}
+
+	err = b.setStatus(tx, BuildStatusWaitingApproval, BuildStatusStarted)
+	if err != nil {
+		return false, err
+	}
+
+	err = tx.Commit()
+	if err != nil {
+		return false, err
+	}
+
+	err = b.conn.Bus().Notify(buildApprovalChannel(b.id))
+	if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// SaveStepResourceUsage appends the resources consumed by a step to the
+// build's resource usage.
+func (b *build) SaveStepResourceUsage(usage atc.StepResourceUsage) error {
+	payload, err := json.Marshal([]atc.StepResourceUsage{usage})
+	if err != nil {
+		return err
+	}
+
+	result, err := psql.Update("builds").
+		Set("resource_usage", sq.Expr("COALESCE(resource_usage, '[]'::jsonb) || ?::jsonb", string(payload))).
+		Where(sq.Eq{"id": b.id}).
+		RunWith(b.conn).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrBuildDisappeared
+	}
+
+	b.resourceUsage = append(b.resourceUsage, usage)
+
+	return nil
+}
+
+// StoredArtifacts returns the artifacts the build's tasks uploaded to the
+// artifact store.
+func (b *build) StoredArtifacts() ([]StoredArtifact, error) {
+	rows, err := storedArtifactsQuery.
+		Where(sq.Eq{"build_id": b.id}).
+		OrderBy("id ASC").
+		RunWith(b.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanStoredArtifacts(rows)
+}
+
+func (b *build) StoredArtifact(id int) (StoredArtifact, bool, error) {
+	artifact, err := scanStoredArtifact(storedArtifactsQuery.
+		Where(sq.Eq{
+			"id":       id,
+			"build_id": b.id,
+		}).
+		RunWith(b.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return StoredArtifact{}, false, nil
+		}
+
+		return StoredArtifact{}, false, err
+	}
+
+	return artifact, true, nil
+}
+
+// setStatus moves a running build from one status to another, saving a
+// status event if it was in the expected status.
+func (b *build) setStatus(tx Tx, from BuildStatus, to BuildStatus) error {
+	result, err := psql.Update("builds").
+		Set("status", to).
+		Where(sq.Eq{
+			"id":     b.id,
+			"status": from,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	err = b.saveEvent(tx, event.Status{
+		Status: atc.BuildStatus(to),
+		Time:   time.Now().Unix(),
+	})
+	if err != nil {
+		return err
+	}
+
+	// resuming after an approval is not a new start; subscribers were told
+	// the build started when it first did
+	if to != BuildStatusStarted {
+		err = queueWebhookDeliveries(tx, b, to)
+		if err != nil {
+			return err
+		}
+	}
+
+	b.status = to
+
+	return nil
+}
+
 func (b *build) SaveImageResourceVersion(rc UsedResourceCache) error {
 	_, err := psql.Insert("build_image_resource_caches").
 		Columns("resource_cache_id", "build_id").
@@ -978,7 +1322,12 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 		return BuildPreparation{}, false, err
 	}
 
-	configInputs := config.Inputs()
+	templates, err := pipeline.Templates()
+	if err != nil {
+		return BuildPreparation{}, false, err
+	}
+
+	configInputs := config.Inputs(templates)
 
 	buildInputs, err := job.GetNextBuildInputs()
 	if err != nil {
@@ -1055,20 +1404,7 @@ func (b *build) Preparation() (BuildPreparation, bool, error) {
 }
 
 func (b *build) Events(from uint) (EventSource, error) {
-	notifier, err := newConditionNotifier(b.conn.Bus(), buildEventsChannel(b.id), func() (bool, error) {
-		return true, nil
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	return newBuildEventSource(
-		b.id,
-		b.eventsTable(),
-		b.conn,
-		notifier,
-		from,
-	), nil
+	return buildEvents(b.conn, b.id, b.eventsTable(), nil, from)
 }
 
 func (b *build) SaveEvent(event atc.Event) error {
@@ -1143,6 +1479,7 @@ func (b *build) Artifacts() ([]WorkerArtifact, error) {
 }
 
 func (b *build) SaveOutput(
+	spanContext SpanContext,
 	resourceType string,
 	source atc.Source,
 	resourceTypes atc.VersionedResourceTypes,
@@ -1198,7 +1535,7 @@ func (b *build) SaveOutput(
 		return err
 	}
 
-	newVersion, err := saveResourceVersion(tx, resourceConfigScope.ID(), version, metadata, nil)
+	newVersion, err := saveResourceVersion(tx, resourceConfigScope.ID(), version, metadata, spanContext)
 	if err != nil {
 		return err
 	}
@@ -1280,11 +1617,14 @@ func (b *build) AdoptInputsAndPipes() ([]BuildInput, bool, error) {
 		return nil, false, err
 	}
 
+	// The scope the versions were taken from is recorded as the resource's
+	// scope may change later on, e.g. when its source is changed.
 	rows, err := psql.Insert("build_resource_config_version_inputs").
-		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
-		Select(psql.Select("i.resource_id", "i.version_md5", "i.input_name", "i.first_occurrence").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "resource_config_scope_id", "build_id").
+		Select(psql.Select("i.resource_id", "i.version_md5", "i.input_name", "i.first_occurrence", "r.resource_config_scope_id").
 			Column("?", b.id).
 			From("next_build_inputs i").
+			Join("resources r ON r.id = i.resource_id").
 			Where(sq.Eq{"i.job_id": b.jobID})).
 		Suffix("ON CONFLICT (build_id, resource_id, version_md5, name) DO UPDATE SET first_occurrence = EXCLUDED.first_occurrence").
 		Suffix("RETURNING name, resource_id, version_md5, first_occurrence").
@@ -1439,8 +1779,8 @@ func (b *build) AdoptRerunInputsAndPipes() ([]BuildInput, bool, error) {
 	}
 
 	rows, err := psql.Insert("build_resource_config_version_inputs").
-		Columns("resource_id", "version_md5", "name", "first_occurrence", "build_id").
-		Select(psql.Select("i.resource_id", "i.version_md5", "i.name", "false").
+		Columns("resource_id", "version_md5", "name", "first_occurrence", "resource_config_scope_id", "build_id").
+		Select(psql.Select("i.resource_id", "i.version_md5", "i.name", "false", "i.resource_config_scope_id").
 			Column("?", b.id).
 			From("build_resource_config_version_inputs i").
 			Where(sq.Eq{"i.build_id": b.rerunOf})).
@@ -1688,12 +2028,66 @@ func (b *build) SpanContext() propagation.HTTPSupplier {
 	return b.spanContext
 }
 
+// SpanLinks returns the span contexts recorded with the versions of the
+// build's inputs and, if the build's pipeline was set by a set_pipeline step,
+// the span context of the build which set it.
+//
+// The versions are looked up in the scope they were taken from. Inputs
+// adopted before the scope was recorded fall back to the resource's current
+// scope.
+func (b *build) SpanLinks() ([]propagation.HTTPSupplier, error) {
+	rows, err := b.conn.Query(`
+		SELECT v.span_context
+		FROM build_resource_config_version_inputs i
+		JOIN resources r ON r.id = i.resource_id
+		JOIN resource_config_versions v
+			ON v.resource_config_scope_id = COALESCE(i.resource_config_scope_id, r.resource_config_scope_id)
+			AND v.version_md5 = i.version_md5
+		WHERE i.build_id = $1
+		AND v.span_context IS NOT NULL
+		UNION ALL
+		SELECT pb.span_context
+		FROM builds b
+		JOIN pipelines p ON p.id = b.pipeline_id
+		JOIN builds pb ON pb.id = p.parent_build_id
+		WHERE b.id = $1
+		AND pb.span_context IS NOT NULL
+	`, b.id)
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var links []propagation.HTTPSupplier
+	for rows.Next() {
+		var spanContextJSON string
+		err := rows.Scan(&spanContextJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var spanContext SpanContext
+		err = json.Unmarshal([]byte(spanContextJSON), &spanContext)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(spanContext) > 0 {
+			links = append(links, spanContext)
+		}
+	}
+
+	return links, nil
+}
+
 func (b *build) SavePipeline(
 	pipelineRef atc.PipelineRef,
 	teamID int,
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
+	policyWarnings []string,
 ) (Pipeline, bool, error) {
 	tx, err := b.conn.Begin()
 	if err != nil {
@@ -1704,7 +2098,7 @@ func (b *build) SavePipeline(
 
 	jobID := newNullInt64(b.jobID)
 	buildID := newNullInt64(b.id)
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, teamID, jobID, buildID, b.setBy(), PipelineConfigSetViaSetPipeline, policyWarnings)
 	if err != nil {
 		return nil, false, err
 	}
@@ -1729,6 +2123,16 @@ func (b *build) SavePipeline(
 	return pipeline, isNewPipeline, nil
 }
 
+// setBy describes the build as the setter of a pipeline's config, e.g.
+// "some-pipeline/some-job #42", or "build #1234" for one-off builds.
+func (b *build) setBy() string {
+	if b.jobID != 0 {
+		return fmt.Sprintf("%s/%s #%s", b.pipelineName, b.jobName, b.name)
+	}
+
+	return fmt.Sprintf("build #%d", b.id)
+}
+
 func newNullInt64(i int) sql.NullInt64 {
 	return sql.NullInt64{
 		Valid: true,
@@ -1752,7 +2156,7 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		jobID, resourceID, resourceTypeID, pipelineID, rerunOf, rerunNumber                                 sql.NullInt64
 		schema, privatePlan, jobName, resourceName, resourceTypeName, pipelineName, publicPlan, rerunOfName sql.NullString
 		createTime, startTime, endTime, reapTime                                                            pq.NullTime
-		nonce, spanContext                                                                                  sql.NullString
+		nonce, spanContext, resourceUsage                                                                   sql.NullString
 		drained, aborted, completed                                                                         bool
 		status                                                                                              string
 		pipelineInstanceVars                                                                                sql.NullString
@@ -1791,6 +2195,8 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		&rerunOfName,
 		&rerunNumber,
 		&spanContext,
+		&b.priority,
+		&resourceUsage,
 	)
 	if err != nil {
 		return err
@@ -1860,6 +2266,14 @@ func scanBuild(b *build, row scannable, encryptionStrategy encryption.Strategy)
 		}
 	}
 
+	b.resourceUsage = nil
+	if resourceUsage.Valid {
+		err = json.Unmarshal([]byte(resourceUsage.String), &b.resourceUsage)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -1878,11 +2292,33 @@ func (b *build) saveEvent(tx Tx, event atc.Event) error {
 }
 
 func (b *build) eventsTable() string {
-	if b.pipelineID != 0 {
-		return fmt.Sprintf("pipeline_build_events_%d", b.pipelineID)
+	return buildEventsTable(b.pipelineID, b.teamID)
+}
+
+func buildEventsTable(pipelineID, teamID int) string {
+	if pipelineID != 0 {
+		return fmt.Sprintf("pipeline_build_events_%d", pipelineID)
 	} else {
-		return fmt.Sprintf("team_build_events_%d", b.teamID)
+		return fmt.Sprintf("team_build_events_%d", teamID)
+	}
+}
+
+func buildEvents(conn Conn, buildID int, table string, archive buildEventArchive, from uint) (EventSource, error) {
+	notifier, err := newConditionNotifier(conn.Bus(), buildEventsChannel(buildID), func() (bool, error) {
+		return true, nil
+	})
+	if err != nil {
+		return nil, err
 	}
+
+	return newBuildEventSource(
+		buildID,
+		table,
+		conn,
+		notifier,
+		archive,
+		from,
+	), nil
 }
 
 func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
@@ -1915,6 +2351,11 @@ func createBuild(tx Tx, build *build, vals map[string]interface{}) error {
 		return err
 	}
 
+	err = queueWebhookDeliveries(tx, build, build.status)
+	if err != nil {
+		return err
+	}
+
 	return createBuildEventSeq(tx, buildID)
 }
 
@@ -1930,6 +2371,10 @@ func buildAbortChannel(buildID int) string {
 	return fmt.Sprintf("build_abort_%d", buildID)
 }
 
+func buildApprovalChannel(buildID int) string {
+	return fmt.Sprintf("build_approval_%d", buildID)
+}
+
 func latestCompletedNonRerunBuild(tx Tx, jobID int) (int, error) {
 	var latestNonRerunId int
 	err := latestCompletedBuildQuery.
@@ -1953,7 +2398,7 @@ func updateNextBuildForJob(tx Tx, jobID int, latestNonRerunId int) error {
 			FROM builds b
 			INNER JOIN jobs j ON j.id = b.job_id
 			WHERE b.job_id = $1
-			AND b.status IN ('pending', 'started')
+			AND b.status IN ('pending', 'started', 'waiting-approval')
 			AND (b.rerun_of IS NULL OR b.rerun_of = $2)
 		)
 		WHERE j.id = $1
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"database/sql"
 	"encoding/json"
 	"errors"
 	"sync"
@@ -12,6 +13,11 @@ import (
 var ErrEndOfBuildEventStream = errors.New("end of build event stream")
 var ErrBuildEventStreamClosed = errors.New("build event stream closed")
 
+// ErrBuildEventsArchived is returned when the events of a build have been
+// moved out of the database and the source was not given an archive to read
+// them from.
+var ErrBuildEventsArchived = errors.New("build events have been archived")
+
 //go:generate counterfeiter . EventSource
 
 type EventSource interface {
@@ -24,6 +30,7 @@ func newBuildEventSource(
 	table string,
 	conn Conn,
 	notifier Notifier,
+	archive buildEventArchive,
 	from uint,
 ) *buildEventSource {
 	wg := new(sync.WaitGroup)
@@ -35,6 +42,7 @@ func newBuildEventSource(
 		conn: conn,
 
 		notifier: notifier,
+		archive:  archive,
 
 		events: make(chan event.Envelope, 2000),
 		stop:   make(chan struct{}),
@@ -53,6 +61,7 @@ type buildEventSource struct {
 
 	conn     Conn
 	notifier Notifier
+	archive  buildEventArchive
 
 	events chan event.Envelope
 	stop   chan struct{}
@@ -178,6 +187,25 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 
 		if completed {
+			// the events may have been archived since they were last queried,
+			// in which case the rest of them are read from the archive
+			var chunks sql.NullInt64
+			err = source.conn.QueryRow(`
+				SELECT builds.event_chunks
+				FROM builds
+				WHERE builds.id = $1
+			`, source.buildID).Scan(&chunks)
+			if err != nil {
+				source.err = err
+				close(source.events)
+				return
+			}
+
+			if chunks.Valid {
+				source.collectArchivedEvents(cursor, int(chunks.Int64))
+				return
+			}
+
 			source.err = ErrEndOfBuildEventStream
 			close(source.events)
 			return
@@ -192,3 +220,44 @@ func (source *buildEventSource) collectEvents(cursor uint) {
 		}
 	}
 }
+
+func (source *buildEventSource) collectArchivedEvents(cursor uint, chunks int) {
+	if source.archive == nil {
+		source.err = ErrBuildEventsArchived
+		close(source.events)
+		return
+	}
+
+	firstChunk := int(cursor / buildEventChunkSize)
+	skip := int(cursor % buildEventChunkSize)
+
+	for chunk := firstChunk; chunk < chunks; chunk++ {
+		events, err := source.archive.readChunk(source.buildID, chunk)
+		if err != nil {
+			source.err = err
+			close(source.events)
+			return
+		}
+
+		if chunk == firstChunk {
+			if skip > len(events) {
+				skip = len(events)
+			}
+
+			events = events[skip:]
+		}
+
+		for _, ev := range events {
+			select {
+			case source.events <- ev:
+			case <-source.stop:
+				source.err = ErrBuildEventStreamClosed
+				close(source.events)
+				return
+			}
+		}
+	}
+
+	source.err = ErrEndOfBuildEventStream
+	close(source.events)
+}
@@ -0,0 +1,412 @@
+package db
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// buildEventChunkSize is the number of events written to each archived chunk.
+const buildEventChunkSize = 1000
+
+//go:generate counterfeiter . BuildEventStore
+
+// BuildEventStore is where build events are read from and reaped from. Events
+// are always saved to Postgres while a build is running; stores other than the
+// Postgres one move them elsewhere once the build has completed.
+type BuildEventStore interface {
+	Events(build Build, from uint) (EventSource, error)
+	DeleteBuildEvents(pipeline Pipeline, buildIDs []int) error
+}
+
+type postgresBuildEventStore struct{}
+
+// NewPostgresBuildEventStore returns a store which keeps build events in the
+// per-pipeline and per-team build events tables.
+func NewPostgresBuildEventStore() BuildEventStore {
+	return postgresBuildEventStore{}
+}
+
+func (postgresBuildEventStore) Events(build Build, from uint) (EventSource, error) {
+	return build.Events(from)
+}
+
+func (postgresBuildEventStore) DeleteBuildEvents(pipeline Pipeline, buildIDs []int) error {
+	return pipeline.DeleteBuildEventsByBuildIDs(buildIDs)
+}
+
+//go:generate counterfeiter . BuildEventArchiver
+
+type BuildEventArchiver interface {
+	// ArchiveBuildEvents moves the events of up to limit completed builds out
+	// of the database and returns how many builds were archived.
+	ArchiveBuildEvents(ctx context.Context, limit int) (int, error)
+
+	// DeleteOrphanedBuildEvents deletes the archived events of up to limit
+	// builds which have since been deleted, e.g. along with their pipeline,
+	// and returns how many builds' events were deleted.
+	DeleteOrphanedBuildEvents(ctx context.Context, limit int) (int, error)
+}
+
+// ObjectBuildEventStore keeps the events of completed builds as gzipped
+// chunks of JSON in a blob store.
+type ObjectBuildEventStore interface {
+	BuildEventStore
+	BuildEventArchiver
+}
+
+type buildEventArchive interface {
+	readChunk(buildID int, chunk int) ([]event.Envelope, error)
+}
+
+type objectBuildEventStore struct {
+	conn  Conn
+	blobs blobstore.Store
+}
+
+func NewObjectBuildEventStore(conn Conn, blobs blobstore.Store) ObjectBuildEventStore {
+	return &objectBuildEventStore{
+		conn:  conn,
+		blobs: blobs,
+	}
+}
+
+func (s *objectBuildEventStore) Events(build Build, from uint) (EventSource, error) {
+	return buildEvents(s.conn, build.ID(), buildEventsTable(build.PipelineID(), build.TeamID()), s, from)
+}
+
+func (s *objectBuildEventStore) DeleteBuildEvents(pipeline Pipeline, buildIDs []int) error {
+	if len(buildIDs) == 0 {
+		return nil
+	}
+
+	rows, err := psql.Select("id", "event_chunks").
+		From("builds").
+		Where(sq.Eq{"id": buildIDs}).
+		Where(sq.NotEq{"event_chunks": nil}).
+		RunWith(s.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	archived := map[int]int{}
+	for rows.Next() {
+		var id, chunks int
+		err = rows.Scan(&id, &chunks)
+		if err != nil {
+			Close(rows)
+			return err
+		}
+
+		archived[id] = chunks
+	}
+
+	Close(rows)
+
+	var archivedIDs []int
+	for id, chunks := range archived {
+		err = s.deleteChunks(context.Background(), id, chunks)
+		if err != nil {
+			return err
+		}
+
+		archivedIDs = append(archivedIDs, id)
+	}
+
+	if len(archivedIDs) != 0 {
+		// the chunks are gone, so they needn't be deleted again when the builds
+		// are deleted
+		_, err = psql.Update("builds").
+			Set("event_chunks", nil).
+			Where(sq.Eq{"id": archivedIDs}).
+			RunWith(s.conn).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	// removes any events which were not archived and marks the builds as
+	// reaped
+	return pipeline.DeleteBuildEventsByBuildIDs(buildIDs)
+}
+
+func (s *objectBuildEventStore) ArchiveBuildEvents(ctx context.Context, limit int) (int, error) {
+	rows, err := psql.Select("id", "COALESCE(pipeline_id, 0)", "team_id").
+		From("builds").
+		Where(sq.Eq{
+			"completed":    true,
+			"event_chunks": nil,
+			"reap_time":    nil,
+		}).
+		OrderBy("id ASC").
+		Limit(uint64(limit)).
+		RunWith(s.conn).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	type archivableBuild struct {
+		id, pipelineID, teamID int
+	}
+
+	var builds []archivableBuild
+	for rows.Next() {
+		var build archivableBuild
+		err = rows.Scan(&build.id, &build.pipelineID, &build.teamID)
+		if err != nil {
+			Close(rows)
+			return 0, err
+		}
+
+		builds = append(builds, build)
+	}
+
+	Close(rows)
+
+	for i, build := range builds {
+		err = s.archiveBuild(ctx, build.id, buildEventsTable(build.pipelineID, build.teamID))
+		if err != nil {
+			return i, fmt.Errorf("archive events of build %d: %w", build.id, err)
+		}
+	}
+
+	return len(builds), nil
+}
+
+func (s *objectBuildEventStore) DeleteOrphanedBuildEvents(ctx context.Context, limit int) (int, error) {
+	// the builds' rows are recorded here by a trigger when they're deleted, as
+	// they may be deleted in many ways, including by cascading deletes
+	rows, err := psql.Select("build_id", "chunks").
+		From("deleted_build_event_chunks").
+		OrderBy("build_id ASC").
+		Limit(uint64(limit)).
+		RunWith(s.conn).
+		Query()
+	if err != nil {
+		return 0, err
+	}
+
+	orphaned := map[int]int{}
+	for rows.Next() {
+		var id, chunks int
+		err = rows.Scan(&id, &chunks)
+		if err != nil {
+			Close(rows)
+			return 0, err
+		}
+
+		orphaned[id] = chunks
+	}
+
+	Close(rows)
+
+	deleted := 0
+	for id, chunks := range orphaned {
+		err = s.deleteChunks(ctx, id, chunks)
+		if err != nil {
+			return deleted, fmt.Errorf("delete events of build %d: %w", id, err)
+		}
+
+		_, err = psql.Delete("deleted_build_event_chunks").
+			Where(sq.Eq{"build_id": id}).
+			RunWith(s.conn).
+			Exec()
+		if err != nil {
+			return deleted, err
+		}
+
+		deleted++
+	}
+
+	return deleted, nil
+}
+
+func (s *objectBuildEventStore) archiveBuild(ctx context.Context, buildID int, table string) error {
+	chunks, err := s.writeChunks(ctx, buildID, table)
+	if err != nil {
+		return err
+	}
+
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	result, err := psql.Update("builds").
+		Set("event_chunks", chunks).
+		Where(sq.Eq{
+			"id":           buildID,
+			"event_chunks": nil,
+			"reap_time":    nil,
+		}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		// the build was reaped while its events were being written
+		Rollback(tx)
+		return s.deleteChunks(ctx, buildID, chunks)
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM `+table+`
+		WHERE build_id = $1 OR build_id_old = $1
+	`, buildID)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *objectBuildEventStore) writeChunks(ctx context.Context, buildID int, table string) (int, error) {
+	rows, err := s.conn.Query(`
+		SELECT type, version, payload
+		FROM `+table+`
+		WHERE build_id = $1 OR build_id_old = $1
+		ORDER BY event_id ASC
+	`, buildID)
+	if err != nil {
+		return 0, err
+	}
+
+	defer Close(rows)
+
+	chunks := 0
+	events := 0
+
+	buf := new(bytes.Buffer)
+	zw := gzip.NewWriter(buf)
+	enc := json.NewEncoder(zw)
+
+	flush := func() error {
+		err := zw.Close()
+		if err != nil {
+			return err
+		}
+
+		_, err = s.blobs.Put(ctx, buildEventChunkKey(buildID, chunks), buf)
+		if err != nil {
+			return err
+		}
+
+		chunks++
+		events = 0
+
+		buf.Reset()
+		zw.Reset(buf)
+
+		return nil
+	}
+
+	for rows.Next() {
+		var t, v, p string
+		err := rows.Scan(&t, &v, &p)
+		if err != nil {
+			return 0, err
+		}
+
+		data := json.RawMessage(p)
+
+		err = enc.Encode(event.Envelope{
+			Data:    &data,
+			Event:   atc.EventType(t),
+			Version: atc.EventVersion(v),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		events++
+
+		if events == buildEventChunkSize {
+			err = flush()
+			if err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	err = rows.Err()
+	if err != nil {
+		return 0, err
+	}
+
+	if events > 0 {
+		err = flush()
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	return chunks, nil
+}
+
+func (s *objectBuildEventStore) readChunk(buildID int, chunk int) ([]event.Envelope, error) {
+	content, err := s.blobs.Get(context.Background(), buildEventChunkKey(buildID, chunk))
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(content)
+
+	zr, err := gzip.NewReader(content)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []event.Envelope
+
+	dec := json.NewDecoder(zr)
+	for {
+		var ev event.Envelope
+		err := dec.Decode(&ev)
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, err
+		}
+
+		events = append(events, ev)
+	}
+
+	return events, nil
+}
+
+func (s *objectBuildEventStore) deleteChunks(ctx context.Context, buildID int, chunks int) error {
+	for chunk := 0; chunk < chunks; chunk++ {
+		err := s.blobs.Delete(ctx, buildEventChunkKey(buildID, chunk))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func buildEventChunkKey(buildID int, chunk int) string {
+	return fmt.Sprintf("build-events/%d/%d.json.gz", buildID, chunk)
+}
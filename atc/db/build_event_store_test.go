@@ -0,0 +1,155 @@
+package db_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ObjectBuildEventStore", func() {
+	var (
+		blobDir    string
+		eventStore db.ObjectBuildEventStore
+		build      db.Build
+	)
+
+	BeforeEach(func() {
+		var err error
+		blobDir, err = ioutil.TempDir("", "build-events")
+		Expect(err).NotTo(HaveOccurred())
+
+		eventStore = db.NewObjectBuildEventStore(dbConn, blobstore.NewLocalStore(blobDir))
+
+		build, err = defaultJob.CreateBuild()
+		Expect(err).NotTo(HaveOccurred())
+	})
+
+	AfterEach(func() {
+		Expect(os.RemoveAll(blobDir)).To(Succeed())
+	})
+
+	readAll := func(from uint) []event.Envelope {
+		events, err := eventStore.Events(build, from)
+		Expect(err).NotTo(HaveOccurred())
+
+		defer db.Close(events)
+
+		var envelopes []event.Envelope
+		for {
+			ev, err := events.Next()
+			if err == db.ErrEndOfBuildEventStream {
+				return envelopes
+			}
+
+			Expect(err).NotTo(HaveOccurred())
+			envelopes = append(envelopes, ev)
+		}
+	}
+
+	Context("when the build has completed", func() {
+		var logs []event.Envelope
+
+		BeforeEach(func() {
+			logs = nil
+			for i := 0; i < 1500; i++ {
+				ev := event.Log{Payload: "some log"}
+				Expect(build.SaveEvent(ev)).To(Succeed())
+				logs = append(logs, envelope(ev))
+			}
+
+			Expect(build.Finish(db.BuildStatusSucceeded)).To(Succeed())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("archives the events and reads them back", func() {
+			archived, err := eventStore.ArchiveBuildEvents(context.TODO(), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archived).To(Equal(1))
+
+			var count int
+			err = dbConn.QueryRow(`SELECT COUNT(*) FROM build_events WHERE build_id = $1`, build.ID()).Scan(&count)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(count).To(BeZero())
+
+			status := envelope(event.Status{
+				Status: atc.StatusSucceeded,
+				Time:   build.EndTime().Unix(),
+			})
+
+			Expect(readAll(0)).To(Equal(append(logs, status)))
+			Expect(readAll(1200)).To(Equal(append(logs[1200:], status)))
+		})
+
+		It("tells the Postgres store that the events have been archived", func() {
+			_, err := eventStore.ArchiveBuildEvents(context.TODO(), 10)
+			Expect(err).NotTo(HaveOccurred())
+
+			events, err := db.NewPostgresBuildEventStore().Events(build, 0)
+			Expect(err).NotTo(HaveOccurred())
+
+			defer db.Close(events)
+
+			_, err = events.Next()
+			Expect(err).To(Equal(db.ErrBuildEventsArchived))
+		})
+
+		It("deletes the archived events when the build is reaped", func() {
+			_, err := eventStore.ArchiveBuildEvents(context.TODO(), 10)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = eventStore.DeleteBuildEvents(defaultPipeline, []int{build.ID()})
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = blobstore.NewLocalStore(blobDir).Get(context.TODO(), fmt.Sprintf("build-events/%d/0.json.gz", build.ID()))
+			Expect(err).To(Equal(blobstore.ErrNotFound))
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(build.ReapTime()).NotTo(BeZero())
+		})
+
+		Context("when the build is deleted along with its pipeline", func() {
+			BeforeEach(func() {
+				_, err := eventStore.ArchiveBuildEvents(context.TODO(), 10)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(defaultPipeline.Destroy()).To(Succeed())
+			})
+
+			It("deletes the orphaned archived events", func() {
+				deleted, err := eventStore.DeleteOrphanedBuildEvents(context.TODO(), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(Equal(1))
+
+				for chunk := 0; chunk < 2; chunk++ {
+					_, err = blobstore.NewLocalStore(blobDir).Get(context.TODO(), fmt.Sprintf("build-events/%d/%d.json.gz", build.ID(), chunk))
+					Expect(err).To(Equal(blobstore.ErrNotFound))
+				}
+
+				deleted, err = eventStore.DeleteOrphanedBuildEvents(context.TODO(), 10)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(deleted).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the build is still running", func() {
+		It("does not archive its events", func() {
+			archived, err := eventStore.ArchiveBuildEvents(context.TODO(), 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(archived).To(BeZero())
+		})
+	})
+})
@@ -127,7 +127,7 @@ func (f *buildFactory) GetDrainableBuilds() ([]Build, error) {
 
 func (f *buildFactory) GetAllStartedBuilds() ([]Build, error) {
 	query := buildsQuery.Where(sq.Eq{
-		"b.status": BuildStatusStarted,
+		"b.status": []BuildStatus{BuildStatusStarted, BuildStatusWaitingApproval},
 	})
 
 	return getBuilds(query, f.conn, f.lockFactory)
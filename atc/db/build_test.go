@@ -23,6 +23,7 @@ import (
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
 	gocache "github.com/patrickmn/go-cache"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
 )
 
 var _ = Describe("Build", func() {
@@ -799,7 +800,7 @@ var _ = Describe("Build", func() {
 			BeforeEach(func() {
 				By("creating a child pipeline")
 				build, _ := defaultJob.CreateBuild()
-				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child1-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
+				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child1-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false, nil)
 				build.Finish(db.BuildStatusSucceeded)
 
 				childPipeline.Reload()
@@ -824,7 +825,7 @@ var _ = Describe("Build", func() {
 						for i := 0; i < 5; i++ {
 							job, _, _ := childPipeline.Job("some-job")
 							build, _ := job.CreateBuild()
-							childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline-" + strconv.Itoa(i)}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
+							childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline-" + strconv.Itoa(i)}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false, nil)
 							build.Finish(db.BuildStatusSucceeded)
 							childPipelines = append(childPipelines, childPipeline)
 						}
@@ -1009,6 +1010,155 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SaveStepResourceUsage", func() {
+		var taskUsage, putUsage atc.StepResourceUsage
+
+		BeforeEach(func() {
+			taskUsage = atc.StepResourceUsage{
+				PlanID:          "some-task-plan",
+				StepName:        "some-task",
+				StepType:        "task",
+				CPUSeconds:      12.5,
+				PeakMemoryBytes: 1024,
+				DiskBytes:       2048,
+				WallSeconds:     30,
+			}
+
+			putUsage = atc.StepResourceUsage{
+				PlanID:     "some-put-plan",
+				StepName:   "some-put",
+				StepType:   "put",
+				CPUSeconds: 1,
+			}
+		})
+
+		It("starts off with no usage", func() {
+			Expect(build.ResourceUsage()).To(BeEmpty())
+		})
+
+		It("appends the usage of each step", func() {
+			err := build.SaveStepResourceUsage(taskUsage)
+			Expect(err).NotTo(HaveOccurred())
+
+			err = build.SaveStepResourceUsage(putUsage)
+			Expect(err).NotTo(HaveOccurred())
+
+			found, err := build.Reload()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			Expect(build.ResourceUsage()).To(Equal([]atc.StepResourceUsage{taskUsage, putUsage}))
+		})
+
+		Context("when the build is gone", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("returns ErrBuildDisappeared", func() {
+				err := build.SaveStepResourceUsage(taskUsage)
+				Expect(err).To(Equal(db.ErrBuildDisappeared))
+			})
+		})
+	})
+
+	Describe("Approvals", func() {
+		BeforeEach(func() {
+			started, err := build.Start(atc.Plan{
+				ID:       "some-plan-id",
+				Approval: &atc.ApprovalPlan{Name: "deploy"},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(started).To(BeTrue())
+		})
+
+		Context("before approval is requested", func() {
+			It("has no decision", func() {
+				_, decided, err := build.Approval("some-plan-id")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+			})
+
+			It("cannot be decided", func() {
+				decided, err := build.DecideApproval(true, "some-user")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(decided).To(BeFalse())
+			})
+		})
+
+		Context("when approval is requested", func() {
+			BeforeEach(func() {
+				err := build.RequestApproval("some-plan-id", "deploy")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			It("waits for approval", func() {
+				found, err := build.Reload()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(build.Status()).To(Equal(db.BuildStatusWaitingApproval))
+			})
+
+			It("is still tracked as a started build", func() {
+				builds, err := buildFactory.GetAllStartedBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(builds).To(HaveLen(1))
+				Expect(builds[0].ID()).To(Equal(build.ID()))
+			})
+
+			It("can be requested again after a restart", func() {
+				err := build.RequestApproval("some-plan-id", "deploy")
+				Expect(err).NotTo(HaveOccurred())
+			})
+
+			Context("when it is decided", func() {
+				var notifier db.Notifier
+
+				BeforeEach(func() {
+					var err error
+					notifier, err = build.ApprovalNotifier("some-plan-id")
+					Expect(err).NotTo(HaveOccurred())
+
+					decided, err := build.DecideApproval(false, "some-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeTrue())
+				})
+
+				AfterEach(func() {
+					Expect(notifier.Close()).To(Succeed())
+				})
+
+				It("notifies the waiting step", func() {
+					Eventually(notifier.Notify()).Should(Receive())
+				})
+
+				It("records the decision", func() {
+					approval, decided, err := build.Approval("some-plan-id")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeTrue())
+					Expect(approval.Name).To(Equal("deploy"))
+					Expect(approval.Approved).To(BeFalse())
+					Expect(approval.DecidedBy).To(Equal("some-user"))
+					Expect(approval.DecidedAt).To(BeTemporally("~", time.Now(), time.Minute))
+				})
+
+				It("moves the build back to started", func() {
+					found, err := build.Reload()
+					Expect(err).NotTo(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(build.Status()).To(Equal(db.BuildStatusStarted))
+				})
+
+				It("cannot be decided again", func() {
+					decided, err := build.DecideApproval(true, "some-other-user")
+					Expect(err).NotTo(HaveOccurred())
+					Expect(decided).To(BeFalse())
+				})
+			})
+		})
+	})
+
 	Describe("Events", func() {
 		It("saves and emits status events", func() {
 			By("allowing you to subscribe when no events have yet occurred")
@@ -1213,6 +1363,7 @@ var _ = Describe("Build", func() {
 
 		JustBeforeEach(func() {
 			err := build.SaveOutput(
+				db.SpanContext{"traceparent": "some-trace-parent"},
 				dbtest.BaseResourceType,
 				atc.Source{"some": "source"},
 				atc.VersionedResourceTypes{},
@@ -1252,6 +1403,11 @@ var _ = Describe("Build", func() {
 				Expect(buildOutputs[0].Version).To(Equal(outputVersion))
 			})
 
+			It("records the span context with the version", func() {
+				rcv := scenario.ResourceVersion("some-resource", outputVersion)
+				Expect(rcv.SpanContext().Get("traceparent")).To(Equal("some-trace-parent"))
+			})
+
 			Context("with a job in a separate team downstream of the same resource config", func() {
 				var otherScenario *dbtest.Scenario
 
@@ -1508,6 +1664,114 @@ var _ = Describe("Build", func() {
 		})
 	})
 
+	Describe("SpanLinks", func() {
+		var (
+			scenario *dbtest.Scenario
+			build    db.Build
+		)
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "some-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name:   "some-resource",
+							Type:   dbtest.BaseResourceType,
+							Source: atc.Source{"some": "source"},
+						},
+					},
+				}),
+				builder.WithSpanContext(db.SpanContext{"traceparent": "some-trace-parent"}),
+				builder.WithResourceVersions("some-resource", atc.Version{"some": "version"}),
+				builder.WithJobBuild(&build, "some-job", dbtest.JobInputs{
+					{
+						Name:    "some-resource",
+						Version: atc.Version{"some": "version"},
+					},
+				}, dbtest.JobOutputs{}),
+			)
+		})
+
+		It("returns the span contexts of the input versions", func() {
+			links, err := build.SpanLinks()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(links).To(ConsistOf(db.SpanContext{"traceparent": "some-trace-parent"}))
+		})
+
+		Context("when the resource's scope has changed since", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE resources SET resource_config_scope_id = NULL WHERE id = $1`, scenario.Resource("some-resource").ID())
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("still returns the span contexts of the input versions", func() {
+				links, err := build.SpanLinks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(links).To(ConsistOf(db.SpanContext{"traceparent": "some-trace-parent"}))
+			})
+		})
+
+		Context("when the pipeline was set by another build", func() {
+			var parentBuild db.Build
+
+			BeforeEach(func() {
+				tracing.ConfigureTraceProvider(tracetest.NewProvider())
+
+				ctx, span := tracing.StartSpan(context.Background(), "fake-operation", nil)
+				defer span.End()
+
+				err := defaultJob.EnsurePendingBuildExists(ctx)
+				Expect(err).ToNot(HaveOccurred())
+
+				pendingBuilds, err := defaultJob.GetPendingBuilds()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pendingBuilds).To(HaveLen(1))
+				parentBuild = pendingBuilds[0]
+
+				config, err := scenario.Pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = parentBuild.SavePipeline(
+					atc.PipelineRef{
+						Name:         scenario.Pipeline.Name(),
+						InstanceVars: scenario.Pipeline.InstanceVars(),
+					},
+					scenario.Team.ID(),
+					config,
+					scenario.Pipeline.ConfigVersion(),
+					false,
+					nil,
+				)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			AfterEach(func() {
+				tracing.Configured = false
+			})
+
+			It("also returns the span context of the build which set it", func() {
+				links, err := build.SpanLinks()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(links).To(ConsistOf(
+					db.SpanContext{"traceparent": "some-trace-parent"},
+					parentBuild.SpanContext(),
+				))
+			})
+		})
+	})
+
 	Describe("Pipeline", func() {
 		var (
 			build           db.Build
@@ -2383,7 +2647,7 @@ var _ = Describe("Build", func() {
 						},
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(pipeline.ParentJobID()).To(Equal(build.JobID()))
 			Expect(pipeline.ParentBuildID()).To(Equal(build.ID()))
@@ -2421,7 +2685,7 @@ var _ = Describe("Build", func() {
 						},
 					},
 				},
-			}, db.ConfigVersion(0), false)
+			}, db.ConfigVersion(0), false, nil)
 			Expect(err).ToNot(HaveOccurred())
 			Expect(pipeline.ParentJobID()).To(Equal(buildTwo.JobID()))
 			Expect(pipeline.ParentBuildID()).To(Equal(buildTwo.ID()))
@@ -2451,7 +2715,7 @@ var _ = Describe("Build", func() {
 						},
 					},
 				},
-			}, pipeline.ConfigVersion(), false)
+			}, pipeline.ConfigVersion(), false, nil)
 			Expect(err).To(Equal(db.ErrSetByNewerBuild))
 		})
 
@@ -2462,7 +2726,7 @@ var _ = Describe("Build", func() {
 				Expect(err).ToNot(HaveOccurred())
 
 				By("re-saving the default pipeline with the build")
-				pipeline, _, err := build.SavePipeline(defaultPipelineRef, build.TeamID(), defaultPipelineConfig, db.ConfigVersion(1), false)
+				pipeline, _, err := build.SavePipeline(defaultPipelineRef, build.TeamID(), defaultPipelineConfig, db.ConfigVersion(1), false, nil)
 				Expect(err).ToNot(HaveOccurred())
 				Expect(pipeline.ParentJobID()).To(Equal(build.JobID()))
 				Expect(pipeline.ParentBuildID()).To(Equal(build.ID()))
@@ -45,6 +45,10 @@ var (
 	resourceConfigFactory               db.ResourceConfigFactory
 	resourceCacheFactory                db.ResourceCacheFactory
 	taskCacheFactory                    db.TaskCacheFactory
+	keyedCacheFactory                   db.KeyedCacheFactory
+	keyedCacheLifecycle                 db.KeyedCacheLifecycle
+	storedArtifactFactory               db.StoredArtifactFactory
+	storedArtifactLifecycle             db.StoredArtifactLifecycle
 	checkFactory                        db.CheckFactory
 	workerBaseResourceTypeFactory       db.WorkerBaseResourceTypeFactory
 	workerTaskCacheFactory              db.WorkerTaskCacheFactory
@@ -125,6 +129,10 @@ var _ = BeforeEach(func() {
 	resourceConfigFactory = db.NewResourceConfigFactory(dbConn, lockFactory)
 	resourceCacheFactory = db.NewResourceCacheFactory(dbConn, lockFactory)
 	taskCacheFactory = db.NewTaskCacheFactory(dbConn)
+	keyedCacheFactory = db.NewKeyedCacheFactory(dbConn)
+	keyedCacheLifecycle = db.NewKeyedCacheLifecycle(dbConn)
+	storedArtifactFactory = db.NewStoredArtifactFactory(dbConn)
+	storedArtifactLifecycle = db.NewStoredArtifactLifecycle(dbConn)
 	checkFactory = db.NewCheckFactory(dbConn, lockFactory, fakeSecrets, fakeVarSourcePool, db.CheckDurations{
 		Timeout:             defaultCheckTimeout,
 		Interval:            defaultCheckInterval,
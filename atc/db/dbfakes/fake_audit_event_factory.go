@@ -0,0 +1,282 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAuditEventFactory struct {
+	AllAuditEventsStub        func(db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)
+	allAuditEventsMutex       sync.RWMutex
+	allAuditEventsArgsForCall []struct {
+		arg1 db.AuditEventFilter
+		arg2 db.Page
+	}
+	allAuditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	allAuditEventsReturnsOnCall map[int]struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	CreateAuditEventStub        func(db.AuditEvent) error
+	createAuditEventMutex       sync.RWMutex
+	createAuditEventArgsForCall []struct {
+		arg1 db.AuditEvent
+	}
+	createAuditEventReturns struct {
+		result1 error
+	}
+	createAuditEventReturnsOnCall map[int]struct {
+		result1 error
+	}
+	TeamAuditEventsStub        func(string, db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)
+	teamAuditEventsMutex       sync.RWMutex
+	teamAuditEventsArgsForCall []struct {
+		arg1 string
+		arg2 db.AuditEventFilter
+		arg3 db.Page
+	}
+	teamAuditEventsReturns struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	teamAuditEventsReturnsOnCall map[int]struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEvents(arg1 db.AuditEventFilter, arg2 db.Page) ([]db.AuditEvent, db.Pagination, error) {
+	fake.allAuditEventsMutex.Lock()
+	ret, specificReturn := fake.allAuditEventsReturnsOnCall[len(fake.allAuditEventsArgsForCall)]
+	fake.allAuditEventsArgsForCall = append(fake.allAuditEventsArgsForCall, struct {
+		arg1 db.AuditEventFilter
+		arg2 db.Page
+	}{arg1, arg2})
+	fake.recordInvocation("AllAuditEvents", []interface{}{arg1, arg2})
+	fake.allAuditEventsMutex.Unlock()
+	if fake.AllAuditEventsStub != nil {
+		return fake.AllAuditEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.allAuditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEventsCallCount() int {
+	fake.allAuditEventsMutex.RLock()
+	defer fake.allAuditEventsMutex.RUnlock()
+	return len(fake.allAuditEventsArgsForCall)
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEventsCalls(stub func(db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)) {
+	fake.allAuditEventsMutex.Lock()
+	defer fake.allAuditEventsMutex.Unlock()
+	fake.AllAuditEventsStub = stub
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEventsArgsForCall(i int) (db.AuditEventFilter, db.Page) {
+	fake.allAuditEventsMutex.RLock()
+	defer fake.allAuditEventsMutex.RUnlock()
+	argsForCall := fake.allAuditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEventsReturns(result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.allAuditEventsMutex.Lock()
+	defer fake.allAuditEventsMutex.Unlock()
+	fake.AllAuditEventsStub = nil
+	fake.allAuditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) AllAuditEventsReturnsOnCall(i int, result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.allAuditEventsMutex.Lock()
+	defer fake.allAuditEventsMutex.Unlock()
+	fake.AllAuditEventsStub = nil
+	if fake.allAuditEventsReturnsOnCall == nil {
+		fake.allAuditEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.AuditEvent
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.allAuditEventsReturnsOnCall[i] = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEvent(arg1 db.AuditEvent) error {
+	fake.createAuditEventMutex.Lock()
+	ret, specificReturn := fake.createAuditEventReturnsOnCall[len(fake.createAuditEventArgsForCall)]
+	fake.createAuditEventArgsForCall = append(fake.createAuditEventArgsForCall, struct {
+		arg1 db.AuditEvent
+	}{arg1})
+	fake.recordInvocation("CreateAuditEvent", []interface{}{arg1})
+	fake.createAuditEventMutex.Unlock()
+	if fake.CreateAuditEventStub != nil {
+		return fake.CreateAuditEventStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.createAuditEventReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventCallCount() int {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	return len(fake.createAuditEventArgsForCall)
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventCalls(stub func(db.AuditEvent) error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = stub
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventArgsForCall(i int) db.AuditEvent {
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	argsForCall := fake.createAuditEventArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventReturns(result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	fake.createAuditEventReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditEventFactory) CreateAuditEventReturnsOnCall(i int, result1 error) {
+	fake.createAuditEventMutex.Lock()
+	defer fake.createAuditEventMutex.Unlock()
+	fake.CreateAuditEventStub = nil
+	if fake.createAuditEventReturnsOnCall == nil {
+		fake.createAuditEventReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.createAuditEventReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEvents(arg1 string, arg2 db.AuditEventFilter, arg3 db.Page) ([]db.AuditEvent, db.Pagination, error) {
+	fake.teamAuditEventsMutex.Lock()
+	ret, specificReturn := fake.teamAuditEventsReturnsOnCall[len(fake.teamAuditEventsArgsForCall)]
+	fake.teamAuditEventsArgsForCall = append(fake.teamAuditEventsArgsForCall, struct {
+		arg1 string
+		arg2 db.AuditEventFilter
+		arg3 db.Page
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("TeamAuditEvents", []interface{}{arg1, arg2, arg3})
+	fake.teamAuditEventsMutex.Unlock()
+	if fake.TeamAuditEventsStub != nil {
+		return fake.TeamAuditEventsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.teamAuditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEventsCallCount() int {
+	fake.teamAuditEventsMutex.RLock()
+	defer fake.teamAuditEventsMutex.RUnlock()
+	return len(fake.teamAuditEventsArgsForCall)
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEventsCalls(stub func(string, db.AuditEventFilter, db.Page) ([]db.AuditEvent, db.Pagination, error)) {
+	fake.teamAuditEventsMutex.Lock()
+	defer fake.teamAuditEventsMutex.Unlock()
+	fake.TeamAuditEventsStub = stub
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEventsArgsForCall(i int) (string, db.AuditEventFilter, db.Page) {
+	fake.teamAuditEventsMutex.RLock()
+	defer fake.teamAuditEventsMutex.RUnlock()
+	argsForCall := fake.teamAuditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEventsReturns(result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.teamAuditEventsMutex.Lock()
+	defer fake.teamAuditEventsMutex.Unlock()
+	fake.TeamAuditEventsStub = nil
+	fake.teamAuditEventsReturns = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) TeamAuditEventsReturnsOnCall(i int, result1 []db.AuditEvent, result2 db.Pagination, result3 error) {
+	fake.teamAuditEventsMutex.Lock()
+	defer fake.teamAuditEventsMutex.Unlock()
+	fake.TeamAuditEventsStub = nil
+	if fake.teamAuditEventsReturnsOnCall == nil {
+		fake.teamAuditEventsReturnsOnCall = make(map[int]struct {
+			result1 []db.AuditEvent
+			result2 db.Pagination
+			result3 error
+		})
+	}
+	fake.teamAuditEventsReturnsOnCall[i] = struct {
+		result1 []db.AuditEvent
+		result2 db.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeAuditEventFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.allAuditEventsMutex.RLock()
+	defer fake.allAuditEventsMutex.RUnlock()
+	fake.createAuditEventMutex.RLock()
+	defer fake.createAuditEventMutex.RUnlock()
+	fake.teamAuditEventsMutex.RLock()
+	defer fake.teamAuditEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditEventFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.AuditEventFactory = new(FakeAuditEventFactory)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeAuditEventLifecycle struct {
+	RemoveExpiredAuditEventsStub        func(time.Duration) (int, error)
+	removeExpiredAuditEventsMutex       sync.RWMutex
+	removeExpiredAuditEventsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	removeExpiredAuditEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	removeExpiredAuditEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEvents(arg1 time.Duration) (int, error) {
+	fake.removeExpiredAuditEventsMutex.Lock()
+	ret, specificReturn := fake.removeExpiredAuditEventsReturnsOnCall[len(fake.removeExpiredAuditEventsArgsForCall)]
+	fake.removeExpiredAuditEventsArgsForCall = append(fake.removeExpiredAuditEventsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("RemoveExpiredAuditEvents", []interface{}{arg1})
+	fake.removeExpiredAuditEventsMutex.Unlock()
+	if fake.RemoveExpiredAuditEventsStub != nil {
+		return fake.RemoveExpiredAuditEventsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.removeExpiredAuditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEventsCallCount() int {
+	fake.removeExpiredAuditEventsMutex.RLock()
+	defer fake.removeExpiredAuditEventsMutex.RUnlock()
+	return len(fake.removeExpiredAuditEventsArgsForCall)
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEventsCalls(stub func(time.Duration) (int, error)) {
+	fake.removeExpiredAuditEventsMutex.Lock()
+	defer fake.removeExpiredAuditEventsMutex.Unlock()
+	fake.RemoveExpiredAuditEventsStub = stub
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEventsArgsForCall(i int) time.Duration {
+	fake.removeExpiredAuditEventsMutex.RLock()
+	defer fake.removeExpiredAuditEventsMutex.RUnlock()
+	argsForCall := fake.removeExpiredAuditEventsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEventsReturns(result1 int, result2 error) {
+	fake.removeExpiredAuditEventsMutex.Lock()
+	defer fake.removeExpiredAuditEventsMutex.Unlock()
+	fake.RemoveExpiredAuditEventsStub = nil
+	fake.removeExpiredAuditEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditEventLifecycle) RemoveExpiredAuditEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.removeExpiredAuditEventsMutex.Lock()
+	defer fake.removeExpiredAuditEventsMutex.Unlock()
+	fake.RemoveExpiredAuditEventsStub = nil
+	if fake.removeExpiredAuditEventsReturnsOnCall == nil {
+		fake.removeExpiredAuditEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.removeExpiredAuditEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeAuditEventLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.removeExpiredAuditEventsMutex.RLock()
+	defer fake.removeExpiredAuditEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeAuditEventLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.AuditEventLifecycle = new(FakeAuditEventLifecycle)
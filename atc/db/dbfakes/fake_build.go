@@ -74,6 +74,34 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	ApprovalStub        func(atc.PlanID) (db.BuildApproval, bool, error)
+	approvalMutex       sync.RWMutex
+	approvalArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalReturns struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	approvalReturnsOnCall map[int]struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}
+	ApprovalNotifierStub        func(atc.PlanID) (db.Notifier, error)
+	approvalNotifierMutex       sync.RWMutex
+	approvalNotifierArgsForCall []struct {
+		arg1 atc.PlanID
+	}
+	approvalNotifierReturns struct {
+		result1 db.Notifier
+		result2 error
+	}
+	approvalNotifierReturnsOnCall map[int]struct {
+		result1 db.Notifier
+		result2 error
+	}
 	ArtifactStub        func(int) (db.WorkerArtifact, error)
 	artifactMutex       sync.RWMutex
 	artifactArgsForCall []struct {
@@ -99,6 +127,20 @@ type FakeBuild struct {
 		result1 []db.WorkerArtifact
 		result2 error
 	}
+	DecideApprovalStub        func(bool, string) (bool, error)
+	decideApprovalMutex       sync.RWMutex
+	decideApprovalArgsForCall []struct {
+		arg1 bool
+		arg2 string
+	}
+	decideApprovalReturns struct {
+		result1 bool
+		result2 error
+	}
+	decideApprovalReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DeleteStub        func() (bool, error)
 	deleteMutex       sync.RWMutex
 	deleteArgsForCall []struct {
@@ -111,6 +153,18 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	DrainedSinksStub        func() ([]string, error)
+	drainedSinksMutex       sync.RWMutex
+	drainedSinksArgsForCall []struct {
+	}
+	drainedSinksReturns struct {
+		result1 []string
+		result2 error
+	}
+	drainedSinksReturnsOnCall map[int]struct {
+		result1 []string
+		result2 error
+	}
 	EndTimeStub        func() time.Time
 	endTimeMutex       sync.RWMutex
 	endTimeArgsForCall []struct {
@@ -376,6 +430,16 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PrivatePlanStub        func() atc.Plan
 	privatePlanMutex       sync.RWMutex
 	privatePlanArgsForCall []struct {
@@ -418,6 +482,18 @@ type FakeBuild struct {
 		result1 bool
 		result2 error
 	}
+	RequestApprovalStub        func(atc.PlanID, string) error
+	requestApprovalMutex       sync.RWMutex
+	requestApprovalArgsForCall []struct {
+		arg1 atc.PlanID
+		arg2 string
+	}
+	requestApprovalReturns struct {
+		result1 error
+	}
+	requestApprovalReturnsOnCall map[int]struct {
+		result1 error
+	}
 	RerunNumberStub        func() int
 	rerunNumberMutex       sync.RWMutex
 	rerunNumberArgsForCall []struct {
@@ -488,6 +564,16 @@ type FakeBuild struct {
 	resourceTypeNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	ResourceUsageStub        func() []atc.StepResourceUsage
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+	}
+	resourceUsageReturns struct {
+		result1 []atc.StepResourceUsage
+	}
+	resourceUsageReturnsOnCall map[int]struct {
+		result1 []atc.StepResourceUsage
+	}
 	ResourcesStub        func() ([]db.BuildInput, []db.BuildOutput, error)
 	resourcesMutex       sync.RWMutex
 	resourcesArgsForCall []struct {
@@ -536,16 +622,17 @@ type FakeBuild struct {
 	saveImageResourceVersionReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SaveOutputStub        func(string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error
+	SaveOutputStub        func(db.SpanContext, string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
-		arg1 string
-		arg2 atc.Source
-		arg3 atc.VersionedResourceTypes
-		arg4 atc.Version
-		arg5 db.ResourceConfigMetadataFields
-		arg6 string
+		arg1 db.SpanContext
+		arg2 string
+		arg3 atc.Source
+		arg4 atc.VersionedResourceTypes
+		arg5 atc.Version
+		arg6 db.ResourceConfigMetadataFields
 		arg7 string
+		arg8 string
 	}
 	saveOutputReturns struct {
 		result1 error
@@ -553,7 +640,7 @@ type FakeBuild struct {
 	saveOutputReturnsOnCall map[int]struct {
 		result1 error
 	}
-	SavePipelineStub        func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)
+	SavePipelineStub        func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool, []string) (db.Pipeline, bool, error)
 	savePipelineMutex       sync.RWMutex
 	savePipelineArgsForCall []struct {
 		arg1 atc.PipelineRef
@@ -561,6 +648,7 @@ type FakeBuild struct {
 		arg3 atc.Config
 		arg4 db.ConfigVersion
 		arg5 bool
+		arg6 []string
 	}
 	savePipelineReturns struct {
 		result1 db.Pipeline
@@ -572,6 +660,17 @@ type FakeBuild struct {
 		result2 bool
 		result3 error
 	}
+	SaveStepResourceUsageStub        func(atc.StepResourceUsage) error
+	saveStepResourceUsageMutex       sync.RWMutex
+	saveStepResourceUsageArgsForCall []struct {
+		arg1 atc.StepResourceUsage
+	}
+	saveStepResourceUsageReturns struct {
+		result1 error
+	}
+	saveStepResourceUsageReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SchemaStub        func() string
 	schemaMutex       sync.RWMutex
 	schemaArgsForCall []struct {
@@ -593,6 +692,17 @@ type FakeBuild struct {
 	setDrainedReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetDrainedSinkStub        func(string) error
+	setDrainedSinkMutex       sync.RWMutex
+	setDrainedSinkArgsForCall []struct {
+		arg1 string
+	}
+	setDrainedSinkReturns struct {
+		result1 error
+	}
+	setDrainedSinkReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SetInterceptibleStub        func(bool) error
 	setInterceptibleMutex       sync.RWMutex
 	setInterceptibleArgsForCall []struct {
@@ -614,6 +724,18 @@ type FakeBuild struct {
 	spanContextReturnsOnCall map[int]struct {
 		result1 propagation.HTTPSupplier
 	}
+	SpanLinksStub        func() ([]propagation.HTTPSupplier, error)
+	spanLinksMutex       sync.RWMutex
+	spanLinksArgsForCall []struct {
+	}
+	spanLinksReturns struct {
+		result1 []propagation.HTTPSupplier
+		result2 error
+	}
+	spanLinksReturnsOnCall map[int]struct {
+		result1 []propagation.HTTPSupplier
+		result2 error
+	}
 	StartStub        func(atc.Plan) (bool, error)
 	startMutex       sync.RWMutex
 	startArgsForCall []struct {
@@ -647,6 +769,33 @@ type FakeBuild struct {
 	statusReturnsOnCall map[int]struct {
 		result1 db.BuildStatus
 	}
+	StoredArtifactStub        func(int) (db.StoredArtifact, bool, error)
+	storedArtifactMutex       sync.RWMutex
+	storedArtifactArgsForCall []struct {
+		arg1 int
+	}
+	storedArtifactReturns struct {
+		result1 db.StoredArtifact
+		result2 bool
+		result3 error
+	}
+	storedArtifactReturnsOnCall map[int]struct {
+		result1 db.StoredArtifact
+		result2 bool
+		result3 error
+	}
+	StoredArtifactsStub        func() ([]db.StoredArtifact, error)
+	storedArtifactsMutex       sync.RWMutex
+	storedArtifactsArgsForCall []struct {
+	}
+	storedArtifactsReturns struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}
+	storedArtifactsReturnsOnCall map[int]struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}
 	SyslogTagStub        func(event.OriginID) string
 	syslogTagMutex       sync.RWMutex
 	syslogTagArgsForCall []struct {
@@ -945,6 +1094,135 @@ func (fake *FakeBuild) AdoptRerunInputsAndPipesReturnsOnCall(i int, result1 []db
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Approval(arg1 atc.PlanID) (db.BuildApproval, bool, error) {
+	fake.approvalMutex.Lock()
+	ret, specificReturn := fake.approvalReturnsOnCall[len(fake.approvalArgsForCall)]
+	fake.approvalArgsForCall = append(fake.approvalArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("Approval", []interface{}{arg1})
+	fake.approvalMutex.Unlock()
+	if fake.ApprovalStub != nil {
+		return fake.ApprovalStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.approvalReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) ApprovalCallCount() int {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	return len(fake.approvalArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalCalls(stub func(atc.PlanID) (db.BuildApproval, bool, error)) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = stub
+}
+
+func (fake *FakeBuild) ApprovalArgsForCall(i int) atc.PlanID {
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	argsForCall := fake.approvalArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalReturns(result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	fake.approvalReturns = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalReturnsOnCall(i int, result1 db.BuildApproval, result2 bool, result3 error) {
+	fake.approvalMutex.Lock()
+	defer fake.approvalMutex.Unlock()
+	fake.ApprovalStub = nil
+	if fake.approvalReturnsOnCall == nil {
+		fake.approvalReturnsOnCall = make(map[int]struct {
+			result1 db.BuildApproval
+			result2 bool
+			result3 error
+		})
+	}
+	fake.approvalReturnsOnCall[i] = struct {
+		result1 db.BuildApproval
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) ApprovalNotifier(arg1 atc.PlanID) (db.Notifier, error) {
+	fake.approvalNotifierMutex.Lock()
+	ret, specificReturn := fake.approvalNotifierReturnsOnCall[len(fake.approvalNotifierArgsForCall)]
+	fake.approvalNotifierArgsForCall = append(fake.approvalNotifierArgsForCall, struct {
+		arg1 atc.PlanID
+	}{arg1})
+	fake.recordInvocation("ApprovalNotifier", []interface{}{arg1})
+	fake.approvalNotifierMutex.Unlock()
+	if fake.ApprovalNotifierStub != nil {
+		return fake.ApprovalNotifierStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.approvalNotifierReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) ApprovalNotifierCallCount() int {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	return len(fake.approvalNotifierArgsForCall)
+}
+
+func (fake *FakeBuild) ApprovalNotifierCalls(stub func(atc.PlanID) (db.Notifier, error)) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = stub
+}
+
+func (fake *FakeBuild) ApprovalNotifierArgsForCall(i int) atc.PlanID {
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
+	argsForCall := fake.approvalNotifierArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturns(result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	fake.approvalNotifierReturns = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) ApprovalNotifierReturnsOnCall(i int, result1 db.Notifier, result2 error) {
+	fake.approvalNotifierMutex.Lock()
+	defer fake.approvalNotifierMutex.Unlock()
+	fake.ApprovalNotifierStub = nil
+	if fake.approvalNotifierReturnsOnCall == nil {
+		fake.approvalNotifierReturnsOnCall = make(map[int]struct {
+			result1 db.Notifier
+			result2 error
+		})
+	}
+	fake.approvalNotifierReturnsOnCall[i] = struct {
+		result1 db.Notifier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Artifact(arg1 int) (db.WorkerArtifact, error) {
 	fake.artifactMutex.Lock()
 	ret, specificReturn := fake.artifactReturnsOnCall[len(fake.artifactArgsForCall)]
@@ -1063,6 +1341,70 @@ func (fake *FakeBuild) ArtifactsReturnsOnCall(i int, result1 []db.WorkerArtifact
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) DecideApproval(arg1 bool, arg2 string) (bool, error) {
+	fake.decideApprovalMutex.Lock()
+	ret, specificReturn := fake.decideApprovalReturnsOnCall[len(fake.decideApprovalArgsForCall)]
+	fake.decideApprovalArgsForCall = append(fake.decideApprovalArgsForCall, struct {
+		arg1 bool
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DecideApproval", []interface{}{arg1, arg2})
+	fake.decideApprovalMutex.Unlock()
+	if fake.DecideApprovalStub != nil {
+		return fake.DecideApprovalStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.decideApprovalReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DecideApprovalCallCount() int {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	return len(fake.decideApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) DecideApprovalCalls(stub func(bool, string) (bool, error)) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = stub
+}
+
+func (fake *FakeBuild) DecideApprovalArgsForCall(i int) (bool, string) {
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
+	argsForCall := fake.decideApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) DecideApprovalReturns(result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	fake.decideApprovalReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DecideApprovalReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.decideApprovalMutex.Lock()
+	defer fake.decideApprovalMutex.Unlock()
+	fake.DecideApprovalStub = nil
+	if fake.decideApprovalReturnsOnCall == nil {
+		fake.decideApprovalReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.decideApprovalReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Delete() (bool, error) {
 	fake.deleteMutex.Lock()
 	ret, specificReturn := fake.deleteReturnsOnCall[len(fake.deleteArgsForCall)]
@@ -1118,6 +1460,61 @@ func (fake *FakeBuild) DeleteReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) DrainedSinks() ([]string, error) {
+	fake.drainedSinksMutex.Lock()
+	ret, specificReturn := fake.drainedSinksReturnsOnCall[len(fake.drainedSinksArgsForCall)]
+	fake.drainedSinksArgsForCall = append(fake.drainedSinksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DrainedSinks", []interface{}{})
+	fake.drainedSinksMutex.Unlock()
+	if fake.DrainedSinksStub != nil {
+		return fake.DrainedSinksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.drainedSinksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) DrainedSinksCallCount() int {
+	fake.drainedSinksMutex.RLock()
+	defer fake.drainedSinksMutex.RUnlock()
+	return len(fake.drainedSinksArgsForCall)
+}
+
+func (fake *FakeBuild) DrainedSinksCalls(stub func() ([]string, error)) {
+	fake.drainedSinksMutex.Lock()
+	defer fake.drainedSinksMutex.Unlock()
+	fake.DrainedSinksStub = stub
+}
+
+func (fake *FakeBuild) DrainedSinksReturns(result1 []string, result2 error) {
+	fake.drainedSinksMutex.Lock()
+	defer fake.drainedSinksMutex.Unlock()
+	fake.DrainedSinksStub = nil
+	fake.drainedSinksReturns = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) DrainedSinksReturnsOnCall(i int, result1 []string, result2 error) {
+	fake.drainedSinksMutex.Lock()
+	defer fake.drainedSinksMutex.Unlock()
+	fake.DrainedSinksStub = nil
+	if fake.drainedSinksReturnsOnCall == nil {
+		fake.drainedSinksReturnsOnCall = make(map[int]struct {
+			result1 []string
+			result2 error
+		})
+	}
+	fake.drainedSinksReturnsOnCall[i] = struct {
+		result1 []string
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) EndTime() time.Time {
 	fake.endTimeMutex.Lock()
 	ret, specificReturn := fake.endTimeReturnsOnCall[len(fake.endTimeArgsForCall)]
@@ -2460,6 +2857,58 @@ func (fake *FakeBuild) PreparationReturnsOnCall(i int, result1 db.BuildPreparati
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.priorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeBuild) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeBuild) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeBuild) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeBuild) PrivatePlan() atc.Plan {
 	fake.privatePlanMutex.Lock()
 	ret, specificReturn := fake.privatePlanReturnsOnCall[len(fake.privatePlanArgsForCall)]
@@ -2671,6 +3120,67 @@ func (fake *FakeBuild) ReloadReturnsOnCall(i int, result1 bool, result2 error) {
 	}{result1, result2}
 }
 
+func (fake *FakeBuild) RequestApproval(arg1 atc.PlanID, arg2 string) error {
+	fake.requestApprovalMutex.Lock()
+	ret, specificReturn := fake.requestApprovalReturnsOnCall[len(fake.requestApprovalArgsForCall)]
+	fake.requestApprovalArgsForCall = append(fake.requestApprovalArgsForCall, struct {
+		arg1 atc.PlanID
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("RequestApproval", []interface{}{arg1, arg2})
+	fake.requestApprovalMutex.Unlock()
+	if fake.RequestApprovalStub != nil {
+		return fake.RequestApprovalStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.requestApprovalReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) RequestApprovalCallCount() int {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	return len(fake.requestApprovalArgsForCall)
+}
+
+func (fake *FakeBuild) RequestApprovalCalls(stub func(atc.PlanID, string) error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = stub
+}
+
+func (fake *FakeBuild) RequestApprovalArgsForCall(i int) (atc.PlanID, string) {
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
+	argsForCall := fake.requestApprovalArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuild) RequestApprovalReturns(result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	fake.requestApprovalReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) RequestApprovalReturnsOnCall(i int, result1 error) {
+	fake.requestApprovalMutex.Lock()
+	defer fake.requestApprovalMutex.Unlock()
+	fake.RequestApprovalStub = nil
+	if fake.requestApprovalReturnsOnCall == nil {
+		fake.requestApprovalReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.requestApprovalReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) RerunNumber() int {
 	fake.rerunNumberMutex.Lock()
 	ret, specificReturn := fake.rerunNumberReturnsOnCall[len(fake.rerunNumberArgsForCall)]
@@ -3035,6 +3545,58 @@ func (fake *FakeBuild) ResourceTypeNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakeBuild) ResourceUsage() []atc.StepResourceUsage {
+	fake.resourceUsageMutex.Lock()
+	ret, specificReturn := fake.resourceUsageReturnsOnCall[len(fake.resourceUsageArgsForCall)]
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ResourceUsage", []interface{}{})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		return fake.ResourceUsageStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourceUsageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) ResourceUsageCalls(stub func() []atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeBuild) ResourceUsageReturns(result1 []atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = nil
+	fake.resourceUsageReturns = struct {
+		result1 []atc.StepResourceUsage
+	}{result1}
+}
+
+func (fake *FakeBuild) ResourceUsageReturnsOnCall(i int, result1 []atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = nil
+	if fake.resourceUsageReturnsOnCall == nil {
+		fake.resourceUsageReturnsOnCall = make(map[int]struct {
+			result1 []atc.StepResourceUsage
+		})
+	}
+	fake.resourceUsageReturnsOnCall[i] = struct {
+		result1 []atc.StepResourceUsage
+	}{result1}
+}
+
 func (fake *FakeBuild) Resources() ([]db.BuildInput, []db.BuildOutput, error) {
 	fake.resourcesMutex.Lock()
 	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
@@ -3268,22 +3830,23 @@ func (fake *FakeBuild) SaveImageResourceVersionReturnsOnCall(i int, result1 erro
 	}{result1}
 }
 
-func (fake *FakeBuild) SaveOutput(arg1 string, arg2 atc.Source, arg3 atc.VersionedResourceTypes, arg4 atc.Version, arg5 db.ResourceConfigMetadataFields, arg6 string, arg7 string) error {
+func (fake *FakeBuild) SaveOutput(arg1 db.SpanContext, arg2 string, arg3 atc.Source, arg4 atc.VersionedResourceTypes, arg5 atc.Version, arg6 db.ResourceConfigMetadataFields, arg7 string, arg8 string) error {
 	fake.saveOutputMutex.Lock()
 	ret, specificReturn := fake.saveOutputReturnsOnCall[len(fake.saveOutputArgsForCall)]
 	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
-		arg1 string
-		arg2 atc.Source
-		arg3 atc.VersionedResourceTypes
-		arg4 atc.Version
-		arg5 db.ResourceConfigMetadataFields
-		arg6 string
+		arg1 db.SpanContext
+		arg2 string
+		arg3 atc.Source
+		arg4 atc.VersionedResourceTypes
+		arg5 atc.Version
+		arg6 db.ResourceConfigMetadataFields
 		arg7 string
-	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
-	fake.recordInvocation("SaveOutput", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7})
+		arg8 string
+	}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
+	fake.recordInvocation("SaveOutput", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8})
 	fake.saveOutputMutex.Unlock()
 	if fake.SaveOutputStub != nil {
-		return fake.SaveOutputStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7)
+		return fake.SaveOutputStub(arg1, arg2, arg3, arg4, arg5, arg6, arg7, arg8)
 	}
 	if specificReturn {
 		return ret.result1
@@ -3298,17 +3861,17 @@ func (fake *FakeBuild) SaveOutputCallCount() int {
 	return len(fake.saveOutputArgsForCall)
 }
 
-func (fake *FakeBuild) SaveOutputCalls(stub func(string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error) {
+func (fake *FakeBuild) SaveOutputCalls(stub func(db.SpanContext, string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) error) {
 	fake.saveOutputMutex.Lock()
 	defer fake.saveOutputMutex.Unlock()
 	fake.SaveOutputStub = stub
 }
 
-func (fake *FakeBuild) SaveOutputArgsForCall(i int) (string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) {
+func (fake *FakeBuild) SaveOutputArgsForCall(i int) (db.SpanContext, string, atc.Source, atc.VersionedResourceTypes, atc.Version, db.ResourceConfigMetadataFields, string, string) {
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	argsForCall := fake.saveOutputArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6, argsForCall.arg7, argsForCall.arg8
 }
 
 func (fake *FakeBuild) SaveOutputReturns(result1 error) {
@@ -3334,7 +3897,12 @@ func (fake *FakeBuild) SaveOutputReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
-func (fake *FakeBuild) SavePipeline(arg1 atc.PipelineRef, arg2 int, arg3 atc.Config, arg4 db.ConfigVersion, arg5 bool) (db.Pipeline, bool, error) {
+func (fake *FakeBuild) SavePipeline(arg1 atc.PipelineRef, arg2 int, arg3 atc.Config, arg4 db.ConfigVersion, arg5 bool, arg6 []string) (db.Pipeline, bool, error) {
+	var arg6Copy []string
+	if arg6 != nil {
+		arg6Copy = make([]string, len(arg6))
+		copy(arg6Copy, arg6)
+	}
 	fake.savePipelineMutex.Lock()
 	ret, specificReturn := fake.savePipelineReturnsOnCall[len(fake.savePipelineArgsForCall)]
 	fake.savePipelineArgsForCall = append(fake.savePipelineArgsForCall, struct {
@@ -3343,11 +3911,12 @@ func (fake *FakeBuild) SavePipeline(arg1 atc.PipelineRef, arg2 int, arg3 atc.Con
 		arg3 atc.Config
 		arg4 db.ConfigVersion
 		arg5 bool
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg6 []string
+	}{arg1, arg2, arg3, arg4, arg5, arg6Copy})
+	fake.recordInvocation("SavePipeline", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6Copy})
 	fake.savePipelineMutex.Unlock()
 	if fake.SavePipelineStub != nil {
-		return fake.SavePipelineStub(arg1, arg2, arg3, arg4, arg5)
+		return fake.SavePipelineStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 	if specificReturn {
 		return ret.result1, ret.result2, ret.result3
@@ -3362,17 +3931,17 @@ func (fake *FakeBuild) SavePipelineCallCount() int {
 	return len(fake.savePipelineArgsForCall)
 }
 
-func (fake *FakeBuild) SavePipelineCalls(stub func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) (db.Pipeline, bool, error)) {
+func (fake *FakeBuild) SavePipelineCalls(stub func(atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool, []string) (db.Pipeline, bool, error)) {
 	fake.savePipelineMutex.Lock()
 	defer fake.savePipelineMutex.Unlock()
 	fake.SavePipelineStub = stub
 }
 
-func (fake *FakeBuild) SavePipelineArgsForCall(i int) (atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool) {
+func (fake *FakeBuild) SavePipelineArgsForCall(i int) (atc.PipelineRef, int, atc.Config, db.ConfigVersion, bool, []string) {
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
 	argsForCall := fake.savePipelineArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakeBuild) SavePipelineReturns(result1 db.Pipeline, result2 bool, result3 error) {
@@ -3404,6 +3973,66 @@ func (fake *FakeBuild) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, res
 	}{result1, result2, result3}
 }
 
+func (fake *FakeBuild) SaveStepResourceUsage(arg1 atc.StepResourceUsage) error {
+	fake.saveStepResourceUsageMutex.Lock()
+	ret, specificReturn := fake.saveStepResourceUsageReturnsOnCall[len(fake.saveStepResourceUsageArgsForCall)]
+	fake.saveStepResourceUsageArgsForCall = append(fake.saveStepResourceUsageArgsForCall, struct {
+		arg1 atc.StepResourceUsage
+	}{arg1})
+	fake.recordInvocation("SaveStepResourceUsage", []interface{}{arg1})
+	fake.saveStepResourceUsageMutex.Unlock()
+	if fake.SaveStepResourceUsageStub != nil {
+		return fake.SaveStepResourceUsageStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.saveStepResourceUsageReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SaveStepResourceUsageCallCount() int {
+	fake.saveStepResourceUsageMutex.RLock()
+	defer fake.saveStepResourceUsageMutex.RUnlock()
+	return len(fake.saveStepResourceUsageArgsForCall)
+}
+
+func (fake *FakeBuild) SaveStepResourceUsageCalls(stub func(atc.StepResourceUsage) error) {
+	fake.saveStepResourceUsageMutex.Lock()
+	defer fake.saveStepResourceUsageMutex.Unlock()
+	fake.SaveStepResourceUsageStub = stub
+}
+
+func (fake *FakeBuild) SaveStepResourceUsageArgsForCall(i int) atc.StepResourceUsage {
+	fake.saveStepResourceUsageMutex.RLock()
+	defer fake.saveStepResourceUsageMutex.RUnlock()
+	argsForCall := fake.saveStepResourceUsageArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SaveStepResourceUsageReturns(result1 error) {
+	fake.saveStepResourceUsageMutex.Lock()
+	defer fake.saveStepResourceUsageMutex.Unlock()
+	fake.SaveStepResourceUsageStub = nil
+	fake.saveStepResourceUsageReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SaveStepResourceUsageReturnsOnCall(i int, result1 error) {
+	fake.saveStepResourceUsageMutex.Lock()
+	defer fake.saveStepResourceUsageMutex.Unlock()
+	fake.SaveStepResourceUsageStub = nil
+	if fake.saveStepResourceUsageReturnsOnCall == nil {
+		fake.saveStepResourceUsageReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.saveStepResourceUsageReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) Schema() string {
 	fake.schemaMutex.Lock()
 	ret, specificReturn := fake.schemaReturnsOnCall[len(fake.schemaArgsForCall)]
@@ -3516,6 +4145,66 @@ func (fake *FakeBuild) SetDrainedReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeBuild) SetDrainedSink(arg1 string) error {
+	fake.setDrainedSinkMutex.Lock()
+	ret, specificReturn := fake.setDrainedSinkReturnsOnCall[len(fake.setDrainedSinkArgsForCall)]
+	fake.setDrainedSinkArgsForCall = append(fake.setDrainedSinkArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("SetDrainedSink", []interface{}{arg1})
+	fake.setDrainedSinkMutex.Unlock()
+	if fake.SetDrainedSinkStub != nil {
+		return fake.SetDrainedSinkStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.setDrainedSinkReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuild) SetDrainedSinkCallCount() int {
+	fake.setDrainedSinkMutex.RLock()
+	defer fake.setDrainedSinkMutex.RUnlock()
+	return len(fake.setDrainedSinkArgsForCall)
+}
+
+func (fake *FakeBuild) SetDrainedSinkCalls(stub func(string) error) {
+	fake.setDrainedSinkMutex.Lock()
+	defer fake.setDrainedSinkMutex.Unlock()
+	fake.SetDrainedSinkStub = stub
+}
+
+func (fake *FakeBuild) SetDrainedSinkArgsForCall(i int) string {
+	fake.setDrainedSinkMutex.RLock()
+	defer fake.setDrainedSinkMutex.RUnlock()
+	argsForCall := fake.setDrainedSinkArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) SetDrainedSinkReturns(result1 error) {
+	fake.setDrainedSinkMutex.Lock()
+	defer fake.setDrainedSinkMutex.Unlock()
+	fake.SetDrainedSinkStub = nil
+	fake.setDrainedSinkReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuild) SetDrainedSinkReturnsOnCall(i int, result1 error) {
+	fake.setDrainedSinkMutex.Lock()
+	defer fake.setDrainedSinkMutex.Unlock()
+	fake.SetDrainedSinkStub = nil
+	if fake.setDrainedSinkReturnsOnCall == nil {
+		fake.setDrainedSinkReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.setDrainedSinkReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeBuild) SetInterceptible(arg1 bool) error {
 	fake.setInterceptibleMutex.Lock()
 	ret, specificReturn := fake.setInterceptibleReturnsOnCall[len(fake.setInterceptibleArgsForCall)]
@@ -3628,6 +4317,61 @@ func (fake *FakeBuild) SpanContextReturnsOnCall(i int, result1 propagation.HTTPS
 	}{result1}
 }
 
+func (fake *FakeBuild) SpanLinks() ([]propagation.HTTPSupplier, error) {
+	fake.spanLinksMutex.Lock()
+	ret, specificReturn := fake.spanLinksReturnsOnCall[len(fake.spanLinksArgsForCall)]
+	fake.spanLinksArgsForCall = append(fake.spanLinksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("SpanLinks", []interface{}{})
+	fake.spanLinksMutex.Unlock()
+	if fake.SpanLinksStub != nil {
+		return fake.SpanLinksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.spanLinksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) SpanLinksCallCount() int {
+	fake.spanLinksMutex.RLock()
+	defer fake.spanLinksMutex.RUnlock()
+	return len(fake.spanLinksArgsForCall)
+}
+
+func (fake *FakeBuild) SpanLinksCalls(stub func() ([]propagation.HTTPSupplier, error)) {
+	fake.spanLinksMutex.Lock()
+	defer fake.spanLinksMutex.Unlock()
+	fake.SpanLinksStub = stub
+}
+
+func (fake *FakeBuild) SpanLinksReturns(result1 []propagation.HTTPSupplier, result2 error) {
+	fake.spanLinksMutex.Lock()
+	defer fake.spanLinksMutex.Unlock()
+	fake.SpanLinksStub = nil
+	fake.spanLinksReturns = struct {
+		result1 []propagation.HTTPSupplier
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) SpanLinksReturnsOnCall(i int, result1 []propagation.HTTPSupplier, result2 error) {
+	fake.spanLinksMutex.Lock()
+	defer fake.spanLinksMutex.Unlock()
+	fake.SpanLinksStub = nil
+	if fake.spanLinksReturnsOnCall == nil {
+		fake.spanLinksReturnsOnCall = make(map[int]struct {
+			result1 []propagation.HTTPSupplier
+			result2 error
+		})
+	}
+	fake.spanLinksReturnsOnCall[i] = struct {
+		result1 []propagation.HTTPSupplier
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) Start(arg1 atc.Plan) (bool, error) {
 	fake.startMutex.Lock()
 	ret, specificReturn := fake.startReturnsOnCall[len(fake.startArgsForCall)]
@@ -3795,6 +4539,127 @@ func (fake *FakeBuild) StatusReturnsOnCall(i int, result1 db.BuildStatus) {
 	}{result1}
 }
 
+func (fake *FakeBuild) StoredArtifact(arg1 int) (db.StoredArtifact, bool, error) {
+	fake.storedArtifactMutex.Lock()
+	ret, specificReturn := fake.storedArtifactReturnsOnCall[len(fake.storedArtifactArgsForCall)]
+	fake.storedArtifactArgsForCall = append(fake.storedArtifactArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("StoredArtifact", []interface{}{arg1})
+	fake.storedArtifactMutex.Unlock()
+	if fake.StoredArtifactStub != nil {
+		return fake.StoredArtifactStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.storedArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeBuild) StoredArtifactCallCount() int {
+	fake.storedArtifactMutex.RLock()
+	defer fake.storedArtifactMutex.RUnlock()
+	return len(fake.storedArtifactArgsForCall)
+}
+
+func (fake *FakeBuild) StoredArtifactCalls(stub func(int) (db.StoredArtifact, bool, error)) {
+	fake.storedArtifactMutex.Lock()
+	defer fake.storedArtifactMutex.Unlock()
+	fake.StoredArtifactStub = stub
+}
+
+func (fake *FakeBuild) StoredArtifactArgsForCall(i int) int {
+	fake.storedArtifactMutex.RLock()
+	defer fake.storedArtifactMutex.RUnlock()
+	argsForCall := fake.storedArtifactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeBuild) StoredArtifactReturns(result1 db.StoredArtifact, result2 bool, result3 error) {
+	fake.storedArtifactMutex.Lock()
+	defer fake.storedArtifactMutex.Unlock()
+	fake.StoredArtifactStub = nil
+	fake.storedArtifactReturns = struct {
+		result1 db.StoredArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) StoredArtifactReturnsOnCall(i int, result1 db.StoredArtifact, result2 bool, result3 error) {
+	fake.storedArtifactMutex.Lock()
+	defer fake.storedArtifactMutex.Unlock()
+	fake.StoredArtifactStub = nil
+	if fake.storedArtifactReturnsOnCall == nil {
+		fake.storedArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.StoredArtifact
+			result2 bool
+			result3 error
+		})
+	}
+	fake.storedArtifactReturnsOnCall[i] = struct {
+		result1 db.StoredArtifact
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeBuild) StoredArtifacts() ([]db.StoredArtifact, error) {
+	fake.storedArtifactsMutex.Lock()
+	ret, specificReturn := fake.storedArtifactsReturnsOnCall[len(fake.storedArtifactsArgsForCall)]
+	fake.storedArtifactsArgsForCall = append(fake.storedArtifactsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("StoredArtifacts", []interface{}{})
+	fake.storedArtifactsMutex.Unlock()
+	if fake.StoredArtifactsStub != nil {
+		return fake.StoredArtifactsStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.storedArtifactsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuild) StoredArtifactsCallCount() int {
+	fake.storedArtifactsMutex.RLock()
+	defer fake.storedArtifactsMutex.RUnlock()
+	return len(fake.storedArtifactsArgsForCall)
+}
+
+func (fake *FakeBuild) StoredArtifactsCalls(stub func() ([]db.StoredArtifact, error)) {
+	fake.storedArtifactsMutex.Lock()
+	defer fake.storedArtifactsMutex.Unlock()
+	fake.StoredArtifactsStub = stub
+}
+
+func (fake *FakeBuild) StoredArtifactsReturns(result1 []db.StoredArtifact, result2 error) {
+	fake.storedArtifactsMutex.Lock()
+	defer fake.storedArtifactsMutex.Unlock()
+	fake.StoredArtifactsStub = nil
+	fake.storedArtifactsReturns = struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuild) StoredArtifactsReturnsOnCall(i int, result1 []db.StoredArtifact, result2 error) {
+	fake.storedArtifactsMutex.Lock()
+	defer fake.storedArtifactsMutex.Unlock()
+	fake.StoredArtifactsStub = nil
+	if fake.storedArtifactsReturnsOnCall == nil {
+		fake.storedArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []db.StoredArtifact
+			result2 error
+		})
+	}
+	fake.storedArtifactsReturnsOnCall[i] = struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeBuild) SyslogTag(arg1 event.OriginID) string {
 	fake.syslogTagMutex.Lock()
 	ret, specificReturn := fake.syslogTagReturnsOnCall[len(fake.syslogTagArgsForCall)]
@@ -4087,12 +4952,20 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.adoptInputsAndPipesMutex.RUnlock()
 	fake.adoptRerunInputsAndPipesMutex.RLock()
 	defer fake.adoptRerunInputsAndPipesMutex.RUnlock()
+	fake.approvalMutex.RLock()
+	defer fake.approvalMutex.RUnlock()
+	fake.approvalNotifierMutex.RLock()
+	defer fake.approvalNotifierMutex.RUnlock()
 	fake.artifactMutex.RLock()
 	defer fake.artifactMutex.RUnlock()
 	fake.artifactsMutex.RLock()
 	defer fake.artifactsMutex.RUnlock()
+	fake.decideApprovalMutex.RLock()
+	defer fake.decideApprovalMutex.RUnlock()
 	fake.deleteMutex.RLock()
 	defer fake.deleteMutex.RUnlock()
+	fake.drainedSinksMutex.RLock()
+	defer fake.drainedSinksMutex.RUnlock()
 	fake.endTimeMutex.RLock()
 	defer fake.endTimeMutex.RUnlock()
 	fake.eventsMutex.RLock()
@@ -4143,6 +5016,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.pipelineRefMutex.RUnlock()
 	fake.preparationMutex.RLock()
 	defer fake.preparationMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.privatePlanMutex.RLock()
 	defer fake.privatePlanMutex.RUnlock()
 	fake.publicPlanMutex.RLock()
@@ -4151,6 +5026,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.reapTimeMutex.RUnlock()
 	fake.reloadMutex.RLock()
 	defer fake.reloadMutex.RUnlock()
+	fake.requestApprovalMutex.RLock()
+	defer fake.requestApprovalMutex.RUnlock()
 	fake.rerunNumberMutex.RLock()
 	defer fake.rerunNumberMutex.RUnlock()
 	fake.rerunOfMutex.RLock()
@@ -4165,6 +5042,8 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.resourceTypeIDMutex.RUnlock()
 	fake.resourceTypeNameMutex.RLock()
 	defer fake.resourceTypeNameMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.resourcesMutex.RLock()
 	defer fake.resourcesMutex.RUnlock()
 	fake.resourcesCheckedMutex.RLock()
@@ -4177,20 +5056,30 @@ func (fake *FakeBuild) Invocations() map[string][][]interface{} {
 	defer fake.saveOutputMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.saveStepResourceUsageMutex.RLock()
+	defer fake.saveStepResourceUsageMutex.RUnlock()
 	fake.schemaMutex.RLock()
 	defer fake.schemaMutex.RUnlock()
 	fake.setDrainedMutex.RLock()
 	defer fake.setDrainedMutex.RUnlock()
+	fake.setDrainedSinkMutex.RLock()
+	defer fake.setDrainedSinkMutex.RUnlock()
 	fake.setInterceptibleMutex.RLock()
 	defer fake.setInterceptibleMutex.RUnlock()
 	fake.spanContextMutex.RLock()
 	defer fake.spanContextMutex.RUnlock()
+	fake.spanLinksMutex.RLock()
+	defer fake.spanLinksMutex.RUnlock()
 	fake.startMutex.RLock()
 	defer fake.startMutex.RUnlock()
 	fake.startTimeMutex.RLock()
 	defer fake.startTimeMutex.RUnlock()
 	fake.statusMutex.RLock()
 	defer fake.statusMutex.RUnlock()
+	fake.storedArtifactMutex.RLock()
+	defer fake.storedArtifactMutex.RUnlock()
+	fake.storedArtifactsMutex.RLock()
+	defer fake.storedArtifactsMutex.RUnlock()
 	fake.syslogTagMutex.RLock()
 	defer fake.syslogTagMutex.RUnlock()
 	fake.teamIDMutex.RLock()
@@ -0,0 +1,198 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildEventArchiver struct {
+	ArchiveBuildEventsStub        func(context.Context, int) (int, error)
+	archiveBuildEventsMutex       sync.RWMutex
+	archiveBuildEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	archiveBuildEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	archiveBuildEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	DeleteOrphanedBuildEventsStub        func(context.Context, int) (int, error)
+	deleteOrphanedBuildEventsMutex       sync.RWMutex
+	deleteOrphanedBuildEventsArgsForCall []struct {
+		arg1 context.Context
+		arg2 int
+	}
+	deleteOrphanedBuildEventsReturns struct {
+		result1 int
+		result2 error
+	}
+	deleteOrphanedBuildEventsReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEvents(arg1 context.Context, arg2 int) (int, error) {
+	fake.archiveBuildEventsMutex.Lock()
+	ret, specificReturn := fake.archiveBuildEventsReturnsOnCall[len(fake.archiveBuildEventsArgsForCall)]
+	fake.archiveBuildEventsArgsForCall = append(fake.archiveBuildEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("ArchiveBuildEvents", []interface{}{arg1, arg2})
+	fake.archiveBuildEventsMutex.Unlock()
+	if fake.ArchiveBuildEventsStub != nil {
+		return fake.ArchiveBuildEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.archiveBuildEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEventsCallCount() int {
+	fake.archiveBuildEventsMutex.RLock()
+	defer fake.archiveBuildEventsMutex.RUnlock()
+	return len(fake.archiveBuildEventsArgsForCall)
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEventsCalls(stub func(context.Context, int) (int, error)) {
+	fake.archiveBuildEventsMutex.Lock()
+	defer fake.archiveBuildEventsMutex.Unlock()
+	fake.ArchiveBuildEventsStub = stub
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEventsArgsForCall(i int) (context.Context, int) {
+	fake.archiveBuildEventsMutex.RLock()
+	defer fake.archiveBuildEventsMutex.RUnlock()
+	argsForCall := fake.archiveBuildEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEventsReturns(result1 int, result2 error) {
+	fake.archiveBuildEventsMutex.Lock()
+	defer fake.archiveBuildEventsMutex.Unlock()
+	fake.ArchiveBuildEventsStub = nil
+	fake.archiveBuildEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventArchiver) ArchiveBuildEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.archiveBuildEventsMutex.Lock()
+	defer fake.archiveBuildEventsMutex.Unlock()
+	fake.ArchiveBuildEventsStub = nil
+	if fake.archiveBuildEventsReturnsOnCall == nil {
+		fake.archiveBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.archiveBuildEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEvents(arg1 context.Context, arg2 int) (int, error) {
+	fake.deleteOrphanedBuildEventsMutex.Lock()
+	ret, specificReturn := fake.deleteOrphanedBuildEventsReturnsOnCall[len(fake.deleteOrphanedBuildEventsArgsForCall)]
+	fake.deleteOrphanedBuildEventsArgsForCall = append(fake.deleteOrphanedBuildEventsArgsForCall, struct {
+		arg1 context.Context
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteOrphanedBuildEvents", []interface{}{arg1, arg2})
+	fake.deleteOrphanedBuildEventsMutex.Unlock()
+	if fake.DeleteOrphanedBuildEventsStub != nil {
+		return fake.DeleteOrphanedBuildEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.deleteOrphanedBuildEventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEventsCallCount() int {
+	fake.deleteOrphanedBuildEventsMutex.RLock()
+	defer fake.deleteOrphanedBuildEventsMutex.RUnlock()
+	return len(fake.deleteOrphanedBuildEventsArgsForCall)
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEventsCalls(stub func(context.Context, int) (int, error)) {
+	fake.deleteOrphanedBuildEventsMutex.Lock()
+	defer fake.deleteOrphanedBuildEventsMutex.Unlock()
+	fake.DeleteOrphanedBuildEventsStub = stub
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEventsArgsForCall(i int) (context.Context, int) {
+	fake.deleteOrphanedBuildEventsMutex.RLock()
+	defer fake.deleteOrphanedBuildEventsMutex.RUnlock()
+	argsForCall := fake.deleteOrphanedBuildEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEventsReturns(result1 int, result2 error) {
+	fake.deleteOrphanedBuildEventsMutex.Lock()
+	defer fake.deleteOrphanedBuildEventsMutex.Unlock()
+	fake.DeleteOrphanedBuildEventsStub = nil
+	fake.deleteOrphanedBuildEventsReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventArchiver) DeleteOrphanedBuildEventsReturnsOnCall(i int, result1 int, result2 error) {
+	fake.deleteOrphanedBuildEventsMutex.Lock()
+	defer fake.deleteOrphanedBuildEventsMutex.Unlock()
+	fake.DeleteOrphanedBuildEventsStub = nil
+	if fake.deleteOrphanedBuildEventsReturnsOnCall == nil {
+		fake.deleteOrphanedBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.deleteOrphanedBuildEventsReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventArchiver) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.archiveBuildEventsMutex.RLock()
+	defer fake.archiveBuildEventsMutex.RUnlock()
+	fake.deleteOrphanedBuildEventsMutex.RLock()
+	defer fake.deleteOrphanedBuildEventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildEventArchiver) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildEventArchiver = new(FakeBuildEventArchiver)
@@ -0,0 +1,197 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeBuildEventStore struct {
+	DeleteBuildEventsStub        func(db.Pipeline, []int) error
+	deleteBuildEventsMutex       sync.RWMutex
+	deleteBuildEventsArgsForCall []struct {
+		arg1 db.Pipeline
+		arg2 []int
+	}
+	deleteBuildEventsReturns struct {
+		result1 error
+	}
+	deleteBuildEventsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	EventsStub        func(db.Build, uint) (db.EventSource, error)
+	eventsMutex       sync.RWMutex
+	eventsArgsForCall []struct {
+		arg1 db.Build
+		arg2 uint
+	}
+	eventsReturns struct {
+		result1 db.EventSource
+		result2 error
+	}
+	eventsReturnsOnCall map[int]struct {
+		result1 db.EventSource
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEvents(arg1 db.Pipeline, arg2 []int) error {
+	var arg2Copy []int
+	if arg2 != nil {
+		arg2Copy = make([]int, len(arg2))
+		copy(arg2Copy, arg2)
+	}
+	fake.deleteBuildEventsMutex.Lock()
+	ret, specificReturn := fake.deleteBuildEventsReturnsOnCall[len(fake.deleteBuildEventsArgsForCall)]
+	fake.deleteBuildEventsArgsForCall = append(fake.deleteBuildEventsArgsForCall, struct {
+		arg1 db.Pipeline
+		arg2 []int
+	}{arg1, arg2Copy})
+	fake.recordInvocation("DeleteBuildEvents", []interface{}{arg1, arg2Copy})
+	fake.deleteBuildEventsMutex.Unlock()
+	if fake.DeleteBuildEventsStub != nil {
+		return fake.DeleteBuildEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteBuildEventsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEventsCallCount() int {
+	fake.deleteBuildEventsMutex.RLock()
+	defer fake.deleteBuildEventsMutex.RUnlock()
+	return len(fake.deleteBuildEventsArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEventsCalls(stub func(db.Pipeline, []int) error) {
+	fake.deleteBuildEventsMutex.Lock()
+	defer fake.deleteBuildEventsMutex.Unlock()
+	fake.DeleteBuildEventsStub = stub
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEventsArgsForCall(i int) (db.Pipeline, []int) {
+	fake.deleteBuildEventsMutex.RLock()
+	defer fake.deleteBuildEventsMutex.RUnlock()
+	argsForCall := fake.deleteBuildEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEventsReturns(result1 error) {
+	fake.deleteBuildEventsMutex.Lock()
+	defer fake.deleteBuildEventsMutex.Unlock()
+	fake.DeleteBuildEventsStub = nil
+	fake.deleteBuildEventsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventStore) DeleteBuildEventsReturnsOnCall(i int, result1 error) {
+	fake.deleteBuildEventsMutex.Lock()
+	defer fake.deleteBuildEventsMutex.Unlock()
+	fake.DeleteBuildEventsStub = nil
+	if fake.deleteBuildEventsReturnsOnCall == nil {
+		fake.deleteBuildEventsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteBuildEventsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeBuildEventStore) Events(arg1 db.Build, arg2 uint) (db.EventSource, error) {
+	fake.eventsMutex.Lock()
+	ret, specificReturn := fake.eventsReturnsOnCall[len(fake.eventsArgsForCall)]
+	fake.eventsArgsForCall = append(fake.eventsArgsForCall, struct {
+		arg1 db.Build
+		arg2 uint
+	}{arg1, arg2})
+	fake.recordInvocation("Events", []interface{}{arg1, arg2})
+	fake.eventsMutex.Unlock()
+	if fake.EventsStub != nil {
+		return fake.EventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.eventsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeBuildEventStore) EventsCallCount() int {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	return len(fake.eventsArgsForCall)
+}
+
+func (fake *FakeBuildEventStore) EventsCalls(stub func(db.Build, uint) (db.EventSource, error)) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = stub
+}
+
+func (fake *FakeBuildEventStore) EventsArgsForCall(i int) (db.Build, uint) {
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	argsForCall := fake.eventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeBuildEventStore) EventsReturns(result1 db.EventSource, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	fake.eventsReturns = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventStore) EventsReturnsOnCall(i int, result1 db.EventSource, result2 error) {
+	fake.eventsMutex.Lock()
+	defer fake.eventsMutex.Unlock()
+	fake.EventsStub = nil
+	if fake.eventsReturnsOnCall == nil {
+		fake.eventsReturnsOnCall = make(map[int]struct {
+			result1 db.EventSource
+			result2 error
+		})
+	}
+	fake.eventsReturnsOnCall[i] = struct {
+		result1 db.EventSource
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeBuildEventStore) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteBuildEventsMutex.RLock()
+	defer fake.deleteBuildEventsMutex.RUnlock()
+	fake.eventsMutex.RLock()
+	defer fake.eventsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeBuildEventStore) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.BuildEventStore = new(FakeBuildEventStore)
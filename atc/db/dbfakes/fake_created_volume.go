@@ -91,6 +91,17 @@ type FakeCreatedVolume struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
+	InitializeKeyedCacheStub        func(string) error
+	initializeKeyedCacheMutex       sync.RWMutex
+	initializeKeyedCacheArgsForCall []struct {
+		arg1 string
+	}
+	initializeKeyedCacheReturns struct {
+		result1 error
+	}
+	initializeKeyedCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitializeResourceCacheStub        func(db.UsedResourceCache) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
@@ -603,6 +614,66 @@ func (fake *FakeCreatedVolume) InitializeArtifactReturnsOnCall(i int, result1 db
 	}{result1, result2}
 }
 
+func (fake *FakeCreatedVolume) InitializeKeyedCache(arg1 string) error {
+	fake.initializeKeyedCacheMutex.Lock()
+	ret, specificReturn := fake.initializeKeyedCacheReturnsOnCall[len(fake.initializeKeyedCacheArgsForCall)]
+	fake.initializeKeyedCacheArgsForCall = append(fake.initializeKeyedCacheArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("InitializeKeyedCache", []interface{}{arg1})
+	fake.initializeKeyedCacheMutex.Unlock()
+	if fake.InitializeKeyedCacheStub != nil {
+		return fake.InitializeKeyedCacheStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.initializeKeyedCacheReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheCallCount() int {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	return len(fake.initializeKeyedCacheArgsForCall)
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheCalls(stub func(string) error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = stub
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheArgsForCall(i int) string {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	argsForCall := fake.initializeKeyedCacheArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheReturns(result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	fake.initializeKeyedCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeCreatedVolume) InitializeKeyedCacheReturnsOnCall(i int, result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	if fake.initializeKeyedCacheReturnsOnCall == nil {
+		fake.initializeKeyedCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeKeyedCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeCreatedVolume) InitializeResourceCache(arg1 db.UsedResourceCache) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
@@ -1173,6 +1244,8 @@ func (fake *FakeCreatedVolume) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.initializeArtifactMutex.RLock()
 	defer fake.initializeArtifactMutex.RUnlock()
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
@@ -0,0 +1,204 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+type FakeFairShare struct {
+	AcquireTeamSchedulingLockStub        func(lager.Logger, int) (lock.Lock, bool, error)
+	acquireTeamSchedulingLockMutex       sync.RWMutex
+	acquireTeamSchedulingLockArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 int
+	}
+	acquireTeamSchedulingLockReturns struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	acquireTeamSchedulingLockReturnsOnCall map[int]struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}
+	CanStartBuildStub        func(int, int) (bool, error)
+	canStartBuildMutex       sync.RWMutex
+	canStartBuildArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	canStartBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	canStartBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLock(arg1 lager.Logger, arg2 int) (lock.Lock, bool, error) {
+	fake.acquireTeamSchedulingLockMutex.Lock()
+	ret, specificReturn := fake.acquireTeamSchedulingLockReturnsOnCall[len(fake.acquireTeamSchedulingLockArgsForCall)]
+	fake.acquireTeamSchedulingLockArgsForCall = append(fake.acquireTeamSchedulingLockArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("AcquireTeamSchedulingLock", []interface{}{arg1, arg2})
+	fake.acquireTeamSchedulingLockMutex.Unlock()
+	if fake.AcquireTeamSchedulingLockStub != nil {
+		return fake.AcquireTeamSchedulingLockStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.acquireTeamSchedulingLockReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLockCallCount() int {
+	fake.acquireTeamSchedulingLockMutex.RLock()
+	defer fake.acquireTeamSchedulingLockMutex.RUnlock()
+	return len(fake.acquireTeamSchedulingLockArgsForCall)
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLockCalls(stub func(lager.Logger, int) (lock.Lock, bool, error)) {
+	fake.acquireTeamSchedulingLockMutex.Lock()
+	defer fake.acquireTeamSchedulingLockMutex.Unlock()
+	fake.AcquireTeamSchedulingLockStub = stub
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLockArgsForCall(i int) (lager.Logger, int) {
+	fake.acquireTeamSchedulingLockMutex.RLock()
+	defer fake.acquireTeamSchedulingLockMutex.RUnlock()
+	argsForCall := fake.acquireTeamSchedulingLockArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLockReturns(result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireTeamSchedulingLockMutex.Lock()
+	defer fake.acquireTeamSchedulingLockMutex.Unlock()
+	fake.AcquireTeamSchedulingLockStub = nil
+	fake.acquireTeamSchedulingLockReturns = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeFairShare) AcquireTeamSchedulingLockReturnsOnCall(i int, result1 lock.Lock, result2 bool, result3 error) {
+	fake.acquireTeamSchedulingLockMutex.Lock()
+	defer fake.acquireTeamSchedulingLockMutex.Unlock()
+	fake.AcquireTeamSchedulingLockStub = nil
+	if fake.acquireTeamSchedulingLockReturnsOnCall == nil {
+		fake.acquireTeamSchedulingLockReturnsOnCall = make(map[int]struct {
+			result1 lock.Lock
+			result2 bool
+			result3 error
+		})
+	}
+	fake.acquireTeamSchedulingLockReturnsOnCall[i] = struct {
+		result1 lock.Lock
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeFairShare) CanStartBuild(arg1 int, arg2 int) (bool, error) {
+	fake.canStartBuildMutex.Lock()
+	ret, specificReturn := fake.canStartBuildReturnsOnCall[len(fake.canStartBuildArgsForCall)]
+	fake.canStartBuildArgsForCall = append(fake.canStartBuildArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("CanStartBuild", []interface{}{arg1, arg2})
+	fake.canStartBuildMutex.Unlock()
+	if fake.CanStartBuildStub != nil {
+		return fake.CanStartBuildStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.canStartBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeFairShare) CanStartBuildCallCount() int {
+	fake.canStartBuildMutex.RLock()
+	defer fake.canStartBuildMutex.RUnlock()
+	return len(fake.canStartBuildArgsForCall)
+}
+
+func (fake *FakeFairShare) CanStartBuildCalls(stub func(int, int) (bool, error)) {
+	fake.canStartBuildMutex.Lock()
+	defer fake.canStartBuildMutex.Unlock()
+	fake.CanStartBuildStub = stub
+}
+
+func (fake *FakeFairShare) CanStartBuildArgsForCall(i int) (int, int) {
+	fake.canStartBuildMutex.RLock()
+	defer fake.canStartBuildMutex.RUnlock()
+	argsForCall := fake.canStartBuildArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeFairShare) CanStartBuildReturns(result1 bool, result2 error) {
+	fake.canStartBuildMutex.Lock()
+	defer fake.canStartBuildMutex.Unlock()
+	fake.CanStartBuildStub = nil
+	fake.canStartBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFairShare) CanStartBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.canStartBuildMutex.Lock()
+	defer fake.canStartBuildMutex.Unlock()
+	fake.CanStartBuildStub = nil
+	if fake.canStartBuildReturnsOnCall == nil {
+		fake.canStartBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.canStartBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeFairShare) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.acquireTeamSchedulingLockMutex.RLock()
+	defer fake.acquireTeamSchedulingLockMutex.RUnlock()
+	fake.canStartBuildMutex.RLock()
+	defer fake.canStartBuildMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeFairShare) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.FairShare = new(FakeFairShare)
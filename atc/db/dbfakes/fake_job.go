@@ -123,6 +123,31 @@ type FakeJob struct {
 		result1 db.Build
 		result2 error
 	}
+	CreateBuildWithPriorityStub        func(int) (db.Build, error)
+	createBuildWithPriorityMutex       sync.RWMutex
+	createBuildWithPriorityArgsForCall []struct {
+		arg1 int
+	}
+	createBuildWithPriorityReturns struct {
+		result1 db.Build
+		result2 error
+	}
+	createBuildWithPriorityReturnsOnCall map[int]struct {
+		result1 db.Build
+		result2 error
+	}
+	DependenciesSucceededSinceLastBuildStub        func() (bool, error)
+	dependenciesSucceededSinceLastBuildMutex       sync.RWMutex
+	dependenciesSucceededSinceLastBuildArgsForCall []struct {
+	}
+	dependenciesSucceededSinceLastBuildReturns struct {
+		result1 bool
+		result2 error
+	}
+	dependenciesSucceededSinceLastBuildReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DisableManualTriggerStub        func() bool
 	disableManualTriggerMutex       sync.RWMutex
 	disableManualTriggerArgsForCall []struct {
@@ -344,6 +369,16 @@ type FakeJob struct {
 	pipelineRefReturnsOnCall map[int]struct {
 		result1 atc.PipelineRef
 	}
+	PriorityStub        func() int
+	priorityMutex       sync.RWMutex
+	priorityArgsForCall []struct {
+	}
+	priorityReturns struct {
+		result1 int
+	}
+	priorityReturnsOnCall map[int]struct {
+		result1 int
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -994,6 +1029,124 @@ func (fake *FakeJob) CreateBuildReturnsOnCall(i int, result1 db.Build, result2 e
 	}{result1, result2}
 }
 
+func (fake *FakeJob) CreateBuildWithPriority(arg1 int) (db.Build, error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	ret, specificReturn := fake.createBuildWithPriorityReturnsOnCall[len(fake.createBuildWithPriorityArgsForCall)]
+	fake.createBuildWithPriorityArgsForCall = append(fake.createBuildWithPriorityArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("CreateBuildWithPriority", []interface{}{arg1})
+	fake.createBuildWithPriorityMutex.Unlock()
+	if fake.CreateBuildWithPriorityStub != nil {
+		return fake.CreateBuildWithPriorityStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createBuildWithPriorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityCallCount() int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	return len(fake.createBuildWithPriorityArgsForCall)
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityCalls(stub func(int) (db.Build, error)) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = stub
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityArgsForCall(i int) int {
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	argsForCall := fake.createBuildWithPriorityArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturns(result1 db.Build, result2 error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = nil
+	fake.createBuildWithPriorityReturns = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) CreateBuildWithPriorityReturnsOnCall(i int, result1 db.Build, result2 error) {
+	fake.createBuildWithPriorityMutex.Lock()
+	defer fake.createBuildWithPriorityMutex.Unlock()
+	fake.CreateBuildWithPriorityStub = nil
+	if fake.createBuildWithPriorityReturnsOnCall == nil {
+		fake.createBuildWithPriorityReturnsOnCall = make(map[int]struct {
+			result1 db.Build
+			result2 error
+		})
+	}
+	fake.createBuildWithPriorityReturnsOnCall[i] = struct {
+		result1 db.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) DependenciesSucceededSinceLastBuild() (bool, error) {
+	fake.dependenciesSucceededSinceLastBuildMutex.Lock()
+	ret, specificReturn := fake.dependenciesSucceededSinceLastBuildReturnsOnCall[len(fake.dependenciesSucceededSinceLastBuildArgsForCall)]
+	fake.dependenciesSucceededSinceLastBuildArgsForCall = append(fake.dependenciesSucceededSinceLastBuildArgsForCall, struct {
+	}{})
+	fake.recordInvocation("DependenciesSucceededSinceLastBuild", []interface{}{})
+	fake.dependenciesSucceededSinceLastBuildMutex.Unlock()
+	if fake.DependenciesSucceededSinceLastBuildStub != nil {
+		return fake.DependenciesSucceededSinceLastBuildStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.dependenciesSucceededSinceLastBuildReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeJob) DependenciesSucceededSinceLastBuildCallCount() int {
+	fake.dependenciesSucceededSinceLastBuildMutex.RLock()
+	defer fake.dependenciesSucceededSinceLastBuildMutex.RUnlock()
+	return len(fake.dependenciesSucceededSinceLastBuildArgsForCall)
+}
+
+func (fake *FakeJob) DependenciesSucceededSinceLastBuildCalls(stub func() (bool, error)) {
+	fake.dependenciesSucceededSinceLastBuildMutex.Lock()
+	defer fake.dependenciesSucceededSinceLastBuildMutex.Unlock()
+	fake.DependenciesSucceededSinceLastBuildStub = stub
+}
+
+func (fake *FakeJob) DependenciesSucceededSinceLastBuildReturns(result1 bool, result2 error) {
+	fake.dependenciesSucceededSinceLastBuildMutex.Lock()
+	defer fake.dependenciesSucceededSinceLastBuildMutex.Unlock()
+	fake.DependenciesSucceededSinceLastBuildStub = nil
+	fake.dependenciesSucceededSinceLastBuildReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeJob) DependenciesSucceededSinceLastBuildReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.dependenciesSucceededSinceLastBuildMutex.Lock()
+	defer fake.dependenciesSucceededSinceLastBuildMutex.Unlock()
+	fake.DependenciesSucceededSinceLastBuildStub = nil
+	if fake.dependenciesSucceededSinceLastBuildReturnsOnCall == nil {
+		fake.dependenciesSucceededSinceLastBuildReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.dependenciesSucceededSinceLastBuildReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeJob) DisableManualTrigger() bool {
 	fake.disableManualTriggerMutex.Lock()
 	ret, specificReturn := fake.disableManualTriggerReturnsOnCall[len(fake.disableManualTriggerArgsForCall)]
@@ -2072,6 +2225,58 @@ func (fake *FakeJob) PipelineRefReturnsOnCall(i int, result1 atc.PipelineRef) {
 	}{result1}
 }
 
+func (fake *FakeJob) Priority() int {
+	fake.priorityMutex.Lock()
+	ret, specificReturn := fake.priorityReturnsOnCall[len(fake.priorityArgsForCall)]
+	fake.priorityArgsForCall = append(fake.priorityArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Priority", []interface{}{})
+	fake.priorityMutex.Unlock()
+	if fake.PriorityStub != nil {
+		return fake.PriorityStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.priorityReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeJob) PriorityCallCount() int {
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
+	return len(fake.priorityArgsForCall)
+}
+
+func (fake *FakeJob) PriorityCalls(stub func() int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = stub
+}
+
+func (fake *FakeJob) PriorityReturns(result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	fake.priorityReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeJob) PriorityReturnsOnCall(i int, result1 int) {
+	fake.priorityMutex.Lock()
+	defer fake.priorityMutex.Unlock()
+	fake.PriorityStub = nil
+	if fake.priorityReturnsOnCall == nil {
+		fake.priorityReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.priorityReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeJob) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -2877,6 +3082,10 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.configMutex.RUnlock()
 	fake.createBuildMutex.RLock()
 	defer fake.createBuildMutex.RUnlock()
+	fake.createBuildWithPriorityMutex.RLock()
+	defer fake.createBuildWithPriorityMutex.RUnlock()
+	fake.dependenciesSucceededSinceLastBuildMutex.RLock()
+	defer fake.dependenciesSucceededSinceLastBuildMutex.RUnlock()
 	fake.disableManualTriggerMutex.RLock()
 	defer fake.disableManualTriggerMutex.RUnlock()
 	fake.ensurePendingBuildExistsMutex.RLock()
@@ -2917,6 +3126,8 @@ func (fake *FakeJob) Invocations() map[string][][]interface{} {
 	defer fake.pipelineNameMutex.RUnlock()
 	fake.pipelineRefMutex.RLock()
 	defer fake.pipelineRefMutex.RUnlock()
+	fake.priorityMutex.RLock()
+	defer fake.priorityMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -0,0 +1,134 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeKeyedCacheFactory struct {
+	FindCacheVolumeStub        func(int, string, []string) (db.CreatedVolume, string, bool, error)
+	findCacheVolumeMutex       sync.RWMutex
+	findCacheVolumeArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 []string
+	}
+	findCacheVolumeReturns struct {
+		result1 db.CreatedVolume
+		result2 string
+		result3 bool
+		result4 error
+	}
+	findCacheVolumeReturnsOnCall map[int]struct {
+		result1 db.CreatedVolume
+		result2 string
+		result3 bool
+		result4 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolume(arg1 int, arg2 string, arg3 []string) (db.CreatedVolume, string, bool, error) {
+	var arg3Copy []string
+	if arg3 != nil {
+		arg3Copy = make([]string, len(arg3))
+		copy(arg3Copy, arg3)
+	}
+	fake.findCacheVolumeMutex.Lock()
+	ret, specificReturn := fake.findCacheVolumeReturnsOnCall[len(fake.findCacheVolumeArgsForCall)]
+	fake.findCacheVolumeArgsForCall = append(fake.findCacheVolumeArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3Copy})
+	fake.recordInvocation("FindCacheVolume", []interface{}{arg1, arg2, arg3Copy})
+	fake.findCacheVolumeMutex.Unlock()
+	if fake.FindCacheVolumeStub != nil {
+		return fake.FindCacheVolumeStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3, ret.result4
+	}
+	fakeReturns := fake.findCacheVolumeReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3, fakeReturns.result4
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolumeCallCount() int {
+	fake.findCacheVolumeMutex.RLock()
+	defer fake.findCacheVolumeMutex.RUnlock()
+	return len(fake.findCacheVolumeArgsForCall)
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolumeCalls(stub func(int, string, []string) (db.CreatedVolume, string, bool, error)) {
+	fake.findCacheVolumeMutex.Lock()
+	defer fake.findCacheVolumeMutex.Unlock()
+	fake.FindCacheVolumeStub = stub
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolumeArgsForCall(i int) (int, string, []string) {
+	fake.findCacheVolumeMutex.RLock()
+	defer fake.findCacheVolumeMutex.RUnlock()
+	argsForCall := fake.findCacheVolumeArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolumeReturns(result1 db.CreatedVolume, result2 string, result3 bool, result4 error) {
+	fake.findCacheVolumeMutex.Lock()
+	defer fake.findCacheVolumeMutex.Unlock()
+	fake.FindCacheVolumeStub = nil
+	fake.findCacheVolumeReturns = struct {
+		result1 db.CreatedVolume
+		result2 string
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeKeyedCacheFactory) FindCacheVolumeReturnsOnCall(i int, result1 db.CreatedVolume, result2 string, result3 bool, result4 error) {
+	fake.findCacheVolumeMutex.Lock()
+	defer fake.findCacheVolumeMutex.Unlock()
+	fake.FindCacheVolumeStub = nil
+	if fake.findCacheVolumeReturnsOnCall == nil {
+		fake.findCacheVolumeReturnsOnCall = make(map[int]struct {
+			result1 db.CreatedVolume
+			result2 string
+			result3 bool
+			result4 error
+		})
+	}
+	fake.findCacheVolumeReturnsOnCall[i] = struct {
+		result1 db.CreatedVolume
+		result2 string
+		result3 bool
+		result4 error
+	}{result1, result2, result3, result4}
+}
+
+func (fake *FakeKeyedCacheFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.findCacheVolumeMutex.RLock()
+	defer fake.findCacheVolumeMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeKeyedCacheFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.KeyedCacheFactory = new(FakeKeyedCacheFactory)
@@ -0,0 +1,116 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeKeyedCacheLifecycle struct {
+	CleanUpKeyedCachesStub        func(time.Duration) (int, error)
+	cleanUpKeyedCachesMutex       sync.RWMutex
+	cleanUpKeyedCachesArgsForCall []struct {
+		arg1 time.Duration
+	}
+	cleanUpKeyedCachesReturns struct {
+		result1 int
+		result2 error
+	}
+	cleanUpKeyedCachesReturnsOnCall map[int]struct {
+		result1 int
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCaches(arg1 time.Duration) (int, error) {
+	fake.cleanUpKeyedCachesMutex.Lock()
+	ret, specificReturn := fake.cleanUpKeyedCachesReturnsOnCall[len(fake.cleanUpKeyedCachesArgsForCall)]
+	fake.cleanUpKeyedCachesArgsForCall = append(fake.cleanUpKeyedCachesArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("CleanUpKeyedCaches", []interface{}{arg1})
+	fake.cleanUpKeyedCachesMutex.Unlock()
+	if fake.CleanUpKeyedCachesStub != nil {
+		return fake.CleanUpKeyedCachesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.cleanUpKeyedCachesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCachesCallCount() int {
+	fake.cleanUpKeyedCachesMutex.RLock()
+	defer fake.cleanUpKeyedCachesMutex.RUnlock()
+	return len(fake.cleanUpKeyedCachesArgsForCall)
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCachesCalls(stub func(time.Duration) (int, error)) {
+	fake.cleanUpKeyedCachesMutex.Lock()
+	defer fake.cleanUpKeyedCachesMutex.Unlock()
+	fake.CleanUpKeyedCachesStub = stub
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCachesArgsForCall(i int) time.Duration {
+	fake.cleanUpKeyedCachesMutex.RLock()
+	defer fake.cleanUpKeyedCachesMutex.RUnlock()
+	argsForCall := fake.cleanUpKeyedCachesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCachesReturns(result1 int, result2 error) {
+	fake.cleanUpKeyedCachesMutex.Lock()
+	defer fake.cleanUpKeyedCachesMutex.Unlock()
+	fake.CleanUpKeyedCachesStub = nil
+	fake.cleanUpKeyedCachesReturns = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) CleanUpKeyedCachesReturnsOnCall(i int, result1 int, result2 error) {
+	fake.cleanUpKeyedCachesMutex.Lock()
+	defer fake.cleanUpKeyedCachesMutex.Unlock()
+	fake.CleanUpKeyedCachesStub = nil
+	if fake.cleanUpKeyedCachesReturnsOnCall == nil {
+		fake.cleanUpKeyedCachesReturnsOnCall = make(map[int]struct {
+			result1 int
+			result2 error
+		})
+	}
+	fake.cleanUpKeyedCachesReturnsOnCall[i] = struct {
+		result1 int
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeKeyedCacheLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.cleanUpKeyedCachesMutex.RLock()
+	defer fake.cleanUpKeyedCachesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeKeyedCacheLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.KeyedCacheLifecycle = new(FakeKeyedCacheLifecycle)
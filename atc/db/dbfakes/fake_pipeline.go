@@ -100,6 +100,33 @@ type FakePipeline struct {
 		result1 atc.Config
 		result2 error
 	}
+	ConfigHistoryStub        func() ([]db.PipelineConfigVersion, error)
+	configHistoryMutex       sync.RWMutex
+	configHistoryArgsForCall []struct {
+	}
+	configHistoryReturns struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	configHistoryReturnsOnCall map[int]struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}
+	ConfigHistoryVersionStub        func(int) (db.PipelineConfigVersion, bool, error)
+	configHistoryVersionMutex       sync.RWMutex
+	configHistoryVersionArgsForCall []struct {
+		arg1 int
+	}
+	configHistoryVersionReturns struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	configHistoryVersionReturnsOnCall map[int]struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	ConfigVersionStub        func() db.ConfigVersion
 	configVersionMutex       sync.RWMutex
 	configVersionArgsForCall []struct {
@@ -355,6 +382,16 @@ type FakePipeline struct {
 	pausedReturnsOnCall map[int]struct {
 		result1 bool
 	}
+	PolicyWarningsStub        func() []string
+	policyWarningsMutex       sync.RWMutex
+	policyWarningsArgsForCall []struct {
+	}
+	policyWarningsReturns struct {
+		result1 []string
+	}
+	policyWarningsReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	PublicStub        func() bool
 	publicMutex       sync.RWMutex
 	publicArgsForCall []struct {
@@ -519,6 +556,18 @@ type FakePipeline struct {
 	teamNameReturnsOnCall map[int]struct {
 		result1 string
 	}
+	TemplatesStub        func() (atc.StepTemplateConfigs, error)
+	templatesMutex       sync.RWMutex
+	templatesArgsForCall []struct {
+	}
+	templatesReturns struct {
+		result1 atc.StepTemplateConfigs
+		result2 error
+	}
+	templatesReturnsOnCall map[int]struct {
+		result1 atc.StepTemplateConfigs
+		result2 error
+	}
 	UnpauseStub        func() error
 	unpauseMutex       sync.RWMutex
 	unpauseArgsForCall []struct {
@@ -967,6 +1016,127 @@ func (fake *FakePipeline) ConfigReturnsOnCall(i int, result1 atc.Config, result2
 	}{result1, result2}
 }
 
+func (fake *FakePipeline) ConfigHistory() ([]db.PipelineConfigVersion, error) {
+	fake.configHistoryMutex.Lock()
+	ret, specificReturn := fake.configHistoryReturnsOnCall[len(fake.configHistoryArgsForCall)]
+	fake.configHistoryArgsForCall = append(fake.configHistoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("ConfigHistory", []interface{}{})
+	fake.configHistoryMutex.Unlock()
+	if fake.ConfigHistoryStub != nil {
+		return fake.ConfigHistoryStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.configHistoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) ConfigHistoryCallCount() int {
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	return len(fake.configHistoryArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigHistoryCalls(stub func() ([]db.PipelineConfigVersion, error)) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = stub
+}
+
+func (fake *FakePipeline) ConfigHistoryReturns(result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	fake.configHistoryReturns = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigHistoryReturnsOnCall(i int, result1 []db.PipelineConfigVersion, result2 error) {
+	fake.configHistoryMutex.Lock()
+	defer fake.configHistoryMutex.Unlock()
+	fake.ConfigHistoryStub = nil
+	if fake.configHistoryReturnsOnCall == nil {
+		fake.configHistoryReturnsOnCall = make(map[int]struct {
+			result1 []db.PipelineConfigVersion
+			result2 error
+		})
+	}
+	fake.configHistoryReturnsOnCall[i] = struct {
+		result1 []db.PipelineConfigVersion
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) ConfigHistoryVersion(arg1 int) (db.PipelineConfigVersion, bool, error) {
+	fake.configHistoryVersionMutex.Lock()
+	ret, specificReturn := fake.configHistoryVersionReturnsOnCall[len(fake.configHistoryVersionArgsForCall)]
+	fake.configHistoryVersionArgsForCall = append(fake.configHistoryVersionArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("ConfigHistoryVersion", []interface{}{arg1})
+	fake.configHistoryVersionMutex.Unlock()
+	if fake.ConfigHistoryVersionStub != nil {
+		return fake.ConfigHistoryVersionStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.configHistoryVersionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakePipeline) ConfigHistoryVersionCallCount() int {
+	fake.configHistoryVersionMutex.RLock()
+	defer fake.configHistoryVersionMutex.RUnlock()
+	return len(fake.configHistoryVersionArgsForCall)
+}
+
+func (fake *FakePipeline) ConfigHistoryVersionCalls(stub func(int) (db.PipelineConfigVersion, bool, error)) {
+	fake.configHistoryVersionMutex.Lock()
+	defer fake.configHistoryVersionMutex.Unlock()
+	fake.ConfigHistoryVersionStub = stub
+}
+
+func (fake *FakePipeline) ConfigHistoryVersionArgsForCall(i int) int {
+	fake.configHistoryVersionMutex.RLock()
+	defer fake.configHistoryVersionMutex.RUnlock()
+	argsForCall := fake.configHistoryVersionArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakePipeline) ConfigHistoryVersionReturns(result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.configHistoryVersionMutex.Lock()
+	defer fake.configHistoryVersionMutex.Unlock()
+	fake.ConfigHistoryVersionStub = nil
+	fake.configHistoryVersionReturns = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakePipeline) ConfigHistoryVersionReturnsOnCall(i int, result1 db.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.configHistoryVersionMutex.Lock()
+	defer fake.configHistoryVersionMutex.Unlock()
+	fake.ConfigHistoryVersionStub = nil
+	if fake.configHistoryVersionReturnsOnCall == nil {
+		fake.configHistoryVersionReturnsOnCall = make(map[int]struct {
+			result1 db.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.configHistoryVersionReturnsOnCall[i] = struct {
+		result1 db.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakePipeline) ConfigVersion() db.ConfigVersion {
 	fake.configVersionMutex.Lock()
 	ret, specificReturn := fake.configVersionReturnsOnCall[len(fake.configVersionArgsForCall)]
@@ -2237,6 +2407,58 @@ func (fake *FakePipeline) PausedReturnsOnCall(i int, result1 bool) {
 	}{result1}
 }
 
+func (fake *FakePipeline) PolicyWarnings() []string {
+	fake.policyWarningsMutex.Lock()
+	ret, specificReturn := fake.policyWarningsReturnsOnCall[len(fake.policyWarningsArgsForCall)]
+	fake.policyWarningsArgsForCall = append(fake.policyWarningsArgsForCall, struct {
+	}{})
+	fake.recordInvocation("PolicyWarnings", []interface{}{})
+	fake.policyWarningsMutex.Unlock()
+	if fake.PolicyWarningsStub != nil {
+		return fake.PolicyWarningsStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.policyWarningsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakePipeline) PolicyWarningsCallCount() int {
+	fake.policyWarningsMutex.RLock()
+	defer fake.policyWarningsMutex.RUnlock()
+	return len(fake.policyWarningsArgsForCall)
+}
+
+func (fake *FakePipeline) PolicyWarningsCalls(stub func() []string) {
+	fake.policyWarningsMutex.Lock()
+	defer fake.policyWarningsMutex.Unlock()
+	fake.PolicyWarningsStub = stub
+}
+
+func (fake *FakePipeline) PolicyWarningsReturns(result1 []string) {
+	fake.policyWarningsMutex.Lock()
+	defer fake.policyWarningsMutex.Unlock()
+	fake.PolicyWarningsStub = nil
+	fake.policyWarningsReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakePipeline) PolicyWarningsReturnsOnCall(i int, result1 []string) {
+	fake.policyWarningsMutex.Lock()
+	defer fake.policyWarningsMutex.Unlock()
+	fake.PolicyWarningsStub = nil
+	if fake.policyWarningsReturnsOnCall == nil {
+		fake.policyWarningsReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.policyWarningsReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakePipeline) Public() bool {
 	fake.publicMutex.Lock()
 	ret, specificReturn := fake.publicReturnsOnCall[len(fake.publicArgsForCall)]
@@ -3009,6 +3231,61 @@ func (fake *FakePipeline) TeamNameReturnsOnCall(i int, result1 string) {
 	}{result1}
 }
 
+func (fake *FakePipeline) Templates() (atc.StepTemplateConfigs, error) {
+	fake.templatesMutex.Lock()
+	ret, specificReturn := fake.templatesReturnsOnCall[len(fake.templatesArgsForCall)]
+	fake.templatesArgsForCall = append(fake.templatesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Templates", []interface{}{})
+	fake.templatesMutex.Unlock()
+	if fake.TemplatesStub != nil {
+		return fake.TemplatesStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.templatesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePipeline) TemplatesCallCount() int {
+	fake.templatesMutex.RLock()
+	defer fake.templatesMutex.RUnlock()
+	return len(fake.templatesArgsForCall)
+}
+
+func (fake *FakePipeline) TemplatesCalls(stub func() (atc.StepTemplateConfigs, error)) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = stub
+}
+
+func (fake *FakePipeline) TemplatesReturns(result1 atc.StepTemplateConfigs, result2 error) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = nil
+	fake.templatesReturns = struct {
+		result1 atc.StepTemplateConfigs
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePipeline) TemplatesReturnsOnCall(i int, result1 atc.StepTemplateConfigs, result2 error) {
+	fake.templatesMutex.Lock()
+	defer fake.templatesMutex.Unlock()
+	fake.TemplatesStub = nil
+	if fake.templatesReturnsOnCall == nil {
+		fake.templatesReturnsOnCall = make(map[int]struct {
+			result1 atc.StepTemplateConfigs
+			result2 error
+		})
+	}
+	fake.templatesReturnsOnCall[i] = struct {
+		result1 atc.StepTemplateConfigs
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePipeline) Unpause() error {
 	fake.unpauseMutex.Lock()
 	ret, specificReturn := fake.unpauseReturnsOnCall[len(fake.unpauseArgsForCall)]
@@ -3195,6 +3472,10 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.checkPausedMutex.RUnlock()
 	fake.configMutex.RLock()
 	defer fake.configMutex.RUnlock()
+	fake.configHistoryMutex.RLock()
+	defer fake.configHistoryMutex.RUnlock()
+	fake.configHistoryVersionMutex.RLock()
+	defer fake.configHistoryVersionMutex.RUnlock()
 	fake.configVersionMutex.RLock()
 	defer fake.configVersionMutex.RUnlock()
 	fake.createOneOffBuildMutex.RLock()
@@ -3241,6 +3522,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.pauseMutex.RUnlock()
 	fake.pausedMutex.RLock()
 	defer fake.pausedMutex.RUnlock()
+	fake.policyWarningsMutex.RLock()
+	defer fake.policyWarningsMutex.RUnlock()
 	fake.publicMutex.RLock()
 	defer fake.publicMutex.RUnlock()
 	fake.reloadMutex.RLock()
@@ -3267,6 +3550,8 @@ func (fake *FakePipeline) Invocations() map[string][][]interface{} {
 	defer fake.teamIDMutex.RUnlock()
 	fake.teamNameMutex.RLock()
 	defer fake.teamNameMutex.RUnlock()
+	fake.templatesMutex.RLock()
+	defer fake.templatesMutex.RUnlock()
 	fake.unpauseMutex.RLock()
 	defer fake.unpauseMutex.RUnlock()
 	fake.varSourcesMutex.RLock()
@@ -0,0 +1,121 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeStoredArtifactFactory struct {
+	CreateStoredArtifactStub        func(int, string, string, int64) (db.StoredArtifact, error)
+	createStoredArtifactMutex       sync.RWMutex
+	createStoredArtifactArgsForCall []struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int64
+	}
+	createStoredArtifactReturns struct {
+		result1 db.StoredArtifact
+		result2 error
+	}
+	createStoredArtifactReturnsOnCall map[int]struct {
+		result1 db.StoredArtifact
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifact(arg1 int, arg2 string, arg3 string, arg4 int64) (db.StoredArtifact, error) {
+	fake.createStoredArtifactMutex.Lock()
+	ret, specificReturn := fake.createStoredArtifactReturnsOnCall[len(fake.createStoredArtifactArgsForCall)]
+	fake.createStoredArtifactArgsForCall = append(fake.createStoredArtifactArgsForCall, struct {
+		arg1 int
+		arg2 string
+		arg3 string
+		arg4 int64
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("CreateStoredArtifact", []interface{}{arg1, arg2, arg3, arg4})
+	fake.createStoredArtifactMutex.Unlock()
+	if fake.CreateStoredArtifactStub != nil {
+		return fake.CreateStoredArtifactStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createStoredArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifactCallCount() int {
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
+	return len(fake.createStoredArtifactArgsForCall)
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifactCalls(stub func(int, string, string, int64) (db.StoredArtifact, error)) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = stub
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifactArgsForCall(i int) (int, string, string, int64) {
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
+	argsForCall := fake.createStoredArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifactReturns(result1 db.StoredArtifact, result2 error) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = nil
+	fake.createStoredArtifactReturns = struct {
+		result1 db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoredArtifactFactory) CreateStoredArtifactReturnsOnCall(i int, result1 db.StoredArtifact, result2 error) {
+	fake.createStoredArtifactMutex.Lock()
+	defer fake.createStoredArtifactMutex.Unlock()
+	fake.CreateStoredArtifactStub = nil
+	if fake.createStoredArtifactReturnsOnCall == nil {
+		fake.createStoredArtifactReturnsOnCall = make(map[int]struct {
+			result1 db.StoredArtifact
+			result2 error
+		})
+	}
+	fake.createStoredArtifactReturnsOnCall[i] = struct {
+		result1 db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoredArtifactFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.createStoredArtifactMutex.RLock()
+	defer fake.createStoredArtifactMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStoredArtifactFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.StoredArtifactFactory = new(FakeStoredArtifactFactory)
@@ -0,0 +1,189 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeStoredArtifactLifecycle struct {
+	DeleteStoredArtifactStub        func(int) error
+	deleteStoredArtifactMutex       sync.RWMutex
+	deleteStoredArtifactArgsForCall []struct {
+		arg1 int
+	}
+	deleteStoredArtifactReturns struct {
+		result1 error
+	}
+	deleteStoredArtifactReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ExpiredStoredArtifactsStub        func(time.Duration) ([]db.StoredArtifact, error)
+	expiredStoredArtifactsMutex       sync.RWMutex
+	expiredStoredArtifactsArgsForCall []struct {
+		arg1 time.Duration
+	}
+	expiredStoredArtifactsReturns struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}
+	expiredStoredArtifactsReturnsOnCall map[int]struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifact(arg1 int) error {
+	fake.deleteStoredArtifactMutex.Lock()
+	ret, specificReturn := fake.deleteStoredArtifactReturnsOnCall[len(fake.deleteStoredArtifactArgsForCall)]
+	fake.deleteStoredArtifactArgsForCall = append(fake.deleteStoredArtifactArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("DeleteStoredArtifact", []interface{}{arg1})
+	fake.deleteStoredArtifactMutex.Unlock()
+	if fake.DeleteStoredArtifactStub != nil {
+		return fake.DeleteStoredArtifactStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteStoredArtifactReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifactCallCount() int {
+	fake.deleteStoredArtifactMutex.RLock()
+	defer fake.deleteStoredArtifactMutex.RUnlock()
+	return len(fake.deleteStoredArtifactArgsForCall)
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifactCalls(stub func(int) error) {
+	fake.deleteStoredArtifactMutex.Lock()
+	defer fake.deleteStoredArtifactMutex.Unlock()
+	fake.DeleteStoredArtifactStub = stub
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifactArgsForCall(i int) int {
+	fake.deleteStoredArtifactMutex.RLock()
+	defer fake.deleteStoredArtifactMutex.RUnlock()
+	argsForCall := fake.deleteStoredArtifactArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifactReturns(result1 error) {
+	fake.deleteStoredArtifactMutex.Lock()
+	defer fake.deleteStoredArtifactMutex.Unlock()
+	fake.DeleteStoredArtifactStub = nil
+	fake.deleteStoredArtifactReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoredArtifactLifecycle) DeleteStoredArtifactReturnsOnCall(i int, result1 error) {
+	fake.deleteStoredArtifactMutex.Lock()
+	defer fake.deleteStoredArtifactMutex.Unlock()
+	fake.DeleteStoredArtifactStub = nil
+	if fake.deleteStoredArtifactReturnsOnCall == nil {
+		fake.deleteStoredArtifactReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteStoredArtifactReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifacts(arg1 time.Duration) ([]db.StoredArtifact, error) {
+	fake.expiredStoredArtifactsMutex.Lock()
+	ret, specificReturn := fake.expiredStoredArtifactsReturnsOnCall[len(fake.expiredStoredArtifactsArgsForCall)]
+	fake.expiredStoredArtifactsArgsForCall = append(fake.expiredStoredArtifactsArgsForCall, struct {
+		arg1 time.Duration
+	}{arg1})
+	fake.recordInvocation("ExpiredStoredArtifacts", []interface{}{arg1})
+	fake.expiredStoredArtifactsMutex.Unlock()
+	if fake.ExpiredStoredArtifactsStub != nil {
+		return fake.ExpiredStoredArtifactsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.expiredStoredArtifactsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifactsCallCount() int {
+	fake.expiredStoredArtifactsMutex.RLock()
+	defer fake.expiredStoredArtifactsMutex.RUnlock()
+	return len(fake.expiredStoredArtifactsArgsForCall)
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifactsCalls(stub func(time.Duration) ([]db.StoredArtifact, error)) {
+	fake.expiredStoredArtifactsMutex.Lock()
+	defer fake.expiredStoredArtifactsMutex.Unlock()
+	fake.ExpiredStoredArtifactsStub = stub
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifactsArgsForCall(i int) time.Duration {
+	fake.expiredStoredArtifactsMutex.RLock()
+	defer fake.expiredStoredArtifactsMutex.RUnlock()
+	argsForCall := fake.expiredStoredArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifactsReturns(result1 []db.StoredArtifact, result2 error) {
+	fake.expiredStoredArtifactsMutex.Lock()
+	defer fake.expiredStoredArtifactsMutex.Unlock()
+	fake.ExpiredStoredArtifactsStub = nil
+	fake.expiredStoredArtifactsReturns = struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoredArtifactLifecycle) ExpiredStoredArtifactsReturnsOnCall(i int, result1 []db.StoredArtifact, result2 error) {
+	fake.expiredStoredArtifactsMutex.Lock()
+	defer fake.expiredStoredArtifactsMutex.Unlock()
+	fake.ExpiredStoredArtifactsStub = nil
+	if fake.expiredStoredArtifactsReturnsOnCall == nil {
+		fake.expiredStoredArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []db.StoredArtifact
+			result2 error
+		})
+	}
+	fake.expiredStoredArtifactsReturnsOnCall[i] = struct {
+		result1 []db.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeStoredArtifactLifecycle) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.deleteStoredArtifactMutex.RLock()
+	defer fake.deleteStoredArtifactMutex.RUnlock()
+	fake.expiredStoredArtifactsMutex.RLock()
+	defer fake.expiredStoredArtifactsMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeStoredArtifactLifecycle) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.StoredArtifactLifecycle = new(FakeStoredArtifactLifecycle)
@@ -253,6 +253,16 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	MaxInFlightStub        func() int
+	maxInFlightMutex       sync.RWMutex
+	maxInFlightArgsForCall []struct {
+	}
+	maxInFlightReturns struct {
+		result1 int
+	}
+	maxInFlightReturnsOnCall map[int]struct {
+		result1 int
+	}
 	NameStub        func() string
 	nameMutex       sync.RWMutex
 	nameArgsForCall []struct {
@@ -357,6 +367,25 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	SavePipelineWithOptionsStub        func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.SavePipelineOptions) (db.Pipeline, bool, error)
+	savePipelineWithOptionsMutex       sync.RWMutex
+	savePipelineWithOptionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 bool
+		arg5 db.SavePipelineOptions
+	}
+	savePipelineWithOptionsReturns struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
+	savePipelineWithOptionsReturnsOnCall map[int]struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}
 	SaveWorkerStub        func(atc.Worker, time.Duration) (db.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -371,6 +400,17 @@ type FakeTeam struct {
 		result1 db.Worker
 		result2 error
 	}
+	UpdateStub        func(atc.Team) error
+	updateMutex       sync.RWMutex
+	updateArgsForCall []struct {
+		arg1 atc.Team
+	}
+	updateReturns struct {
+		result1 error
+	}
+	updateReturnsOnCall map[int]struct {
+		result1 error
+	}
 	UpdateProviderAuthStub        func(atc.TeamAuth) error
 	updateProviderAuthMutex       sync.RWMutex
 	updateProviderAuthArgsForCall []struct {
@@ -382,6 +422,16 @@ type FakeTeam struct {
 	updateProviderAuthReturnsOnCall map[int]struct {
 		result1 error
 	}
+	WeightStub        func() int
+	weightMutex       sync.RWMutex
+	weightArgsForCall []struct {
+	}
+	weightReturns struct {
+		result1 int
+	}
+	weightReturnsOnCall map[int]struct {
+		result1 int
+	}
 	WorkersStub        func() ([]db.Worker, error)
 	workersMutex       sync.RWMutex
 	workersArgsForCall []struct {
@@ -1501,6 +1551,58 @@ func (fake *FakeTeam) IsContainerWithinTeamReturnsOnCall(i int, result1 bool, re
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) MaxInFlight() int {
+	fake.maxInFlightMutex.Lock()
+	ret, specificReturn := fake.maxInFlightReturnsOnCall[len(fake.maxInFlightArgsForCall)]
+	fake.maxInFlightArgsForCall = append(fake.maxInFlightArgsForCall, struct {
+	}{})
+	fake.recordInvocation("MaxInFlight", []interface{}{})
+	fake.maxInFlightMutex.Unlock()
+	if fake.MaxInFlightStub != nil {
+		return fake.MaxInFlightStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.maxInFlightReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) MaxInFlightCallCount() int {
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
+	return len(fake.maxInFlightArgsForCall)
+}
+
+func (fake *FakeTeam) MaxInFlightCalls(stub func() int) {
+	fake.maxInFlightMutex.Lock()
+	defer fake.maxInFlightMutex.Unlock()
+	fake.MaxInFlightStub = stub
+}
+
+func (fake *FakeTeam) MaxInFlightReturns(result1 int) {
+	fake.maxInFlightMutex.Lock()
+	defer fake.maxInFlightMutex.Unlock()
+	fake.MaxInFlightStub = nil
+	fake.maxInFlightReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) MaxInFlightReturnsOnCall(i int, result1 int) {
+	fake.maxInFlightMutex.Lock()
+	defer fake.maxInFlightMutex.Unlock()
+	fake.MaxInFlightStub = nil
+	if fake.maxInFlightReturnsOnCall == nil {
+		fake.maxInFlightReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.maxInFlightReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeTeam) Name() string {
 	fake.nameMutex.Lock()
 	ret, specificReturn := fake.nameReturnsOnCall[len(fake.nameArgsForCall)]
@@ -1989,6 +2091,76 @@ func (fake *FakeTeam) SavePipelineReturnsOnCall(i int, result1 db.Pipeline, resu
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) SavePipelineWithOptions(arg1 atc.PipelineRef, arg2 atc.Config, arg3 db.ConfigVersion, arg4 bool, arg5 db.SavePipelineOptions) (db.Pipeline, bool, error) {
+	fake.savePipelineWithOptionsMutex.Lock()
+	ret, specificReturn := fake.savePipelineWithOptionsReturnsOnCall[len(fake.savePipelineWithOptionsArgsForCall)]
+	fake.savePipelineWithOptionsArgsForCall = append(fake.savePipelineWithOptionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 atc.Config
+		arg3 db.ConfigVersion
+		arg4 bool
+		arg5 db.SavePipelineOptions
+	}{arg1, arg2, arg3, arg4, arg5})
+	fake.recordInvocation("SavePipelineWithOptions", []interface{}{arg1, arg2, arg3, arg4, arg5})
+	fake.savePipelineWithOptionsMutex.Unlock()
+	if fake.SavePipelineWithOptionsStub != nil {
+		return fake.SavePipelineWithOptionsStub(arg1, arg2, arg3, arg4, arg5)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.savePipelineWithOptionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SavePipelineWithOptionsCallCount() int {
+	fake.savePipelineWithOptionsMutex.RLock()
+	defer fake.savePipelineWithOptionsMutex.RUnlock()
+	return len(fake.savePipelineWithOptionsArgsForCall)
+}
+
+func (fake *FakeTeam) SavePipelineWithOptionsCalls(stub func(atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.SavePipelineOptions) (db.Pipeline, bool, error)) {
+	fake.savePipelineWithOptionsMutex.Lock()
+	defer fake.savePipelineWithOptionsMutex.Unlock()
+	fake.SavePipelineWithOptionsStub = stub
+}
+
+func (fake *FakeTeam) SavePipelineWithOptionsArgsForCall(i int) (atc.PipelineRef, atc.Config, db.ConfigVersion, bool, db.SavePipelineOptions) {
+	fake.savePipelineWithOptionsMutex.RLock()
+	defer fake.savePipelineWithOptionsMutex.RUnlock()
+	argsForCall := fake.savePipelineWithOptionsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+}
+
+func (fake *FakeTeam) SavePipelineWithOptionsReturns(result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineWithOptionsMutex.Lock()
+	defer fake.savePipelineWithOptionsMutex.Unlock()
+	fake.SavePipelineWithOptionsStub = nil
+	fake.savePipelineWithOptionsReturns = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SavePipelineWithOptionsReturnsOnCall(i int, result1 db.Pipeline, result2 bool, result3 error) {
+	fake.savePipelineWithOptionsMutex.Lock()
+	defer fake.savePipelineWithOptionsMutex.Unlock()
+	fake.SavePipelineWithOptionsStub = nil
+	if fake.savePipelineWithOptionsReturnsOnCall == nil {
+		fake.savePipelineWithOptionsReturnsOnCall = make(map[int]struct {
+			result1 db.Pipeline
+			result2 bool
+			result3 error
+		})
+	}
+	fake.savePipelineWithOptionsReturnsOnCall[i] = struct {
+		result1 db.Pipeline
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) SaveWorker(arg1 atc.Worker, arg2 time.Duration) (db.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -2053,6 +2225,66 @@ func (fake *FakeTeam) SaveWorkerReturnsOnCall(i int, result1 db.Worker, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) Update(arg1 atc.Team) error {
+	fake.updateMutex.Lock()
+	ret, specificReturn := fake.updateReturnsOnCall[len(fake.updateArgsForCall)]
+	fake.updateArgsForCall = append(fake.updateArgsForCall, struct {
+		arg1 atc.Team
+	}{arg1})
+	fake.recordInvocation("Update", []interface{}{arg1})
+	fake.updateMutex.Unlock()
+	if fake.UpdateStub != nil {
+		return fake.UpdateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.updateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) UpdateCallCount() int {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	return len(fake.updateArgsForCall)
+}
+
+func (fake *FakeTeam) UpdateCalls(stub func(atc.Team) error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = stub
+}
+
+func (fake *FakeTeam) UpdateArgsForCall(i int) atc.Team {
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
+	argsForCall := fake.updateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) UpdateReturns(result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	fake.updateReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeTeam) UpdateReturnsOnCall(i int, result1 error) {
+	fake.updateMutex.Lock()
+	defer fake.updateMutex.Unlock()
+	fake.UpdateStub = nil
+	if fake.updateReturnsOnCall == nil {
+		fake.updateReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.updateReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeTeam) UpdateProviderAuth(arg1 atc.TeamAuth) error {
 	fake.updateProviderAuthMutex.Lock()
 	ret, specificReturn := fake.updateProviderAuthReturnsOnCall[len(fake.updateProviderAuthArgsForCall)]
@@ -2113,6 +2345,58 @@ func (fake *FakeTeam) UpdateProviderAuthReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) Weight() int {
+	fake.weightMutex.Lock()
+	ret, specificReturn := fake.weightReturnsOnCall[len(fake.weightArgsForCall)]
+	fake.weightArgsForCall = append(fake.weightArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Weight", []interface{}{})
+	fake.weightMutex.Unlock()
+	if fake.WeightStub != nil {
+		return fake.WeightStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.weightReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTeam) WeightCallCount() int {
+	fake.weightMutex.RLock()
+	defer fake.weightMutex.RUnlock()
+	return len(fake.weightArgsForCall)
+}
+
+func (fake *FakeTeam) WeightCalls(stub func() int) {
+	fake.weightMutex.Lock()
+	defer fake.weightMutex.Unlock()
+	fake.WeightStub = stub
+}
+
+func (fake *FakeTeam) WeightReturns(result1 int) {
+	fake.weightMutex.Lock()
+	defer fake.weightMutex.Unlock()
+	fake.WeightStub = nil
+	fake.weightReturns = struct {
+		result1 int
+	}{result1}
+}
+
+func (fake *FakeTeam) WeightReturnsOnCall(i int, result1 int) {
+	fake.weightMutex.Lock()
+	defer fake.weightMutex.Unlock()
+	fake.WeightStub = nil
+	if fake.weightReturnsOnCall == nil {
+		fake.weightReturnsOnCall = make(map[int]struct {
+			result1 int
+		})
+	}
+	fake.weightReturnsOnCall[i] = struct {
+		result1 int
+	}{result1}
+}
+
 func (fake *FakeTeam) Workers() ([]db.Worker, error) {
 	fake.workersMutex.Lock()
 	ret, specificReturn := fake.workersReturnsOnCall[len(fake.workersArgsForCall)]
@@ -2207,6 +2491,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.isCheckContainerMutex.RUnlock()
 	fake.isContainerWithinTeamMutex.RLock()
 	defer fake.isContainerWithinTeamMutex.RUnlock()
+	fake.maxInFlightMutex.RLock()
+	defer fake.maxInFlightMutex.RUnlock()
 	fake.nameMutex.RLock()
 	defer fake.nameMutex.RUnlock()
 	fake.orderPipelinesMutex.RLock()
@@ -2223,10 +2509,16 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.renameMutex.RUnlock()
 	fake.savePipelineMutex.RLock()
 	defer fake.savePipelineMutex.RUnlock()
+	fake.savePipelineWithOptionsMutex.RLock()
+	defer fake.savePipelineWithOptionsMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
+	fake.updateMutex.RLock()
+	defer fake.updateMutex.RUnlock()
 	fake.updateProviderAuthMutex.RLock()
 	defer fake.updateProviderAuthMutex.RUnlock()
+	fake.weightMutex.RLock()
+	defer fake.weightMutex.RUnlock()
 	fake.workersMutex.RLock()
 	defer fake.workersMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
@@ -0,0 +1,270 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWebhookDeliveryRepository struct {
+	MarkDeliveredStub        func(int64, int) error
+	markDeliveredMutex       sync.RWMutex
+	markDeliveredArgsForCall []struct {
+		arg1 int64
+		arg2 int
+	}
+	markDeliveredReturns struct {
+		result1 error
+	}
+	markDeliveredReturnsOnCall map[int]struct {
+		result1 error
+	}
+	MarkFailedStub        func(int64, int, string, time.Time) error
+	markFailedMutex       sync.RWMutex
+	markFailedArgsForCall []struct {
+		arg1 int64
+		arg2 int
+		arg3 string
+		arg4 time.Time
+	}
+	markFailedReturns struct {
+		result1 error
+	}
+	markFailedReturnsOnCall map[int]struct {
+		result1 error
+	}
+	PendingDeliveriesStub        func(int) ([]db.PendingWebhookDelivery, error)
+	pendingDeliveriesMutex       sync.RWMutex
+	pendingDeliveriesArgsForCall []struct {
+		arg1 int
+	}
+	pendingDeliveriesReturns struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}
+	pendingDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDelivered(arg1 int64, arg2 int) error {
+	fake.markDeliveredMutex.Lock()
+	ret, specificReturn := fake.markDeliveredReturnsOnCall[len(fake.markDeliveredArgsForCall)]
+	fake.markDeliveredArgsForCall = append(fake.markDeliveredArgsForCall, struct {
+		arg1 int64
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("MarkDelivered", []interface{}{arg1, arg2})
+	fake.markDeliveredMutex.Unlock()
+	if fake.MarkDeliveredStub != nil {
+		return fake.MarkDeliveredStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markDeliveredReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDeliveredCallCount() int {
+	fake.markDeliveredMutex.RLock()
+	defer fake.markDeliveredMutex.RUnlock()
+	return len(fake.markDeliveredArgsForCall)
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDeliveredCalls(stub func(int64, int) error) {
+	fake.markDeliveredMutex.Lock()
+	defer fake.markDeliveredMutex.Unlock()
+	fake.MarkDeliveredStub = stub
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDeliveredArgsForCall(i int) (int64, int) {
+	fake.markDeliveredMutex.RLock()
+	defer fake.markDeliveredMutex.RUnlock()
+	argsForCall := fake.markDeliveredArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDeliveredReturns(result1 error) {
+	fake.markDeliveredMutex.Lock()
+	defer fake.markDeliveredMutex.Unlock()
+	fake.MarkDeliveredStub = nil
+	fake.markDeliveredReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkDeliveredReturnsOnCall(i int, result1 error) {
+	fake.markDeliveredMutex.Lock()
+	defer fake.markDeliveredMutex.Unlock()
+	fake.MarkDeliveredStub = nil
+	if fake.markDeliveredReturnsOnCall == nil {
+		fake.markDeliveredReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markDeliveredReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailed(arg1 int64, arg2 int, arg3 string, arg4 time.Time) error {
+	fake.markFailedMutex.Lock()
+	ret, specificReturn := fake.markFailedReturnsOnCall[len(fake.markFailedArgsForCall)]
+	fake.markFailedArgsForCall = append(fake.markFailedArgsForCall, struct {
+		arg1 int64
+		arg2 int
+		arg3 string
+		arg4 time.Time
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("MarkFailed", []interface{}{arg1, arg2, arg3, arg4})
+	fake.markFailedMutex.Unlock()
+	if fake.MarkFailedStub != nil {
+		return fake.MarkFailedStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.markFailedReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailedCallCount() int {
+	fake.markFailedMutex.RLock()
+	defer fake.markFailedMutex.RUnlock()
+	return len(fake.markFailedArgsForCall)
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailedCalls(stub func(int64, int, string, time.Time) error) {
+	fake.markFailedMutex.Lock()
+	defer fake.markFailedMutex.Unlock()
+	fake.MarkFailedStub = stub
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailedArgsForCall(i int) (int64, int, string, time.Time) {
+	fake.markFailedMutex.RLock()
+	defer fake.markFailedMutex.RUnlock()
+	argsForCall := fake.markFailedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailedReturns(result1 error) {
+	fake.markFailedMutex.Lock()
+	defer fake.markFailedMutex.Unlock()
+	fake.MarkFailedStub = nil
+	fake.markFailedReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookDeliveryRepository) MarkFailedReturnsOnCall(i int, result1 error) {
+	fake.markFailedMutex.Lock()
+	defer fake.markFailedMutex.Unlock()
+	fake.MarkFailedStub = nil
+	if fake.markFailedReturnsOnCall == nil {
+		fake.markFailedReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.markFailedReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveries(arg1 int) ([]db.PendingWebhookDelivery, error) {
+	fake.pendingDeliveriesMutex.Lock()
+	ret, specificReturn := fake.pendingDeliveriesReturnsOnCall[len(fake.pendingDeliveriesArgsForCall)]
+	fake.pendingDeliveriesArgsForCall = append(fake.pendingDeliveriesArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("PendingDeliveries", []interface{}{arg1})
+	fake.pendingDeliveriesMutex.Unlock()
+	if fake.PendingDeliveriesStub != nil {
+		return fake.PendingDeliveriesStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.pendingDeliveriesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveriesCallCount() int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	return len(fake.pendingDeliveriesArgsForCall)
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveriesCalls(stub func(int) ([]db.PendingWebhookDelivery, error)) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = stub
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveriesArgsForCall(i int) int {
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	argsForCall := fake.pendingDeliveriesArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveriesReturns(result1 []db.PendingWebhookDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	fake.pendingDeliveriesReturns = struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookDeliveryRepository) PendingDeliveriesReturnsOnCall(i int, result1 []db.PendingWebhookDelivery, result2 error) {
+	fake.pendingDeliveriesMutex.Lock()
+	defer fake.pendingDeliveriesMutex.Unlock()
+	fake.PendingDeliveriesStub = nil
+	if fake.pendingDeliveriesReturnsOnCall == nil {
+		fake.pendingDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.PendingWebhookDelivery
+			result2 error
+		})
+	}
+	fake.pendingDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.PendingWebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookDeliveryRepository) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.markDeliveredMutex.RLock()
+	defer fake.markDeliveredMutex.RUnlock()
+	fake.markFailedMutex.RLock()
+	defer fake.markFailedMutex.RUnlock()
+	fake.pendingDeliveriesMutex.RLock()
+	defer fake.pendingDeliveriesMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookDeliveryRepository) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WebhookDeliveryRepository = new(FakeWebhookDeliveryRepository)
@@ -0,0 +1,438 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package dbfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+type FakeWebhookFactory struct {
+	DestroyWebhookStub        func(int, string) (bool, error)
+	destroyWebhookMutex       sync.RWMutex
+	destroyWebhookArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	destroyWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	destroyWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	SaveWebhookStub        func(db.Webhook) (bool, error)
+	saveWebhookMutex       sync.RWMutex
+	saveWebhookArgsForCall []struct {
+		arg1 db.Webhook
+	}
+	saveWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	saveWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
+	WebhookStub        func(int, string) (db.Webhook, bool, error)
+	webhookMutex       sync.RWMutex
+	webhookArgsForCall []struct {
+		arg1 int
+		arg2 string
+	}
+	webhookReturns struct {
+		result1 db.Webhook
+		result2 bool
+		result3 error
+	}
+	webhookReturnsOnCall map[int]struct {
+		result1 db.Webhook
+		result2 bool
+		result3 error
+	}
+	WebhookDeliveriesStub        func(int, int) ([]db.WebhookDelivery, error)
+	webhookDeliveriesMutex       sync.RWMutex
+	webhookDeliveriesArgsForCall []struct {
+		arg1 int
+		arg2 int
+	}
+	webhookDeliveriesReturns struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	webhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}
+	WebhooksStub        func(int) ([]db.Webhook, error)
+	webhooksMutex       sync.RWMutex
+	webhooksArgsForCall []struct {
+		arg1 int
+	}
+	webhooksReturns struct {
+		result1 []db.Webhook
+		result2 error
+	}
+	webhooksReturnsOnCall map[int]struct {
+		result1 []db.Webhook
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhook(arg1 int, arg2 string) (bool, error) {
+	fake.destroyWebhookMutex.Lock()
+	ret, specificReturn := fake.destroyWebhookReturnsOnCall[len(fake.destroyWebhookArgsForCall)]
+	fake.destroyWebhookArgsForCall = append(fake.destroyWebhookArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DestroyWebhook", []interface{}{arg1, arg2})
+	fake.destroyWebhookMutex.Unlock()
+	if fake.DestroyWebhookStub != nil {
+		return fake.DestroyWebhookStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.destroyWebhookReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhookCallCount() int {
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
+	return len(fake.destroyWebhookArgsForCall)
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhookCalls(stub func(int, string) (bool, error)) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = stub
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhookArgsForCall(i int) (int, string) {
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
+	argsForCall := fake.destroyWebhookArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhookReturns(result1 bool, result2 error) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = nil
+	fake.destroyWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) DestroyWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = nil
+	if fake.destroyWebhookReturnsOnCall == nil {
+		fake.destroyWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.destroyWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) SaveWebhook(arg1 db.Webhook) (bool, error) {
+	fake.saveWebhookMutex.Lock()
+	ret, specificReturn := fake.saveWebhookReturnsOnCall[len(fake.saveWebhookArgsForCall)]
+	fake.saveWebhookArgsForCall = append(fake.saveWebhookArgsForCall, struct {
+		arg1 db.Webhook
+	}{arg1})
+	fake.recordInvocation("SaveWebhook", []interface{}{arg1})
+	fake.saveWebhookMutex.Unlock()
+	if fake.SaveWebhookStub != nil {
+		return fake.SaveWebhookStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.saveWebhookReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookFactory) SaveWebhookCallCount() int {
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	return len(fake.saveWebhookArgsForCall)
+}
+
+func (fake *FakeWebhookFactory) SaveWebhookCalls(stub func(db.Webhook) (bool, error)) {
+	fake.saveWebhookMutex.Lock()
+	defer fake.saveWebhookMutex.Unlock()
+	fake.SaveWebhookStub = stub
+}
+
+func (fake *FakeWebhookFactory) SaveWebhookArgsForCall(i int) db.Webhook {
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	argsForCall := fake.saveWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookFactory) SaveWebhookReturns(result1 bool, result2 error) {
+	fake.saveWebhookMutex.Lock()
+	defer fake.saveWebhookMutex.Unlock()
+	fake.SaveWebhookStub = nil
+	fake.saveWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) SaveWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.saveWebhookMutex.Lock()
+	defer fake.saveWebhookMutex.Unlock()
+	fake.SaveWebhookStub = nil
+	if fake.saveWebhookReturnsOnCall == nil {
+		fake.saveWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.saveWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) Webhook(arg1 int, arg2 string) (db.Webhook, bool, error) {
+	fake.webhookMutex.Lock()
+	ret, specificReturn := fake.webhookReturnsOnCall[len(fake.webhookArgsForCall)]
+	fake.webhookArgsForCall = append(fake.webhookArgsForCall, struct {
+		arg1 int
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Webhook", []interface{}{arg1, arg2})
+	fake.webhookMutex.Unlock()
+	if fake.WebhookStub != nil {
+		return fake.WebhookStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.webhookReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeWebhookFactory) WebhookCallCount() int {
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
+	return len(fake.webhookArgsForCall)
+}
+
+func (fake *FakeWebhookFactory) WebhookCalls(stub func(int, string) (db.Webhook, bool, error)) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = stub
+}
+
+func (fake *FakeWebhookFactory) WebhookArgsForCall(i int) (int, string) {
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
+	argsForCall := fake.webhookArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookFactory) WebhookReturns(result1 db.Webhook, result2 bool, result3 error) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = nil
+	fake.webhookReturns = struct {
+		result1 db.Webhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWebhookFactory) WebhookReturnsOnCall(i int, result1 db.Webhook, result2 bool, result3 error) {
+	fake.webhookMutex.Lock()
+	defer fake.webhookMutex.Unlock()
+	fake.WebhookStub = nil
+	if fake.webhookReturnsOnCall == nil {
+		fake.webhookReturnsOnCall = make(map[int]struct {
+			result1 db.Webhook
+			result2 bool
+			result3 error
+		})
+	}
+	fake.webhookReturnsOnCall[i] = struct {
+		result1 db.Webhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveries(arg1 int, arg2 int) ([]db.WebhookDelivery, error) {
+	fake.webhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.webhookDeliveriesReturnsOnCall[len(fake.webhookDeliveriesArgsForCall)]
+	fake.webhookDeliveriesArgsForCall = append(fake.webhookDeliveriesArgsForCall, struct {
+		arg1 int
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("WebhookDeliveries", []interface{}{arg1, arg2})
+	fake.webhookDeliveriesMutex.Unlock()
+	if fake.WebhookDeliveriesStub != nil {
+		return fake.WebhookDeliveriesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.webhookDeliveriesReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveriesCallCount() int {
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	return len(fake.webhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveriesCalls(stub func(int, int) ([]db.WebhookDelivery, error)) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = stub
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveriesArgsForCall(i int) (int, int) {
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.webhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveriesReturns(result1 []db.WebhookDelivery, result2 error) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = nil
+	fake.webhookDeliveriesReturns = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) WebhookDeliveriesReturnsOnCall(i int, result1 []db.WebhookDelivery, result2 error) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = nil
+	if fake.webhookDeliveriesReturnsOnCall == nil {
+		fake.webhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []db.WebhookDelivery
+			result2 error
+		})
+	}
+	fake.webhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []db.WebhookDelivery
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) Webhooks(arg1 int) ([]db.Webhook, error) {
+	fake.webhooksMutex.Lock()
+	ret, specificReturn := fake.webhooksReturnsOnCall[len(fake.webhooksArgsForCall)]
+	fake.webhooksArgsForCall = append(fake.webhooksArgsForCall, struct {
+		arg1 int
+	}{arg1})
+	fake.recordInvocation("Webhooks", []interface{}{arg1})
+	fake.webhooksMutex.Unlock()
+	if fake.WebhooksStub != nil {
+		return fake.WebhooksStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.webhooksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeWebhookFactory) WebhooksCallCount() int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	return len(fake.webhooksArgsForCall)
+}
+
+func (fake *FakeWebhookFactory) WebhooksCalls(stub func(int) ([]db.Webhook, error)) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = stub
+}
+
+func (fake *FakeWebhookFactory) WebhooksArgsForCall(i int) int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	argsForCall := fake.webhooksArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeWebhookFactory) WebhooksReturns(result1 []db.Webhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	fake.webhooksReturns = struct {
+		result1 []db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) WebhooksReturnsOnCall(i int, result1 []db.Webhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	if fake.webhooksReturnsOnCall == nil {
+		fake.webhooksReturnsOnCall = make(map[int]struct {
+			result1 []db.Webhook
+			result2 error
+		})
+	}
+	fake.webhooksReturnsOnCall[i] = struct {
+		result1 []db.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeWebhookFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
+	fake.saveWebhookMutex.RLock()
+	defer fake.saveWebhookMutex.RUnlock()
+	fake.webhookMutex.RLock()
+	defer fake.webhookMutex.RUnlock()
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeWebhookFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ db.WebhookFactory = new(FakeWebhookFactory)
@@ -116,6 +116,16 @@ type FakeWorker struct {
 	expiresAtReturnsOnCall map[int]struct {
 		result1 time.Time
 	}
+	FeaturesStub        func() []string
+	featuresMutex       sync.RWMutex
+	featuresArgsForCall []struct {
+	}
+	featuresReturns struct {
+		result1 []string
+	}
+	featuresReturnsOnCall map[int]struct {
+		result1 []string
+	}
 	FindContainerStub        func(db.ContainerOwner) (db.CreatingContainer, db.CreatedContainer, error)
 	findContainerMutex       sync.RWMutex
 	findContainerArgsForCall []struct {
@@ -257,6 +267,16 @@ type FakeWorker struct {
 	resourceTypesReturnsOnCall map[int]struct {
 		result1 []atc.WorkerResourceType
 	}
+	ResourcesStub        func() *atc.WorkerResources
+	resourcesMutex       sync.RWMutex
+	resourcesArgsForCall []struct {
+	}
+	resourcesReturns struct {
+		result1 *atc.WorkerResources
+	}
+	resourcesReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
 	RetireStub        func() error
 	retireMutex       sync.RWMutex
 	retireArgsForCall []struct {
@@ -866,6 +886,58 @@ func (fake *FakeWorker) ExpiresAtReturnsOnCall(i int, result1 time.Time) {
 	}{result1}
 }
 
+func (fake *FakeWorker) Features() []string {
+	fake.featuresMutex.Lock()
+	ret, specificReturn := fake.featuresReturnsOnCall[len(fake.featuresArgsForCall)]
+	fake.featuresArgsForCall = append(fake.featuresArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Features", []interface{}{})
+	fake.featuresMutex.Unlock()
+	if fake.FeaturesStub != nil {
+		return fake.FeaturesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.featuresReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) FeaturesCallCount() int {
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
+	return len(fake.featuresArgsForCall)
+}
+
+func (fake *FakeWorker) FeaturesCalls(stub func() []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = stub
+}
+
+func (fake *FakeWorker) FeaturesReturns(result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	fake.featuresReturns = struct {
+		result1 []string
+	}{result1}
+}
+
+func (fake *FakeWorker) FeaturesReturnsOnCall(i int, result1 []string) {
+	fake.featuresMutex.Lock()
+	defer fake.featuresMutex.Unlock()
+	fake.FeaturesStub = nil
+	if fake.featuresReturnsOnCall == nil {
+		fake.featuresReturnsOnCall = make(map[int]struct {
+			result1 []string
+		})
+	}
+	fake.featuresReturnsOnCall[i] = struct {
+		result1 []string
+	}{result1}
+}
+
 func (fake *FakeWorker) FindContainer(arg1 db.ContainerOwner) (db.CreatingContainer, db.CreatedContainer, error) {
 	fake.findContainerMutex.Lock()
 	ret, specificReturn := fake.findContainerReturnsOnCall[len(fake.findContainerArgsForCall)]
@@ -1565,6 +1637,58 @@ func (fake *FakeWorker) ResourceTypesReturnsOnCall(i int, result1 []atc.WorkerRe
 	}{result1}
 }
 
+func (fake *FakeWorker) Resources() *atc.WorkerResources {
+	fake.resourcesMutex.Lock()
+	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
+	fake.resourcesArgsForCall = append(fake.resourcesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Resources", []interface{}{})
+	fake.resourcesMutex.Unlock()
+	if fake.ResourcesStub != nil {
+		return fake.ResourcesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourcesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ResourcesCallCount() int {
+	fake.resourcesMutex.RLock()
+	defer fake.resourcesMutex.RUnlock()
+	return len(fake.resourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ResourcesCalls(stub func() *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = stub
+}
+
+func (fake *FakeWorker) ResourcesReturns(result1 *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = nil
+	fake.resourcesReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) ResourcesReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = nil
+	if fake.resourcesReturnsOnCall == nil {
+		fake.resourcesReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.resourcesReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) Retire() error {
 	fake.retireMutex.Lock()
 	ret, specificReturn := fake.retireReturnsOnCall[len(fake.retireArgsForCall)]
@@ -1952,6 +2076,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.ephemeralMutex.RUnlock()
 	fake.expiresAtMutex.RLock()
 	defer fake.expiresAtMutex.RUnlock()
+	fake.featuresMutex.RLock()
+	defer fake.featuresMutex.RUnlock()
 	fake.findContainerMutex.RLock()
 	defer fake.findContainerMutex.RUnlock()
 	fake.gardenAddrMutex.RLock()
@@ -1978,6 +2104,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.resourceCertsMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
+	fake.resourcesMutex.RLock()
+	defer fake.resourcesMutex.RUnlock()
 	fake.retireMutex.RLock()
 	defer fake.retireMutex.RUnlock()
 	fake.startTimeMutex.RLock()
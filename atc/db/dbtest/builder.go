@@ -413,6 +413,7 @@ func (builder Builder) WithJobBuild(assign *db.Build, jobName string, inputs Job
 			}
 
 			err = build.SaveOutput(
+				scenario.SpanContext,
 				resource.Type(),
 				resource.Source(),
 				resourceTypes.Deserialize(),
@@ -0,0 +1,233 @@
+package db
+
+import (
+	"math"
+
+	"code.cloudfoundry.org/lager"
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc/db/lock"
+)
+
+//go:generate counterfeiter . FairShare
+
+// FairShare decides whether a team may start another build, based on the
+// builds every team currently has in flight and waiting to be scheduled.
+//
+// Each team may be limited to a number of builds in flight of its own. On top
+// of that, a cluster-wide limit is divided between the teams with builds in
+// flight or waiting, in proportion to their weight. A team may go over its
+// share only while no other team is waiting within its own.
+//
+// Within a team, the builds waiting with a higher priority get the team's
+// slots first, so that they are not held up by the builds of the team's other
+// jobs.
+//
+// The builds of a team's jobs are scheduled in parallel, so a build should
+// only be checked and scheduled while holding the team's scheduling lock,
+// lest several of them take the team's last slot.
+type FairShare interface {
+	AcquireTeamSchedulingLock(logger lager.Logger, teamID int) (lock.Lock, bool, error)
+	CanStartBuild(teamID int, priority int) (bool, error)
+}
+
+type fairShare struct {
+	conn        Conn
+	lockFactory lock.LockFactory
+	maxInFlight int
+}
+
+type teamBuilds struct {
+	maxInFlight int
+	weight      int
+	inFlight    int
+	waiting     int
+}
+
+func NewFairShare(conn Conn, lockFactory lock.LockFactory, maxInFlight int) FairShare {
+	return &fairShare{
+		conn:        conn,
+		lockFactory: lockFactory,
+		maxInFlight: maxInFlight,
+	}
+}
+
+func (f *fairShare) AcquireTeamSchedulingLock(logger lager.Logger, teamID int) (lock.Lock, bool, error) {
+	return f.lockFactory.Acquire(
+		logger.Session("lock", lager.Data{
+			"team-id": teamID,
+		}),
+		lock.NewTeamSchedulingLockID(teamID),
+	)
+}
+
+func (f *fairShare) CanStartBuild(teamID int, priority int) (bool, error) {
+	builds, err := f.teamBuilds()
+	if err != nil {
+		return false, err
+	}
+
+	team, found := builds[teamID]
+	if !found {
+		team, err = f.emptyTeamBuilds(teamID)
+		if err != nil {
+			return false, err
+		}
+
+		builds[teamID] = team
+	}
+
+	slots := f.slots(teamID, builds)
+	if slots <= 0 {
+		return false, nil
+	}
+
+	waiting, err := f.waitingWithHigherPriority(teamID, priority)
+	if err != nil {
+		return false, err
+	}
+
+	return waiting < slots, nil
+}
+
+// slots returns the number of builds the team may start right now.
+func (f *fairShare) slots(teamID int, builds map[int]teamBuilds) int {
+	team := builds[teamID]
+
+	slots := math.MaxInt32
+	if team.maxInFlight > 0 {
+		slots = team.maxInFlight - team.inFlight
+	}
+
+	if f.maxInFlight == 0 {
+		return slots
+	}
+
+	var totalInFlight, totalWeight int
+	for _, b := range builds {
+		totalInFlight += b.inFlight
+		totalWeight += b.effectiveWeight()
+	}
+
+	slots = min(slots, f.maxInFlight-totalInFlight)
+
+	for id, other := range builds {
+		if id == teamID || other.waiting == 0 {
+			continue
+		}
+
+		if other.maxInFlight > 0 && other.inFlight >= other.maxInFlight {
+			continue
+		}
+
+		if other.inFlight < f.share(other, totalWeight) {
+			return min(slots, f.share(team, totalWeight)-team.inFlight)
+		}
+	}
+
+	return slots
+}
+
+func (f *fairShare) share(team teamBuilds, totalWeight int) int {
+	share := f.maxInFlight * team.effectiveWeight() / totalWeight
+	if share < 1 {
+		return 1
+	}
+
+	return share
+}
+
+func (f *fairShare) teamBuilds() (map[int]teamBuilds, error) {
+	rows, err := psql.Select(
+		"t.id",
+		"t.max_in_flight",
+		"t.weight",
+		"COUNT(*) FILTER (WHERE b.scheduled)",
+		"COUNT(*) FILTER (WHERE NOT b.scheduled AND NOT j.paused AND j.inputs_determined)",
+	).
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Join("teams t ON t.id = b.team_id").
+		Where(sq.Eq{"b.completed": false}).
+		GroupBy("t.id").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	builds := map[int]teamBuilds{}
+	for rows.Next() {
+		var id int
+		var b teamBuilds
+
+		err = rows.Scan(&id, &b.maxInFlight, &b.weight, &b.inFlight, &b.waiting)
+		if err != nil {
+			return nil, err
+		}
+
+		builds[id] = b
+	}
+
+	return builds, nil
+}
+
+// waitingWithHigherPriority returns the number of the team's builds waiting to
+// be scheduled with a higher priority than the given one, leaving out the
+// builds of jobs which can't schedule any more builds anyway.
+func (f *fairShare) waitingWithHigherPriority(teamID int, priority int) (int, error) {
+	var waiting int
+	err := psql.Select("COUNT(*)").
+		From("builds b").
+		Join("jobs j ON j.id = b.job_id").
+		Where(sq.Eq{
+			"b.team_id":               teamID,
+			"b.completed":             false,
+			"b.scheduled":             false,
+			"j.paused":                false,
+			"j.inputs_determined":     true,
+			"j.max_in_flight_reached": false,
+		}).
+		Where(sq.Gt{"b.priority": priority}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&waiting)
+	if err != nil {
+		return 0, err
+	}
+
+	return waiting, nil
+}
+
+func (f *fairShare) emptyTeamBuilds(teamID int) (teamBuilds, error) {
+	var b teamBuilds
+
+	err := psql.Select("max_in_flight", "weight").
+		From("teams").
+		Where(sq.Eq{"id": teamID}).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&b.maxInFlight, &b.weight)
+	if err != nil {
+		return teamBuilds{}, err
+	}
+
+	return b, nil
+}
+
+func (b teamBuilds) effectiveWeight() int {
+	if b.weight <= 0 {
+		return 1
+	}
+
+	return b.weight
+}
+
+func min(a int, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
@@ -0,0 +1,215 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("FairShare", func() {
+	var (
+		fairShare   db.FairShare
+		maxInFlight int
+
+		otherTeam db.Team
+		otherJob  db.Job
+	)
+
+	createJobForTeam := func(team db.Team) db.Job {
+		pipeline, _, err := team.SavePipeline(defaultPipelineRef, defaultPipelineConfig, db.ConfigVersion(0), false)
+		Expect(err).ToNot(HaveOccurred())
+
+		job, found, err := pipeline.Job("some-job")
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeTrue())
+
+		return job
+	}
+
+	startBuilds := func(job db.Job, count int) {
+		for i := 0; i < count; i++ {
+			build, err := job.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			scheduled, err := job.ScheduleBuild(build)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(scheduled).To(BeTrue())
+		}
+	}
+
+	queueBuild := func(job db.Job) {
+		_, err := job.CreateBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = dbConn.Exec(`UPDATE jobs SET inputs_determined = true WHERE id = $1`, job.ID())
+		Expect(err).ToNot(HaveOccurred())
+	}
+
+	BeforeEach(func() {
+		maxInFlight = 0
+
+		var err error
+		otherTeam, err = teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+		Expect(err).ToNot(HaveOccurred())
+
+		otherJob = createJobForTeam(otherTeam)
+	})
+
+	JustBeforeEach(func() {
+		fairShare = db.NewFairShare(dbConn, lockFactory, maxInFlight)
+	})
+
+	Context("when the team has no builds", func() {
+		It("allows starting a build", func() {
+			canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(canStart).To(BeTrue())
+		})
+	})
+
+	Context("when the team has a max in flight", func() {
+		BeforeEach(func() {
+			err := defaultTeam.Update(atc.Team{Auth: defaultTeam.Auth(), MaxInFlight: 2})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		Context("when the team is below it", func() {
+			BeforeEach(func() {
+				startBuilds(defaultJob, 1)
+			})
+
+			It("allows starting a build", func() {
+				canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(canStart).To(BeTrue())
+			})
+		})
+
+		Context("when the team has reached it", func() {
+			BeforeEach(func() {
+				startBuilds(defaultJob, 2)
+			})
+
+			It("does not allow starting a build", func() {
+				canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(canStart).To(BeFalse())
+			})
+
+			It("does not affect other teams", func() {
+				canStart, err := fairShare.CanStartBuild(otherTeam.ID(), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(canStart).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when a build of another of the team's jobs is waiting with a higher priority", func() {
+		BeforeEach(func() {
+			err := defaultTeam.Update(atc.Team{Auth: defaultTeam.Auth(), MaxInFlight: 2})
+			Expect(err).ToNot(HaveOccurred())
+
+			startBuilds(defaultJob, 1)
+
+			pipeline, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "other-pipeline"}, defaultPipelineConfig, db.ConfigVersion(0), false)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherPipelineJob, found, err := pipeline.Job("some-job")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+
+			_, err = otherPipelineJob.CreateBuildWithPriority(10)
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = dbConn.Exec(`UPDATE jobs SET inputs_determined = true WHERE id = $1`, otherPipelineJob.ID())
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("leaves the team's last slot to it", func() {
+			canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(canStart).To(BeFalse())
+
+			canStart, err = fairShare.CanStartBuild(defaultTeam.ID(), 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(canStart).To(BeTrue())
+		})
+
+		Context("when the team has slots for both", func() {
+			BeforeEach(func() {
+				err := defaultTeam.Update(atc.Team{Auth: defaultTeam.Auth(), MaxInFlight: 3})
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("allows starting a build with a lower priority", func() {
+				canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(canStart).To(BeTrue())
+			})
+		})
+	})
+
+	Context("when there is a cluster-wide max in flight", func() {
+		BeforeEach(func() {
+			maxInFlight = 4
+		})
+
+		Context("when it has been reached", func() {
+			BeforeEach(func() {
+				startBuilds(defaultJob, 4)
+			})
+
+			It("does not allow starting a build", func() {
+				canStart, err := fairShare.CanStartBuild(otherTeam.ID(), 0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(canStart).To(BeFalse())
+			})
+		})
+
+		Context("when the team has reached its share", func() {
+			BeforeEach(func() {
+				startBuilds(defaultJob, 2)
+			})
+
+			Context("when another team is waiting within its share", func() {
+				BeforeEach(func() {
+					queueBuild(otherJob)
+				})
+
+				It("does not allow the team to start a build", func() {
+					canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(canStart).To(BeFalse())
+				})
+
+				It("allows the waiting team to start a build", func() {
+					canStart, err := fairShare.CanStartBuild(otherTeam.ID(), 0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(canStart).To(BeTrue())
+				})
+
+				Context("when the team has a higher weight", func() {
+					BeforeEach(func() {
+						err := defaultTeam.Update(atc.Team{Auth: defaultTeam.Auth(), Weight: 3})
+						Expect(err).ToNot(HaveOccurred())
+					})
+
+					It("allows the team to start a build", func() {
+						canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+						Expect(err).ToNot(HaveOccurred())
+						Expect(canStart).To(BeTrue())
+					})
+				})
+			})
+
+			Context("when no other team is waiting", func() {
+				It("allows the team to go over its share", func() {
+					canStart, err := fairShare.CanStartBuild(defaultTeam.ID(), 0)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(canStart).To(BeTrue())
+				})
+			})
+		})
+	})
+})
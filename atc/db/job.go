@@ -24,6 +24,7 @@ type InputConfig struct {
 	Name            string
 	Trigger         bool
 	Passed          JobSet
+	DependsOn       JobSet
 	UseEveryVersion bool
 	PinnedVersion   atc.Version
 	ResourceID      int
@@ -66,6 +67,7 @@ type Job interface {
 	Public() bool
 	ScheduleRequestedTime() time.Time
 	MaxInFlight() int
+	Priority() int
 	DisableManualTrigger() bool
 
 	Config() (atc.JobConfig, error)
@@ -80,6 +82,7 @@ type Job interface {
 
 	ScheduleBuild(Build) (bool, error)
 	CreateBuild() (Build, error)
+	CreateBuildWithPriority(priority int) (Build, error)
 	RerunBuild(Build) (Build, error)
 
 	RequestSchedule() error
@@ -91,6 +94,7 @@ type Job interface {
 	FinishedAndNextBuild() (Build, Build, error)
 	UpdateFirstLoggedBuildID(newFirstLoggedBuildID int) error
 	EnsurePendingBuildExists(context.Context) error
+	DependenciesSucceededSinceLastBuild() (bool, error)
 	GetPendingBuilds() ([]Build, error)
 
 	GetNextBuildInputs() ([]BuildInput, error)
@@ -105,7 +109,7 @@ type Job interface {
 	HasNewInputs() bool
 }
 
-var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.disable_manual_trigger").
+var jobsQuery = psql.Select("j.id", "j.name", "j.config", "j.paused", "j.public", "j.first_logged_build_id", "j.pipeline_id", "p.name", "p.instance_vars", "p.team_id", "t.name", "j.nonce", "j.tags", "j.has_new_inputs", "j.schedule_requested", "j.max_in_flight", "j.priority", "j.disable_manual_trigger").
 	From("jobs j, pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id").
 	Where(sq.Expr("j.pipeline_id = p.id"))
@@ -134,6 +138,7 @@ type job struct {
 	hasNewInputs          bool
 	scheduleRequestedTime time.Time
 	maxInFlight           int
+	priority              int
 	disableManualTrigger  bool
 
 	config    *atc.JobConfig
@@ -195,6 +200,7 @@ func (j *job) Tags() []string                   { return j.tags }
 func (j *job) HasNewInputs() bool               { return j.hasNewInputs }
 func (j *job) ScheduleRequestedTime() time.Time { return j.scheduleRequestedTime }
 func (j *job) MaxInFlight() int                 { return j.maxInFlight }
+func (j *job) Priority() int                    { return j.priority }
 func (j *job) DisableManualTrigger() bool       { return j.disableManualTrigger }
 
 func (j *job) Config() (atc.JobConfig, error) {
@@ -293,9 +299,59 @@ func (j *job) AlgorithmInputs() (InputConfigs, error) {
 		inputs = append(inputs, inputConfig)
 	}
 
+	err = j.addInputDependencies(inputs)
+	if err != nil {
+		return nil, err
+	}
+
 	return inputs, nil
 }
 
+// addInputDependencies marks each input with the jobs the job depends on
+// which interact with the input's resource.
+func (j *job) addInputDependencies(inputs InputConfigs) error {
+	rows, err := psql.Select("jd.depends_on_job_id", "ji.resource_id").
+		From("job_dependencies jd").
+		Join("job_inputs ji ON ji.job_id = jd.depends_on_job_id").
+		Where(sq.Eq{
+			"jd.job_id": j.id,
+		}).
+		Suffix(`UNION
+			SELECT jd.depends_on_job_id, jo.resource_id
+			FROM job_dependencies jd
+			JOIN job_outputs jo ON jo.job_id = jd.depends_on_job_id
+			WHERE jd.job_id = ?`, j.id).
+		RunWith(j.conn).
+		Query()
+	if err != nil {
+		return err
+	}
+
+	defer Close(rows)
+
+	for rows.Next() {
+		var dependencyID, resourceID int
+		err = rows.Scan(&dependencyID, &resourceID)
+		if err != nil {
+			return err
+		}
+
+		for i, input := range inputs {
+			if input.ResourceID != resourceID {
+				continue
+			}
+
+			if input.DependsOn == nil {
+				inputs[i].DependsOn = JobSet{}
+			}
+
+			inputs[i].DependsOn[dependencyID] = true
+		}
+	}
+
+	return nil
+}
+
 func (j *job) Inputs() ([]atc.JobInput, error) {
 	rows, err := psql.Select("ji.name", "r.name", "array_agg(p.name ORDER BY p.id)", "ji.trigger", "ji.version").
 		From("job_inputs ji").
@@ -665,6 +721,50 @@ func (j *job) GetNextBuildInputs() ([]BuildInput, error) {
 	return buildInputs, nil
 }
 
+// DependenciesSucceededSinceLastBuild returns whether any of the jobs the job
+// depends on without sharing an input with it succeeded after the job's latest
+// build was created. Dependencies sharing an input constrain the input's
+// versions instead, see addInputDependencies.
+func (j *job) DependenciesSucceededSinceLastBuild() (bool, error) {
+	var succeeded bool
+	err := j.conn.QueryRow(`
+		SELECT EXISTS (
+			SELECT 1
+			FROM job_dependencies jd
+			JOIN LATERAL (
+				SELECT b.end_time
+				FROM builds b
+				WHERE b.job_id = jd.depends_on_job_id
+				AND b.status = 'succeeded'
+				ORDER BY b.id DESC
+				LIMIT 1
+			) upstream ON true
+			WHERE jd.job_id = $1
+			AND NOT EXISTS (
+				SELECT 1
+				FROM job_inputs ji
+				WHERE ji.job_id = jd.job_id
+				AND ji.resource_id IN (
+					SELECT resource_id FROM job_inputs WHERE job_id = jd.depends_on_job_id
+					UNION
+					SELECT resource_id FROM job_outputs WHERE job_id = jd.depends_on_job_id
+				)
+			)
+			AND NOT EXISTS (
+				SELECT 1
+				FROM builds b
+				WHERE b.job_id = jd.job_id
+				AND b.create_time >= upstream.end_time
+			)
+		)
+	`, j.id).Scan(&succeeded)
+	if err != nil {
+		return false, err
+	}
+
+	return succeeded, nil
+}
+
 func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 	defer tracing.FromContext(ctx).End()
 	spanContextJSON, err := json.Marshal(NewSpanContext(ctx))
@@ -685,12 +785,12 @@ func (j *job) EnsurePendingBuildExists(ctx context.Context) error {
 	}
 
 	rows, err := tx.Query(`
-		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context)
-		SELECT $1, $2, $3, $4, 'pending', false, $5
+		INSERT INTO builds (name, job_id, pipeline_id, team_id, status, needs_v6_migration, span_context, priority)
+		SELECT $1, $2, $3, $4, 'pending', false, $5, $6
 		WHERE NOT EXISTS
 			(SELECT id FROM builds WHERE job_id = $2 AND status = 'pending')
 		RETURNING id
-	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON))
+	`, buildName, j.id, j.pipelineID, j.teamID, string(spanContextJSON), j.priority)
 	if err != nil {
 		return err
 	}
@@ -750,7 +850,7 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 			"b.job_id": j.id,
 			"b.status": BuildStatusPending,
 		}).
-		OrderBy("COALESCE(b.rerun_of, b.id) ASC, b.id ASC").
+		OrderBy("b.priority DESC, COALESCE(b.rerun_of, b.id) ASC, b.id ASC").
 		RunWith(j.conn).
 		Query()
 	if err != nil {
@@ -773,6 +873,13 @@ func (j *job) GetPendingBuilds() ([]Build, error) {
 }
 
 func (j *job) CreateBuild() (Build, error) {
+	return j.CreateBuildWithPriority(j.priority)
+}
+
+// CreateBuildWithPriority creates a manually triggered build which is
+// scheduled ahead of any pending builds with a lower priority, regardless of
+// the priority configured on the job.
+func (j *job) CreateBuildWithPriority(priority int) (Build, error) {
 	tx, err := j.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -793,6 +900,7 @@ func (j *job) CreateBuild() (Build, error) {
 		"team_id":            j.teamID,
 		"status":             BuildStatusPending,
 		"manually_triggered": true,
+		"priority":           priority,
 	})
 	if err != nil {
 		return nil, err
@@ -863,6 +971,7 @@ func (j *job) tryRerunBuild(buildToRerun Build) (Build, error) {
 		"status":       BuildStatusPending,
 		"rerun_of":     buildToRerunID,
 		"rerun_number": rerunNumber,
+		"priority":     buildToRerun.Priority(),
 	})
 	if err != nil {
 		return nil, err
@@ -1072,7 +1181,7 @@ func (j *job) getNextPendingBuildBySerialGroup(tx Tx, serialGroups []string) (Bu
 
 	row := tx.QueryRow(`
 			SELECT * FROM (`+subQuery+`) j
-			ORDER BY COALESCE(rerun_of, id) ASC, id ASC
+			ORDER BY priority DESC, COALESCE(rerun_of, id) ASC, id ASC
 			LIMIT 1`, params...)
 
 	build := newEmptyBuild(j.conn, j.lockFactory)
@@ -1379,7 +1488,7 @@ func scanJob(j *job, row scannable) error {
 		pipelineInstanceVars sql.NullString
 	)
 
-	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.disableManualTrigger)
+	err := row.Scan(&j.id, &j.name, &config, &j.paused, &j.public, &j.firstLoggedBuildID, &j.pipelineID, &j.pipelineName, &pipelineInstanceVars, &j.teamID, &j.teamName, &nonce, pq.Array(&j.tags), &j.hasNewInputs, &j.scheduleRequestedTime, &j.maxInFlight, &j.priority, &j.disableManualTrigger)
 	if err != nil {
 		return err
 	}
@@ -1448,12 +1557,16 @@ func requestSchedule(tx Tx, jobID int) error {
 // Updating multiple rows using a SELECT subquery does not preserve the same
 // order for the updates, which can lead to deadlocking.
 func requestScheduleOnDownstreamJobs(tx Tx, jobID int) error {
-	rows, err := psql.Select("DISTINCT job_id").
+	rows, err := psql.Select("job_id").
 		From("job_inputs").
 		Where(sq.Eq{
 			"passed_job_id": jobID,
 		}).
-		OrderBy("job_id DESC").
+		Suffix(`UNION
+			SELECT job_id
+			FROM job_dependencies
+			WHERE depends_on_job_id = ?
+			ORDER BY job_id DESC`, jobID).
 		RunWith(tx).
 		Query()
 	if err != nil {
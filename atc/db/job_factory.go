@@ -41,6 +41,7 @@ type SchedulerJob struct {
 	Job
 	Resources     SchedulerResources
 	ResourceTypes atc.VersionedResourceTypes
+	Templates     atc.StepTemplateConfigs
 }
 
 type SchedulerResources []SchedulerResource
@@ -88,6 +89,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 			"j.paused": false,
 			"p.paused": false,
 		}).
+		OrderBy("j.priority DESC").
 		RunWith(tx).
 		Query()
 	if err != nil {
@@ -101,6 +103,7 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 
 	var schedulerJobs SchedulerJobs
 	pipelineResourceTypes := make(map[int]ResourceTypes)
+	pipelineTemplates := make(map[int]atc.StepTemplateConfigs)
 	for _, job := range jobs {
 		rows, err := tx.Query(`WITH inputs AS (
 				SELECT ji.resource_id from job_inputs ji where ji.job_id = $1
@@ -180,10 +183,21 @@ func (j *jobFactory) JobsToSchedule() (SchedulerJobs, error) {
 			pipelineResourceTypes[job.PipelineID()] = resourceTypes
 		}
 
+		templates, found := pipelineTemplates[job.PipelineID()]
+		if !found {
+			templates, err = loadStepTemplates(tx, j.conn.EncryptionStrategy(), job.PipelineID())
+			if err != nil {
+				return nil, err
+			}
+
+			pipelineTemplates[job.PipelineID()] = templates
+		}
+
 		schedulerJobs = append(schedulerJobs, SchedulerJob{
 			Job:           job,
 			Resources:     schedulerResources,
 			ResourceTypes: resourceTypes.Deserialize(),
+			Templates:     templates,
 		})
 	}
 
@@ -275,7 +289,12 @@ func (d dashboardFactory) buildDashboard() ([]atc.JobSummary, error) {
 		return nil, err
 	}
 
-	return d.combineJobInputsAndOutputsWithDashboardJobs(dashboard, jobInputs, jobOutputs), nil
+	jobDependencies, err := d.fetchJobDependencies()
+	if err != nil {
+		return nil, err
+	}
+
+	return d.combineJobInputsAndOutputsWithDashboardJobs(dashboard, jobInputs, jobOutputs, jobDependencies), nil
 }
 
 func (d dashboardFactory) constructJobsForDashboard() ([]atc.JobSummary, error) {
@@ -467,7 +486,40 @@ func (d dashboardFactory) fetchJobOutputs() (map[int][]atc.JobOutputSummary, err
 	return jobOutputs, err
 }
 
-func (d dashboardFactory) combineJobInputsAndOutputsWithDashboardJobs(dashboard []atc.JobSummary, jobInputs map[int][]atc.JobInputSummary, jobOutputs map[int][]atc.JobOutputSummary) []atc.JobSummary {
+func (d dashboardFactory) fetchJobDependencies() (map[int][]string, error) {
+	rows, err := psql.Select("j.id", "jd.name").
+		From("job_dependencies d").
+		Join("jobs j ON j.id = d.job_id").
+		Join("jobs jd ON jd.id = d.depends_on_job_id").
+		Join("pipelines p ON p.id = j.pipeline_id").
+		Join("teams tm ON tm.id = p.team_id").
+		Where(d.pred).
+		Where(sq.Eq{
+			"j.active": true,
+		}).
+		OrderBy("j.id", "jd.id").
+		RunWith(d.tx).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	jobDependencies := make(map[int][]string)
+	for rows.Next() {
+		var jobID int
+		var dependency string
+		err = rows.Scan(&jobID, &dependency)
+		if err != nil {
+			return nil, err
+		}
+
+		jobDependencies[jobID] = append(jobDependencies[jobID], dependency)
+	}
+
+	return jobDependencies, err
+}
+
+func (d dashboardFactory) combineJobInputsAndOutputsWithDashboardJobs(dashboard []atc.JobSummary, jobInputs map[int][]atc.JobInputSummary, jobOutputs map[int][]atc.JobOutputSummary, jobDependencies map[int][]string) []atc.JobSummary {
 	var finalDashboard []atc.JobSummary
 	for _, job := range dashboard {
 		for _, input := range jobInputs[job.ID] {
@@ -486,6 +538,8 @@ func (d dashboardFactory) combineJobInputsAndOutputsWithDashboardJobs(dashboard
 			return job.Outputs[p].Name < job.Outputs[q].Name
 		})
 
+		job.DependsOn = jobDependencies[job.ID]
+
 		finalDashboard = append(finalDashboard, job)
 	}
 
@@ -384,6 +384,52 @@ var _ = Describe("JobFactory", func() {
 			})
 		})
 
+		Context("when the job's pipeline has templates", func() {
+			var templates atc.StepTemplateConfigs
+
+			BeforeEach(func() {
+				templates = atc.StepTemplateConfigs{
+					{
+						Name: "some-template",
+						Step: atc.Step{
+							Config: &atc.LoadVarStep{
+								Name: "some-var",
+								File: "some-file",
+							},
+						},
+					},
+				}
+
+				pipeline1, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name: "job-name",
+							PlanSequence: []atc.Step{
+								{Config: &atc.UseStep{Name: "some-template"}},
+							},
+						},
+					},
+					Templates: templates,
+				}, db.ConfigVersion(1), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				var found bool
+				job1, found, err = pipeline1.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				err = job1.RequestSchedule()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("fetches the job with the pipeline's templates", func() {
+				jobs, err := jobFactory.JobsToSchedule()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(len(jobs)).To(Equal(1))
+				Expect(jobs[0].Templates).To(Equal(templates))
+			})
+		})
+
 		Context("when the job has a requested schedule time earlier than the last scheduled", func() {
 			BeforeEach(func() {
 				pipeline1, _, err := defaultTeam.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, atc.Config{
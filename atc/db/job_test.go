@@ -1810,6 +1810,27 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("and another is created for the same job with a higher priority", func() {
+			var build2DB db.Build
+
+			BeforeEach(func() {
+				var err error
+				build2DB, err = job.CreateBuildWithPriority(10)
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(build2DB.Name()).To(Equal("2"))
+				Expect(build2DB.Priority()).To(Equal(10))
+			})
+
+			It("becomes the next pending build", func() {
+				nextPendingBuilds, err := job.GetPendingBuilds()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(nextPendingBuilds).To(HaveLen(2))
+				Expect(nextPendingBuilds[0].ID()).To(Equal(build2DB.ID()))
+				Expect(nextPendingBuilds[1].ID()).To(Equal(build1DB.ID()))
+			})
+		})
+
 		Context("when there is a rerun build created for an old build", func() {
 			var rerunBuild db.Build
 			var newBuild db.Build
@@ -1981,6 +2002,90 @@ var _ = Describe("Job", func() {
 		})
 	})
 
+	Describe("DependenciesSucceededSinceLastBuild", func() {
+		var scenario *dbtest.Scenario
+
+		BeforeEach(func() {
+			scenario = dbtest.Setup(
+				builder.WithPipeline(atc.Config{
+					Jobs: atc.JobConfigs{
+						{
+							Name:      "some-job",
+							DependsOn: []string{"upstream-job", "sharing-job"},
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.GetStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+						{
+							Name: "upstream-job",
+						},
+						{
+							Name: "sharing-job",
+							PlanSequence: []atc.Step{
+								{
+									Config: &atc.PutStep{
+										Name: "some-resource",
+									},
+								},
+							},
+						},
+					},
+					Resources: atc.ResourceConfigs{
+						{
+							Name: "some-resource",
+							Type: "some-type",
+						},
+					},
+				}),
+			)
+		})
+
+		succeeded := func() bool {
+			succeeded, err := scenario.Job("some-job").DependenciesSucceededSinceLastBuild()
+			Expect(err).ToNot(HaveOccurred())
+			return succeeded
+		}
+
+		finishBuild := func(jobName string, status db.BuildStatus) {
+			build, err := scenario.Job(jobName).CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(status)
+			Expect(err).ToNot(HaveOccurred())
+		}
+
+		It("returns false when the dependencies have not succeeded", func() {
+			finishBuild("upstream-job", db.BuildStatusFailed)
+			Expect(succeeded()).To(BeFalse())
+		})
+
+		It("returns true when a dependency succeeded", func() {
+			finishBuild("upstream-job", db.BuildStatusSucceeded)
+			Expect(succeeded()).To(BeTrue())
+		})
+
+		It("returns false once the job has a build created after the dependency succeeded", func() {
+			finishBuild("upstream-job", db.BuildStatusSucceeded)
+
+			err := scenario.Job("some-job").EnsurePendingBuildExists(context.TODO())
+			Expect(err).ToNot(HaveOccurred())
+
+			Expect(succeeded()).To(BeFalse())
+
+			finishBuild("upstream-job", db.BuildStatusSucceeded)
+			Expect(succeeded()).To(BeTrue())
+		})
+
+		It("ignores dependencies sharing an input with the job", func() {
+			finishBuild("sharing-job", db.BuildStatusSucceeded)
+			Expect(succeeded()).To(BeFalse())
+		})
+	})
+
 	Describe("Clear task cache", func() {
 		Context("when task cache exists", func() {
 			var (
@@ -2210,6 +2315,84 @@ var _ = Describe("Job", func() {
 			})
 		})
 
+		Context("when the job depends on a job which interacts with the input", func() {
+			BeforeEach(func() {
+				scenario = dbtest.Setup(
+					builder.WithPipeline(atc.Config{
+						Jobs: atc.JobConfigs{
+							{
+								Name:      "some-job",
+								DependsOn: []string{"job-1", "job-2"},
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name:     "some-input",
+											Resource: "some-resource",
+										},
+									},
+									{
+										Config: &atc.GetStep{
+											Name:     "some-other-input",
+											Resource: "some-other-resource",
+										},
+									},
+								},
+							},
+							{
+								Name: "job-1",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.GetStep{
+											Name: "some-resource",
+										},
+									},
+								},
+							},
+							{
+								Name: "job-2",
+								PlanSequence: []atc.Step{
+									{
+										Config: &atc.PutStep{
+											Name: "some-resource",
+										},
+									},
+								},
+							},
+						},
+						Resources: atc.ResourceConfigs{
+							{
+								Name: "some-resource",
+								Type: "some-type",
+							},
+							{
+								Name: "some-other-resource",
+								Type: "some-type",
+							},
+						},
+					}),
+				)
+			})
+
+			It("marks only that input with the dependencies", func() {
+				Expect(inputs).To(ConsistOf(
+					db.InputConfig{
+						Name:       "some-input",
+						JobID:      scenario.Job("some-job").ID(),
+						ResourceID: scenario.Resource("some-resource").ID(),
+						DependsOn: db.JobSet{
+							scenario.Job("job-1").ID(): true,
+							scenario.Job("job-2").ID(): true,
+						},
+					},
+					db.InputConfig{
+						Name:       "some-other-input",
+						JobID:      scenario.Job("some-job").ID(),
+						ResourceID: scenario.Resource("some-other-resource").ID(),
+					},
+				))
+			})
+		})
+
 		Context("when the input is pinned through the get step", func() {
 			BeforeEach(func() {
 				scenario = dbtest.Setup(
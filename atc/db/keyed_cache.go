@@ -0,0 +1,46 @@
+package db
+
+import (
+	sq "github.com/Masterminds/squirrel"
+)
+
+type keyedCache struct {
+	teamID int
+	key    string
+}
+
+func (f keyedCache) findOrCreate(tx Tx) (int, error) {
+	var id int
+	err := psql.Insert("keyed_caches").
+		Columns(
+			"team_id",
+			"key",
+		).
+		Values(
+			f.teamID,
+			f.key,
+		).
+		Suffix(`
+			ON CONFLICT (team_id, key) DO UPDATE SET
+				last_used = now()
+			RETURNING id
+		`).
+		RunWith(tx).
+		QueryRow().
+		Scan(&id)
+	if err != nil {
+		return 0, err
+	}
+
+	return id, nil
+}
+
+func (f keyedCache) release(tx Tx, cacheID int, volumeID int) error {
+	_, err := psql.Update("volumes").
+		Set("keyed_cache_id", nil).
+		Where(sq.Eq{"keyed_cache_id": cacheID}).
+		Where(sq.NotEq{"id": volumeID}).
+		RunWith(tx).
+		Exec()
+	return err
+}
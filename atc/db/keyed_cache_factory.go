@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"strings"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . KeyedCacheFactory
+
+type KeyedCacheFactory interface {
+	// FindCacheVolume finds the volume of the team's cache with the given key
+	// or, failing that, the volume of the most recently used cache whose key
+	// starts with one of the restore keys, tried in order. The key of the
+	// cache that was found is returned along with its volume.
+	FindCacheVolume(teamID int, key string, restoreKeys []string) (CreatedVolume, string, bool, error)
+}
+
+type keyedCacheFactory struct {
+	conn Conn
+}
+
+func NewKeyedCacheFactory(conn Conn) KeyedCacheFactory {
+	return &keyedCacheFactory{
+		conn: conn,
+	}
+}
+
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+func (f *keyedCacheFactory) FindCacheVolume(teamID int, key string, restoreKeys []string) (CreatedVolume, string, bool, error) {
+	conditions := []sq.Sqlizer{sq.Eq{"kc.key": key}}
+	for _, restoreKey := range restoreKeys {
+		conditions = append(conditions, sq.Expr("kc.key LIKE ?", likeEscaper.Replace(restoreKey)+"%"))
+	}
+
+	for _, condition := range conditions {
+		var cacheID int
+		var cacheKey, handle string
+		err := psql.Select("kc.id", "kc.key", "v.handle").
+			From("keyed_caches kc").
+			Join("volumes v ON v.keyed_cache_id = kc.id").
+			Join("workers w ON w.name = v.worker_name").
+			Where(sq.Eq{
+				"kc.team_id": teamID,
+				"v.state":    string(VolumeStateCreated),
+				"w.state":    string(WorkerStateRunning),
+			}).
+			Where(condition).
+			OrderBy("kc.last_used DESC", "v.id DESC").
+			Limit(1).
+			RunWith(f.conn).
+			QueryRow().
+			Scan(&cacheID, &cacheKey, &handle)
+		if err != nil {
+			if err == sql.ErrNoRows {
+				continue
+			}
+
+			return nil, "", false, err
+		}
+
+		_, createdVolume, err := getVolume(f.conn, map[string]interface{}{
+			"v.handle": handle,
+		})
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		if createdVolume == nil {
+			continue
+		}
+
+		_, err = psql.Update("keyed_caches").
+			Set("last_used", sq.Expr("now()")).
+			Where(sq.Eq{"id": cacheID}).
+			RunWith(f.conn).
+			Exec()
+		if err != nil {
+			return nil, "", false, err
+		}
+
+		return createdVolume, cacheKey, true, nil
+	}
+
+	return nil, "", false, nil
+}
@@ -0,0 +1,109 @@
+package db_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheFactory", func() {
+	var creatingContainer db.CreatingContainer
+
+	BeforeEach(func() {
+		build, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		creatingContainer, err = defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{})
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	createCacheVolume := func(path string, key string) db.CreatedVolume {
+		creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, path)
+		Expect(err).ToNot(HaveOccurred())
+
+		createdVolume, err := creatingVolume.Created()
+		Expect(err).ToNot(HaveOccurred())
+
+		err = createdVolume.InitializeKeyedCache(key)
+		Expect(err).ToNot(HaveOccurred())
+
+		return createdVolume
+	}
+
+	Describe("FindCacheVolume", func() {
+		Context("when there is no cache", func() {
+			It("returns not found", func() {
+				_, _, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-abc", []string{"go-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+
+		Context("when there is a cache under the key", func() {
+			var cacheVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				createCacheVolume("some-other-path", "go-def")
+				cacheVolume = createCacheVolume("some-path", "go-abc")
+			})
+
+			It("returns its volume", func() {
+				volume, key, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-abc", []string{"go-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(key).To(Equal("go-abc"))
+				Expect(volume.Handle()).To(Equal(cacheVolume.Handle()))
+			})
+
+			It("does not return it to other teams", func() {
+				otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "some-other-team"})
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, found, err := keyedCacheFactory.FindCacheVolume(otherTeam.ID(), "go-abc", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			Context("when another volume is stored under the key", func() {
+				var newVolume db.CreatedVolume
+
+				BeforeEach(func() {
+					newVolume = createCacheVolume("some-new-path", "go-abc")
+				})
+
+				It("returns the new volume", func() {
+					volume, _, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-abc", nil)
+					Expect(err).ToNot(HaveOccurred())
+					Expect(found).To(BeTrue())
+					Expect(volume.Handle()).To(Equal(newVolume.Handle()))
+				})
+			})
+		})
+
+		Context("when there are caches matching a restore key", func() {
+			var recentVolume db.CreatedVolume
+
+			BeforeEach(func() {
+				createCacheVolume("some-path", "go-abc")
+				createCacheVolume("some-other-path", "node-abc")
+				recentVolume = createCacheVolume("some-recent-path", "go-def")
+			})
+
+			It("returns the volume of the most recently used one", func() {
+				volume, key, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-ghi", []string{"go-"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(key).To(Equal("go-def"))
+				Expect(volume.Handle()).To(Equal(recentVolume.Handle()))
+			})
+
+			It("does not treat wildcards in the restore key as such", func() {
+				_, _, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-ghi", []string{"go_"})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
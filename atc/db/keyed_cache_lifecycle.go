@@ -0,0 +1,47 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . KeyedCacheLifecycle
+
+type KeyedCacheLifecycle interface {
+	CleanUpKeyedCaches(unusedFor time.Duration) (int, error)
+}
+
+type keyedCacheLifecycle struct {
+	conn Conn
+}
+
+func NewKeyedCacheLifecycle(conn Conn) KeyedCacheLifecycle {
+	return &keyedCacheLifecycle{
+		conn: conn,
+	}
+}
+
+// CleanUpKeyedCaches removes caches which have not been used for the given
+// period, along with caches whose volume has gone away. Their volumes are then
+// garbage-collected as orphans.
+func (lifecycle *keyedCacheLifecycle) CleanUpKeyedCaches(unusedFor time.Duration) (int, error) {
+	result, err := psql.Delete("keyed_caches").
+		Where(sq.Or{
+			sq.Expr(fmt.Sprintf("now() - last_used > '%d seconds'::interval", int(unusedFor.Seconds()))),
+			sq.Expr("NOT EXISTS (SELECT 1 FROM volumes v WHERE v.keyed_cache_id = keyed_caches.id)"),
+		}).
+		RunWith(lifecycle.conn).
+		Exec()
+	if err != nil {
+		return 0, err
+	}
+
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+
+	return int(deleted), nil
+}
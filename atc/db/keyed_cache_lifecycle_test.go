@@ -0,0 +1,89 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheLifecycle", func() {
+	Describe("CleanUpKeyedCaches", func() {
+		var cacheVolume db.CreatedVolume
+
+		BeforeEach(func() {
+			build, err := defaultTeam.CreateOneOffBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingContainer, err := defaultWorker.CreateContainer(db.NewBuildStepContainerOwner(build.ID(), "some-plan", defaultTeam.ID()), db.ContainerMetadata{})
+			Expect(err).ToNot(HaveOccurred())
+
+			creatingVolume, err := volumeRepository.CreateContainerVolume(defaultTeam.ID(), defaultWorker.Name(), creatingContainer, "some-path")
+			Expect(err).ToNot(HaveOccurred())
+
+			cacheVolume, err = creatingVolume.Created()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = cacheVolume.InitializeKeyedCache("go-abc")
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("keeps caches which have been used recently", func() {
+			deleted, err := keyedCacheLifecycle.CleanUpKeyedCaches(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deleted).To(BeZero())
+
+			_, _, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-abc", nil)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		Context("when a cache has not been used for the period", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE keyed_caches SET last_used = now() - interval '2 hours'`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes it", func() {
+				deleted, err := keyedCacheLifecycle.CleanUpKeyedCaches(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(1))
+
+				_, _, found, err := keyedCacheFactory.FindCacheVolume(defaultTeam.ID(), "go-abc", nil)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+
+			It("leaves its volume to be garbage collected", func() {
+				_, err := keyedCacheLifecycle.CleanUpKeyedCaches(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = dbConn.Exec(`UPDATE volumes SET container_id = NULL`)
+				Expect(err).ToNot(HaveOccurred())
+
+				orphaned, err := volumeRepository.GetOrphanedVolumes()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(orphaned).To(HaveLen(1))
+				Expect(orphaned[0].Handle()).To(Equal(cacheVolume.Handle()))
+			})
+		})
+
+		Context("when the volume of a cache is gone", func() {
+			BeforeEach(func() {
+				destroying, err := cacheVolume.Destroying()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = destroying.Destroy()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("removes it", func() {
+				deleted, err := keyedCacheLifecycle.CleanUpKeyedCaches(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deleted).To(Equal(1))
+			})
+		})
+	})
+})
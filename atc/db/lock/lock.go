@@ -22,6 +22,7 @@ const (
 	LockTypeActiveTasks
 	LockTypeResourceScanning
 	LockTypeJobScheduling
+	LockTypeTeamScheduling
 )
 
 var ErrLostLock = errors.New("lock was lost while held, possibly due to connection breakage")
@@ -58,6 +59,10 @@ func NewJobSchedulingLockID(jobID int) LockID {
 	return LockID{LockTypeJobScheduling, jobID}
 }
 
+func NewTeamSchedulingLockID(teamID int) LockID {
+	return LockID{LockTypeTeamScheduling, teamID}
+}
+
 //go:generate counterfeiter . LockFactory
 
 type LockFactory interface {
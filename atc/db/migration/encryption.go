@@ -16,6 +16,9 @@ var encryptedColumns = []encryptedColumn{
 	{"builds", "private_plan", "id"},
 	{"cert_cache", "cert", "domain"},
 	{"pipelines", "var_sources", "id"},
+	{"step_templates", "config", "id"},
+	{"pipeline_config_versions", "config", "id"},
+	{"webhooks", "secret", "id"},
 }
 
 type encryptedColumn struct {
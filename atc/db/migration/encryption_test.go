@@ -107,6 +107,32 @@ var _ = Describe("Encryption", func() {
 				Expect(isEncryptedWith(db, key1, "test")).To(BeTrue())
 			})
 
+			It("re-encrypts pipeline config versions with the new key", func() {
+				migrator := migration.NewMigrator(db, lockFactory)
+
+				err := migrator.Up(key2, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				id := insertPipelineConfigVersion(db, key2)
+
+				err = migrator.Up(key1, key2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isColumnEncryptedWith(db, key1, "pipeline_config_versions", "config", id)).To(BeTrue())
+			})
+
+			It("re-encrypts webhook secrets with the new key", func() {
+				migrator := migration.NewMigrator(db, lockFactory)
+
+				err := migrator.Up(key2, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				id := insertWebhook(db, key2)
+
+				err = migrator.Up(key1, key2)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(isColumnEncryptedWith(db, key1, "webhooks", "secret", id)).To(BeTrue())
+			})
+
 			It("rotates the key while doing a migration", func() {
 				migrator := migration.NewMigrator(db, lockFactory)
 
@@ -215,6 +241,58 @@ func isEncryptedWith(db *sql.DB, strategy encryption.Strategy, name string) bool
 	return err == nil
 }
 
+func insertPipelineConfigVersion(db *sql.DB, strategy encryption.Strategy) int {
+	var teamID, pipelineID, id int
+	err := db.QueryRow(`INSERT INTO teams(name) VALUES('config-versions-team') RETURNING id`).Scan(&teamID)
+	Expect(err).ToNot(HaveOccurred())
+
+	err = db.QueryRow(`INSERT INTO pipelines(name, team_id) VALUES('some-pipeline', $1) RETURNING id`, teamID).Scan(&pipelineID)
+	Expect(err).ToNot(HaveOccurred())
+
+	ciphertext, nonce, err := strategy.Encrypt([]byte("{}"))
+	Expect(err).ToNot(HaveOccurred())
+
+	err = db.QueryRow(`
+		INSERT INTO pipeline_config_versions(pipeline_id, version, config, nonce, set_via)
+		VALUES($1, 1, $2, $3, 'fly')
+		RETURNING id
+	`, pipelineID, ciphertext, nonce).Scan(&id)
+	Expect(err).ToNot(HaveOccurred())
+
+	return id
+}
+
+func insertWebhook(db *sql.DB, strategy encryption.Strategy) int {
+	var teamID, id int
+	err := db.QueryRow(`INSERT INTO teams(name) VALUES('webhooks-team') RETURNING id`).Scan(&teamID)
+	Expect(err).ToNot(HaveOccurred())
+
+	ciphertext, nonce, err := strategy.Encrypt([]byte("some-secret"))
+	Expect(err).ToNot(HaveOccurred())
+
+	err = db.QueryRow(`
+		INSERT INTO webhooks(team_id, name, url, secret, nonce)
+		VALUES($1, 'some-webhook', 'https://example.com', $2, $3)
+		RETURNING id
+	`, teamID, ciphertext, nonce).Scan(&id)
+	Expect(err).ToNot(HaveOccurred())
+
+	return id
+}
+
+func isColumnEncryptedWith(db *sql.DB, strategy encryption.Strategy, table string, column string, id int) bool {
+	var (
+		ciphertext string
+		nonce      *string
+	)
+	row := db.QueryRow(`SELECT `+column+`, nonce FROM `+table+` WHERE id = $1`, id)
+	err := row.Scan(&ciphertext, &nonce)
+	Expect(err).ToNot(HaveOccurred())
+
+	_, err = strategy.Decrypt(ciphertext, nonce)
+	return err == nil
+}
+
 // createKey generates an encryption.Key from a 32 characters key
 func createKey(key string) *encryption.Key {
 	k := []byte(key)
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    DROP COLUMN max_in_flight,
+    DROP COLUMN weight;
+COMMIT;
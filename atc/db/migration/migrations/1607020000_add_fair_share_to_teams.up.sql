@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE teams
+    ADD COLUMN max_in_flight integer NOT NULL DEFAULT 0,
+    ADD COLUMN weight integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE builds
+    DROP COLUMN priority;
+
+  ALTER TABLE jobs
+    DROP COLUMN priority;
+COMMIT;
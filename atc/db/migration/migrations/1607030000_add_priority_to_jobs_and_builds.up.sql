@@ -0,0 +1,7 @@
+BEGIN;
+  ALTER TABLE jobs
+    ADD COLUMN priority integer NOT NULL DEFAULT 0;
+
+  ALTER TABLE builds
+    ADD COLUMN priority integer NOT NULL DEFAULT 0;
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  ALTER TABLE volumes
+    DROP CONSTRAINT volumes_keyed_cache_id_fkey;
+
+  ALTER TABLE volumes
+    DROP COLUMN keyed_cache_id;
+
+  DROP TABLE keyed_caches;
+COMMIT;
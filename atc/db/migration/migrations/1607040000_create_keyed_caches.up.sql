@@ -0,0 +1,20 @@
+BEGIN;
+  CREATE TABLE keyed_caches (
+    id SERIAL PRIMARY KEY,
+    team_id INTEGER NOT NULL,
+    key TEXT NOT NULL,
+    last_used TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (team_id, key)
+  );
+
+  ALTER TABLE keyed_caches
+    ADD CONSTRAINT keyed_caches_team_id_fkey FOREIGN KEY (team_id) REFERENCES teams(id) ON DELETE CASCADE;
+
+  ALTER TABLE volumes
+    ADD COLUMN keyed_cache_id INTEGER;
+
+  ALTER TABLE volumes
+    ADD CONSTRAINT volumes_keyed_cache_id_fkey FOREIGN KEY (keyed_cache_id) REFERENCES keyed_caches(id) ON DELETE SET NULL;
+
+  CREATE INDEX volumes_keyed_cache_id ON volumes (keyed_cache_id);
+COMMIT;
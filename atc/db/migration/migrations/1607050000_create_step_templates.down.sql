@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE step_templates;
+COMMIT;
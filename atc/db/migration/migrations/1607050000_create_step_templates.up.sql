@@ -0,0 +1,10 @@
+BEGIN;
+  CREATE TABLE step_templates (
+    id serial PRIMARY KEY,
+    pipeline_id integer NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    name text NOT NULL,
+    config text NOT NULL,
+    nonce text,
+    UNIQUE (pipeline_id, name)
+  );
+COMMIT;
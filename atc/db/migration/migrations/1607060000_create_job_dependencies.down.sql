@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE job_dependencies;
+COMMIT;
@@ -0,0 +1,9 @@
+BEGIN;
+  CREATE TABLE job_dependencies (
+      job_id integer REFERENCES jobs(id) ON DELETE CASCADE NOT NULL,
+      depends_on_job_id integer REFERENCES jobs(id) ON DELETE CASCADE NOT NULL,
+      UNIQUE (job_id, depends_on_job_id)
+  );
+
+  CREATE INDEX job_dependencies_depends_on_job_id_idx ON job_dependencies (depends_on_job_id);
+COMMIT;
@@ -0,0 +1,26 @@
+BEGIN;
+  UPDATE builds SET status = 'started' WHERE status = 'waiting-approval';
+
+  DROP INDEX pending_builds_idx;
+  DROP INDEX succeeded_builds_ordering_with_rerun_builds_idx;
+  DROP INDEX needs_v6_migration_idx;
+
+  ALTER TYPE build_status RENAME TO build_status_old;
+
+  CREATE TYPE build_status AS ENUM (
+      'pending',
+      'started',
+      'aborted',
+      'succeeded',
+      'failed',
+      'errored'
+  );
+
+  ALTER TABLE builds ALTER COLUMN status TYPE build_status USING status::text::build_status;
+
+  DROP TYPE build_status_old;
+
+  CREATE INDEX pending_builds_idx ON builds (job_id, id ASC) WHERE status = 'pending';
+  CREATE INDEX succeeded_builds_ordering_with_rerun_builds_idx ON builds (job_id, rerun_of, COALESCE(rerun_of, id) DESC, id DESC) WHERE status = 'succeeded';
+  CREATE INDEX needs_v6_migration_idx ON builds (job_id, COALESCE(rerun_of, id) DESC, id DESC) WHERE status = 'succeeded' AND needs_v6_migration;
+COMMIT;
@@ -0,0 +1 @@
+ALTER TYPE build_status ADD VALUE IF NOT EXISTS 'waiting-approval' AFTER 'started';
@@ -0,0 +1,11 @@
+BEGIN;
+  CREATE TABLE build_approvals (
+      build_id integer REFERENCES builds(id) ON DELETE CASCADE NOT NULL,
+      plan_id text NOT NULL,
+      name text NOT NULL,
+      approved boolean,
+      decided_by text,
+      decided_at timestamp with time zone,
+      UNIQUE (build_id, plan_id)
+  );
+COMMIT;
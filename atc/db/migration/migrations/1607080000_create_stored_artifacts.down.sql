@@ -0,0 +1,3 @@
+BEGIN;
+  DROP TABLE stored_artifacts;
+COMMIT;
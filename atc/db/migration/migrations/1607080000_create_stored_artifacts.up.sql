@@ -0,0 +1,18 @@
+BEGIN;
+  CREATE TABLE stored_artifacts (
+    id SERIAL PRIMARY KEY,
+    build_id INTEGER,
+    name TEXT NOT NULL,
+    key TEXT NOT NULL,
+    size BIGINT DEFAULT 0 NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  -- the row outlives its build so that the blob can still be found and
+  -- removed from the artifact store by the collector
+  ALTER TABLE stored_artifacts
+    ADD CONSTRAINT stored_artifacts_build_id_fkey FOREIGN KEY (build_id) REFERENCES builds(id) ON DELETE SET NULL;
+
+  CREATE INDEX stored_artifacts_build_id ON stored_artifacts (build_id);
+  CREATE INDEX stored_artifacts_created_at ON stored_artifacts (created_at);
+COMMIT;
@@ -0,0 +1,14 @@
+BEGIN;
+  CREATE TABLE pipeline_config_versions (
+    id SERIAL PRIMARY KEY,
+    pipeline_id INTEGER NOT NULL REFERENCES pipelines (id) ON DELETE CASCADE,
+    version INTEGER NOT NULL,
+    config TEXT NOT NULL,
+    nonce TEXT,
+    set_by TEXT,
+    set_via TEXT NOT NULL,
+    build_id INTEGER REFERENCES builds (id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL,
+    UNIQUE (pipeline_id, version)
+  );
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+  CREATE TABLE audit_events (
+    id BIGSERIAL PRIMARY KEY,
+    action TEXT NOT NULL,
+    user_name TEXT,
+    team_name TEXT,
+    pipeline_name TEXT,
+    object TEXT,
+    source_ip TEXT,
+    status INTEGER NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT NOW() NOT NULL
+  );
+
+  CREATE INDEX audit_events_team_name_id_idx ON audit_events (team_name, id);
+  CREATE INDEX audit_events_created_at_idx ON audit_events (created_at);
+COMMIT;
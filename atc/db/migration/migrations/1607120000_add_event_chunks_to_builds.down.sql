@@ -0,0 +1,5 @@
+BEGIN;
+  DROP INDEX IF EXISTS builds_unarchived_events_idx;
+
+  ALTER TABLE builds DROP COLUMN event_chunks;
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+  ALTER TABLE builds ADD COLUMN event_chunks integer;
+
+  CREATE INDEX builds_unarchived_events_idx ON builds (id) WHERE completed AND event_chunks IS NULL AND reap_time IS NULL;
+COMMIT;
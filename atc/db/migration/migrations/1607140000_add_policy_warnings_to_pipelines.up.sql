@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE pipelines ADD COLUMN policy_warnings text[];
+COMMIT;
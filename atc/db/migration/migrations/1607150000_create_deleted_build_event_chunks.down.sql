@@ -0,0 +1,5 @@
+BEGIN;
+  DROP TRIGGER IF EXISTS archived_build_delete_trigger ON builds;
+  DROP FUNCTION IF EXISTS on_archived_build_delete();
+  DROP TABLE IF EXISTS deleted_build_event_chunks;
+COMMIT;
@@ -0,0 +1,15 @@
+BEGIN;
+  CREATE TABLE deleted_build_event_chunks (
+    build_id integer NOT NULL,
+    chunks integer NOT NULL
+  );
+
+  CREATE OR REPLACE FUNCTION on_archived_build_delete() RETURNS TRIGGER AS $$
+  BEGIN
+          INSERT INTO deleted_build_event_chunks (build_id, chunks) VALUES (OLD.id, OLD.event_chunks);
+          RETURN NULL;
+  END;
+  $$ LANGUAGE plpgsql;
+
+  CREATE TRIGGER archived_build_delete_trigger AFTER DELETE ON builds FOR EACH ROW WHEN (OLD.event_chunks IS NOT NULL) EXECUTE PROCEDURE on_archived_build_delete();
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+  CREATE TABLE build_drains (
+    build_id integer NOT NULL REFERENCES builds (id) ON DELETE CASCADE,
+    sink text NOT NULL,
+    PRIMARY KEY (build_id, sink)
+  );
+COMMIT;
@@ -0,0 +1,3 @@
+BEGIN;
+  ALTER TABLE build_resource_config_version_inputs ADD COLUMN resource_config_scope_id integer;
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+  ALTER TABLE audit_events ADD COLUMN team_name text;
+
+  UPDATE audit_events e SET team_name = t.name FROM teams t WHERE t.id = e.team_id;
+
+  DROP INDEX audit_events_team_id_id_idx;
+  ALTER TABLE audit_events DROP COLUMN team_id;
+
+  CREATE INDEX audit_events_team_name_id_idx ON audit_events (team_name, id);
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+  ALTER TABLE audit_events ADD COLUMN team_id integer REFERENCES teams (id) ON DELETE CASCADE;
+
+  -- events of teams which have since been renamed or destroyed can't be told
+  -- apart, so they are kept without a team for admins to see
+  UPDATE audit_events e SET team_id = t.id FROM teams t WHERE t.name = e.team_name;
+
+  DROP INDEX audit_events_team_name_id_idx;
+  ALTER TABLE audit_events DROP COLUMN team_name;
+
+  CREATE INDEX audit_events_team_id_id_idx ON audit_events (team_id, id);
+COMMIT;
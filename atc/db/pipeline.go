@@ -48,10 +48,13 @@ type Pipeline interface {
 	Display() *atc.DisplayConfig
 	ConfigVersion() ConfigVersion
 	Config() (atc.Config, error)
+	ConfigHistory() ([]PipelineConfigVersion, error)
+	ConfigHistoryVersion(version int) (PipelineConfigVersion, bool, error)
 	Public() bool
 	Paused() bool
 	Archived() bool
 	LastUpdated() time.Time
+	PolicyWarnings() []string
 
 	CheckPaused() (bool, error)
 	Reload() (bool, error)
@@ -80,6 +83,8 @@ type Pipeline interface {
 	ResourceType(name string) (ResourceType, bool, error)
 	ResourceTypeByID(id int) (ResourceType, bool, error)
 
+	Templates() (atc.StepTemplateConfigs, error)
+
 	Job(name string) (Job, bool, error)
 	Jobs() (Jobs, error)
 	Dashboard() ([]atc.JobSummary, error)
@@ -116,6 +121,7 @@ type pipeline struct {
 	public        bool
 	archived      bool
 	lastUpdated   time.Time
+	warnings      []string
 
 	conn        Conn
 	lockFactory lock.LockFactory
@@ -140,7 +146,8 @@ var pipelinesQuery = psql.Select(`
 		p.last_updated,
 		p.parent_job_id,
 		p.parent_build_id,
-		p.instance_vars
+		p.instance_vars,
+		p.policy_warnings
 	`).
 	From("pipelines p").
 	LeftJoin("teams t ON p.team_id = t.id")
@@ -168,6 +175,7 @@ func (p *pipeline) Public() bool                     { return p.public }
 func (p *pipeline) Paused() bool                     { return p.paused }
 func (p *pipeline) Archived() bool                   { return p.archived }
 func (p *pipeline) LastUpdated() time.Time           { return p.lastUpdated }
+func (p *pipeline) PolicyWarnings() []string         { return p.warnings }
 
 // IMPORTANT: This method is broken with the new resource config versions changes
 func (p *pipeline) Causality(versionedResourceID int) ([]Cause, error) {
@@ -270,12 +278,18 @@ func (p *pipeline) Config() (atc.Config, error) {
 		return atc.Config{}, fmt.Errorf("failed to get job configs: %w", err)
 	}
 
+	templates, err := p.Templates()
+	if err != nil {
+		return atc.Config{}, fmt.Errorf("failed to get templates: %w", err)
+	}
+
 	config := atc.Config{
 		Groups:        p.Groups(),
 		VarSources:    p.VarSources(),
 		Resources:     resources.Configs(),
 		ResourceTypes: resourceTypes.Configs(),
 		Jobs:          jobConfigs,
+		Templates:     templates,
 		Display:       p.Display(),
 	}
 
@@ -484,6 +498,10 @@ func (p *pipeline) Resources() (Resources, error) {
 	return resources(p.id, p.conn, p.lockFactory)
 }
 
+func (p *pipeline) Templates() (atc.StepTemplateConfigs, error) {
+	return loadStepTemplates(p.conn, p.conn.EncryptionStrategy(), p.id)
+}
+
 func (p *pipeline) ResourceTypes() (ResourceTypes, error) {
 	rows, err := resourceTypesQuery.
 		Where(sq.Eq{"r.pipeline_id": p.id}).
@@ -687,7 +705,12 @@ func (p *pipeline) archive(tx Tx) error {
 		return err
 	}
 
-	return p.clearConfigForResourceTypesInPipeline(tx)
+	err = p.clearConfigForResourceTypesInPipeline(tx)
+	if err != nil {
+		return err
+	}
+
+	return p.clearStepTemplatesInPipeline(tx)
 }
 
 func (p *pipeline) Hide() error {
@@ -1154,7 +1177,7 @@ func (p *pipeline) Variables(logger lager.Logger, globalSecrets creds.Secrets, v
 		if !ok {
 			return nil, fmt.Errorf("var_source '%s' invalid config", cm.Name)
 		}
-		secrets, err := varSourcePool.FindOrCreate(logger, config, factory)
+		secrets, err := varSourcePool.FindOrCreateForPipeline(logger, config, factory, p.TeamName(), p.Name())
 		if err != nil {
 			return nil, errors.Wrapf(err, "create var_source '%s' error", cm.Name)
 		}
@@ -1316,6 +1339,18 @@ func (p *pipeline) clearConfigForResourcesInPipeline(tx Tx) error {
 	return nil
 }
 
+func (p *pipeline) clearStepTemplatesInPipeline(tx Tx) error {
+	_, err := psql.Delete("step_templates").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (p *pipeline) clearConfigForResourceTypesInPipeline(tx Tx) error {
 	_, err := psql.Update("resource_types").
 		Set("config", nil).
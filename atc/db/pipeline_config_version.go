@@ -0,0 +1,140 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/concourse/concourse/atc"
+)
+
+const (
+	PipelineConfigSetViaFly         = "fly"
+	PipelineConfigSetViaSetPipeline = "set_pipeline"
+)
+
+// PipelineConfigVersion is a config which was saved for a pipeline at some
+// point in time. Versions are numbered per pipeline, starting from 1.
+type PipelineConfigVersion struct {
+	Version   int
+	Config    atc.Config
+	SetBy     string
+	SetVia    string
+	BuildID   int
+	CreatedAt time.Time
+}
+
+func saveConfigVersion(
+	tx Tx,
+	pipelineID int,
+	config atc.Config,
+	setBy string,
+	setVia string,
+	buildID sql.NullInt64,
+) error {
+	payload, err := json.Marshal(config)
+	if err != nil {
+		return err
+	}
+
+	encryptedPayload, nonce, err := tx.EncryptionStrategy().Encrypt(payload)
+	if err != nil {
+		return err
+	}
+
+	_, err = psql.Insert("pipeline_config_versions").
+		SetMap(map[string]interface{}{
+			"pipeline_id": pipelineID,
+			"version": sq.Expr(
+				"(SELECT COALESCE(MAX(version), 0) + 1 FROM pipeline_config_versions WHERE pipeline_id = ?)",
+				pipelineID,
+			),
+			"config":   encryptedPayload,
+			"nonce":    nonce,
+			"set_by":   sql.NullString{String: setBy, Valid: setBy != ""},
+			"set_via":  setVia,
+			"build_id": buildID,
+		}).
+		RunWith(tx).
+		Exec()
+	return err
+}
+
+func (p *pipeline) ConfigHistory() ([]PipelineConfigVersion, error) {
+	rows, err := psql.Select("version", "COALESCE(set_by, '')", "set_via", "COALESCE(build_id, 0)", "created_at").
+		From("pipeline_config_versions").
+		Where(sq.Eq{"pipeline_id": p.id}).
+		OrderBy("version DESC").
+		RunWith(p.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	versions := []PipelineConfigVersion{}
+	for rows.Next() {
+		var version PipelineConfigVersion
+		err = rows.Scan(&version.Version, &version.SetBy, &version.SetVia, &version.BuildID, &version.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		versions = append(versions, version)
+	}
+
+	return versions, nil
+}
+
+func (p *pipeline) ConfigHistoryVersion(version int) (PipelineConfigVersion, bool, error) {
+	var (
+		configVersion PipelineConfigVersion
+		payload       string
+		nonce         sql.NullString
+	)
+
+	err := psql.Select("version", "config", "nonce", "COALESCE(set_by, '')", "set_via", "COALESCE(build_id, 0)", "created_at").
+		From("pipeline_config_versions").
+		Where(sq.Eq{
+			"pipeline_id": p.id,
+			"version":     version,
+		}).
+		RunWith(p.conn).
+		QueryRow().
+		Scan(
+			&configVersion.Version,
+			&payload,
+			&nonce,
+			&configVersion.SetBy,
+			&configVersion.SetVia,
+			&configVersion.BuildID,
+			&configVersion.CreatedAt,
+		)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return PipelineConfigVersion{}, false, nil
+		}
+
+		return PipelineConfigVersion{}, false, err
+	}
+
+	var noncense *string
+	if nonce.Valid {
+		noncense = &nonce.String
+	}
+
+	decrypted, err := p.conn.EncryptionStrategy().Decrypt(payload, noncense)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	err = json.Unmarshal(decrypted, &configVersion.Config)
+	if err != nil {
+		return PipelineConfigVersion{}, false, err
+	}
+
+	return configVersion, true, nil
+}
@@ -33,7 +33,7 @@ var _ = Describe("PipelineLifecycle", func() {
 
 			BeforeEach(func() {
 				build, _ := defaultJob.CreateBuild()
-				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false)
+				childPipeline, _, _ = build.SavePipeline(atc.PipelineRef{Name: "child-pipeline"}, defaultTeam.ID(), defaultPipelineConfig, db.ConfigVersion(0), false, nil)
 				build.Finish(db.BuildStatusSucceeded)
 			})
 
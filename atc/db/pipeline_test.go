@@ -757,7 +757,7 @@ var _ = Describe("Pipeline", func() {
 				build1DB, err := scenarioPipeline1.Job("a-job").CreateBuild()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = build1DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
+				err = build1DB.SaveOutput(nil, "some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build1DB.Finish(db.BuildStatusSucceeded)
@@ -808,7 +808,7 @@ var _ = Describe("Pipeline", func() {
 				build2DB, err := scenarioPipeline1.Job("a-job").CreateBuild()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = build2DB.SaveOutput("some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
+				err = build2DB.SaveOutput(nil, "some-type", atc.Source{"source-config": "some-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-resource")
 				Expect(err).ToNot(HaveOccurred())
 
 				err = build2DB.Finish(db.BuildStatusFailed)
@@ -857,7 +857,7 @@ var _ = Describe("Pipeline", func() {
 				otherPipelineBuild, err := scenarioPipeline1.Job("a-job").CreateBuild()
 				Expect(err).ToNot(HaveOccurred())
 
-				err = otherPipelineBuild.SaveOutput("some-type", atc.Source{"other-source-config": "some-other-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-other-resource")
+				err = otherPipelineBuild.SaveOutput(nil, "some-type", atc.Source{"other-source-config": "some-other-value"}, atc.VersionedResourceTypes{}, atc.Version{"version": "1"}, nil, "some-output-name", "some-other-resource")
 				Expect(err).ToNot(HaveOccurred())
 
 				// After SaveOutput to other resource, we need to reload it because its resourceConfigScopeID
@@ -1114,7 +1114,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(found).To(BeTrue())
 
 			By("populating build outputs")
-			err = build.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"key": "value"}, nil, "some-output-name", "some-resource")
+			err = build.SaveOutput(nil, "some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"key": "value"}, nil, "some-output-name", "some-resource")
 			Expect(err).ToNot(HaveOccurred())
 
 			By("populating build events")
@@ -1628,7 +1628,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			err = dbBuild.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v1"}, []db.ResourceConfigMetadataField{
+			err = dbBuild.SaveOutput(nil, "some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v1"}, []db.ResourceConfigMetadataField{
 				{
 					Name:  "some",
 					Value: "value",
@@ -1640,7 +1640,7 @@ var _ = Describe("Pipeline", func() {
 			Expect(err).ToNot(HaveOccurred())
 			Expect(found).To(BeTrue())
 
-			err = dbSecondBuild.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v1"}, []db.ResourceConfigMetadataField{
+			err = dbSecondBuild.SaveOutput(nil, "some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v1"}, []db.ResourceConfigMetadataField{
 				{
 					Name:  "some",
 					Value: "value",
@@ -1648,7 +1648,7 @@ var _ = Describe("Pipeline", func() {
 			}, "some-output-name", "some-resource")
 			Expect(err).ToNot(HaveOccurred())
 
-			err = dbSecondBuild.SaveOutput("some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v3"}, nil, "some-output-name", "some-resource")
+			err = dbSecondBuild.SaveOutput(nil, "some-type", atc.Source{"some": "source"}, atc.VersionedResourceTypes{}, atc.Version{"version": "v3"}, nil, "some-output-name", "some-resource")
 			Expect(err).ToNot(HaveOccurred())
 
 			rcv1 := scenario.ResourceVersion("some-resource", atc.Version{"version": "v1"})
@@ -2119,6 +2119,34 @@ var _ = Describe("Pipeline", func() {
 		})
 	})
 
+	Describe("PolicyWarnings", func() {
+		savePolicyWarnings := func(warnings []string) {
+			_, _, err := team.SavePipelineWithOptions(atc.PipelineRef{Name: "fake-pipeline"}, pipelineConfig, pipeline.ConfigVersion(), false, db.SavePipelineOptions{
+				PolicyWarnings: warnings,
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			found, err := pipeline.Reload()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		}
+
+		It("has no policy warnings initially", func() {
+			Expect(pipeline.PolicyWarnings()).To(BeEmpty())
+		})
+
+		It("saves the policy warnings with the config", func() {
+			savePolicyWarnings([]string{"some-warning", "other-warning"})
+			Expect(pipeline.PolicyWarnings()).To(Equal([]string{"some-warning", "other-warning"}))
+		})
+
+		It("clears the policy warnings of the previous config", func() {
+			savePolicyWarnings([]string{"some-warning"})
+			savePolicyWarnings(nil)
+			Expect(pipeline.PolicyWarnings()).To(BeEmpty())
+		})
+	})
+
 	Describe("SetParentIDs", func() {
 		It("sets the parent_job_id and parent_build_id fields", func() {
 			jobID := 123
@@ -2170,6 +2198,76 @@ var _ = Describe("Pipeline", func() {
 			Expect(pipeline.Config()).To(Equal(pipelineConfig))
 		})
 	})
+
+	Describe("ConfigHistory", func() {
+		var updatedConfig atc.Config
+
+		BeforeEach(func() {
+			updatedConfig = pipelineConfig
+			updatedConfig.Jobs = append(atc.JobConfigs{}, pipelineConfig.Jobs...)
+			updatedConfig.Jobs[0].Public = true
+
+			_, _, err := team.SavePipelineWithOptions(atc.PipelineRef{Name: "fake-pipeline"}, updatedConfig, pipeline.ConfigVersion(), false, db.SavePipelineOptions{
+				SetBy: "some-user",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("records every saved config, newest first", func() {
+			versions, err := pipeline.ConfigHistory()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(versions).To(HaveLen(2))
+
+			Expect(versions[0].Version).To(Equal(2))
+			Expect(versions[0].SetBy).To(Equal("some-user"))
+			Expect(versions[0].SetVia).To(Equal(db.PipelineConfigSetViaFly))
+
+			Expect(versions[1].Version).To(Equal(1))
+			Expect(versions[1].SetBy).To(BeEmpty())
+		})
+
+		It("returns the config of a given version", func() {
+			version, found, err := pipeline.ConfigHistoryVersion(1)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Config).To(Equal(pipelineConfig))
+
+			version, found, err = pipeline.ConfigHistoryVersion(2)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(version.Config).To(Equal(updatedConfig))
+		})
+
+		It("does not find versions which were never saved", func() {
+			_, found, err := pipeline.ConfigHistoryVersion(3)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+		})
+
+		Context("when the config is set by a set_pipeline step", func() {
+			It("records the build which set it", func() {
+				job, found, err := pipeline.Job("job-name")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				build, err := job.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, err = pipeline.Reload()
+				Expect(err).ToNot(HaveOccurred())
+
+				_, _, err = build.SavePipeline(atc.PipelineRef{Name: "fake-pipeline"}, team.ID(), pipelineConfig, pipeline.ConfigVersion(), false, nil)
+				Expect(err).ToNot(HaveOccurred())
+
+				versions, err := pipeline.ConfigHistory()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(versions[0].Version).To(Equal(3))
+				Expect(versions[0].SetBy).To(Equal("fake-pipeline/job-name #" + build.Name()))
+				Expect(versions[0].SetVia).To(Equal(db.PipelineConfigSetViaSetPipeline))
+				Expect(versions[0].BuildID).To(Equal(build.ID()))
+			})
+		})
+	})
 })
 
 func intptr(i int) *int {
@@ -177,7 +177,7 @@ func (r *resource) ResourceConfigID() int            { return r.resourceConfigID
 func (r *resource) ResourceConfigScopeID() int       { return r.resourceConfigScopeID }
 func (r *resource) Icon() string                     { return r.config.Icon }
 
-func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" }
+func (r *resource) HasWebhook() bool { return r.WebhookToken() != "" || r.config.Webhook != nil }
 
 func (r *resource) Reload() (bool, error) {
 	row := resourcesQuery.Where(sq.Eq{"r.id": r.id}).
@@ -265,7 +265,9 @@ func saveResourceVersion(tx Tx, rcsID int, version atc.Version, metadata Resourc
 		INSERT INTO resource_config_versions (resource_config_scope_id, version, version_md5, metadata, span_context)
 		SELECT $1, $2, md5($3), $4, $5
 		ON CONFLICT (resource_config_scope_id, version_md5)
-		DO UPDATE SET metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata)
+		DO UPDATE SET
+			metadata = COALESCE(NULLIF(excluded.metadata, 'null'::jsonb), resource_config_versions.metadata),
+			span_context = COALESCE(NULLIF(resource_config_versions.span_context, 'null'::jsonb), excluded.span_context)
 		RETURNING check_order
 		`, rcsID, string(versionJSON), string(versionJSON), string(metadataJSON), string(spanContextJSON)).Scan(&checkOrder)
 	if err != nil {
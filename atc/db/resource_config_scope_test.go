@@ -125,6 +125,31 @@ var _ = Describe("Resource Config Scope", func() {
 				Expect(latestVR.CheckOrder()).To(Equal(2))
 			})
 
+			It("records the span context it is saved with if it had none", func() {
+				err := resourceScope.SaveVersions(db.SpanContext{"traceparent": "some-trace-parent"}, newVersionSlice)
+				Expect(err).ToNot(HaveOccurred())
+
+				latestVR, found, err := resourceScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(latestVR.SpanContext()).To(Equal(db.SpanContext{"traceparent": "some-trace-parent"}))
+			})
+
+			It("keeps the span context it was first saved with", func() {
+				err := resourceScope.SaveVersions(db.SpanContext{"traceparent": "some-trace-parent"}, newVersionSlice)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = resourceScope.SaveVersions(db.SpanContext{"traceparent": "some-other-trace-parent"}, newVersionSlice)
+				Expect(err).ToNot(HaveOccurred())
+
+				latestVR, found, err := resourceScope.LatestVersion()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				Expect(latestVR.SpanContext()).To(Equal(db.SpanContext{"traceparent": "some-trace-parent"}))
+			})
+
 			It("does not change the check order", func() {
 				err := resourceScope.SaveVersions(nil, newVersionSlice)
 				Expect(err).ToNot(HaveOccurred())
@@ -55,6 +55,15 @@ var _ = Describe("Resource", func() {
 						CheckEvery:   "10ms",
 						CheckTimeout: "1m",
 					},
+					{
+						Name:   "some-signed-webhook-resource",
+						Type:   "git",
+						Source: atc.Source{"some": "signed-repository"},
+						Webhook: &atc.ResourceWebhook{
+							Provider: atc.WebhookProviderGitHub,
+							Secret:   "some-secret",
+						},
+					},
 				},
 				Jobs: atc.JobConfigs{
 					{
@@ -89,7 +98,7 @@ var _ = Describe("Resource", func() {
 		})
 
 		It("returns the resources", func() {
-			Expect(resources).To(HaveLen(4))
+			Expect(resources).To(HaveLen(5))
 
 			ids := map[int]struct{}{}
 
@@ -117,6 +126,12 @@ var _ = Describe("Resource", func() {
 					Expect(r.CheckEvery()).To(Equal("10ms"))
 					Expect(r.CheckTimeout()).To(Equal("1m"))
 					Expect(r.HasWebhook()).To(BeFalse())
+				case "some-signed-webhook-resource":
+					Expect(r.Config().Webhook).To(Equal(&atc.ResourceWebhook{
+						Provider: atc.WebhookProviderGitHub,
+						Secret:   "some-secret",
+					}))
+					Expect(r.HasWebhook()).To(BeTrue())
 				}
 			}
 		})
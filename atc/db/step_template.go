@@ -0,0 +1,88 @@
+package db
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+)
+
+func saveStepTemplates(tx Tx, templates atc.StepTemplateConfigs, pipelineID int) error {
+	_, err := psql.Delete("step_templates").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	for _, template := range templates {
+		configPayload, err := json.Marshal(template)
+		if err != nil {
+			return err
+		}
+
+		encryptedPayload, nonce, err := tx.EncryptionStrategy().Encrypt(configPayload)
+		if err != nil {
+			return err
+		}
+
+		_, err = psql.Insert("step_templates").
+			Columns("pipeline_id", "name", "config", "nonce").
+			Values(pipelineID, template.Name, encryptedPayload, nonce).
+			RunWith(tx).
+			Exec()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func loadStepTemplates(runner sq.BaseRunner, es encryption.Strategy, pipelineID int) (atc.StepTemplateConfigs, error) {
+	rows, err := psql.Select("config", "nonce").
+		From("step_templates").
+		Where(sq.Eq{"pipeline_id": pipelineID}).
+		OrderBy("id").
+		RunWith(runner).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var templates atc.StepTemplateConfigs
+	for rows.Next() {
+		var config string
+		var nonce sql.NullString
+
+		err := rows.Scan(&config, &nonce)
+		if err != nil {
+			return nil, err
+		}
+
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decryptedConfig, err := es.Decrypt(config, noncense)
+		if err != nil {
+			return nil, err
+		}
+
+		var template atc.StepTemplateConfig
+		err = json.Unmarshal(decryptedConfig, &template)
+		if err != nil {
+			return nil, err
+		}
+
+		templates = append(templates, template)
+	}
+
+	return templates, nil
+}
@@ -0,0 +1,80 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+// StoredArtifact is a path from a task's output which was uploaded to the
+// artifact store, where it outlives the volume it was produced in.
+type StoredArtifact struct {
+	ID        int
+	BuildID   int
+	Name      string
+	Key       string
+	Size      int64
+	CreatedAt time.Time
+}
+
+//go:generate counterfeiter . StoredArtifactFactory
+
+type StoredArtifactFactory interface {
+	CreateStoredArtifact(buildID int, name string, key string, size int64) (StoredArtifact, error)
+}
+
+type storedArtifactFactory struct {
+	conn Conn
+}
+
+func NewStoredArtifactFactory(conn Conn) StoredArtifactFactory {
+	return &storedArtifactFactory{
+		conn: conn,
+	}
+}
+
+func (f *storedArtifactFactory) CreateStoredArtifact(buildID int, name string, key string, size int64) (StoredArtifact, error) {
+	row := psql.Insert("stored_artifacts").
+		Columns("build_id", "name", "key", "size").
+		Values(buildID, name, key, size).
+		Suffix("RETURNING " + storedArtifactColumns).
+		RunWith(f.conn).
+		QueryRow()
+
+	return scanStoredArtifact(row)
+}
+
+const storedArtifactColumns = "id, COALESCE(build_id, 0), name, key, size, created_at"
+
+var storedArtifactsQuery = psql.Select(storedArtifactColumns).
+	From("stored_artifacts")
+
+func scanStoredArtifact(row sq.RowScanner) (StoredArtifact, error) {
+	var artifact StoredArtifact
+	err := row.Scan(
+		&artifact.ID,
+		&artifact.BuildID,
+		&artifact.Name,
+		&artifact.Key,
+		&artifact.Size,
+		&artifact.CreatedAt,
+	)
+	return artifact, err
+}
+
+func scanStoredArtifacts(rows *sql.Rows) ([]StoredArtifact, error) {
+	defer Close(rows)
+
+	artifacts := []StoredArtifact{}
+	for rows.Next() {
+		artifact, err := scanStoredArtifact(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		artifacts = append(artifacts, artifact)
+	}
+
+	return artifacts, nil
+}
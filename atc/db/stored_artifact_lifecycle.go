@@ -0,0 +1,59 @@
+package db
+
+import (
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+)
+
+//go:generate counterfeiter . StoredArtifactLifecycle
+
+type StoredArtifactLifecycle interface {
+	ExpiredStoredArtifacts(retention time.Duration) ([]StoredArtifact, error)
+	DeleteStoredArtifact(id int) error
+}
+
+type storedArtifactLifecycle struct {
+	conn Conn
+}
+
+func NewStoredArtifactLifecycle(conn Conn) StoredArtifactLifecycle {
+	return &storedArtifactLifecycle{
+		conn: conn,
+	}
+}
+
+// ExpiredStoredArtifacts returns artifacts whose build has been deleted, along
+// with artifacts older than the given retention period. A retention of zero
+// keeps artifacts for as long as their build exists.
+func (lifecycle *storedArtifactLifecycle) ExpiredStoredArtifacts(retention time.Duration) ([]StoredArtifact, error) {
+	expired := sq.Or{
+		sq.Eq{"build_id": nil},
+	}
+
+	if retention > 0 {
+		expired = append(expired, sq.Expr(fmt.Sprintf("now() - created_at > '%d seconds'::interval", int(retention.Seconds()))))
+	}
+
+	rows, err := storedArtifactsQuery.
+		Where(expired).
+		OrderBy("id ASC").
+		RunWith(lifecycle.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	return scanStoredArtifacts(rows)
+}
+
+// DeleteStoredArtifact removes the record of an artifact once its blob has
+// been removed from the artifact store.
+func (lifecycle *storedArtifactLifecycle) DeleteStoredArtifact(id int) error {
+	_, err := psql.Delete("stored_artifacts").
+		Where(sq.Eq{"id": id}).
+		RunWith(lifecycle.conn).
+		Exec()
+	return err
+}
@@ -0,0 +1,107 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoredArtifactLifecycle", func() {
+	var (
+		build    db.Build
+		artifact db.StoredArtifact
+	)
+
+	BeforeEach(func() {
+		var err error
+		build, err = defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		artifact, err = storedArtifactFactory.CreateStoredArtifact(build.ID(), "app.tgz", "builds/1/some-plan/app.tgz.tgz", 1024)
+		Expect(err).ToNot(HaveOccurred())
+	})
+
+	It("creates the artifact for the build", func() {
+		Expect(artifact.ID).ToNot(BeZero())
+		Expect(artifact.BuildID).To(Equal(build.ID()))
+		Expect(artifact.Name).To(Equal("app.tgz"))
+		Expect(artifact.Key).To(Equal("builds/1/some-plan/app.tgz.tgz"))
+		Expect(artifact.Size).To(Equal(int64(1024)))
+		Expect(artifact.CreatedAt).ToNot(BeZero())
+
+		artifacts, err := build.StoredArtifacts()
+		Expect(err).ToNot(HaveOccurred())
+		Expect(artifacts).To(Equal([]db.StoredArtifact{artifact}))
+
+		found, ok, err := build.StoredArtifact(artifact.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(ok).To(BeTrue())
+		Expect(found).To(Equal(artifact))
+	})
+
+	It("does not find artifacts of other builds", func() {
+		otherBuild, err := defaultTeam.CreateOneOffBuild()
+		Expect(err).ToNot(HaveOccurred())
+
+		_, found, err := otherBuild.StoredArtifact(artifact.ID)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(found).To(BeFalse())
+	})
+
+	Describe("ExpiredStoredArtifacts", func() {
+		It("keeps recent artifacts", func() {
+			expired, err := storedArtifactLifecycle.ExpiredStoredArtifacts(time.Hour)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expired).To(BeEmpty())
+		})
+
+		Context("when the artifact is older than the retention period", func() {
+			BeforeEach(func() {
+				_, err := dbConn.Exec(`UPDATE stored_artifacts SET created_at = now() - interval '2 hours'`)
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns it", func() {
+				expired, err := storedArtifactLifecycle.ExpiredStoredArtifacts(time.Hour)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(expired).To(HaveLen(1))
+				Expect(expired[0].ID).To(Equal(artifact.ID))
+			})
+
+			It("keeps it when there is no retention period", func() {
+				expired, err := storedArtifactLifecycle.ExpiredStoredArtifacts(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(expired).To(BeEmpty())
+			})
+		})
+
+		Context("when the build is deleted", func() {
+			BeforeEach(func() {
+				_, err := build.Delete()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns the artifact so that its blob can be removed", func() {
+				expired, err := storedArtifactLifecycle.ExpiredStoredArtifacts(0)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(expired).To(HaveLen(1))
+				Expect(expired[0].Key).To(Equal(artifact.Key))
+				Expect(expired[0].BuildID).To(BeZero())
+			})
+		})
+	})
+
+	Describe("DeleteStoredArtifact", func() {
+		It("removes the artifact", func() {
+			err := storedArtifactLifecycle.DeleteStoredArtifact(artifact.ID)
+			Expect(err).ToNot(HaveOccurred())
+
+			artifacts, err := build.StoredArtifacts()
+			Expect(err).ToNot(HaveOccurred())
+			Expect(artifacts).To(BeEmpty())
+		})
+	})
+})
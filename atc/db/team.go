@@ -29,6 +29,8 @@ type Team interface {
 	Admin() bool
 
 	Auth() atc.TeamAuth
+	MaxInFlight() int
+	Weight() int
 
 	Delete() error
 	Rename(string) error
@@ -40,6 +42,14 @@ type Team interface {
 		initiallyPaused bool,
 	) (Pipeline, bool, error)
 
+	SavePipelineWithOptions(
+		pipelineRef atc.PipelineRef,
+		config atc.Config,
+		from ConfigVersion,
+		initiallyPaused bool,
+		opts SavePipelineOptions,
+	) (Pipeline, bool, error)
+
 	Pipeline(pipelineRef atc.PipelineRef) (Pipeline, bool, error)
 	Pipelines() ([]Pipeline, error)
 	PublicPipelines() ([]Pipeline, error)
@@ -68,6 +78,7 @@ type Team interface {
 	FindWorkerForVolume(handle string) (Worker, bool, error)
 
 	UpdateProviderAuth(auth atc.TeamAuth) error
+	Update(atcTeam atc.Team) error
 }
 
 type team struct {
@@ -79,6 +90,9 @@ type team struct {
 	admin bool
 
 	auth atc.TeamAuth
+
+	maxInFlight int
+	weight      int
 }
 
 func (t *team) ID() int      { return t.id }
@@ -87,6 +101,9 @@ func (t *team) Admin() bool  { return t.admin }
 
 func (t *team) Auth() atc.TeamAuth { return t.auth }
 
+func (t *team) MaxInFlight() int { return t.maxInFlight }
+func (t *team) Weight() int      { return t.weight }
+
 func (t *team) Delete() error {
 	_, err := psql.Delete("teams").
 		Where(sq.Eq{
@@ -356,6 +373,9 @@ func savePipeline(
 	teamID int,
 	jobID sql.NullInt64,
 	buildID sql.NullInt64,
+	setBy string,
+	setVia string,
+	policyWarnings []string,
 ) (int, bool, error) {
 
 	var instanceVars sql.NullString
@@ -422,6 +442,7 @@ func savePipeline(
 				"parent_job_id":   jobID,
 				"parent_build_id": buildID,
 				"instance_vars":   instanceVars,
+				"policy_warnings": policyWarningsValue(policyWarnings),
 			}).
 			Suffix("RETURNING id").
 			RunWith(tx).
@@ -441,6 +462,7 @@ func savePipeline(
 			Set("last_updated", sq.Expr("now()")).
 			Set("parent_job_id", jobID).
 			Set("parent_build_id", buildID).
+			Set("policy_warnings", policyWarningsValue(policyWarnings)).
 			Where(sq.And{
 				pipelineRefWhereClause,
 				sq.Eq{"version": from},
@@ -513,17 +535,22 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = saveStepTemplates(tx, config.Templates, pipelineID)
+	if err != nil {
+		return 0, false, err
+	}
+
 	jobNameToID, err := saveJobsAndSerialGroups(tx, config.Jobs, config.Groups, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs)
+	err = removeUnusedWorkerTaskCaches(tx, pipelineID, config.Jobs, config.Templates)
 	if err != nil {
 		return 0, false, err
 	}
 
-	err = insertJobPipes(tx, config.Jobs, resourceNameToID, jobNameToID, pipelineID)
+	err = insertJobPipes(tx, config.Jobs, config.Templates, resourceNameToID, jobNameToID, pipelineID)
 	if err != nil {
 		return 0, false, err
 	}
@@ -533,14 +560,50 @@ func savePipeline(
 		return 0, false, err
 	}
 
+	err = saveConfigVersion(tx, pipelineID, config, setBy, setVia, buildID)
+	if err != nil {
+		return 0, false, err
+	}
+
 	return pipelineID, !existingConfig, nil
 }
 
+// policyWarningsValue stores no warnings as NULL, as pipelines which were
+// never checked have none.
+func policyWarningsValue(warnings []string) interface{} {
+	if len(warnings) == 0 {
+		return nil
+	}
+
+	return pq.Array(warnings)
+}
+
+// SavePipelineOptions are details recorded along with a pipeline config
+// saved by a user.
+type SavePipelineOptions struct {
+	// SetBy is the name of the user saving the config.
+	SetBy string
+
+	// PolicyWarnings are the warnings of the policy check of the config,
+	// replacing any from previous configs.
+	PolicyWarnings []string
+}
+
 func (t *team) SavePipeline(
 	pipelineRef atc.PipelineRef,
 	config atc.Config,
 	from ConfigVersion,
 	initiallyPaused bool,
+) (Pipeline, bool, error) {
+	return t.SavePipelineWithOptions(pipelineRef, config, from, initiallyPaused, SavePipelineOptions{})
+}
+
+func (t *team) SavePipelineWithOptions(
+	pipelineRef atc.PipelineRef,
+	config atc.Config,
+	from ConfigVersion,
+	initiallyPaused bool,
+	opts SavePipelineOptions,
 ) (Pipeline, bool, error) {
 	tx, err := t.conn.Begin()
 	if err != nil {
@@ -550,7 +613,7 @@ func (t *team) SavePipeline(
 	defer Rollback(tx)
 
 	nullID := sql.NullInt64{Valid: false}
-	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID)
+	pipelineID, isNewPipeline, err := savePipeline(tx, pipelineRef, config, from, initiallyPaused, t.id, nullID, nullID, opts.SetBy, PipelineConfigSetViaFly, opts.PolicyWarnings)
 	if err != nil {
 		return nil, false, err
 	}
@@ -829,7 +892,7 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 		UPDATE teams
 		SET auth = $1, legacy_auth = NULL, nonce = NULL
 		WHERE id = $2
-		RETURNING id, name, admin, auth, nonce
+		RETURNING id, name, admin, auth, max_in_flight, weight, nonce
 	`
 	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, t.id)
 	if err != nil {
@@ -839,6 +902,34 @@ func (t *team) UpdateProviderAuth(auth atc.TeamAuth) error {
 	return tx.Commit()
 }
 
+// Update sets the team's auth and fair share at once, so that a team is
+// never left with only some of its settings updated.
+func (t *team) Update(atcTeam atc.Team) error {
+	tx, err := t.conn.Begin()
+	if err != nil {
+		return err
+	}
+	defer Rollback(tx)
+
+	jsonEncodedProviderAuth, err := json.Marshal(atcTeam.Auth)
+	if err != nil {
+		return err
+	}
+
+	query := `
+		UPDATE teams
+		SET auth = $1, legacy_auth = NULL, nonce = NULL, max_in_flight = $2, weight = $3
+		WHERE id = $4
+		RETURNING id, name, admin, auth, max_in_flight, weight, nonce
+	`
+	err = t.queryTeam(tx, query, jsonEncodedProviderAuth, atcTeam.MaxInFlight, atcTeam.Weight, t.id)
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
 func (t *team) FindCheckContainers(logger lager.Logger, pipelineRef atc.PipelineRef, resourceName string, secretManager creds.Secrets, varSourcePool creds.VarSourcePool) ([]Container, map[int]time.Time, error) {
 	pipeline, found, err := t.Pipeline(pipelineRef)
 	if err != nil {
@@ -1114,9 +1205,9 @@ func saveJob(tx Tx, job atc.JobConfig, pipelineID int, groups []string) (int, er
 
 	var jobID int
 	err = psql.Insert("jobs").
-		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
-		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
-		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
+		Columns("name", "pipeline_id", "config", "public", "max_in_flight", "priority", "disable_manual_trigger", "interruptible", "active", "nonce", "tags").
+		Values(job.Name, pipelineID, encryptedPayload, job.Public, job.MaxInFlight(), job.Priority, job.DisableManualTrigger, job.Interruptible, true, nonce, pq.Array(groups)).
+		Suffix("ON CONFLICT (name, pipeline_id) DO UPDATE SET config = EXCLUDED.config, public = EXCLUDED.public, max_in_flight = EXCLUDED.max_in_flight, priority = EXCLUDED.priority, disable_manual_trigger = EXCLUDED.disable_manual_trigger, interruptible = EXCLUDED.interruptible, active = EXCLUDED.active, nonce = EXCLUDED.nonce, tags = EXCLUDED.tags").
 		Suffix("RETURNING id").
 		RunWith(tx).
 		QueryRow().
@@ -1281,7 +1372,7 @@ func scanPipeline(p *pipeline, scan scannable) error {
 		parentBuildID sql.NullInt64
 		instanceVars  sql.NullString
 	)
-	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars)
+	err := scan.Scan(&p.id, &p.name, &groups, &varSources, &display, &nonce, &p.configVersion, &p.teamID, &p.teamName, &p.paused, &p.public, &p.archived, &lastUpdated, &parentJobID, &parentBuildID, &instanceVars, pq.Array(&p.warnings))
 	if err != nil {
 		return err
 	}
@@ -1392,6 +1483,8 @@ func (t *team) queryTeam(tx Tx, query string, params ...interface{}) error {
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.maxInFlight,
+		&t.weight,
 		&nonce,
 	)
 	if err != nil {
@@ -1510,7 +1603,7 @@ func saveJobsAndSerialGroups(tx Tx, jobs atc.JobConfigs, groups atc.GroupConfigs
 	return jobNameToID, nil
 }
 
-func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
+func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, templates atc.StepTemplateConfigs, resourceNameToID map[string]int, jobNameToID map[string]int, pipelineID int) error {
 	_, err := psql.Delete("job_inputs").
 		Where(sq.Expr(`job_id in (
         SELECT j.id
@@ -1535,8 +1628,32 @@ func insertJobPipes(tx Tx, jobConfigs atc.JobConfigs, resourceNameToID map[strin
 		return err
 	}
 
+	_, err = psql.Delete("job_dependencies").
+		Where(sq.Expr(`job_id in (
+        SELECT j.id
+        FROM jobs j
+        WHERE j.pipeline_id = $1
+      )`, pipelineID)).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
 	for _, jobConfig := range jobConfigs {
+		for _, dependency := range jobConfig.DependsOn {
+			_, err := psql.Insert("job_dependencies").
+				Columns("job_id", "depends_on_job_id").
+				Values(jobNameToID[jobConfig.Name], jobNameToID[dependency]).
+				RunWith(tx).
+				Exec()
+			if err != nil {
+				return err
+			}
+		}
+
 		err := jobConfig.StepConfig().Visit(atc.StepRecursor{
+			Templates: templates,
 			OnGet: func(step *atc.GetStep) error {
 				return insertJobInput(tx, step, jobConfig.Name, resourceNameToID, jobNameToID)
 			},
@@ -53,9 +53,9 @@ func (factory *teamFactory) createTeam(t atc.Team, admin bool) (Team, error) {
 	}
 
 	row := psql.Insert("teams").
-		Columns("name, auth, admin").
-		Values(t.Name, auth, admin).
-		Suffix("RETURNING id, name, admin, auth").
+		Columns("name, auth, admin, max_in_flight, weight").
+		Values(t.Name, auth, admin, t.MaxInFlight, t.Weight).
+		Suffix("RETURNING id, name, admin, auth, max_in_flight, weight").
 		RunWith(tx).
 		QueryRow()
 
@@ -91,7 +91,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 		lockFactory: factory.lockFactory,
 	}
 
-	row := psql.Select("id, name, admin, auth").
+	row := psql.Select("id, name, admin, auth, max_in_flight, weight").
 		From("teams").
 		Where(sq.Eq{"LOWER(name)": strings.ToLower(teamName)}).
 		RunWith(factory.conn).
@@ -110,7 +110,7 @@ func (factory *teamFactory) FindTeam(teamName string) (Team, bool, error) {
 }
 
 func (factory *teamFactory) GetTeams() ([]Team, error) {
-	rows, err := psql.Select("id, name, admin, auth").
+	rows, err := psql.Select("id, name, admin, auth, max_in_flight, weight").
 		From("teams").
 		OrderBy("name ASC").
 		RunWith(factory.conn).
@@ -183,6 +183,8 @@ func (factory *teamFactory) scanTeam(t *team, rows scannable) error {
 		&t.name,
 		&t.admin,
 		&providerAuth,
+		&t.maxInFlight,
+		&t.weight,
 	)
 
 	if providerAuth.Valid {
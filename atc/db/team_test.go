@@ -915,6 +915,28 @@ var _ = Describe("Team", func() {
 				})
 			})
 		})
+
+		Describe("Update", func() {
+			It("saves the auth and fair share of the team", func() {
+				err := team.Update(atc.Team{
+					Auth:        authProvider,
+					MaxInFlight: 5,
+					Weight:      2,
+				})
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(team.Auth()).To(Equal(authProvider))
+				Expect(team.MaxInFlight()).To(Equal(5))
+				Expect(team.Weight()).To(Equal(2))
+
+				reloadedTeam, found, err := teamFactory.FindTeam(team.Name())
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(reloadedTeam.Auth()).To(Equal(authProvider))
+				Expect(reloadedTeam.MaxInFlight()).To(Equal(5))
+				Expect(reloadedTeam.Weight()).To(Equal(2))
+			})
+		})
 	})
 
 	Describe("Pipelines", func() {
@@ -1824,6 +1846,80 @@ var _ = Describe("Team", func() {
 			Expect(pipeline.Archived()).To(BeFalse())
 		})
 
+		Context("with templates", func() {
+			BeforeEach(func() {
+				config.Templates = atc.StepTemplateConfigs{
+					{
+						Name:   "get-some-resource",
+						Params: []atc.StepTemplateParam{{Name: "name"}},
+						Step: atc.Step{
+							Config: &atc.GetStep{
+								Name:     "((.:name))",
+								Resource: "some-resource",
+								Trigger:  true,
+							},
+						},
+					},
+				}
+
+				config.Jobs[0].PlanSequence = []atc.Step{
+					{
+						Config: &atc.UseStep{
+							Name: "get-some-resource",
+							Args: map[string]interface{}{"name": "some-input"},
+						},
+					},
+				}
+			})
+
+			It("returns the templates and the steps using them unexpanded", func() {
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				savedConfig, err := pipeline.Config()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(savedConfig.Templates).To(Equal(config.Templates))
+
+				job, found := savedConfig.Jobs.Lookup("some-other-job")
+				Expect(found).To(BeTrue())
+				Expect(job.PlanSequence).To(Equal(config.Jobs[0].PlanSequence))
+			})
+
+			It("saves the inputs of the templates used by each job", func() {
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				job, found, err := pipeline.Job("some-other-job")
+				Expect(err).ToNot(HaveOccurred())
+				Expect(found).To(BeTrue())
+
+				inputs, err := job.Inputs()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(inputs).To(Equal([]atc.JobInput{
+					{
+						Name:     "some-input",
+						Resource: "some-resource",
+						Trigger:  true,
+					},
+				}))
+			})
+
+			It("removes templates which are no longer configured", func() {
+				pipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
+				Expect(err).ToNot(HaveOccurred())
+
+				config.Templates = nil
+				config.Jobs[0].PlanSequence = nil
+
+				pipeline, _, err = team.SavePipeline(pipelineRef, config, pipeline.ConfigVersion(), false)
+				Expect(err).ToNot(HaveOccurred())
+
+				templates, err := pipeline.Templates()
+				Expect(err).ToNot(HaveOccurred())
+				Expect(templates).To(BeEmpty())
+			})
+		})
+
 		It("requests schedule on the pipeline", func() {
 			requestedPipeline, _, err := team.SavePipeline(pipelineRef, config, 0, false)
 			Expect(err).ToNot(HaveOccurred())
@@ -53,6 +53,7 @@ const (
 	VolumeTypeResourceCerts VolumeType = "resource-certs"
 	VolumeTypeTaskCache     VolumeType = "task-cache"
 	VolumeTypeArtifact      VolumeType = "artifact"
+	VolumeTypeKeyedCache    VolumeType = "keyed-cache"
 	VolumeTypeUknown        VolumeType = "unknown" // for migration to life
 )
 
@@ -155,6 +156,7 @@ type CreatedVolume interface {
 	GetResourceCacheID() int
 	InitializeArtifact(name string, buildID int) (WorkerArtifact, error)
 	InitializeTaskCache(jobID int, stepName string, path string) error
+	InitializeKeyedCache(key string) error
 
 	ContainerHandle() string
 	ParentHandle() string
@@ -519,6 +521,52 @@ func (volume *createdVolume) InitializeTaskCache(jobID int, stepName string, pat
 	return nil
 }
 
+// InitializeKeyedCache stores the volume as the team's cache for the given
+// key, releasing any volume previously stored under it for gc.
+func (volume *createdVolume) InitializeKeyedCache(key string) error {
+	tx, err := volume.conn.Begin()
+	if err != nil {
+		return err
+	}
+
+	defer Rollback(tx)
+
+	cache := keyedCache{
+		teamID: volume.teamID,
+		key:    key,
+	}
+
+	cacheID, err := cache.findOrCreate(tx)
+	if err != nil {
+		return err
+	}
+
+	err = cache.release(tx, cacheID, volume.id)
+	if err != nil {
+		return err
+	}
+
+	rows, err := psql.Update("volumes").
+		Set("keyed_cache_id", cacheID).
+		Where(sq.Eq{"id": volume.id}).
+		RunWith(tx).
+		Exec()
+	if err != nil {
+		return err
+	}
+
+	affected, err := rows.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return ErrVolumeMissing
+	}
+
+	return tx.Commit()
+}
+
 func (volume *createdVolume) CreateChildForContainer(container CreatingContainer, mountPath string) (CreatingVolume, error) {
 	tx, err := volume.conn.Begin()
 	if err != nil {
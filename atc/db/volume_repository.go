@@ -482,6 +482,7 @@ func (repository *volumeRepository) GetOrphanedVolumes() ([]CreatedVolume, error
 				"v.worker_task_cache_id":         nil,
 				"v.worker_resource_certs_id":     nil,
 				"v.worker_artifact_id":           nil,
+				"v.keyed_cache_id":               nil,
 			},
 		).
 		Where(sq.Eq{"v.state": string(VolumeStateCreated)}).
@@ -734,6 +735,7 @@ var volumeColumns = []string{
 	when v.worker_resource_cache_id is not NULL then 'resource'
 	when v.container_id is not NULL then 'container'
 	when v.worker_task_cache_id is not NULL then 'task-cache'
+	when v.keyed_cache_id is not NULL then 'keyed-cache'
 	when v.worker_resource_certs_id is not NULL then 'resource-certs'
 	when v.worker_artifact_id is not NULL then 'artifact'
 	else 'unknown'
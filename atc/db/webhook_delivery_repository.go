@@ -0,0 +1,171 @@
+package db
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+)
+
+// PendingWebhookDelivery is a delivery which is due to be attempted, along
+// with the webhook it is to be sent to.
+type PendingWebhookDelivery struct {
+	WebhookDelivery
+
+	Webhook Webhook
+}
+
+//go:generate counterfeiter . WebhookDeliveryRepository
+
+type WebhookDeliveryRepository interface {
+	PendingDeliveries(limit int) ([]PendingWebhookDelivery, error)
+	MarkDelivered(id int64, responseStatus int) error
+
+	// MarkFailed records a failed attempt. The delivery is retried at retryAt,
+	// or given up on if retryAt is zero.
+	MarkFailed(id int64, responseStatus int, message string, retryAt time.Time) error
+}
+
+type webhookDeliveryRepository struct {
+	conn Conn
+}
+
+func NewWebhookDeliveryRepository(conn Conn) WebhookDeliveryRepository {
+	return &webhookDeliveryRepository{
+		conn: conn,
+	}
+}
+
+func (r *webhookDeliveryRepository) PendingDeliveries(limit int) ([]PendingWebhookDelivery, error) {
+	rows, err := psql.Select(
+		"d.id",
+		"d.build_id",
+		"d.status",
+		"d.attempts",
+		"d.created_at",
+		"w.id",
+		"w.team_id",
+		"w.name",
+		"w.url",
+		"w.secret",
+		"w.nonce",
+		"COALESCE(w.pipeline_name, '')",
+		"w.events",
+		"w.created_at",
+	).
+		From("webhook_deliveries d").
+		Join("webhooks w ON w.id = d.webhook_id").
+		Where(sq.Eq{"d.state": atc.WebhookDeliveryPending}).
+		Where(sq.Expr("d.next_attempt_at <= now()")).
+		OrderBy("d.id ASC").
+		Limit(uint64(limit)).
+		RunWith(r.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	var deliveries []PendingWebhookDelivery
+	for rows.Next() {
+		var delivery PendingWebhookDelivery
+		var deliveryID int64
+		var buildID, attempts int
+		var status string
+		var createdAt time.Time
+
+		webhook, err := scanWebhook(scannerFunc(func(dest ...interface{}) error {
+			return rows.Scan(append([]interface{}{&deliveryID, &buildID, &status, &attempts, &createdAt}, dest...)...)
+		}), r.conn.EncryptionStrategy())
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.ID = deliveryID
+		delivery.WebhookID = webhook.ID
+		delivery.BuildID = buildID
+		delivery.Status = BuildStatus(status)
+		delivery.State = atc.WebhookDeliveryPending
+		delivery.Attempts = attempts
+		delivery.CreatedAt = createdAt
+		delivery.Webhook = webhook
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (r *webhookDeliveryRepository) MarkDelivered(id int64, responseStatus int) error {
+	_, err := psql.Update("webhook_deliveries").
+		Set("state", atc.WebhookDeliveryDelivered).
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", responseStatus).
+		Set("error", nil).
+		Set("delivered_at", sq.Expr("now()")).
+		Where(sq.Eq{"id": id}).
+		RunWith(r.conn).
+		Exec()
+	return err
+}
+
+func (r *webhookDeliveryRepository) MarkFailed(id int64, responseStatus int, message string, retryAt time.Time) error {
+	update := psql.Update("webhook_deliveries").
+		Set("attempts", sq.Expr("attempts + 1")).
+		Set("response_status", sql.NullInt64{Int64: int64(responseStatus), Valid: responseStatus != 0}).
+		Set("error", message).
+		Where(sq.Eq{"id": id})
+
+	if retryAt.IsZero() {
+		update = update.Set("state", atc.WebhookDeliveryFailed)
+	} else {
+		update = update.Set("next_attempt_at", retryAt)
+	}
+
+	_, err := update.RunWith(r.conn).Exec()
+	return err
+}
+
+// queueWebhookDeliveries records a delivery for every webhook subscribed to
+// the build's new status. It is called in the same transaction as the status
+// change, so that a delivery is queued if and only if the change is committed.
+func queueWebhookDeliveries(tx Tx, b *build, status BuildStatus) error {
+	// check builds are not interesting to subscribe to
+	if b.resourceID != 0 || b.resourceTypeID != 0 {
+		return nil
+	}
+
+	result, err := tx.Exec(`
+		INSERT INTO webhook_deliveries (webhook_id, build_id, status)
+		SELECT w.id, $1, $2
+		FROM webhooks w
+		WHERE w.team_id = $3
+		AND (w.pipeline_name IS NULL OR w.pipeline_name = $4)
+		AND (cardinality(w.events) = 0 OR $2 = ANY(w.events))
+	`, b.id, string(status), b.teamID, sql.NullString{String: b.pipelineName, Valid: b.pipelineName != ""})
+	if err != nil {
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if affected == 0 {
+		return nil
+	}
+
+	// notifications are only delivered once the transaction commits
+	_, err = tx.Exec(fmt.Sprintf("NOTIFY %s", atc.ComponentWebhookDispatcher))
+	return err
+}
+
+type scannerFunc func(...interface{}) error
+
+func (f scannerFunc) Scan(dest ...interface{}) error {
+	return f(dest...)
+}
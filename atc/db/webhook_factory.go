@@ -0,0 +1,282 @@
+package db
+
+import (
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db/encryption"
+	"github.com/lib/pq"
+)
+
+type Webhook struct {
+	ID           int
+	TeamID       int
+	Name         string
+	URL          string
+	Secret       string
+	PipelineName string
+	Events       []BuildStatus
+	CreatedAt    time.Time
+}
+
+type WebhookDelivery struct {
+	ID             int64
+	WebhookID      int
+	BuildID        int
+	Status         BuildStatus
+	State          atc.WebhookDeliveryState
+	Attempts       int
+	ResponseStatus int
+	Error          string
+	CreatedAt      time.Time
+	NextAttemptAt  time.Time
+	DeliveredAt    time.Time
+}
+
+//go:generate counterfeiter . WebhookFactory
+
+type WebhookFactory interface {
+	Webhooks(teamID int) ([]Webhook, error)
+	Webhook(teamID int, name string) (Webhook, bool, error)
+	SaveWebhook(Webhook) (bool, error)
+	DestroyWebhook(teamID int, name string) (bool, error)
+	WebhookDeliveries(webhookID int, limit int) ([]WebhookDelivery, error)
+}
+
+type webhookFactory struct {
+	conn Conn
+}
+
+func NewWebhookFactory(conn Conn) WebhookFactory {
+	return &webhookFactory{
+		conn: conn,
+	}
+}
+
+var webhooksQuery = psql.Select(
+	"w.id",
+	"w.team_id",
+	"w.name",
+	"w.url",
+	"w.secret",
+	"w.nonce",
+	"COALESCE(w.pipeline_name, '')",
+	"w.events",
+	"w.created_at",
+).From("webhooks w")
+
+func (f *webhookFactory) Webhooks(teamID int) ([]Webhook, error) {
+	rows, err := webhooksQuery.
+		Where(sq.Eq{"w.team_id": teamID}).
+		OrderBy("w.name ASC").
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	webhooks := []Webhook{}
+	for rows.Next() {
+		webhook, err := f.scanWebhook(rows)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks = append(webhooks, webhook)
+	}
+
+	return webhooks, nil
+}
+
+func (f *webhookFactory) Webhook(teamID int, name string) (Webhook, bool, error) {
+	webhook, err := f.scanWebhook(webhooksQuery.
+		Where(sq.Eq{
+			"w.team_id": teamID,
+			"w.name":    name,
+		}).
+		RunWith(f.conn).
+		QueryRow())
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return Webhook{}, false, nil
+		}
+
+		return Webhook{}, false, err
+	}
+
+	return webhook, true, nil
+}
+
+func (f *webhookFactory) SaveWebhook(webhook Webhook) (bool, error) {
+	var secret, nonce sql.NullString
+	if webhook.Secret != "" {
+		encryptedSecret, encryptionNonce, err := f.conn.EncryptionStrategy().Encrypt([]byte(webhook.Secret))
+		if err != nil {
+			return false, err
+		}
+
+		secret = sql.NullString{String: encryptedSecret, Valid: true}
+		if encryptionNonce != nil {
+			nonce = sql.NullString{String: *encryptionNonce, Valid: true}
+		}
+	}
+
+	events := make([]string, len(webhook.Events))
+	for i, event := range webhook.Events {
+		events[i] = string(event)
+	}
+
+	var created bool
+	err := psql.Insert("webhooks").
+		SetMap(map[string]interface{}{
+			"team_id":       webhook.TeamID,
+			"name":          webhook.Name,
+			"url":           webhook.URL,
+			"secret":        secret,
+			"nonce":         nonce,
+			"pipeline_name": sql.NullString{String: webhook.PipelineName, Valid: webhook.PipelineName != ""},
+			"events":        pq.Array(events),
+		}).
+		Suffix(`
+			ON CONFLICT (team_id, name) DO UPDATE SET
+				url = EXCLUDED.url,
+				secret = EXCLUDED.secret,
+				nonce = EXCLUDED.nonce,
+				pipeline_name = EXCLUDED.pipeline_name,
+				events = EXCLUDED.events
+			RETURNING xmax = 0
+		`).
+		RunWith(f.conn).
+		QueryRow().
+		Scan(&created)
+	if err != nil {
+		return false, err
+	}
+
+	return created, nil
+}
+
+func (f *webhookFactory) DestroyWebhook(teamID int, name string) (bool, error) {
+	result, err := psql.Delete("webhooks").
+		Where(sq.Eq{
+			"team_id": teamID,
+			"name":    name,
+		}).
+		RunWith(f.conn).
+		Exec()
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (f *webhookFactory) WebhookDeliveries(webhookID int, limit int) ([]WebhookDelivery, error) {
+	rows, err := psql.Select(
+		"d.id",
+		"d.webhook_id",
+		"d.build_id",
+		"d.status",
+		"d.state",
+		"d.attempts",
+		"COALESCE(d.response_status, 0)",
+		"COALESCE(d.error, '')",
+		"d.created_at",
+		"d.next_attempt_at",
+		"d.delivered_at",
+	).
+		From("webhook_deliveries d").
+		Where(sq.Eq{"d.webhook_id": webhookID}).
+		OrderBy("d.id DESC").
+		Limit(uint64(limit)).
+		RunWith(f.conn).
+		Query()
+	if err != nil {
+		return nil, err
+	}
+
+	defer Close(rows)
+
+	deliveries := []WebhookDelivery{}
+	for rows.Next() {
+		var delivery WebhookDelivery
+		var deliveredAt pq.NullTime
+
+		err = rows.Scan(
+			&delivery.ID,
+			&delivery.WebhookID,
+			&delivery.BuildID,
+			&delivery.Status,
+			&delivery.State,
+			&delivery.Attempts,
+			&delivery.ResponseStatus,
+			&delivery.Error,
+			&delivery.CreatedAt,
+			&delivery.NextAttemptAt,
+			&deliveredAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		delivery.DeliveredAt = deliveredAt.Time
+
+		deliveries = append(deliveries, delivery)
+	}
+
+	return deliveries, nil
+}
+
+func (f *webhookFactory) scanWebhook(row scannable) (Webhook, error) {
+	return scanWebhook(row, f.conn.EncryptionStrategy())
+}
+
+func scanWebhook(row scannable, es encryption.Strategy) (Webhook, error) {
+	var webhook Webhook
+	var secret, nonce sql.NullString
+	var events []string
+
+	err := row.Scan(
+		&webhook.ID,
+		&webhook.TeamID,
+		&webhook.Name,
+		&webhook.URL,
+		&secret,
+		&nonce,
+		&webhook.PipelineName,
+		pq.Array(&events),
+		&webhook.CreatedAt,
+	)
+	if err != nil {
+		return Webhook{}, err
+	}
+
+	if secret.Valid {
+		var noncense *string
+		if nonce.Valid {
+			noncense = &nonce.String
+		}
+
+		decrypted, err := es.Decrypt(secret.String, noncense)
+		if err != nil {
+			return Webhook{}, err
+		}
+
+		webhook.Secret = string(decrypted)
+	}
+
+	for _, event := range events {
+		webhook.Events = append(webhook.Events, BuildStatus(event))
+	}
+
+	return webhook, nil
+}
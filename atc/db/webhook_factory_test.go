@@ -0,0 +1,287 @@
+package db_test
+
+import (
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("WebhookFactory", func() {
+	var factory db.WebhookFactory
+
+	BeforeEach(func() {
+		factory = db.NewWebhookFactory(dbConn)
+	})
+
+	Describe("SaveWebhook", func() {
+		It("creates the webhook, then updates it", func() {
+			created, err := factory.SaveWebhook(db.Webhook{
+				TeamID: defaultTeam.ID(),
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+				Secret: "some-secret",
+				Events: []db.BuildStatus{db.BuildStatusFailed},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeTrue())
+
+			webhook, found, err := factory.Webhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(webhook.URL).To(Equal("https://example.com/hook"))
+			Expect(webhook.Secret).To(Equal("some-secret"))
+			Expect(webhook.PipelineName).To(BeEmpty())
+			Expect(webhook.Events).To(Equal([]db.BuildStatus{db.BuildStatusFailed}))
+			Expect(webhook.CreatedAt).ToNot(BeZero())
+
+			created, err = factory.SaveWebhook(db.Webhook{
+				TeamID:       defaultTeam.ID(),
+				Name:         "some-webhook",
+				URL:          "https://example.com/other-hook",
+				PipelineName: "some-pipeline",
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(created).To(BeFalse())
+
+			webhook, found, err = factory.Webhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+			Expect(webhook.URL).To(Equal("https://example.com/other-hook"))
+			Expect(webhook.Secret).To(BeEmpty())
+			Expect(webhook.PipelineName).To(Equal("some-pipeline"))
+			Expect(webhook.Events).To(BeEmpty())
+		})
+	})
+
+	Describe("Webhooks", func() {
+		BeforeEach(func() {
+			for _, name := range []string{"b-webhook", "a-webhook"} {
+				_, err := factory.SaveWebhook(db.Webhook{
+					TeamID: defaultTeam.ID(),
+					Name:   name,
+					URL:    "https://example.com/hook",
+				})
+				Expect(err).ToNot(HaveOccurred())
+			}
+		})
+
+		It("returns the team's webhooks by name", func() {
+			webhooks, err := factory.Webhooks(defaultTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(HaveLen(2))
+			Expect(webhooks[0].Name).To(Equal("a-webhook"))
+			Expect(webhooks[1].Name).To(Equal("b-webhook"))
+		})
+
+		It("does not return other teams' webhooks", func() {
+			otherTeam, err := teamFactory.CreateTeam(atc.Team{Name: "other-team"})
+			Expect(err).ToNot(HaveOccurred())
+
+			webhooks, err := factory.Webhooks(otherTeam.ID())
+			Expect(err).ToNot(HaveOccurred())
+			Expect(webhooks).To(BeEmpty())
+		})
+	})
+
+	Describe("DestroyWebhook", func() {
+		BeforeEach(func() {
+			_, err := factory.SaveWebhook(db.Webhook{
+				TeamID: defaultTeam.ID(),
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+			})
+			Expect(err).ToNot(HaveOccurred())
+		})
+
+		It("removes the webhook", func() {
+			destroyed, err := factory.DestroyWebhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(destroyed).To(BeTrue())
+
+			_, found, err := factory.Webhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeFalse())
+
+			destroyed, err = factory.DestroyWebhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(destroyed).To(BeFalse())
+		})
+	})
+
+	Describe("deliveries", func() {
+		var (
+			repository db.WebhookDeliveryRepository
+			webhook    db.Webhook
+		)
+
+		BeforeEach(func() {
+			repository = db.NewWebhookDeliveryRepository(dbConn)
+
+			_, err := factory.SaveWebhook(db.Webhook{
+				TeamID:       defaultTeam.ID(),
+				Name:         "some-webhook",
+				URL:          "https://example.com/hook",
+				Secret:       "some-secret",
+				PipelineName: defaultPipeline.Name(),
+				Events:       []db.BuildStatus{db.BuildStatusPending, db.BuildStatusFailed},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			_, err = factory.SaveWebhook(db.Webhook{
+				TeamID:       defaultTeam.ID(),
+				Name:         "other-pipeline-webhook",
+				URL:          "https://example.com/hook",
+				PipelineName: "other-pipeline",
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			var found bool
+			webhook, found, err = factory.Webhook(defaultTeam.ID(), "some-webhook")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(found).To(BeTrue())
+		})
+
+		It("queues a delivery for each subscribed status change", func() {
+			build, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = build.Finish(db.BuildStatusSucceeded)
+			Expect(err).ToNot(HaveOccurred())
+
+			otherBuild, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			err = otherBuild.Finish(db.BuildStatusFailed)
+			Expect(err).ToNot(HaveOccurred())
+
+			deliveries, err := factory.WebhookDeliveries(webhook.ID, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(3))
+
+			Expect(deliveries[0].BuildID).To(Equal(otherBuild.ID()))
+			Expect(deliveries[0].Status).To(Equal(db.BuildStatusFailed))
+			Expect(deliveries[0].State).To(Equal(atc.WebhookDeliveryPending))
+			Expect(deliveries[1].BuildID).To(Equal(otherBuild.ID()))
+			Expect(deliveries[1].Status).To(Equal(db.BuildStatusPending))
+			Expect(deliveries[2].BuildID).To(Equal(build.ID()))
+			Expect(deliveries[2].Status).To(Equal(db.BuildStatusPending))
+
+			otherWebhook, _, err := factory.Webhook(defaultTeam.ID(), "other-pipeline-webhook")
+			Expect(err).ToNot(HaveOccurred())
+
+			deliveries, err = factory.WebhookDeliveries(otherWebhook.ID, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(BeEmpty())
+		})
+
+		It("only queues a started delivery when the build first starts", func() {
+			_, err := factory.SaveWebhook(db.Webhook{
+				TeamID: defaultTeam.ID(),
+				Name:   "started-webhook",
+				URL:    "https://example.com/hook",
+				Events: []db.BuildStatus{db.BuildStatusStarted},
+			})
+			Expect(err).ToNot(HaveOccurred())
+
+			startedWebhook, _, err := factory.Webhook(defaultTeam.ID(), "started-webhook")
+			Expect(err).ToNot(HaveOccurred())
+
+			build, err := defaultJob.CreateBuild()
+			Expect(err).ToNot(HaveOccurred())
+
+			started, err := build.Start(atc.Plan{
+				ID:       "some-plan-id",
+				Approval: &atc.ApprovalPlan{Name: "deploy"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(started).To(BeTrue())
+
+			err = build.RequestApproval("some-plan-id", "deploy")
+			Expect(err).ToNot(HaveOccurred())
+
+			decided, err := build.DecideApproval(true, "some-user")
+			Expect(err).ToNot(HaveOccurred())
+			Expect(decided).To(BeTrue())
+
+			deliveries, err := factory.WebhookDeliveries(startedWebhook.ID, 10)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(deliveries).To(HaveLen(1))
+			Expect(deliveries[0].Status).To(Equal(db.BuildStatusStarted))
+		})
+
+		Describe("WebhookDeliveryRepository", func() {
+			var build db.Build
+
+			BeforeEach(func() {
+				var err error
+				build, err = defaultJob.CreateBuild()
+				Expect(err).ToNot(HaveOccurred())
+			})
+
+			It("returns pending deliveries along with their webhook", func() {
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(HaveLen(1))
+				Expect(pending[0].BuildID).To(Equal(build.ID()))
+				Expect(pending[0].Status).To(Equal(db.BuildStatusPending))
+				Expect(pending[0].Webhook.Name).To(Equal("some-webhook"))
+				Expect(pending[0].Webhook.Secret).To(Equal("some-secret"))
+			})
+
+			It("records successful deliveries", func() {
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = repository.MarkDelivered(pending[0].ID, 204)
+				Expect(err).ToNot(HaveOccurred())
+
+				pending, err = repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+
+				deliveries, err := factory.WebhookDeliveries(webhook.ID, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries[0].State).To(Equal(atc.WebhookDeliveryDelivered))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].ResponseStatus).To(Equal(204))
+				Expect(deliveries[0].DeliveredAt).ToNot(BeZero())
+			})
+
+			It("defers failed deliveries until they are to be retried", func() {
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = repository.MarkFailed(pending[0].ID, 500, "oh no", time.Now().Add(time.Hour))
+				Expect(err).ToNot(HaveOccurred())
+
+				pending, err = repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(pending).To(BeEmpty())
+
+				deliveries, err := factory.WebhookDeliveries(webhook.ID, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries[0].State).To(Equal(atc.WebhookDeliveryPending))
+				Expect(deliveries[0].Attempts).To(Equal(1))
+				Expect(deliveries[0].ResponseStatus).To(Equal(500))
+				Expect(deliveries[0].Error).To(Equal("oh no"))
+			})
+
+			It("gives up on deliveries with no retry time", func() {
+				pending, err := repository.PendingDeliveries(10)
+				Expect(err).ToNot(HaveOccurred())
+
+				err = repository.MarkFailed(pending[0].ID, 0, "connection refused", time.Time{})
+				Expect(err).ToNot(HaveOccurred())
+
+				deliveries, err := factory.WebhookDeliveries(webhook.ID, 10)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(deliveries[0].State).To(Equal(atc.WebhookDeliveryFailed))
+				Expect(deliveries[0].ResponseStatus).To(BeZero())
+			})
+		})
+	})
+})
@@ -61,9 +61,11 @@ type Worker interface {
 	NoProxy() string
 	ActiveContainers() int
 	ActiveVolumes() int
+	Resources() *atc.WorkerResources
 	ResourceTypes() []atc.WorkerResourceType
 	Platform() string
 	Tags() []string
+	Features() []string
 	TeamID() int
 	TeamName() string
 	StartTime() time.Time
@@ -99,9 +101,11 @@ type worker struct {
 	activeContainers int
 	activeVolumes    int
 	activeTasks      int
+	resources        *atc.WorkerResources
 	resourceTypes    []atc.WorkerResourceType
 	platform         string
 	tags             []string
+	features         []string
 	teamID           int
 	teamName         string
 	startTime        time.Time
@@ -122,9 +126,11 @@ func (worker *worker) HTTPSProxyURL() string                   { return worker.h
 func (worker *worker) NoProxy() string                         { return worker.noProxy }
 func (worker *worker) ActiveContainers() int                   { return worker.activeContainers }
 func (worker *worker) ActiveVolumes() int                      { return worker.activeVolumes }
+func (worker *worker) Resources() *atc.WorkerResources         { return worker.resources }
 func (worker *worker) ResourceTypes() []atc.WorkerResourceType { return worker.resourceTypes }
 func (worker *worker) Platform() string                        { return worker.platform }
 func (worker *worker) Tags() []string                          { return worker.tags }
+func (worker *worker) Features() []string                      { return worker.features }
 func (worker *worker) TeamID() int                             { return worker.teamID }
 func (worker *worker) TeamName() string                        { return worker.teamName }
 func (worker *worker) Ephemeral() bool                         { return worker.ephemeral }
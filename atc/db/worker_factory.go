@@ -47,9 +47,11 @@ var workersQuery = psql.Select(`
 		w.no_proxy,
 		w.active_containers,
 		w.active_volumes,
+		w.resources,
 		w.resource_types,
 		w.platform,
 		w.tags,
+		w.features,
 		t.name,
 		w.team_id,
 		w.start_time,
@@ -132,9 +134,11 @@ func scanWorker(worker *worker, row scannable) error {
 		httpProxyURL  sql.NullString
 		httpsProxyURL sql.NullString
 		noProxy       sql.NullString
+		resources     []byte
 		resourceTypes []byte
 		platform      sql.NullString
 		tags          []byte
+		features      []byte
 		teamName      sql.NullString
 		teamID        sql.NullInt64
 		startTime     pq.NullTime
@@ -154,9 +158,11 @@ func scanWorker(worker *worker, row scannable) error {
 		&noProxy,
 		&worker.activeContainers,
 		&worker.activeVolumes,
+		&resources,
 		&resourceTypes,
 		&platform,
 		&tags,
+		&features,
 		&teamName,
 		&teamID,
 		&startTime,
@@ -215,6 +221,20 @@ func scanWorker(worker *worker, row scannable) error {
 		worker.ephemeral = ephemeral.Bool
 	}
 
+	if resources != nil {
+		err = json.Unmarshal(resources, &worker.resources)
+		if err != nil {
+			return err
+		}
+	}
+
+	if features != nil {
+		err = json.Unmarshal(features, &worker.features)
+		if err != nil {
+			return err
+		}
+	}
+
 	err = json.Unmarshal(resourceTypes, &worker.resourceTypes)
 	if err != nil {
 		return err
@@ -230,6 +250,11 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 	// So we format time.Now() without any timezone information and then
 	// parse that using the same layout to strip the timezone information
 
+	resources, err := json.Marshal(atcWorker.Resources)
+	if err != nil {
+		return nil, err
+	}
+
 	tx, err := f.conn.Begin()
 	if err != nil {
 		return nil, err
@@ -256,6 +281,7 @@ func (f *workerFactory) HeartbeatWorker(atcWorker atc.Worker, ttl time.Duration)
 		Set("expires", sq.Expr(expires)).
 		Set("active_containers", atcWorker.ActiveContainers).
 		Set("active_volumes", atcWorker.ActiveVolumes).
+		Set("resources", resources).
 		Set("state", sq.Expr("("+cSQL+")")).
 		Where(sq.Eq{"name": atcWorker.Name}).
 		RunWith(tx).
@@ -375,6 +401,16 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		return nil, err
 	}
 
+	resources, err := json.Marshal(atcWorker.Resources)
+	if err != nil {
+		return nil, err
+	}
+
+	features, err := json.Marshal(atcWorker.Features)
+	if err != nil {
+		return nil, err
+	}
+
 	expires := "NULL"
 	if ttl != 0 {
 		expires = fmt.Sprintf(`NOW() + '%d second'::INTERVAL`, int(ttl.Seconds()))
@@ -398,8 +434,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		atcWorker.GardenAddr,
 		atcWorker.ActiveContainers,
 		atcWorker.ActiveVolumes,
+		resources,
 		resourceTypes,
 		tags,
+		features,
 		atcWorker.Platform,
 		atcWorker.BaggageclaimURL,
 		atcWorker.CertsPath,
@@ -429,8 +467,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 			"addr",
 			"active_containers",
 			"active_volumes",
+			"resources",
 			"resource_types",
 			"tags",
+			"features",
 			"platform",
 			"baggageclaim_url",
 			"certs_path",
@@ -454,8 +494,10 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 				addr = ?,
 				active_containers = ?,
 				active_volumes = ?,
+				resources = ?,
 				resource_types = ?,
 				tags = ?,
+				features = ?,
 				platform = ?,
 				baggageclaim_url = ?,
 				certs_path = ?,
@@ -502,9 +544,11 @@ func saveWorker(tx Tx, atcWorker atc.Worker, teamID *int, ttl time.Duration, con
 		noProxy:          atcWorker.NoProxy,
 		activeContainers: atcWorker.ActiveContainers,
 		activeVolumes:    atcWorker.ActiveVolumes,
+		resources:        atcWorker.Resources,
 		resourceTypes:    atcWorker.ResourceTypes,
 		platform:         atcWorker.Platform,
 		tags:             atcWorker.Tags,
+		features:         atcWorker.Features,
 		teamName:         atcWorker.Team,
 		teamID:           workerTeamID,
 		startTime:        time.Unix(atcWorker.StartTime, 0),
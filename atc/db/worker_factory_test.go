@@ -436,6 +436,24 @@ var _ = Describe("WorkerFactory", func() {
 				Expect(*foundWorker.BaggageclaimURL()).To(Equal("some-bc-url"))
 			})
 
+			It("updates the available resources", func() {
+				atcWorker.Resources = &atc.WorkerResources{
+					FreeMemory:    1024,
+					FreeDiskSpace: 2048,
+					CPULoad:       1.5,
+				}
+
+				foundWorker, err := workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.Resources()).To(Equal(atcWorker.Resources))
+
+				atcWorker.Resources = nil
+
+				foundWorker, err = workerFactory.HeartbeatWorker(atcWorker, ttl)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(foundWorker.Resources()).To(BeNil())
+			})
+
 			Context("when the current state is landing", func() {
 				BeforeEach(func() {
 					atcWorker.State = string(db.WorkerStateLanding)
@@ -79,10 +79,11 @@ func (workerTaskCache WorkerTaskCache) find(runner sq.Runner) (*UsedWorkerTaskCa
 	}, true, nil
 }
 
-func removeUnusedWorkerTaskCaches(tx Tx, pipelineID int, jobConfigs []atc.JobConfig) error {
+func removeUnusedWorkerTaskCaches(tx Tx, pipelineID int, jobConfigs []atc.JobConfig, templates atc.StepTemplateConfigs) error {
 	steps := make(map[string][]string)
 	for _, jobConfig := range jobConfigs {
 		_ = jobConfig.StepConfig().Visit(atc.StepRecursor{
+			Templates: templates,
 			OnTask: func(step *atc.TaskStep) error {
 				steps[jobConfig.Name] = append(steps[jobConfig.Name], step.Name)
 				return nil
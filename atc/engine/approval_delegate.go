@@ -0,0 +1,76 @@
+package engine
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/clock"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/exec"
+)
+
+func NewApprovalStepDelegate(
+	build db.Build,
+	planID atc.PlanID,
+	state exec.RunState,
+	clock clock.Clock,
+) *approvalStepDelegate {
+	return &approvalStepDelegate{
+		buildStepDelegate{
+			build:  build,
+			planID: planID,
+			clock:  clock,
+			state:  state,
+			stdout: nil,
+			stderr: nil,
+		},
+	}
+}
+
+type approvalStepDelegate struct {
+	buildStepDelegate
+}
+
+// WaitForApproval marks the build as waiting for approval and blocks until a
+// decision is made on the step or the context is canceled. Requesting
+// approval is idempotent, so a build resumed after a restart picks up where
+// it left off.
+func (delegate *approvalStepDelegate) WaitForApproval(ctx context.Context, logger lager.Logger, name string) (bool, string, error) {
+	err := delegate.build.RequestApproval(delegate.planID, name)
+	if err != nil {
+		logger.Error("failed-to-request-approval", err)
+		return false, "", err
+	}
+
+	notifier, err := delegate.build.ApprovalNotifier(delegate.planID)
+	if err != nil {
+		logger.Error("failed-to-listen-for-approval", err)
+		return false, "", err
+	}
+
+	defer notifier.Close()
+
+	for {
+		approval, decided, err := delegate.build.Approval(delegate.planID)
+		if err != nil {
+			logger.Error("failed-to-get-approval", err)
+			return false, "", err
+		}
+
+		if decided {
+			logger.Info("decided", lager.Data{
+				"approved":   approval.Approved,
+				"decided-by": approval.DecidedBy,
+			})
+
+			return approval.Approved, approval.DecidedBy, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return false, "", ctx.Err()
+		case <-notifier.Notify():
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package engine_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"code.cloudfoundry.org/clock/fakeclock"
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/vars"
+)
+
+var _ = Describe("ApprovalStepDelegate", func() {
+	var (
+		logger       *lagertest.TestLogger
+		fakeBuild    *dbfakes.FakeBuild
+		fakeClock    *fakeclock.FakeClock
+		fakeNotifier *dbfakes.FakeNotifier
+		notify       chan struct{}
+
+		state exec.RunState
+
+		now      = time.Date(1991, 6, 3, 5, 30, 0, 0, time.UTC)
+		delegate exec.ApprovalStepDelegate
+
+		ctx    context.Context
+		cancel context.CancelFunc
+
+		approved  bool
+		decidedBy string
+		waitErr   error
+	)
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("test")
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeClock = fakeclock.NewFakeClock(now)
+		state = exec.NewRunState(noopStepper, vars.StaticVariables{}, true)
+
+		notify = make(chan struct{}, 1)
+		fakeNotifier = new(dbfakes.FakeNotifier)
+		fakeNotifier.NotifyReturns(notify)
+		fakeBuild.ApprovalNotifierReturns(fakeNotifier, nil)
+		fakeBuild.ApprovalReturns(db.BuildApproval{Name: "deploy", Approved: true, DecidedBy: "some-user"}, true, nil)
+
+		ctx, cancel = context.WithCancel(context.Background())
+
+		delegate = engine.NewApprovalStepDelegate(fakeBuild, "some-plan-id", state, fakeClock)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		approved, decidedBy, waitErr = delegate.WaitForApproval(ctx, logger, "deploy")
+	})
+
+	It("requests approval for the plan", func() {
+		Expect(fakeBuild.RequestApprovalCallCount()).To(Equal(1))
+		planID, name := fakeBuild.RequestApprovalArgsForCall(0)
+		Expect(planID).To(Equal(atc.PlanID("some-plan-id")))
+		Expect(name).To(Equal("deploy"))
+	})
+
+	Context("when a decision has already been made", func() {
+		It("returns the decision without waiting", func() {
+			Expect(waitErr).ToNot(HaveOccurred())
+			Expect(approved).To(BeTrue())
+			Expect(decidedBy).To(Equal("some-user"))
+		})
+
+		It("closes the notifier", func() {
+			Expect(fakeNotifier.CloseCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when the decision is made while waiting", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturnsOnCall(0, db.BuildApproval{}, false, nil)
+			fakeBuild.ApprovalReturnsOnCall(1, db.BuildApproval{
+				Name:      "deploy",
+				Approved:  false,
+				DecidedBy: "some-user",
+			}, true, nil)
+
+			notify <- struct{}{}
+		})
+
+		It("returns the decision once notified", func() {
+			Expect(waitErr).ToNot(HaveOccurred())
+			Expect(approved).To(BeFalse())
+			Expect(decidedBy).To(Equal("some-user"))
+			Expect(fakeBuild.ApprovalCallCount()).To(Equal(2))
+		})
+	})
+
+	Context("when the context is canceled", func() {
+		BeforeEach(func() {
+			fakeBuild.ApprovalReturns(db.BuildApproval{}, false, nil)
+			cancel()
+		})
+
+		It("returns the context's error", func() {
+			Expect(waitErr).To(Equal(context.Canceled))
+		})
+	})
+
+	Context("when requesting approval fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeBuild.RequestApprovalReturns(disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(waitErr).To(Equal(disaster))
+			Expect(fakeBuild.ApprovalNotifierCallCount()).To(BeZero())
+		})
+	})
+})
@@ -10,11 +10,13 @@ import (
 
 	"code.cloudfoundry.org/clock"
 	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/policy"
 	"github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/tracing"
@@ -77,57 +79,42 @@ func (it *credVarsIterator) YieldCred(name, value string) {
 }
 
 func (delegate *buildStepDelegate) Stdout() io.Writer {
-	if delegate.stdout != nil {
-		return delegate.stdout
-	}
-	if delegate.state.RedactionEnabled() {
-		delegate.stdout = newDBEventWriterWithSecretRedaction(
-			delegate.build,
-			event.Origin{
-				Source: event.OriginSourceStdout,
-				ID:     event.OriginID(delegate.planID),
-			},
-			delegate.clock,
-			delegate.buildOutputFilter,
-		)
-	} else {
-		delegate.stdout = newDBEventWriter(
-			delegate.build,
-			event.Origin{
-				Source: event.OriginSourceStdout,
-				ID:     event.OriginID(delegate.planID),
-			},
-			delegate.clock,
-		)
+	if delegate.stdout == nil {
+		delegate.stdout = delegate.outputWriter(event.Origin{
+			Source: event.OriginSourceStdout,
+			ID:     event.OriginID(delegate.planID),
+		})
 	}
+
 	return delegate.stdout
 }
 
 func (delegate *buildStepDelegate) Stderr() io.Writer {
-	if delegate.stderr != nil {
-		return delegate.stderr
+	if delegate.stderr == nil {
+		delegate.stderr = delegate.outputWriter(event.Origin{
+			Source: event.OriginSourceStderr,
+			ID:     event.OriginID(delegate.planID),
+		})
 	}
+
+	return delegate.stderr
+}
+
+func (delegate *buildStepDelegate) outputWriter(origin event.Origin) io.Writer {
 	if delegate.state.RedactionEnabled() {
-		delegate.stderr = newDBEventWriterWithSecretRedaction(
+		return newDBEventWriterWithSecretRedaction(
 			delegate.build,
-			event.Origin{
-				Source: event.OriginSourceStderr,
-				ID:     event.OriginID(delegate.planID),
-			},
+			origin,
 			delegate.clock,
 			delegate.buildOutputFilter,
 		)
-	} else {
-		delegate.stderr = newDBEventWriter(
-			delegate.build,
-			event.Origin{
-				Source: event.OriginSourceStderr,
-				ID:     event.OriginID(delegate.planID),
-			},
-			delegate.clock,
-		)
 	}
-	return delegate.stderr
+
+	return newDBEventWriter(
+		delegate.build,
+		origin,
+		delegate.clock,
+	)
 }
 
 func (delegate *buildStepDelegate) Initializing(logger lager.Logger) {
@@ -207,6 +194,49 @@ func (delegate *buildStepDelegate) Errored(logger lager.Logger, message string)
 	}
 }
 
+func (delegate *buildStepDelegate) Warned(logger lager.Logger, message string) {
+	err := delegate.build.SaveEvent(event.Warning{
+		Message: message,
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-warning-event", err)
+	}
+}
+
+func (delegate *buildStepDelegate) Skipped(logger lager.Logger) {
+	err := delegate.build.SaveEvent(event.Skipped{
+		Origin: event.Origin{
+			ID: event.OriginID(delegate.planID),
+		},
+		Time: delegate.clock.Now().Unix(),
+	})
+	if err != nil {
+		logger.Error("failed-to-save-skipped-event", err)
+		return
+	}
+
+	logger.Info("skipped")
+}
+
+func (delegate *buildStepDelegate) ResourceUsage(logger lager.Logger, usage atc.StepResourceUsage) {
+	err := delegate.build.SaveStepResourceUsage(usage)
+	if err != nil {
+		logger.Error("failed-to-save-resource-usage", err)
+		return
+	}
+
+	metric.StepResourceUsage{
+		Build: delegate.build,
+		Usage: usage,
+	}.Emit(logger)
+
+	logger.Debug("resource-usage", lager.Data{"usage": usage})
+}
+
 // Name of the artifact fetched when using image_resource. Note that this only
 // exists within a local scope, so it doesn't pollute the build state.
 const defaultImageName = "image"
@@ -217,7 +247,19 @@ func (delegate *buildStepDelegate) FetchImage(
 	types atc.VersionedResourceTypes,
 	privileged bool,
 ) (worker.ImageSpec, error) {
-	err := delegate.checkImagePolicy(image, privileged)
+	return delegate.fetchImage(ctx, delegate.planID, image, types, privileged)
+}
+
+// fetchImage checks and fetches the image with plans whose IDs are derived
+// from the given plan ID, so that a step can fetch more than one image.
+func (delegate *buildStepDelegate) fetchImage(
+	ctx context.Context,
+	planID atc.PlanID,
+	image atc.ImageResource,
+	types atc.VersionedResourceTypes,
+	privileged bool,
+) (worker.ImageSpec, error) {
+	err := delegate.checkImagePolicy(lagerctx.FromContext(ctx), image, privileged)
 	if err != nil {
 		return worker.ImageSpec{}, err
 	}
@@ -231,7 +273,7 @@ func (delegate *buildStepDelegate) FetchImage(
 
 	version := image.Version
 	if version == nil {
-		checkID := delegate.planID + "/image-check"
+		checkID := planID + "/image-check"
 
 		checkPlan := atc.Plan{
 			ID: checkID,
@@ -271,7 +313,7 @@ func (delegate *buildStepDelegate) FetchImage(
 		}
 	}
 
-	getID := delegate.planID + "/image-get"
+	getID := planID + "/image-get"
 
 	getPlan := atc.Plan{
 		ID: getID,
@@ -323,13 +365,23 @@ func (delegate *buildStepDelegate) FetchImage(
 		return worker.ImageSpec{}, fmt.Errorf("fetched artifact not found")
 	}
 
+	versionJSON, err := json.Marshal(version)
+	if err != nil {
+		return worker.ImageSpec{}, fmt.Errorf("marshal image version: %w", err)
+	}
+
+	tracing.SetAttrs(ctx, tracing.Attrs{
+		"image_type":    image.Type,
+		"image_version": string(versionJSON),
+	})
+
 	return worker.ImageSpec{
 		ImageArtifact: art,
 		Privileged:    privileged,
 	}, nil
 }
 
-func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, privileged bool) error {
+func (delegate *buildStepDelegate) checkImagePolicy(logger lager.Logger, image atc.ImageResource, privileged bool) error {
 	if !delegate.policyChecker.ShouldCheckAction(policy.ActionUseImage) {
 		return nil
 	}
@@ -359,6 +411,10 @@ func (delegate *buildStepDelegate) checkImagePolicy(image atc.ImageResource, pri
 		}
 	}
 
+	for _, warning := range result.Warnings {
+		delegate.Warned(logger, fmt.Sprintf("policy check warning: %s", warning))
+	}
+
 	return nil
 }
 
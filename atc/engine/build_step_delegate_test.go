@@ -24,7 +24,11 @@ import (
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/runtime/runtimefakes"
 	"github.com/concourse/concourse/atc/worker"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
+	"go.opentelemetry.io/otel/label"
 )
 
 var _ = Describe("BuildStepDelegate", func() {
@@ -99,10 +103,13 @@ var _ = Describe("BuildStepDelegate", func() {
 		var types atc.VersionedResourceTypes
 		var privileged bool
 
+		var ctx context.Context
 		var imageSpec worker.ImageSpec
 		var fetchErr error
 
 		BeforeEach(func() {
+			ctx = context.TODO()
+
 			repo := build.NewRepository()
 			runState.ArtifactRepositoryReturns(repo)
 
@@ -201,7 +208,7 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 
 		JustBeforeEach(func() {
-			imageSpec, fetchErr = delegate.FetchImage(context.TODO(), imageResource, types, privileged)
+			imageSpec, fetchErr = delegate.FetchImage(ctx, imageResource, types, privileged)
 		})
 
 		It("succeeds", func() {
@@ -230,6 +237,25 @@ var _ = Describe("BuildStepDelegate", func() {
 			Expect(fakeBuild.SaveImageResourceVersionArgsForCall(0)).To(Equal(fakeResourceCache))
 		})
 
+		Context("when tracing is configured", func() {
+			var span trace.Span
+
+			BeforeEach(func() {
+				tracing.ConfigureTraceProvider(tracetest.NewProvider())
+				ctx, span = tracing.StartSpan(ctx, "task", nil)
+			})
+
+			AfterEach(func() {
+				tracing.Configured = false
+			})
+
+			It("sets the image type and version on the step's span", func() {
+				attrs := span.(*tracetest.Span).Attributes()
+				Expect(attrs).To(HaveKeyWithValue(label.Key("image_type"), label.StringValue("docker")))
+				Expect(attrs).To(HaveKeyWithValue(label.Key("image_version"), label.StringValue(`{"some":"version"}`)))
+			})
+		})
+
 		Context("when privileged", func() {
 			BeforeEach(func() {
 				privileged = true
@@ -274,6 +300,27 @@ var _ = Describe("BuildStepDelegate", func() {
 					fakePolicyChecker.ShouldCheckActionReturns(true)
 				})
 
+				Context("when the check is allowed with warnings", func() {
+					BeforeEach(func() {
+						fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
+							Allowed:  true,
+							Warnings: []string{"images should be pinned"},
+						}, nil)
+					})
+
+					It("succeeds", func() {
+						Expect(fetchErr).ToNot(HaveOccurred())
+					})
+
+					It("saves a warning event before fetching the image", func() {
+						Expect(fakeBuild.SaveEventCallCount()).To(BeNumerically(">", 1))
+						e := fakeBuild.SaveEventArgsForCall(0)
+						Expect(e).To(BeAssignableToTypeOf(event.Warning{}))
+						Expect(e.(event.Warning).Message).To(Equal("policy check warning: images should be pinned"))
+						Expect(e.(event.Warning).Origin).To(Equal(event.Origin{ID: event.OriginID(planID)}))
+					})
+				})
+
 				Context("when the check is allowed", func() {
 					BeforeEach(func() {
 						fakePolicyChecker.CheckReturns(policy.PolicyCheckOutput{
@@ -581,6 +628,44 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("ResourceUsage", func() {
+		var usage atc.StepResourceUsage
+
+		BeforeEach(func() {
+			usage = atc.StepResourceUsage{
+				PlanID:          "some-plan-id",
+				StepName:        "some-task",
+				StepType:        "task",
+				CPUSeconds:      1.5,
+				PeakMemoryBytes: 1024,
+				DiskBytes:       2048,
+				WallSeconds:     10,
+			}
+		})
+
+		JustBeforeEach(func() {
+			delegate.ResourceUsage(logger, usage)
+		})
+
+		It("saves the usage on the build", func() {
+			Expect(fakeBuild.SaveStepResourceUsageCallCount()).To(Equal(1))
+			Expect(fakeBuild.SaveStepResourceUsageArgsForCall(0)).To(Equal(usage))
+		})
+
+		Context("when saving the usage fails", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveStepResourceUsageReturns(errors.New("nope"))
+			})
+
+			It("logs an error", func() {
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(1))
+				Expect(logs[0].Message).To(Equal("test.failed-to-save-resource-usage"))
+				Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+			})
+		})
+	})
+
 	Describe("Errored", func() {
 		JustBeforeEach(func() {
 			delegate.Errored(logger, "fake error message")
@@ -619,6 +704,44 @@ var _ = Describe("BuildStepDelegate", func() {
 		})
 	})
 
+	Describe("Warned", func() {
+		JustBeforeEach(func() {
+			delegate.Warned(logger, "fake warning message")
+		})
+
+		Context("when saving the event succeeds", func() {
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(nil)
+			})
+
+			It("saves it with the current time", func() {
+				Expect(fakeBuild.SaveEventCallCount()).To(Equal(1))
+				Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Warning{
+					Time:    now.Unix(),
+					Message: "fake warning message",
+					Origin: event.Origin{
+						ID: "some-plan-id",
+					},
+				}))
+			})
+		})
+
+		Context("when saving the event fails", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				fakeBuild.SaveEventReturns(disaster)
+			})
+
+			It("logs an error", func() {
+				logs := logger.Logs()
+				Expect(len(logs)).To(Equal(1))
+				Expect(logs[0].Message).To(Equal("test.failed-to-save-warning-event"))
+				Expect(logs[0].Data).To(Equal(lager.Data{"error": "nope"}))
+			})
+		})
+	})
+
 	Describe("No line buffer without secrets redaction", func() {
 		var runState exec.RunState
 
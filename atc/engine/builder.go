@@ -24,6 +24,7 @@ type CoreStepFactory interface {
 	CheckStep(atc.Plan, exec.StepMetadata, db.ContainerMetadata, DelegateFactory) exec.Step
 	SetPipelineStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	LoadVarStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
+	ApprovalStep(atc.Plan, exec.StepMetadata, DelegateFactory) exec.Step
 	ArtifactInputStep(atc.Plan, db.Build) exec.Step
 	ArtifactOutputStep(atc.Plan, db.Build) exec.Step
 }
@@ -87,6 +88,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildTimeoutStep(build, plan)
 	}
 
+	if plan.If != nil {
+		return factory.buildIfStep(build, plan)
+	}
+
 	if plan.Try != nil {
 		return factory.buildTryStep(build, plan)
 	}
@@ -123,6 +128,10 @@ func (factory *stepperFactory) buildStep(build db.Build, plan atc.Plan) exec.Ste
 		return factory.buildLoadVarStep(build, plan)
 	}
 
+	if plan.Approval != nil {
+		return factory.buildApprovalStep(build, plan)
+	}
+
 	if plan.Check != nil {
 		return factory.buildCheckStep(build, plan)
 	}
@@ -219,6 +228,22 @@ func (factory *stepperFactory) buildTimeoutStep(build db.Build, plan atc.Plan) e
 	return exec.Timeout(step, plan.Timeout.Duration)
 }
 
+func (factory *stepperFactory) buildIfStep(build db.Build, plan atc.Plan) exec.Step {
+	innerPlan := plan.If.Step
+	innerPlan.Attempts = plan.Attempts
+	step := factory.buildStep(build, innerPlan)
+
+	// every step within is marked as skipped when the condition does not hold
+	var skippedDelegateFactories []exec.BuildStepDelegateFactory
+	innerPlan.Each(func(p *atc.Plan) {
+		if p.Get != nil || p.Put != nil || p.Task != nil || p.SetPipeline != nil || p.LoadVar != nil || p.Approval != nil || p.Check != nil {
+			skippedDelegateFactories = append(skippedDelegateFactories, buildDelegateFactory(build, *p, factory.rateLimiter, factory.policyChecker))
+		}
+	})
+
+	return exec.If(step, plan.If.Condition, skippedDelegateFactories)
+}
+
 func (factory *stepperFactory) buildTryStep(build db.Build, plan atc.Plan) exec.Step {
 	innerPlan := plan.Try.Step
 	innerPlan.Attempts = plan.Attempts
@@ -394,6 +419,20 @@ func (factory *stepperFactory) buildLoadVarStep(build db.Build, plan atc.Plan) e
 	)
 }
 
+func (factory *stepperFactory) buildApprovalStep(build db.Build, plan atc.Plan) exec.Step {
+
+	stepMetadata := factory.stepMetadata(
+		build,
+		factory.externalURL,
+	)
+
+	return factory.coreFactory.ApprovalStep(
+		plan,
+		stepMetadata,
+		buildDelegateFactory(build, plan, factory.rateLimiter, factory.policyChecker),
+	)
+}
+
 func (factory *stepperFactory) buildArtifactInputStep(build db.Build, plan atc.Plan) exec.Step {
 	return factory.coreFactory.ArtifactInputStep(
 		plan,
@@ -457,5 +496,6 @@ func (factory *stepperFactory) stepMetadata(
 		PipelineName:         build.PipelineName(),
 		PipelineInstanceVars: build.PipelineInstanceVars(),
 		ExternalURL:          externalURL,
+		Priority:             build.Priority(),
 	}
 }
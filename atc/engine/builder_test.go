@@ -555,6 +555,20 @@ var _ = Describe("Builder", func() {
 						})
 					})
 
+					Context("that contains an approval step", func() {
+						BeforeEach(func() {
+							expectedPlan = planFactory.NewPlan(atc.ApprovalPlan{
+								Name: "deploy",
+							})
+						})
+
+						It("constructs approval correctly", func() {
+							plan, stepMetadata, _ := fakeCoreStepFactory.ApprovalStepArgsForCall(0)
+							Expect(plan).To(Equal(expectedPlan))
+							Expect(stepMetadata).To(Equal(expectedMetadata))
+						})
+					})
+
 					Context("that contains a check step", func() {
 						BeforeEach(func() {
 							expectedPlan = planFactory.NewPlan(atc.CheckPlan{
@@ -818,6 +832,28 @@ var _ = Describe("Builder", func() {
 					})
 				})
 
+				Context("running if steps", func() {
+					var inputPlan atc.Plan
+
+					BeforeEach(func() {
+						inputPlan = planFactory.NewPlan(atc.GetPlan{
+							Name: "some-input",
+						})
+
+						expectedPlan = planFactory.NewPlan(atc.IfPlan{
+							Step:      inputPlan,
+							Condition: "((.:flag))",
+						})
+					})
+
+					It("constructs the nested step", func() {
+						Expect(fakeCoreStepFactory.GetStepCallCount()).To(Equal(1))
+						plan, stepMetadata, _, _ := fakeCoreStepFactory.GetStepArgsForCall(0)
+						Expect(plan).To(Equal(inputPlan))
+						Expect(stepMetadata).To(Equal(expectedMetadata))
+					})
+				})
+
 				Context("running across steps", func() {
 					BeforeEach(func() {
 						planner := builds.NewPlanner(planFactory)
@@ -840,7 +876,7 @@ var _ = Describe("Builder", func() {
 							},
 						}
 
-						expectedPlan, err = planner.Create(step, nil, nil, nil)
+						expectedPlan, err = planner.Create(step, nil, nil, nil, nil)
 						Expect(err).ToNot(HaveOccurred())
 					})
 
@@ -53,3 +53,7 @@ func (delegate DelegateFactory) BuildStepDelegate(state exec.RunState) exec.Buil
 func (delegate DelegateFactory) SetPipelineStepDelegate(state exec.RunState) exec.SetPipelineStepDelegate {
 	return NewSetPipelineStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
 }
+
+func (delegate DelegateFactory) ApprovalStepDelegate(state exec.RunState) exec.ApprovalStepDelegate {
+	return NewApprovalStepDelegate(delegate.build, delegate.plan.ID, state, clock.NewClock())
+}
@@ -17,6 +17,7 @@ import (
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/util"
 	"github.com/concourse/concourse/tracing"
+	"go.opentelemetry.io/otel/api/propagation"
 )
 
 //go:generate counterfeiter . Engine
@@ -161,7 +162,7 @@ func (b *engineBuild) Run(ctx context.Context) {
 
 	defer notifier.Close()
 
-	ctx, span := tracing.StartSpanFollowing(ctx, b.build, "build", b.build.TracingAttrs())
+	ctx, span := tracing.StartSpanFollowing(ctx, b.build, "build", b.build.TracingAttrs(), b.spanLinks(logger)...)
 	defer span.End()
 
 	stepper, err := b.builder.StepperForBuild(b.build)
@@ -301,6 +302,23 @@ func (b *engineBuild) trackFinished(logger lager.Logger) {
 	}
 }
 
+// spanLinks returns the span contexts the build's span should be linked to.
+// They are only looked up when tracing is configured, as doing so costs a
+// query per build.
+func (b *engineBuild) spanLinks(logger lager.Logger) []propagation.HTTPSupplier {
+	if !tracing.Configured {
+		return nil
+	}
+
+	links, err := b.build.SpanLinks()
+	if err != nil {
+		logger.Error("failed-to-get-span-links", err)
+		return nil
+	}
+
+	return links
+}
+
 func (b *engineBuild) runState(logger lager.Logger, stepper exec.Stepper) (exec.RunState, error) {
 	id := fmt.Sprintf("build:%v", b.build.ID())
 	existingState, ok := b.trackedStates.Load(id)
@@ -20,7 +20,10 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/api/propagation"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
@@ -168,6 +171,41 @@ var _ = Describe("Engine", func() {
 								Expect(plan).To(Equal(fakeBuild.PrivatePlan())) //XXX
 							})
 
+							It("does not look up span links when tracing is not configured", func() {
+								waitGroup.Wait()
+								Expect(fakeBuild.SpanLinksCallCount()).To(BeZero())
+							})
+
+							Context("when tracing is configured", func() {
+								BeforeEach(func() {
+									tracing.ConfigureTraceProvider(tracetest.NewProvider())
+
+									fakeBuild.SpanLinksReturns([]propagation.HTTPSupplier{
+										db.SpanContext{"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01"},
+									}, nil)
+								})
+
+								AfterEach(func() {
+									tracing.Configured = false
+								})
+
+								It("links the build's span to the build's span links", func() {
+									waitGroup.Wait()
+									Expect(fakeStep.RunCallCount()).To(Equal(1))
+
+									stepCtx, _ := fakeStep.RunArgsForCall(0)
+									span, ok := tracing.FromContext(stepCtx).(*tracetest.Span)
+									Expect(ok).To(BeTrue())
+									Expect(span.Name()).To(Equal("build"))
+
+									var linkedTraceIDs []string
+									for linked := range span.Links() {
+										linkedTraceIDs = append(linkedTraceIDs, linked.TraceID.String())
+									}
+									Expect(linkedTraceIDs).To(ConsistOf("0af7651916cd43dd8448eb211c80319c"))
+								})
+							})
+
 							Context("when getting the build vars succeeds", func() {
 								var invokedState chan exec.RunState
 
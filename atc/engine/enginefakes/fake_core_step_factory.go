@@ -11,6 +11,19 @@ import (
 )
 
 type FakeCoreStepFactory struct {
+	ApprovalStepStub        func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step
+	approvalStepMutex       sync.RWMutex
+	approvalStepArgsForCall []struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}
+	approvalStepReturns struct {
+		result1 exec.Step
+	}
+	approvalStepReturnsOnCall map[int]struct {
+		result1 exec.Step
+	}
 	ArtifactInputStepStub        func(atc.Plan, db.Build) exec.Step
 	artifactInputStepMutex       sync.RWMutex
 	artifactInputStepArgsForCall []struct {
@@ -121,6 +134,68 @@ type FakeCoreStepFactory struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakeCoreStepFactory) ApprovalStep(arg1 atc.Plan, arg2 exec.StepMetadata, arg3 engine.DelegateFactory) exec.Step {
+	fake.approvalStepMutex.Lock()
+	ret, specificReturn := fake.approvalStepReturnsOnCall[len(fake.approvalStepArgsForCall)]
+	fake.approvalStepArgsForCall = append(fake.approvalStepArgsForCall, struct {
+		arg1 atc.Plan
+		arg2 exec.StepMetadata
+		arg3 engine.DelegateFactory
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApprovalStep", []interface{}{arg1, arg2, arg3})
+	fake.approvalStepMutex.Unlock()
+	if fake.ApprovalStepStub != nil {
+		return fake.ApprovalStepStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approvalStepReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCallCount() int {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	return len(fake.approvalStepArgsForCall)
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepCalls(stub func(atc.Plan, exec.StepMetadata, engine.DelegateFactory) exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = stub
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepArgsForCall(i int) (atc.Plan, exec.StepMetadata, engine.DelegateFactory) {
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
+	argsForCall := fake.approvalStepArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturns(result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	fake.approvalStepReturns = struct {
+		result1 exec.Step
+	}{result1}
+}
+
+func (fake *FakeCoreStepFactory) ApprovalStepReturnsOnCall(i int, result1 exec.Step) {
+	fake.approvalStepMutex.Lock()
+	defer fake.approvalStepMutex.Unlock()
+	fake.ApprovalStepStub = nil
+	if fake.approvalStepReturnsOnCall == nil {
+		fake.approvalStepReturnsOnCall = make(map[int]struct {
+			result1 exec.Step
+		})
+	}
+	fake.approvalStepReturnsOnCall[i] = struct {
+		result1 exec.Step
+	}{result1}
+}
+
 func (fake *FakeCoreStepFactory) ArtifactInputStep(arg1 atc.Plan, arg2 db.Build) exec.Step {
 	fake.artifactInputStepMutex.Lock()
 	ret, specificReturn := fake.artifactInputStepReturnsOnCall[len(fake.artifactInputStepArgsForCall)]
@@ -622,6 +697,8 @@ func (fake *FakeCoreStepFactory) TaskStepReturnsOnCall(i int, result1 exec.Step)
 func (fake *FakeCoreStepFactory) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.approvalStepMutex.RLock()
+	defer fake.approvalStepMutex.RUnlock()
 	fake.artifactInputStepMutex.RLock()
 	defer fake.artifactInputStepMutex.RUnlock()
 	fake.artifactOutputStepMutex.RLock()
@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"io"
 	"time"
 
@@ -84,7 +85,7 @@ func (d *putDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus,
 	logger.Info("finished", lager.Data{"exit-status": exitStatus, "version-info": info})
 }
 
-func (d *putDelegate) SaveOutput(log lager.Logger, plan atc.PutPlan, source atc.Source, resourceTypes atc.VersionedResourceTypes, info runtime.VersionResult) {
+func (d *putDelegate) SaveOutput(ctx context.Context, log lager.Logger, plan atc.PutPlan, source atc.Source, resourceTypes atc.VersionedResourceTypes, info runtime.VersionResult) {
 	logger := log.WithData(lager.Data{
 		"step":          plan.Name,
 		"resource":      plan.Resource,
@@ -93,6 +94,7 @@ func (d *putDelegate) SaveOutput(log lager.Logger, plan atc.PutPlan, source atc.
 	})
 
 	err := d.build.SaveOutput(
+		db.NewSpanContext(ctx),
 		plan.Type,
 		source,
 		resourceTypes,
@@ -1,6 +1,7 @@
 package engine_test
 
 import (
+	"context"
 	"time"
 
 	. "github.com/onsi/ginkgo"
@@ -16,7 +17,10 @@ import (
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/vars"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
 )
 
 var _ = Describe("PutDelegate", func() {
@@ -77,6 +81,11 @@ var _ = Describe("PutDelegate", func() {
 		var plan atc.PutPlan
 		var source atc.Source
 		var resourceTypes atc.VersionedResourceTypes
+		var ctx context.Context
+
+		BeforeEach(func() {
+			ctx = context.Background()
+		})
 
 		JustBeforeEach(func() {
 			plan = atc.PutPlan{
@@ -87,12 +96,12 @@ var _ = Describe("PutDelegate", func() {
 			source = atc.Source{"some": "source"}
 			resourceTypes = atc.VersionedResourceTypes{}
 
-			delegate.SaveOutput(logger, plan, source, resourceTypes, info)
+			delegate.SaveOutput(ctx, logger, plan, source, resourceTypes, info)
 		})
 
 		It("saves the build output", func() {
 			Expect(fakeBuild.SaveOutputCallCount()).To(Equal(1))
-			resourceType, sourceArg, resourceTypesArg, version, metadata, name, resource := fakeBuild.SaveOutputArgsForCall(0)
+			_, resourceType, sourceArg, resourceTypesArg, version, metadata, name, resource := fakeBuild.SaveOutputArgsForCall(0)
 			Expect(resourceType).To(Equal(plan.Type))
 			Expect(sourceArg).To(Equal(source))
 			Expect(resourceTypesArg).To(Equal(resourceTypes))
@@ -101,5 +110,26 @@ var _ = Describe("PutDelegate", func() {
 			Expect(name).To(Equal(plan.Name))
 			Expect(resource).To(Equal(plan.Resource))
 		})
+
+		Context("when tracing is configured", func() {
+			var traceID string
+
+			BeforeEach(func() {
+				tracing.ConfigureTraceProvider(tracetest.NewProvider())
+
+				var span trace.Span
+				ctx, span = tracing.StartSpan(ctx, "put", nil)
+				traceID = span.SpanContext().TraceID.String()
+			})
+
+			AfterEach(func() {
+				tracing.Configured = false
+			})
+
+			It("saves the output with the span context of the step", func() {
+				spanContext, _, _, _, _, _, _, _ := fakeBuild.SaveOutputArgsForCall(0)
+				Expect(spanContext.Get("traceparent")).To(ContainSubstring(traceID))
+			})
+		})
 	})
 })
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec"
@@ -26,6 +27,9 @@ type coreStepFactory struct {
 	strategy              worker.ContainerPlacementStrategy
 	lockFactory           lock.LockFactory
 	defaultCheckTimeout   time.Duration
+	keyedCacheFactory     db.KeyedCacheFactory
+	artifactStore         blobstore.Store
+	storedArtifactFactory db.StoredArtifactFactory
 }
 
 func NewCoreStepFactory(
@@ -40,6 +44,9 @@ func NewCoreStepFactory(
 	strategy worker.ContainerPlacementStrategy,
 	lockFactory lock.LockFactory,
 	defaultCheckTimeout time.Duration,
+	keyedCacheFactory db.KeyedCacheFactory,
+	artifactStore blobstore.Store,
+	storedArtifactFactory db.StoredArtifactFactory,
 ) CoreStepFactory {
 	return &coreStepFactory{
 		pool:                  pool,
@@ -53,6 +60,9 @@ func NewCoreStepFactory(
 		strategy:              strategy,
 		lockFactory:           lockFactory,
 		defaultCheckTimeout:   defaultCheckTimeout,
+		keyedCacheFactory:     keyedCacheFactory,
+		artifactStore:         artifactStore,
+		storedArtifactFactory: storedArtifactFactory,
 	}
 }
 
@@ -158,6 +168,9 @@ func (factory *coreStepFactory) TaskStep(
 		factory.client,
 		delegateFactory,
 		factory.lockFactory,
+		factory.keyedCacheFactory,
+		factory.artifactStore,
+		factory.storedArtifactFactory,
 	)
 
 	taskStep = exec.LogError(taskStep, delegateFactory)
@@ -210,6 +223,21 @@ func (factory *coreStepFactory) LoadVarStep(
 	return loadVarStep
 }
 
+func (factory *coreStepFactory) ApprovalStep(
+	plan atc.Plan,
+	stepMetadata exec.StepMetadata,
+	delegateFactory DelegateFactory,
+) exec.Step {
+	approvalStep := exec.NewApprovalStep(
+		plan.ID,
+		*plan.Approval,
+		stepMetadata,
+		delegateFactory,
+	)
+
+	return exec.LogError(approvalStep, delegateFactory)
+}
+
 func (factory *coreStepFactory) ArtifactInputStep(
 	plan atc.Plan,
 	build db.Build,
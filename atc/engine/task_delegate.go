@@ -1,6 +1,7 @@
 package engine
 
 import (
+	"context"
 	"io"
 
 	"code.cloudfoundry.org/clock"
@@ -10,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/worker"
 )
 
 func NewTaskDelegate(
@@ -19,8 +21,12 @@ func NewTaskDelegate(
 	clock clock.Clock,
 	policyChecker policy.Checker,
 ) exec.TaskDelegate {
+	stepDelegate := NewBuildStepDelegate(build, planID, state, clock, policyChecker)
+
 	return &taskDelegate{
-		BuildStepDelegate: NewBuildStepDelegate(build, planID, state, clock, policyChecker),
+		BuildStepDelegate: stepDelegate,
+
+		stepDelegate: stepDelegate,
 
 		eventOrigin: event.Origin{ID: event.OriginID(planID)},
 		build:       build,
@@ -31,6 +37,9 @@ func NewTaskDelegate(
 type taskDelegate struct {
 	exec.BuildStepDelegate
 
+	stepDelegate   *buildStepDelegate
+	serviceOutputs []io.Writer
+
 	config      atc.TaskConfig
 	build       db.Build
 	eventOrigin event.Origin
@@ -41,6 +50,38 @@ func (d *taskDelegate) SetTaskConfig(config atc.TaskConfig) {
 	d.config = config
 }
 
+// FetchServiceImage fetches the image of one of the task's services, which
+// always run unprivileged.
+func (d *taskDelegate) FetchServiceImage(
+	ctx context.Context,
+	name string,
+	image atc.ImageResource,
+	types atc.VersionedResourceTypes,
+) (worker.ImageSpec, error) {
+	planID := d.stepDelegate.planID + "/services/" + atc.PlanID(name)
+	return d.stepDelegate.fetchImage(ctx, planID, image, types, false)
+}
+
+func (d *taskDelegate) ServiceStdout(name string) io.Writer {
+	return d.serviceOutput(name, event.OriginSourceStdout)
+}
+
+func (d *taskDelegate) ServiceStderr(name string) io.Writer {
+	return d.serviceOutput(name, event.OriginSourceStderr)
+}
+
+func (d *taskDelegate) serviceOutput(name string, source event.OriginSource) io.Writer {
+	writer := d.stepDelegate.outputWriter(event.Origin{
+		ID:      d.eventOrigin.ID,
+		Source:  source,
+		Service: name,
+	})
+
+	d.serviceOutputs = append(d.serviceOutputs, writer)
+
+	return writer
+}
+
 func (d *taskDelegate) Initializing(logger lager.Logger) {
 	err := d.build.SaveEvent(event.InitializeTask{
 		Origin:     d.eventOrigin,
@@ -74,6 +115,10 @@ func (d *taskDelegate) Finished(logger lager.Logger, exitStatus exec.ExitStatus)
 	d.Stdout().(io.Closer).Close()
 	d.Stderr().(io.Closer).Close()
 
+	for _, output := range d.serviceOutputs {
+		output.(io.Closer).Close()
+	}
+
 	err := d.build.SaveEvent(event.FinishTask{
 		ExitStatus: int(exitStatus),
 		Time:       d.clock.Now().Unix(),
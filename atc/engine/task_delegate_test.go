@@ -12,6 +12,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/engine"
+	"github.com/concourse/concourse/atc/event"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/policy/policyfakes"
 	"github.com/concourse/concourse/vars"
@@ -129,4 +130,41 @@ var _ = Describe("TaskDelegate", func() {
 			Expect(event.EventType()).To(Equal(atc.EventType("finish-task")))
 		})
 	})
+
+	Describe("ServiceStdout", func() {
+		It("saves log events originating from the service", func() {
+			_, err := delegate.ServiceStdout("postgres").Write([]byte("ready to accept connections\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			delegate.Finished(logger, exitStatus)
+
+			Expect(fakeBuild.SaveEventCallCount()).To(Equal(2))
+			Expect(fakeBuild.SaveEventArgsForCall(0)).To(Equal(event.Log{
+				Time:    now.Unix(),
+				Payload: "ready to accept connections\n",
+				Origin: event.Origin{
+					ID:      "some-plan-id",
+					Source:  event.OriginSourceStdout,
+					Service: "postgres",
+				},
+			}))
+		})
+	})
+
+	Describe("ServiceStderr", func() {
+		It("saves log events originating from the service", func() {
+			_, err := delegate.ServiceStderr("redis").Write([]byte("oops\n"))
+			Expect(err).ToNot(HaveOccurred())
+
+			delegate.Finished(logger, exitStatus)
+
+			log, ok := fakeBuild.SaveEventArgsForCall(0).(event.Log)
+			Expect(ok).To(BeTrue())
+			Expect(log.Origin).To(Equal(event.Origin{
+				ID:      "some-plan-id",
+				Source:  event.OriginSourceStderr,
+				Service: "redis",
+			}))
+		})
+	})
 })
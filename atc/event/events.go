@@ -15,6 +15,15 @@ type Error struct {
 func (Error) EventType() atc.EventType  { return EventTypeError }
 func (Error) Version() atc.EventVersion { return "4.1" }
 
+type Warning struct {
+	Message string `json:"message"`
+	Origin  Origin `json:"origin"`
+	Time    int64  `json:"time"`
+}
+
+func (Warning) EventType() atc.EventType  { return EventTypeWarning }
+func (Warning) Version() atc.EventVersion { return "1.0" }
+
 type FinishTask struct {
 	Time       int64  `json:"time"`
 	ExitStatus int    `json:"exit_status"`
@@ -113,6 +122,9 @@ func (Log) Version() atc.EventVersion { return "5.1" }
 type Origin struct {
 	ID     OriginID     `json:"id,omitempty"`
 	Source OriginSource `json:"source,omitempty"`
+
+	// Name of the task's service container the event originates from, if any.
+	Service string `json:"service,omitempty"`
 }
 
 type OriginID string
@@ -215,6 +227,14 @@ type Finish struct {
 func (Finish) EventType() atc.EventType  { return EventTypeFinish }
 func (Finish) Version() atc.EventVersion { return "1.0" }
 
+type Skipped struct {
+	Origin Origin `json:"origin"`
+	Time   int64  `json:"time"`
+}
+
+func (Skipped) EventType() atc.EventType  { return EventTypeSkipped }
+func (Skipped) Version() atc.EventVersion { return "1.0" }
+
 type ImageCheck struct {
 	Time       int64            `json:"time"`
 	Origin     Origin           `json:"origin"`
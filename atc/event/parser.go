@@ -44,10 +44,12 @@ func init() {
 	RegisterEvent(StartPut{})
 	RegisterEvent(FinishPut{})
 	RegisterEvent(SetPipelineChanged{})
+	RegisterEvent(Skipped{})
 	RegisterEvent(Status{})
 	RegisterEvent(SelectedWorker{})
 	RegisterEvent(Log{})
 	RegisterEvent(Error{})
+	RegisterEvent(Warning{})
 	RegisterEvent(ImageCheck{})
 	RegisterEvent(ImageGet{})
 
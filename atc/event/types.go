@@ -50,9 +50,16 @@ const (
 	// finished step
 	EventTypeFinish atc.EventType = "finish"
 
+	// step skipped as its 'if' condition did not hold
+	EventTypeSkipped atc.EventType = "skipped"
+
 	// error occurred
 	EventTypeError atc.EventType = "error"
 
+	// something the user should know about, which did not fail the build,
+	// e.g. a policy check warning
+	EventTypeWarning atc.EventType = "warning"
+
 	// image check sub-plan
 	EventTypeImageCheck atc.EventType = "image-check"
 
@@ -0,0 +1,76 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/tracing"
+)
+
+// ApprovalStep pauses the build until a user approves or rejects it. The
+// step succeeds when approved and fails when rejected.
+type ApprovalStep struct {
+	planID          atc.PlanID
+	plan            atc.ApprovalPlan
+	metadata        StepMetadata
+	delegateFactory ApprovalStepDelegateFactory
+}
+
+func NewApprovalStep(
+	planID atc.PlanID,
+	plan atc.ApprovalPlan,
+	metadata StepMetadata,
+	delegateFactory ApprovalStepDelegateFactory,
+) Step {
+	return &ApprovalStep{
+		planID:          planID,
+		plan:            plan,
+		metadata:        metadata,
+		delegateFactory: delegateFactory,
+	}
+}
+
+func (step *ApprovalStep) Run(ctx context.Context, state RunState) (bool, error) {
+	delegate := step.delegateFactory.ApprovalStepDelegate(state)
+	ctx, span := delegate.StartSpan(ctx, "approval", tracing.Attrs{
+		"name": step.plan.Name,
+	})
+
+	ok, err := step.run(ctx, delegate)
+	tracing.End(span, err)
+
+	return ok, err
+}
+
+func (step *ApprovalStep) run(ctx context.Context, delegate ApprovalStepDelegate) (bool, error) {
+	logger := lagerctx.FromContext(ctx)
+	logger = logger.Session("approval-step", lager.Data{
+		"step-name": step.plan.Name,
+		"job-id":    step.metadata.JobID,
+	})
+
+	delegate.Initializing(logger)
+	delegate.Starting(logger)
+
+	stdout := delegate.Stdout()
+	fmt.Fprintf(stdout, "waiting for approval of %s...\n", step.plan.Name)
+
+	approved, decidedBy, err := delegate.WaitForApproval(ctx, logger, step.plan.Name)
+	if err != nil {
+		return false, err
+	}
+
+	if approved {
+		fmt.Fprintf(stdout, "approved by %s\n", decidedBy)
+	} else {
+		fmt.Fprintf(stdout, "rejected by %s\n", decidedBy)
+	}
+
+	delegate.Finished(logger, approved)
+
+	return approved, nil
+}
@@ -0,0 +1,123 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	"code.cloudfoundry.org/lager/lagerctx"
+	"code.cloudfoundry.org/lager/lagertest"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"go.opentelemetry.io/otel/api/trace"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+)
+
+var _ = Describe("ApprovalStep", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		fakeDelegate        *execfakes.FakeApprovalStepDelegate
+		fakeDelegateFactory *execfakes.FakeApprovalStepDelegateFactory
+
+		state  *execfakes.FakeRunState
+		stdout *gbytes.Buffer
+
+		step    exec.Step
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+		ctx = lagerctx.NewContext(ctx, lagertest.NewTestLogger("approval-step-test"))
+
+		state = new(execfakes.FakeRunState)
+		stdout = gbytes.NewBuffer()
+
+		fakeDelegate = new(execfakes.FakeApprovalStepDelegate)
+		fakeDelegate.StdoutReturns(stdout)
+		fakeDelegate.StartSpanReturns(context.Background(), trace.NoopSpan{})
+
+		fakeDelegateFactory = new(execfakes.FakeApprovalStepDelegateFactory)
+		fakeDelegateFactory.ApprovalStepDelegateReturns(fakeDelegate)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	JustBeforeEach(func() {
+		step = exec.NewApprovalStep(
+			"some-plan-id",
+			atc.ApprovalPlan{Name: "deploy"},
+			exec.StepMetadata{JobID: 1},
+			fakeDelegateFactory,
+		)
+
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	It("waits for approval of the named step", func() {
+		Expect(fakeDelegate.InitializingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.StartingCallCount()).To(Equal(1))
+		Expect(fakeDelegate.WaitForApprovalCallCount()).To(Equal(1))
+		_, _, name := fakeDelegate.WaitForApprovalArgsForCall(0)
+		Expect(name).To(Equal("deploy"))
+		Expect(stdout).To(gbytes.Say("waiting for approval of deploy..."))
+	})
+
+	Context("when approved", func() {
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(true, "some-user", nil)
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+
+		It("finishes successfully and says who approved", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeTrue())
+			Expect(stdout).To(gbytes.Say("approved by some-user"))
+		})
+	})
+
+	Context("when rejected", func() {
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(false, "some-user", nil)
+		})
+
+		It("fails without erroring", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeFalse())
+		})
+
+		It("finishes unsuccessfully and says who rejected", func() {
+			Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
+			_, succeeded := fakeDelegate.FinishedArgsForCall(0)
+			Expect(succeeded).To(BeFalse())
+			Expect(stdout).To(gbytes.Say("rejected by some-user"))
+		})
+	})
+
+	Context("when waiting fails", func() {
+		disaster := errors.New("nope")
+
+		BeforeEach(func() {
+			fakeDelegate.WaitForApprovalReturns(false, "", disaster)
+		})
+
+		It("returns the error", func() {
+			Expect(stepErr).To(Equal(disaster))
+			Expect(stepOk).To(BeFalse())
+			Expect(fakeDelegate.FinishedCallCount()).To(BeZero())
+		})
+	})
+})
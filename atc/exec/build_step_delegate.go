@@ -33,6 +33,10 @@ type BuildStepDelegate interface {
 	Finished(lager.Logger, bool)
 	SelectedWorker(lager.Logger, string)
 	Errored(lager.Logger, string)
+	Warned(lager.Logger, string)
+	Skipped(lager.Logger)
+
+	ResourceUsage(lager.Logger, atc.StepResourceUsage)
 }
 
 //go:generate counterfeiter . SetPipelineStepDelegateFactory
@@ -47,3 +51,16 @@ type SetPipelineStepDelegate interface {
 	BuildStepDelegate
 	SetPipelineChanged(lager.Logger, bool)
 }
+
+//go:generate counterfeiter . ApprovalStepDelegateFactory
+
+type ApprovalStepDelegateFactory interface {
+	ApprovalStepDelegate(state RunState) ApprovalStepDelegate
+}
+
+//go:generate counterfeiter . ApprovalStepDelegate
+
+type ApprovalStepDelegate interface {
+	BuildStepDelegate
+	WaitForApproval(context.Context, lager.Logger, string) (bool, string, error)
+}
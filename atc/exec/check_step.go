@@ -270,6 +270,7 @@ func (step *CheckStep) runCheck(
 		}
 	} else {
 		imageSpec.ResourceType = step.plan.Type
+		tracing.SetAttrs(ctx, tracing.Attrs{"base_resource_type": step.plan.Type})
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -0,0 +1,110 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package execfakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/atc/exec"
+)
+
+type FakeApprovalStepDelegateFactory struct {
+	ApprovalStepDelegateStub        func(exec.RunState) exec.ApprovalStepDelegate
+	approvalStepDelegateMutex       sync.RWMutex
+	approvalStepDelegateArgsForCall []struct {
+		arg1 exec.RunState
+	}
+	approvalStepDelegateReturns struct {
+		result1 exec.ApprovalStepDelegate
+	}
+	approvalStepDelegateReturnsOnCall map[int]struct {
+		result1 exec.ApprovalStepDelegate
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegate(arg1 exec.RunState) exec.ApprovalStepDelegate {
+	fake.approvalStepDelegateMutex.Lock()
+	ret, specificReturn := fake.approvalStepDelegateReturnsOnCall[len(fake.approvalStepDelegateArgsForCall)]
+	fake.approvalStepDelegateArgsForCall = append(fake.approvalStepDelegateArgsForCall, struct {
+		arg1 exec.RunState
+	}{arg1})
+	fake.recordInvocation("ApprovalStepDelegate", []interface{}{arg1})
+	fake.approvalStepDelegateMutex.Unlock()
+	if fake.ApprovalStepDelegateStub != nil {
+		return fake.ApprovalStepDelegateStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approvalStepDelegateReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegateCallCount() int {
+	fake.approvalStepDelegateMutex.RLock()
+	defer fake.approvalStepDelegateMutex.RUnlock()
+	return len(fake.approvalStepDelegateArgsForCall)
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegateCalls(stub func(exec.RunState) exec.ApprovalStepDelegate) {
+	fake.approvalStepDelegateMutex.Lock()
+	defer fake.approvalStepDelegateMutex.Unlock()
+	fake.ApprovalStepDelegateStub = stub
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegateArgsForCall(i int) exec.RunState {
+	fake.approvalStepDelegateMutex.RLock()
+	defer fake.approvalStepDelegateMutex.RUnlock()
+	argsForCall := fake.approvalStepDelegateArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegateReturns(result1 exec.ApprovalStepDelegate) {
+	fake.approvalStepDelegateMutex.Lock()
+	defer fake.approvalStepDelegateMutex.Unlock()
+	fake.ApprovalStepDelegateStub = nil
+	fake.approvalStepDelegateReturns = struct {
+		result1 exec.ApprovalStepDelegate
+	}{result1}
+}
+
+func (fake *FakeApprovalStepDelegateFactory) ApprovalStepDelegateReturnsOnCall(i int, result1 exec.ApprovalStepDelegate) {
+	fake.approvalStepDelegateMutex.Lock()
+	defer fake.approvalStepDelegateMutex.Unlock()
+	fake.ApprovalStepDelegateStub = nil
+	if fake.approvalStepDelegateReturnsOnCall == nil {
+		fake.approvalStepDelegateReturnsOnCall = make(map[int]struct {
+			result1 exec.ApprovalStepDelegate
+		})
+	}
+	fake.approvalStepDelegateReturnsOnCall[i] = struct {
+		result1 exec.ApprovalStepDelegate
+	}{result1}
+}
+
+func (fake *FakeApprovalStepDelegateFactory) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.approvalStepDelegateMutex.RLock()
+	defer fake.approvalStepDelegateMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeApprovalStepDelegateFactory) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ exec.ApprovalStepDelegateFactory = new(FakeApprovalStepDelegateFactory)
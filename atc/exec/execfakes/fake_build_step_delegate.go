@@ -48,12 +48,23 @@ type FakeBuildStepDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ResourceUsageStub        func(lager.Logger, atc.StepResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -94,6 +105,12 @@ type FakeBuildStepDelegate struct {
 	stdoutReturnsOnCall map[int]struct {
 		result1 io.Writer
 	}
+	WarnedStub        func(lager.Logger, string)
+	warnedMutex       sync.RWMutex
+	warnedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -259,6 +276,38 @@ func (fake *FakeBuildStepDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeBuildStepDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.StepResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.StepResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -291,6 +340,37 @@ func (fake *FakeBuildStepDelegate) SelectedWorkerArgsForCall(i int) (lager.Logge
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeBuildStepDelegate) Skipped(arg1 lager.Logger) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Skipped", []interface{}{arg1})
+	fake.skippedMutex.Unlock()
+	if fake.SkippedStub != nil {
+		fake.SkippedStub(arg1)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) SkippedCalls(stub func(lager.Logger)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) SkippedArgsForCall(i int) lager.Logger {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeBuildStepDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -491,6 +571,38 @@ func (fake *FakeBuildStepDelegate) StdoutReturnsOnCall(i int, result1 io.Writer)
 	}{result1}
 }
 
+func (fake *FakeBuildStepDelegate) Warned(arg1 lager.Logger, arg2 string) {
+	fake.warnedMutex.Lock()
+	fake.warnedArgsForCall = append(fake.warnedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Warned", []interface{}{arg1, arg2})
+	fake.warnedMutex.Unlock()
+	if fake.WarnedStub != nil {
+		fake.WarnedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeBuildStepDelegate) WarnedCallCount() int {
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
+	return len(fake.warnedArgsForCall)
+}
+
+func (fake *FakeBuildStepDelegate) WarnedCalls(stub func(lager.Logger, string)) {
+	fake.warnedMutex.Lock()
+	defer fake.warnedMutex.Unlock()
+	fake.WarnedStub = stub
+}
+
+func (fake *FakeBuildStepDelegate) WarnedArgsForCall(i int) (lager.Logger, string) {
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
+	argsForCall := fake.warnedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -502,8 +614,12 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -512,6 +628,8 @@ func (fake *FakeBuildStepDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stderrMutex.RUnlock()
 	fake.stdoutMutex.RLock()
 	defer fake.stdoutMutex.RUnlock()
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -74,12 +74,23 @@ type FakeCheckDelegate struct {
 	pointToCheckedConfigReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ResourceUsageStub        func(lager.Logger, atc.StepResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	SkippedStub        func(lager.Logger)
+	skippedMutex       sync.RWMutex
+	skippedArgsForCall []struct {
+		arg1 lager.Logger
+	}
 	StartSpanStub        func(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 	startSpanMutex       sync.RWMutex
 	startSpanArgsForCall []struct {
@@ -136,6 +147,12 @@ type FakeCheckDelegate struct {
 		result2 bool
 		result3 error
 	}
+	WarnedStub        func(lager.Logger, string)
+	warnedMutex       sync.RWMutex
+	warnedArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -424,6 +441,38 @@ func (fake *FakeCheckDelegate) PointToCheckedConfigReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeCheckDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.StepResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeCheckDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.StepResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -456,6 +505,37 @@ func (fake *FakeCheckDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, s
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeCheckDelegate) Skipped(arg1 lager.Logger) {
+	fake.skippedMutex.Lock()
+	fake.skippedArgsForCall = append(fake.skippedArgsForCall, struct {
+		arg1 lager.Logger
+	}{arg1})
+	fake.recordInvocation("Skipped", []interface{}{arg1})
+	fake.skippedMutex.Unlock()
+	if fake.SkippedStub != nil {
+		fake.SkippedStub(arg1)
+	}
+}
+
+func (fake *FakeCheckDelegate) SkippedCallCount() int {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	return len(fake.skippedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) SkippedCalls(stub func(lager.Logger)) {
+	fake.skippedMutex.Lock()
+	defer fake.skippedMutex.Unlock()
+	fake.SkippedStub = stub
+}
+
+func (fake *FakeCheckDelegate) SkippedArgsForCall(i int) lager.Logger {
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
+	argsForCall := fake.skippedArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeCheckDelegate) StartSpan(arg1 context.Context, arg2 string, arg3 tracing.Attrs) (context.Context, trace.Span) {
 	fake.startSpanMutex.Lock()
 	ret, specificReturn := fake.startSpanReturnsOnCall[len(fake.startSpanArgsForCall)]
@@ -723,6 +803,38 @@ func (fake *FakeCheckDelegate) WaitToRunReturnsOnCall(i int, result1 lock.Lock,
 	}{result1, result2, result3}
 }
 
+func (fake *FakeCheckDelegate) Warned(arg1 lager.Logger, arg2 string) {
+	fake.warnedMutex.Lock()
+	fake.warnedArgsForCall = append(fake.warnedArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("Warned", []interface{}{arg1, arg2})
+	fake.warnedMutex.Unlock()
+	if fake.WarnedStub != nil {
+		fake.WarnedStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeCheckDelegate) WarnedCallCount() int {
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
+	return len(fake.warnedArgsForCall)
+}
+
+func (fake *FakeCheckDelegate) WarnedCalls(stub func(lager.Logger, string)) {
+	fake.warnedMutex.Lock()
+	defer fake.warnedMutex.Unlock()
+	fake.WarnedStub = stub
+}
+
+func (fake *FakeCheckDelegate) WarnedArgsForCall(i int) (lager.Logger, string) {
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
+	argsForCall := fake.warnedArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -738,8 +850,12 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.initializingMutex.RUnlock()
 	fake.pointToCheckedConfigMutex.RLock()
 	defer fake.pointToCheckedConfigMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.skippedMutex.RLock()
+	defer fake.skippedMutex.RUnlock()
 	fake.startSpanMutex.RLock()
 	defer fake.startSpanMutex.RUnlock()
 	fake.startingMutex.RLock()
@@ -750,6 +866,8 @@ func (fake *FakeCheckDelegate) Invocations() map[string][][]interface{} {
 	defer fake.stdoutMutex.RUnlock()
 	fake.waitToRunMutex.RLock()
 	defer fake.waitToRunMutex.RUnlock()
+	fake.warnedMutex.RLock()
+	defer fake.warnedMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
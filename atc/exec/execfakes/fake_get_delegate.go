@@ -50,6 +50,12 @@ type FakeGetDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ResourceUsageStub        func(lager.Logger, atc.StepResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
@@ -269,6 +275,38 @@ func (fake *FakeGetDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeGetDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeGetDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeGetDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.StepResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeGetDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.StepResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeGetDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -545,6 +583,8 @@ func (fake *FakeGetDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
 	fake.startSpanMutex.RLock()
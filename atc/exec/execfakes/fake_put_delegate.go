@@ -50,14 +50,21 @@ type FakePutDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
-	SaveOutputStub        func(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
+	ResourceUsageStub        func(lager.Logger, atc.StepResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}
+	SaveOutputStub        func(context.Context, lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
 	saveOutputMutex       sync.RWMutex
 	saveOutputArgsForCall []struct {
-		arg1 lager.Logger
-		arg2 atc.PutPlan
-		arg3 atc.Source
-		arg4 atc.VersionedResourceTypes
-		arg5 runtime.VersionResult
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 atc.PutPlan
+		arg4 atc.Source
+		arg5 atc.VersionedResourceTypes
+		arg6 runtime.VersionResult
 	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
@@ -271,19 +278,52 @@ func (fake *FakePutDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
-func (fake *FakePutDelegate) SaveOutput(arg1 lager.Logger, arg2 atc.PutPlan, arg3 atc.Source, arg4 atc.VersionedResourceTypes, arg5 runtime.VersionResult) {
+func (fake *FakePutDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakePutDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakePutDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.StepResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakePutDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.StepResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePutDelegate) SaveOutput(arg1 context.Context, arg2 lager.Logger, arg3 atc.PutPlan, arg4 atc.Source, arg5 atc.VersionedResourceTypes, arg6 runtime.VersionResult) {
 	fake.saveOutputMutex.Lock()
 	fake.saveOutputArgsForCall = append(fake.saveOutputArgsForCall, struct {
-		arg1 lager.Logger
-		arg2 atc.PutPlan
-		arg3 atc.Source
-		arg4 atc.VersionedResourceTypes
-		arg5 runtime.VersionResult
-	}{arg1, arg2, arg3, arg4, arg5})
-	fake.recordInvocation("SaveOutput", []interface{}{arg1, arg2, arg3, arg4, arg5})
+		arg1 context.Context
+		arg2 lager.Logger
+		arg3 atc.PutPlan
+		arg4 atc.Source
+		arg5 atc.VersionedResourceTypes
+		arg6 runtime.VersionResult
+	}{arg1, arg2, arg3, arg4, arg5, arg6})
+	fake.recordInvocation("SaveOutput", []interface{}{arg1, arg2, arg3, arg4, arg5, arg6})
 	fake.saveOutputMutex.Unlock()
 	if fake.SaveOutputStub != nil {
-		fake.SaveOutputStub(arg1, arg2, arg3, arg4, arg5)
+		fake.SaveOutputStub(arg1, arg2, arg3, arg4, arg5, arg6)
 	}
 }
 
@@ -293,17 +333,17 @@ func (fake *FakePutDelegate) SaveOutputCallCount() int {
 	return len(fake.saveOutputArgsForCall)
 }
 
-func (fake *FakePutDelegate) SaveOutputCalls(stub func(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)) {
+func (fake *FakePutDelegate) SaveOutputCalls(stub func(context.Context, lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)) {
 	fake.saveOutputMutex.Lock()
 	defer fake.saveOutputMutex.Unlock()
 	fake.SaveOutputStub = stub
 }
 
-func (fake *FakePutDelegate) SaveOutputArgsForCall(i int) (lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult) {
+func (fake *FakePutDelegate) SaveOutputArgsForCall(i int) (context.Context, lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult) {
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	argsForCall := fake.saveOutputArgsForCall[i]
-	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4, argsForCall.arg5, argsForCall.arg6
 }
 
 func (fake *FakePutDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
@@ -549,6 +589,8 @@ func (fake *FakePutDelegate) Invocations() map[string][][]interface{} {
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.saveOutputMutex.RLock()
 	defer fake.saveOutputMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
@@ -37,6 +37,22 @@ type FakeTaskDelegate struct {
 		result1 worker.ImageSpec
 		result2 error
 	}
+	FetchServiceImageStub        func(context.Context, string, atc.ImageResource, atc.VersionedResourceTypes) (worker.ImageSpec, error)
+	fetchServiceImageMutex       sync.RWMutex
+	fetchServiceImageArgsForCall []struct {
+		arg1 context.Context
+		arg2 string
+		arg3 atc.ImageResource
+		arg4 atc.VersionedResourceTypes
+	}
+	fetchServiceImageReturns struct {
+		result1 worker.ImageSpec
+		result2 error
+	}
+	fetchServiceImageReturnsOnCall map[int]struct {
+		result1 worker.ImageSpec
+		result2 error
+	}
 	FinishedStub        func(lager.Logger, exec.ExitStatus)
 	finishedMutex       sync.RWMutex
 	finishedArgsForCall []struct {
@@ -48,12 +64,40 @@ type FakeTaskDelegate struct {
 	initializingArgsForCall []struct {
 		arg1 lager.Logger
 	}
+	ResourceUsageStub        func(lager.Logger, atc.StepResourceUsage)
+	resourceUsageMutex       sync.RWMutex
+	resourceUsageArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}
 	SelectedWorkerStub        func(lager.Logger, string)
 	selectedWorkerMutex       sync.RWMutex
 	selectedWorkerArgsForCall []struct {
 		arg1 lager.Logger
 		arg2 string
 	}
+	ServiceStderrStub        func(string) io.Writer
+	serviceStderrMutex       sync.RWMutex
+	serviceStderrArgsForCall []struct {
+		arg1 string
+	}
+	serviceStderrReturns struct {
+		result1 io.Writer
+	}
+	serviceStderrReturnsOnCall map[int]struct {
+		result1 io.Writer
+	}
+	ServiceStdoutStub        func(string) io.Writer
+	serviceStdoutMutex       sync.RWMutex
+	serviceStdoutArgsForCall []struct {
+		arg1 string
+	}
+	serviceStdoutReturns struct {
+		result1 io.Writer
+	}
+	serviceStdoutReturnsOnCall map[int]struct {
+		result1 io.Writer
+	}
 	SetTaskConfigStub        func(atc.TaskConfig)
 	setTaskConfigMutex       sync.RWMutex
 	setTaskConfigArgsForCall []struct {
@@ -201,6 +245,72 @@ func (fake *FakeTaskDelegate) FetchImageReturnsOnCall(i int, result1 worker.Imag
 	}{result1, result2}
 }
 
+func (fake *FakeTaskDelegate) FetchServiceImage(arg1 context.Context, arg2 string, arg3 atc.ImageResource, arg4 atc.VersionedResourceTypes) (worker.ImageSpec, error) {
+	fake.fetchServiceImageMutex.Lock()
+	ret, specificReturn := fake.fetchServiceImageReturnsOnCall[len(fake.fetchServiceImageArgsForCall)]
+	fake.fetchServiceImageArgsForCall = append(fake.fetchServiceImageArgsForCall, struct {
+		arg1 context.Context
+		arg2 string
+		arg3 atc.ImageResource
+		arg4 atc.VersionedResourceTypes
+	}{arg1, arg2, arg3, arg4})
+	fake.recordInvocation("FetchServiceImage", []interface{}{arg1, arg2, arg3, arg4})
+	fake.fetchServiceImageMutex.Unlock()
+	if fake.FetchServiceImageStub != nil {
+		return fake.FetchServiceImageStub(arg1, arg2, arg3, arg4)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.fetchServiceImageReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTaskDelegate) FetchServiceImageCallCount() int {
+	fake.fetchServiceImageMutex.RLock()
+	defer fake.fetchServiceImageMutex.RUnlock()
+	return len(fake.fetchServiceImageArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) FetchServiceImageCalls(stub func(context.Context, string, atc.ImageResource, atc.VersionedResourceTypes) (worker.ImageSpec, error)) {
+	fake.fetchServiceImageMutex.Lock()
+	defer fake.fetchServiceImageMutex.Unlock()
+	fake.FetchServiceImageStub = stub
+}
+
+func (fake *FakeTaskDelegate) FetchServiceImageArgsForCall(i int) (context.Context, string, atc.ImageResource, atc.VersionedResourceTypes) {
+	fake.fetchServiceImageMutex.RLock()
+	defer fake.fetchServiceImageMutex.RUnlock()
+	argsForCall := fake.fetchServiceImageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3, argsForCall.arg4
+}
+
+func (fake *FakeTaskDelegate) FetchServiceImageReturns(result1 worker.ImageSpec, result2 error) {
+	fake.fetchServiceImageMutex.Lock()
+	defer fake.fetchServiceImageMutex.Unlock()
+	fake.FetchServiceImageStub = nil
+	fake.fetchServiceImageReturns = struct {
+		result1 worker.ImageSpec
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTaskDelegate) FetchServiceImageReturnsOnCall(i int, result1 worker.ImageSpec, result2 error) {
+	fake.fetchServiceImageMutex.Lock()
+	defer fake.fetchServiceImageMutex.Unlock()
+	fake.FetchServiceImageStub = nil
+	if fake.fetchServiceImageReturnsOnCall == nil {
+		fake.fetchServiceImageReturnsOnCall = make(map[int]struct {
+			result1 worker.ImageSpec
+			result2 error
+		})
+	}
+	fake.fetchServiceImageReturnsOnCall[i] = struct {
+		result1 worker.ImageSpec
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTaskDelegate) Finished(arg1 lager.Logger, arg2 exec.ExitStatus) {
 	fake.finishedMutex.Lock()
 	fake.finishedArgsForCall = append(fake.finishedArgsForCall, struct {
@@ -264,6 +374,38 @@ func (fake *FakeTaskDelegate) InitializingArgsForCall(i int) lager.Logger {
 	return argsForCall.arg1
 }
 
+func (fake *FakeTaskDelegate) ResourceUsage(arg1 lager.Logger, arg2 atc.StepResourceUsage) {
+	fake.resourceUsageMutex.Lock()
+	fake.resourceUsageArgsForCall = append(fake.resourceUsageArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 atc.StepResourceUsage
+	}{arg1, arg2})
+	fake.recordInvocation("ResourceUsage", []interface{}{arg1, arg2})
+	fake.resourceUsageMutex.Unlock()
+	if fake.ResourceUsageStub != nil {
+		fake.ResourceUsageStub(arg1, arg2)
+	}
+}
+
+func (fake *FakeTaskDelegate) ResourceUsageCallCount() int {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	return len(fake.resourceUsageArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ResourceUsageCalls(stub func(lager.Logger, atc.StepResourceUsage)) {
+	fake.resourceUsageMutex.Lock()
+	defer fake.resourceUsageMutex.Unlock()
+	fake.ResourceUsageStub = stub
+}
+
+func (fake *FakeTaskDelegate) ResourceUsageArgsForCall(i int) (lager.Logger, atc.StepResourceUsage) {
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
+	argsForCall := fake.resourceUsageArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
 func (fake *FakeTaskDelegate) SelectedWorker(arg1 lager.Logger, arg2 string) {
 	fake.selectedWorkerMutex.Lock()
 	fake.selectedWorkerArgsForCall = append(fake.selectedWorkerArgsForCall, struct {
@@ -296,6 +438,126 @@ func (fake *FakeTaskDelegate) SelectedWorkerArgsForCall(i int) (lager.Logger, st
 	return argsForCall.arg1, argsForCall.arg2
 }
 
+func (fake *FakeTaskDelegate) ServiceStderr(arg1 string) io.Writer {
+	fake.serviceStderrMutex.Lock()
+	ret, specificReturn := fake.serviceStderrReturnsOnCall[len(fake.serviceStderrArgsForCall)]
+	fake.serviceStderrArgsForCall = append(fake.serviceStderrArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ServiceStderr", []interface{}{arg1})
+	fake.serviceStderrMutex.Unlock()
+	if fake.ServiceStderrStub != nil {
+		return fake.ServiceStderrStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.serviceStderrReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ServiceStderrCallCount() int {
+	fake.serviceStderrMutex.RLock()
+	defer fake.serviceStderrMutex.RUnlock()
+	return len(fake.serviceStderrArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ServiceStderrCalls(stub func(string) io.Writer) {
+	fake.serviceStderrMutex.Lock()
+	defer fake.serviceStderrMutex.Unlock()
+	fake.ServiceStderrStub = stub
+}
+
+func (fake *FakeTaskDelegate) ServiceStderrArgsForCall(i int) string {
+	fake.serviceStderrMutex.RLock()
+	defer fake.serviceStderrMutex.RUnlock()
+	argsForCall := fake.serviceStderrArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskDelegate) ServiceStderrReturns(result1 io.Writer) {
+	fake.serviceStderrMutex.Lock()
+	defer fake.serviceStderrMutex.Unlock()
+	fake.ServiceStderrStub = nil
+	fake.serviceStderrReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ServiceStderrReturnsOnCall(i int, result1 io.Writer) {
+	fake.serviceStderrMutex.Lock()
+	defer fake.serviceStderrMutex.Unlock()
+	fake.ServiceStderrStub = nil
+	if fake.serviceStderrReturnsOnCall == nil {
+		fake.serviceStderrReturnsOnCall = make(map[int]struct {
+			result1 io.Writer
+		})
+	}
+	fake.serviceStderrReturnsOnCall[i] = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ServiceStdout(arg1 string) io.Writer {
+	fake.serviceStdoutMutex.Lock()
+	ret, specificReturn := fake.serviceStdoutReturnsOnCall[len(fake.serviceStdoutArgsForCall)]
+	fake.serviceStdoutArgsForCall = append(fake.serviceStdoutArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ServiceStdout", []interface{}{arg1})
+	fake.serviceStdoutMutex.Unlock()
+	if fake.ServiceStdoutStub != nil {
+		return fake.ServiceStdoutStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.serviceStdoutReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeTaskDelegate) ServiceStdoutCallCount() int {
+	fake.serviceStdoutMutex.RLock()
+	defer fake.serviceStdoutMutex.RUnlock()
+	return len(fake.serviceStdoutArgsForCall)
+}
+
+func (fake *FakeTaskDelegate) ServiceStdoutCalls(stub func(string) io.Writer) {
+	fake.serviceStdoutMutex.Lock()
+	defer fake.serviceStdoutMutex.Unlock()
+	fake.ServiceStdoutStub = stub
+}
+
+func (fake *FakeTaskDelegate) ServiceStdoutArgsForCall(i int) string {
+	fake.serviceStdoutMutex.RLock()
+	defer fake.serviceStdoutMutex.RUnlock()
+	argsForCall := fake.serviceStdoutArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTaskDelegate) ServiceStdoutReturns(result1 io.Writer) {
+	fake.serviceStdoutMutex.Lock()
+	defer fake.serviceStdoutMutex.Unlock()
+	fake.ServiceStdoutStub = nil
+	fake.serviceStdoutReturns = struct {
+		result1 io.Writer
+	}{result1}
+}
+
+func (fake *FakeTaskDelegate) ServiceStdoutReturnsOnCall(i int, result1 io.Writer) {
+	fake.serviceStdoutMutex.Lock()
+	defer fake.serviceStdoutMutex.Unlock()
+	fake.ServiceStdoutStub = nil
+	if fake.serviceStdoutReturnsOnCall == nil {
+		fake.serviceStdoutReturnsOnCall = make(map[int]struct {
+			result1 io.Writer
+		})
+	}
+	fake.serviceStdoutReturnsOnCall[i] = struct {
+		result1 io.Writer
+	}{result1}
+}
+
 func (fake *FakeTaskDelegate) SetTaskConfig(arg1 atc.TaskConfig) {
 	fake.setTaskConfigMutex.Lock()
 	fake.setTaskConfigArgsForCall = append(fake.setTaskConfigArgsForCall, struct {
@@ -534,12 +796,20 @@ func (fake *FakeTaskDelegate) Invocations() map[string][][]interface{} {
 	defer fake.erroredMutex.RUnlock()
 	fake.fetchImageMutex.RLock()
 	defer fake.fetchImageMutex.RUnlock()
+	fake.fetchServiceImageMutex.RLock()
+	defer fake.fetchServiceImageMutex.RUnlock()
 	fake.finishedMutex.RLock()
 	defer fake.finishedMutex.RUnlock()
 	fake.initializingMutex.RLock()
 	defer fake.initializingMutex.RUnlock()
+	fake.resourceUsageMutex.RLock()
+	defer fake.resourceUsageMutex.RUnlock()
 	fake.selectedWorkerMutex.RLock()
 	defer fake.selectedWorkerMutex.RUnlock()
+	fake.serviceStderrMutex.RLock()
+	defer fake.serviceStderrMutex.RUnlock()
+	fake.serviceStdoutMutex.RLock()
+	defer fake.serviceStdoutMutex.RUnlock()
 	fake.setTaskConfigMutex.RLock()
 	defer fake.setTaskConfigMutex.RUnlock()
 	fake.startSpanMutex.RLock()
@@ -57,6 +57,8 @@ type GetDelegate interface {
 	Errored(lager.Logger, string)
 
 	UpdateVersion(lager.Logger, atc.GetPlan, runtime.VersionResult)
+
+	ResourceUsage(lager.Logger, atc.StepResourceUsage)
 }
 
 // GetStep will fetch a version of a resource on a worker that supports the
@@ -158,6 +160,7 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		}
 	} else {
 		imageSpec.ResourceType = step.plan.Type
+		tracing.SetAttrs(ctx, tracing.Attrs{"base_resource_type": step.plan.Type})
 	}
 
 	resourceTypes, err := creds.NewVersionedResourceTypes(state, step.plan.VersionedResourceTypes).Evaluate()
@@ -222,6 +225,10 @@ func (step *GetStep) run(ctx context.Context, state RunState, delegate GetDelega
 		return false, err
 	}
 
+	if getResult.ResourceUsage != nil {
+		delegate.ResourceUsage(logger, stepResourceUsage(step.planID, step.plan.Name, "get", getResult.ResourceUsage))
+	}
+
 	var succeeded bool
 	if getResult.ExitStatus == 0 {
 		state.StoreResult(step.planID, resourceCache)
@@ -3,6 +3,7 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
@@ -503,11 +504,44 @@ var _ = Describe("GetStep", func() {
 			})
 		})
 
+		It("does not report resource usage when none was measured", func() {
+			Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(0))
+		})
+
 		It("does not return an err", func() {
 			Expect(getStepErr).ToNot(HaveOccurred())
 		})
 	})
 
+	Context("when the worker measured the resource usage of the step", func() {
+		BeforeEach(func() {
+			fakeClient.RunGetStepReturns(
+				worker.GetResult{
+					ExitStatus: 1,
+					ResourceUsage: &worker.ResourceUsage{
+						CPU:        2 * time.Second,
+						PeakMemory: 1024,
+						Disk:       2048,
+						Wall:       10 * time.Second,
+					},
+				}, nil)
+		})
+
+		It("reports the resource usage via the delegate", func() {
+			Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+			_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+			Expect(usage).To(Equal(atc.StepResourceUsage{
+				PlanID:          atc.PlanID(planID),
+				StepName:        getPlan.Name,
+				StepType:        "get",
+				CPUSeconds:      2,
+				PeakMemoryBytes: 1024,
+				DiskBytes:       2048,
+				WallSeconds:     10,
+			}))
+		})
+	})
+
 	Context("when Client.RunGetStep returns a Failed GetResult", func() {
 		BeforeEach(func() {
 			fakeClient.RunGetStepReturns(
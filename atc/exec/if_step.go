@@ -0,0 +1,59 @@
+package exec
+
+import (
+	"context"
+	"fmt"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+)
+
+// IfStep runs a step only if its condition holds, evaluated against the
+// build's vars when the step is run.
+type IfStep struct {
+	step      Step
+	condition string
+
+	skippedDelegateFactories []BuildStepDelegateFactory
+}
+
+// If constructs an IfStep. When the condition does not hold, each of the
+// delegates is notified that its step was skipped.
+func If(step Step, condition string, skippedDelegateFactories []BuildStepDelegateFactory) *IfStep {
+	return &IfStep{
+		step:      step,
+		condition: condition,
+
+		skippedDelegateFactories: skippedDelegateFactories,
+	}
+}
+
+// Run evaluates the condition and invokes the nested step if it holds.
+//
+// A skipped step succeeds, so that the steps following it still run.
+func (is *IfStep) Run(ctx context.Context, state RunState) (bool, error) {
+	logger := lagerctx.FromContext(ctx).Session("if", lager.Data{
+		"condition": is.condition,
+	})
+
+	condition, err := atc.ParseStepCondition(is.condition)
+	if err != nil {
+		return false, fmt.Errorf("invalid condition '%s': %w", is.condition, err)
+	}
+
+	holds, err := condition.Evaluate(state)
+	if err != nil {
+		return false, fmt.Errorf("evaluate condition '%s': %w", is.condition, err)
+	}
+
+	if holds {
+		return is.step.Run(ctx, state)
+	}
+
+	for _, delegateFactory := range is.skippedDelegateFactories {
+		delegateFactory.BuildStepDelegate(state).Skipped(logger)
+	}
+
+	return true, nil
+}
@@ -0,0 +1,148 @@
+package exec_test
+
+import (
+	"context"
+	"errors"
+
+	. "github.com/concourse/concourse/atc/exec"
+	"github.com/concourse/concourse/atc/exec/execfakes"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("If Step", func() {
+	var (
+		ctx    context.Context
+		cancel func()
+
+		runStep *execfakes.FakeStep
+
+		state *execfakes.FakeRunState
+
+		fakeDelegate        *execfakes.FakeBuildStepDelegate
+		fakeDelegateFactory *execfakes.FakeBuildStepDelegateFactory
+
+		condition string
+
+		stepOk  bool
+		stepErr error
+	)
+
+	BeforeEach(func() {
+		ctx, cancel = context.WithCancel(context.Background())
+
+		runStep = new(execfakes.FakeStep)
+		runStep.RunReturns(true, nil)
+
+		state = new(execfakes.FakeRunState)
+		state.GetStub = func(ref vars.Reference) (interface{}, bool, error) {
+			switch ref.Path {
+			case "release":
+				return "major", true, nil
+			case "dry_run":
+				return false, true, nil
+			default:
+				return nil, false, nil
+			}
+		}
+
+		fakeDelegate = new(execfakes.FakeBuildStepDelegate)
+		fakeDelegateFactory = new(execfakes.FakeBuildStepDelegateFactory)
+		fakeDelegateFactory.BuildStepDelegateReturns(fakeDelegate)
+	})
+
+	JustBeforeEach(func() {
+		step := If(runStep, condition, []BuildStepDelegateFactory{fakeDelegateFactory})
+		stepOk, stepErr = step.Run(ctx, state)
+	})
+
+	AfterEach(func() {
+		cancel()
+	})
+
+	Context("when the condition holds", func() {
+		BeforeEach(func() {
+			condition = `((.:release)) == "major" && !((.:dry_run))`
+		})
+
+		It("runs the inner step", func() {
+			Expect(runStep.RunCallCount()).To(Equal(1))
+
+			runCtx, runState := runStep.RunArgsForCall(0)
+			Expect(runCtx).To(Equal(ctx))
+			Expect(runState).To(Equal(state))
+		})
+
+		It("does not mark any step as skipped", func() {
+			Expect(fakeDelegate.SkippedCallCount()).To(BeZero())
+		})
+
+		Context("when the inner step fails", func() {
+			BeforeEach(func() {
+				runStep.RunReturns(false, nil)
+			})
+
+			It("fails", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeFalse())
+			})
+		})
+
+		Context("when the inner step errors", func() {
+			disaster := errors.New("nope")
+
+			BeforeEach(func() {
+				runStep.RunReturns(false, disaster)
+			})
+
+			It("returns the error", func() {
+				Expect(stepErr).To(Equal(disaster))
+			})
+		})
+	})
+
+	Context("when the condition does not hold", func() {
+		BeforeEach(func() {
+			condition = `((.:release)) != "major"`
+		})
+
+		It("does not run the inner step", func() {
+			Expect(runStep.RunCallCount()).To(BeZero())
+		})
+
+		It("marks the steps within as skipped", func() {
+			Expect(fakeDelegateFactory.BuildStepDelegateCallCount()).To(Equal(1))
+			Expect(fakeDelegateFactory.BuildStepDelegateArgsForCall(0)).To(Equal(state))
+			Expect(fakeDelegate.SkippedCallCount()).To(Equal(1))
+		})
+
+		It("succeeds", func() {
+			Expect(stepErr).ToNot(HaveOccurred())
+			Expect(stepOk).To(BeTrue())
+		})
+	})
+
+	Context("when the condition references an undefined var", func() {
+		BeforeEach(func() {
+			condition = `((.:missing))`
+		})
+
+		It("errors without running the inner step", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("undefined vars: .:missing")))
+			Expect(stepOk).To(BeFalse())
+			Expect(runStep.RunCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when the condition is invalid", func() {
+		BeforeEach(func() {
+			condition = `((.:release)) ==`
+		})
+
+		It("errors without running the inner step", func() {
+			Expect(stepErr).To(MatchError(ContainSubstring("invalid condition")))
+			Expect(runStep.RunCallCount()).To(BeZero())
+		})
+	})
+})
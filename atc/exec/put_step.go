@@ -38,7 +38,9 @@ type PutDelegate interface {
 	SelectedWorker(lager.Logger, string)
 	Errored(lager.Logger, string)
 
-	SaveOutput(lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
+	SaveOutput(context.Context, lager.Logger, atc.PutPlan, atc.Source, atc.VersionedResourceTypes, runtime.VersionResult)
+
+	ResourceUsage(lager.Logger, atc.StepResourceUsage)
 }
 
 // PutStep produces a resource version using preconfigured params and any data
@@ -175,6 +177,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		}
 	} else {
 		imageSpec.ResourceType = step.plan.Type
+		tracing.SetAttrs(ctx, tracing.Attrs{"base_resource_type": step.plan.Type})
 	}
 
 	containerSpec := worker.ContainerSpec{
@@ -221,6 +224,10 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 		return false, err
 	}
 
+	if result.ResourceUsage != nil {
+		delegate.ResourceUsage(logger, stepResourceUsage(step.planID, step.plan.Name, "put", result.ResourceUsage))
+	}
+
 	if result.ExitStatus != 0 {
 		delegate.Finished(logger, ExitStatus(result.ExitStatus), runtime.VersionResult{})
 		return false, nil
@@ -230,7 +237,7 @@ func (step *PutStep) run(ctx context.Context, state RunState, delegate PutDelega
 	// step.plan.Resource maps to an actual resource that may have been used outside of a pipeline context.
 	// Hence, if it was used outside the pipeline context, we don't want to save the output.
 	if step.plan.Resource != "" {
-		delegate.SaveOutput(logger, step.plan, source, resourceTypes, versionResult)
+		delegate.SaveOutput(ctx, logger, step.plan, source, resourceTypes, versionResult)
 	}
 
 	state.StoreResult(step.planID, versionResult)
@@ -3,6 +3,7 @@ package exec_test
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/concourse/concourse/tracing"
 	. "github.com/onsi/ginkgo"
@@ -80,6 +81,7 @@ var _ = Describe("PutStep", func() {
 		versionResult  runtime.VersionResult
 		clientErr      error
 		someExitStatus int
+		resourceUsage  *worker.ResourceUsage
 	)
 
 	BeforeEach(func() {
@@ -188,6 +190,7 @@ var _ = Describe("PutStep", func() {
 
 		someExitStatus = 0
 		clientErr = nil
+		resourceUsage = nil
 	})
 
 	AfterEach(func() {
@@ -201,7 +204,7 @@ var _ = Describe("PutStep", func() {
 		}
 
 		fakeClient.RunPutStepReturns(
-			worker.PutResult{ExitStatus: someExitStatus, VersionResult: versionResult},
+			worker.PutResult{ExitStatus: someExitStatus, VersionResult: versionResult, ResourceUsage: resourceUsage},
 			clientErr,
 		)
 
@@ -523,7 +526,7 @@ var _ = Describe("PutStep", func() {
 	It("saves the build output", func() {
 		Expect(fakeDelegate.SaveOutputCallCount()).To(Equal(1))
 
-		_, plan, actualSource, actualResourceTypes, info := fakeDelegate.SaveOutputArgsForCall(0)
+		_, _, plan, actualSource, actualResourceTypes, info := fakeDelegate.SaveOutputArgsForCall(0)
 		Expect(plan.Name).To(Equal("some-name"))
 		Expect(plan.Type).To(Equal("some-resource-type"))
 		Expect(plan.Resource).To(Equal("some-resource"))
@@ -566,6 +569,35 @@ var _ = Describe("PutStep", func() {
 		It("is successful", func() {
 			Expect(stepOk).To(BeTrue())
 		})
+
+		It("does not report resource usage when none was measured", func() {
+			Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(0))
+		})
+
+		Context("when the worker measured the resource usage of the step", func() {
+			BeforeEach(func() {
+				resourceUsage = &worker.ResourceUsage{
+					CPU:        2 * time.Second,
+					PeakMemory: 1024,
+					Disk:       2048,
+					Wall:       10 * time.Second,
+				}
+			})
+
+			It("reports the resource usage via the delegate", func() {
+				Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+				_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+				Expect(usage).To(Equal(atc.StepResourceUsage{
+					PlanID:          planID,
+					StepName:        "some-name",
+					StepType:        "put",
+					CPUSeconds:      2,
+					PeakMemoryBytes: 1024,
+					DiskBytes:       2048,
+					WallSeconds:     10,
+				}))
+			})
+		})
 	})
 
 	Context("when RunPutStep exits unsuccessfully", func() {
@@ -0,0 +1,18 @@
+package exec
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/worker"
+)
+
+func stepResourceUsage(planID atc.PlanID, name string, stepType string, usage *worker.ResourceUsage) atc.StepResourceUsage {
+	return atc.StepResourceUsage{
+		PlanID:          planID,
+		StepName:        name,
+		StepType:        stepType,
+		CPUSeconds:      usage.CPU.Seconds(),
+		PeakMemoryBytes: usage.PeakMemory,
+		DiskBytes:       usage.Disk,
+		WallSeconds:     usage.Wall.Seconds(),
+	}
+}
@@ -145,6 +145,11 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, nil
 	}
 
+	atcConfig, err = atcConfig.ExpandJobMatrices()
+	if err != nil {
+		return false, err
+	}
+
 	var team db.Team
 	if step.plan.Team == "" {
 		team = step.teamFactory.GetByID(step.metadata.TeamID)
@@ -227,6 +232,7 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 	}
 
 	// conditionally check step
+	var policyWarnings []string
 	if step.policyChecker != nil && step.policyChecker.ShouldCheckAction(ActionRunSetPipeline) {
 		input := policy.PolicyCheckInput{
 			Action:   ActionRunSetPipeline,
@@ -242,6 +248,11 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 			return false, fmt.Errorf("policy check failed for set_pipeline: %s", strings.Join(result.Reasons, ", "))
 		}
 		logger.Debug("policy check passed for set_pipeline")
+
+		policyWarnings = result.Warnings
+		for _, warning := range policyWarnings {
+			delegate.Warned(logger, fmt.Sprintf("policy check warning: %s", warning))
+		}
 	}
 
 	fmt.Fprintf(stdout, "setting pipeline: %s\n", pipelineRef.String())
@@ -256,7 +267,7 @@ func (step *SetPipelineStep) run(ctx context.Context, state RunState, delegate S
 		return false, fmt.Errorf("set_pipeline step not attached to a buildID")
 	}
 
-	pipeline, _, err = parentBuild.SavePipeline(pipelineRef, team.ID(), atcConfig, fromVersion, false)
+	pipeline, _, err = parentBuild.SavePipeline(pipelineRef, team.ID(), atcConfig, fromVersion, false, policyWarnings)
 	if err != nil {
 		if err == db.ErrSetByNewerBuild {
 			fmt.Fprintln(stderr, "\x1b[1;33mWARNING: the pipeline was not saved because it was already saved by a newer build\x1b[0m")
@@ -187,7 +187,7 @@ jobs:
 		fakeAgent.CheckReturns(policy.PassedPolicyCheck(), nil)
 		fakePolicyAgentFactory.NewAgentReturns(fakeAgent, nil)
 
-		fakeChecker, _ = policy.Initialize(testLogger, "some-cluster", "some-version", filter)
+		fakeChecker, _ = policy.Initialize(testLogger, "some-cluster", "some-version", filter, false)
 
 		fakeWorkerClient = new(workerfakes.FakeClient)
 
@@ -310,7 +310,7 @@ jobs:
 
 				It("should save the pipeline", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					ref, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
+					ref, _, _, _, paused, _ := fakeBuild.SavePipelineArgsForCall(0)
 					Expect(ref).To(Equal(atc.PipelineRef{
 						Name:         "some-pipeline",
 						InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
@@ -396,7 +396,7 @@ jobs:
 
 				It("should save the pipeline un-paused", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					ref, _, _, _, paused := fakeBuild.SavePipelineArgsForCall(0)
+					ref, _, _, _, paused, _ := fakeBuild.SavePipelineArgsForCall(0)
 					Expect(ref).To(Equal(atc.PipelineRef{
 						Name:         "some-pipeline",
 						InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
@@ -429,7 +429,7 @@ jobs:
 
 				It("should save the pipeline itself", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					pipelineRef, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+					pipelineRef, _, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
 					Expect(pipelineRef).To(Equal(atc.PipelineRef{
 						Name:         "some-pipeline",
 						InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
@@ -438,7 +438,7 @@ jobs:
 
 				It("should save to the current team", func() {
 					Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
-					_, teamId, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+					_, teamId, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
 					Expect(teamId).To(Equal(fakeTeam.ID()))
 				})
 
@@ -511,7 +511,7 @@ jobs:
 						})
 
 						It("should finish successfully", func() {
-							_, teamID, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+							_, teamID, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
 							Expect(teamID).To(Equal(fakeUserCurrentTeam.ID()))
 							Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
 							_, succeeded := fakeDelegate.FinishedArgsForCall(0)
@@ -543,7 +543,7 @@ jobs:
 							})
 
 							It("should finish successfully", func() {
-								_, teamID, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+								_, teamID, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
 								Expect(teamID).To(Equal(fakeTeam.ID()))
 								Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
 								_, succeeded := fakeDelegate.FinishedArgsForCall(0)
@@ -598,12 +598,45 @@ jobs:
 					})
 
 					It("should finish successfully", func() {
-						_, teamID, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
+						_, teamID, _, _, _, _ := fakeBuild.SavePipelineArgsForCall(0)
 						Expect(teamID).To(Equal(fakeTeam.ID()))
 						Expect(fakeDelegate.FinishedCallCount()).To(Equal(1))
 						_, succeeded := fakeDelegate.FinishedArgsForCall(0)
 						Expect(succeeded).To(BeTrue())
 					})
+
+					It("should clear the policy warnings of the pipeline", func() {
+						_, _, _, _, _, policyWarnings := fakeBuild.SavePipelineArgsForCall(0)
+						Expect(policyWarnings).To(BeEmpty())
+						Expect(fakeDelegate.WarnedCallCount()).To(Equal(0))
+					})
+				})
+
+				Context("policy check succeeds with warnings", func() {
+					BeforeEach(func() {
+						fakeAgent.CheckReturns(policy.PolicyCheckOutput{
+							Allowed:  true,
+							Warnings: []string{"foo", "bar"},
+						}, nil)
+						fakeBuild.PipelineReturns(fakePipeline, true, nil)
+						fakeBuild.SavePipelineReturns(fakePipeline, false, nil)
+						spPlan.Team = ""
+					})
+
+					It("should save the pipeline and its policy warnings", func() {
+						Expect(stepErr).ToNot(HaveOccurred())
+						Expect(fakeBuild.SavePipelineCallCount()).To(Equal(1))
+						_, _, _, _, _, policyWarnings := fakeBuild.SavePipelineArgsForCall(0)
+						Expect(policyWarnings).To(Equal([]string{"foo", "bar"}))
+					})
+
+					It("should report the warnings", func() {
+						Expect(fakeDelegate.WarnedCallCount()).To(Equal(2))
+						_, message := fakeDelegate.WarnedArgsForCall(0)
+						Expect(message).To(Equal("policy check warning: foo"))
+						_, message = fakeDelegate.WarnedArgsForCall(1)
+						Expect(message).To(Equal("policy check warning: bar"))
+					})
 				})
 			})
 		})
@@ -16,6 +16,7 @@ type StepMetadata struct {
 	PipelineName         string
 	PipelineInstanceVars map[string]interface{}
 	ExternalURL          string
+	Priority             int
 }
 
 func (metadata StepMetadata) Env() []string {
@@ -0,0 +1,135 @@
+package exec
+
+import (
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"strings"
+	"text/template"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/exec/build"
+)
+
+// keyedTaskCache is a task cache stored under a key, along with the volume of
+// the cache it is restored from, if any.
+type keyedTaskCache struct {
+	path        string
+	key         string
+	restoredKey string
+	handle      string
+}
+
+// findKeyedCaches evaluates the keys of the task's keyed caches and looks up
+// the caches to restore them from.
+func (step *TaskStep) findKeyedCaches(ctx context.Context, logger lager.Logger, repository *build.Repository, config atc.TaskConfig) ([]keyedTaskCache, error) {
+	var caches []keyedTaskCache
+	for _, cacheConfig := range config.Caches {
+		if cacheConfig.Key == "" {
+			continue
+		}
+
+		key, err := step.evaluateCacheKey(ctx, logger, repository, cacheConfig.Key)
+		if err != nil {
+			return nil, err
+		}
+
+		var restoreKeys []string
+		for _, restoreKey := range cacheConfig.RestoreKeys {
+			evaluated, err := step.evaluateCacheKey(ctx, logger, repository, restoreKey)
+			if err != nil {
+				return nil, err
+			}
+
+			restoreKeys = append(restoreKeys, evaluated)
+		}
+
+		cache := keyedTaskCache{
+			path: cacheConfig.Path,
+			key:  key,
+		}
+
+		volume, restoredKey, found, err := step.keyedCacheFactory.FindCacheVolume(step.metadata.TeamID, key, restoreKeys)
+		if err != nil {
+			return nil, err
+		}
+
+		if found {
+			cache.restoredKey = restoredKey
+			cache.handle = volume.Handle()
+		}
+
+		logger.Debug("found-keyed-cache", lager.Data{
+			"path":     cache.path,
+			"key":      cache.key,
+			"restored": cache.restoredKey,
+		})
+
+		caches = append(caches, cache)
+	}
+
+	return caches, nil
+}
+
+// evaluateCacheKey renders a cache key template. The template may call
+// hashFiles with paths of files within the build's artifacts, e.g.
+// 'repo/go.sum', to key the cache by the content of those files.
+func (step *TaskStep) evaluateCacheKey(ctx context.Context, logger lager.Logger, repository *build.Repository, key string) (string, error) {
+	tmpl, err := template.New("key").
+		Option("missingkey=error").
+		Funcs(template.FuncMap{
+			"hashFiles": func(paths ...string) (string, error) {
+				return step.hashFiles(ctx, logger, repository, paths)
+			},
+		}).
+		Parse(key)
+	if err != nil {
+		return "", fmt.Errorf("invalid cache key '%s': %w", key, err)
+	}
+
+	var rendered strings.Builder
+	err = tmpl.Execute(&rendered, nil)
+	if err != nil {
+		return "", fmt.Errorf("evaluate cache key '%s': %w", key, err)
+	}
+
+	return rendered.String(), nil
+}
+
+func (step *TaskStep) hashFiles(ctx context.Context, logger lager.Logger, repository *build.Repository, paths []string) (string, error) {
+	hash := sha256.New()
+
+	for _, path := range paths {
+		segs := strings.SplitN(path, "/", 2)
+		if len(segs) != 2 {
+			return "", fmt.Errorf("path '%s' does not specify which artifact the file lives in", path)
+		}
+
+		sourceName := build.ArtifactName(segs[0])
+
+		artifact, found := repository.ArtifactFor(sourceName)
+		if !found {
+			return "", fmt.Errorf("unknown artifact '%s' in path '%s'", sourceName, path)
+		}
+
+		stream, err := step.workerClient.StreamFileFromArtifact(ctx, logger, artifact, segs[1])
+		if err != nil {
+			if err == baggageclaim.ErrFileNotFound {
+				return "", fmt.Errorf("file '%s' not found", path)
+			}
+
+			return "", err
+		}
+
+		_, err = io.Copy(hash, stream)
+		stream.Close()
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
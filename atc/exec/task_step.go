@@ -2,15 +2,19 @@ package exec
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -50,6 +54,19 @@ func (err TaskImageSourceParametersError) Error() string {
 	return fmt.Sprintf("failed to evaluate image resource parameters: %s", err.Err)
 }
 
+// ErrNoArtifactStore is returned when a task specifies artifacts to upload
+// but no artifact store has been configured.
+var ErrNoArtifactStore = errors.New("no artifact store configured")
+
+type UnknownArtifactOutputError struct {
+	Artifact string
+	Output   string
+}
+
+func (err UnknownArtifactOutputError) Error() string {
+	return fmt.Sprintf("artifact %s refers to unknown output '%s'", err.Artifact, err.Output)
+}
+
 //go:generate counterfeiter . TaskDelegateFactory
 
 type TaskDelegateFactory interface {
@@ -62,10 +79,14 @@ type TaskDelegate interface {
 	StartSpan(context.Context, string, tracing.Attrs) (context.Context, trace.Span)
 
 	FetchImage(context.Context, atc.ImageResource, atc.VersionedResourceTypes, bool) (worker.ImageSpec, error)
+	FetchServiceImage(context.Context, string, atc.ImageResource, atc.VersionedResourceTypes) (worker.ImageSpec, error)
 
 	Stdout() io.Writer
 	Stderr() io.Writer
 
+	ServiceStdout(string) io.Writer
+	ServiceStderr(string) io.Writer
+
 	SetTaskConfig(config atc.TaskConfig)
 
 	Initializing(lager.Logger)
@@ -73,6 +94,8 @@ type TaskDelegate interface {
 	Finished(lager.Logger, ExitStatus)
 	SelectedWorker(lager.Logger, string)
 	Errored(lager.Logger, string)
+
+	ResourceUsage(lager.Logger, atc.StepResourceUsage)
 }
 
 // TaskStep executes a TaskConfig, whose inputs will be fetched from the
@@ -87,6 +110,10 @@ type TaskStep struct {
 	workerClient      worker.Client
 	delegateFactory   TaskDelegateFactory
 	lockFactory       lock.LockFactory
+	keyedCacheFactory db.KeyedCacheFactory
+
+	artifactStore         blobstore.Store
+	storedArtifactFactory db.StoredArtifactFactory
 }
 
 func NewTaskStep(
@@ -99,6 +126,9 @@ func NewTaskStep(
 	workerClient worker.Client,
 	delegateFactory TaskDelegateFactory,
 	lockFactory lock.LockFactory,
+	keyedCacheFactory db.KeyedCacheFactory,
+	artifactStore blobstore.Store,
+	storedArtifactFactory db.StoredArtifactFactory,
 ) Step {
 	return &TaskStep{
 		planID:            planID,
@@ -110,6 +140,10 @@ func NewTaskStep(
 		workerClient:      workerClient,
 		delegateFactory:   delegateFactory,
 		lockFactory:       lockFactory,
+		keyedCacheFactory: keyedCacheFactory,
+
+		artifactStore:         artifactStore,
+		storedArtifactFactory: storedArtifactFactory,
 	}
 }
 
@@ -128,6 +162,9 @@ func NewTaskStep(
 // are registered with the artifact.Repository. If no outputs are specified, the
 // task's entire working directory is registered as an StreamableArtifactSource under the
 // name of the task.
+//
+// If the script exits successfully, any artifacts specified in the plan are
+// uploaded from the outputs to the artifact store.
 func (step *TaskStep) Run(ctx context.Context, state RunState) (bool, error) {
 	delegate := step.delegateFactory.TaskDelegate(state)
 	ctx, span := delegate.StartSpan(ctx, "task", tracing.Attrs{
@@ -222,7 +259,17 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		return false, err
 	}
 
-	containerSpec, err := step.containerSpec(state, imageSpec, config, step.containerMetadata)
+	keyedCaches, err := step.findKeyedCaches(ctx, logger, repository, config)
+	if err != nil {
+		return false, err
+	}
+
+	containerSpec, err := step.containerSpec(state, imageSpec, config, keyedCaches, step.containerMetadata)
+	if err != nil {
+		return false, err
+	}
+
+	containerSpec.Services, err = step.serviceSpecs(ctx, delegate, config)
 	if err != nil {
 		return false, err
 	}
@@ -251,6 +298,10 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		step.lockFactory,
 	)
 
+	if result.ResourceUsage != nil {
+		delegate.ResourceUsage(logger, stepResourceUsage(step.planID, step.plan.Name, "task", result.ResourceUsage))
+	}
+
 	if err != nil {
 		if err == context.Canceled || err == context.DeadlineExceeded {
 			step.registerOutputs(logger, repository, config, result.VolumeMounts, step.containerMetadata)
@@ -270,6 +321,19 @@ func (step *TaskStep) run(ctx context.Context, state RunState, delegate TaskDele
 		}
 	}
 
+	// Only store keyed caches of successful tasks, as they are shared
+	if result.ExitStatus == 0 {
+		err = step.registerKeyedCaches(logger, keyedCaches, result.VolumeMounts, step.containerMetadata)
+		if err != nil {
+			return false, err
+		}
+
+		err = step.uploadArtifacts(ctx, logger, config, result.VolumeMounts, step.containerMetadata)
+		if err != nil {
+			return false, err
+		}
+	}
+
 	return result.ExitStatus == 0, nil
 }
 
@@ -287,6 +351,7 @@ func (step *TaskStep) imageSpec(ctx context.Context, state RunState, delegate Ta
 		}
 
 		imageSpec.ImageArtifact = art
+		tracing.SetAttrs(ctx, tracing.Attrs{"image_artifact": step.plan.ImageArtifactName})
 
 		//an image_resource
 	} else if config.ImageResource != nil {
@@ -305,6 +370,7 @@ func (step *TaskStep) imageSpec(ctx context.Context, state RunState, delegate Ta
 		// a rootfs_uri
 	} else if config.RootfsURI != "" {
 		imageSpec.ImageURL = config.RootfsURI
+		tracing.SetAttrs(ctx, tracing.Attrs{"image_url": config.RootfsURI})
 	}
 
 	return imageSpec, nil
@@ -342,6 +408,10 @@ func (step *TaskStep) containerInputs(repository *build.Repository, config atc.T
 	}
 
 	for _, cacheConfig := range config.Caches {
+		if cacheConfig.Key != "" {
+			continue
+		}
+
 		cacheArt := &runtime.CacheArtifact{
 			TeamID:   step.metadata.TeamID,
 			JobID:    step.metadata.JobID,
@@ -359,7 +429,7 @@ func (step *TaskStep) containerInputs(repository *build.Repository, config atc.T
 	return inputs, nil
 }
 
-func (step *TaskStep) containerSpec(state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
+func (step *TaskStep) containerSpec(state RunState, imageSpec worker.ImageSpec, config atc.TaskConfig, keyedCaches []keyedTaskCache, metadata db.ContainerMetadata) (worker.ContainerSpec, error) {
 	var limits worker.ContainerLimits
 	if config.Limits != nil {
 		limits.CPU = (*uint64)(config.Limits.CPU)
@@ -374,6 +444,7 @@ func (step *TaskStep) containerSpec(state RunState, imageSpec worker.ImageSpec,
 		Dir:       metadata.WorkingDirectory,
 		Env:       config.Params.Env(),
 		Type:      metadata.Type,
+		Network:   config.Network,
 
 		Outputs: worker.OutputPaths{},
 	}
@@ -389,15 +460,131 @@ func (step *TaskStep) containerSpec(state RunState, imageSpec worker.ImageSpec,
 		containerSpec.Outputs[output.Name] = path
 	}
 
+	// restore keyed caches from the volume they were found in, which may be
+	// streamed from another worker, or start them off empty
+	for _, cache := range keyedCaches {
+		path := filepath.Join(metadata.WorkingDirectory, cache.path)
+		if cache.handle != "" {
+			containerSpec.ArtifactByPath[path] = &runtime.TaskArtifact{
+				VolumeHandle: cache.handle,
+			}
+		} else {
+			containerSpec.Outputs["cache:"+cache.path] = path
+		}
+	}
+
 	return containerSpec, nil
 }
 
+// serviceSpecs fetches the images of the task's services and describes the
+// containers to run them in, owned by the step under a plan ID of their own.
+func (step *TaskStep) serviceSpecs(ctx context.Context, delegate TaskDelegate, config atc.TaskConfig) ([]worker.ServiceSpec, error) {
+	var specs []worker.ServiceSpec
+
+	for _, service := range config.Services {
+		image := *service.ImageResource
+		if len(image.Tags) == 0 {
+			image.Tags = step.plan.Tags
+		}
+
+		imageSpec, err := delegate.FetchServiceImage(ctx, service.Name, image, step.plan.VersionedResourceTypes)
+		if err != nil {
+			return nil, fmt.Errorf("fetch image of service %s: %w", service.Name, err)
+		}
+
+		readiness, err := serviceReadiness(service)
+		if err != nil {
+			return nil, err
+		}
+
+		planID := step.planID + "/services/" + atc.PlanID(service.Name)
+
+		specs = append(specs, worker.ServiceSpec{
+			Name:     service.Name,
+			Owner:    db.NewBuildStepContainerOwner(step.metadata.BuildID, planID, step.metadata.TeamID),
+			Metadata: step.containerMetadata,
+			ContainerSpec: worker.ContainerSpec{
+				TeamID:    step.metadata.TeamID,
+				ImageSpec: imageSpec,
+				User:      service.Run.User,
+				Env:       service.Params.Env(),
+				Type:      step.containerMetadata.Type,
+			},
+			ProcessSpec: runtime.ProcessSpec{
+				Path:         service.Run.Path,
+				Args:         service.Run.Args,
+				Dir:          service.Run.Dir,
+				StdoutWriter: delegate.ServiceStdout(service.Name),
+				StderrWriter: delegate.ServiceStderr(service.Name),
+			},
+			Readiness: readiness,
+		})
+	}
+
+	return specs, nil
+}
+
+// serviceReadiness returns the service's readiness probe or, when it has none
+// but lists ports, a probe which succeeds once something listens on all of
+// them.
+func serviceReadiness(service atc.TaskServiceConfig) (*worker.ReadinessSpec, error) {
+	if service.Readiness == nil && len(service.Ports) == 0 {
+		return nil, nil
+	}
+
+	readiness := atc.TaskServiceReadiness{}
+	if service.Readiness != nil {
+		readiness = *service.Readiness
+	}
+
+	interval, err := readiness.IntervalDuration()
+	if err != nil {
+		return nil, fmt.Errorf("service %s readiness interval: %w", service.Name, err)
+	}
+
+	timeout, err := readiness.TimeoutDuration()
+	if err != nil {
+		return nil, fmt.Errorf("service %s readiness timeout: %w", service.Name, err)
+	}
+
+	if service.Readiness != nil {
+		return &worker.ReadinessSpec{
+			Path:     readiness.Run.Path,
+			Args:     readiness.Run.Args,
+			Interval: interval,
+			Timeout:  timeout,
+		}, nil
+	}
+
+	return &worker.ReadinessSpec{
+		Ports:    service.Ports,
+		Interval: interval,
+		Timeout:  timeout,
+	}, nil
+}
+
 func (step *TaskStep) workerSpec(config atc.TaskConfig) worker.WorkerSpec {
-	return worker.WorkerSpec{
+	spec := worker.WorkerSpec{
 		Platform: config.Platform,
 		Tags:     step.plan.Tags,
 		TeamID:   step.metadata.TeamID,
+		Priority: step.metadata.Priority,
+	}
+
+	// only some runtimes enforce the network config, and running the task
+	// without it being enforced would silently expose what it's meant to
+	// protect
+	if config.Network != nil {
+		spec.Features = append(spec.Features, atc.WorkerFeatureNetworkRules)
 	}
+
+	// services are run in the network of the task's container, which only
+	// some runtimes can do
+	if len(config.Services) > 0 {
+		spec.Features = append(spec.Features, atc.WorkerFeatureJoinNetwork)
+	}
+
+	return spec
 }
 
 func (step *TaskStep) registerOutputs(logger lager.Logger, repository *build.Repository, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) {
@@ -447,6 +634,113 @@ func (step *TaskStep) registerCaches(logger lager.Logger, repository *build.Repo
 	return nil
 }
 
+func (step *TaskStep) registerKeyedCaches(logger lager.Logger, keyedCaches []keyedTaskCache, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+	for _, cache := range keyedCaches {
+		// the cache was restored from the volume already stored under its key
+		if cache.restoredKey == cache.key {
+			continue
+		}
+
+		cachePath := filepath.Join(metadata.WorkingDirectory, cache.path)
+
+		for _, volumeMount := range volumeMounts {
+			if filepath.Clean(volumeMount.MountPath) == cachePath {
+				logger.Debug("initializing-keyed-cache", lager.Data{"path": volumeMount.MountPath, "key": cache.key})
+
+				err := volumeMount.Volume.InitializeKeyedCache(
+					logger,
+					cache.key,
+					bool(step.plan.Privileged))
+				if err != nil {
+					return err
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// uploadArtifacts streams each artifact out of the output it lives in and
+// stores it as a gzipped tarball in the artifact store, so that it outlives
+// the volumes of the build.
+func (step *TaskStep) uploadArtifacts(ctx context.Context, logger lager.Logger, config atc.TaskConfig, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) error {
+	if len(step.plan.Artifacts) == 0 {
+		return nil
+	}
+
+	if step.artifactStore == nil {
+		return ErrNoArtifactStore
+	}
+
+	for _, artifact := range step.plan.Artifacts {
+		outputName, subPath := splitArtifactPath(artifact)
+
+		volume, found := outputVolume(config, outputName, volumeMounts, metadata)
+		if !found {
+			return UnknownArtifactOutputError{
+				Artifact: artifact,
+				Output:   outputName,
+			}
+		}
+
+		key := path.Join("builds", strconv.Itoa(step.metadata.BuildID), string(step.planID), artifact) + ".tgz"
+
+		logger.Debug("uploading-artifact", lager.Data{"artifact": artifact, "key": key})
+
+		err := step.uploadArtifact(ctx, artifact, key, volume, subPath)
+		if err != nil {
+			return fmt.Errorf("upload artifact %s: %w", artifact, err)
+		}
+	}
+
+	return nil
+}
+
+func (step *TaskStep) uploadArtifact(ctx context.Context, name string, key string, volume worker.Volume, subPath string) error {
+	content, err := volume.StreamOut(ctx, subPath, baggageclaim.GzipEncoding)
+	if err != nil {
+		return err
+	}
+
+	defer content.Close()
+
+	size, err := step.artifactStore.Put(ctx, key, content)
+	if err != nil {
+		return err
+	}
+
+	_, err = step.storedArtifactFactory.CreateStoredArtifact(step.metadata.BuildID, name, key, size)
+	return err
+}
+
+func splitArtifactPath(artifact string) (string, string) {
+	segs := strings.SplitN(path.Clean(artifact), "/", 2)
+	if len(segs) == 1 {
+		return segs[0], "."
+	}
+
+	return segs[0], segs[1]
+}
+
+func outputVolume(config atc.TaskConfig, outputName string, volumeMounts []worker.VolumeMount, metadata db.ContainerMetadata) (worker.Volume, bool) {
+	for _, output := range config.Outputs {
+		if output.Name != outputName {
+			continue
+		}
+
+		outputPath := artifactsPath(output, metadata.WorkingDirectory)
+
+		for _, mount := range volumeMounts {
+			if filepath.Clean(mount.MountPath) == filepath.Clean(outputPath) {
+				return mount.Volume, true
+			}
+		}
+	}
+
+	return nil, false
+}
+
 type taskInput struct {
 	config        atc.TaskInputConfig
 	artifact      runtime.Artifact
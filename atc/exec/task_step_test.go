@@ -2,11 +2,20 @@ package exec_test
 
 import (
 	"context"
+	"crypto/sha256"
 	"errors"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/baggageclaim"
 	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
 	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/exec"
 	"github.com/concourse/concourse/atc/exec/build"
@@ -38,6 +47,12 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory *lockfakes.FakeLockFactory
 
+		fakeKeyedCacheFactory *dbfakes.FakeKeyedCacheFactory
+
+		fakeArtifactStore         *blobstorefakes.FakeStore
+		fakeStoredArtifactFactory *dbfakes.FakeStoredArtifactFactory
+		artifactStore             blobstore.Store
+
 		spanCtx      context.Context
 		fakeDelegate *execfakes.FakeTaskDelegate
 
@@ -79,6 +94,12 @@ var _ = Describe("TaskStep", func() {
 
 		fakeLockFactory = new(lockfakes.FakeLockFactory)
 
+		fakeKeyedCacheFactory = new(dbfakes.FakeKeyedCacheFactory)
+
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
+		fakeStoredArtifactFactory = new(dbfakes.FakeStoredArtifactFactory)
+		artifactStore = fakeArtifactStore
+
 		fakeDelegate = new(execfakes.FakeTaskDelegate)
 		fakeDelegate.StdoutReturns(stdoutBuf)
 		fakeDelegate.StderrReturns(stderrBuf)
@@ -133,6 +154,9 @@ var _ = Describe("TaskStep", func() {
 			fakeClient,
 			fakeDelegateFactory,
 			fakeLockFactory,
+			fakeKeyedCacheFactory,
+			artifactStore,
+			fakeStoredArtifactFactory,
 		)
 
 		stepOk, stepErr = taskStep.Run(ctx, state)
@@ -238,6 +262,130 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when network rules are configured", func() {
+			BeforeEach(func() {
+				taskPlan.Config.Network = &atc.TaskNetworkConfig{
+					Deny:   []atc.TaskNetworkRule{{Network: "0.0.0.0/0"}},
+					Expose: []atc.TaskPortMapping{{ContainerPort: 8080}},
+				}
+			})
+
+			It("passes them through to the container spec", func() {
+				_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Network).To(Equal(&atc.TaskNetworkConfig{
+					Deny:   []atc.TaskNetworkRule{{Network: "0.0.0.0/0"}},
+					Expose: []atc.TaskPortMapping{{ContainerPort: 8080}},
+				}))
+			})
+
+			It("requires a worker which supports network rules", func() {
+				_, _, _, _, workerSpec, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(workerSpec.Features).To(Equal([]string{atc.WorkerFeatureNetworkRules}))
+			})
+		})
+
+		Context("when services are configured", func() {
+			var fakeServiceStdout, fakeServiceStderr *gbytes.Buffer
+
+			BeforeEach(func() {
+				taskPlan.Config.Services = []atc.TaskServiceConfig{
+					{
+						Name:          "postgres",
+						ImageResource: &atc.ImageResource{Type: "docker", Source: atc.Source{"repository": "postgres"}},
+						Params:        atc.TaskEnv{"POSTGRES_PASSWORD": "secret"},
+						Run:           atc.TaskRunConfig{Path: "docker-entrypoint.sh", Args: []string{"postgres"}},
+						Ports:         []uint16{5432},
+					},
+					{
+						Name:          "redis",
+						ImageResource: &atc.ImageResource{Type: "docker", Source: atc.Source{"repository": "redis"}},
+						Run:           atc.TaskRunConfig{Path: "redis-server"},
+						Readiness: &atc.TaskServiceReadiness{
+							Run:     atc.TaskRunConfig{Path: "redis-cli", Args: []string{"ping"}},
+							Timeout: "10s",
+						},
+					},
+				}
+
+				fakeDelegate.FetchServiceImageStub = func(_ context.Context, name string, _ atc.ImageResource, _ atc.VersionedResourceTypes) (worker.ImageSpec, error) {
+					return worker.ImageSpec{ImageURL: "some-" + name + "-image"}, nil
+				}
+
+				fakeServiceStdout = gbytes.NewBuffer()
+				fakeServiceStderr = gbytes.NewBuffer()
+				fakeDelegate.ServiceStdoutReturns(fakeServiceStdout)
+				fakeDelegate.ServiceStderrReturns(fakeServiceStderr)
+			})
+
+			It("fetches the image of each service", func() {
+				Expect(fakeDelegate.FetchServiceImageCallCount()).To(Equal(2))
+
+				_, name, image, _ := fakeDelegate.FetchServiceImageArgsForCall(0)
+				Expect(name).To(Equal("postgres"))
+				Expect(image.Source).To(Equal(atc.Source{"repository": "postgres"}))
+
+				_, name, _, _ = fakeDelegate.FetchServiceImageArgsForCall(1)
+				Expect(name).To(Equal("redis"))
+			})
+
+			It("describes the services in the container spec", func() {
+				_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(containerSpec.Services).To(HaveLen(2))
+
+				postgres := containerSpec.Services[0]
+				Expect(postgres.Name).To(Equal("postgres"))
+				Expect(postgres.Owner).To(Equal(db.NewBuildStepContainerOwner(1234, "42/services/postgres", 123)))
+				Expect(postgres.ContainerSpec.ImageSpec).To(Equal(worker.ImageSpec{ImageURL: "some-postgres-image"}))
+				Expect(postgres.ContainerSpec.Env).To(Equal([]string{"POSTGRES_PASSWORD=secret"}))
+				Expect(postgres.ProcessSpec.Path).To(Equal("docker-entrypoint.sh"))
+				Expect(postgres.ProcessSpec.Args).To(Equal([]string{"postgres"}))
+				Expect(postgres.ProcessSpec.StdoutWriter).To(Equal(fakeServiceStdout))
+				Expect(postgres.ProcessSpec.StderrWriter).To(Equal(fakeServiceStderr))
+				Expect(fakeDelegate.ServiceStdoutArgsForCall(0)).To(Equal("postgres"))
+				Expect(fakeDelegate.ServiceStderrArgsForCall(0)).To(Equal("postgres"))
+			})
+
+			It("probes the ports of services without a readiness probe", func() {
+				_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+
+				Expect(containerSpec.Services[0].Readiness).To(Equal(&worker.ReadinessSpec{
+					Ports:    []uint16{5432},
+					Interval: atc.DefaultServiceReadinessInterval,
+					Timeout:  atc.DefaultServiceReadinessTimeout,
+				}))
+			})
+
+			It("requires a worker which can run containers in the network of another one", func() {
+				_, _, _, _, workerSpec, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+				Expect(workerSpec.Features).To(Equal([]string{atc.WorkerFeatureJoinNetwork}))
+			})
+
+			It("uses the configured readiness probe", func() {
+				_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+
+				Expect(containerSpec.Services[1].Readiness).To(Equal(&worker.ReadinessSpec{
+					Path:     "redis-cli",
+					Args:     []string{"ping"},
+					Interval: atc.DefaultServiceReadinessInterval,
+					Timeout:  10 * time.Second,
+				}))
+			})
+
+			Context("when fetching a service image fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeDelegate.FetchServiceImageStub = nil
+					fakeDelegate.FetchServiceImageReturns(worker.ImageSpec{}, disaster)
+				})
+
+				It("returns the error without running the task", func() {
+					Expect(stepErr).To(MatchError(ContainSubstring("fetch image of service postgres: nope")))
+					Expect(fakeClient.RunTaskStepCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when tracing is enabled", func() {
 			var buildSpan trace.Span
 
@@ -485,6 +633,266 @@ var _ = Describe("TaskStep", func() {
 			})
 		})
 
+		Context("when the configuration specifies keyed caches", func() {
+			var (
+				fakeCacheVolume *workerfakes.FakeVolume
+				taskResult      worker.TaskResult
+			)
+
+			BeforeEach(func() {
+				taskPlan.Config = &atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Caches: []atc.TaskCacheConfig{
+						{
+							Path:        "gopath",
+							Key:         `go-{{hashFiles "some-input/go.sum"}}`,
+							RestoreKeys: []string{"go-"},
+						},
+					},
+				}
+
+				repo.RegisterArtifact("some-input", new(runtimefakes.FakeArtifact))
+
+				fakeClient.StreamFileFromArtifactReturns(ioutil.NopCloser(strings.NewReader("some-sums")), nil)
+
+				fakeCacheVolume = new(workerfakes.FakeVolume)
+				taskResult = worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeCacheVolume,
+							MountPath: "some-artifact-root/gopath",
+						},
+					},
+				}
+			})
+
+			JustBeforeEach(func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+			})
+
+			It("keys the cache by the hash of the files", func() {
+				Expect(fakeClient.StreamFileFromArtifactCallCount()).To(Equal(1))
+				_, _, _, filePath := fakeClient.StreamFileFromArtifactArgsForCall(0)
+				Expect(filePath).To(Equal("go.sum"))
+
+				Expect(fakeKeyedCacheFactory.FindCacheVolumeCallCount()).To(Equal(1))
+				teamID, key, restoreKeys := fakeKeyedCacheFactory.FindCacheVolumeArgsForCall(0)
+				Expect(teamID).To(Equal(stepMetadata.TeamID))
+				Expect(key).To(Equal(fmt.Sprintf("go-%x", sha256.Sum256([]byte("some-sums")))))
+				Expect(restoreKeys).To(Equal([]string{"go-"}))
+			})
+
+			Context("when no cache is found", func() {
+				BeforeEach(func() {
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("mounts an empty volume at the cache path", func() {
+					_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+					Expect(containerSpec.ArtifactByPath).To(BeEmpty())
+					Expect(containerSpec.Outputs).To(ContainElement("some-artifact-root/gopath"))
+				})
+
+				It("stores the volume under the key", func() {
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(Equal(1))
+					_, key, privileged := fakeCacheVolume.InitializeKeyedCacheArgsForCall(0)
+					Expect(key).To(Equal(fmt.Sprintf("go-%x", sha256.Sum256([]byte("some-sums")))))
+					Expect(privileged).To(BeFalse())
+				})
+
+				Context("when the task fails", func() {
+					BeforeEach(func() {
+						taskResult.ExitStatus = 1
+						fakeClient.RunTaskStepReturns(taskResult, nil)
+					})
+
+					It("does not store the volume", func() {
+						Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+					})
+				})
+			})
+
+			Context("when a cache is found under a restore key", func() {
+				BeforeEach(func() {
+					fakeRestoredVolume := new(dbfakes.FakeCreatedVolume)
+					fakeRestoredVolume.HandleReturns("some-restored-handle")
+					fakeKeyedCacheFactory.FindCacheVolumeReturns(fakeRestoredVolume, "go-some-old-hash", true, nil)
+
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("restores the cache from its volume", func() {
+					_, _, _, containerSpec, _, _, _, _, _, _ := fakeClient.RunTaskStepArgsForCall(0)
+					Expect(containerSpec.ArtifactByPath).To(HaveLen(1))
+					Expect(containerSpec.ArtifactByPath["some-artifact-root/gopath"]).To(Equal(&runtime.TaskArtifact{
+						VolumeHandle: "some-restored-handle",
+					}))
+					Expect(containerSpec.Outputs).ToNot(ContainElement("some-artifact-root/gopath"))
+				})
+
+				It("stores the volume under the new key", func() {
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when the cache is found under its key", func() {
+				BeforeEach(func() {
+					fakeRestoredVolume := new(dbfakes.FakeCreatedVolume)
+					fakeRestoredVolume.HandleReturns("some-restored-handle")
+					fakeKeyedCacheFactory.FindCacheVolumeStub = func(_ int, key string, _ []string) (db.CreatedVolume, string, bool, error) {
+						return fakeRestoredVolume, key, true, nil
+					}
+
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("does not store the volume again", func() {
+					Expect(fakeCacheVolume.InitializeKeyedCacheCallCount()).To(BeZero())
+				})
+			})
+
+			It("does not treat the cache as a job task cache", func() {
+				Expect(fakeCacheVolume.InitializeTaskCacheCallCount()).To(BeZero())
+			})
+		})
+
+		Context("when the plan specifies artifacts", func() {
+			var (
+				fakeOutputVolume *workerfakes.FakeVolume
+				taskResult       worker.TaskResult
+			)
+
+			BeforeEach(func() {
+				taskPlan.Artifacts = []string{"some-output/dist/app.tgz", "some-other-output"}
+
+				taskPlan.Config = &atc.TaskConfig{
+					Platform:  "some-platform",
+					RootfsURI: "some-image",
+					Run: atc.TaskRunConfig{
+						Path: "ls",
+					},
+					Outputs: []atc.TaskOutputConfig{
+						{Name: "some-output", Path: "some-output-configured-path"},
+						{Name: "some-other-output"},
+					},
+				}
+
+				fakeOutputVolume = new(workerfakes.FakeVolume)
+				fakeOutputVolume.StreamOutReturns(ioutil.NopCloser(strings.NewReader("some-tarball")), nil)
+
+				taskResult = worker.TaskResult{
+					ExitStatus: 0,
+					VolumeMounts: []worker.VolumeMount{
+						{
+							Volume:    fakeOutputVolume,
+							MountPath: "some-artifact-root/some-output-configured-path/",
+						},
+						{
+							Volume:    fakeOutputVolume,
+							MountPath: "some-artifact-root/some-other-output/",
+						},
+					},
+				}
+
+				fakeClient.RunTaskStepReturns(taskResult, nil)
+
+				fakeArtifactStore.PutReturns(12, nil)
+			})
+
+			It("succeeds", func() {
+				Expect(stepErr).ToNot(HaveOccurred())
+				Expect(stepOk).To(BeTrue())
+			})
+
+			It("streams each artifact out of its output", func() {
+				Expect(fakeOutputVolume.StreamOutCallCount()).To(Equal(2))
+
+				_, subPath, encoding := fakeOutputVolume.StreamOutArgsForCall(0)
+				Expect(subPath).To(Equal("dist/app.tgz"))
+				Expect(encoding).To(Equal(baggageclaim.GzipEncoding))
+
+				_, subPath, _ = fakeOutputVolume.StreamOutArgsForCall(1)
+				Expect(subPath).To(Equal("."))
+			})
+
+			It("uploads each artifact under a key for the build and plan", func() {
+				Expect(fakeArtifactStore.PutCallCount()).To(Equal(2))
+
+				_, key, content := fakeArtifactStore.PutArgsForCall(0)
+				Expect(key).To(Equal("builds/1234/42/some-output/dist/app.tgz.tgz"))
+				Expect(ioutil.ReadAll(content)).To(Equal([]byte("some-tarball")))
+
+				_, key, _ = fakeArtifactStore.PutArgsForCall(1)
+				Expect(key).To(Equal("builds/1234/42/some-other-output.tgz"))
+			})
+
+			It("records each artifact for the build", func() {
+				Expect(fakeStoredArtifactFactory.CreateStoredArtifactCallCount()).To(Equal(2))
+
+				buildID, name, key, size := fakeStoredArtifactFactory.CreateStoredArtifactArgsForCall(0)
+				Expect(buildID).To(Equal(stepMetadata.BuildID))
+				Expect(name).To(Equal("some-output/dist/app.tgz"))
+				Expect(key).To(Equal("builds/1234/42/some-output/dist/app.tgz.tgz"))
+				Expect(size).To(Equal(int64(12)))
+			})
+
+			Context("when the task fails", func() {
+				BeforeEach(func() {
+					taskResult.ExitStatus = 1
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("does not upload anything", func() {
+					Expect(stepErr).ToNot(HaveOccurred())
+					Expect(fakeArtifactStore.PutCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when uploading fails", func() {
+				disaster := errors.New("nope")
+
+				BeforeEach(func() {
+					fakeArtifactStore.PutReturns(0, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(errors.Is(stepErr, disaster)).To(BeTrue())
+				})
+
+				It("does not record the artifact", func() {
+					Expect(fakeStoredArtifactFactory.CreateStoredArtifactCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when an artifact refers to an unknown output", func() {
+				BeforeEach(func() {
+					taskPlan.Artifacts = []string{"bogus/app.tgz"}
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(Equal(exec.UnknownArtifactOutputError{
+						Artifact: "bogus/app.tgz",
+						Output:   "bogus",
+					}))
+				})
+			})
+
+			Context("when no artifact store is configured", func() {
+				BeforeEach(func() {
+					artifactStore = nil
+				})
+
+				It("returns an error", func() {
+					Expect(stepErr).To(Equal(exec.ErrNoArtifactStore))
+				})
+			})
+		})
+
 		Context("when the configuration specifies paths for outputs", func() {
 			BeforeEach(func() {
 				taskPlan.Config = &atc.TaskConfig{
@@ -923,6 +1331,36 @@ var _ = Describe("TaskStep", func() {
 					Expect(stepErr).ToNot(HaveOccurred())
 				})
 			})
+
+			Context("when the worker measured the resource usage of the task", func() {
+				BeforeEach(func() {
+					taskResult := worker.TaskResult{
+						ExitStatus:   0,
+						VolumeMounts: []worker.VolumeMount{},
+						ResourceUsage: &worker.ResourceUsage{
+							CPU:        3 * time.Second,
+							PeakMemory: 4096,
+							Disk:       8192,
+							Wall:       5 * time.Second,
+						},
+					}
+					fakeClient.RunTaskStepReturns(taskResult, nil)
+				})
+
+				It("reports the resource usage via the delegate", func() {
+					Expect(fakeDelegate.ResourceUsageCallCount()).To(Equal(1))
+					_, usage := fakeDelegate.ResourceUsageArgsForCall(0)
+					Expect(usage).To(Equal(atc.StepResourceUsage{
+						PlanID:          planID,
+						StepName:        "some-task",
+						StepType:        "task",
+						CPUSeconds:      3,
+						PeakMemoryBytes: 4096,
+						DiskBytes:       8192,
+						WallSeconds:     5,
+					}))
+				})
+			})
 		})
 
 		Context("when running the task fails", func() {
@@ -0,0 +1,41 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type auditEventsCollector struct {
+	lifecycle db.AuditEventLifecycle
+	retention time.Duration
+}
+
+func NewAuditEventsCollector(lifecycle db.AuditEventLifecycle, retention time.Duration) *auditEventsCollector {
+	return &auditEventsCollector{
+		lifecycle: lifecycle,
+		retention: retention,
+	}
+}
+
+func (c *auditEventsCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("audit-events-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	removed, err := c.lifecycle.RemoveExpiredAuditEvents(c.retention)
+	if err != nil {
+		logger.Error("failed-to-remove-expired-audit-events", err)
+		return err
+	}
+
+	if removed > 0 {
+		logger.Debug("removed-expired-audit-events", lager.Data{"count": removed})
+	}
+
+	return nil
+}
@@ -0,0 +1,45 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("AuditEventsCollector", func() {
+	var collector GcCollector
+	var fakeLifecycle *dbfakes.FakeAuditEventLifecycle
+
+	BeforeEach(func() {
+		fakeLifecycle = new(dbfakes.FakeAuditEventLifecycle)
+
+		collector = gc.NewAuditEventsCollector(fakeLifecycle, 24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("tells the audit event lifecycle to remove events past the retention period", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeLifecycle.RemoveExpiredAuditEventsCallCount()).To(Equal(1))
+			retention := fakeLifecycle.RemoveExpiredAuditEventsArgsForCall(0)
+			Expect(retention).To(Equal(24 * time.Hour))
+		})
+
+		Context("when removing the events fails", func() {
+			BeforeEach(func() {
+				fakeLifecycle.RemoveExpiredAuditEventsReturns(0, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
@@ -0,0 +1,50 @@
+package gc
+
+import (
+	"context"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+type buildEventArchiver struct {
+	archiver  db.BuildEventArchiver
+	batchSize int
+}
+
+func NewBuildEventArchiver(archiver db.BuildEventArchiver, batchSize int) *buildEventArchiver {
+	return &buildEventArchiver{
+		archiver:  archiver,
+		batchSize: batchSize,
+	}
+}
+
+func (a *buildEventArchiver) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("build-event-archiver")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	archived, err := a.archiver.ArchiveBuildEvents(ctx, a.batchSize)
+	if archived > 0 {
+		logger.Debug("archived-build-events", lager.Data{"count": archived})
+	}
+
+	if err != nil {
+		logger.Error("failed-to-archive-build-events", err)
+		return err
+	}
+
+	deleted, err := a.archiver.DeleteOrphanedBuildEvents(ctx, a.batchSize)
+	if deleted > 0 {
+		logger.Debug("deleted-orphaned-build-events", lager.Data{"count": deleted})
+	}
+
+	if err != nil {
+		logger.Error("failed-to-delete-orphaned-build-events", err)
+		return err
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("BuildEventArchiver", func() {
+	var archiver GcCollector
+	var fakeArchiver *dbfakes.FakeBuildEventArchiver
+
+	BeforeEach(func() {
+		fakeArchiver = new(dbfakes.FakeBuildEventArchiver)
+
+		archiver = gc.NewBuildEventArchiver(fakeArchiver, 100)
+	})
+
+	Describe("Run", func() {
+		It("archives a batch of build events", func() {
+			err := archiver.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeArchiver.ArchiveBuildEventsCallCount()).To(Equal(1))
+			_, limit := fakeArchiver.ArchiveBuildEventsArgsForCall(0)
+			Expect(limit).To(Equal(100))
+		})
+
+		It("deletes a batch of orphaned build events", func() {
+			err := archiver.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeArchiver.DeleteOrphanedBuildEventsCallCount()).To(Equal(1))
+			_, limit := fakeArchiver.DeleteOrphanedBuildEventsArgsForCall(0)
+			Expect(limit).To(Equal(100))
+		})
+
+		Context("when archiving fails", func() {
+			BeforeEach(func() {
+				fakeArchiver.ArchiveBuildEventsReturns(3, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := archiver.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+
+		Context("when deleting orphaned build events fails", func() {
+			BeforeEach(func() {
+				fakeArchiver.DeleteOrphanedBuildEventsReturns(1, errors.New("nope"))
+			})
+
+			It("returns the error", func() {
+				err := archiver.Run(context.TODO())
+				Expect(err).To(MatchError("nope"))
+			})
+		})
+	})
+})
@@ -14,6 +14,7 @@ import (
 type buildLogCollector struct {
 	pipelineFactory             db.PipelineFactory
 	pipelineLifecycle           db.PipelineLifecycle
+	buildEventStore             db.BuildEventStore
 	batchSize                   int
 	drainerConfigured           bool
 	buildLogRetentionCalculator BuildLogRetentionCalculator
@@ -22,6 +23,7 @@ type buildLogCollector struct {
 func NewBuildLogCollector(
 	pipelineFactory db.PipelineFactory,
 	pipelineLifecycle db.PipelineLifecycle,
+	buildEventStore db.BuildEventStore,
 	batchSize int,
 	buildLogRetentionCalculator BuildLogRetentionCalculator,
 	drainerConfigured bool,
@@ -29,6 +31,7 @@ func NewBuildLogCollector(
 	return &buildLogCollector{
 		pipelineFactory:             pipelineFactory,
 		pipelineLifecycle:           pipelineLifecycle,
+		buildEventStore:             buildEventStore,
 		batchSize:                   batchSize,
 		drainerConfigured:           drainerConfigured,
 		buildLogRetentionCalculator: buildLogRetentionCalculator,
@@ -202,7 +205,7 @@ func (br *buildLogCollector) reapLogsOfJob(pipeline db.Pipeline,
 		"build_ids": buildIDsToDelete,
 	})
 
-	err = pipeline.DeleteBuildEventsByBuildIDs(buildIDsToDelete)
+	err = br.buildEventStore.DeleteBuildEvents(pipeline, buildIDsToDelete)
 	if err != nil {
 		logger.Error("failed-to-delete-build-events", err)
 		return err
@@ -35,6 +35,7 @@ var _ = Describe("BuildLogCollector", func() {
 		buildLogCollector = NewBuildLogCollector(
 			fakePipelineFactory,
 			fakePipelineLifecycle,
+			db.NewPostgresBuildEventStore(),
 			batchSize,
 			buildLogRetainCalc,
 			false,
@@ -101,6 +102,7 @@ var _ = Describe("BuildLogCollector", func() {
 					buildLogCollector = NewBuildLogCollector(
 						fakePipelineFactory,
 						fakePipelineLifecycle,
+						db.NewPostgresBuildEventStore(),
 						batchSize,
 						buildLogRetainCalc,
 						true,
@@ -154,6 +156,7 @@ var _ = Describe("BuildLogCollector", func() {
 					buildLogCollector = NewBuildLogCollector(
 						fakePipelineFactory,
 						fakePipelineLifecycle,
+						db.NewPostgresBuildEventStore(),
 						batchSize,
 						buildLogRetainCalc,
 						false,
@@ -184,6 +187,44 @@ var _ = Describe("BuildLogCollector", func() {
 				})
 			})
 
+			Context("when builds are reaped through a build event store", func() {
+				var fakeBuildEventStore *dbfakes.FakeBuildEventStore
+
+				BeforeEach(func() {
+					fakeBuildEventStore = new(dbfakes.FakeBuildEventStore)
+
+					buildLogCollector = NewBuildLogCollector(
+						fakePipelineFactory,
+						fakePipelineLifecycle,
+						fakeBuildEventStore,
+						batchSize,
+						buildLogRetainCalc,
+						false,
+					)
+					fakeJob.BuildsStub = func(page db.Page) ([]db.Build, db.Pagination, error) {
+						if *page.From == 5 {
+							return []db.Build{sbDrained(9, true), sbDrained(8, false), sbDrained(7, false), sbDrained(6, true), sbDrained(5, false)}, db.Pagination{Newer: &db.Page{From: db.NewIntPtr(10), Limit: 5}}, nil
+						} else if *page.From == 10 {
+							return []db.Build{sbDrained(10, true)}, db.Pagination{}, nil
+						}
+						Fail(fmt.Sprintf("Builds called with unexpected argument: page=%#v", page))
+						return []db.Build{}, db.Pagination{}, nil
+					}
+				})
+
+				It("deletes the build events from the store", func() {
+					err := buildLogCollector.Run(context.TODO())
+					Expect(err).NotTo(HaveOccurred())
+
+					Expect(fakeBuildEventStore.DeleteBuildEventsCallCount()).To(Equal(1))
+					pipeline, buildIDs := fakeBuildEventStore.DeleteBuildEventsArgsForCall(0)
+					Expect(pipeline).To(Equal(fakePipeline))
+					Expect(buildIDs).To(ConsistOf(5, 6, 7, 8))
+
+					Expect(fakePipeline.DeleteBuildEventsByBuildIDsCallCount()).To(BeZero())
+				})
+			})
+
 			Context("when deleting build events fails", func() {
 				var disaster error
 
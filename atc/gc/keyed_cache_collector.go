@@ -0,0 +1,49 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type keyedCacheCollector struct {
+	keyedCacheLifecycle db.KeyedCacheLifecycle
+	unusedPeriod        time.Duration
+}
+
+func NewKeyedCacheCollector(keyedCacheLifecycle db.KeyedCacheLifecycle, unusedPeriod time.Duration) *keyedCacheCollector {
+	return &keyedCacheCollector{
+		keyedCacheLifecycle: keyedCacheLifecycle,
+		unusedPeriod:        unusedPeriod,
+	}
+}
+
+func (k *keyedCacheCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("keyed-cache-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	start := time.Now()
+	defer func() {
+		metric.KeyedCacheCollectorDuration{
+			Duration: time.Since(start),
+		}.Emit(logger)
+	}()
+
+	deleted, err := k.keyedCacheLifecycle.CleanUpKeyedCaches(k.unusedPeriod)
+	if err != nil {
+		logger.Error("failed-to-clean-up-keyed-caches", err)
+		return err
+	}
+
+	if deleted > 0 {
+		logger.Debug("cleaned-up-keyed-caches", lager.Data{"count": deleted})
+	}
+
+	return nil
+}
@@ -0,0 +1,44 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("KeyedCacheCollector", func() {
+	var collector GcCollector
+	var fakeKeyedCacheLifecycle *dbfakes.FakeKeyedCacheLifecycle
+
+	BeforeEach(func() {
+		fakeKeyedCacheLifecycle = new(dbfakes.FakeKeyedCacheLifecycle)
+
+		collector = gc.NewKeyedCacheCollector(fakeKeyedCacheLifecycle, 24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("tells the keyed cache lifecycle to clean up caches unused for the period", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeKeyedCacheLifecycle.CleanUpKeyedCachesCallCount()).To(Equal(1))
+			Expect(fakeKeyedCacheLifecycle.CleanUpKeyedCachesArgsForCall(0)).To(Equal(24 * time.Hour))
+		})
+
+		Context("when cleaning up fails", func() {
+			BeforeEach(func() {
+				fakeKeyedCacheLifecycle.CleanUpKeyedCachesReturns(0, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
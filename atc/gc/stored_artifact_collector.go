@@ -0,0 +1,68 @@
+package gc
+
+import (
+	"context"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/blobstore"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/metric"
+)
+
+type storedArtifactCollector struct {
+	storedArtifactLifecycle db.StoredArtifactLifecycle
+	artifactStore           blobstore.Store
+	retention               time.Duration
+}
+
+func NewStoredArtifactCollector(storedArtifactLifecycle db.StoredArtifactLifecycle, artifactStore blobstore.Store, retention time.Duration) *storedArtifactCollector {
+	return &storedArtifactCollector{
+		storedArtifactLifecycle: storedArtifactLifecycle,
+		artifactStore:           artifactStore,
+		retention:               retention,
+	}
+}
+
+func (s *storedArtifactCollector) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("stored-artifact-collector")
+
+	logger.Debug("start")
+	defer logger.Debug("done")
+
+	start := time.Now()
+	defer func() {
+		metric.StoredArtifactCollectorDuration{
+			Duration: time.Since(start),
+		}.Emit(logger)
+	}()
+
+	expired, err := s.storedArtifactLifecycle.ExpiredStoredArtifacts(s.retention)
+	if err != nil {
+		logger.Error("failed-to-find-expired-stored-artifacts", err)
+		return err
+	}
+
+	for _, artifact := range expired {
+		// remove the blob before the record of it, so that a failure leaves the
+		// record around to try again on the next run
+		err := s.artifactStore.Delete(ctx, artifact.Key)
+		if err != nil {
+			logger.Error("failed-to-delete-blob", err, lager.Data{"key": artifact.Key})
+			continue
+		}
+
+		err = s.storedArtifactLifecycle.DeleteStoredArtifact(artifact.ID)
+		if err != nil {
+			logger.Error("failed-to-delete-stored-artifact", err, lager.Data{"id": artifact.ID})
+			return err
+		}
+	}
+
+	if len(expired) > 0 {
+		logger.Debug("cleaned-up-stored-artifacts", lager.Data{"count": len(expired)})
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package gc_test
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc/blobstore/blobstorefakes"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/gc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StoredArtifactCollector", func() {
+	var collector GcCollector
+	var fakeStoredArtifactLifecycle *dbfakes.FakeStoredArtifactLifecycle
+	var fakeArtifactStore *blobstorefakes.FakeStore
+
+	BeforeEach(func() {
+		fakeStoredArtifactLifecycle = new(dbfakes.FakeStoredArtifactLifecycle)
+		fakeArtifactStore = new(blobstorefakes.FakeStore)
+
+		fakeStoredArtifactLifecycle.ExpiredStoredArtifactsReturns([]db.StoredArtifact{
+			{ID: 1, Key: "builds/1/some-plan/some-output.tgz"},
+			{ID: 2, Key: "builds/2/some-plan/some-output.tgz"},
+		}, nil)
+
+		collector = gc.NewStoredArtifactCollector(fakeStoredArtifactLifecycle, fakeArtifactStore, 24*time.Hour)
+	})
+
+	Describe("Run", func() {
+		It("finds artifacts expired for the retention period", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeStoredArtifactLifecycle.ExpiredStoredArtifactsCallCount()).To(Equal(1))
+			Expect(fakeStoredArtifactLifecycle.ExpiredStoredArtifactsArgsForCall(0)).To(Equal(24 * time.Hour))
+		})
+
+		It("deletes the blob and the record of each expired artifact", func() {
+			err := collector.Run(context.TODO())
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(fakeArtifactStore.DeleteCallCount()).To(Equal(2))
+			_, key := fakeArtifactStore.DeleteArgsForCall(0)
+			Expect(key).To(Equal("builds/1/some-plan/some-output.tgz"))
+			_, key = fakeArtifactStore.DeleteArgsForCall(1)
+			Expect(key).To(Equal("builds/2/some-plan/some-output.tgz"))
+
+			Expect(fakeStoredArtifactLifecycle.DeleteStoredArtifactCallCount()).To(Equal(2))
+			Expect(fakeStoredArtifactLifecycle.DeleteStoredArtifactArgsForCall(0)).To(Equal(1))
+			Expect(fakeStoredArtifactLifecycle.DeleteStoredArtifactArgsForCall(1)).To(Equal(2))
+		})
+
+		Context("when deleting a blob fails", func() {
+			BeforeEach(func() {
+				fakeArtifactStore.DeleteReturnsOnCall(0, errors.New("disaster"))
+			})
+
+			It("keeps its record and moves on to the next artifact", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).NotTo(HaveOccurred())
+
+				Expect(fakeStoredArtifactLifecycle.DeleteStoredArtifactCallCount()).To(Equal(1))
+				Expect(fakeStoredArtifactLifecycle.DeleteStoredArtifactArgsForCall(0)).To(Equal(2))
+			})
+		})
+
+		Context("when finding expired artifacts fails", func() {
+			BeforeEach(func() {
+				fakeStoredArtifactLifecycle.ExpiredStoredArtifactsReturns(nil, errors.New("disaster"))
+			})
+
+			It("returns the error", func() {
+				err := collector.Run(context.TODO())
+				Expect(err).To(MatchError("disaster"))
+			})
+		})
+	})
+})
@@ -10,10 +10,15 @@ type JobConfig struct {
 	Interruptible        bool     `json:"interruptible,omitempty"`
 	SerialGroups         []string `json:"serial_groups,omitempty"`
 	RawMaxInFlight       int      `json:"max_in_flight,omitempty"`
+	Priority             int      `json:"priority,omitempty"`
 	BuildLogsToRetain    int      `json:"build_logs_to_retain,omitempty"`
 
 	BuildLogRetention *BuildLogRetention `json:"build_log_retention,omitempty"`
 
+	Matrix []JobMatrixVar `json:"matrix,omitempty"`
+
+	DependsOn []string `json:"depends_on,omitempty"`
+
 	OnSuccess *Step `json:"on_success,omitempty"`
 	OnFailure *Step `json:"on_failure,omitempty"`
 	OnAbort   *Step `json:"on_abort,omitempty"`
@@ -88,10 +93,13 @@ func (config JobConfig) MaxInFlight() int {
 	return 0
 }
 
-func (config JobConfig) Inputs() []JobInputParams {
+// Inputs returns the get steps of the job, including those within any of the
+// given templates used by the job.
+func (config JobConfig) Inputs(templates StepTemplateConfigs) []JobInputParams {
 	var inputs []JobInputParams
 
 	_ = config.StepConfig().Visit(StepRecursor{
+		Templates: templates,
 		OnGet: func(step *GetStep) error {
 			inputs = append(inputs, JobInputParams{
 				JobInput: JobInput{
@@ -112,10 +120,13 @@ func (config JobConfig) Inputs() []JobInputParams {
 	return inputs
 }
 
-func (config JobConfig) Outputs() []JobOutput {
+// Outputs returns the put steps of the job, including those within any of the
+// given templates used by the job.
+func (config JobConfig) Outputs(templates StepTemplateConfigs) []JobOutput {
 	var outputs []JobOutput
 
 	_ = config.StepConfig().Visit(StepRecursor{
+		Templates: templates,
 		OnPut: func(step *PutStep) error {
 			outputs = append(outputs, JobOutput{
 				Name:     step.Name,
@@ -74,7 +74,7 @@ var _ = Describe("JobConfig", func() {
 		})
 
 		JustBeforeEach(func() {
-			inputs = jobConfig.Inputs()
+			inputs = jobConfig.Inputs(nil)
 		})
 
 		Context("with a build plan", func() {
@@ -456,7 +456,7 @@ var _ = Describe("JobConfig", func() {
 		})
 
 		JustBeforeEach(func() {
-			outputs = jobConfig.Outputs()
+			outputs = jobConfig.Outputs(nil)
 		})
 
 		Context("with a build plan", func() {
@@ -0,0 +1,324 @@
+package atc
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// JobMatrixVar is one dimension of a job's matrix. A job is generated for
+// every combination of the values of all of its matrix vars.
+//
+// Values must be strings. Unquoted YAML numbers lose how they were written
+// (1.10 becomes 1.1), which would change the generated job names and the
+// interpolated values.
+type JobMatrixVar struct {
+	Var    string   `json:"var"`
+	Values []string `json:"values"`
+}
+
+func (config *JobMatrixVar) UnmarshalJSON(data []byte) error {
+	var t struct {
+		Var    string        `json:"var"`
+		Values []interface{} `json:"values"`
+	}
+	if err := unmarshalStrict(data, &t); err != nil {
+		return err
+	}
+
+	var values []string
+	for _, value := range t.Values {
+		str, ok := value.(string)
+		if !ok {
+			return fmt.Errorf("matrix var '%s' has a value which is not a string (%v); quote it so that it is used as written", t.Var, value)
+		}
+
+		values = append(values, str)
+	}
+
+	config.Var = t.Var
+	config.Values = values
+	return nil
+}
+
+var localVarRegex = regexp.MustCompile(`\(\(\.:([-\w\pL]+)\)\)`)
+
+// ExpandJobMatrices replaces every job configured with a matrix with a job
+// for each combination of its matrix values, in which any ((.:var)) reference
+// to a matrix var is replaced by its value.
+//
+// The generated jobs are named after the job followed by the values of the
+// combination, e.g. 'unit-1.15-linux', so that reordering the values does not
+// change which job (and build history) a combination belongs to.
+//
+// Groups, passed constraints and dependencies referring to a job with a matrix are expanded
+// to refer to all of its generated jobs.
+func (config Config) ExpandJobMatrices() (Config, error) {
+	expandedNames := map[string][]string{}
+	for _, job := range config.Jobs {
+		if len(job.Matrix) != 0 {
+			expandedNames[job.Name] = nil
+		}
+	}
+
+	if len(expandedNames) == 0 {
+		return config, nil
+	}
+
+	var jobs JobConfigs
+	for _, job := range config.Jobs {
+		if len(job.Matrix) == 0 {
+			copied, err := copyJob(job, nil)
+			if err != nil {
+				return Config{}, err
+			}
+
+			jobs = append(jobs, copied)
+			continue
+		}
+
+		combinations, err := job.matrixCombinations()
+		if err != nil {
+			return Config{}, fmt.Errorf("job '%s': %w", job.Name, err)
+		}
+
+		for _, combination := range combinations {
+			generated, err := copyJob(job, combination)
+			if err != nil {
+				return Config{}, fmt.Errorf("job '%s': %w", job.Name, err)
+			}
+
+			suffix := job.matrixSuffix(combination)
+
+			generated.Name = job.Name + "-" + suffix
+			if job.OldName != "" {
+				generated.OldName = job.OldName + "-" + suffix
+			}
+
+			generated.Matrix = nil
+
+			expandedNames[job.Name] = append(expandedNames[job.Name], generated.Name)
+			jobs = append(jobs, generated)
+		}
+	}
+
+	expandPassed := StepRecursor{
+		OnGet: func(step *GetStep) error {
+			step.Passed = expandJobNames(step.Passed, expandedNames)
+			return nil
+		},
+	}
+
+	for i, job := range jobs {
+		jobs[i].DependsOn = expandJobNames(job.DependsOn, expandedNames)
+
+		err := job.Step().Config.Visit(expandPassed)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	// Templates are expanded as they are rather than through the steps using
+	// them, as they're instantiated again whenever the pipeline is loaded.
+	var templates StepTemplateConfigs
+	for _, template := range config.Templates {
+		copied, err := copyTemplate(template)
+		if err != nil {
+			return Config{}, fmt.Errorf("template '%s': %w", template.Name, err)
+		}
+
+		if copied.Step.Config != nil {
+			err = copied.Step.Config.Visit(expandPassed)
+			if err != nil {
+				return Config{}, fmt.Errorf("template '%s': %w", template.Name, err)
+			}
+		}
+
+		templates = append(templates, copied)
+	}
+
+	var groups GroupConfigs
+	for _, group := range config.Groups {
+		group.Jobs = expandJobNames(group.Jobs, expandedNames)
+		groups = append(groups, group)
+	}
+
+	config.Jobs = jobs
+	config.Templates = templates
+	config.Groups = groups
+
+	return config, nil
+}
+
+func (config JobConfig) matrixCombinations() ([]map[string]string, error) {
+	combinations := []map[string]string{{}}
+
+	seen := map[string]bool{}
+	for _, matrixVar := range config.Matrix {
+		if matrixVar.Var == "" {
+			return nil, errors.New("matrix var has no name")
+		}
+
+		if seen[matrixVar.Var] {
+			return nil, fmt.Errorf("matrix var '%s' is declared more than once", matrixVar.Var)
+		}
+
+		seen[matrixVar.Var] = true
+
+		if len(matrixVar.Values) == 0 {
+			return nil, fmt.Errorf("matrix var '%s' has no values", matrixVar.Var)
+		}
+
+		var expanded []map[string]string
+		for _, combination := range combinations {
+			for _, value := range matrixVar.Values {
+				next := map[string]string{}
+				for k, v := range combination {
+					next[k] = v
+				}
+
+				next[matrixVar.Var] = value
+				expanded = append(expanded, next)
+			}
+		}
+
+		combinations = expanded
+	}
+
+	return combinations, nil
+}
+
+func (config JobConfig) matrixSuffix(combination map[string]string) string {
+	var values []string
+	for _, matrixVar := range config.Matrix {
+		values = append(values, combination[matrixVar.Var])
+	}
+
+	return strings.Join(values, "-")
+}
+
+// copyJob makes a deep copy of the job, replacing references to the given
+// matrix vars with their values along the way.
+func copyJob(job JobConfig, combination map[string]string) (JobConfig, error) {
+	payload, err := json.Marshal(job)
+	if err != nil {
+		return JobConfig{}, err
+	}
+
+	var values map[string]interface{}
+	if combination != nil {
+		values = map[string]interface{}{}
+		for name, value := range combination {
+			values[name] = value
+		}
+	}
+
+	payload, err = interpolateLocalVarsJSON(payload, values)
+	if err != nil {
+		return JobConfig{}, err
+	}
+
+	var copied JobConfig
+	err = json.Unmarshal(payload, &copied)
+	if err != nil {
+		return JobConfig{}, err
+	}
+
+	return copied, nil
+}
+
+// copyTemplate makes a deep copy of the template, leaving any references to
+// its params in place.
+func copyTemplate(template StepTemplateConfig) (StepTemplateConfig, error) {
+	payload, err := json.Marshal(template)
+	if err != nil {
+		return StepTemplateConfig{}, err
+	}
+
+	var copied StepTemplateConfig
+	err = json.Unmarshal(payload, &copied)
+	if err != nil {
+		return StepTemplateConfig{}, err
+	}
+
+	return copied, nil
+}
+
+// interpolateLocalVarsJSON decodes the JSON payload, interpolates the given
+// vars into it and re-encodes it.
+func interpolateLocalVarsJSON(payload []byte, values map[string]interface{}) ([]byte, error) {
+	decoder := json.NewDecoder(bytes.NewReader(payload))
+	decoder.UseNumber()
+
+	var tree interface{}
+	err := decoder.Decode(&tree)
+	if err != nil {
+		return nil, err
+	}
+
+	if values != nil {
+		tree = interpolateLocalVars(tree, values)
+	}
+
+	return json.Marshal(tree)
+}
+
+// interpolateLocalVars replaces ((.:var)) references to the given vars within
+// the decoded JSON node. A reference making up an entire string is replaced
+// by the value itself, preserving its type.
+func interpolateLocalVars(node interface{}, values map[string]interface{}) interface{} {
+	switch typedNode := node.(type) {
+	case map[string]interface{}:
+		for k, v := range typedNode {
+			typedNode[k] = interpolateLocalVars(v, values)
+		}
+
+		return typedNode
+
+	case []interface{}:
+		for i, v := range typedNode {
+			typedNode[i] = interpolateLocalVars(v, values)
+		}
+
+		return typedNode
+
+	case string:
+		if match := localVarRegex.FindStringSubmatch(typedNode); match != nil && match[0] == typedNode {
+			if value, found := values[match[1]]; found {
+				return value
+			}
+		}
+
+		return localVarRegex.ReplaceAllStringFunc(typedNode, func(ref string) string {
+			name := localVarRegex.FindStringSubmatch(ref)[1]
+			if value, found := values[name]; found {
+				return fmt.Sprint(value)
+			}
+
+			return ref
+		})
+
+	default:
+		return node
+	}
+}
+
+func expandJobNames(names []string, expandedNames map[string][]string) []string {
+	if names == nil {
+		return nil
+	}
+
+	expanded := []string{}
+	for _, name := range names {
+		if generated, found := expandedNames[name]; found {
+			expanded = append(expanded, generated...)
+		} else {
+			expanded = append(expanded, name)
+		}
+	}
+
+	return expanded
+}
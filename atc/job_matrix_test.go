@@ -0,0 +1,342 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("ExpandJobMatrices", func() {
+	var (
+		payload  string
+		expanded atc.Config
+		err      error
+	)
+
+	JustBeforeEach(func() {
+		var config atc.Config
+		err = atc.UnmarshalConfig([]byte(payload), &config)
+		if err != nil {
+			return
+		}
+
+		expanded, err = config.ExpandJobMatrices()
+	})
+
+	Context("when no job has a matrix", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: some-job
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("leaves the jobs as they are", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Jobs).To(HaveLen(1))
+			Expect(expanded.Jobs[0].Name).To(Equal("some-job"))
+		})
+	})
+
+	Context("when a job has a matrix", func() {
+		BeforeEach(func() {
+			payload = `
+groups:
+- name: some-group
+  jobs: [unit, ship]
+
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: ["1.15", "1.16"]
+  - var: os
+    values: [linux, darwin]
+  plan:
+  - get: some-resource
+  - task: test
+    image: ((.:os))-image
+    config:
+      platform: ((.:os))
+      run:
+        path: go-((.:go))
+        args: [((.:other))]
+
+- name: ship
+  plan:
+  - get: some-resource
+    passed: [unit]
+
+- name: deploy
+  depends_on: [unit]
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("generates a job for every combination of values", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			var names []string
+			for _, job := range expanded.Jobs {
+				names = append(names, job.Name)
+			}
+
+			Expect(names).To(Equal([]string{
+				"unit-1.15-linux",
+				"unit-1.15-darwin",
+				"unit-1.16-linux",
+				"unit-1.16-darwin",
+				"ship",
+				"deploy",
+			}))
+		})
+
+		It("replaces references to the matrix vars with their values", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("unit-1.16-darwin")
+			Expect(found).To(BeTrue())
+			Expect(job.Matrix).To(BeEmpty())
+
+			task := job.PlanSequence[1].Config.(*atc.TaskStep)
+			Expect(task.ImageArtifactName).To(Equal("darwin-image"))
+			Expect(task.Config.Platform).To(Equal("darwin"))
+			Expect(task.Config.Run.Path).To(Equal("go-1.16"))
+		})
+
+		It("leaves references to other local vars alone", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("unit-1.15-linux")
+			Expect(found).To(BeTrue())
+
+			task := job.PlanSequence[1].Config.(*atc.TaskStep)
+			Expect(task.Config.Run.Args).To(Equal([]string{"((.:other))"}))
+		})
+
+		It("expands passed constraints to all generated jobs", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("ship")
+			Expect(found).To(BeTrue())
+
+			get := job.PlanSequence[0].Config.(*atc.GetStep)
+			Expect(get.Passed).To(Equal([]string{
+				"unit-1.15-linux",
+				"unit-1.15-darwin",
+				"unit-1.16-linux",
+				"unit-1.16-darwin",
+			}))
+		})
+
+		It("expands dependencies to all generated jobs", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("deploy")
+			Expect(found).To(BeTrue())
+			Expect(job.DependsOn).To(Equal([]string{
+				"unit-1.15-linux",
+				"unit-1.15-darwin",
+				"unit-1.16-linux",
+				"unit-1.16-darwin",
+			}))
+		})
+
+		It("expands groups to all generated jobs", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Groups[0].Jobs).To(Equal([]string{
+				"unit-1.15-linux",
+				"unit-1.15-darwin",
+				"unit-1.16-linux",
+				"unit-1.16-darwin",
+				"ship",
+			}))
+		})
+	})
+
+	Context("when a template has a passed constraint on a job with a matrix", func() {
+		BeforeEach(func() {
+			payload = `
+templates:
+- name: ship
+  step:
+    get: some-resource
+    passed: [unit]
+
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: ["1.15", "1.16"]
+  plan:
+  - get: some-resource
+
+- name: ship
+  plan:
+  - use: ship
+`
+		})
+
+		It("expands the passed constraint within the template", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			template, found := expanded.Templates.Lookup("ship")
+			Expect(found).To(BeTrue())
+
+			get := template.Step.Config.(*atc.GetStep)
+			Expect(get.Passed).To(Equal([]string{"unit-1.15", "unit-1.16"}))
+		})
+
+		It("expands the inputs of jobs using the template", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("ship")
+			Expect(found).To(BeTrue())
+
+			inputs := job.Inputs(expanded.Templates)
+			Expect(inputs).To(HaveLen(1))
+			Expect(inputs[0].Passed).To(Equal([]string{"unit-1.15", "unit-1.16"}))
+		})
+	})
+
+	Context("when the values of a matrix var are reordered", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: ["1.16", "1.15"]
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("generates the same job names", func() {
+			Expect(err).ToNot(HaveOccurred())
+			Expect(expanded.Jobs).To(HaveLen(2))
+			Expect(expanded.Jobs[0].Name).To(Equal("unit-1.16"))
+			Expect(expanded.Jobs[1].Name).To(Equal("unit-1.15"))
+		})
+	})
+
+	Context("when a matrix var has no values", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: []
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("job 'unit': matrix var 'go' has no values"))
+		})
+	})
+
+	Context("when a matrix var is declared more than once", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: ["1.15"]
+  - var: go
+    values: ["1.16"]
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(MatchError("job 'unit': matrix var 'go' is declared more than once"))
+		})
+	})
+
+	Context("when matrix values only differ in trailing zeros", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: ["1.10", "1.1"]
+  plan:
+  - get: some-resource
+  - task: test
+    config:
+      platform: linux
+      run:
+        path: ((.:go))
+`
+		})
+
+		It("uses the values as written", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			var names []string
+			for _, job := range expanded.Jobs {
+				names = append(names, job.Name)
+			}
+
+			Expect(names).To(Equal([]string{"unit-1.10", "unit-1.1"}))
+		})
+
+		It("replaces a reference making up an entire string field with the value", func() {
+			Expect(err).ToNot(HaveOccurred())
+
+			job, found := expanded.Jobs.Lookup("unit-1.10")
+			Expect(found).To(BeTrue())
+
+			task := job.PlanSequence[1].Config.(*atc.TaskStep)
+			Expect(task.Config.Run.Path).To(Equal("1.10"))
+		})
+	})
+
+	Context("when a matrix value is an unquoted number", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: [1.10, 1.20]
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matrix var 'go' has a value which is not a string (1.1); quote it so that it is used as written"))
+		})
+	})
+
+	Context("when a matrix value is not a scalar", func() {
+		BeforeEach(func() {
+			payload = `
+jobs:
+- name: unit
+  matrix:
+  - var: go
+    values: [{version: "1.15"}]
+  plan:
+  - get: some-resource
+`
+		})
+
+		It("returns an error", func() {
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("matrix var 'go' has a value which is not a string"))
+		})
+	})
+})
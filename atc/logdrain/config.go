@@ -0,0 +1,58 @@
+package logdrain
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// Config selects the sinks to which the events of completed builds are
+// shipped. Any number of them may be configured at once.
+type Config struct {
+	Interval time.Duration `long:"interval" default:"30s" description:"Interval on which to ship the events of completed builds to the configured sinks."`
+
+	JSONLines     JSONLines
+	Loki          Loki
+	Elasticsearch Elasticsearch
+}
+
+// Sinks returns the configured sinks.
+func (c Config) Sinks() []Sink {
+	var sinks []Sink
+
+	if c.JSONLines.IsConfigured() {
+		sinks = append(sinks, c.JSONLines.Sink())
+	}
+
+	if c.Loki.IsConfigured() {
+		sinks = append(sinks, c.Loki.Sink())
+	}
+
+	if c.Elasticsearch.IsConfigured() {
+		sinks = append(sinks, c.Elasticsearch.Sink())
+	}
+
+	return sinks
+}
+
+func send(client *http.Client, req *http.Request) error {
+	res, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return unexpectedStatus(res)
+	}
+
+	return nil
+}
+
+func unexpectedStatus(res *http.Response) error {
+	body, _ := ioutil.ReadAll(io.LimitReader(res.Body, 1024))
+	return fmt.Errorf("unexpected response from %s: %s: %s", res.Request.URL.Host, res.Status, body)
+}
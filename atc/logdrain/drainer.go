@@ -0,0 +1,219 @@
+package logdrain
+
+import (
+	"context"
+	"io"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc/db"
+)
+
+// batchSize is the maximum number of records handed to a sink at once.
+const batchSize = 500
+
+//go:generate counterfeiter . Sink
+
+// Sink ships the records of completed builds somewhere outside of Concourse.
+// Drain is called with consecutive batches of a build's records; if it fails,
+// the build is drained to the sink again from the start on a later run. Other
+// sinks are unaffected.
+//
+// Sinks which implement io.Closer are closed at the end of every run.
+type Sink interface {
+	// Name identifies the sink in the builds' drain progress, so it must not
+	// change across restarts.
+	Name() string
+
+	Drain(ctx context.Context, build db.Build, records []Record) error
+}
+
+//go:generate counterfeiter . Drainer
+
+type Drainer interface {
+	Run(context.Context) error
+}
+
+type drainer struct {
+	buildFactory db.BuildFactory
+	eventStore   db.BuildEventStore
+	sinks        []Sink
+	allSinks     []string
+}
+
+// NewDrainer returns a drainer shipping builds to the given sinks. Sinks which
+// are drained on different intervals are run by separate drainers, so every
+// drainer is given the names of all of the configured sinks: a build is only
+// marked as drained once it has been shipped to each of them.
+func NewDrainer(buildFactory db.BuildFactory, eventStore db.BuildEventStore, sinks []Sink, allSinks []string) Drainer {
+	return &drainer{
+		buildFactory: buildFactory,
+		eventStore:   eventStore,
+		sinks:        sinks,
+		allSinks:     allSinks,
+	}
+}
+
+func (d *drainer) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("log-drain")
+
+	defer closeSinks(logger, d.sinks)
+
+	builds, err := d.buildFactory.GetDrainableBuilds()
+	if err != nil {
+		logger.Error("failed-to-get-drainable-builds", err)
+		return err
+	}
+
+	// a sink which fails is left alone for the rest of the run, so that it
+	// doesn't hold up the others
+	failed := map[string]error{}
+
+	for _, build := range builds {
+		err := d.drainBuild(ctx, logger, build, failed)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, sink := range d.sinks {
+		if err, found := failed[sink.Name()]; found {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (d *drainer) drainBuild(ctx context.Context, logger lager.Logger, build db.Build, failed map[string]error) error {
+	logger = logger.Session("drain-build", build.LagerData())
+
+	drainedSinks, err := build.DrainedSinks()
+	if err != nil {
+		logger.Error("failed-to-get-drained-sinks", err)
+		return err
+	}
+
+	drained := map[string]bool{}
+	for _, name := range drainedSinks {
+		drained[name] = true
+	}
+
+	var pending []Sink
+	for _, sink := range d.sinks {
+		if !drained[sink.Name()] && failed[sink.Name()] == nil {
+			pending = append(pending, sink)
+		}
+	}
+
+	if len(pending) != 0 {
+		succeeded, err := d.drainToSinks(ctx, logger, build, pending, failed)
+		if err != nil {
+			return err
+		}
+
+		for _, sink := range succeeded {
+			err = build.SetDrainedSink(sink.Name())
+			if err != nil {
+				logger.Error("failed-to-update-drained-sinks", err)
+				return err
+			}
+
+			drained[sink.Name()] = true
+		}
+	}
+
+	for _, name := range d.allSinks {
+		if !drained[name] {
+			return nil
+		}
+	}
+
+	err = build.SetDrained(true)
+	if err != nil {
+		logger.Error("failed-to-update-status", err)
+		return err
+	}
+
+	return nil
+}
+
+// drainToSinks reads the build's events once, shipping them to each of the
+// given sinks, and returns the sinks which all of the records were shipped to.
+func (d *drainer) drainToSinks(ctx context.Context, logger lager.Logger, build db.Build, sinks []Sink, failed map[string]error) ([]Sink, error) {
+	events, err := d.eventStore.Events(build, 0)
+	if err != nil {
+		logger.Error("failed-to-get-events", err)
+		return nil, err
+	}
+
+	// ignore any errors coming from events.Close()
+	defer db.Close(events)
+
+	builder := newRecordBuilder(build)
+
+	records := make([]Record, 0, batchSize)
+	for {
+		ev, err := events.Next()
+		if err != nil {
+			if err == db.ErrEndOfBuildEventStream {
+				break
+			}
+
+			logger.Error("failed-to-get-next-event", err)
+			return nil, err
+		}
+
+		record, err := builder.record(ev)
+		if err != nil {
+			logger.Error("failed-to-unmarshal", err)
+			return nil, err
+		}
+
+		records = append(records, record)
+
+		if len(records) == batchSize {
+			sinks = drain(ctx, logger, build, sinks, records, failed)
+			if len(sinks) == 0 {
+				return nil, nil
+			}
+
+			records = make([]Record, 0, batchSize)
+		}
+	}
+
+	if len(records) > 0 {
+		sinks = drain(ctx, logger, build, sinks, records, failed)
+	}
+
+	return sinks, nil
+}
+
+// drain hands the records to each of the sinks and returns the sinks which
+// succeeded.
+func drain(ctx context.Context, logger lager.Logger, build db.Build, sinks []Sink, records []Record, failed map[string]error) []Sink {
+	var succeeded []Sink
+	for _, sink := range sinks {
+		err := sink.Drain(ctx, build, records)
+		if err != nil {
+			logger.Error("failed-to-drain", err, lager.Data{"sink": sink.Name()})
+			failed[sink.Name()] = err
+			continue
+		}
+
+		succeeded = append(succeeded, sink)
+	}
+
+	return succeeded
+}
+
+func closeSinks(logger lager.Logger, sinks []Sink) {
+	for _, sink := range sinks {
+		if closer, ok := sink.(io.Closer); ok {
+			err := closer.Close()
+			if err != nil {
+				logger.Error("failed-to-close-sink", err, lager.Data{"sink": sink.Name()})
+			}
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package logdrain_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logdrain"
+	"github.com/concourse/concourse/atc/logdrain/logdrainfakes"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func envelope(eventType string, payload string) event.Envelope {
+	msg := json.RawMessage(payload)
+	return event.Envelope{
+		Data:    &msg,
+		Event:   atc.EventType(eventType),
+		Version: "1.0",
+	}
+}
+
+var _ = Describe("Drainer", func() {
+	var (
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeEventStore   *dbfakes.FakeBuildEventStore
+		fakeBuild        *dbfakes.FakeBuild
+		fakeSink         *logdrainfakes.FakeSink
+		otherSink        *logdrainfakes.FakeSink
+
+		allSinks []string
+		drainer  logdrain.Drainer
+		runErr   error
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(123)
+		fakeBuild.NameReturns("42")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.StartTimeReturns(time.Unix(1533744500, 0))
+
+		plan := json.RawMessage(`{
+			"id": "do-id",
+			"do": [
+				{"id": "get-id", "get": {"name": "some-input", "type": "git"}},
+				{"id": "task-id", "task": {"name": "unit"}}
+			]
+		}`)
+		fakeBuild.PublicPlanReturns(&plan)
+
+		fakeEventStore = new(dbfakes.FakeBuildEventStore)
+		fakeEventStore.EventsStub = func(db.Build, uint) (db.EventSource, error) {
+			fakeEventSource := new(dbfakes.FakeEventSource)
+			fakeEventSource.NextReturnsOnCall(0, envelope("initialize-task", `{"origin":{"id":"task-id"},"time":1533744530}`), nil)
+			fakeEventSource.NextReturnsOnCall(1, envelope("log", `{"origin":{"id":"task-id","source":"stderr"},"time":1533744538,"payload":"some log"}`), nil)
+			fakeEventSource.NextReturnsOnCall(2, envelope("status", `{"status":"succeeded","time":1533744540}`), nil)
+			fakeEventSource.NextReturns(event.Envelope{}, db.ErrEndOfBuildEventStream)
+			return fakeEventSource, nil
+		}
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.GetDrainableBuildsReturns([]db.Build{fakeBuild}, nil)
+
+		fakeSink = new(logdrainfakes.FakeSink)
+		fakeSink.NameReturns("some-sink")
+		otherSink = new(logdrainfakes.FakeSink)
+		otherSink.NameReturns("other-sink")
+
+		allSinks = []string{"some-sink", "other-sink"}
+	})
+
+	JustBeforeEach(func() {
+		drainer = logdrain.NewDrainer(fakeBuildFactory, fakeEventStore, []logdrain.Sink{fakeSink, otherSink}, allSinks)
+	})
+
+	JustBeforeEach(func() {
+		runErr = drainer.Run(context.TODO())
+	})
+
+	It("ships labelled records of every event to each sink", func() {
+		Expect(runErr).NotTo(HaveOccurred())
+
+		Expect(fakeEventStore.EventsCallCount()).To(Equal(1))
+		build, from := fakeEventStore.EventsArgsForCall(0)
+		Expect(build).To(Equal(fakeBuild))
+		Expect(from).To(BeZero())
+
+		expected := []logdrain.Record{
+			{
+				Time:     time.Unix(1533744530, 0),
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Build:    "42",
+				BuildID:  123,
+				Step:     "unit",
+				StepID:   "task-id",
+				Event:    "initialize-task",
+			},
+			{
+				Time:     time.Unix(1533744538, 0),
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Build:    "42",
+				BuildID:  123,
+				Step:     "unit",
+				StepID:   "task-id",
+				Origin:   "stderr",
+				Event:    "log",
+				Message:  "some log",
+			},
+			{
+				Time:     time.Unix(1533744540, 0),
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Build:    "42",
+				BuildID:  123,
+				Event:    "status",
+				Message:  "succeeded",
+			},
+		}
+
+		for _, sink := range []*logdrainfakes.FakeSink{fakeSink, otherSink} {
+			Expect(sink.DrainCallCount()).To(Equal(1))
+			_, drainedBuild, records := sink.DrainArgsForCall(0)
+			Expect(drainedBuild).To(Equal(fakeBuild))
+			Expect(records).To(Equal(expected))
+		}
+	})
+
+	It("records that the build was drained to each sink", func() {
+		Expect(fakeBuild.SetDrainedSinkCallCount()).To(Equal(2))
+		Expect(fakeBuild.SetDrainedSinkArgsForCall(0)).To(Equal("some-sink"))
+		Expect(fakeBuild.SetDrainedSinkArgsForCall(1)).To(Equal("other-sink"))
+	})
+
+	It("marks the build as drained", func() {
+		Expect(fakeBuild.SetDrainedCallCount()).To(Equal(1))
+		Expect(fakeBuild.SetDrainedArgsForCall(0)).To(BeTrue())
+	})
+
+	Context("when the build has already been drained to a sink", func() {
+		BeforeEach(func() {
+			fakeBuild.DrainedSinksReturns([]string{"some-sink"}, nil)
+		})
+
+		It("only drains it to the other sinks", func() {
+			Expect(fakeSink.DrainCallCount()).To(BeZero())
+			Expect(otherSink.DrainCallCount()).To(Equal(1))
+
+			Expect(fakeBuild.SetDrainedSinkCallCount()).To(Equal(1))
+			Expect(fakeBuild.SetDrainedSinkArgsForCall(0)).To(Equal("other-sink"))
+		})
+
+		It("marks the build as drained", func() {
+			Expect(fakeBuild.SetDrainedCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when a sink drained by another drainer has yet to drain the build", func() {
+		BeforeEach(func() {
+			allSinks = append(allSinks, "syslog")
+		})
+
+		It("does not mark the build as drained", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeBuild.SetDrainedSinkCallCount()).To(Equal(2))
+			Expect(fakeBuild.SetDrainedCallCount()).To(BeZero())
+		})
+	})
+
+	Context("when a sink fails", func() {
+		var otherBuild *dbfakes.FakeBuild
+
+		BeforeEach(func() {
+			fakeSink.DrainReturns(errors.New("nope"))
+
+			otherBuild = new(dbfakes.FakeBuild)
+			fakeBuildFactory.GetDrainableBuildsReturns([]db.Build{fakeBuild, otherBuild}, nil)
+		})
+
+		It("returns the error without marking the build as drained", func() {
+			Expect(runErr).To(MatchError("nope"))
+			Expect(fakeBuild.SetDrainedCallCount()).To(BeZero())
+		})
+
+		It("still drains the build to the other sinks", func() {
+			Expect(otherSink.DrainCallCount()).To(Equal(2))
+
+			Expect(fakeBuild.SetDrainedSinkCallCount()).To(Equal(1))
+			Expect(fakeBuild.SetDrainedSinkArgsForCall(0)).To(Equal("other-sink"))
+		})
+
+		It("does not drain any more builds to the failing sink", func() {
+			Expect(fakeSink.DrainCallCount()).To(Equal(1))
+		})
+	})
+
+	Context("when there are no builds to drain", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.GetDrainableBuildsReturns(nil, nil)
+		})
+
+		It("does not call the sinks", func() {
+			Expect(runErr).NotTo(HaveOccurred())
+			Expect(fakeSink.DrainCallCount()).To(BeZero())
+		})
+	})
+})
@@ -0,0 +1,133 @@
+package logdrain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Elasticsearch indexes records through the Elasticsearch bulk API
+type Elasticsearch struct {
+	URL      string `long:"elasticsearch-url"      description:"URL of an Elasticsearch cluster to index build events in."`
+	Index    string `long:"elasticsearch-index"    description:"Index to add build events to." default:"concourse-build-events"`
+	Username string `long:"elasticsearch-username" description:"Username for basic auth against the Elasticsearch cluster."`
+	Password string `long:"elasticsearch-password" description:"Password for basic auth against the Elasticsearch cluster."`
+}
+
+// IsConfigured identifies if a URL has been set
+func (e Elasticsearch) IsConfigured() bool {
+	return e.URL != ""
+}
+
+// Sink returns a Sink which indexes into the configured cluster
+func (e Elasticsearch) Sink() Sink {
+	return NewElasticsearchSink(http.DefaultClient, e.URL, e.Index, e.Username, e.Password)
+}
+
+type elasticsearchSink struct {
+	client   *http.Client
+	url      string
+	index    string
+	username string
+	password string
+}
+
+func NewElasticsearchSink(client *http.Client, url string, index string, username string, password string) Sink {
+	return &elasticsearchSink{
+		client:   client,
+		url:      strings.TrimSuffix(url, "/") + "/_bulk",
+		index:    index,
+		username: username,
+		password: password,
+	}
+}
+
+type elasticsearchBulkAction struct {
+	Index struct {
+		Index string `json:"_index"`
+	} `json:"index"`
+}
+
+type elasticsearchBulkResponse struct {
+	Errors bool `json:"errors"`
+	Items  []struct {
+		Index struct {
+			Status int `json:"status"`
+			Error  struct {
+				Type   string `json:"type"`
+				Reason string `json:"reason"`
+			} `json:"error"`
+		} `json:"index"`
+	} `json:"items"`
+}
+
+func (s *elasticsearchSink) Name() string {
+	return "elasticsearch"
+}
+
+func (s *elasticsearchSink) Drain(ctx context.Context, build db.Build, records []Record) error {
+	var action elasticsearchBulkAction
+	action.Index.Index = s.index
+
+	body := new(bytes.Buffer)
+	enc := json.NewEncoder(body)
+
+	for _, record := range records {
+		err := enc.Encode(action)
+		if err != nil {
+			return err
+		}
+
+		err = enc.Encode(record)
+		if err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, body)
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/x-ndjson")
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	res, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer res.Body.Close()
+
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return unexpectedStatus(res)
+	}
+
+	// the bulk API responds with 200 even if some of the documents failed to
+	// be indexed
+	var response elasticsearchBulkResponse
+	err = json.NewDecoder(res.Body).Decode(&response)
+	if err != nil {
+		return err
+	}
+
+	if response.Errors {
+		for _, item := range response.Items {
+			if item.Index.Error.Type != "" {
+				return fmt.Errorf("failed to index build event: %s: %s", item.Index.Error.Type, item.Index.Error.Reason)
+			}
+		}
+
+		return fmt.Errorf("failed to index build events")
+	}
+
+	return nil
+}
@@ -0,0 +1,72 @@
+package logdrain
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// JSONLines appends records to a file, one JSON object per line
+type JSONLines struct {
+	Path string `long:"json-lines-path" description:"File to append build events to, one JSON object per line."`
+}
+
+// IsConfigured identifies if a path has been set
+func (j JSONLines) IsConfigured() bool {
+	return j.Path != ""
+}
+
+// Sink returns a Sink which appends to the configured file
+func (j JSONLines) Sink() Sink {
+	return NewJSONLinesSink(j.Path)
+}
+
+type jsonLinesSink struct {
+	path string
+
+	mu sync.Mutex
+}
+
+func NewJSONLinesSink(path string) Sink {
+	return &jsonLinesSink{
+		path: path,
+	}
+}
+
+func (s *jsonLinesSink) Name() string {
+	return "json-lines"
+}
+
+func (s *jsonLinesSink) Drain(ctx context.Context, build db.Build, records []Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// the file is reopened for every batch so that it can be rotated
+	file, err := os.OpenFile(s.path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	writer := bufio.NewWriter(file)
+	enc := json.NewEncoder(writer)
+
+	for _, record := range records {
+		err = enc.Encode(record)
+		if err != nil {
+			_ = file.Close()
+			return err
+		}
+	}
+
+	err = writer.Flush()
+	if err != nil {
+		_ = file.Close()
+		return err
+	}
+
+	return file.Close()
+}
@@ -0,0 +1,13 @@
+package logdrain_test
+
+import (
+	"testing"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+func TestLogDrain(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Log Drain Suite")
+}
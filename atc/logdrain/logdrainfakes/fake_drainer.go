@@ -1,11 +1,11 @@
 // Code generated by counterfeiter. DO NOT EDIT.
-package syslogfakes
+package logdrainfakes
 
 import (
 	"context"
 	"sync"
 
-	"github.com/concourse/concourse/atc/syslog"
+	"github.com/concourse/concourse/atc/logdrain"
 )
 
 type FakeDrainer struct {
@@ -108,4 +108,4 @@ func (fake *FakeDrainer) recordInvocation(key string, args []interface{}) {
 	fake.invocations[key] = append(fake.invocations[key], args)
 }
 
-var _ syslog.Drainer = new(FakeDrainer)
+var _ logdrain.Drainer = new(FakeDrainer)
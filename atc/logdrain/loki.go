@@ -0,0 +1,143 @@
+package logdrain
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/concourse/concourse/atc/db"
+)
+
+// Loki pushes records to a server implementing Loki's push API
+type Loki struct {
+	URL      string `long:"loki-url"       description:"URL of a Loki server to push build events to."`
+	TenantID string `long:"loki-tenant-id" description:"Tenant to push build events as, sent in the X-Scope-OrgID header."`
+	Username string `long:"loki-username"  description:"Username for basic auth against the Loki server."`
+	Password string `long:"loki-password"  description:"Password for basic auth against the Loki server."`
+}
+
+// IsConfigured identifies if a URL has been set
+func (l Loki) IsConfigured() bool {
+	return l.URL != ""
+}
+
+// Sink returns a Sink which pushes to the configured server
+func (l Loki) Sink() Sink {
+	return NewLokiSink(http.DefaultClient, l.URL, l.TenantID, l.Username, l.Password)
+}
+
+type lokiSink struct {
+	client   *http.Client
+	url      string
+	tenantID string
+	username string
+	password string
+}
+
+func NewLokiSink(client *http.Client, url string, tenantID string, username string, password string) Sink {
+	return &lokiSink{
+		client:   client,
+		url:      strings.TrimSuffix(url, "/") + "/loki/api/v1/push",
+		tenantID: tenantID,
+		username: username,
+		password: password,
+	}
+}
+
+type lokiPush struct {
+	Streams []*lokiStream `json:"streams"`
+}
+
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+func (s *lokiSink) Name() string {
+	return "loki"
+}
+
+func (s *lokiSink) Drain(ctx context.Context, build db.Build, records []Record) error {
+	push := lokiPush{}
+	streams := map[string]*lokiStream{}
+
+	for _, record := range records {
+		labels := lokiLabels(record)
+
+		key := lokiStreamKey(labels)
+		stream, found := streams[key]
+		if !found {
+			stream = &lokiStream{Stream: labels}
+			streams[key] = stream
+			push.Streams = append(push.Streams, stream)
+		}
+
+		line, err := json.Marshal(record)
+		if err != nil {
+			return err
+		}
+
+		stream.Values = append(stream.Values, [2]string{
+			strconv.FormatInt(record.Time.UnixNano(), 10),
+			string(line),
+		})
+	}
+
+	body, err := json.Marshal(push)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+
+	if s.tenantID != "" {
+		req.Header.Set("X-Scope-OrgID", s.tenantID)
+	}
+
+	if s.username != "" {
+		req.SetBasicAuth(s.username, s.password)
+	}
+
+	return send(s.client, req)
+}
+
+func lokiLabels(record Record) map[string]string {
+	labels := map[string]string{}
+
+	for name, value := range map[string]string{
+		"team":     record.Team,
+		"pipeline": record.Pipeline,
+		"job":      record.Job,
+		"build":    record.Build,
+		"step":     record.Step,
+		"origin":   record.Origin,
+		"event":    record.Event,
+	} {
+		// Loki rejects labels with empty values
+		if value != "" {
+			labels[name] = value
+		}
+	}
+
+	return labels
+}
+
+func lokiStreamKey(labels map[string]string) string {
+	return strings.Join([]string{
+		labels["team"],
+		labels["pipeline"],
+		labels["job"],
+		labels["build"],
+		labels["step"],
+		labels["origin"],
+		labels["event"],
+	}, "\x00")
+}
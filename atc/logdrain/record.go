@@ -0,0 +1,136 @@
+package logdrain
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+)
+
+// Record is a single build event as shipped to a sink, labelled with where
+// in the cluster it came from.
+type Record struct {
+	Time     time.Time `json:"time"`
+	Team     string    `json:"team"`
+	Pipeline string    `json:"pipeline,omitempty"`
+	Job      string    `json:"job,omitempty"`
+	Build    string    `json:"build"`
+	BuildID  int       `json:"build_id"`
+	Step     string    `json:"step,omitempty"`
+	StepID   string    `json:"step_id,omitempty"`
+	Origin   string    `json:"origin,omitempty"`
+	Event    string    `json:"event"`
+	Message  string    `json:"message,omitempty"`
+}
+
+// eventPayload holds the fields shared by the build events which are
+// interesting to ship; any of them may be missing for a given event type.
+type eventPayload struct {
+	Origin  event.Origin `json:"origin"`
+	Time    int64        `json:"time"`
+	Payload string       `json:"payload"`
+	Message string       `json:"message"`
+	Status  string       `json:"status"`
+}
+
+type recordBuilder struct {
+	build     db.Build
+	stepNames map[string]string
+	lastTime  time.Time
+}
+
+func newRecordBuilder(build db.Build) *recordBuilder {
+	return &recordBuilder{
+		build:     build,
+		stepNames: stepNames(build.PublicPlan()),
+		lastTime:  build.StartTime(),
+	}
+}
+
+func (b *recordBuilder) record(ev event.Envelope) (Record, error) {
+	var payload eventPayload
+	if ev.Data != nil {
+		err := json.Unmarshal(*ev.Data, &payload)
+		if err != nil {
+			return Record{}, err
+		}
+	}
+
+	// not every event carries a time, so fall back on the last one seen
+	if payload.Time != 0 {
+		b.lastTime = time.Unix(payload.Time, 0)
+	}
+
+	message := payload.Payload
+	if message == "" {
+		message = payload.Message
+	}
+
+	if message == "" {
+		message = payload.Status
+	}
+
+	return Record{
+		Time:     b.lastTime,
+		Team:     b.build.TeamName(),
+		Pipeline: b.build.PipelineName(),
+		Job:      b.build.JobName(),
+		Build:    b.build.Name(),
+		BuildID:  b.build.ID(),
+		Step:     b.stepNames[payload.Origin.ID.String()],
+		StepID:   payload.Origin.ID.String(),
+		Origin:   string(payload.Origin.Source),
+		Event:    string(ev.Event),
+		Message:  message,
+	}, nil
+}
+
+// stepNames maps the plan IDs found in a build's public plan to the names of
+// the steps they identify.
+func stepNames(plan *json.RawMessage) map[string]string {
+	names := map[string]string{}
+	if plan == nil {
+		return names
+	}
+
+	var tree interface{}
+	err := json.Unmarshal(*plan, &tree)
+	if err != nil {
+		return names
+	}
+
+	collectStepNames(tree, names)
+
+	return names
+}
+
+func collectStepNames(node interface{}, names map[string]string) {
+	switch n := node.(type) {
+	case map[string]interface{}:
+		if id, ok := n["id"].(string); ok {
+			for key, value := range n {
+				if key == "id" {
+					continue
+				}
+
+				step, ok := value.(map[string]interface{})
+				if !ok {
+					continue
+				}
+
+				if name, ok := step["name"].(string); ok {
+					names[id] = name
+				}
+			}
+		}
+
+		for _, value := range n {
+			collectStepNames(value, names)
+		}
+	case []interface{}:
+		for _, value := range n {
+			collectStepNames(value, names)
+		}
+	}
+}
@@ -0,0 +1,178 @@
+package logdrain_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/logdrain"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Sinks", func() {
+	var (
+		fakeBuild *dbfakes.FakeBuild
+		records   []logdrain.Record
+	)
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+
+		records = []logdrain.Record{
+			{
+				Time:     time.Unix(1533744538, 0).UTC(),
+				Team:     "some-team",
+				Pipeline: "some-pipeline",
+				Job:      "some-job",
+				Build:    "42",
+				BuildID:  123,
+				Step:     "unit",
+				StepID:   "task-id",
+				Origin:   "stdout",
+				Event:    "log",
+				Message:  "some log",
+			},
+			{
+				Time:    time.Unix(1533744540, 0).UTC(),
+				Team:    "some-team",
+				Build:   "42",
+				BuildID: 123,
+				Event:   "status",
+				Message: "succeeded",
+			},
+		}
+	})
+
+	Describe("JSONLines", func() {
+		var dir string
+
+		BeforeEach(func() {
+			var err error
+			dir, err = ioutil.TempDir("", "log-drain")
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		AfterEach(func() {
+			Expect(os.RemoveAll(dir)).To(Succeed())
+		})
+
+		It("appends a line per record", func() {
+			path := filepath.Join(dir, "events.jsonl")
+			sink := logdrain.JSONLines{Path: path}.Sink()
+
+			Expect(sink.Drain(context.TODO(), fakeBuild, records[:1])).To(Succeed())
+			Expect(sink.Drain(context.TODO(), fakeBuild, records[1:])).To(Succeed())
+
+			content, err := ioutil.ReadFile(path)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(string(content)).To(Equal(
+				`{"time":"2018-08-08T16:08:58Z","team":"some-team","pipeline":"some-pipeline","job":"some-job","build":"42","build_id":123,"step":"unit","step_id":"task-id","origin":"stdout","event":"log","message":"some log"}` + "\n" +
+					`{"time":"2018-08-08T16:09:00Z","team":"some-team","build":"42","build_id":123,"event":"status","message":"succeeded"}` + "\n",
+			))
+		})
+	})
+
+	Describe("Loki", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("pushes a stream per label set", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/loki/api/v1/push"),
+				ghttp.VerifyHeaderKV("X-Scope-OrgID", "some-tenant"),
+				ghttp.VerifyBasicAuth("some-user", "some-password"),
+				ghttp.VerifyJSON(`{
+					"streams": [
+						{
+							"stream": {"team":"some-team","pipeline":"some-pipeline","job":"some-job","build":"42","step":"unit","origin":"stdout","event":"log"},
+							"values": [["1533744538000000000", "{\"time\":\"2018-08-08T16:08:58Z\",\"team\":\"some-team\",\"pipeline\":\"some-pipeline\",\"job\":\"some-job\",\"build\":\"42\",\"build_id\":123,\"step\":\"unit\",\"step_id\":\"task-id\",\"origin\":\"stdout\",\"event\":\"log\",\"message\":\"some log\"}"]]
+						},
+						{
+							"stream": {"team":"some-team","build":"42","event":"status"},
+							"values": [["1533744540000000000", "{\"time\":\"2018-08-08T16:09:00Z\",\"team\":\"some-team\",\"build\":\"42\",\"build_id\":123,\"event\":\"status\",\"message\":\"succeeded\"}"]]
+						}
+					]
+				}`),
+				ghttp.RespondWith(http.StatusNoContent, nil),
+			))
+
+			sink := logdrain.Loki{
+				URL:      server.URL() + "/",
+				TenantID: "some-tenant",
+				Username: "some-user",
+				Password: "some-password",
+			}.Sink()
+
+			Expect(sink.Drain(context.TODO(), fakeBuild, records)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when the push is rejected", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadRequest, "entry out of order"))
+
+			sink := logdrain.Loki{URL: server.URL()}.Sink()
+
+			err := sink.Drain(context.TODO(), fakeBuild, records)
+			Expect(err).To(MatchError(ContainSubstring("entry out of order")))
+		})
+	})
+
+	Describe("Elasticsearch", func() {
+		var server *ghttp.Server
+
+		BeforeEach(func() {
+			server = ghttp.NewServer()
+		})
+
+		AfterEach(func() {
+			server.Close()
+		})
+
+		It("indexes the records in bulk", func() {
+			server.AppendHandlers(ghttp.CombineHandlers(
+				ghttp.VerifyRequest("POST", "/_bulk"),
+				ghttp.VerifyContentType("application/x-ndjson"),
+				ghttp.VerifyBody([]byte(
+					`{"index":{"_index":"some-index"}}`+"\n"+
+						`{"time":"2018-08-08T16:08:58Z","team":"some-team","pipeline":"some-pipeline","job":"some-job","build":"42","build_id":123,"step":"unit","step_id":"task-id","origin":"stdout","event":"log","message":"some log"}`+"\n"+
+						`{"index":{"_index":"some-index"}}`+"\n"+
+						`{"time":"2018-08-08T16:09:00Z","team":"some-team","build":"42","build_id":123,"event":"status","message":"succeeded"}`+"\n",
+				)),
+				ghttp.RespondWith(http.StatusOK, `{"errors":false,"items":[]}`),
+			))
+
+			sink := logdrain.Elasticsearch{URL: server.URL(), Index: "some-index"}.Sink()
+
+			Expect(sink.Drain(context.TODO(), fakeBuild, records)).To(Succeed())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		It("returns an error when documents fail to be indexed", func() {
+			server.AppendHandlers(ghttp.RespondWith(http.StatusOK, `{
+				"errors": true,
+				"items": [
+					{"index": {"status": 201}},
+					{"index": {"status": 400, "error": {"type": "mapper_parsing_exception", "reason": "bad time"}}}
+				]
+			}`))
+
+			sink := logdrain.Elasticsearch{URL: server.URL(), Index: "some-index"}.Sink()
+
+			err := sink.Drain(context.TODO(), fakeBuild, records)
+			Expect(err).To(MatchError("failed to index build event: mapper_parsing_exception: bad time"))
+		})
+	})
+})
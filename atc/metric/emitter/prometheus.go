@@ -38,6 +38,13 @@ type PrometheusEmitter struct {
 	buildsFinishedVec *prometheus.CounterVec
 	buildsSucceeded   prometheus.Counter
 
+	stepsCPUSeconds *prometheus.CounterVec
+	stepsDuration   *prometheus.HistogramVec
+	stepsMemoryPeak *prometheus.GaugeVec
+	stepsDisk       *prometheus.GaugeVec
+
+	policyChecksDuration *prometheus.HistogramVec
+
 	dbConnections  *prometheus.GaugeVec
 	dbQueriesTotal prometheus.Counter
 
@@ -254,6 +261,67 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 	)
 	prometheus.MustRegister(buildDurationsVec)
 
+	// step metrics
+	stepLabelNames := []string{"team", "pipeline", "job", "step", "step_type"}
+
+	stepsCPUSeconds := prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "cpu_seconds_total",
+			Help:      "Total CPU time in seconds consumed by step containers.",
+		},
+		stepLabelNames,
+	)
+	prometheus.MustRegister(stepsCPUSeconds)
+
+	stepsDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "duration_seconds",
+			Help:      "Time in seconds that step containers spent running.",
+			Buckets:   []float64{1, 60, 180, 300, 600, 900, 1200, 1800, 2700, 3600, 7200, 18000, 36000},
+		},
+		stepLabelNames,
+	)
+	prometheus.MustRegister(stepsDuration)
+
+	stepsMemoryPeak := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "memory_peak_bytes",
+			Help:      "Peak memory usage in bytes of the most recent run of a step.",
+		},
+		stepLabelNames,
+	)
+	prometheus.MustRegister(stepsMemoryPeak)
+
+	stepsDisk := prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "concourse",
+			Subsystem: "steps",
+			Name:      "disk_bytes",
+			Help:      "Size in bytes of the outputs of the most recent run of a step.",
+		},
+		stepLabelNames,
+	)
+	prometheus.MustRegister(stepsDisk)
+
+	// policy check metrics
+	policyChecksDuration := prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Namespace: "concourse",
+			Subsystem: "policy_checks",
+			Name:      "duration_seconds",
+			Help:      "Time in seconds taken by policy checks, by action and decision.",
+			Buckets:   []float64{0.001, 0.005, 0.01, 0.05, 0.1, 0.5, 1, 5},
+		},
+		[]string{"action", "decision"},
+	)
+	prometheus.MustRegister(policyChecksDuration)
+
 	// worker metrics
 	workerContainers := prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
@@ -431,6 +499,13 @@ func (config *PrometheusConfig) NewEmitter() (metric.Emitter, error) {
 		buildsFinishedVec: buildsFinishedVec,
 		buildsSucceeded:   buildsSucceeded,
 
+		stepsCPUSeconds: stepsCPUSeconds,
+		stepsDuration:   stepsDuration,
+		stepsMemoryPeak: stepsMemoryPeak,
+		stepsDisk:       stepsDisk,
+
+		policyChecksDuration: policyChecksDuration,
+
 		dbConnections:  dbConnections,
 		dbQueriesTotal: dbQueriesTotal,
 
@@ -506,6 +581,19 @@ func (emitter *PrometheusEmitter) Emit(logger lager.Logger, event metric.Event)
 			).Observe(event.Value)
 	case "build finished":
 		emitter.buildFinishedMetrics(logger, event)
+	case "step cpu seconds":
+		emitter.stepsCPUSeconds.With(stepLabels(event)).Add(event.Value)
+	case "step duration seconds":
+		emitter.stepsDuration.With(stepLabels(event)).Observe(event.Value)
+	case "step memory peak bytes":
+		emitter.stepsMemoryPeak.With(stepLabels(event)).Set(event.Value)
+	case "step disk bytes":
+		emitter.stepsDisk.With(stepLabels(event)).Set(event.Value)
+	case "policy check":
+		emitter.policyChecksDuration.With(prometheus.Labels{
+			"action":   event.Attributes["action"],
+			"decision": event.Attributes["decision"],
+		}).Observe(event.Value / 1000)
 	case "worker containers":
 		emitter.workerContainersMetric(logger, event)
 	case "worker volumes":
@@ -615,6 +703,18 @@ func (emitter *PrometheusEmitter) buildFinishedMetrics(logger lager.Logger, even
 	emitter.buildDurationsVec.WithLabelValues(team, pipeline, job).Observe(duration)
 }
 
+// stepLabels are left empty for dimensions the step's build lacks, e.g. the
+// pipeline and job of a one-off build.
+func stepLabels(event metric.Event) prometheus.Labels {
+	return prometheus.Labels{
+		"team":      event.Attributes["team"],
+		"pipeline":  event.Attributes["pipeline"],
+		"job":       event.Attributes["job"],
+		"step":      event.Attributes["step"],
+		"step_type": event.Attributes["step_type"],
+	}
+}
+
 func (emitter *PrometheusEmitter) workerContainersMetric(logger lager.Logger, event metric.Event) {
 	worker, exists := event.Attributes["worker"]
 	if !exists {
@@ -798,7 +898,7 @@ func (emitter *PrometheusEmitter) updateLastSeen(event metric.Event) {
 	}
 }
 
-//periodically remove stale metrics for workers
+// periodically remove stale metrics for workers
 func (emitter *PrometheusEmitter) periodicMetricGC() {
 	for {
 		emitter.mu.Lock()
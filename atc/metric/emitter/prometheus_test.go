@@ -188,7 +188,11 @@ var _ = Describe("PrometheusEmitter", func() {
 	})
 
 	JustBeforeEach(func() {
-		prometheusEmitter, err = prometheusConfig.NewEmitter()
+		// the emitter registers its collectors globally, so it may only be
+		// constructed once
+		if prometheusEmitter == nil {
+			prometheusEmitter, err = prometheusConfig.NewEmitter()
+		}
 	})
 
 	It("emits task waiting metric", func() {
@@ -210,4 +214,51 @@ var _ = Describe("PrometheusEmitter", func() {
 		Expect(string(body)).To(ContainSubstring("concourse_tasks_waiting{platform=\"darwin\",teamId=\"42\",workerTags=\"tester\"} 4"))
 		Expect(err).To(BeNil())
 	})
+
+	It("emits step resource usage metrics", func() {
+		attrs := map[string]string{
+			"team":      "some-team",
+			"pipeline":  "some-pipeline",
+			"job":       "some-job",
+			"step":      "some-task",
+			"step_type": "task",
+		}
+
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step cpu seconds", Value: 2, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step cpu seconds", Value: 3, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step memory peak bytes", Value: 1024, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step disk bytes", Value: 2048, Attributes: attrs})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "step duration seconds", Value: 30, Attributes: attrs})
+
+		res, err := http.Get(fmt.Sprintf("http://%s:%s/metrics", prometheusConfig.BindIP, prometheusConfig.BindPort))
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+
+		body, err := ioutil.ReadAll(res.Body)
+		Expect(err).NotTo(HaveOccurred())
+
+		labels := `job="some-job",pipeline="some-pipeline",step="some-task",step_type="task",team="some-team"`
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(body)).To(ContainSubstring("concourse_steps_cpu_seconds_total{" + labels + "} 5"))
+		Expect(string(body)).To(ContainSubstring("concourse_steps_memory_peak_bytes{" + labels + "} 1024"))
+		Expect(string(body)).To(ContainSubstring("concourse_steps_disk_bytes{" + labels + "} 2048"))
+		Expect(string(body)).To(ContainSubstring("concourse_steps_duration_seconds_sum{" + labels + "} 30"))
+	})
+
+	It("emits policy check metrics", func() {
+		prometheusEmitter.Emit(logger, metric.Event{Name: "policy check", Value: 2, Attributes: map[string]string{"action": "SaveConfig", "decision": "denied"}})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "policy check", Value: 3, Attributes: map[string]string{"action": "SaveConfig", "decision": "denied"}})
+		prometheusEmitter.Emit(logger, metric.Event{Name: "policy check", Value: 1, Attributes: map[string]string{"action": "UseImage", "decision": "allowed"}})
+
+		res, err := http.Get(fmt.Sprintf("http://%s:%s/metrics", prometheusConfig.BindIP, prometheusConfig.BindPort))
+		Expect(err).NotTo(HaveOccurred())
+		defer res.Body.Close()
+		body, _ := ioutil.ReadAll(res.Body)
+
+		Expect(res.StatusCode).To(Equal(http.StatusOK))
+		Expect(string(body)).To(ContainSubstring(`concourse_policy_checks_duration_seconds_count{action="SaveConfig",decision="denied"} 2`))
+		Expect(string(body)).To(ContainSubstring(`concourse_policy_checks_duration_seconds_sum{action="SaveConfig",decision="denied"} 0.005`))
+		Expect(string(body)).To(ContainSubstring(`concourse_policy_checks_duration_seconds_count{action="UseImage",decision="allowed"} 1`))
+	})
 })
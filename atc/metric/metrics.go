@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
@@ -135,6 +136,34 @@ func (event ArtifactCollectorDuration) Emit(logger lager.Logger) {
 	)
 }
 
+type KeyedCacheCollectorDuration struct {
+	Duration time.Duration
+}
+
+func (event KeyedCacheCollectorDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-keyed-cache-collector-duration"),
+		Event{
+			Name:  "gc: keyed cache collector duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
+type StoredArtifactCollectorDuration struct {
+	Duration time.Duration
+}
+
+func (event StoredArtifactCollectorDuration) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("gc-stored-artifact-collector-duration"),
+		Event{
+			Name:  "gc: stored artifact collector duration (ms)",
+			Value: ms(event.Duration),
+		},
+	)
+}
+
 type ContainerCollectorDuration struct {
 	Duration time.Duration
 }
@@ -459,6 +488,67 @@ func (event BuildFinished) Emit(logger lager.Logger) {
 	)
 }
 
+type StepResourceUsage struct {
+	Build db.Build
+	Usage atc.StepResourceUsage
+}
+
+func (event StepResourceUsage) Emit(logger lager.Logger) {
+	logger = logger.Session("step-resource-usage")
+
+	attrs := map[string]string{
+		"step":      event.Usage.StepName,
+		"step_type": event.Usage.StepType,
+	}
+	for k, v := range event.Build.TracingAttrs() {
+		attrs[k] = v
+	}
+
+	Metrics.emit(logger, Event{
+		Name:       "step cpu seconds",
+		Value:      event.Usage.CPUSeconds,
+		Attributes: attrs,
+	})
+
+	Metrics.emit(logger, Event{
+		Name:       "step memory peak bytes",
+		Value:      float64(event.Usage.PeakMemoryBytes),
+		Attributes: attrs,
+	})
+
+	Metrics.emit(logger, Event{
+		Name:       "step disk bytes",
+		Value:      float64(event.Usage.DiskBytes),
+		Attributes: attrs,
+	})
+
+	Metrics.emit(logger, Event{
+		Name:       "step duration seconds",
+		Value:      event.Usage.WallSeconds,
+		Attributes: attrs,
+	})
+}
+
+type PolicyCheck struct {
+	Action   string
+	Decision string
+	Duration time.Duration
+}
+
+func (event PolicyCheck) Emit(logger lager.Logger) {
+	Metrics.emit(
+		logger.Session("policy-check"),
+		Event{
+			Name:  "policy check",
+			Value: ms(event.Duration),
+			Attributes: map[string]string{
+				"action":   event.Action,
+				"decision": event.Decision,
+			},
+		},
+	)
+}
+
 func ms(duration time.Duration) float64 {
 	return float64(duration) / 1000000
 }
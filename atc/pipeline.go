@@ -22,6 +22,8 @@ type Pipeline struct {
 	TeamName     string         `json:"team_name"`
 	Display      *DisplayConfig `json:"display,omitempty"`
 	LastUpdated  int64          `json:"last_updated,omitempty"`
+
+	PolicyWarnings []string `json:"policy_warnings,omitempty"`
 }
 
 func (p Pipeline) Ref() PipelineRef {
@@ -0,0 +1,12 @@
+package atc
+
+// PipelineConfigVersion is an entry in the history of configs saved for a
+// pipeline. Config is only populated when fetching a single version.
+type PipelineConfigVersion struct {
+	Version   int     `json:"version"`
+	SetBy     string  `json:"set_by,omitempty"`
+	SetVia    string  `json:"set_via"`
+	BuildID   int     `json:"build_id,omitempty"`
+	CreatedAt int64   `json:"created_at"`
+	Config    *Config `json:"config,omitempty"`
+}
@@ -10,6 +10,7 @@ type Plan struct {
 	Task        *TaskPlan        `json:"task,omitempty"`
 	SetPipeline *SetPipelinePlan `json:"set_pipeline,omitempty"`
 	LoadVar     *LoadVarPlan     `json:"load_var,omitempty"`
+	Approval    *ApprovalPlan    `json:"approval,omitempty"`
 
 	Do         *DoPlan         `json:"do,omitempty"`
 	InParallel *InParallelPlan `json:"in_parallel,omitempty"`
@@ -22,6 +23,7 @@ type Plan struct {
 	OnError   *OnErrorPlan   `json:"on_error,omitempty"`
 	Ensure    *EnsurePlan    `json:"ensure,omitempty"`
 
+	If      *IfPlan      `json:"if,omitempty"`
 	Try     *TryPlan     `json:"try,omitempty"`
 	Timeout *TimeoutPlan `json:"timeout,omitempty"`
 	Retry   *RetryPlan   `json:"retry,omitempty"`
@@ -90,6 +92,10 @@ func (plan *Plan) Each(f func(*Plan)) {
 		plan.Ensure.Next.Each(f)
 	}
 
+	if plan.If != nil {
+		plan.If.Step.Each(f)
+	}
+
 	if plan.Try != nil {
 		plan.Try.Step.Each(f)
 	}
@@ -151,6 +157,11 @@ type TimeoutPlan struct {
 	Duration string `json:"duration"`
 }
 
+type IfPlan struct {
+	Step      Plan   `json:"step"`
+	Condition string `json:"condition"`
+}
+
 type TryPlan struct {
 	Step Plan `json:"step"`
 }
@@ -288,6 +299,10 @@ type TaskPlan struct {
 	InputMapping  map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping map[string]string `json:"output_mapping,omitempty"`
 
+	// Paths within the task's outputs to upload to the artifact store once the
+	// task succeeds, each in the form of <output>/<path>.
+	Artifacts []string `json:"artifacts,omitempty"`
+
 	// Resource types to have available for use when fetching the task's image.
 	//
 	// XXX(check-refactor): Eliminating this would be great - if we can replace
@@ -315,6 +330,10 @@ type LoadVarPlan struct {
 	Reveal bool   `json:"reveal,omitempty"`
 }
 
+type ApprovalPlan struct {
+	Name string `json:"name"`
+}
+
 type RetryPlan []Plan
 
 type DependentGetPlan struct {
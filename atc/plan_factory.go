@@ -43,6 +43,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.SetPipeline = &t
 	case LoadVarPlan:
 		plan.LoadVar = &t
+	case ApprovalPlan:
+		plan.Approval = &t
 	case CheckPlan:
 		plan.Check = &t
 	case OnAbortPlan:
@@ -55,6 +57,8 @@ func (factory PlanFactory) NewPlan(step PlanConfig) Plan {
 		plan.OnSuccess = &t
 	case OnFailurePlan:
 		plan.OnFailure = &t
+	case IfPlan:
+		plan.If = &t
 	case TryPlan:
 		plan.Try = &t
 	case TimeoutPlan:
@@ -3,13 +3,22 @@ package policy
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/metric"
 	"github.com/jessevdk/go-flags"
 )
 
 const ActionUseImage = "UseImage"
 
+const (
+	DecisionAllowed = "allowed"
+	DecisionWarned  = "warned"
+	DecisionDenied  = "denied"
+	DecisionErrored = "errored"
+)
+
 type PolicyCheckNotPass struct {
 	Reasons []string
 }
@@ -37,9 +46,12 @@ type PolicyCheckInput struct {
 	Data           interface{} `json:"data,omitempty"`
 }
 
+// PolicyCheckOutput is the result of a policy check. Warnings are reported
+// to the user but, unlike reasons, do not prevent the action.
 type PolicyCheckOutput struct {
-	Allowed bool
-	Reasons []string
+	Allowed  bool
+	Reasons  []string
+	Warnings []string
 }
 
 // FailedPolicyCheck creates a generic failed check
@@ -105,7 +117,10 @@ type Checker interface {
 	Check(input PolicyCheckInput) (PolicyCheckOutput, error)
 }
 
-func Initialize(logger lager.Logger, cluster string, version string, filter Filter) (Checker, error) {
+// Initialize returns a Checker for the configured policy agent. In audit-only
+// mode, checks which fail or error are logged but let through, with the
+// reasons they failed for turned into warnings.
+func Initialize(logger lager.Logger, cluster string, version string, filter Filter, auditOnly bool) (Checker, error) {
 	logger.Debug("policy-checker-initialize")
 
 	clusterName = cluster
@@ -132,8 +147,10 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 				lager.Data{"rfc": "https://github.com/concourse/rfcs/pull/41"})
 
 			return &AgentChecker{
-				filter: filter,
-				agent:  agent,
+				logger:    logger.Session("policy-checker"),
+				filter:    filter,
+				agent:     agent,
+				auditOnly: auditOnly,
 			}, nil
 		}
 	}
@@ -143,8 +160,10 @@ func Initialize(logger lager.Logger, cluster string, version string, filter Filt
 }
 
 type AgentChecker struct {
-	filter Filter
-	agent  Agent
+	logger    lager.Logger
+	filter    Filter
+	agent     Agent
+	auditOnly bool
 }
 
 func (c *AgentChecker) ShouldCheckHttpMethod(method string) bool {
@@ -174,7 +193,48 @@ func (c *AgentChecker) Check(input PolicyCheckInput) (PolicyCheckOutput, error)
 	input.Service = "concourse"
 	input.ClusterName = clusterName
 	input.ClusterVersion = clusterVersion
-	return c.agent.Check(input)
+
+	start := time.Now()
+	output, err := c.agent.Check(input)
+
+	decision := DecisionAllowed
+	if err != nil {
+		decision = DecisionErrored
+	} else if !output.Allowed {
+		decision = DecisionDenied
+	} else if len(output.Warnings) > 0 {
+		decision = DecisionWarned
+	}
+
+	metric.PolicyCheck{
+		Action:   input.Action,
+		Decision: decision,
+		Duration: time.Since(start),
+	}.Emit(c.logger)
+
+	if c.auditOnly && (decision == DecisionDenied || decision == DecisionErrored) {
+		data := lager.Data{
+			"action":   input.Action,
+			"team":     input.Team,
+			"pipeline": input.Pipeline,
+			"user":     input.User,
+		}
+
+		if err != nil {
+			c.logger.Error("audit-only-policy-check-errored", err, data)
+		} else {
+			data["reasons"] = output.Reasons
+			c.logger.Info("audit-only-policy-check-denied", data)
+		}
+
+		return PolicyCheckOutput{
+			Allowed:  true,
+			Reasons:  []string{},
+			Warnings: append(output.Warnings, output.Reasons...),
+		}, nil
+	}
+
+	return output, err
 }
 
 type NoopChecker struct{}
@@ -8,13 +8,15 @@ import (
 
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
 )
 
 var _ = Describe("Policy checker", func() {
 	var (
-		checker policy.Checker
-		filter  policy.Filter
-		err     error
+		checker   policy.Checker
+		filter    policy.Filter
+		auditOnly bool
+		err       error
 	)
 
 	BeforeEach(func() {
@@ -23,13 +25,14 @@ var _ = Describe("Policy checker", func() {
 			Actions:       []string{"do_1", "do_2"},
 			ActionsToSkip: []string{"skip_1", "skip_2"},
 		}
+		auditOnly = false
 
 		fakeAgent = new(policyfakes.FakeAgent)
 		fakeAgentFactory.NewAgentReturns(fakeAgent, nil)
 	})
 
 	JustBeforeEach(func() {
-		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter)
+		checker, err = policy.Initialize(testLogger, "some-cluster", "some-version", filter, auditOnly)
 	})
 
 	// fakeAgent is configured in BeforeSuite.
@@ -135,6 +138,24 @@ var _ = Describe("Policy checker", func() {
 					})
 				})
 
+				Context("when agent includes warnings", func() {
+					BeforeEach(func() {
+						fakeAgent.CheckReturns(
+							policy.PolicyCheckOutput{
+								Allowed:  true,
+								Warnings: []string{"a policy would rather you didn't do that"},
+							},
+							nil,
+						)
+					})
+
+					It("should pass and include warnings", func() {
+						Expect(checkErr).ToNot(HaveOccurred())
+						Expect(output.Allowed).To(BeTrue())
+						Expect(output.Warnings).To(ConsistOf("a policy would rather you didn't do that"))
+					})
+				})
+
 				Context("when agent says error", func() {
 					BeforeEach(func() {
 						fakeAgent.CheckReturns(policy.FailedPolicyCheck(), errors.New("some-error"))
@@ -146,6 +167,44 @@ var _ = Describe("Policy checker", func() {
 						Expect(output.Allowed).To(BeFalse())
 					})
 				})
+
+				Context("in audit-only mode", func() {
+					BeforeEach(func() {
+						auditOnly = true
+						input = policy.PolicyCheckInput{Action: "SaveConfig", Team: "some-team"}
+					})
+
+					Context("when agent says not-pass", func() {
+						BeforeEach(func() {
+							fakeAgent.CheckReturns(policy.PolicyCheckOutput{
+								Allowed: false,
+								Reasons: []string{"a policy says you can't do that"},
+							}, nil)
+						})
+
+						It("should pass, turning the reasons into warnings", func() {
+							Expect(checkErr).ToNot(HaveOccurred())
+							Expect(output.Allowed).To(BeTrue())
+							Expect(output.Reasons).To(BeEmpty())
+							Expect(output.Warnings).To(ConsistOf("a policy says you can't do that"))
+						})
+
+						It("should log the violation", func() {
+							Expect(testLogger).To(gbytes.Say("audit-only-policy-check-denied.*SaveConfig.*a policy says you can't do that"))
+						})
+					})
+
+					Context("when agent says error", func() {
+						BeforeEach(func() {
+							fakeAgent.CheckReturns(policy.FailedPolicyCheck(), errors.New("some-error"))
+						})
+
+						It("should pass", func() {
+							Expect(checkErr).ToNot(HaveOccurred())
+							Expect(output.Allowed).To(BeTrue())
+						})
+					})
+				})
 			})
 		})
 	})
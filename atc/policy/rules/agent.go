@@ -0,0 +1,163 @@
+package rules
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/fsnotify/fsnotify"
+	"sigs.k8s.io/yaml"
+)
+
+// reloadDelay coalesces the events of a single change to the rule file, e.g.
+// an editor replacing it or Kubernetes swapping a mounted config map.
+const reloadDelay = 100 * time.Millisecond
+
+type RulesConfig struct {
+	File string `long:"policy-rules-file" description:"Path to a YAML file of policy rules to check actions against in-process. The file is reloaded when it changes."`
+}
+
+func init() {
+	policy.RegisterAgent(&RulesConfig{})
+}
+
+func (c *RulesConfig) Description() string { return "Rule File" }
+func (c *RulesConfig) IsConfigured() bool  { return c.File != "" }
+
+func (c *RulesConfig) NewAgent(logger lager.Logger) (policy.Agent, error) {
+	agent := &Agent{
+		logger: logger,
+		file:   c.File,
+	}
+
+	err := agent.load()
+	if err != nil {
+		return nil, err
+	}
+
+	err = agent.watch()
+	if err != nil {
+		return nil, err
+	}
+
+	return agent, nil
+}
+
+type Agent struct {
+	logger lager.Logger
+	file   string
+
+	lock  sync.RWMutex
+	rules *RuleSet
+}
+
+func (agent *Agent) Check(input policy.PolicyCheckInput) (policy.PolicyCheckOutput, error) {
+	payload, err := json.Marshal(input)
+	if err != nil {
+		return policy.FailedPolicyCheck(), err
+	}
+
+	var document interface{}
+	err = json.Unmarshal(payload, &document)
+	if err != nil {
+		return policy.FailedPolicyCheck(), err
+	}
+
+	agent.lock.RLock()
+	rules := agent.rules
+	agent.lock.RUnlock()
+
+	reasons, warnings := rules.Evaluate(input, document)
+
+	return policy.PolicyCheckOutput{
+		Allowed:  len(reasons) == 0,
+		Reasons:  reasons,
+		Warnings: warnings,
+	}, nil
+}
+
+func (agent *Agent) load() error {
+	payload, err := ioutil.ReadFile(agent.file)
+	if err != nil {
+		return err
+	}
+
+	var rules RuleSet
+	err = yaml.UnmarshalStrict(payload, &rules)
+	if err != nil {
+		return err
+	}
+
+	err = rules.compile()
+	if err != nil {
+		return err
+	}
+
+	agent.lock.Lock()
+	agent.rules = &rules
+	agent.lock.Unlock()
+
+	return nil
+}
+
+// watch reloads the rules whenever the rule file changes. The directory is
+// watched rather than the file so that the file can be replaced, not just
+// written to, including by Kubernetes swapping its '..data' link. Rules
+// which fail to load are logged and the previous rules are kept.
+func (agent *Agent) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	err = watcher.Add(filepath.Dir(agent.file))
+	if err != nil {
+		watcher.Close()
+		return err
+	}
+
+	go func() {
+		defer watcher.Close()
+
+		var reload <-chan time.Time
+
+		for {
+			select {
+			case event, ok := <-watcher.Events:
+				if !ok {
+					return
+				}
+
+				name := filepath.Base(event.Name)
+				if name == filepath.Base(agent.file) || strings.HasPrefix(name, "..") {
+					reload = time.After(reloadDelay)
+				}
+
+			case err, ok := <-watcher.Errors:
+				if !ok {
+					return
+				}
+
+				agent.logger.Error("failed-to-watch-rules", err)
+
+			case <-reload:
+				reload = nil
+
+				err := agent.load()
+				if err != nil {
+					agent.logger.Error("failed-to-reload-rules", err)
+					continue
+				}
+
+				agent.logger.Info("reloaded-rules")
+			}
+		}
+	}()
+
+	return nil
+}
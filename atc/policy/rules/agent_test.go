@@ -0,0 +1,293 @@
+package rules_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/concourse/concourse/atc/policy/rules"
+	"sigs.k8s.io/yaml"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+)
+
+var _ = Describe("Rules agent", func() {
+	var (
+		logger   *lagertest.TestLogger
+		dir      string
+		file     string
+		content  string
+		agent    policy.Agent
+		agentErr error
+	)
+
+	writeRules := func(content string) {
+		Expect(ioutil.WriteFile(file, []byte(content), 0644)).To(Succeed())
+	}
+
+	pipelineInput := func(team, pipeline string, config string) policy.PolicyCheckInput {
+		var data interface{}
+		Expect(yaml.Unmarshal([]byte(config), &data)).To(Succeed())
+
+		return policy.PolicyCheckInput{
+			Action:   "SaveConfig",
+			Team:     team,
+			Pipeline: pipeline,
+			Data:     data,
+		}
+	}
+
+	BeforeEach(func() {
+		logger = lagertest.NewTestLogger("rules-test")
+		content = ""
+
+		var err error
+		dir, err = ioutil.TempDir("", "policy-rules")
+		Expect(err).NotTo(HaveOccurred())
+
+		file = filepath.Join(dir, "rules.yml")
+	})
+
+	JustBeforeEach(func() {
+		writeRules(content)
+		agent, agentErr = (&rules.RulesConfig{File: file}).NewAgent(logger)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(dir)
+	})
+
+	Context("with valid rules", func() {
+		BeforeEach(func() {
+			content = `
+rules:
+- name: no-privileged-tasks
+  actions: [SaveConfig]
+  when:
+  - path: data.jobs.*.plan.**.privileged
+    equals: true
+  reason: privileged tasks are not allowed
+
+- name: no-public-pipelines-in-main
+  teams: [main]
+  when:
+  - path: data.public
+    equals: true
+
+- name: only-approved-registries
+  actions: [Save*]
+  pipelines: [prod-*]
+  when:
+  - path: data.resource_types.*.source.repository
+    not_matches: registry.example.com/*
+
+- name: pinned-versions-only
+  actions: [SaveConfig]
+  when:
+  - path: data.resources.*.version
+    exists: false
+  - path: data.resources.*.type
+    matches: git
+
+- name: no-latest-images
+  level: warn
+  actions: [UseImage]
+  when:
+  - path: data.image_source.tag
+    equals: latest
+  reason: images should be pinned to a tag other than latest
+
+- name: no-admins
+  actions: [SetTeam]
+  when:
+  - path: data.auth.*.users.*
+    not_equals: local:readonly
+`
+		})
+
+		It("loads them", func() {
+			Expect(agentErr).NotTo(HaveOccurred())
+		})
+
+		It("allows inputs that violate no rules", func() {
+			result, err := agent.Check(pipelineInput("main", "some-pipeline", `
+jobs:
+- name: some-job
+  plan:
+  - task: unprivileged
+    privileged: false
+resources:
+- name: repo
+  type: git
+  version: {ref: abc}
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.Reasons).To(BeEmpty())
+		})
+
+		It("finds values at any depth with '**'", func() {
+			result, err := agent.Check(pipelineInput("other", "some-pipeline", `
+jobs:
+- name: some-job
+  plan:
+  - in_parallel:
+      steps:
+      - do:
+        - task: nested
+          privileged: true
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reasons).To(ConsistOf("privileged tasks are not allowed"))
+		})
+
+		It("only applies rules to the matching teams", func() {
+			result, err := agent.Check(pipelineInput("other", "some-pipeline", "public: true"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+
+			result, err = agent.Check(pipelineInput("main", "some-pipeline", "public: true"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reasons).To(ConsistOf("violates policy rule 'no-public-pipelines-in-main'"))
+		})
+
+		It("only applies rules to the matching pipelines", func() {
+			config := `
+resource_types:
+- name: approved
+  source: {repository: registry.example.com/approved}
+- name: unapproved
+  source: {repository: docker.io/unapproved}
+`
+
+			result, err := agent.Check(pipelineInput("main", "dev-pipeline", config))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+
+			result, err = agent.Check(pipelineInput("main", "prod-pipeline", config))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reasons).To(ConsistOf("violates policy rule 'only-approved-registries'"))
+		})
+
+		It("requires every condition of a rule to hold", func() {
+			result, err := agent.Check(pipelineInput("main", "some-pipeline", `
+resources:
+- name: image
+  type: registry-image
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+
+			result, err = agent.Check(pipelineInput("main", "some-pipeline", `
+resources:
+- name: repo
+  type: git
+`))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reasons).To(ConsistOf("violates policy rule 'pinned-versions-only'"))
+		})
+
+		It("only applies rules to the matching actions", func() {
+			input := policy.PolicyCheckInput{
+				Action: "SetTeam",
+				Team:   "main",
+				Data: map[string]interface{}{
+					"auth": map[string]interface{}{
+						"owner": map[string]interface{}{
+							"users": []string{"local:admin"},
+						},
+					},
+				},
+			}
+
+			result, err := agent.Check(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+			Expect(result.Reasons).To(ConsistOf("violates policy rule 'no-admins'"))
+
+			input.Action = "SaveConfig"
+			result, err = agent.Check(input)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+		})
+
+		It("only warns about inputs that violate rules with the warn level", func() {
+			result, err := agent.Check(policy.PolicyCheckInput{
+				Action: "UseImage",
+				Data: map[string]interface{}{
+					"image_type":   "registry-image",
+					"image_source": map[string]interface{}{"repository": "busybox", "tag": "latest"},
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeTrue())
+			Expect(result.Reasons).To(BeEmpty())
+			Expect(result.Warnings).To(ConsistOf("images should be pinned to a tag other than latest"))
+		})
+
+		It("reloads the rules when the file changes", func() {
+			input := pipelineInput("other", "some-pipeline", "public: true")
+
+			writeRules(`
+rules:
+- name: no-public-pipelines
+  when:
+  - path: data.public
+    equals: true
+`)
+
+			Eventually(func() bool {
+				result, err := agent.Check(input)
+				Expect(err).NotTo(HaveOccurred())
+				return result.Allowed
+			}).Should(BeFalse())
+		})
+
+		It("keeps the previous rules when the file becomes invalid", func() {
+			writeRules("rules: [")
+
+			Eventually(logger.Buffer()).Should(gbytes.Say("failed-to-reload-rules"))
+
+			result, err := agent.Check(pipelineInput("main", "some-pipeline", "public: true"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.Allowed).To(BeFalse())
+		})
+	})
+
+	Context("when the file does not exist", func() {
+		JustBeforeEach(func() {
+			os.Remove(file)
+			agent, agentErr = (&rules.RulesConfig{File: file}).NewAgent(logger)
+		})
+
+		It("fails", func() {
+			Expect(agentErr).To(HaveOccurred())
+		})
+	})
+
+	DescribeTable("invalid rules",
+		func(content string, message string) {
+			writeRules(content)
+			_, err := (&rules.RulesConfig{File: file}).NewAgent(logger)
+			Expect(err).To(MatchError(ContainSubstring(message)))
+		},
+		Entry("unknown fields", "rules:\n- name: a\n  wen: []\n", "unknown field"),
+		Entry("missing names", "rules:\n- when: [{path: a, exists: true}]\n", "rule #1 has no name"),
+		Entry("duplicate names", "rules:\n- name: a\n  when: [{path: a, exists: true}]\n- name: a\n  when: [{path: a, exists: true}]\n", "duplicate rule name 'a'"),
+		Entry("no conditions", "rules:\n- name: a\n", "rule 'a': no conditions given"),
+		Entry("no path", "rules:\n- name: a\n  when: [{exists: true}]\n", "condition #1: no path given"),
+		Entry("several operators", "rules:\n- name: a\n  when: [{path: a, equals: 1, exists: true}]\n", "exactly one of"),
+		Entry("no operator", "rules:\n- name: a\n  when: [{path: a}]\n", "exactly one of"),
+		Entry("unknown levels", "rules:\n- name: a\n  level: info\n  when: [{path: a, exists: true}]\n", "rule 'a': unknown level 'info'"),
+		Entry("invalid patterns", "rules:\n- name: a\n  teams: ['[']\n  when: [{path: a, exists: true}]\n", "invalid pattern '['"),
+	)
+})
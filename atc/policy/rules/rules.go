@@ -0,0 +1,331 @@
+package rules
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/concourse/concourse/atc/policy"
+	"github.com/gobwas/glob"
+)
+
+// RuleSet is the content of a rule file. Every rule which applies to an input
+// and whose conditions all hold denies it, giving its reason, or only warns
+// about it if its level is 'warn'.
+//
+//	rules:
+//	- name: no-privileged-tasks
+//	  actions: [SaveConfig]
+//	  when:
+//	  - path: data.jobs.*.plan.**.privileged
+//	    equals: true
+//	  reason: privileged tasks are not allowed
+//	  level: warn
+type RuleSet struct {
+	Rules []Rule `json:"rules"`
+}
+
+const (
+	LevelDeny = "deny"
+	LevelWarn = "warn"
+)
+
+type Rule struct {
+	Name   string `json:"name"`
+	Reason string `json:"reason,omitempty"`
+	Level  string `json:"level,omitempty"`
+
+	// Actions, Teams and Pipelines restrict the inputs the rule applies to,
+	// by glob. A rule applies to every input if they are left empty.
+	Actions   []string `json:"actions,omitempty"`
+	Teams     []string `json:"teams,omitempty"`
+	Pipelines []string `json:"pipelines,omitempty"`
+
+	When []Condition `json:"when"`
+
+	actions   []glob.Glob
+	teams     []glob.Glob
+	pipelines []glob.Glob
+}
+
+// Condition tests the values found at a path in the input. A path is a
+// dot-separated list of keys, where '*' matches any key or list element and
+// '**' matches any number of them. Every condition but 'exists: false' holds
+// if any value found satisfies it.
+type Condition struct {
+	Path string `json:"path"`
+
+	Equals     interface{} `json:"equals,omitempty"`
+	NotEquals  interface{} `json:"not_equals,omitempty"`
+	Matches    string      `json:"matches,omitempty"`
+	NotMatches string      `json:"not_matches,omitempty"`
+	Exists     *bool       `json:"exists,omitempty"`
+
+	path []string
+	glob glob.Glob
+}
+
+func (set *RuleSet) compile() error {
+	names := map[string]bool{}
+
+	for i := range set.Rules {
+		rule := &set.Rules[i]
+
+		if rule.Name == "" {
+			return fmt.Errorf("rule #%d has no name", i+1)
+		}
+
+		if names[rule.Name] {
+			return fmt.Errorf("duplicate rule name '%s'", rule.Name)
+		}
+		names[rule.Name] = true
+
+		err := rule.compile()
+		if err != nil {
+			return fmt.Errorf("rule '%s': %w", rule.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func (rule *Rule) compile() error {
+	switch rule.Level {
+	case "":
+		rule.Level = LevelDeny
+	case LevelDeny, LevelWarn:
+	default:
+		return fmt.Errorf("unknown level '%s'", rule.Level)
+	}
+
+	var err error
+
+	rule.actions, err = compileGlobs(rule.Actions)
+	if err != nil {
+		return err
+	}
+
+	rule.teams, err = compileGlobs(rule.Teams)
+	if err != nil {
+		return err
+	}
+
+	rule.pipelines, err = compileGlobs(rule.Pipelines)
+	if err != nil {
+		return err
+	}
+
+	if len(rule.When) == 0 {
+		return errors.New("no conditions given")
+	}
+
+	for i := range rule.When {
+		err = rule.When[i].compile()
+		if err != nil {
+			return fmt.Errorf("condition #%d: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (condition *Condition) compile() error {
+	if condition.Path == "" {
+		return errors.New("no path given")
+	}
+
+	condition.path = strings.Split(condition.Path, ".")
+
+	operators := 0
+	for _, set := range []bool{
+		condition.Equals != nil,
+		condition.NotEquals != nil,
+		condition.Matches != "",
+		condition.NotMatches != "",
+		condition.Exists != nil,
+	} {
+		if set {
+			operators++
+		}
+	}
+
+	if operators != 1 {
+		return errors.New("exactly one of equals, not_equals, matches, not_matches or exists must be given")
+	}
+
+	pattern := condition.Matches
+	if pattern == "" {
+		pattern = condition.NotMatches
+	}
+
+	if pattern != "" {
+		var err error
+		condition.glob, err = glob.Compile(pattern)
+		if err != nil {
+			return fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+	}
+
+	return nil
+}
+
+func compileGlobs(patterns []string) ([]glob.Glob, error) {
+	globs := make([]glob.Glob, len(patterns))
+	for i, pattern := range patterns {
+		g, err := glob.Compile(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern '%s': %w", pattern, err)
+		}
+
+		globs[i] = g
+	}
+
+	return globs, nil
+}
+
+// Evaluate returns the reasons of every rule the input violates, split by
+// whether the rule denies or warns. The document is the input marshalled to
+// JSON and back, so that it only holds maps, slices and JSON scalars.
+func (set *RuleSet) Evaluate(input policy.PolicyCheckInput, document interface{}) ([]string, []string) {
+	reasons := []string{}
+	warnings := []string{}
+
+	for _, rule := range set.Rules {
+		if !rule.appliesTo(input) {
+			continue
+		}
+
+		if rule.violatedBy(document) {
+			reason := rule.Reason
+			if reason == "" {
+				reason = fmt.Sprintf("violates policy rule '%s'", rule.Name)
+			}
+
+			if rule.Level == LevelWarn {
+				warnings = append(warnings, reason)
+			} else {
+				reasons = append(reasons, reason)
+			}
+		}
+	}
+
+	return reasons, warnings
+}
+
+func (rule Rule) appliesTo(input policy.PolicyCheckInput) bool {
+	return matchesAny(rule.actions, input.Action) &&
+		matchesAny(rule.teams, input.Team) &&
+		matchesAny(rule.pipelines, input.Pipeline)
+}
+
+func (rule Rule) violatedBy(document interface{}) bool {
+	for _, condition := range rule.When {
+		if !condition.holds(document) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (condition Condition) holds(document interface{}) bool {
+	values := lookup(document, condition.path)
+
+	if condition.Exists != nil {
+		return (len(values) > 0) == *condition.Exists
+	}
+
+	for _, value := range values {
+		switch {
+		case condition.Equals != nil:
+			if reflect.DeepEqual(value, condition.Equals) {
+				return true
+			}
+
+		case condition.NotEquals != nil:
+			if !reflect.DeepEqual(value, condition.NotEquals) {
+				return true
+			}
+
+		case condition.Matches != "":
+			if s, ok := value.(string); ok && condition.glob.Match(s) {
+				return true
+			}
+
+		case condition.NotMatches != "":
+			if s, ok := value.(string); ok && !condition.glob.Match(s) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func matchesAny(globs []glob.Glob, value string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+
+	for _, g := range globs {
+		if g.Match(value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// lookup returns every value found at the path within the document.
+func lookup(document interface{}, path []string) []interface{} {
+	if len(path) == 0 {
+		return []interface{}{document}
+	}
+
+	segment, rest := path[0], path[1:]
+
+	switch segment {
+	case "*":
+		var values []interface{}
+		for _, child := range children(document) {
+			values = append(values, lookup(child, rest)...)
+		}
+		return values
+
+	case "**":
+		values := lookup(document, rest)
+		for _, child := range children(document) {
+			values = append(values, lookup(child, path)...)
+		}
+		return values
+
+	default:
+		m, ok := document.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+
+		child, found := m[segment]
+		if !found {
+			return nil
+		}
+
+		return lookup(child, rest)
+	}
+}
+
+func children(document interface{}) []interface{} {
+	switch d := document.(type) {
+	case map[string]interface{}:
+		values := make([]interface{}, 0, len(d))
+		for _, v := range d {
+			values = append(values, v)
+		}
+		return values
+	case []interface{}:
+		return d
+	default:
+		return nil
+	}
+}
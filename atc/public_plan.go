@@ -16,11 +16,13 @@ func (plan Plan) Public() *json.RawMessage {
 		Task           *json.RawMessage `json:"task,omitempty"`
 		SetPipeline    *json.RawMessage `json:"set_pipeline,omitempty"`
 		LoadVar        *json.RawMessage `json:"load_var,omitempty"`
+		Approval       *json.RawMessage `json:"approval,omitempty"`
 		OnAbort        *json.RawMessage `json:"on_abort,omitempty"`
 		OnError        *json.RawMessage `json:"on_error,omitempty"`
 		Ensure         *json.RawMessage `json:"ensure,omitempty"`
 		OnSuccess      *json.RawMessage `json:"on_success,omitempty"`
 		OnFailure      *json.RawMessage `json:"on_failure,omitempty"`
+		If             *json.RawMessage `json:"if,omitempty"`
 		Try            *json.RawMessage `json:"try,omitempty"`
 		DependentGet   *json.RawMessage `json:"dependent_get,omitempty"`
 		Timeout        *json.RawMessage `json:"timeout,omitempty"`
@@ -71,6 +73,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.LoadVar = plan.LoadVar.Public()
 	}
 
+	if plan.Approval != nil {
+		public.Approval = plan.Approval.Public()
+	}
+
 	if plan.OnAbort != nil {
 		public.OnAbort = plan.OnAbort.Public()
 	}
@@ -91,6 +97,10 @@ func (plan Plan) Public() *json.RawMessage {
 		public.OnFailure = plan.OnFailure.Public()
 	}
 
+	if plan.If != nil {
+		public.If = plan.If.Public()
+	}
+
 	if plan.Try != nil {
 		public.Try = plan.Try.Public()
 	}
@@ -309,6 +319,14 @@ func (plan LoadVarPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan ApprovalPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Name string `json:"name"`
+	}{
+		Name: plan.Name,
+	})
+}
+
 func (plan TimeoutPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step     *json.RawMessage `json:"step"`
@@ -319,6 +337,16 @@ func (plan TimeoutPlan) Public() *json.RawMessage {
 	})
 }
 
+func (plan IfPlan) Public() *json.RawMessage {
+	return enc(struct {
+		Step      *json.RawMessage `json:"step"`
+		Condition string           `json:"condition"`
+	}{
+		Step:      plan.Step.Public(),
+		Condition: plan.Condition,
+	})
+}
+
 func (plan TryPlan) Public() *json.RawMessage {
 	return enc(struct {
 		Step *json.RawMessage `json:"step"`
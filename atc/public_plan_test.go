@@ -394,6 +394,28 @@ var _ = Describe("Plan", func() {
 							FailFast: true,
 						},
 					},
+					atc.Plan{
+						ID: "41",
+						If: &atc.IfPlan{
+							Step: atc.Plan{
+								ID: "42",
+								Task: &atc.TaskPlan{
+									Name:       "name",
+									ConfigPath: "some/config/path.yml",
+									Config: &atc.TaskConfig{
+										Params: atc.TaskEnv{"some": "secret"},
+									},
+								},
+							},
+							Condition: "((.:go))",
+						},
+					},
+					atc.Plan{
+						ID: "43",
+						Approval: &atc.ApprovalPlan{
+							Name: "deploy",
+						},
+					},
 				},
 			}
 
@@ -690,6 +712,25 @@ var _ = Describe("Plan", func() {
 	    ],
 	    "fail_fast": true
 	  }
+	},
+	{
+	  "id": "41",
+	  "if": {
+	    "step": {
+	      "id": "42",
+	      "task": {
+	        "name": "name",
+	        "privileged": false
+	      }
+	    },
+	    "condition": "((.:go))"
+	  }
+	},
+	{
+	  "id": "43",
+	  "approval": {
+	    "name": "deploy"
+	  }
 	}
   ]
 }
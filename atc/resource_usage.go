@@ -0,0 +1,23 @@
+package atc
+
+// StepResourceUsage is what the container of a step consumed while its
+// process ran, as reported by the worker's container runtime.
+type StepResourceUsage struct {
+	PlanID   PlanID `json:"plan_id"`
+	StepName string `json:"step_name"`
+	StepType string `json:"step_type"`
+
+	// CPUSeconds is the CPU time spent by every process in the container.
+	CPUSeconds float64 `json:"cpu_seconds"`
+
+	// PeakMemoryBytes is the highest memory usage observed while the process
+	// ran, counted the same way as memory limits are enforced.
+	PeakMemoryBytes uint64 `json:"peak_memory_bytes"`
+
+	// DiskBytes is the disk space used by the container when its process
+	// exited.
+	DiskBytes uint64 `json:"disk_bytes"`
+
+	// WallSeconds is how long the process ran for.
+	WallSeconds float64 `json:"wall_seconds"`
+}
@@ -6,6 +6,9 @@ const (
 	SaveConfig = "SaveConfig"
 	GetConfig  = "GetConfig"
 
+	ListPipelineConfigVersions = "ListPipelineConfigVersions"
+	GetPipelineConfigVersion   = "GetPipelineConfigVersion"
+
 	GetBuild            = "GetBuild"
 	GetBuildPlan        = "GetBuildPlan"
 	CreateBuild         = "CreateBuild"
@@ -13,6 +16,8 @@ const (
 	BuildEvents         = "BuildEvents"
 	BuildResources      = "BuildResources"
 	AbortBuild          = "AbortBuild"
+	ApproveBuild        = "ApproveBuild"
+	RejectBuild         = "RejectBuild"
 	GetBuildPreparation = "GetBuildPreparation"
 
 	GetJob         = "GetJob"
@@ -100,10 +105,21 @@ const (
 	DestroyTeam    = "DestroyTeam"
 	ListTeamBuilds = "ListTeamBuilds"
 
+	ListAuditEvents    = "ListAuditEvents"
+	ListAllAuditEvents = "ListAllAuditEvents"
+
+	ListWebhooks          = "ListWebhooks"
+	SetWebhook            = "SetWebhook"
+	DestroyWebhook        = "DestroyWebhook"
+	ListWebhookDeliveries = "ListWebhookDeliveries"
+
 	CreateArtifact     = "CreateArtifact"
 	GetArtifact        = "GetArtifact"
 	ListBuildArtifacts = "ListBuildArtifacts"
 
+	ListBuildStoredArtifacts = "ListBuildStoredArtifacts"
+	GetBuildStoredArtifact   = "GetBuildStoredArtifact"
+
 	GetUser              = "GetUser"
 	ListActiveUsersSince = "ListActiveUsersSince"
 
@@ -120,6 +136,8 @@ const (
 var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "PUT", Name: SaveConfig},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config", Method: "GET", Name: GetConfig},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions", Method: "GET", Name: ListPipelineConfigVersions},
+	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/config/versions/:config_version", Method: "GET", Name: GetPipelineConfigVersion},
 
 	{Path: "/api/v1/teams/:team_name/builds", Method: "POST", Name: CreateBuild},
 
@@ -129,8 +147,12 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/builds/:build_id/events", Method: "GET", Name: BuildEvents},
 	{Path: "/api/v1/builds/:build_id/resources", Method: "GET", Name: BuildResources},
 	{Path: "/api/v1/builds/:build_id/abort", Method: "PUT", Name: AbortBuild},
+	{Path: "/api/v1/builds/:build_id/approve", Method: "PUT", Name: ApproveBuild},
+	{Path: "/api/v1/builds/:build_id/reject", Method: "PUT", Name: RejectBuild},
 	{Path: "/api/v1/builds/:build_id/preparation", Method: "GET", Name: GetBuildPreparation},
 	{Path: "/api/v1/builds/:build_id/artifacts", Method: "GET", Name: ListBuildArtifacts},
+	{Path: "/api/v1/builds/:build_id/stored_artifacts", Method: "GET", Name: ListBuildStoredArtifacts},
+	{Path: "/api/v1/builds/:build_id/stored_artifacts/:artifact_id", Method: "GET", Name: GetBuildStoredArtifact},
 
 	{Path: "/api/v1/jobs", Method: "GET", Name: ListAllJobs},
 	{Path: "/api/v1/teams/:team_name/pipelines/:pipeline_name/jobs", Method: "GET", Name: ListJobs},
@@ -220,6 +242,14 @@ var Routes = rata.Routes([]rata.Route{
 	{Path: "/api/v1/teams/:team_name", Method: "DELETE", Name: DestroyTeam},
 	{Path: "/api/v1/teams/:team_name/builds", Method: "GET", Name: ListTeamBuilds},
 
+	{Path: "/api/v1/teams/:team_name/audit_events", Method: "GET", Name: ListAuditEvents},
+	{Path: "/api/v1/audit_events", Method: "GET", Name: ListAllAuditEvents},
+
+	{Path: "/api/v1/teams/:team_name/webhooks", Method: "GET", Name: ListWebhooks},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "PUT", Name: SetWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name", Method: "DELETE", Name: DestroyWebhook},
+	{Path: "/api/v1/teams/:team_name/webhooks/:webhook_name/deliveries", Method: "GET", Name: ListWebhookDeliveries},
+
 	{Path: "/api/v1/teams/:team_name/artifacts", Method: "POST", Name: CreateArtifact},
 	{Path: "/api/v1/teams/:team_name/artifacts/:artifact_id", Method: "GET", Name: GetArtifact},
 
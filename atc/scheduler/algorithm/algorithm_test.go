@@ -36,6 +36,62 @@ var _ = DescribeTable("Input resolving",
 		},
 	}),
 
+	Entry("constrains inputs to versions that passed a job dependency", Example{
+		DB: DB{
+			Resources: []DBRow{
+				{Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+
+			BuildOutputs: []DBRow{
+				{Job: "migrate-db", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				DependsOn: []string{"migrate-db"},
+			},
+		},
+
+		// no v2 as migrate-db hasn't succeeded with it yet
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
+	Entry("combines job dependencies with passed constraints", Example{
+		DB: DB{
+			BuildOutputs: []DBRow{
+				{Job: "simple-a", BuildID: 1, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+				{Job: "migrate-db", BuildID: 2, Resource: "resource-x", Version: "rxv1", CheckOrder: 1},
+
+				{Job: "simple-a", BuildID: 3, Resource: "resource-x", Version: "rxv2", CheckOrder: 2},
+			},
+		},
+
+		Inputs: Inputs{
+			{
+				Name:      "resource-x",
+				Resource:  "resource-x",
+				Passed:    []string{"simple-a"},
+				DependsOn: []string{"migrate-db"},
+			},
+		},
+
+		Result: Result{
+			OK: true,
+			Values: map[string]string{
+				"resource-x": "rxv1",
+			},
+		},
+	}),
+
 	Entry("propagates resources together", Example{
 		DB: DB{
 			BuildOutputs: []DBRow{
@@ -18,6 +18,8 @@ func constructResolvers(
 	resolvers := []Resolver{}
 	inputConfigsWithPassed := db.InputConfigs{}
 	for _, input := range inputs {
+		input = constrainByDependencies(input)
+
 		if len(input.Passed) == 0 {
 			if input.PinnedVersion != nil {
 				resolvers = append(resolvers, NewPinnedResolver(versions, input))
@@ -38,6 +40,28 @@ func constructResolvers(
 	return resolvers, nil
 }
 
+// constrainByDependencies treats each job the input's job depends on as an
+// additional passed constraint, so that the input is only satisfied by versions
+// the upstream job has succeeded with.
+func constrainByDependencies(input db.InputConfig) db.InputConfig {
+	if len(input.DependsOn) == 0 {
+		return input
+	}
+
+	passed := db.JobSet{}
+	for jobID := range input.Passed {
+		passed[jobID] = true
+	}
+
+	for jobID := range input.DependsOn {
+		passed[jobID] = true
+	}
+
+	input.Passed = passed
+
+	return input
+}
+
 func groupInputsConfigsByPassedJobs(passedInputConfigs db.InputConfigs) []relatedInputConfigs {
 	groupedPassedInputConfigs := []relatedInputConfigs{}
 	for _, inputConfig := range passedInputConfigs {
@@ -62,6 +62,7 @@ type Input struct {
 	Name                  string
 	Resource              string
 	Passed                []string
+	DependsOn             []string
 	Version               Version
 	NoResourceConfigScope bool
 }
@@ -161,9 +162,21 @@ func (example Example) Run() {
 			passed[setup.jobIDs.ID(jobName)] = true
 		}
 
+		var dependsOn db.JobSet
+		for _, jobName := range input.DependsOn {
+			setup.insertJob(jobName)
+
+			if dependsOn == nil {
+				dependsOn = db.JobSet{}
+			}
+
+			dependsOn[setup.jobIDs.ID(jobName)] = true
+		}
+
 		inputConfigs[i] = db.InputConfig{
 			Name:            input.Name,
 			Passed:          passed,
+			DependsOn:       dependsOn,
 			ResourceID:      setup.resourceIDs.ID(input.Resource),
 			UseEveryVersion: input.Version.Every,
 			JobID:           setup.jobIDs.ID(CurrentJobName),
@@ -23,7 +23,7 @@ type BuildStarter interface {
 //go:generate counterfeiter . BuildPlanner
 
 type BuildPlanner interface {
-	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, []db.BuildInput) (atc.Plan, error)
+	Create(atc.StepConfig, db.SchedulerResources, atc.VersionedResourceTypes, atc.StepTemplateConfigs, []db.BuildInput) (atc.Plan, error)
 }
 
 type Build interface {
@@ -36,16 +36,19 @@ type Build interface {
 func NewBuildStarter(
 	planner BuildPlanner,
 	algorithm Algorithm,
+	fairShare db.FairShare,
 ) BuildStarter {
 	return &buildStarter{
 		planner:   planner,
 		algorithm: algorithm,
+		fairShare: fairShare,
 	}
 }
 
 type buildStarter struct {
 	planner   BuildPlanner
 	algorithm Algorithm
+	fairShare db.FairShare
 }
 
 func (s *buildStarter) TryStartPendingBuildsForJob(
@@ -122,6 +125,48 @@ func (s *buildStarter) constructBuilds(job db.Job, jobInputs db.InputConfigs, bu
 	return buildsToSchedule
 }
 
+// scheduleBuild schedules the build unless its team has reached its fair
+// share. The two happen while holding the team's scheduling lock, so that the
+// builds of the team's other jobs, which are scheduled in parallel, can't
+// take the same slot.
+func (s *buildStarter) scheduleBuild(logger lager.Logger, build Build, job db.SchedulerJob) (bool, error) {
+	if !build.IsScheduled() {
+		teamLock, acquired, err := s.fairShare.AcquireTeamSchedulingLock(logger, job.TeamID())
+		if err != nil {
+			return false, fmt.Errorf("acquire team scheduling lock: %w", err)
+		}
+
+		if !acquired {
+			logger.Debug("team-scheduling-lock-not-acquired")
+			return false, nil
+		}
+
+		defer func() {
+			err := teamLock.Release()
+			if err != nil {
+				logger.Error("failed-to-release-team-scheduling-lock", err)
+			}
+		}()
+
+		canStart, err := s.fairShare.CanStartBuild(job.TeamID(), build.Priority())
+		if err != nil {
+			return false, fmt.Errorf("check fair share: %w", err)
+		}
+
+		if !canStart {
+			logger.Debug("team-fair-share-reached")
+			return false, nil
+		}
+	}
+
+	scheduled, err := job.ScheduleBuild(build)
+	if err != nil {
+		return false, fmt.Errorf("schedule build: %w", err)
+	}
+
+	return scheduled, nil
+}
+
 type startResults struct {
 	finished               bool
 	scheduled              bool
@@ -152,9 +197,9 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		}, nil
 	}
 
-	scheduled, err := job.ScheduleBuild(nextPendingBuild)
+	scheduled, err := s.scheduleBuild(logger, nextPendingBuild, job)
 	if err != nil {
-		return startResults{}, fmt.Errorf("schedule build: %w", err)
+		return startResults{}, err
 	}
 
 	if !scheduled {
@@ -198,7 +243,7 @@ func (s *buildStarter) tryStartNextPendingBuild(
 		return startResults{}, fmt.Errorf("config: %w", err)
 	}
 
-	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, buildInputs)
+	plan, err := s.planner.Create(config.StepConfig(), job.Resources, job.ResourceTypes, job.Templates, buildInputs)
 	if err != nil {
 		logger.Error("failed-to-create-build-plan", err)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 
@@ -21,6 +22,8 @@ var _ = Describe("BuildStarter", func() {
 		fakePlanner   *schedulerfakes.FakeBuildPlanner
 		pendingBuilds []db.Build
 		fakeAlgorithm *schedulerfakes.FakeAlgorithm
+		fakeFairShare *dbfakes.FakeFairShare
+		fakeTeamLock  *lockfakes.FakeLock
 
 		buildStarter scheduler.BuildStarter
 
@@ -33,8 +36,12 @@ var _ = Describe("BuildStarter", func() {
 		fakePipeline = new(dbfakes.FakePipeline)
 		fakePlanner = new(schedulerfakes.FakeBuildPlanner)
 		fakeAlgorithm = new(schedulerfakes.FakeAlgorithm)
+		fakeTeamLock = new(lockfakes.FakeLock)
+		fakeFairShare = new(dbfakes.FakeFairShare)
+		fakeFairShare.AcquireTeamSchedulingLockReturns(fakeTeamLock, true, nil)
+		fakeFairShare.CanStartBuildReturns(true, nil)
 
-		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm)
+		buildStarter = scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm, fakeFairShare)
 
 		disaster = errors.New("bad thing")
 	})
@@ -67,6 +74,7 @@ var _ = Describe("BuildStarter", func() {
 				createdBuild = new(dbfakes.FakeBuild)
 				createdBuild.IDReturns(66)
 				createdBuild.NameReturns("some-build")
+				createdBuild.PriorityReturns(5)
 
 				pendingBuilds = []db.Build{createdBuild}
 
@@ -74,6 +82,7 @@ var _ = Describe("BuildStarter", func() {
 				job.GetPendingBuildsReturns(pendingBuilds, nil)
 				job.NameReturns("some-job")
 				job.IDReturns(1)
+				job.TeamIDReturns(123)
 				job.ConfigReturns(atc.JobConfig{
 					PlanSequence: []atc.Step{
 						{
@@ -194,6 +203,80 @@ var _ = Describe("BuildStarter", func() {
 					Expect(actualBuild.Name()).To(Equal(createdBuild.Name()))
 				})
 
+				It("checks the fair share of the job's team for the build's priority", func() {
+					Expect(fakeFairShare.CanStartBuildCallCount()).To(Equal(1))
+					teamID, priority := fakeFairShare.CanStartBuildArgsForCall(0)
+					Expect(teamID).To(Equal(123))
+					Expect(priority).To(Equal(5))
+				})
+
+				It("holds the team's scheduling lock while checking the fair share and scheduling the build", func() {
+					Expect(fakeFairShare.AcquireTeamSchedulingLockCallCount()).To(Equal(1))
+					_, teamID := fakeFairShare.AcquireTeamSchedulingLockArgsForCall(0)
+					Expect(teamID).To(Equal(123))
+
+					Expect(fakeTeamLock.ReleaseCallCount()).To(Equal(1))
+				})
+
+				Context("when the team's scheduling lock is held elsewhere", func() {
+					BeforeEach(func() {
+						fakeFairShare.AcquireTeamSchedulingLockReturns(nil, false, nil)
+					})
+
+					It("does not schedule the build and needs to be rescheduled", func() {
+						Expect(fakeFairShare.CanStartBuildCallCount()).To(BeZero())
+						Expect(job.ScheduleBuildCallCount()).To(BeZero())
+						Expect(tryStartErr).ToNot(HaveOccurred())
+						Expect(needsReschedule).To(BeTrue())
+					})
+				})
+
+				Context("when acquiring the team's scheduling lock fails", func() {
+					BeforeEach(func() {
+						fakeFairShare.AcquireTeamSchedulingLockReturns(nil, false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(tryStartErr).To(Equal(fmt.Errorf("acquire team scheduling lock: %w", disaster)))
+						Expect(job.ScheduleBuildCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the build is already scheduled", func() {
+					BeforeEach(func() {
+						createdBuild.IsScheduledReturns(true)
+					})
+
+					It("does not check the fair share", func() {
+						Expect(fakeFairShare.CanStartBuildCallCount()).To(BeZero())
+						Expect(fakeFairShare.AcquireTeamSchedulingLockCallCount()).To(BeZero())
+					})
+				})
+
+				Context("when the team has reached its fair share", func() {
+					BeforeEach(func() {
+						fakeFairShare.CanStartBuildReturns(false, nil)
+					})
+
+					It("does not schedule the build and needs to be rescheduled", func() {
+						Expect(job.ScheduleBuildCallCount()).To(BeZero())
+						Expect(createdBuild.StartCallCount()).To(BeZero())
+						Expect(tryStartErr).ToNot(HaveOccurred())
+						Expect(needsReschedule).To(BeTrue())
+					})
+				})
+
+				Context("when checking the fair share fails", func() {
+					BeforeEach(func() {
+						fakeFairShare.CanStartBuildReturns(false, disaster)
+					})
+
+					It("returns the error", func() {
+						Expect(tryStartErr).To(Equal(fmt.Errorf("check fair share: %w", disaster)))
+						Expect(needsReschedule).To(BeFalse())
+					})
+				})
+
 				Context("when the build not scheduled", func() {
 					BeforeEach(func() {
 						job.ScheduleBuildReturns(false, nil)
@@ -661,19 +744,19 @@ var _ = Describe("BuildStarter", func() {
 									It("creates build plans for all builds", func() {
 										Expect(fakePlanner.CreateCallCount()).To(Equal(3))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs := fakePlanner.CreateArgsForCall(0)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, _, actualBuildInputs := fakePlanner.CreateArgsForCall(0)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(1)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, _, actualBuildInputs = fakePlanner.CreateArgsForCall(1)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
 										Expect(actualBuildInputs).To(Equal([]db.BuildInput{{Name: "some-input"}}))
 
-										actualPlanConfig, actualResourceConfigs, actualResourceTypes, actualBuildInputs = fakePlanner.CreateArgsForCall(2)
+										actualPlanConfig, actualResourceConfigs, actualResourceTypes, _, actualBuildInputs = fakePlanner.CreateArgsForCall(2)
 										Expect(actualPlanConfig).To(Equal(&atc.DoStep{Steps: jobConfig.PlanSequence}))
 										Expect(actualResourceConfigs).To(Equal(db.SchedulerResources{{Name: "some-resource"}}))
 										Expect(actualResourceTypes).To(Equal(versionedResourceTypes))
@@ -8,6 +8,7 @@ import (
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/scheduler"
 	"github.com/concourse/concourse/atc/scheduler/schedulerfakes"
 	. "github.com/onsi/ginkgo/extensions/table"
@@ -261,7 +262,11 @@ func (example Example) Run() {
 	fakeAlgorithm := new(schedulerfakes.FakeAlgorithm)
 	fakeAlgorithm.ComputeReturns(nil, true, false, nil)
 
-	buildStarter := scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm)
+	fakeFairShare := new(dbfakes.FakeFairShare)
+	fakeFairShare.AcquireTeamSchedulingLockReturns(new(lockfakes.FakeLock), true, nil)
+	fakeFairShare.CanStartBuildReturns(true, nil)
+
+	buildStarter := scheduler.NewBuildStarter(fakePlanner, fakeAlgorithm, fakeFairShare)
 
 	fakeJob := new(dbfakes.FakeJob)
 	fakeJob.ConfigReturns(atc.JobConfig{}, nil)
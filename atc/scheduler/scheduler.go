@@ -81,14 +81,17 @@ func (s *Scheduler) ensurePendingBuildExists(
 		inputMapping[input.Name] = input
 	}
 
-	var hasNewInputs bool
+	var hasNewInputs, pendingBuildEnsured bool
 	for _, inputConfig := range jobInputs {
 		inputSource, ok := inputMapping[inputConfig.Name]
 
 		//trigger: true, and the version has not been used
 		if ok && inputSource.FirstOccurrence {
 			hasNewInputs = true
-			if inputConfig.Trigger {
+
+			// inputs constrained by a job dependency always trigger, as the
+			// dependency is a trigger on the upstream job succeeding
+			if inputConfig.Trigger || len(inputConfig.DependsOn) > 0 {
 				version, _ := json.Marshal(inputSource.Version)
 				spanCtx, _ := tracing.StartSpanLinkedToFollowing(
 					ctx,
@@ -107,11 +110,19 @@ func (s *Scheduler) ensurePendingBuildExists(
 					return fmt.Errorf("ensure pending build exists: %w", err)
 				}
 
+				pendingBuildEnsured = true
 				break
 			}
 		}
 	}
 
+	if !pendingBuildEnsured {
+		err = s.ensurePendingBuildForDependencies(ctx, job)
+		if err != nil {
+			return err
+		}
+	}
+
 	if hasNewInputs != job.HasNewInputs() {
 		if err := job.SetHasNewInputs(hasNewInputs); err != nil {
 			return fmt.Errorf("set has new inputs: %w", err)
@@ -120,3 +131,39 @@ func (s *Scheduler) ensurePendingBuildExists(
 
 	return nil
 }
+
+// ensurePendingBuildForDependencies triggers the job when a job it depends on
+// without sharing an input with it has succeeded since the job's latest build,
+// so that the job runs after each of the upstream job's successful builds.
+func (s *Scheduler) ensurePendingBuildForDependencies(ctx context.Context, job db.SchedulerJob) error {
+	config, err := job.Config()
+	if err != nil {
+		return fmt.Errorf("job config: %w", err)
+	}
+
+	if len(config.DependsOn) == 0 {
+		return nil
+	}
+
+	succeeded, err := job.DependenciesSucceededSinceLastBuild()
+	if err != nil {
+		return fmt.Errorf("check dependencies: %w", err)
+	}
+
+	if !succeeded {
+		return nil
+	}
+
+	spanCtx, _ := tracing.StartSpan(ctx, "job.EnsurePendingBuildExists", tracing.Attrs{
+		"team":     job.TeamName(),
+		"pipeline": job.PipelineName(),
+		"job":      job.Name(),
+	})
+
+	err = job.EnsurePendingBuildExists(spanCtx)
+	if err != nil {
+		return fmt.Errorf("ensure pending build exists: %w", err)
+	}
+
+	return nil
+}
@@ -409,6 +409,136 @@ var _ = Describe("Scheduler", func() {
 			})
 		})
 
+		Context("when the job has an input constrained by a job dependency", func() {
+			BeforeEach(func() {
+				fakeJob.NameReturns("some-job")
+				fakeJob.AlgorithmInputsReturns(db.InputConfigs{
+					{Name: "a", Trigger: false},
+					{Name: "b", Trigger: false, DependsOn: db.JobSet{1: true}},
+				}, nil)
+
+				fakeBuildStarter.TryStartPendingBuildsForJobReturns(false, nil)
+				fakeJob.SaveNextInputMappingReturns(nil)
+			})
+
+			Context("when the constrained input is a first occurrence", func() {
+				BeforeEach(func() {
+					fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+						{
+							Name:            "a",
+							Version:         atc.Version{"ref": "v1"},
+							ResourceID:      11,
+							FirstOccurrence: false,
+						},
+						{
+							Name:            "b",
+							Version:         atc.Version{"ref": "v2"},
+							ResourceID:      12,
+							FirstOccurrence: true,
+						},
+					}, true, nil)
+				})
+
+				It("creates a pending build even though the input is not trigger: true", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when only the unconstrained input is a first occurrence", func() {
+				BeforeEach(func() {
+					fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+						{
+							Name:            "a",
+							Version:         atc.Version{"ref": "v1"},
+							ResourceID:      11,
+							FirstOccurrence: true,
+						},
+						{
+							Name:            "b",
+							Version:         atc.Version{"ref": "v2"},
+							ResourceID:      12,
+							FirstOccurrence: false,
+						},
+					}, true, nil)
+				})
+
+				It("didn't create a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+				})
+			})
+		})
+
+		Context("when the job depends on other jobs", func() {
+			BeforeEach(func() {
+				fakeJob.NameReturns("some-job")
+				fakeJob.ConfigReturns(atc.JobConfig{
+					Name:      "some-job",
+					DependsOn: []string{"some-upstream-job"},
+				}, nil)
+				fakeJob.AlgorithmInputsReturns(db.InputConfigs{
+					{Name: "a", Trigger: false},
+				}, nil)
+				fakeJob.GetFullNextBuildInputsReturns([]db.BuildInput{
+					{
+						Name:            "a",
+						Version:         atc.Version{"ref": "v1"},
+						ResourceID:      11,
+						FirstOccurrence: false,
+					},
+				}, true, nil)
+
+				fakeBuildStarter.TryStartPendingBuildsForJobReturns(false, nil)
+				fakeJob.SaveNextInputMappingReturns(nil)
+			})
+
+			Context("when a dependency succeeded since the job's latest build", func() {
+				BeforeEach(func() {
+					fakeJob.DependenciesSucceededSinceLastBuildReturns(true, nil)
+				})
+
+				It("creates a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(Equal(1))
+				})
+			})
+
+			Context("when no dependency succeeded since the job's latest build", func() {
+				BeforeEach(func() {
+					fakeJob.DependenciesSucceededSinceLastBuildReturns(false, nil)
+				})
+
+				It("didn't create a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+				})
+			})
+
+			Context("when checking the dependencies fails", func() {
+				BeforeEach(func() {
+					fakeJob.DependenciesSucceededSinceLastBuildReturns(false, disaster)
+				})
+
+				It("returns the error", func() {
+					Expect(scheduleErr).To(Equal(fmt.Errorf("check dependencies: %w", disaster)))
+				})
+			})
+
+			Context("when the next build inputs are not satisfiable", func() {
+				BeforeEach(func() {
+					fakeJob.GetFullNextBuildInputsReturns(nil, false, nil)
+					fakeJob.DependenciesSucceededSinceLastBuildReturns(true, nil)
+				})
+
+				It("didn't create a pending build", func() {
+					Expect(scheduleErr).NotTo(HaveOccurred())
+					Expect(fakeJob.DependenciesSucceededSinceLastBuildCallCount()).To(BeZero())
+					Expect(fakeJob.EnsurePendingBuildExistsCallCount()).To(BeZero())
+				})
+			})
+		})
+
 		Context("when multiple first occurrence inputs have trigger: true and tracing is configured", func() {
 			var inputCtx1, inputCtx2 context.Context
 
@@ -0,0 +1,441 @@
+package atc
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/concourse/concourse/vars"
+)
+
+// StepCondition is a boolean expression configured as the 'if' of a step,
+// e.g.:
+//
+//	((.:release_type)) == "major" && !((.:dry_run))
+//
+// Operands are string, number and boolean literals and ((var)) references,
+// which are resolved against the build's vars when the condition is
+// evaluated. Operands can be compared with == and !=, and conditions combined
+// with &&, || and !, grouped with parentheses.
+//
+// An operand used as a condition by itself holds unless it is false, empty,
+// zero, or the string "false".
+type StepCondition struct {
+	expr conditionExpr
+}
+
+// ParseStepCondition parses the condition, returning an error if it is not a
+// valid expression.
+func ParseStepCondition(condition string) (StepCondition, error) {
+	tokens, err := tokenizeCondition(condition)
+	if err != nil {
+		return StepCondition{}, err
+	}
+
+	parser := &conditionParser{tokens: tokens}
+
+	expr, err := parser.parseOr()
+	if err != nil {
+		return StepCondition{}, err
+	}
+
+	if !parser.done() {
+		return StepCondition{}, fmt.Errorf("unexpected '%s'", parser.peek().text)
+	}
+
+	return StepCondition{expr: expr}, nil
+}
+
+// Evaluate resolves the condition's var references and returns whether it
+// holds. Referencing an undefined var is an error.
+func (condition StepCondition) Evaluate(variables vars.Variables) (bool, error) {
+	value, err := condition.expr.evaluate(variables)
+	if err != nil {
+		return false, err
+	}
+
+	return truthy(value), nil
+}
+
+type conditionExpr interface {
+	evaluate(vars.Variables) (interface{}, error)
+}
+
+type literalExpr struct {
+	value interface{}
+}
+
+func (expr literalExpr) evaluate(vars.Variables) (interface{}, error) {
+	return expr.value, nil
+}
+
+type varExpr struct {
+	ref vars.Reference
+}
+
+func (expr varExpr) evaluate(variables vars.Variables) (interface{}, error) {
+	value, found, err := variables.Get(expr.ref)
+	if err != nil {
+		return nil, err
+	}
+
+	if !found {
+		return nil, vars.UndefinedVarsError{Vars: []string{expr.ref.String()}}
+	}
+
+	return value, nil
+}
+
+type notExpr struct {
+	expr conditionExpr
+}
+
+func (expr notExpr) evaluate(variables vars.Variables) (interface{}, error) {
+	value, err := expr.expr.evaluate(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return !truthy(value), nil
+}
+
+type binaryExpr struct {
+	op          string
+	left, right conditionExpr
+}
+
+func (expr binaryExpr) evaluate(variables vars.Variables) (interface{}, error) {
+	left, err := expr.left.evaluate(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case "&&":
+		if !truthy(left) {
+			return false, nil
+		}
+	case "||":
+		if truthy(left) {
+			return true, nil
+		}
+	}
+
+	right, err := expr.right.evaluate(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	switch expr.op {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	default:
+		return truthy(right), nil
+	}
+}
+
+func truthy(value interface{}) bool {
+	switch v := value.(type) {
+	case nil:
+		return false
+	case bool:
+		return v
+	case string:
+		return v != "" && v != "false"
+	case map[string]interface{}:
+		return len(v) != 0
+	case []interface{}:
+		return len(v) != 0
+	default:
+		return fmt.Sprint(v) != "0"
+	}
+}
+
+// equal compares scalars by their string form, so that e.g. a number loaded
+// from a file equals the same number written in the condition.
+func equal(left, right interface{}) bool {
+	if isScalar(left) && isScalar(right) {
+		return fmt.Sprint(left) == fmt.Sprint(right)
+	}
+
+	return reflect.DeepEqual(left, right)
+}
+
+func isScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		return false
+	default:
+		return true
+	}
+}
+
+type conditionTokenKind int
+
+const (
+	tokenOperator conditionTokenKind = iota
+	tokenString
+	tokenNumber
+	tokenWord
+	tokenVar
+)
+
+type conditionToken struct {
+	kind conditionTokenKind
+	text string
+}
+
+var conditionOperators = []string{"==", "!=", "&&", "||", "!", "(", ")"}
+
+func tokenizeCondition(condition string) ([]conditionToken, error) {
+	var tokens []conditionToken
+
+	rest := condition
+	for {
+		rest = strings.TrimLeftFunc(rest, unicode.IsSpace)
+		if rest == "" {
+			break
+		}
+
+		switch {
+		case strings.HasPrefix(rest, "(("):
+			end := strings.Index(rest, "))")
+			if end == -1 {
+				return nil, fmt.Errorf("unterminated var reference '%s'", rest)
+			}
+
+			tokens = append(tokens, conditionToken{kind: tokenVar, text: rest[2:end]})
+			rest = rest[end+2:]
+			continue
+
+		case rest[0] == '"':
+			end := 1
+			for end < len(rest) && rest[end] != '"' {
+				if rest[end] == '\\' {
+					end++
+				}
+				end++
+			}
+
+			if end >= len(rest) {
+				return nil, fmt.Errorf("unterminated string '%s'", rest)
+			}
+
+			literal := rest[:end+1]
+
+			tokens = append(tokens, conditionToken{kind: tokenString, text: literal})
+			rest = rest[len(literal):]
+			continue
+		}
+
+		matchedOperator := false
+		for _, op := range conditionOperators {
+			if strings.HasPrefix(rest, op) {
+				tokens = append(tokens, conditionToken{kind: tokenOperator, text: op})
+				rest = rest[len(op):]
+				matchedOperator = true
+				break
+			}
+		}
+
+		if matchedOperator {
+			continue
+		}
+
+		end := strings.IndexFunc(rest, func(r rune) bool {
+			return unicode.IsSpace(r) || strings.ContainsRune(`=!&|()"`, r)
+		})
+		if end == -1 {
+			end = len(rest)
+		}
+
+		if end == 0 {
+			return nil, fmt.Errorf("unexpected '%s'", rest)
+		}
+
+		word := rest[:end]
+		if _, err := strconv.ParseFloat(word, 64); err == nil {
+			tokens = append(tokens, conditionToken{kind: tokenNumber, text: word})
+		} else {
+			tokens = append(tokens, conditionToken{kind: tokenWord, text: word})
+		}
+
+		rest = rest[end:]
+	}
+
+	if len(tokens) == 0 {
+		return nil, fmt.Errorf("condition is empty")
+	}
+
+	return tokens, nil
+}
+
+type conditionParser struct {
+	tokens []conditionToken
+	pos    int
+}
+
+func (parser *conditionParser) done() bool {
+	return parser.pos >= len(parser.tokens)
+}
+
+func (parser *conditionParser) peek() conditionToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *conditionParser) acceptOperator(ops ...string) (string, bool) {
+	if parser.done() {
+		return "", false
+	}
+
+	token := parser.peek()
+	if token.kind != tokenOperator {
+		return "", false
+	}
+
+	for _, op := range ops {
+		if token.text == op {
+			parser.pos++
+			return op, true
+		}
+	}
+
+	return "", false
+}
+
+func (parser *conditionParser) parseOr() (conditionExpr, error) {
+	left, err := parser.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := parser.acceptOperator("||"); !ok {
+			return left, nil
+		}
+
+		right, err := parser.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryExpr{op: "||", left: left, right: right}
+	}
+}
+
+func (parser *conditionParser) parseAnd() (conditionExpr, error) {
+	left, err := parser.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		if _, ok := parser.acceptOperator("&&"); !ok {
+			return left, nil
+		}
+
+		right, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		left = binaryExpr{op: "&&", left: left, right: right}
+	}
+}
+
+func (parser *conditionParser) parseNot() (conditionExpr, error) {
+	if _, ok := parser.acceptOperator("!"); ok {
+		expr, err := parser.parseNot()
+		if err != nil {
+			return nil, err
+		}
+
+		return notExpr{expr: expr}, nil
+	}
+
+	return parser.parseComparison()
+}
+
+func (parser *conditionParser) parseComparison() (conditionExpr, error) {
+	left, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	op, ok := parser.acceptOperator("==", "!=")
+	if !ok {
+		return left, nil
+	}
+
+	right, err := parser.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	return binaryExpr{op: op, left: left, right: right}, nil
+}
+
+func (parser *conditionParser) parseOperand() (conditionExpr, error) {
+	if parser.done() {
+		return nil, fmt.Errorf("unexpected end of condition")
+	}
+
+	if _, ok := parser.acceptOperator("("); ok {
+		expr, err := parser.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := parser.acceptOperator(")"); !ok {
+			return nil, fmt.Errorf("missing ')'")
+		}
+
+		return expr, nil
+	}
+
+	token := parser.peek()
+	parser.pos++
+
+	switch token.kind {
+	case tokenVar:
+		ref, err := vars.ParseReference(token.text)
+		if err != nil {
+			return nil, err
+		}
+
+		return varExpr{ref: ref}, nil
+
+	case tokenString:
+		value, err := strconv.Unquote(token.text)
+		if err != nil {
+			return nil, fmt.Errorf("invalid string %s", token.text)
+		}
+
+		return literalExpr{value: value}, nil
+
+	case tokenNumber:
+		value, err := strconv.ParseFloat(token.text, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid number '%s'", token.text)
+		}
+
+		return literalExpr{value: value}, nil
+
+	case tokenWord:
+		switch token.text {
+		case "true":
+			return literalExpr{value: true}, nil
+		case "false":
+			return literalExpr{value: false}, nil
+		default:
+			return nil, fmt.Errorf("unknown word '%s': strings must be quoted and vars written as ((var))", token.text)
+		}
+
+	default:
+		return nil, fmt.Errorf("unexpected '%s'", token.text)
+	}
+}
@@ -0,0 +1,77 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/vars"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepCondition", func() {
+	var variables vars.StaticVariables
+
+	BeforeEach(func() {
+		variables = vars.StaticVariables{
+			"release":  "major",
+			"dry_run":  false,
+			"count":    3,
+			"empty":    "",
+			"disabled": "false",
+			"nested":   map[string]interface{}{"key": "value"},
+		}
+	})
+
+	DescribeTable("evaluating",
+		func(condition string, expected bool) {
+			parsed, err := atc.ParseStepCondition(condition)
+			Expect(err).ToNot(HaveOccurred())
+
+			holds, err := parsed.Evaluate(variables)
+			Expect(err).ToNot(HaveOccurred())
+			Expect(holds).To(Equal(expected))
+		},
+		Entry("true literal", `true`, true),
+		Entry("false literal", `false`, false),
+		Entry("truthy var", `((release))`, true),
+		Entry("false var", `((dry_run))`, false),
+		Entry("empty string var", `((empty))`, false),
+		Entry("'false' string var", `((disabled))`, false),
+		Entry("nested var", `((nested.key)) == "value"`, true),
+		Entry("string equality", `((release)) == "major"`, true),
+		Entry("string inequality", `((release)) != "major"`, false),
+		Entry("number equality", `((count)) == 3`, true),
+		Entry("negation", `!((dry_run))`, true),
+		Entry("double negation", `!!((release))`, true),
+		Entry("and", `((release)) == "major" && !((dry_run))`, true),
+		Entry("or", `((dry_run)) || ((count)) != 3`, false),
+		Entry("and binds tighter than or", `true || false && false`, true),
+		Entry("parentheses", `(true || false) && false`, false),
+		Entry("escaped quotes", `"a\"b" == "a\"b"`, true),
+		Entry("short-circuit and skips undefined vars", `false && ((missing))`, false),
+		Entry("short-circuit or skips undefined vars", `true || ((missing))`, true),
+	)
+
+	DescribeTable("invalid conditions",
+		func(condition string, message string) {
+			_, err := atc.ParseStepCondition(condition)
+			Expect(err).To(MatchError(message))
+		},
+		Entry("empty", ``, "condition is empty"),
+		Entry("whitespace", `   `, "condition is empty"),
+		Entry("dangling operator", `((release)) ==`, "unexpected end of condition"),
+		Entry("missing paren", `(true`, "missing ')'"),
+		Entry("extra paren", `true)`, "unexpected ')'"),
+		Entry("unterminated string", `"abc`, `unterminated string '"abc'`),
+		Entry("unterminated var", `((abc`, "unterminated var reference '((abc'"),
+		Entry("bare word", `major`, "unknown word 'major': strings must be quoted and vars written as ((var))"),
+	)
+
+	It("errors when a var is undefined", func() {
+		parsed, err := atc.ParseStepCondition(`((missing)) == "x"`)
+		Expect(err).ToNot(HaveOccurred())
+
+		_, err = parsed.Evaluate(variables)
+		Expect(err).To(Equal(vars.UndefinedVarsError{Vars: []string{"missing"}}))
+	})
+})
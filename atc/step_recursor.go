@@ -22,6 +22,19 @@ type StepRecursor struct {
 
 	// OnLoadVar will be invoked for any *LoadVarStep present in the StepConfig.
 	OnLoadVar func(*LoadVarStep) error
+
+	// OnApproval will be invoked for any *ApprovalStep present in the StepConfig.
+	OnApproval func(*ApprovalStep) error
+
+	// OnUse will be invoked for any *UseStep present in the StepConfig, before
+	// recursing through the step of the template it uses.
+	OnUse func(*UseStep) error
+
+	// Templates are the pipeline's step templates. If configured, any
+	// *UseStep is recursed through as the step of the template it uses.
+	Templates StepTemplateConfigs
+
+	using []string
 }
 
 // VisitTask calls the OnTask hook if configured.
@@ -69,6 +82,43 @@ func (recursor StepRecursor) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+// VisitApproval calls the OnApproval hook if configured.
+func (recursor StepRecursor) VisitApproval(step *ApprovalStep) error {
+	if recursor.OnApproval != nil {
+		return recursor.OnApproval(step)
+	}
+
+	return nil
+}
+
+// VisitUse calls the OnUse hook if configured and recurses through to the
+// step of the used template. Uses of unknown
+// templates, invalid args, and templates which use themselves are skipped, as
+// they are reported by the StepValidator instead.
+func (recursor StepRecursor) VisitUse(step *UseStep) error {
+	if recursor.OnUse != nil {
+		err := recursor.OnUse(step)
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, name := range recursor.using {
+		if name == step.Name {
+			return nil
+		}
+	}
+
+	instance, err := recursor.Templates.Instantiate(step)
+	if err != nil {
+		return nil
+	}
+
+	recursor.using = append(append([]string{}, recursor.using...), step.Name)
+
+	return instance.Config.Visit(recursor)
+}
+
 // VisitTry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitTry(step *TryStep) error {
 	return step.Step.Config.Visit(recursor)
@@ -120,6 +170,11 @@ func (recursor StepRecursor) VisitTimeout(step *TimeoutStep) error {
 	return step.Step.Visit(recursor)
 }
 
+// VisitIf recurses through to the wrapped step.
+func (recursor StepRecursor) VisitIf(step *IfStep) error {
+	return step.Step.Visit(recursor)
+}
+
 // VisitRetry recurses through to the wrapped step.
 func (recursor StepRecursor) VisitRetry(step *RetryStep) error {
 	return step.Step.Visit(recursor)
@@ -0,0 +1,107 @@
+package atc
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// StepTemplateConfig is a reusable, parameterized step definition configured
+// under the pipeline's 'templates:' and instantiated by 'use:' steps.
+//
+// Within the step, params are referenced as ((.:param)), the same as matrix
+// and across vars.
+type StepTemplateConfig struct {
+	Name   string              `json:"name"`
+	Params []StepTemplateParam `json:"params,omitempty"`
+	Step   Step                `json:"step"`
+}
+
+// StepTemplateParam declares a param of a template. A param without a default
+// must be given as an arg by every step using the template.
+type StepTemplateParam struct {
+	Name    string      `json:"name"`
+	Default interface{} `json:"default,omitempty"`
+}
+
+type StepTemplateConfigs []StepTemplateConfig
+
+func (templates StepTemplateConfigs) Lookup(name string) (StepTemplateConfig, bool) {
+	for _, template := range templates {
+		if template.Name == name {
+			return template, true
+		}
+	}
+
+	return StepTemplateConfig{}, false
+}
+
+// Instantiate returns the step of the template used by the given step, with
+// the step's args interpolated in place of the template's params.
+//
+// The returned step may itself contain 'use:' steps; it is up to the caller
+// to guard against templates which (indirectly) use themselves.
+func (templates StepTemplateConfigs) Instantiate(step *UseStep) (Step, error) {
+	template, found := templates.Lookup(step.Name)
+	if !found {
+		return Step{}, fmt.Errorf("unknown template '%s'", step.Name)
+	}
+
+	values, err := template.values(step.Args)
+	if err != nil {
+		return Step{}, err
+	}
+
+	payload, err := json.Marshal(template.Step)
+	if err != nil {
+		return Step{}, err
+	}
+
+	payload, err = interpolateLocalVarsJSON(payload, values)
+	if err != nil {
+		return Step{}, err
+	}
+
+	var instance Step
+	err = json.Unmarshal(payload, &instance)
+	if err != nil {
+		return Step{}, fmt.Errorf("template '%s': %w", template.Name, err)
+	}
+
+	return instance, nil
+}
+
+func (template StepTemplateConfig) values(args map[string]interface{}) (map[string]interface{}, error) {
+	declared := map[string]bool{}
+	for _, param := range template.Params {
+		declared[param.Name] = true
+	}
+
+	var unknown []string
+	for name := range args {
+		if !declared[name] {
+			unknown = append(unknown, name)
+		}
+	}
+
+	if len(unknown) != 0 {
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("template '%s' has no param '%s'", template.Name, unknown[0])
+	}
+
+	values := map[string]interface{}{}
+	for _, param := range template.Params {
+		value, found := args[param.Name]
+		if !found {
+			if param.Default == nil {
+				return nil, fmt.Errorf("missing arg '%s' for template '%s'", param.Name, template.Name)
+			}
+
+			value = param.Default
+		}
+
+		values[param.Name] = value
+	}
+
+	return values, nil
+}
@@ -0,0 +1,92 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("StepTemplateConfigs", func() {
+	var templates atc.StepTemplateConfigs
+
+	BeforeEach(func() {
+		templates = atc.StepTemplateConfigs{
+			{
+				Name: "run-tests",
+				Params: []atc.StepTemplateParam{
+					{Name: "package"},
+					{Name: "parallelism", Default: 2},
+				},
+				Step: atc.Step{
+					Config: &atc.TaskStep{
+						Name:       "test-((.:package))",
+						ConfigPath: "ci/tasks/test.yml",
+						Params: atc.TaskEnv{
+							"PACKAGE":     "((.:package))",
+							"PARALLELISM": "((.:parallelism))",
+							"TOKEN":       "((token))",
+						},
+					},
+				},
+			},
+		}
+	})
+
+	Describe("Instantiate", func() {
+		It("interpolates the args into the template's step", func() {
+			step, err := templates.Instantiate(&atc.UseStep{
+				Name: "run-tests",
+				Args: map[string]interface{}{
+					"package":     "atc",
+					"parallelism": 4,
+				},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(step.Config).To(Equal(&atc.TaskStep{
+				Name:       "test-atc",
+				ConfigPath: "ci/tasks/test.yml",
+				Params: atc.TaskEnv{
+					"PACKAGE":     "atc",
+					"PARALLELISM": "4",
+					"TOKEN":       "((token))",
+				},
+			}))
+		})
+
+		It("uses the default of params which are not given", func() {
+			step, err := templates.Instantiate(&atc.UseStep{
+				Name: "run-tests",
+				Args: map[string]interface{}{"package": "atc"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(step.Config.(*atc.TaskStep).Params["PARALLELISM"]).To(Equal("2"))
+		})
+
+		It("does not modify the template", func() {
+			_, err := templates.Instantiate(&atc.UseStep{
+				Name: "run-tests",
+				Args: map[string]interface{}{"package": "atc"},
+			})
+			Expect(err).ToNot(HaveOccurred())
+			Expect(templates[0].Step.Config.(*atc.TaskStep).Name).To(Equal("test-((.:package))"))
+		})
+
+		It("errors when the template is unknown", func() {
+			_, err := templates.Instantiate(&atc.UseStep{Name: "bogus"})
+			Expect(err).To(MatchError("unknown template 'bogus'"))
+		})
+
+		It("errors when an arg is missing", func() {
+			_, err := templates.Instantiate(&atc.UseStep{Name: "run-tests"})
+			Expect(err).To(MatchError("missing arg 'package' for template 'run-tests'"))
+		})
+
+		It("errors when an arg is not a param", func() {
+			_, err := templates.Instantiate(&atc.UseStep{
+				Name: "run-tests",
+				Args: map[string]interface{}{"package": "atc", "bogus": "arg"},
+			})
+			Expect(err).To(MatchError("template 'run-tests' has no param 'bogus'"))
+		})
+	})
+})
@@ -2,6 +2,7 @@ package atc
 
 import (
 	"fmt"
+	"path"
 	"strings"
 	"time"
 )
@@ -27,6 +28,7 @@ type StepValidator struct {
 
 	seenGetName    scope
 	localVarScopes []scope
+	usingTemplates []string
 }
 
 type scope map[string]bool
@@ -84,6 +86,25 @@ func (validator *StepValidator) VisitTask(plan *TaskStep) error {
 		})
 	}
 
+	for i, artifact := range plan.Artifacts {
+		validator.pushContext(fmt.Sprintf(".artifacts[%d]", i))
+
+		if artifact == "" {
+			validator.recordError("must specify a path")
+		} else if path.IsAbs(artifact) {
+			validator.recordError("must be relative to an output")
+		} else {
+			for _, segment := range strings.Split(artifact, "/") {
+				if segment == ".." {
+					validator.recordError("must not contain '..'")
+					break
+				}
+			}
+		}
+
+		validator.popContext()
+	}
+
 	if plan.Config != nil {
 		validator.pushContext(".config")
 
@@ -137,6 +158,7 @@ func (validator *StepValidator) VisitGet(step *GetStep) error {
 		foundResource := false
 
 		_ = jobConfig.StepConfig().Visit(StepRecursor{
+			Templates: validator.config.Templates,
 			OnGet: func(input *GetStep) error {
 				if input.ResourceName() == resourceName {
 					foundResource = true
@@ -214,6 +236,18 @@ func (validator *StepValidator) VisitLoadVar(step *LoadVarStep) error {
 	return nil
 }
 
+func (validator *StepValidator) VisitApproval(step *ApprovalStep) error {
+	validator.pushContext(".approval(%s)", step.Name)
+	defer validator.popContext()
+
+	warning := ValidateIdentifier(step.Name, validator.context...)
+	if warning != nil {
+		validator.recordWarning(*warning)
+	}
+
+	return nil
+}
+
 func (validator *StepValidator) VisitTry(step *TryStep) error {
 	validator.pushContext(".try")
 	defer validator.popContext()
@@ -311,6 +345,42 @@ func (validator *StepValidator) VisitAcross(step *AcrossStep) error {
 	return step.Step.Visit(validator)
 }
 
+func (validator *StepValidator) VisitIf(step *IfStep) error {
+	validator.pushContext(".if")
+	_, err := ParseStepCondition(step.Condition)
+	if err != nil {
+		validator.recordError("invalid condition: %s", err)
+	}
+	validator.popContext()
+
+	return step.Step.Visit(validator)
+}
+
+func (validator *StepValidator) VisitUse(step *UseStep) error {
+	validator.pushContext(".use(%s)", step.Name)
+	defer validator.popContext()
+
+	for _, name := range validator.usingTemplates {
+		if name == step.Name {
+			validator.recordError("template '%s' uses itself", step.Name)
+			return nil
+		}
+	}
+
+	instance, err := validator.config.Templates.Instantiate(step)
+	if err != nil {
+		validator.recordError("%s", err)
+		return nil
+	}
+
+	validator.usingTemplates = append(validator.usingTemplates, step.Name)
+	defer func() {
+		validator.usingTemplates = validator.usingTemplates[:len(validator.usingTemplates)-1]
+	}()
+
+	return validator.Validate(instance)
+}
+
 func (validator *StepValidator) VisitTimeout(step *TimeoutStep) error {
 	err := step.Step.Visit(validator)
 	if err != nil {
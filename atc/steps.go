@@ -193,6 +193,7 @@ type StepVisitor interface {
 	VisitPut(*PutStep) error
 	VisitSetPipeline(*SetPipelineStep) error
 	VisitLoadVar(*LoadVarStep) error
+	VisitApproval(*ApprovalStep) error
 	VisitTry(*TryStep) error
 	VisitDo(*DoStep) error
 	VisitInParallel(*InParallelStep) error
@@ -205,6 +206,8 @@ type StepVisitor interface {
 	VisitOnAbort(*OnAbortStep) error
 	VisitOnError(*OnErrorStep) error
 	VisitEnsure(*EnsureStep) error
+	VisitIf(*IfStep) error
+	VisitUse(*UseStep) error
 }
 
 // StepDetector is a simple structure used to detect whether a step type is
@@ -222,6 +225,10 @@ type StepDetector struct {
 // some important inter-modifier precedence - while core step types are parsed
 // last.
 var StepPrecedence = []StepDetector{
+	{
+		Key: "if",
+		New: func() StepConfig { return &IfStep{} },
+	},
 	{
 		Key: "ensure",
 		New: func() StepConfig { return &EnsureStep{} },
@@ -274,6 +281,14 @@ var StepPrecedence = []StepDetector{
 		Key: "load_var",
 		New: func() StepConfig { return &LoadVarStep{} },
 	},
+	{
+		Key: "approval",
+		New: func() StepConfig { return &ApprovalStep{} },
+	},
+	{
+		Key: "use",
+		New: func() StepConfig { return &UseStep{} },
+	},
 	{
 		Key: "try",
 		New: func() StepConfig { return &TryStep{} },
@@ -346,6 +361,7 @@ type TaskStep struct {
 	InputMapping      map[string]string `json:"input_mapping,omitempty"`
 	OutputMapping     map[string]string `json:"output_mapping,omitempty"`
 	ImageArtifactName string            `json:"image,omitempty"`
+	Artifacts         []string          `json:"artifacts,omitempty"`
 }
 
 func (step *TaskStep) Visit(v StepVisitor) error {
@@ -376,6 +392,26 @@ func (step *LoadVarStep) Visit(v StepVisitor) error {
 	return v.VisitLoadVar(step)
 }
 
+// ApprovalStep pauses the build until a user approves or rejects it.
+type ApprovalStep struct {
+	Name string `json:"approval"`
+}
+
+func (step *ApprovalStep) Visit(v StepVisitor) error {
+	return v.VisitApproval(step)
+}
+
+// UseStep instantiates one of the pipeline's step templates with the given
+// args. It is replaced by the template's step when the build is planned.
+type UseStep struct {
+	Name string                 `json:"use"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+func (step *UseStep) Visit(v StepVisitor) error {
+	return v.VisitUse(step)
+}
+
 type TryStep struct {
 	Step Step `json:"try"`
 }
@@ -521,6 +557,27 @@ func (step *TimeoutStep) Visit(v StepVisitor) error {
 	return v.VisitTimeout(step)
 }
 
+// IfStep skips the step, along with its hooks, unless its condition holds.
+// The condition is evaluated against the build's vars when the step is run;
+// see StepCondition for its syntax.
+type IfStep struct {
+	Step StepConfig `json:"-"`
+
+	Condition string `json:"if"`
+}
+
+func (step *IfStep) Wrap(sub StepConfig) {
+	step.Step = sub
+}
+
+func (step *IfStep) Unwrap() StepConfig {
+	return step.Step
+}
+
+func (step *IfStep) Visit(v StepVisitor) error {
+	return v.VisitIf(step)
+}
+
 type OnSuccessStep struct {
 	Step StepConfig `json:"-"`
 	Hook Step       `json:"on_success"`
@@ -140,6 +140,17 @@ var factoryTests = []StepTest{
 			InstanceVars: atc.InstanceVars{"branch": "feature/foo"},
 		},
 	},
+	{
+		Title: "approval step",
+
+		ConfigYAML: `
+			approval: deploy
+		`,
+
+		StepConfig: &atc.ApprovalStep{
+			Name: "deploy",
+		},
+	},
 	{
 		Title: "load_var step",
 
@@ -400,12 +411,44 @@ var factoryTests = []StepTest{
 			Attempts: 3,
 		},
 	},
+	{
+		Title: "use step",
+
+		ConfigYAML: `
+			use: some-template
+			args:
+			  some: arg
+		`,
+
+		StepConfig: &atc.UseStep{
+			Name: "some-template",
+			Args: map[string]interface{}{"some": "arg"},
+		},
+	},
+	{
+		Title: "if modifier",
+
+		ConfigYAML: `
+			load_var: some-var
+			file: some-file
+			if: ((.:some-flag)) == "yes"
+		`,
+
+		StepConfig: &atc.IfStep{
+			Step: &atc.LoadVarStep{
+				Name: "some-var",
+				File: "some-file",
+			},
+			Condition: `((.:some-flag)) == "yes"`,
+		},
+	},
 	{
 		Title: "precedence of all hooks and modifiers",
 
 		ConfigYAML: `
 			load_var: some-var
 			file: some-file
+			if: ((.:some-flag))
 			timeout: 1h
 			attempts: 3
 			across:
@@ -428,63 +471,66 @@ var factoryTests = []StepTest{
 			  file: ensure-file
 		`,
 
-		StepConfig: &atc.EnsureStep{
-			Step: &atc.OnErrorStep{
-				Step: &atc.OnAbortStep{
-					Step: &atc.OnFailureStep{
-						Step: &atc.OnSuccessStep{
-							Step: &atc.AcrossStep{
-								Step: &atc.RetryStep{
-									Step: &atc.TimeoutStep{
-										Step: &atc.LoadVarStep{
-											Name: "some-var",
-											File: "some-file",
+		StepConfig: &atc.IfStep{
+			Step: &atc.EnsureStep{
+				Step: &atc.OnErrorStep{
+					Step: &atc.OnAbortStep{
+						Step: &atc.OnFailureStep{
+							Step: &atc.OnSuccessStep{
+								Step: &atc.AcrossStep{
+									Step: &atc.RetryStep{
+										Step: &atc.TimeoutStep{
+											Step: &atc.LoadVarStep{
+												Name: "some-var",
+												File: "some-file",
+											},
+											Duration: "1h",
+										},
+										Attempts: 3,
+									},
+									Vars: []atc.AcrossVarConfig{
+										{
+											Var:    "version",
+											Values: []interface{}{"v1", "v2", "v3"},
 										},
-										Duration: "1h",
 									},
-									Attempts: 3,
 								},
-								Vars: []atc.AcrossVarConfig{
-									{
-										Var:    "version",
-										Values: []interface{}{"v1", "v2", "v3"},
+								Hook: atc.Step{
+									Config: &atc.LoadVarStep{
+										Name: "success-var",
+										File: "success-file",
 									},
 								},
 							},
 							Hook: atc.Step{
 								Config: &atc.LoadVarStep{
-									Name: "success-var",
-									File: "success-file",
+									Name: "failure-var",
+									File: "failure-file",
 								},
 							},
 						},
 						Hook: atc.Step{
 							Config: &atc.LoadVarStep{
-								Name: "failure-var",
-								File: "failure-file",
+								Name: "abort-var",
+								File: "abort-file",
 							},
 						},
 					},
 					Hook: atc.Step{
 						Config: &atc.LoadVarStep{
-							Name: "abort-var",
-							File: "abort-file",
+							Name: "error-var",
+							File: "error-file",
 						},
 					},
 				},
 				Hook: atc.Step{
 					Config: &atc.LoadVarStep{
-						Name: "error-var",
-						File: "error-file",
+						Name: "ensure-var",
+						File: "ensure-file",
 					},
 				},
 			},
-			Hook: atc.Step{
-				Config: &atc.LoadVarStep{
-					Name: "ensure-var",
-					File: "ensure-file",
-				},
-			},
+			Condition: "((.:some-flag))",
 		},
 	},
 	{
@@ -0,0 +1,10 @@
+package atc
+
+// StoredArtifact is a path from a task's outputs which was uploaded to the
+// artifact store once the task succeeded.
+type StoredArtifact struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Size      int64  `json:"size"`
+	CreatedAt int64  `json:"created_at"`
+}
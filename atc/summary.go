@@ -21,6 +21,8 @@ type JobSummary struct {
 
 	Inputs  []JobInputSummary  `json:"inputs,omitempty"`
 	Outputs []JobOutputSummary `json:"outputs,omitempty"`
+
+	DependsOn []string `json:"depends_on,omitempty"`
 }
 
 type BuildSummary struct {
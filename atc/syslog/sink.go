@@ -0,0 +1,89 @@
+package syslog
+
+import (
+	"context"
+	"sync"
+
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/event"
+	"github.com/concourse/concourse/atc/logdrain"
+)
+
+type sink struct {
+	hostname  string
+	transport string
+	address   string
+	caCerts   []string
+
+	// the connection is dialed on the first batch of a run and reused until
+	// the drainer closes the sink at the end of the run
+	syslog *Syslog
+	mu     sync.Mutex
+}
+
+// NewSink returns a log drain sink which writes the build logs to a syslog
+// server. Events other than logs are not sent.
+func NewSink(transport string, address string, hostname string, caCerts []string) logdrain.Sink {
+	return &sink{
+		hostname:  hostname,
+		transport: transport,
+		address:   address,
+		caCerts:   caCerts,
+	}
+}
+
+func (s *sink) Name() string {
+	return "syslog"
+}
+
+func (s *sink) Drain(ctx context.Context, build db.Build, records []logdrain.Record) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.syslog == nil {
+		syslog, err := Dial(s.transport, s.address, s.caCerts)
+		if err != nil {
+			return err
+		}
+
+		s.syslog = syslog
+	}
+
+	for _, record := range records {
+		if record.Event != string(event.EventTypeLog) {
+			continue
+		}
+
+		err := s.syslog.Write(
+			s.hostname,
+			build.SyslogTag(event.OriginID(record.StepID)),
+			record.Time,
+			record.Message,
+		)
+		if err != nil {
+			// redial on the next batch rather than reusing a broken connection
+			s.close()
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *sink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.close()
+}
+
+func (s *sink) close() error {
+	if s.syslog == nil {
+		return nil
+	}
+
+	err := s.syslog.Close()
+	s.syslog = nil
+
+	return err
+}
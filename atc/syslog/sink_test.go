@@ -0,0 +1,58 @@
+package syslog_test
+
+import (
+	"context"
+	"time"
+
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/logdrain"
+	"github.com/concourse/concourse/atc/syslog"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Sink", func() {
+	var fakeBuild *dbfakes.FakeBuild
+	var server *testServer
+
+	BeforeEach(func() {
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.SyslogTagReturns("main/123/some-origin")
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	Context("when tls is not set", func() {
+		BeforeEach(func() {
+			server = newTestServer(nil)
+		})
+
+		It("drains the build logs by tcp", func() {
+			sink := syslog.NewSink("tcp", server.Addr, "test", []string{})
+			err := sink.Drain(context.TODO(), fakeBuild, []logdrain.Record{
+				{
+					Time:    time.Unix(1533744538, 0),
+					StepID:  "some-origin",
+					Event:   "log",
+					Message: "build 123 log",
+				},
+				{
+					Time:    time.Unix(1533744538, 0),
+					Event:   "status",
+					Message: "build 123 status",
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			got := <-server.Messages
+			Expect(got).To(ContainSubstring("main/123/some-origin"))
+			Expect(got).To(ContainSubstring("build 123 log"))
+			Expect(got).NotTo(ContainSubstring("build 123 status"))
+
+			Expect(fakeBuild.SyslogTagCallCount()).To(Equal(1))
+			Expect(fakeBuild.SyslogTagArgsForCall(0).String()).To(Equal("some-origin"))
+		}, 0.2)
+	})
+})
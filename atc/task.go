@@ -36,6 +36,12 @@ type TaskConfig struct {
 
 	// Path to cached directory that will be shared between builds for the same task.
 	Caches []TaskCacheConfig `json:"caches,omitempty"`
+
+	// Network access rules to set on the Task Container
+	Network *TaskNetworkConfig `json:"network,omitempty"`
+
+	// Service containers to run alongside the Task Container
+	Services []TaskServiceConfig `json:"services,omitempty"`
 }
 
 type ImageResource struct {
@@ -99,6 +105,12 @@ func (config TaskConfig) Validate() error {
 
 	errors = append(errors, config.validateInputContainsNames()...)
 	errors = append(errors, config.validateOutputContainsNames()...)
+	errors = append(errors, config.validateCacheKeys()...)
+	errors = append(errors, config.validateServices()...)
+
+	if config.Network != nil {
+		errors = append(errors, config.Network.validate()...)
+	}
 
 	if len(errors) > 0 {
 		return TaskValidationError{
@@ -133,6 +145,18 @@ func (config TaskConfig) validateInputContainsNames() []string {
 	return messages
 }
 
+func (config TaskConfig) validateCacheKeys() []string {
+	messages := []string{}
+
+	for i, cache := range config.Caches {
+		if cache.Key == "" && len(cache.RestoreKeys) != 0 {
+			messages = append(messages, fmt.Sprintf("  cache in position %d has restore_keys but no key", i))
+		}
+	}
+
+	return messages
+}
+
 type TaskRunConfig struct {
 	Path string   `json:"path"`
 	Args []string `json:"args,omitempty"`
@@ -155,6 +179,16 @@ type TaskOutputConfig struct {
 
 type TaskCacheConfig struct {
 	Path string `json:"path,omitempty"`
+
+	// Key, if set, stores the cache under a key which is shared by every job
+	// in the team instead of scoping it to the job's step. The key is a
+	// template which may hash the content of input files, e.g.
+	// 'go-{{hashFiles "repo/go.sum"}}'.
+	Key string `json:"key,omitempty"`
+
+	// RestoreKeys are key prefixes used to find the most recently used cache
+	// when no cache exists for the key.
+	RestoreKeys []string `json:"restore_keys,omitempty"`
 }
 
 type TaskEnv map[string]string
@@ -0,0 +1,164 @@
+package atc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+)
+
+const (
+	NetworkProtocolAll  = "all"
+	NetworkProtocolTCP  = "tcp"
+	NetworkProtocolUDP  = "udp"
+	NetworkProtocolICMP = "icmp"
+)
+
+// TaskNetworkConfig configures the network access of a task's container.
+type TaskNetworkConfig struct {
+	// Egress to allow even if it matches one of the deny rules. If there are
+	// no deny rules, any other egress is rejected.
+	Allow []TaskNetworkRule `json:"allow,omitempty"`
+
+	// Egress to reject.
+	Deny []TaskNetworkRule `json:"deny,omitempty"`
+
+	// Ports of the container to expose on the worker.
+	Expose []TaskPortMapping `json:"expose,omitempty"`
+}
+
+type TaskNetworkRule struct {
+	// Destination network in CIDR notation, e.g. 10.0.0.0/8. Defaults to any
+	// destination.
+	Network string `json:"network,omitempty"`
+
+	// One of tcp, udp, icmp or all (the default).
+	Protocol string `json:"protocol,omitempty"`
+
+	// Destination ports, only applicable to tcp and udp. Defaults to any
+	// port.
+	Ports []PortRange `json:"ports,omitempty"`
+}
+
+type TaskPortMapping struct {
+	ContainerPort uint16 `json:"container_port"`
+
+	// Port of the worker to forward to the container port, which must lie
+	// within the worker's host port range. A free port from that range is
+	// picked if not set.
+	HostPort uint16 `json:"host_port,omitempty"`
+}
+
+// PortRange is either a single port, given as a number, or an inclusive range
+// of ports, given as a string like "8000-8100".
+type PortRange struct {
+	Start uint16
+	End   uint16
+}
+
+func (r PortRange) MarshalJSON() ([]byte, error) {
+	if r.Start == r.End {
+		return json.Marshal(r.Start)
+	}
+
+	return json.Marshal(fmt.Sprintf("%d-%d", r.Start, r.End))
+}
+
+func (r *PortRange) UnmarshalJSON(data []byte) error {
+	var port uint16
+	if err := json.Unmarshal(data, &port); err == nil {
+		*r = PortRange{Start: port, End: port}
+		return nil
+	}
+
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return errors.New("port must be a number or a range like 8000-8100")
+	}
+
+	portRange, err := ParsePortRange(str)
+	if err != nil {
+		return err
+	}
+
+	*r = portRange
+	return nil
+}
+
+func ParsePortRange(str string) (PortRange, error) {
+	bounds := strings.SplitN(str, "-", 2)
+
+	start, err := strconv.ParseUint(strings.TrimSpace(bounds[0]), 10, 16)
+	if err != nil {
+		return PortRange{}, fmt.Errorf("invalid port '%s'", str)
+	}
+
+	end := start
+	if len(bounds) == 2 {
+		end, err = strconv.ParseUint(strings.TrimSpace(bounds[1]), 10, 16)
+		if err != nil {
+			return PortRange{}, fmt.Errorf("invalid port range '%s'", str)
+		}
+	}
+
+	if end < start {
+		return PortRange{}, fmt.Errorf("invalid port range '%s'", str)
+	}
+
+	return PortRange{Start: uint16(start), End: uint16(end)}, nil
+}
+
+func (config TaskNetworkConfig) validate() []string {
+	var messages []string
+
+	for i, rule := range config.Allow {
+		for _, problem := range rule.validate() {
+			messages = append(messages, fmt.Sprintf("  network allow rule in position %d %s", i, problem))
+		}
+	}
+
+	for i, rule := range config.Deny {
+		for _, problem := range rule.validate() {
+			messages = append(messages, fmt.Sprintf("  network deny rule in position %d %s", i, problem))
+		}
+	}
+
+	for i, mapping := range config.Expose {
+		if mapping.ContainerPort == 0 {
+			messages = append(messages, fmt.Sprintf("  network expose in position %d is missing a container_port", i))
+		}
+	}
+
+	return messages
+}
+
+func (rule TaskNetworkRule) validate() []string {
+	var problems []string
+
+	if rule.Network != "" {
+		_, _, err := net.ParseCIDR(rule.Network)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("has invalid network '%s'", rule.Network))
+		}
+	}
+
+	switch rule.Protocol {
+	case "", NetworkProtocolAll, NetworkProtocolICMP:
+		if len(rule.Ports) > 0 {
+			problems = append(problems, "has ports but protocol is not tcp or udp")
+		}
+	case NetworkProtocolTCP, NetworkProtocolUDP:
+	default:
+		problems = append(problems, fmt.Sprintf("has unknown protocol '%s'", rule.Protocol))
+	}
+
+	for _, port := range rule.Ports {
+		if port.Start == 0 || port.End < port.Start {
+			problems = append(problems, fmt.Sprintf("has invalid ports '%d-%d'", port.Start, port.End))
+		}
+	}
+
+	return problems
+}
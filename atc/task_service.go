@@ -0,0 +1,117 @@
+package atc
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// TaskServiceConfig configures a service container, e.g. a database, which
+// is started before the task's script runs and stopped once it exits. It
+// shares the network of the task's container, so its ports are reachable on
+// localhost or by the service's name.
+type TaskServiceConfig struct {
+	// Name of the service, which is also the hostname it can be reached at.
+	Name string `json:"name"`
+
+	// The image to run the service in.
+	ImageResource *ImageResource `json:"image_resource,omitempty"`
+
+	// Parameters to pass to the service via environment variables.
+	Params TaskEnv `json:"params,omitempty"`
+
+	// Command which runs the service in the foreground.
+	Run TaskRunConfig `json:"run,omitempty"`
+
+	// Ports the service listens on. Unless a readiness probe is configured,
+	// the service is considered ready once something listens on all of them,
+	// which is checked with a shell from the task's container.
+	Ports []uint16 `json:"ports,omitempty"`
+
+	// Probe determining whether the service is ready to be used.
+	Readiness *TaskServiceReadiness `json:"readiness,omitempty"`
+}
+
+// TaskServiceReadiness configures a command which is run in the service's
+// container until it succeeds.
+type TaskServiceReadiness struct {
+	Run TaskRunConfig `json:"run,omitempty"`
+
+	// How long to wait between attempts. Defaults to 1s.
+	Interval string `json:"interval,omitempty"`
+
+	// How long to wait for the service to become ready. Defaults to 1m.
+	Timeout string `json:"timeout,omitempty"`
+}
+
+const (
+	DefaultServiceReadinessInterval = time.Second
+	DefaultServiceReadinessTimeout  = time.Minute
+)
+
+var validServiceName = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]*[a-z0-9])?$`)
+
+// IntervalDuration parses the interval, falling back to the default.
+func (readiness TaskServiceReadiness) IntervalDuration() (time.Duration, error) {
+	return parseDurationOrDefault(readiness.Interval, DefaultServiceReadinessInterval)
+}
+
+// TimeoutDuration parses the timeout, falling back to the default.
+func (readiness TaskServiceReadiness) TimeoutDuration() (time.Duration, error) {
+	return parseDurationOrDefault(readiness.Timeout, DefaultServiceReadinessTimeout)
+}
+
+func parseDurationOrDefault(value string, def time.Duration) (time.Duration, error) {
+	if value == "" {
+		return def, nil
+	}
+
+	return time.ParseDuration(value)
+}
+
+func (config TaskConfig) validateServices() []string {
+	var messages []string
+
+	names := map[string]bool{}
+	for i, service := range config.Services {
+		if service.Name == "" {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing a name", i))
+		} else if !validServiceName.MatchString(service.Name) {
+			messages = append(messages, fmt.Sprintf("  service in position %d has invalid name '%s' (must be a lowercase hostname)", i, service.Name))
+		} else if names[service.Name] {
+			messages = append(messages, fmt.Sprintf("  service '%s' is defined more than once", service.Name))
+		}
+
+		names[service.Name] = true
+
+		if service.ImageResource == nil {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing an image_resource", i))
+		}
+
+		if service.Run.Path == "" {
+			messages = append(messages, fmt.Sprintf("  service in position %d is missing path to executable to run", i))
+		}
+
+		for _, port := range service.Ports {
+			if port == 0 {
+				messages = append(messages, fmt.Sprintf("  service in position %d has invalid port 0", i))
+			}
+		}
+
+		if service.Readiness != nil {
+			if service.Readiness.Run.Path == "" {
+				messages = append(messages, fmt.Sprintf("  service in position %d has a readiness probe without path to executable to run", i))
+			}
+
+			if _, err := service.Readiness.IntervalDuration(); err != nil {
+				messages = append(messages, fmt.Sprintf("  service in position %d has invalid readiness interval '%s'", i, service.Readiness.Interval))
+			}
+
+			if _, err := service.Readiness.TimeoutDuration(); err != nil {
+				messages = append(messages, fmt.Sprintf("  service in position %d has invalid readiness timeout '%s'", i, service.Readiness.Timeout))
+			}
+		}
+	}
+
+	return messages
+}
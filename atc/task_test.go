@@ -1,6 +1,8 @@
 package atc_test
 
 import (
+	"encoding/json"
+
 	"github.com/concourse/concourse/atc"
 	. "github.com/concourse/concourse/atc"
 
@@ -386,6 +388,232 @@ run: {path: a/file}
 			})
 		})
 
+		Context("when the task has keyed caches", func() {
+			BeforeEach(func() {
+				validConfig.Caches = append(validConfig.Caches, TaskCacheConfig{
+					Path:        "gopath",
+					Key:         `go-{{hashFiles "repo/go.sum"}}`,
+					RestoreKeys: []string{"go-"},
+				})
+			})
+
+			It("is valid", func() {
+				Expect(validConfig.Validate()).ToNot(HaveOccurred())
+			})
+
+			Context("when restore_keys are given without a key", func() {
+				BeforeEach(func() {
+					invalidConfig.Caches = append(invalidConfig.Caches, TaskCacheConfig{
+						Path:        "gopath",
+						RestoreKeys: []string{"go-"},
+					})
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("cache in position 0 has restore_keys but no key")))
+				})
+			})
+		})
+
+		Context("when the task has network rules", func() {
+			It("parses single ports and port ranges", func() {
+				data := []byte(`
+platform: linux
+network:
+  allow:
+  - network: 10.0.0.0/8
+    protocol: tcp
+    ports: [443, "8000-8100"]
+  deny:
+  - network: 0.0.0.0/0
+  expose:
+  - container_port: 8080
+
+run: {path: a/file}
+`)
+				task, err := NewTaskConfig(data)
+				Expect(err).ToNot(HaveOccurred())
+				Expect(task.Network).To(Equal(&TaskNetworkConfig{
+					Allow: []TaskNetworkRule{
+						{
+							Network:  "10.0.0.0/8",
+							Protocol: "tcp",
+							Ports: []PortRange{
+								{Start: 443, End: 443},
+								{Start: 8000, End: 8100},
+							},
+						},
+					},
+					Deny: []TaskNetworkRule{
+						{Network: "0.0.0.0/0"},
+					},
+					Expose: []TaskPortMapping{
+						{ContainerPort: 8080},
+					},
+				}))
+			})
+
+			It("round-trips port ranges through json", func() {
+				payload, err := json.Marshal([]PortRange{{Start: 443, End: 443}, {Start: 8000, End: 8100}})
+				Expect(err).ToNot(HaveOccurred())
+				Expect(payload).To(MatchJSON(`[443, "8000-8100"]`))
+			})
+
+			It("rejects invalid port ranges", func() {
+				_, err := NewTaskConfig([]byte(`
+platform: linux
+network: {allow: [{protocol: tcp, ports: ["9000-8000"]}]}
+run: {path: a/file}
+`))
+				Expect(err).To(MatchError(ContainSubstring("invalid port range '9000-8000'")))
+			})
+
+			Context("when a rule has an invalid network", func() {
+				BeforeEach(func() {
+					invalidConfig.Network = &TaskNetworkConfig{
+						Deny: []TaskNetworkRule{{Network: "10.0.0.0"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("network deny rule in position 0 has invalid network '10.0.0.0'")))
+				})
+			})
+
+			Context("when a rule has an unknown protocol", func() {
+				BeforeEach(func() {
+					invalidConfig.Network = &TaskNetworkConfig{
+						Allow: []TaskNetworkRule{{Protocol: "sctp"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("network allow rule in position 0 has unknown protocol 'sctp'")))
+				})
+			})
+
+			Context("when a rule has ports without tcp or udp", func() {
+				BeforeEach(func() {
+					invalidConfig.Network = &TaskNetworkConfig{
+						Allow: []TaskNetworkRule{{Ports: []PortRange{{Start: 443, End: 443}}}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("network allow rule in position 0 has ports but protocol is not tcp or udp")))
+				})
+			})
+
+			Context("when an exposed port is missing the container port", func() {
+				BeforeEach(func() {
+					invalidConfig.Network = &TaskNetworkConfig{
+						Expose: []TaskPortMapping{{HostPort: 8080}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("network expose in position 0 is missing a container_port")))
+				})
+			})
+		})
+
+		Context("when the task has services", func() {
+			It("parses them", func() {
+				task, err := NewTaskConfig([]byte(`
+platform: linux
+services:
+- name: postgres
+  image_resource: {type: registry-image, source: {repository: postgres}}
+  params: {POSTGRES_PASSWORD: secret}
+  run: {path: docker-entrypoint.sh, args: [postgres]}
+  ports: [5432]
+  readiness:
+    run: {path: pg_isready}
+    interval: 2s
+    timeout: 30s
+run: {path: a/file}
+`))
+				Expect(err).ToNot(HaveOccurred())
+				Expect(task.Services).To(Equal([]TaskServiceConfig{
+					{
+						Name: "postgres",
+						ImageResource: &ImageResource{
+							Type:   "registry-image",
+							Source: Source{"repository": "postgres"},
+						},
+						Params: TaskEnv{"POSTGRES_PASSWORD": "secret"},
+						Run: TaskRunConfig{
+							Path: "docker-entrypoint.sh",
+							Args: []string{"postgres"},
+						},
+						Ports: []uint16{5432},
+						Readiness: &TaskServiceReadiness{
+							Run:      TaskRunConfig{Path: "pg_isready"},
+							Interval: "2s",
+							Timeout:  "30s",
+						},
+					},
+				}))
+			})
+
+			Context("when a service is missing a name, image and run", func() {
+				BeforeEach(func() {
+					invalidConfig.Services = []TaskServiceConfig{{}}
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+					Expect(err).To(MatchError(ContainSubstring("service in position 0 is missing a name")))
+					Expect(err).To(MatchError(ContainSubstring("service in position 0 is missing an image_resource")))
+					Expect(err).To(MatchError(ContainSubstring("service in position 0 is missing path to executable to run")))
+				})
+			})
+
+			Context("when a service name is not a hostname", func() {
+				BeforeEach(func() {
+					invalidConfig.Services = []TaskServiceConfig{
+						{Name: "Postgres_DB", ImageResource: &ImageResource{}, Run: TaskRunConfig{Path: "a"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service in position 0 has invalid name 'Postgres_DB'")))
+				})
+			})
+
+			Context("when a service is defined twice", func() {
+				BeforeEach(func() {
+					invalidConfig.Services = []TaskServiceConfig{
+						{Name: "redis", ImageResource: &ImageResource{}, Run: TaskRunConfig{Path: "a"}},
+						{Name: "redis", ImageResource: &ImageResource{}, Run: TaskRunConfig{Path: "a"}},
+					}
+				})
+
+				It("returns an error", func() {
+					Expect(invalidConfig.Validate()).To(MatchError(ContainSubstring("service 'redis' is defined more than once")))
+				})
+			})
+
+			Context("when a readiness probe is invalid", func() {
+				BeforeEach(func() {
+					invalidConfig.Services = []TaskServiceConfig{
+						{
+							Name:          "redis",
+							ImageResource: &ImageResource{},
+							Run:           TaskRunConfig{Path: "a"},
+							Readiness:     &TaskServiceReadiness{Timeout: "soon"},
+						},
+					}
+				})
+
+				It("returns an error", func() {
+					err := invalidConfig.Validate()
+					Expect(err).To(MatchError(ContainSubstring("service in position 0 has a readiness probe without path to executable to run")))
+					Expect(err).To(MatchError(ContainSubstring("service in position 0 has invalid readiness timeout 'soon'")))
+				})
+			})
+		})
+
 		Context("when run is missing", func() {
 			BeforeEach(func() {
 				invalidConfig.Run.Path = ""
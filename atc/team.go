@@ -5,17 +5,35 @@ import (
 )
 
 var (
-	ErrAuthConfigEmpty   = errors.New("auth config for the team must not be empty")
-	ErrAuthConfigInvalid = errors.New("auth config for the team does not have users and groups configured")
+	ErrAuthConfigEmpty    = errors.New("auth config for the team must not be empty")
+	ErrAuthConfigInvalid  = errors.New("auth config for the team does not have users and groups configured")
+	ErrMaxInFlightInvalid = errors.New("max in flight for the team must not be negative")
+	ErrWeightInvalid      = errors.New("weight for the team must not be negative")
 )
 
 type Team struct {
 	ID   int      `json:"id,omitempty"`
 	Name string   `json:"name,omitempty"`
 	Auth TeamAuth `json:"auth,omitempty"`
+
+	// Maximum number of builds the team may run at the same time. 0 means no
+	// limit.
+	MaxInFlight int `json:"max_in_flight,omitempty"`
+
+	// The team's share of the cluster-wide build capacity relative to other
+	// teams. 0 is the same as 1.
+	Weight int `json:"weight,omitempty"`
 }
 
 func (team Team) Validate() error {
+	if team.MaxInFlight < 0 {
+		return ErrMaxInFlightInvalid
+	}
+
+	if team.Weight < 0 {
+		return ErrWeightInvalid
+	}
+
 	return team.Auth.Validate()
 }
 
@@ -0,0 +1,82 @@
+package atc
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// Webhook subscribes a URL to the status transitions of a team's builds,
+// optionally narrowed down to a single pipeline and to some of the statuses.
+type Webhook struct {
+	Name      string        `json:"name"`
+	URL       string        `json:"url"`
+	Secret    string        `json:"secret,omitempty"`
+	Pipeline  string        `json:"pipeline,omitempty"`
+	Events    []BuildStatus `json:"events,omitempty"`
+	CreatedAt int64         `json:"created_at,omitempty"`
+}
+
+var webhookEvents = map[BuildStatus]bool{
+	StatusPending:         true,
+	StatusStarted:         true,
+	StatusWaitingApproval: true,
+	StatusSucceeded:       true,
+	StatusFailed:          true,
+	StatusErrored:         true,
+	StatusAborted:         true,
+}
+
+func (webhook Webhook) Validate() error {
+	if webhook.Name == "" {
+		return errors.New("webhook name must be specified")
+	}
+
+	if warning := ValidateIdentifier(webhook.Name, "webhook"); warning != nil {
+		return errors.New(warning.Message)
+	}
+
+	u, err := url.Parse(webhook.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return fmt.Errorf("invalid webhook url '%s': must be an absolute http or https url", webhook.URL)
+	}
+
+	for _, event := range webhook.Events {
+		if !webhookEvents[event] {
+			return fmt.Errorf("invalid webhook event '%s'", event)
+		}
+	}
+
+	return nil
+}
+
+type WebhookDeliveryState string
+
+const (
+	WebhookDeliveryPending   WebhookDeliveryState = "pending"
+	WebhookDeliveryDelivered WebhookDeliveryState = "delivered"
+	WebhookDeliveryFailed    WebhookDeliveryState = "failed"
+)
+
+// WebhookDelivery is an entry in a webhook's delivery log.
+type WebhookDelivery struct {
+	ID             int64                `json:"id"`
+	BuildID        int                  `json:"build_id"`
+	Status         BuildStatus          `json:"status"`
+	State          WebhookDeliveryState `json:"state"`
+	Attempts       int                  `json:"attempts"`
+	ResponseStatus int                  `json:"response_status,omitempty"`
+	Error          string               `json:"error,omitempty"`
+	CreatedAt      int64                `json:"created_at"`
+	NextAttemptAt  int64                `json:"next_attempt_at,omitempty"`
+	DeliveredAt    int64                `json:"delivered_at,omitempty"`
+}
+
+// WebhookPayload is the body sent to a webhook when a build's status changes.
+type WebhookPayload struct {
+	Webhook  string      `json:"webhook"`
+	Delivery int64       `json:"delivery"`
+	Status   BuildStatus `json:"status"`
+	Build    Build       `json:"build"`
+	URL      string      `json:"url"`
+}
@@ -0,0 +1,48 @@
+package atc_test
+
+import (
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Webhook", func() {
+	Describe("Validate", func() {
+		var webhook atc.Webhook
+
+		BeforeEach(func() {
+			webhook = atc.Webhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+				Events: []atc.BuildStatus{atc.StatusSucceeded, atc.StatusFailed},
+			}
+		})
+
+		It("accepts a valid webhook", func() {
+			Expect(webhook.Validate()).To(Succeed())
+		})
+
+		It("requires a name", func() {
+			webhook.Name = ""
+			Expect(webhook.Validate()).To(MatchError("webhook name must be specified"))
+		})
+
+		It("rejects invalid names", func() {
+			webhook.Name = "Some Webhook"
+			Expect(webhook.Validate()).ToNot(Succeed())
+		})
+
+		It("requires an absolute http url", func() {
+			for _, url := range []string{"", "/hook", "example.com/hook", "ftp://example.com/hook"} {
+				webhook.URL = url
+				Expect(webhook.Validate()).To(MatchError(ContainSubstring("must be an absolute http or https url")), url)
+			}
+		})
+
+		It("rejects unknown events", func() {
+			webhook.Events = []atc.BuildStatus{"exploded"}
+			Expect(webhook.Validate()).To(MatchError("invalid webhook event 'exploded'"))
+		})
+	})
+})
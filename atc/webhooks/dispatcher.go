@@ -0,0 +1,193 @@
+package webhooks
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.cloudfoundry.org/lager"
+	"code.cloudfoundry.org/lager/lagerctx"
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/api/present"
+	"github.com/concourse/concourse/atc/db"
+)
+
+const (
+	// batchSize is the maximum number of deliveries attempted per run.
+	batchSize = 100
+
+	// MaxAttempts is the number of times a delivery is attempted before it is
+	// given up on.
+	MaxAttempts = 5
+
+	// RetryBackoff is the delay before the first retry of a failed delivery.
+	// It doubles with every subsequent attempt.
+	RetryBackoff = 30 * time.Second
+)
+
+const (
+	SignatureHeader = "X-Concourse-Signature"
+	EventHeader     = "X-Concourse-Event"
+	DeliveryHeader  = "X-Concourse-Delivery"
+)
+
+//go:generate counterfeiter . Dispatcher
+
+type Dispatcher interface {
+	Run(context.Context) error
+}
+
+type dispatcher struct {
+	repository   db.WebhookDeliveryRepository
+	buildFactory db.BuildFactory
+	externalURL  string
+	client       *http.Client
+}
+
+func NewDispatcher(
+	repository db.WebhookDeliveryRepository,
+	buildFactory db.BuildFactory,
+	externalURL string,
+	client *http.Client,
+) Dispatcher {
+	return &dispatcher{
+		repository:   repository,
+		buildFactory: buildFactory,
+		externalURL:  externalURL,
+		client:       client,
+	}
+}
+
+func (d *dispatcher) Run(ctx context.Context) error {
+	logger := lagerctx.FromContext(ctx).Session("webhook-dispatcher")
+
+	deliveries, err := d.repository.PendingDeliveries(batchSize)
+	if err != nil {
+		logger.Error("failed-to-get-pending-deliveries", err)
+		return err
+	}
+
+	for _, delivery := range deliveries {
+		err := d.deliver(ctx, logger, delivery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// deliver attempts a single delivery and records the outcome. Only errors
+// which prevent the outcome from being recorded are returned; anything going
+// wrong on the receiving end is noted on the delivery and retried later.
+func (d *dispatcher) deliver(ctx context.Context, logger lager.Logger, delivery db.PendingWebhookDelivery) error {
+	logger = logger.Session("deliver", lager.Data{
+		"webhook":  delivery.Webhook.Name,
+		"delivery": delivery.ID,
+		"build":    delivery.BuildID,
+	})
+
+	build, found, err := d.buildFactory.Build(delivery.BuildID)
+	if err != nil {
+		logger.Error("failed-to-get-build", err)
+		return err
+	}
+
+	if !found {
+		return d.repository.MarkFailed(delivery.ID, 0, "build not found", time.Time{})
+	}
+
+	body, err := json.Marshal(atc.WebhookPayload{
+		Webhook:  delivery.Webhook.Name,
+		Delivery: delivery.ID,
+		Status:   atc.BuildStatus(delivery.Status),
+		Build:    present.Build(build),
+		URL:      d.buildURL(build),
+	})
+	if err != nil {
+		return err
+	}
+
+	responseStatus, err := d.post(ctx, delivery, body)
+	if err != nil {
+		logger.Info("failed", lager.Data{"error": err.Error(), "attempt": delivery.Attempts + 1})
+
+		var retryAt time.Time
+		if delivery.Attempts+1 < MaxAttempts {
+			retryAt = time.Now().Add(RetryBackoff << uint(delivery.Attempts))
+		}
+
+		return d.repository.MarkFailed(delivery.ID, responseStatus, err.Error(), retryAt)
+	}
+
+	return d.repository.MarkDelivered(delivery.ID, responseStatus)
+}
+
+func (d *dispatcher) post(ctx context.Context, delivery db.PendingWebhookDelivery, body []byte) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Concourse-Webhook")
+	req.Header.Set(EventHeader, "build-"+string(delivery.Status))
+	req.Header.Set(DeliveryHeader, strconv.FormatInt(delivery.ID, 10))
+
+	if delivery.Webhook.Secret != "" {
+		req.Header.Set(SignatureHeader, Sign([]byte(delivery.Webhook.Secret), body))
+	}
+
+	res, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+
+	defer res.Body.Close()
+
+	// the response body is not recorded, as it is shown to anyone who can
+	// see the webhook's deliveries
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res.StatusCode, fmt.Errorf("unexpected response: %s", res.Status)
+	}
+
+	return res.StatusCode, nil
+}
+
+// buildURL returns the link to the build in the web UI.
+func (d *dispatcher) buildURL(build db.Build) string {
+	if build.JobName() == "" {
+		return fmt.Sprintf("%s/builds/%d", d.externalURL, build.ID())
+	}
+
+	buildURL := fmt.Sprintf(
+		"%s/teams/%s/pipelines/%s/jobs/%s/builds/%s",
+		d.externalURL,
+		url.PathEscape(build.TeamName()),
+		url.PathEscape(build.PipelineName()),
+		url.PathEscape(build.JobName()),
+		url.PathEscape(build.Name()),
+	)
+
+	if params := build.PipelineRef().QueryParams(); params != nil {
+		buildURL += "?" + params.Encode()
+	}
+
+	return buildURL
+}
+
+// Sign returns the value of the signature header for a payload, i.e. the
+// hex-encoded HMAC-SHA256 of the body keyed by the webhook's secret.
+func Sign(secret []byte, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
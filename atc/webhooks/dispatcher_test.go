@@ -0,0 +1,279 @@
+package webhooks_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/db/dbfakes"
+	"github.com/concourse/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("Dispatcher", func() {
+	var (
+		server           *ghttp.Server
+		fakeRepository   *dbfakes.FakeWebhookDeliveryRepository
+		fakeBuildFactory *dbfakes.FakeBuildFactory
+		fakeBuild        *dbfakes.FakeBuild
+		delivery         db.PendingWebhookDelivery
+		pendingErr       error
+
+		dispatcher webhooks.Dispatcher
+		runErr     error
+	)
+
+	BeforeEach(func() {
+		server = ghttp.NewServer()
+
+		fakeBuild = new(dbfakes.FakeBuild)
+		fakeBuild.IDReturns(123)
+		fakeBuild.NameReturns("42")
+		fakeBuild.TeamNameReturns("some-team")
+		fakeBuild.PipelineNameReturns("some-pipeline")
+		fakeBuild.JobNameReturns("some-job")
+		fakeBuild.StatusReturns(db.BuildStatusFailed)
+
+		fakeBuildFactory = new(dbfakes.FakeBuildFactory)
+		fakeBuildFactory.BuildReturns(fakeBuild, true, nil)
+
+		delivery = db.PendingWebhookDelivery{
+			WebhookDelivery: db.WebhookDelivery{
+				ID:      7,
+				BuildID: 123,
+				Status:  db.BuildStatusFailed,
+			},
+			Webhook: db.Webhook{
+				Name: "some-webhook",
+				URL:  server.URL() + "/hook",
+			},
+		}
+
+		pendingErr = nil
+
+		fakeRepository = new(dbfakes.FakeWebhookDeliveryRepository)
+
+		dispatcher = webhooks.NewDispatcher(
+			fakeRepository,
+			fakeBuildFactory,
+			"https://ci.example.com",
+			&http.Client{Timeout: time.Second},
+		)
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	JustBeforeEach(func() {
+		if pendingErr != nil {
+			fakeRepository.PendingDeliveriesReturns(nil, pendingErr)
+		} else {
+			fakeRepository.PendingDeliveriesReturns([]db.PendingWebhookDelivery{delivery}, nil)
+		}
+
+		runErr = dispatcher.Run(context.TODO())
+	})
+
+	Context("when the receiver accepts the delivery", func() {
+		var payload atc.WebhookPayload
+		var header http.Header
+
+		BeforeEach(func() {
+			server.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", "/hook"),
+					ghttp.VerifyHeaderKV("Content-Type", "application/json"),
+					ghttp.VerifyHeaderKV(webhooks.EventHeader, "build-failed"),
+					ghttp.VerifyHeaderKV(webhooks.DeliveryHeader, "7"),
+					func(w http.ResponseWriter, r *http.Request) {
+						header = r.Header
+
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).ToNot(HaveOccurred())
+
+						err = json.Unmarshal(body, &payload)
+						Expect(err).ToNot(HaveOccurred())
+					},
+					ghttp.RespondWith(http.StatusNoContent, nil),
+				),
+			)
+		})
+
+		It("posts the build to the webhook", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(HaveLen(1))
+
+			Expect(payload.Webhook).To(Equal("some-webhook"))
+			Expect(payload.Delivery).To(Equal(int64(7)))
+			Expect(payload.Status).To(Equal(atc.StatusFailed))
+			Expect(payload.Build.ID).To(Equal(123))
+			Expect(payload.Build.JobName).To(Equal("some-job"))
+			Expect(payload.URL).To(Equal("https://ci.example.com/teams/some-team/pipelines/some-pipeline/jobs/some-job/builds/42"))
+		})
+
+		It("does not sign the payload", func() {
+			Expect(header).ToNot(HaveKey(webhooks.SignatureHeader))
+		})
+
+		It("marks the delivery as delivered", func() {
+			Expect(fakeRepository.MarkDeliveredCallCount()).To(Equal(1))
+			id, status := fakeRepository.MarkDeliveredArgsForCall(0)
+			Expect(id).To(Equal(int64(7)))
+			Expect(status).To(Equal(http.StatusNoContent))
+		})
+
+		Context("when the webhook has a secret", func() {
+			BeforeEach(func() {
+				delivery.Webhook.Secret = "some-secret"
+			})
+
+			It("signs the payload", func() {
+				body, err := json.Marshal(payload)
+				Expect(err).ToNot(HaveOccurred())
+
+				Expect(header.Get(webhooks.SignatureHeader)).To(Equal(webhooks.Sign([]byte("some-secret"), body)))
+			})
+		})
+
+		Context("when the build is a one-off", func() {
+			BeforeEach(func() {
+				fakeBuild.JobNameReturns("")
+				fakeBuild.PipelineNameReturns("")
+			})
+
+			It("links to the build by id", func() {
+				Expect(payload.URL).To(Equal("https://ci.example.com/builds/123"))
+			})
+		})
+
+		Context("when the pipeline is an instance", func() {
+			BeforeEach(func() {
+				fakeBuild.PipelineRefReturns(atc.PipelineRef{
+					Name:         "some-pipeline",
+					InstanceVars: atc.InstanceVars{"branch": "main"},
+				})
+			})
+
+			It("includes the instance vars in the link", func() {
+				Expect(payload.URL).To(HaveSuffix(`/builds/42?instance_vars=%7B%22branch%22%3A%22main%22%7D`))
+			})
+		})
+	})
+
+	Context("when the receiver rejects the delivery", func() {
+		BeforeEach(func() {
+			delivery.Attempts = 2
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusBadGateway, "bad gateway"))
+		})
+
+		It("schedules a retry with backoff", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.MarkDeliveredCallCount()).To(BeZero())
+
+			Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+			id, status, message, retryAt := fakeRepository.MarkFailedArgsForCall(0)
+			Expect(id).To(Equal(int64(7)))
+			Expect(status).To(Equal(http.StatusBadGateway))
+			Expect(message).To(Equal("unexpected response: 502 Bad Gateway"))
+			Expect(retryAt).To(BeTemporally("~", time.Now().Add(4*webhooks.RetryBackoff), 5*time.Second))
+		})
+
+		Context("on the last attempt", func() {
+			BeforeEach(func() {
+				delivery.Attempts = webhooks.MaxAttempts - 1
+			})
+
+			It("gives up", func() {
+				Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+				_, _, _, retryAt := fakeRepository.MarkFailedArgsForCall(0)
+				Expect(retryAt).To(BeZero())
+			})
+		})
+	})
+
+	Context("when the receiver cannot be reached", func() {
+		BeforeEach(func() {
+			delivery.Webhook.URL = "http://127.0.0.1:1/hook"
+		})
+
+		It("records the error", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+			_, status, message, retryAt := fakeRepository.MarkFailedArgsForCall(0)
+			Expect(status).To(BeZero())
+			Expect(message).ToNot(BeEmpty())
+			Expect(retryAt).ToNot(BeZero())
+		})
+	})
+
+	Context("when the receiver is on a private network", func() {
+		BeforeEach(func() {
+			dispatcher = webhooks.NewDispatcher(
+				fakeRepository,
+				fakeBuildFactory,
+				"https://ci.example.com",
+				webhooks.NewClient(time.Second),
+			)
+
+			server.AppendHandlers(ghttp.RespondWith(http.StatusNoContent, nil))
+		})
+
+		It("does not send the delivery", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+
+			Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+			_, status, message, _ := fakeRepository.MarkFailedArgsForCall(0)
+			Expect(status).To(BeZero())
+			Expect(message).To(ContainSubstring(webhooks.ErrBlockedDestination.Error()))
+		})
+
+		Context("when the receiver is given by name", func() {
+			BeforeEach(func() {
+				delivery.Webhook.URL = strings.Replace(server.URL(), "127.0.0.1", "localhost", 1) + "/hook"
+			})
+
+			It("checks the resolved address", func() {
+				Expect(server.ReceivedRequests()).To(BeEmpty())
+
+				Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+				_, _, message, _ := fakeRepository.MarkFailedArgsForCall(0)
+				Expect(message).To(ContainSubstring(webhooks.ErrBlockedDestination.Error()))
+			})
+		})
+	})
+
+	Context("when the build cannot be found", func() {
+		BeforeEach(func() {
+			fakeBuildFactory.BuildReturns(nil, false, nil)
+		})
+
+		It("gives up on the delivery", func() {
+			Expect(runErr).ToNot(HaveOccurred())
+			Expect(server.ReceivedRequests()).To(BeEmpty())
+			Expect(fakeRepository.MarkFailedCallCount()).To(Equal(1))
+			_, _, _, retryAt := fakeRepository.MarkFailedArgsForCall(0)
+			Expect(retryAt).To(BeZero())
+		})
+	})
+
+	Context("when fetching pending deliveries fails", func() {
+		BeforeEach(func() {
+			pendingErr = errors.New("disaster")
+		})
+
+		It("returns the error", func() {
+			Expect(runErr).To(MatchError("disaster"))
+		})
+	})
+})
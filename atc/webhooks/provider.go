@@ -0,0 +1,318 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/gobwas/glob"
+)
+
+// DefaultSignatureHeader carries the signature of calls to a resource's check
+// webhook when no provider is configured.
+const DefaultSignatureHeader = "X-Hub-Signature-256"
+
+// ErrInvalidSignature is returned when a call to a resource's check webhook
+// cannot be verified against the configured secret.
+var ErrInvalidSignature = errors.New("invalid webhook signature")
+
+// Event is what a provider's webhook call says happened, as far as filtering
+// is concerned.
+type Event struct {
+	Name     string
+	Branches []string
+
+	// Paths is nil if the event does not list the paths it changed.
+	Paths []string
+}
+
+type provider interface {
+	verify(secret string, header http.Header, body []byte) bool
+	parse(header http.Header, body []byte) (Event, error)
+}
+
+// Verify authenticates a call to a resource's check webhook and extracts the
+// event it describes.
+func Verify(config atc.ResourceWebhook, secret string, header http.Header, body []byte) (Event, error) {
+	var p provider
+	switch config.Provider {
+	case atc.WebhookProviderGitHub:
+		p = github{}
+	case atc.WebhookProviderGitLab:
+		p = gitlab{}
+	case atc.WebhookProviderBitbucket:
+		p = bitbucket{}
+	default:
+		signatureHeader := config.SignatureHeader
+		if signatureHeader == "" {
+			signatureHeader = DefaultSignatureHeader
+		}
+
+		p = generic{signatureHeader: signatureHeader}
+	}
+
+	if secret == "" || !p.verify(secret, header, body) {
+		return Event{}, ErrInvalidSignature
+	}
+
+	return p.parse(header, body)
+}
+
+// Matches returns whether the event passes the filter. Events which do not
+// list the paths they changed are not filtered by path, as it's safer to
+// check once too often than to miss a change.
+func (event Event) Matches(filter *atc.ResourceWebhookFilter) (bool, error) {
+	if filter == nil {
+		return true, nil
+	}
+
+	if len(filter.Events) > 0 && !contains(filter.Events, event.Name) {
+		return false, nil
+	}
+
+	if len(filter.Branches) > 0 {
+		matched, err := matchAny(filter.Branches, event.Branches)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	if len(filter.Paths) > 0 && event.Paths != nil {
+		matched, err := matchAny(filter.Paths, event.Paths)
+		if err != nil || !matched {
+			return false, err
+		}
+	}
+
+	return true, nil
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func matchAny(patterns []string, values []string) (bool, error) {
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return false, err
+		}
+
+		for _, value := range values {
+			if g.Match(value) {
+				return true, nil
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func validSignature(secret string, body []byte, signature string) bool {
+	return hmac.Equal([]byte(Sign([]byte(secret), body)), []byte(signature))
+}
+
+type generic struct {
+	signatureHeader string
+}
+
+func (p generic) verify(secret string, header http.Header, body []byte) bool {
+	signature := header.Get(p.signatureHeader)
+	if !strings.HasPrefix(signature, "sha256=") {
+		signature = "sha256=" + signature
+	}
+
+	return validSignature(secret, body, signature)
+}
+
+func (generic) parse(http.Header, []byte) (Event, error) {
+	return Event{}, nil
+}
+
+type github struct{}
+
+func (github) verify(secret string, header http.Header, body []byte) bool {
+	return validSignature(secret, body, header.Get("X-Hub-Signature-256"))
+}
+
+func (github) parse(header http.Header, body []byte) (Event, error) {
+	var payload struct {
+		Ref         string         `json:"ref"`
+		Commits     []commitChange `json:"commits"`
+		PullRequest *struct {
+			Base struct {
+				Ref string `json:"ref"`
+			} `json:"base"`
+		} `json:"pull_request"`
+	}
+
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	event := Event{Name: header.Get("X-GitHub-Event")}
+
+	if payload.PullRequest != nil {
+		event.Branches = []string{payload.PullRequest.Base.Ref}
+	} else if branch, ok := branchFromRef(payload.Ref); ok {
+		event.Branches = []string{branch}
+		event.Paths = changedPaths(payload.Commits)
+	}
+
+	return event, nil
+}
+
+type gitlab struct{}
+
+func (gitlab) verify(secret string, header http.Header, body []byte) bool {
+	// gitlab does not sign its payloads; it sends the secret token as-is
+	return subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), []byte(secret)) == 1
+}
+
+func (gitlab) parse(header http.Header, body []byte) (Event, error) {
+	var payload struct {
+		Ref              string         `json:"ref"`
+		Commits          []commitChange `json:"commits"`
+		ObjectAttributes *struct {
+			TargetBranch string `json:"target_branch"`
+		} `json:"object_attributes"`
+	}
+
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	// e.g. "Push Hook" -> "push", "Merge Request Hook" -> "merge_request"
+	name := strings.TrimSuffix(strings.ToLower(header.Get("X-Gitlab-Event")), " hook")
+	event := Event{Name: strings.ReplaceAll(name, " ", "_")}
+
+	if payload.ObjectAttributes != nil && payload.ObjectAttributes.TargetBranch != "" {
+		event.Branches = []string{payload.ObjectAttributes.TargetBranch}
+	} else if branch, ok := branchFromRef(payload.Ref); ok {
+		event.Branches = []string{branch}
+		event.Paths = changedPaths(payload.Commits)
+	}
+
+	return event, nil
+}
+
+// bitbucket handles both Bitbucket Cloud and Bitbucket Server, which sign
+// their payloads the same way but describe them differently.
+type bitbucket struct{}
+
+func (bitbucket) verify(secret string, header http.Header, body []byte) bool {
+	return validSignature(secret, body, header.Get("X-Hub-Signature"))
+}
+
+func (bitbucket) parse(header http.Header, body []byte) (Event, error) {
+	type serverRef struct {
+		Type      string `json:"type"`
+		DisplayID string `json:"displayId"`
+	}
+
+	var payload struct {
+		// cloud
+		Push *struct {
+			Changes []struct {
+				New *struct {
+					Type string `json:"type"`
+					Name string `json:"name"`
+				} `json:"new"`
+			} `json:"changes"`
+		} `json:"push"`
+		PullRequest *struct {
+			Destination struct {
+				Branch struct {
+					Name string `json:"name"`
+				} `json:"branch"`
+			} `json:"destination"`
+		} `json:"pullrequest"`
+
+		// server
+		Changes []struct {
+			Ref serverRef `json:"ref"`
+		} `json:"changes"`
+		ServerPullRequest *struct {
+			ToRef serverRef `json:"toRef"`
+		} `json:"pullRequest"`
+	}
+
+	err := json.Unmarshal(body, &payload)
+	if err != nil {
+		return Event{}, err
+	}
+
+	event := Event{Name: header.Get("X-Event-Key")}
+
+	if payload.Push != nil {
+		for _, change := range payload.Push.Changes {
+			if change.New != nil && change.New.Type == "branch" {
+				event.Branches = append(event.Branches, change.New.Name)
+			}
+		}
+	}
+
+	if payload.PullRequest != nil {
+		event.Branches = append(event.Branches, payload.PullRequest.Destination.Branch.Name)
+	}
+
+	for _, change := range payload.Changes {
+		if change.Ref.Type == "BRANCH" {
+			event.Branches = append(event.Branches, change.Ref.DisplayID)
+		}
+	}
+
+	if payload.ServerPullRequest != nil {
+		event.Branches = append(event.Branches, payload.ServerPullRequest.ToRef.DisplayID)
+	}
+
+	return event, nil
+}
+
+type commitChange struct {
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+}
+
+// maxListedCommits is the number of commits both GitHub and GitLab list in a
+// push event at most.
+const maxListedCommits = 20
+
+// changedPaths returns nil when the commits may not tell the whole story,
+// e.g. for force pushes which add no commits, or pushes of more commits than
+// are listed.
+func changedPaths(commits []commitChange) []string {
+	if len(commits) == 0 || len(commits) >= maxListedCommits {
+		return nil
+	}
+
+	paths := []string{}
+	for _, commit := range commits {
+		paths = append(paths, commit.Added...)
+		paths = append(paths, commit.Modified...)
+		paths = append(paths, commit.Removed...)
+	}
+
+	return paths
+}
+
+func branchFromRef(ref string) (string, bool) {
+	if !strings.HasPrefix(ref, "refs/heads/") {
+		return "", false
+	}
+
+	return strings.TrimPrefix(ref, "refs/heads/"), true
+}
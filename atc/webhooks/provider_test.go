@@ -0,0 +1,266 @@
+package webhooks_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/atc/webhooks"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/ginkgo/extensions/table"
+	. "github.com/onsi/gomega"
+)
+
+var _ = Describe("Verify", func() {
+	var (
+		config atc.ResourceWebhook
+		header http.Header
+		body   []byte
+
+		event     webhooks.Event
+		verifyErr error
+	)
+
+	BeforeEach(func() {
+		header = http.Header{}
+	})
+
+	JustBeforeEach(func() {
+		event, verifyErr = webhooks.Verify(config, "some-secret", header, body)
+	})
+
+	Context("without a provider", func() {
+		BeforeEach(func() {
+			config = atc.ResourceWebhook{}
+			body = []byte(`anything`)
+		})
+
+		Context("when the default header has a valid signature", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+			})
+
+			It("succeeds", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(event).To(Equal(webhooks.Event{}))
+			})
+		})
+
+		Context("when a custom header has a bare hex signature", func() {
+			BeforeEach(func() {
+				config.SignatureHeader = "X-Signature"
+				header.Set("X-Signature", webhooks.Sign([]byte("some-secret"), body)[len("sha256="):])
+			})
+
+			It("succeeds", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+			})
+		})
+
+		Context("when the signature is made with another secret", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("other-secret"), body))
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhooks.ErrInvalidSignature))
+			})
+		})
+
+		Context("when the signature is missing", func() {
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhooks.ErrInvalidSignature))
+			})
+		})
+	})
+
+	Context("with the github provider", func() {
+		BeforeEach(func() {
+			config = atc.ResourceWebhook{Provider: atc.WebhookProviderGitHub}
+			header.Set("X-GitHub-Event", "push")
+			body = []byte(`{
+				"ref": "refs/heads/main",
+				"commits": [
+					{"added": ["docs/new.md"], "modified": ["main.go"], "removed": []},
+					{"added": [], "modified": [], "removed": ["old.go"]}
+				]
+			}`)
+		})
+
+		Context("when the signature is valid", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+			})
+
+			It("describes the push", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(event).To(Equal(webhooks.Event{
+					Name:     "push",
+					Branches: []string{"main"},
+					Paths:    []string{"docs/new.md", "main.go", "old.go"},
+				}))
+			})
+
+			Context("for a tag", func() {
+				BeforeEach(func() {
+					body = []byte(`{"ref": "refs/tags/v1.0.0", "commits": []}`)
+					header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+				})
+
+				It("has no branch", func() {
+					Expect(event.Branches).To(BeEmpty())
+				})
+			})
+
+			Context("for a pull request", func() {
+				BeforeEach(func() {
+					header.Set("X-GitHub-Event", "pull_request")
+					body = []byte(`{"pull_request": {"base": {"ref": "main"}}}`)
+					header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+				})
+
+				It("uses the base branch", func() {
+					Expect(event).To(Equal(webhooks.Event{
+						Name:     "pull_request",
+						Branches: []string{"main"},
+					}))
+				})
+			})
+
+			Context("for a force push without new commits", func() {
+				BeforeEach(func() {
+					body = []byte(`{"ref": "refs/heads/main", "commits": []}`)
+					header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+				})
+
+				It("does not know which paths changed", func() {
+					Expect(event.Paths).To(BeNil())
+				})
+			})
+		})
+
+		Context("when the payload is signed with sha1", func() {
+			BeforeEach(func() {
+				header.Set("X-Hub-Signature", "sha1=0123456789abcdef")
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhooks.ErrInvalidSignature))
+			})
+		})
+
+		Context("when the payload is not json", func() {
+			BeforeEach(func() {
+				body = []byte(`nope`)
+				header.Set("X-Hub-Signature-256", webhooks.Sign([]byte("some-secret"), body))
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(HaveOccurred())
+				Expect(verifyErr).ToNot(Equal(webhooks.ErrInvalidSignature))
+			})
+		})
+	})
+
+	Context("with the gitlab provider", func() {
+		BeforeEach(func() {
+			config = atc.ResourceWebhook{Provider: atc.WebhookProviderGitLab}
+			header.Set("X-Gitlab-Event", "Merge Request Hook")
+			body = []byte(`{"object_attributes": {"target_branch": "main"}}`)
+		})
+
+		Context("when the token matches", func() {
+			BeforeEach(func() {
+				header.Set("X-Gitlab-Token", "some-secret")
+			})
+
+			It("describes the merge request", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(event).To(Equal(webhooks.Event{
+					Name:     "merge_request",
+					Branches: []string{"main"},
+				}))
+			})
+		})
+
+		Context("when the token does not match", func() {
+			BeforeEach(func() {
+				header.Set("X-Gitlab-Token", "other-secret")
+			})
+
+			It("fails", func() {
+				Expect(verifyErr).To(Equal(webhooks.ErrInvalidSignature))
+			})
+		})
+	})
+
+	Context("with the bitbucket provider", func() {
+		BeforeEach(func() {
+			config = atc.ResourceWebhook{Provider: atc.WebhookProviderBitbucket}
+		})
+
+		Context("for a cloud push", func() {
+			BeforeEach(func() {
+				header.Set("X-Event-Key", "repo:push")
+				body = []byte(`{"push": {"changes": [
+					{"new": {"type": "branch", "name": "main"}},
+					{"new": {"type": "tag", "name": "v1.0.0"}},
+					{"new": null}
+				]}}`)
+				header.Set("X-Hub-Signature", webhooks.Sign([]byte("some-secret"), body))
+			})
+
+			It("describes the push", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(event).To(Equal(webhooks.Event{
+					Name:     "repo:push",
+					Branches: []string{"main"},
+				}))
+			})
+		})
+
+		Context("for a server push", func() {
+			BeforeEach(func() {
+				header.Set("X-Event-Key", "repo:refs_changed")
+				body = []byte(`{"changes": [
+					{"ref": {"type": "BRANCH", "displayId": "main"}},
+					{"ref": {"type": "TAG", "displayId": "v1.0.0"}}
+				]}`)
+				header.Set("X-Hub-Signature", webhooks.Sign([]byte("some-secret"), body))
+			})
+
+			It("describes the push", func() {
+				Expect(verifyErr).ToNot(HaveOccurred())
+				Expect(event).To(Equal(webhooks.Event{
+					Name:     "repo:refs_changed",
+					Branches: []string{"main"},
+				}))
+			})
+		})
+	})
+})
+
+var _ = DescribeTable("Event.Matches",
+	func(event webhooks.Event, filter *atc.ResourceWebhookFilter, expected bool) {
+		matched, err := event.Matches(filter)
+		Expect(err).ToNot(HaveOccurred())
+		Expect(matched).To(Equal(expected))
+	},
+	Entry("without a filter",
+		webhooks.Event{Name: "push"}, nil, true),
+	Entry("with a matching event",
+		webhooks.Event{Name: "push"}, &atc.ResourceWebhookFilter{Events: []string{"ping", "push"}}, true),
+	Entry("with another event",
+		webhooks.Event{Name: "ping"}, &atc.ResourceWebhookFilter{Events: []string{"push"}}, false),
+	Entry("with a matching branch",
+		webhooks.Event{Branches: []string{"release/1.0"}}, &atc.ResourceWebhookFilter{Branches: []string{"main", "release/*"}}, true),
+	Entry("with another branch",
+		webhooks.Event{Branches: []string{"feature/foo"}}, &atc.ResourceWebhookFilter{Branches: []string{"main"}}, false),
+	Entry("with no branch",
+		webhooks.Event{}, &atc.ResourceWebhookFilter{Branches: []string{"main"}}, false),
+	Entry("with a matching path",
+		webhooks.Event{Paths: []string{"README.md", "src/pkg/main.go"}}, &atc.ResourceWebhookFilter{Paths: []string{"src/**"}}, true),
+	Entry("with other paths",
+		webhooks.Event{Paths: []string{"README.md"}}, &atc.ResourceWebhookFilter{Paths: []string{"src/**"}}, false),
+	Entry("with unknown paths",
+		webhooks.Event{Paths: nil}, &atc.ResourceWebhookFilter{Paths: []string{"src/**"}}, true),
+)
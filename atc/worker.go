@@ -20,6 +20,8 @@ type Worker struct {
 	ActiveVolumes    int `json:"active_volumes"`
 	ActiveTasks      int `json:"active_tasks"`
 
+	Resources *WorkerResources `json:"resources,omitempty"`
+
 	ResourceTypes []WorkerResourceType `json:"resource_types"`
 
 	Platform  string   `json:"platform"`
@@ -30,8 +32,27 @@ type Worker struct {
 	StartTime int64    `json:"start_time"`
 	Ephemeral bool     `json:"ephemeral"`
 	State     string   `json:"state"`
+
+	// CPUs is the number of CPU cores of the worker, by which its CPU load is
+	// normalized.
+	CPUs int `json:"cpus,omitempty"`
+
+	// Features are the optional capabilities of the worker's runtime, which
+	// steps relying on them are only placed on workers having.
+	Features []string `json:"features,omitempty"`
 }
 
+const (
+	// WorkerFeatureNetworkRules is the feature of runtimes enforcing the
+	// network config of tasks, i.e. their port mappings and egress rules.
+	WorkerFeatureNetworkRules = "network-rules"
+
+	// WorkerFeatureJoinNetwork is the feature of runtimes able to run a
+	// container in the network of another one, as needed for the services
+	// of tasks.
+	WorkerFeatureJoinNetwork = "join-network"
+)
+
 var ErrInvalidWorkerVersion = errors.New("invalid worker version, only numeric characters are allowed")
 var ErrMissingWorkerGardenAddress = errors.New("missing garden address")
 var ErrNoWorkers = errors.New("no workers available for checking")
@@ -56,6 +77,24 @@ type WorkerResourceType struct {
 	UniqueVersionHistory bool   `json:"unique_version_history"`
 }
 
+// WorkerResources describes the resources available on a worker as of its
+// last heartbeat. It is only reported by runtimes which support container
+// metrics.
+type WorkerResources struct {
+	// Memory not in use by any container, in bytes.
+	FreeMemory uint64 `json:"free_memory"`
+
+	// Disk space not in use by any container, in bytes.
+	FreeDiskSpace uint64 `json:"free_disk_space"`
+
+	// Number of CPU cores of the worker.
+	CPUs int `json:"cpus"`
+
+	// Fraction of the worker's CPU cores kept busy by containers since the
+	// previous heartbeat, between 0 and 1.
+	CPULoad float64 `json:"cpu_load"`
+}
+
 type PruneWorkerResponseBody struct {
 	Stderr string `json:"stderr"`
 }
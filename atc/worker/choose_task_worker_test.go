@@ -3,11 +3,13 @@ package worker_test
 import (
 	"bytes"
 	"context"
+	"sync/atomic"
 	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/atc/metric"
 
+	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
 
 	"github.com/concourse/concourse/atc"
@@ -184,6 +186,103 @@ var _ = Describe("RunTaskStep", func() {
 				Expect(output).To(ContainSubstring("Found a free worker after waiting"))
 			})
 		})
+
+		Context("a task with a higher priority is waiting for a worker", func() {
+			var (
+				workerFreed int32
+
+				highPriorityWorkerSpec worker.WorkerSpec
+
+				runTask func(worker.WorkerSpec) <-chan error
+			)
+
+			BeforeEach(func() {
+				workerFreed = 0
+
+				highPriorityWorkerSpec = workerSpecDummy()
+				highPriorityWorkerSpec.Priority = 10
+
+				fakePool.CompatibleWorkersReturns([]worker.Worker{fakeWorker}, nil)
+
+				fakePool.FindOrChooseWorkerForContainerStub = func(
+					_ context.Context,
+					_ lager.Logger,
+					_ db.ContainerOwner,
+					_ worker.ContainerSpec,
+					workerSpec worker.WorkerSpec,
+					_ worker.ContainerPlacementStrategy,
+				) (worker.Worker, error) {
+					if workerSpec.Priority > 0 && atomic.LoadInt32(&workerFreed) == 0 {
+						return nil, nil
+					}
+
+					return fakeWorker, nil
+				}
+
+				runTask = func(workerSpec worker.WorkerSpec) <-chan error {
+					done := make(chan error, 1)
+					go func() {
+						defer GinkgoRecover()
+
+						_, err := subject.RunTaskStep(ctx,
+							logger,
+							fakeContainerOwner,
+							fakeContainerSpec,
+							workerSpec,
+							fakeStrategy,
+							fakeMetadata,
+							fakeTaskProcessSpec,
+							fakeEventDelegate,
+							fakeLockFactory)
+						done <- err
+					}()
+					return done
+				}
+			})
+
+			It("places the task with the higher priority first", func() {
+				highPriorityDone := runTask(highPriorityWorkerSpec)
+				Eventually(fakePool.FindOrChooseWorkerForContainerCallCount).ShouldNot(BeZero())
+
+				lowPriorityDone := runTask(fakeWorkerSpec)
+				Consistently(lowPriorityDone, time.Second).ShouldNot(Receive())
+
+				atomic.StoreInt32(&workerFreed, 1)
+
+				Eventually(highPriorityDone, 2*time.Second).Should(Receive(BeNil()))
+				Eventually(lowPriorityDone, 2*time.Second).Should(Receive(BeNil()))
+			})
+
+			Context("when it waits for a disjoint pool of workers", func() {
+				BeforeEach(func() {
+					highPriorityWorkerSpec.Tags = []string{"gpu"}
+
+					gpuWorker := fakeWorkerStub()
+					gpuWorker.NameReturns("gpu-worker")
+
+					fakePool.CompatibleWorkersStub = func(_ lager.Logger, workerSpec worker.WorkerSpec) ([]worker.Worker, error) {
+						if len(workerSpec.Tags) == 1 && workerSpec.Tags[0] == "gpu" {
+							return []worker.Worker{gpuWorker}, nil
+						}
+
+						return []worker.Worker{fakeWorker}, nil
+					}
+				})
+
+				It("does not hold up the task with the lower priority", func() {
+					highPriorityDone := runTask(highPriorityWorkerSpec)
+					Eventually(fakePool.FindOrChooseWorkerForContainerCallCount).ShouldNot(BeZero())
+
+					lowPriorityDone := runTask(fakeWorkerSpec)
+					Eventually(lowPriorityDone, 2*time.Second).Should(Receive(BeNil()))
+					Consistently(highPriorityDone).ShouldNot(Receive())
+
+					atomic.StoreInt32(&workerFreed, 1)
+
+					Eventually(highPriorityDone, 2*time.Second).Should(Receive(BeNil()))
+				})
+			})
+		})
 	})
 })
 
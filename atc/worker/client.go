@@ -20,6 +20,7 @@ import (
 	"github.com/concourse/concourse/atc/metric"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/tracing"
 	"github.com/hashicorp/go-multierror"
 )
 
@@ -110,6 +111,7 @@ func NewClient(pool Pool,
 		workerStatusPublishInterval: workerStatusPublishInterval,
 		enabledP2pStreaming:         enabledP2pStreaming,
 		p2pStreamingTimeout:         p2pStreamingTimeout,
+		waitingTasks:                newWaitingTasks(),
 	}
 }
 
@@ -121,11 +123,13 @@ type client struct {
 	workerStatusPublishInterval time.Duration
 	enabledP2pStreaming         bool
 	p2pStreamingTimeout         time.Duration
+	waitingTasks                *waitingTasks
 }
 
 type TaskResult struct {
-	ExitStatus   int
-	VolumeMounts []VolumeMount
+	ExitStatus    int
+	VolumeMounts  []VolumeMount
+	ResourceUsage *ResourceUsage
 }
 
 type CheckResult struct {
@@ -135,12 +139,14 @@ type CheckResult struct {
 type PutResult struct {
 	ExitStatus    int
 	VersionResult runtime.VersionResult
+	ResourceUsage *ResourceUsage
 }
 
 type GetResult struct {
 	ExitStatus    int
 	VersionResult runtime.VersionResult
 	GetArtifact   runtime.GetArtifact
+	ResourceUsage *ResourceUsage
 }
 
 type processStatus struct {
@@ -224,6 +230,7 @@ func (client *client) RunCheckStep(
 	}
 
 	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	tracing.SetAttrs(ctx, tracing.Attrs{"worker": chosenWorker.Name()})
 
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
@@ -292,6 +299,7 @@ func (client *client) RunTaskStep(
 	}
 
 	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	tracing.SetAttrs(ctx, tracing.Attrs{"worker": chosenWorker.Name()})
 
 	if strategy.ModifiesActiveTasks() {
 		defer decreaseActiveTasks(logger.Session("decrease-active-tasks"), chosenWorker)
@@ -334,7 +342,23 @@ func (client *client) RunTaskStep(
 	process, err := container.Attach(context.Background(), taskProcessID, processIO)
 	if err == nil {
 		logger.Info("already-running")
+
+		var services runningServices
+		services, err = attachServices(ctx, logger, chosenWorker, container, containerSpec.Services)
+		if err != nil {
+			return TaskResult{}, err
+		}
+
+		defer services.stop(logger)
 	} else {
+		var services runningServices
+		services, err = startServices(ctx, logger, chosenWorker, container, containerSpec.Services)
+		if err != nil {
+			return TaskResult{}, err
+		}
+
+		defer services.stop(logger)
+
 		eventDelegate.Starting(logger)
 		logger.Info("spawning")
 
@@ -364,6 +388,8 @@ func (client *client) RunTaskStep(
 
 	logger.Info("attached")
 
+	sampler := sampleResourceUsage(logger, container, containerSpec.Outputs.paths(), resourceUsageSampleInterval)
+
 	exitStatusChan := make(chan processStatus)
 
 	go func() {
@@ -381,11 +407,14 @@ func (client *client) RunTaskStep(
 
 		status := <-exitStatusChan
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: sampler.Stop(),
 		}, ctx.Err()
 
 	case status := <-exitStatusChan:
+		usage := sampler.Stop()
+
 		if status.processErr != nil {
 			return TaskResult{
 				ExitStatus: status.processStatus,
@@ -399,8 +428,9 @@ func (client *client) RunTaskStep(
 			}, err
 		}
 		return TaskResult{
-			ExitStatus:   status.processStatus,
-			VolumeMounts: container.VolumeMounts(),
+			ExitStatus:    status.processStatus,
+			VolumeMounts:  container.VolumeMounts(),
+			ResourceUsage: usage,
 		}, err
 	}
 }
@@ -438,6 +468,7 @@ func (client *client) RunGetStep(
 	}
 
 	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	tracing.SetAttrs(ctx, tracing.Attrs{"worker": chosenWorker.Name()})
 
 	sign, err := resource.Signature()
 	if err != nil {
@@ -502,6 +533,7 @@ func (client *client) RunPutStep(
 	}
 
 	eventDelegate.SelectedWorker(logger, chosenWorker.Name())
+	tracing.SetAttrs(ctx, tracing.Attrs{"worker": chosenWorker.Name()})
 
 	container, err := chosenWorker.FindOrCreateContainer(
 		ctx,
@@ -532,12 +564,18 @@ func (client *client) RunPutStep(
 
 	eventDelegate.Starting(logger)
 
+	sampler := sampleResourceUsage(logger, container, nil, resourceUsageSampleInterval)
+
 	vr, err = resource.Put(ctx, spec, container)
+
+	usage := sampler.Stop()
+
 	if err != nil {
 		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
 			return PutResult{
 				ExitStatus:    failErr.ExitStatus,
 				VersionResult: runtime.VersionResult{},
+				ResourceUsage: usage,
 			}, nil
 		} else {
 			return PutResult{}, err
@@ -546,6 +584,7 @@ func (client *client) RunPutStep(
 	return PutResult{
 		ExitStatus:    0,
 		VersionResult: vr,
+		ResourceUsage: usage,
 	}, nil
 }
 
@@ -601,70 +640,94 @@ func (client *client) chooseTaskWorker(
 		Platform:   workerSpec.Platform,
 	}
 
+	var waitingTask *waitingTask
+	if strategy.ModifiesActiveTasks() {
+		waitingTask = client.waitingTasks.add(workerSpec.Priority)
+		defer client.waitingTasks.remove(waitingTask)
+	}
+
 	for {
 		if strategy.ModifiesActiveTasks() {
-			if activeTasksLock, lockAcquired, err = lockFactory.Acquire(logger, lock.NewActiveTasksLockID()); err != nil {
-				return nil, err
-			}
-
-			if !lockAcquired {
-				time.Sleep(time.Second)
-				continue
-			}
+			// errors are left to be surfaced when choosing a worker, in the
+			// meantime the task doesn't compete for any
+			compatibleWorkers, _ := client.pool.CompatibleWorkers(logger, workerSpec)
+			client.waitingTasks.setCandidates(waitingTask, compatibleWorkers)
 		}
 
-		if chosenWorker, err = client.pool.FindOrChooseWorkerForContainer(
-			ctx,
-			logger,
-			owner,
-			containerSpec,
-			workerSpec,
-			strategy,
-		); err != nil {
-			if chosenWorker == nil && !strategy.ModifiesActiveTasks() {
-				// only the limit-active-tasks placement strategy waits for a
-				// worker to become available. All others should error out for
-				// now
-				return nil, err
+		if strategy.ModifiesActiveTasks() && client.waitingTasks.higherThan(waitingTask) {
+			// leave any worker that becomes available to the tasks with a
+			// higher priority waiting for one
+			select {
+			case <-ctx.Done():
+				logger.Info("aborted-waiting-worker")
+				return nil, ctx.Err()
+			default:
+			}
+		} else {
+			if strategy.ModifiesActiveTasks() {
+				if activeTasksLock, lockAcquired, err = lockFactory.Acquire(logger, lock.NewActiveTasksLockID()); err != nil {
+					return nil, err
+				}
+
+				if !lockAcquired {
+					time.Sleep(time.Second)
+					continue
+				}
 			}
-		}
-
-		if !strategy.ModifiesActiveTasks() {
-			return chosenWorker, nil
-		}
-
-		select {
-		case <-ctx.Done():
-			logger.Info("aborted-waiting-worker")
-			e := multierror.Append(err, activeTasksLock.Release(), ctx.Err())
-			return nil, e
-		default:
-		}
 
-		if chosenWorker != nil {
-			err = increaseActiveTasks(logger,
-				client.pool,
-				chosenWorker,
-				activeTasksLock,
+			if chosenWorker, err = client.pool.FindOrChooseWorkerForContainer(
+				ctx,
+				logger,
 				owner,
 				containerSpec,
-				workerSpec)
-
-			if elapsed > 0 {
-				message := fmt.Sprintf("Found a free worker after waiting %s.\n", elapsed.Round(1*time.Second))
-				writeOutputMessage(logger, outputWriter, message)
-				metric.TasksWaitingDuration{
-					Labels:   tasksWaitingLabels,
-					Duration: elapsed,
-				}.Emit(logger)
+				workerSpec,
+				strategy,
+			); err != nil {
+				if chosenWorker == nil && !strategy.ModifiesActiveTasks() {
+					// only the limit-active-tasks placement strategy waits for a
+					// worker to become available. All others should error out for
+					// now
+					return nil, err
+				}
 			}
 
-			return chosenWorker, err
-		}
+			if !strategy.ModifiesActiveTasks() {
+				return chosenWorker, nil
+			}
 
-		err := activeTasksLock.Release()
-		if err != nil {
-			return nil, err
+			select {
+			case <-ctx.Done():
+				logger.Info("aborted-waiting-worker")
+				e := multierror.Append(err, activeTasksLock.Release(), ctx.Err())
+				return nil, e
+			default:
+			}
+
+			if chosenWorker != nil {
+				err = increaseActiveTasks(logger,
+					client.pool,
+					chosenWorker,
+					activeTasksLock,
+					owner,
+					containerSpec,
+					workerSpec)
+
+				if elapsed > 0 {
+					message := fmt.Sprintf("Found a free worker after waiting %s.\n", elapsed.Round(1*time.Second))
+					writeOutputMessage(logger, outputWriter, message)
+					metric.TasksWaitingDuration{
+						Labels:   tasksWaitingLabels,
+						Duration: elapsed,
+					}.Emit(logger)
+				}
+
+				return chosenWorker, err
+			}
+
+			err := activeTasksLock.Release()
+			if err != nil {
+				return nil, err
+			}
 		}
 
 		// Increase task waiting only once
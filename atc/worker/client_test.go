@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"path"
+	"sync"
 	"time"
 
 	"code.cloudfoundry.org/garden"
@@ -990,6 +991,76 @@ var _ = Describe("Client", func() {
 					Expect(actualProcessIO.Stderr).To(Equal(stderrBuf))
 				})
 
+				Context("when the task has services", func() {
+					var (
+						serviceOwner         db.ContainerOwner
+						fakeServiceContainer *workerfakes.FakeContainer
+						fakeServiceProcess   *gardenfakes.FakeProcess
+					)
+
+					BeforeEach(func() {
+						serviceOwner = db.NewBuildStepContainerOwner(1234, "42/services/postgres", 123)
+
+						fakeContainerSpec.Services = []worker.ServiceSpec{
+							{
+								Name:  "postgres",
+								Owner: serviceOwner,
+								ProcessSpec: runtime.ProcessSpec{
+									Path: "docker-entrypoint.sh",
+									Args: []string{"postgres"},
+								},
+								Readiness: &worker.ReadinessSpec{
+									Path:     "pg_isready",
+									Interval: 10 * time.Millisecond,
+									Timeout:  time.Second,
+								},
+							},
+						}
+
+						fakeServiceProcess = new(gardenfakes.FakeProcess)
+
+						fakeServiceContainer = new(workerfakes.FakeContainer)
+						fakeServiceContainer.AttachReturns(fakeServiceProcess, nil)
+
+						fakeWorker.FindOrCreateContainerStub = func(_ context.Context, _ lager.Logger, owner db.ContainerOwner, _ db.ContainerMetadata, _ worker.ContainerSpec) (worker.Container, error) {
+							if owner == serviceOwner {
+								return fakeServiceContainer, nil
+							}
+
+							return fakeContainer, nil
+						}
+					})
+
+					It("attaches to the running service without probing it again", func() {
+						Expect(err).ToNot(HaveOccurred())
+						Expect(fakeServiceContainer.AttachCallCount()).To(Equal(1))
+						_, processID, _ := fakeServiceContainer.AttachArgsForCall(0)
+						Expect(processID).To(Equal("service"))
+						Expect(fakeServiceContainer.RunCallCount()).To(BeZero())
+					})
+
+					It("stops the service once the task exits", func() {
+						Expect(fakeServiceContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeServiceContainer.StopArgsForCall(0)).To(BeFalse())
+					})
+
+					Context("when finding the service's container fails", func() {
+						BeforeEach(func() {
+							fakeWorker.FindOrCreateContainerStub = func(_ context.Context, _ lager.Logger, owner db.ContainerOwner, _ db.ContainerMetadata, _ worker.ContainerSpec) (worker.Container, error) {
+								if owner == serviceOwner {
+									return nil, errors.New("nope")
+								}
+
+								return fakeContainer, nil
+							}
+						})
+
+						It("returns the error", func() {
+							Expect(err).To(MatchError("start service postgres: nope"))
+						})
+					})
+				})
+
 				Context("when the process is interrupted", func() {
 					var stopped chan struct{}
 					BeforeEach(func() {
@@ -1140,6 +1211,248 @@ var _ = Describe("Client", func() {
 					Expect(fakeEventDelegate.StartingCallCount()).Should((Equal(1)))
 				})
 
+				Context("when the task has services", func() {
+					var (
+						serviceOwner         db.ContainerOwner
+						fakeServiceContainer *workerfakes.FakeContainer
+						fakeServiceProcess   *gardenfakes.FakeProcess
+						fakeProbeProcess     *gardenfakes.FakeProcess
+						serviceStdout        *gbytes.Buffer
+						serviceStderr        *gbytes.Buffer
+
+						stopService func()
+					)
+
+					BeforeEach(func() {
+						serviceOwner = db.NewBuildStepContainerOwner(1234, "42/services/postgres", 123)
+						serviceStdout = gbytes.NewBuffer()
+						serviceStderr = gbytes.NewBuffer()
+
+						fakeContainerSpec.Services = []worker.ServiceSpec{
+							{
+								Name:     "postgres",
+								Owner:    serviceOwner,
+								Metadata: fakeMetadata,
+								ContainerSpec: worker.ContainerSpec{
+									TeamID: 123,
+									Env:    []string{"POSTGRES_PASSWORD=secret"},
+								},
+								ProcessSpec: runtime.ProcessSpec{
+									Path:         "docker-entrypoint.sh",
+									Args:         []string{"postgres"},
+									StdoutWriter: serviceStdout,
+									StderrWriter: serviceStderr,
+								},
+								Readiness: &worker.ReadinessSpec{
+									Path:     "pg_isready",
+									Interval: 10 * time.Millisecond,
+									Timeout:  time.Second,
+								},
+							},
+						}
+
+						fakeContainer.HandleReturns("task-handle")
+
+						serviceDone := make(chan struct{})
+						var once sync.Once
+						stopService = func() {
+							once.Do(func() { close(serviceDone) })
+						}
+
+						fakeServiceProcess = new(gardenfakes.FakeProcess)
+						fakeServiceProcess.WaitStub = func() (int, error) {
+							<-serviceDone
+							return 143, nil
+						}
+
+						fakeProbeProcess = new(gardenfakes.FakeProcess)
+						fakeProbeProcess.WaitReturns(0, nil)
+
+						fakeServiceContainer = new(workerfakes.FakeContainer)
+						fakeServiceContainer.AttachReturns(nil, errors.New("container not running"))
+						fakeServiceContainer.RunStub = func(_ context.Context, spec garden.ProcessSpec, _ garden.ProcessIO) (garden.Process, error) {
+							if spec.ID == "service" {
+								return fakeServiceProcess, nil
+							}
+
+							return fakeProbeProcess, nil
+						}
+						fakeServiceContainer.StopStub = func(bool) error {
+							stopService()
+							return nil
+						}
+
+						fakeWorker.FindOrCreateContainerStub = func(_ context.Context, _ lager.Logger, owner db.ContainerOwner, _ db.ContainerMetadata, _ worker.ContainerSpec) (worker.Container, error) {
+							if owner == serviceOwner {
+								return fakeServiceContainer, nil
+							}
+
+							return fakeContainer, nil
+						}
+
+						fakeContainer.RunStub = func(context.Context, garden.ProcessSpec, garden.ProcessIO) (garden.Process, error) {
+							defer GinkgoRecover()
+							Expect(fakeProbeProcess.WaitCallCount()).To(BeNumerically(">", 0))
+							return fakeProcess, nil
+						}
+					})
+
+					AfterEach(func() {
+						stopService()
+					})
+
+					It("creates the service's container in the network of the task's container", func() {
+						Expect(fakeWorker.FindOrCreateContainerCallCount()).To(Equal(2))
+
+						_, _, owner, metadata, spec := fakeWorker.FindOrCreateContainerArgsForCall(1)
+						Expect(owner).To(Equal(serviceOwner))
+						Expect(metadata).To(Equal(fakeMetadata))
+						Expect(spec.JoinNetwork).To(Equal("task-handle"))
+						Expect(spec.NetworkAlias).To(Equal("postgres"))
+						Expect(spec.Env).To(Equal([]string{"POSTGRES_PASSWORD=secret"}))
+					})
+
+					It("runs the service's process with its own output", func() {
+						_, spec, processIO := fakeServiceContainer.RunArgsForCall(0)
+						Expect(spec.ID).To(Equal("service"))
+						Expect(spec.Path).To(Equal("docker-entrypoint.sh"))
+						Expect(spec.Args).To(Equal([]string{"postgres"}))
+						Expect(processIO.Stdout).To(Equal(serviceStdout))
+						Expect(processIO.Stderr).To(Equal(serviceStderr))
+					})
+
+					It("probes the service before running the task", func() {
+						_, spec, _ := fakeServiceContainer.RunArgsForCall(1)
+						Expect(spec.Path).To(Equal("pg_isready"))
+
+						Expect(err).ToNot(HaveOccurred())
+						Expect(fakeContainer.RunCallCount()).To(Equal(1))
+					})
+
+					It("stops the service once the task exits", func() {
+						Expect(fakeServiceContainer.StopCallCount()).To(Equal(1))
+						Expect(fakeServiceContainer.StopArgsForCall(0)).To(BeFalse())
+					})
+
+					Context("when the service's ports are probed", func() {
+						BeforeEach(func() {
+							fakeContainerSpec.Services[0].Readiness = &worker.ReadinessSpec{
+								Ports:    []uint16{5432},
+								Interval: 10 * time.Millisecond,
+								Timeout:  time.Second,
+							}
+
+							fakeContainer.RunStub = func(_ context.Context, spec garden.ProcessSpec, _ garden.ProcessIO) (garden.Process, error) {
+								if spec.ID == "task" {
+									return fakeProcess, nil
+								}
+
+								return fakeProbeProcess, nil
+							}
+						})
+
+						It("probes them from the task's container", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(fakeServiceContainer.RunCallCount()).To(Equal(1))
+
+							Expect(fakeContainer.RunCallCount()).To(Equal(2))
+							_, spec, _ := fakeContainer.RunArgsForCall(0)
+							Expect(spec.Path).To(Equal("sh"))
+							Expect(spec.Args).To(HaveLen(2))
+							Expect(spec.Args[1]).To(ContainSubstring("for port in 1538;"))
+							Expect(spec.Args[1]).ToNot(ContainSubstring("grep"))
+
+							_, spec, _ = fakeContainer.RunArgsForCall(1)
+							Expect(spec.ID).To(Equal("task"))
+						})
+					})
+
+					Context("when a probe hangs", func() {
+						var fakeHungProbeProcess *gardenfakes.FakeProcess
+
+						BeforeEach(func() {
+							killed := make(chan struct{})
+
+							fakeHungProbeProcess = new(gardenfakes.FakeProcess)
+							fakeHungProbeProcess.WaitStub = func() (int, error) {
+								<-killed
+								return 137, nil
+							}
+							fakeHungProbeProcess.SignalStub = func(garden.Signal) error {
+								close(killed)
+								return nil
+							}
+
+							probes := 0
+							fakeServiceContainer.RunStub = func(_ context.Context, spec garden.ProcessSpec, _ garden.ProcessIO) (garden.Process, error) {
+								if spec.ID == "service" {
+									return fakeServiceProcess, nil
+								}
+
+								probes++
+								if probes == 1 {
+									return fakeHungProbeProcess, nil
+								}
+
+								return fakeProbeProcess, nil
+							}
+						})
+
+						It("kills it before probing again", func() {
+							Expect(err).ToNot(HaveOccurred())
+							Expect(fakeHungProbeProcess.SignalCallCount()).To(Equal(1))
+							Expect(fakeHungProbeProcess.SignalArgsForCall(0)).To(Equal(garden.SignalKill))
+							Expect(fakeServiceContainer.RunCallCount()).To(Equal(3))
+						})
+					})
+
+					Context("when the service exits before becoming ready", func() {
+						BeforeEach(func() {
+							fakeProbeProcess.WaitReturns(1, nil)
+							fakeServiceProcess.WaitStub = nil
+							fakeServiceProcess.WaitReturns(1, nil)
+						})
+
+						It("returns an error without running the task", func() {
+							Expect(err).To(Equal(worker.ServiceExitedError{Name: "postgres", ExitStatus: 1}))
+							Expect(fakeContainer.RunCallCount()).To(BeZero())
+						})
+					})
+
+					Context("when the service does not become ready in time", func() {
+						BeforeEach(func() {
+							fakeProbeProcess.WaitReturns(1, nil)
+							fakeContainerSpec.Services[0].Readiness.Timeout = 50 * time.Millisecond
+						})
+
+						It("returns an error without running the task", func() {
+							Expect(err).To(Equal(worker.ServiceNotReadyError{Name: "postgres", Timeout: 50 * time.Millisecond}))
+							Expect(fakeContainer.RunCallCount()).To(BeZero())
+						})
+
+						It("stops the service", func() {
+							Expect(fakeServiceContainer.StopCallCount()).To(Equal(1))
+						})
+					})
+
+					Context("when creating the service's container fails", func() {
+						BeforeEach(func() {
+							fakeWorker.FindOrCreateContainerStub = func(_ context.Context, _ lager.Logger, owner db.ContainerOwner, _ db.ContainerMetadata, _ worker.ContainerSpec) (worker.Container, error) {
+								if owner == serviceOwner {
+									return nil, errors.New("nope")
+								}
+
+								return fakeContainer, nil
+							}
+						})
+
+						It("returns the error without running the task", func() {
+							Expect(err).To(MatchError("start service postgres: nope"))
+							Expect(fakeContainer.RunCallCount()).To(BeZero())
+						})
+					})
+				})
+
 				Context("when the process is interrupted", func() {
 					var stopped chan struct{}
 					BeforeEach(func() {
@@ -1291,6 +1604,89 @@ var _ = Describe("Client", func() {
 							Expect(fakeWorker.ActiveTasks()).To(Equal(0))
 						})
 					})
+
+					Context("when the runtime reports metrics for the container", func() {
+						BeforeEach(func() {
+							fakeContainer.MetricsReturnsOnCall(0, garden.Metrics{
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 2048},
+								CPUStat:    garden.ContainerCPUStat{Usage: uint64(time.Second)},
+							}, nil)
+							fakeContainer.MetricsReturns(garden.Metrics{
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+								CPUStat:    garden.ContainerCPUStat{Usage: uint64(3 * time.Second)},
+								DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: 4096},
+							}, nil)
+						})
+
+						It("returns the resource usage of the container", func() {
+							Expect(taskResult.ResourceUsage).ToNot(BeNil())
+							Expect(taskResult.ResourceUsage.CPU).To(Equal(3 * time.Second))
+							Expect(taskResult.ResourceUsage.PeakMemory).To(Equal(uint64(2048)))
+						})
+
+						It("does not count the container's own filesystem as disk usage", func() {
+							Expect(taskResult.ResourceUsage.Disk).To(BeZero())
+						})
+
+						Context("when the task has outputs", func() {
+							BeforeEach(func() {
+								fakeContainerSpec.Outputs = worker.OutputPaths{
+									"some-output":  "some-artifact-root/some-output",
+									"other-output": "some-artifact-root/other-output",
+								}
+
+								fakeContainer.RunStub = func(ctx context.Context, spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+									if spec.Path == "du" {
+										fmt.Fprint(processIO.Stdout, "4\tsome-artifact-root/other-output\n8\tsome-artifact-root/some-output\n")
+									}
+
+									return fakeProcess, nil
+								}
+							})
+
+							It("measures the output volumes once the process has exited", func() {
+								Expect(fakeContainer.RunCallCount()).To(Equal(2))
+
+								_, spec, _ := fakeContainer.RunArgsForCall(1)
+								Expect(spec.Path).To(Equal("du"))
+								Expect(spec.Args).To(Equal([]string{"-s", "-k", "some-artifact-root/other-output", "some-artifact-root/some-output"}))
+
+								Expect(taskResult.ResourceUsage.Disk).To(Equal(uint64(12 * 1024)))
+							})
+
+							Context("when the outputs can't be measured", func() {
+								BeforeEach(func() {
+									fakeContainer.RunStub = func(ctx context.Context, spec garden.ProcessSpec, processIO garden.ProcessIO) (garden.Process, error) {
+										if spec.Path == "du" {
+											return nil, errors.New("executable not found")
+										}
+
+										return fakeProcess, nil
+									}
+								})
+
+								It("still returns the rest of the resource usage", func() {
+									Expect(taskResult.ResourceUsage).ToNot(BeNil())
+									Expect(taskResult.ResourceUsage.CPU).To(Equal(3 * time.Second))
+									Expect(taskResult.ResourceUsage.Disk).To(BeZero())
+								})
+							})
+						})
+					})
+
+					Context("when the runtime does not report metrics", func() {
+						BeforeEach(func() {
+							fakeContainer.MetricsReturns(garden.Metrics{}, errors.New("not implemented"))
+						})
+
+						It("returns no resource usage", func() {
+							Expect(taskResult.ResourceUsage).To(BeNil())
+						})
+
+						It("stops asking for metrics", func() {
+							Expect(fakeContainer.MetricsCallCount()).To(Equal(1))
+						})
+					})
 				})
 
 				Context("when the process exits on failure", func() {
@@ -1632,6 +2028,21 @@ var _ = Describe("Client", func() {
 					Expect(versionResult).To(Equal(expectedVersionResult))
 				})
 			})
+
+			Context("when the runtime reports metrics for the container", func() {
+				BeforeEach(func() {
+					fakeContainer.MetricsReturns(garden.Metrics{
+						MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+						CPUStat:    garden.ContainerCPUStat{Usage: uint64(time.Second)},
+					}, nil)
+				})
+
+				It("returns the resource usage of the container", func() {
+					Expect(result.ResourceUsage).ToNot(BeNil())
+					Expect(result.ResourceUsage.CPU).To(Equal(time.Second))
+					Expect(result.ResourceUsage.PeakMemory).To(Equal(uint64(1024)))
+				})
+			})
 		})
 
 		Context("worker.FindOrCreateContainer errored", func() {
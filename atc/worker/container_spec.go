@@ -2,9 +2,12 @@ package worker
 
 import (
 	"fmt"
+	"net"
+	"sort"
 	"strings"
 
 	"code.cloudfoundry.org/garden"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/runtime"
 )
@@ -14,6 +17,11 @@ type WorkerSpec struct {
 	ResourceType string
 	Tags         []string
 	TeamID       int
+	Priority     int
+
+	// Features of the runtime the worker must have, e.g. to enforce the
+	// network config of a task.
+	Features []string
 }
 
 type ContainerSpec struct {
@@ -45,6 +53,21 @@ type ContainerSpec struct {
 
 	// Optional user to run processes as. Overwrites the one specified in the docker image.
 	User string
+
+	// Network access rules to be set on the container when creating in garden.
+	Network *atc.TaskNetworkConfig
+
+	// Optional handle of a container whose network the container joins
+	// instead of getting one of its own.
+	JoinNetwork string
+
+	// Hostname the container can be reached at from the container whose
+	// network it joins.
+	NetworkAlias string
+
+	// Service containers started before the task's process runs, sharing
+	// the container's network. Only honored by RunTaskStep.
+	Services []ServiceSpec
 }
 
 // The below methods cause ContainerSpec to fulfill the
@@ -88,6 +111,17 @@ type BindMountSource interface {
 // OutputPaths is a mapping from output name to its path in the container.
 type OutputPaths map[string]string
 
+func (outputs OutputPaths) paths() []string {
+	paths := make([]string, 0, len(outputs))
+	for _, path := range outputs {
+		paths = append(paths, path)
+	}
+
+	sort.Strings(paths)
+
+	return paths
+}
+
 type ImageSpec struct {
 	ResourceType        string
 	ImageURL            string
@@ -131,6 +165,60 @@ func (cl ContainerLimits) ToGardenLimits() garden.Limits {
 	return gardenLimits
 }
 
+func toGardenNetOutRules(rules []atc.TaskNetworkRule) ([]garden.NetOutRule, error) {
+	var gardenRules []garden.NetOutRule
+
+	for _, rule := range rules {
+		gardenRule := garden.NetOutRule{}
+
+		switch rule.Protocol {
+		case "", atc.NetworkProtocolAll:
+			gardenRule.Protocol = garden.ProtocolAll
+		case atc.NetworkProtocolTCP:
+			gardenRule.Protocol = garden.ProtocolTCP
+		case atc.NetworkProtocolUDP:
+			gardenRule.Protocol = garden.ProtocolUDP
+		case atc.NetworkProtocolICMP:
+			gardenRule.Protocol = garden.ProtocolICMP
+		default:
+			return nil, fmt.Errorf("unknown protocol '%s'", rule.Protocol)
+		}
+
+		if rule.Network != "" {
+			_, ipNet, err := net.ParseCIDR(rule.Network)
+			if err != nil {
+				return nil, err
+			}
+
+			gardenRule.Networks = []garden.IPRange{garden.IPRangeFromIPNet(ipNet)}
+		}
+
+		for _, port := range rule.Ports {
+			gardenRule.Ports = append(gardenRule.Ports, garden.PortRange{
+				Start: port.Start,
+				End:   port.End,
+			})
+		}
+
+		gardenRules = append(gardenRules, gardenRule)
+	}
+
+	return gardenRules, nil
+}
+
+func toGardenNetIn(mappings []atc.TaskPortMapping) []garden.NetIn {
+	var netIn []garden.NetIn
+
+	for _, mapping := range mappings {
+		netIn = append(netIn, garden.NetIn{
+			HostPort:      uint32(mapping.HostPort),
+			ContainerPort: uint32(mapping.ContainerPort),
+		})
+	}
+
+	return netIn
+}
+
 func (spec WorkerSpec) Description() string {
 	var attrs []string
 
@@ -146,5 +234,9 @@ func (spec WorkerSpec) Description() string {
 		attrs = append(attrs, fmt.Sprintf("tag '%s'", tag))
 	}
 
+	for _, feature := range spec.Features {
+		attrs = append(attrs, fmt.Sprintf("feature '%s'", feature))
+	}
+
 	return strings.Join(attrs, ", ")
 }
@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/tracing"
 )
 
 //go:generate counterfeiter . FetchSource
@@ -137,9 +138,12 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 	}
 
 	if found {
+		tracing.SetAttrs(ctx, tracing.Attrs{"cache_hit": "true"})
 		return findResult, volume, nil
 	}
 
+	tracing.SetAttrs(ctx, tracing.Attrs{"cache_hit": "false"})
+
 	s.containerSpec.BindMounts = []BindMountSource{
 		&CertsVolumeMount{Logger: s.logger},
 	}
@@ -157,14 +161,20 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		return GetResult{}, nil, err
 	}
 
+	sampler := sampleResourceUsage(s.logger, container, s.containerSpec.Outputs.paths(), resourceUsageSampleInterval)
+
 	vr, err := s.resource.Get(ctx, s.processSpec, container)
+
+	usage := sampler.Stop()
+
 	if err != nil {
 		sLog.Error("failed-to-fetch-resource", err)
 		// TODO: Is this compatible with previous behaviour of returning a nil when error type is NOT ErrResourceScriptFailed
 
 		if failErr, ok := err.(runtime.ErrResourceScriptFailed); ok {
 			return GetResult{
-				ExitStatus: failErr.ExitStatus,
+				ExitStatus:    failErr.ExitStatus,
+				ResourceUsage: usage,
 			}, nil, nil
 		}
 		return GetResult{}, nil, err
@@ -196,6 +206,7 @@ func (s *fetchSource) Create(ctx context.Context) (GetResult, Volume, error) {
 		GetArtifact: runtime.GetArtifact{
 			VolumeHandle: volume.Handle(),
 		},
+		ResourceUsage: usage,
 	}, volume, nil
 }
 
@@ -3,6 +3,8 @@ package worker_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/garden/gardenfakes"
@@ -264,6 +266,64 @@ var _ = Describe("FetchSource", func() {
 				Expect(getResult.GetArtifact.VolumeHandle).To(Equal(fakeVolume.Handle()))
 				Expect(volume).ToNot(BeNil())
 			})
+
+			Context("when the runtime reports metrics for the container", func() {
+				BeforeEach(func() {
+					fakeContainer.MetricsReturns(garden.Metrics{
+						MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 1024},
+						CPUStat:    garden.ContainerCPUStat{Usage: uint64(time.Second)},
+						DiskStat:   garden.ContainerDiskStat{TotalBytesUsed: 4096},
+					}, nil)
+
+					getRun := fakeContainer.RunStub
+					fakeContainer.RunStub = func(ctx context.Context, spec garden.ProcessSpec, io garden.ProcessIO) (garden.Process, error) {
+						if spec.Path != "du" {
+							return getRun(ctx, spec, io)
+						}
+
+						_, err := fmt.Fprintf(io.Stdout, "16\t%s\n", resource.ResourcesDir("get"))
+						Expect(err).NotTo(HaveOccurred())
+
+						return new(gardenfakes.FakeProcess), nil
+					}
+				})
+
+				It("returns the resource usage of the container", func() {
+					Expect(getResult.ResourceUsage).ToNot(BeNil())
+					Expect(getResult.ResourceUsage.CPU).To(Equal(time.Second))
+					Expect(getResult.ResourceUsage.PeakMemory).To(Equal(uint64(1024)))
+				})
+
+				It("measures the volume the resource was fetched into", func() {
+					Expect(fakeContainer.RunCallCount()).To(Equal(1))
+
+					_, spec, _ := fakeContainer.RunArgsForCall(0)
+					Expect(spec.Args).To(Equal([]string{"-s", "-k", resource.ResourcesDir("get")}))
+
+					Expect(getResult.ResourceUsage.Disk).To(Equal(uint64(16 * 1024)))
+				})
+
+				Context("when the get script fails", func() {
+					BeforeEach(func() {
+						fakeResource.GetReturns(runtime.VersionResult{}, runtime.ErrResourceScriptFailed{ExitStatus: 1})
+					})
+
+					It("still returns the resource usage", func() {
+						Expect(getResult.ExitStatus).To(Equal(1))
+						Expect(getResult.ResourceUsage).ToNot(BeNil())
+					})
+				})
+			})
+
+			Context("when the runtime does not report metrics", func() {
+				BeforeEach(func() {
+					fakeContainer.MetricsReturns(garden.Metrics{}, errors.New("not implemented"))
+				})
+
+				It("returns no resource usage", func() {
+					Expect(getResult.ResourceUsage).To(BeNil())
+				})
+			})
 		})
 	})
 })
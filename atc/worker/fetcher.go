@@ -11,6 +11,7 @@ import (
 	"github.com/concourse/concourse/atc/db/lock"
 	"github.com/concourse/concourse/atc/resource"
 	"github.com/concourse/concourse/atc/runtime"
+	"github.com/concourse/concourse/tracing"
 )
 
 const GetResourceLockInterval = 5 * time.Second
@@ -137,6 +138,7 @@ func (f *fetcher) fetchUnderLock(
 	}
 
 	if found {
+		tracing.SetAttrs(ctx, tracing.Attrs{"cache_hit": "true"})
 		return findResult, volume, nil
 	}
 
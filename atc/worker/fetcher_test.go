@@ -18,8 +18,12 @@ import (
 	"github.com/concourse/concourse/atc/db/lock/lockfakes"
 	"github.com/concourse/concourse/atc/resource/resourcefakes"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
+	"github.com/concourse/concourse/tracing"
 	. "github.com/onsi/ginkgo"
 	. "github.com/onsi/gomega"
+	"go.opentelemetry.io/otel/api/trace"
+	"go.opentelemetry.io/otel/api/trace/tracetest"
+	"go.opentelemetry.io/otel/label"
 )
 
 var _ = Describe("Fetcher", func() {
@@ -171,6 +175,34 @@ var _ = Describe("Fetcher", func() {
 			})
 		})
 
+		Context("when the source is found", func() {
+			BeforeEach(func() {
+				fakeFetchSource.FindReturns(worker.GetResult{}, fakeVolume, true, nil)
+			})
+
+			It("does not create source", func() {
+				Expect(fakeFetchSource.CreateCallCount()).To(BeZero())
+			})
+
+			Context("when tracing is configured", func() {
+				var span trace.Span
+
+				BeforeEach(func() {
+					tracing.ConfigureTraceProvider(tracetest.NewProvider())
+					ctx, span = tracing.StartSpan(ctx, "get", nil)
+				})
+
+				AfterEach(func() {
+					tracing.Configured = false
+				})
+
+				It("marks the span as a cache hit", func() {
+					attrs := span.(*tracetest.Span).Attributes()
+					Expect(attrs).To(HaveKeyWithValue(label.Key("cache_hit"), label.StringValue("true")))
+				})
+			})
+		})
+
 		Context("when finding fails", func() {
 			var disaster error
 
@@ -3,19 +3,23 @@ package worker
 import (
 	"errors"
 	"fmt"
+	"math"
 	"math/rand"
 	"strings"
 	"time"
 
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 )
 
 type ContainerPlacementStrategyOptions struct {
-	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
+	ContainerPlacementStrategy   []string `long:"container-placement-strategy" default:"volume-locality" choice:"volume-locality" choice:"random" choice:"fewest-build-containers" choice:"limit-active-tasks" choice:"limit-active-containers" choice:"limit-active-volumes" choice:"available-resources" description:"Method by which a worker is selected during container placement. If multiple methods are specified, they will be applied in order. Random strategy should only be used alone."`
 	MaxActiveTasksPerWorker      int      `long:"max-active-tasks-per-worker" default:"0" description:"Maximum allowed number of active build tasks per worker. Has effect only when used with limit-active-tasks placement strategy. 0 means no limit."`
 	MaxActiveContainersPerWorker int      `long:"max-active-containers-per-worker" default:"0" description:"Maximum allowed number of active containers per worker. Has effect only when used with limit-active-containers placement strategy. 0 means no limit."`
 	MaxActiveVolumesPerWorker    int      `long:"max-active-volumes-per-worker" default:"0" description:"Maximum allowed number of active volumes per worker. Has effect only when used with limit-active-volumes placement strategy. 0 means no limit."`
+	MaxCPULoadPerWorker          float64  `long:"max-cpu-load-per-worker" default:"0" description:"Maximum allowed CPU load per worker, as the fraction of its CPU cores which are busy (e.g. 0.8). Has effect only when used with available-resources placement strategy. 0 means no limit."`
+	MinFreeDiskSpacePerWorker    uint64   `long:"min-free-disk-space-per-worker" default:"0" description:"Minimum amount of free disk space, in bytes, a worker must have to be chosen. Has effect only when used with available-resources placement strategy. 0 means no limit."`
 }
 
 type NoWorkerFitContainerPlacementStrategyError struct {
@@ -67,6 +71,11 @@ func NewContainerPlacementStrategy(opts ContainerPlacementStrategyOptions) (*con
 				return nil, errors.New("max-active-volumes-per-worker must be greater or equal than 0")
 			}
 			cps.nodes = append(cps.nodes, newLimitActiveVolumesPlacementStrategy(strategy, opts.MaxActiveVolumesPerWorker))
+		case "available-resources":
+			if opts.MaxCPULoadPerWorker < 0 || opts.MaxCPULoadPerWorker > 1 {
+				return nil, errors.New("max-cpu-load-per-worker must be between 0 and 1")
+			}
+			cps.nodes = append(cps.nodes, newAvailableResourcesPlacementStrategy(strategy, opts.MaxCPULoadPerWorker, opts.MinFreeDiskSpacePerWorker))
 		case "volume-locality":
 			cps.nodes = append(cps.nodes, newVolumeLocalityPlacementStrategyNode(strategy))
 		default:
@@ -293,3 +302,132 @@ func (strategy *LimitActiveVolumesPlacementStrategyNode) ModifiesActiveTasks() b
 func (strategy *LimitActiveVolumesPlacementStrategyNode) StrategyName() string {
 	return strategy.GivenName
 }
+
+type AvailableResourcesPlacementStrategyNode struct {
+	GivenName        string
+	maxCPULoad       float64
+	minFreeDiskSpace uint64
+}
+
+func newAvailableResourcesPlacementStrategy(name string, maxCPULoad float64, minFreeDiskSpace uint64) ContainerPlacementStrategyChainNode {
+	return &AvailableResourcesPlacementStrategyNode{
+		GivenName:        name,
+		maxCPULoad:       maxCPULoad,
+		minFreeDiskSpace: minFreeDiskSpace,
+	}
+}
+
+// cpuSharesPerCore is the number of CPU shares conventionally corresponding
+// to a single core, used to tell how many cores a container's CPU limit asks
+// for.
+const cpuSharesPerCore = 1024
+
+// Choose filters out workers which cannot satisfy the container's memory
+// limit or which exceed the configured CPU load and disk space thresholds,
+// and then picks the workers with the most headroom, weighing free memory,
+// free disk space and idle CPU cores equally. Idle cores are counted after
+// taking out the cores asked for by the container's CPU limit, if any.
+//
+// Workers which do not report their resources are only considered when none
+// of the reporting workers fit.
+func (strategy *AvailableResourcesPlacementStrategyNode) Choose(logger lager.Logger, workers []Worker, spec ContainerSpec) ([]Worker, error) {
+	var requiredMemory uint64
+	if spec.Limits.Memory != nil {
+		requiredMemory = *spec.Limits.Memory
+	}
+
+	var requiredCPUs float64
+	if spec.Limits.CPU != nil {
+		requiredCPUs = float64(*spec.Limits.CPU) / cpuSharesPerCore
+	}
+
+	var (
+		candidates  []Worker
+		unreported  []Worker
+		maxFreeMem  uint64
+		maxFreeDisk uint64
+		maxIdleCPUs float64
+	)
+
+	for _, w := range workers {
+		resources := w.Resources()
+		if resources == nil {
+			unreported = append(unreported, w)
+			continue
+		}
+
+		if resources.FreeMemory < requiredMemory {
+			logger.Debug("worker-insufficient-memory", lager.Data{"worker": w.Name(), "free-memory": resources.FreeMemory})
+			continue
+		}
+
+		if resources.FreeDiskSpace < strategy.minFreeDiskSpace {
+			logger.Debug("worker-insufficient-disk-space", lager.Data{"worker": w.Name(), "free-disk-space": resources.FreeDiskSpace})
+			continue
+		}
+
+		if strategy.maxCPULoad > 0 && resources.CPULoad >= strategy.maxCPULoad {
+			logger.Debug("worker-busy", lager.Data{"worker": w.Name(), "cpu-load": resources.CPULoad})
+			continue
+		}
+
+		candidates = append(candidates, w)
+
+		if resources.FreeMemory > maxFreeMem {
+			maxFreeMem = resources.FreeMemory
+		}
+
+		if resources.FreeDiskSpace > maxFreeDisk {
+			maxFreeDisk = resources.FreeDiskSpace
+		}
+
+		if idle := idleCPUs(resources, requiredCPUs); idle > maxIdleCPUs {
+			maxIdleCPUs = idle
+		}
+	}
+
+	if len(candidates) == 0 {
+		return unreported, nil
+	}
+
+	workersByScore := map[float64][]Worker{}
+	var highestScore float64
+
+	for i, w := range candidates {
+		resources := w.Resources()
+
+		var score float64
+		if maxFreeMem > 0 {
+			score += float64(resources.FreeMemory) / float64(maxFreeMem)
+		}
+
+		if maxFreeDisk > 0 {
+			score += float64(resources.FreeDiskSpace) / float64(maxFreeDisk)
+		}
+
+		if maxIdleCPUs > 0 {
+			score += idleCPUs(resources, requiredCPUs) / maxIdleCPUs
+		}
+
+		workersByScore[score] = append(workersByScore[score], w)
+		if i == 0 || score > highestScore {
+			highestScore = score
+		}
+	}
+
+	return workersByScore[highestScore], nil
+}
+
+// idleCPUs returns the number of the worker's CPU cores which would still be
+// idle after placing a container asking for the given number of cores.
+func idleCPUs(resources *atc.WorkerResources, requiredCPUs float64) float64 {
+	return math.Max(0, float64(resources.CPUs)*(1-resources.CPULoad)-requiredCPUs)
+}
+
+func (strategy *AvailableResourcesPlacementStrategyNode) ModifiesActiveTasks() bool {
+	return false
+}
+
+func (strategy *AvailableResourcesPlacementStrategyNode) StrategyName() string {
+	return strategy.GivenName
+}
@@ -5,6 +5,7 @@ import (
 
 	"code.cloudfoundry.org/lager"
 	"code.cloudfoundry.org/lager/lagertest"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	. "github.com/concourse/concourse/atc/worker"
 	"github.com/concourse/concourse/atc/worker/workerfakes"
@@ -713,6 +714,199 @@ var _ = Describe("LimitActiveVolumesPlacementStrategyNode", func() {
 	})
 })
 
+var _ = Describe("AvailableResourcesPlacementStrategyNode", func() {
+	Describe("Choose", func() {
+		var compatibleWorker1 *workerfakes.FakeWorker
+		var compatibleWorker2 *workerfakes.FakeWorker
+		var compatibleWorker3 *workerfakes.FakeWorker
+		var maxCPULoad float64
+		var minFreeDiskSpace uint64
+
+		BeforeEach(func() {
+			logger = lagertest.NewTestLogger("available-resources-placement-test")
+			compatibleWorker1 = new(workerfakes.FakeWorker)
+			compatibleWorker1.NameReturns("compatibleWorker1")
+			compatibleWorker2 = new(workerfakes.FakeWorker)
+			compatibleWorker2.NameReturns("compatibleWorker2")
+			compatibleWorker3 = new(workerfakes.FakeWorker)
+			compatibleWorker3.NameReturns("compatibleWorker3")
+			maxCPULoad = 0
+			minFreeDiskSpace = 0
+
+			compatibleWorker1.ResourcesReturns(&atc.WorkerResources{
+				FreeMemory:    8 * 1024 * 1024 * 1024,
+				FreeDiskSpace: 100 * 1024 * 1024 * 1024,
+				CPUs:          8,
+				CPULoad:       0.125,
+			})
+			compatibleWorker2.ResourcesReturns(&atc.WorkerResources{
+				FreeMemory:    1024 * 1024 * 1024,
+				FreeDiskSpace: 100 * 1024 * 1024 * 1024,
+				CPUs:          8,
+				CPULoad:       0.125,
+			})
+			compatibleWorker3.ResourcesReturns(&atc.WorkerResources{
+				FreeMemory:    8 * 1024 * 1024 * 1024,
+				FreeDiskSpace: 10 * 1024 * 1024 * 1024,
+				CPUs:          8,
+				CPULoad:       0.75,
+			})
+			workers = []Worker{compatibleWorker1, compatibleWorker2, compatibleWorker3}
+
+			spec = ContainerSpec{
+				ImageSpec: ImageSpec{ResourceType: "some-type"},
+				TeamID:    4567,
+				Inputs:    []InputSource{},
+			}
+		})
+
+		JustBeforeEach(func() {
+			strategy, newStrategyError = NewContainerPlacementStrategy(ContainerPlacementStrategyOptions{
+				ContainerPlacementStrategy: []string{"available-resources"},
+				MaxCPULoadPerWorker:        maxCPULoad,
+				MinFreeDiskSpacePerWorker:  minFreeDiskSpace,
+			})
+			Expect(newStrategyError).ToNot(HaveOccurred())
+
+			chosenWorker, chooseErr = strategy.Choose(
+				logger,
+				workers,
+				spec,
+			)
+		})
+
+		It("picks the worker with the most available resources", func() {
+			Expect(chooseErr).ToNot(HaveOccurred())
+			Expect(chosenWorker).To(Equal(compatibleWorker1))
+		})
+
+		Context("when MaxCPULoadPerWorker is less than 0", func() {
+			It("returns an error", func() {
+				_, newStrategyError = NewContainerPlacementStrategy(ContainerPlacementStrategyOptions{
+					ContainerPlacementStrategy: []string{"available-resources"},
+					MaxCPULoadPerWorker:        -1,
+				})
+				Expect(newStrategyError).To(HaveOccurred())
+			})
+		})
+
+		Context("when MaxCPULoadPerWorker is greater than 1", func() {
+			It("returns an error", func() {
+				_, newStrategyError = NewContainerPlacementStrategy(ContainerPlacementStrategyOptions{
+					ContainerPlacementStrategy: []string{"available-resources"},
+					MaxCPULoadPerWorker:        4,
+				})
+				Expect(newStrategyError).To(HaveOccurred())
+			})
+		})
+
+		Context("when workers have different numbers of idle CPU cores", func() {
+			BeforeEach(func() {
+				compatibleWorker1.ResourcesReturns(&atc.WorkerResources{
+					FreeMemory:    8 * 1024 * 1024 * 1024,
+					FreeDiskSpace: 100 * 1024 * 1024 * 1024,
+					CPUs:          4,
+					CPULoad:       0.375,
+				})
+				compatibleWorker2.ResourcesReturns(&atc.WorkerResources{
+					FreeMemory:    4 * 1024 * 1024 * 1024,
+					FreeDiskSpace: 100 * 1024 * 1024 * 1024,
+					CPUs:          4,
+					CPULoad:       0,
+				})
+				workers = []Worker{compatibleWorker1, compatibleWorker2}
+			})
+
+			It("weighs the idle cores against the other resources", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker1))
+			})
+
+			Context("when the container has a cpu limit", func() {
+				BeforeEach(func() {
+					cpu := uint64(2048)
+					spec.Limits = ContainerLimits{CPU: &cpu}
+				})
+
+				It("takes the cores it asks for out of the idle cores", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker2))
+				})
+			})
+		})
+
+		Context("when the container has a memory limit", func() {
+			BeforeEach(func() {
+				memory := uint64(2 * 1024 * 1024 * 1024)
+				spec.Limits = ContainerLimits{Memory: &memory}
+				workers = []Worker{compatibleWorker2, compatibleWorker3}
+			})
+
+			It("skips workers without enough free memory", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker3))
+			})
+
+			Context("when no worker has enough free memory", func() {
+				BeforeEach(func() {
+					workers = []Worker{compatibleWorker2}
+				})
+
+				It("returns no worker", func() {
+					Expect(chooseErr).To(Equal(NoWorkerFitContainerPlacementStrategyError{Strategy: "available-resources"}))
+					Expect(chosenWorker).To(BeNil())
+				})
+			})
+		})
+
+		Context("when a max cpu load is configured", func() {
+			BeforeEach(func() {
+				maxCPULoad = 0.5
+				workers = []Worker{compatibleWorker2, compatibleWorker3}
+			})
+
+			It("skips workers at or above the limit", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker2))
+			})
+		})
+
+		Context("when a min free disk space is configured", func() {
+			BeforeEach(func() {
+				minFreeDiskSpace = 50 * 1024 * 1024 * 1024
+				workers = []Worker{compatibleWorker2, compatibleWorker3}
+			})
+
+			It("skips workers below the limit", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker2))
+			})
+		})
+
+		Context("when a worker does not report its resources", func() {
+			BeforeEach(func() {
+				compatibleWorker1.ResourcesReturns(nil)
+			})
+
+			It("prefers the workers which do", func() {
+				Expect(chooseErr).ToNot(HaveOccurred())
+				Expect(chosenWorker).To(Equal(compatibleWorker2))
+			})
+
+			Context("when none of the reporting workers fit", func() {
+				BeforeEach(func() {
+					minFreeDiskSpace = 500 * 1024 * 1024 * 1024
+				})
+
+				It("falls back to the workers which do not report", func() {
+					Expect(chooseErr).ToNot(HaveOccurred())
+					Expect(chosenWorker).To(Equal(compatibleWorker1))
+				})
+			})
+		})
+	})
+})
+
 var _ = Describe("ChainedPlacementStrategy #Choose", func() {
 
 	var someWorker1 *workerfakes.FakeWorker
@@ -76,6 +76,11 @@ type Pool interface {
 		WorkerSpec,
 		ContainerPlacementStrategy,
 	) (Worker, error)
+
+	CompatibleWorkers(
+		lager.Logger,
+		WorkerSpec,
+	) ([]Worker, error)
 }
 
 type pool struct {
@@ -128,6 +133,12 @@ func (pool *pool) allSatisfying(logger lager.Logger, spec WorkerSpec) ([]Worker,
 	}
 }
 
+// CompatibleWorkers returns the running workers a container with the given
+// spec could be placed on.
+func (pool *pool) CompatibleWorkers(logger lager.Logger, spec WorkerSpec) ([]Worker, error) {
+	return pool.allSatisfying(logger, spec)
+}
+
 func (pool *pool) ContainerInWorker(logger lager.Logger, owner db.ContainerOwner, workerSpec WorkerSpec) (bool, error) {
 	workersWithContainer, err := pool.provider.FindWorkersForContainerByOwner(
 		logger.Session("find-worker"),
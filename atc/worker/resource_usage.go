@@ -0,0 +1,175 @@
+package worker
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/worker/gclient"
+)
+
+// resourceUsageSampleInterval is how often the metrics of a container are
+// sampled while its process runs, in order to find its peak memory usage.
+const resourceUsageSampleInterval = 5 * time.Second
+
+// ResourceUsage is what the container of a step consumed while its process
+// ran, as reported by the worker's container runtime. Disk is the size of the
+// volumes the step wrote its outputs to.
+type ResourceUsage struct {
+	CPU        time.Duration
+	PeakMemory uint64
+	Disk       uint64
+	Wall       time.Duration
+}
+
+// usageSampler samples the metrics of a container until it is stopped, and
+// then measures the output volumes mounted at the given paths.
+//
+// Runtimes which do not report metrics are sampled once; the sampler then
+// gives up and reports no usage.
+type usageSampler struct {
+	logger      lager.Logger
+	container   gclient.Container
+	outputPaths []string
+
+	started time.Time
+
+	usage     ResourceUsage
+	available bool
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func sampleResourceUsage(logger lager.Logger, container gclient.Container, outputPaths []string, interval time.Duration) *usageSampler {
+	sampler := &usageSampler{
+		logger:      logger.Session("sample-resource-usage"),
+		container:   container,
+		outputPaths: outputPaths,
+		started:     time.Now(),
+		stop:        make(chan struct{}),
+		done:        make(chan struct{}),
+	}
+
+	go sampler.run(interval)
+
+	return sampler
+}
+
+func (sampler *usageSampler) run(interval time.Duration) {
+	defer close(sampler.done)
+
+	if !sampler.sample() {
+		return
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-sampler.stop:
+			return
+		case <-ticker.C:
+			sampler.sample()
+		}
+	}
+}
+
+// Stop takes a final sample and returns the usage of the container, or nil
+// if its runtime did not report any metrics.
+func (sampler *usageSampler) Stop() *ResourceUsage {
+	close(sampler.stop)
+	<-sampler.done
+
+	wall := time.Since(sampler.started)
+
+	if !sampler.available {
+		return nil
+	}
+
+	// the process has exited, but the container is still around to report
+	// the totals
+	sampler.sample()
+
+	usage := sampler.usage
+	usage.Wall = wall
+	usage.Disk = sampler.measureDisk()
+
+	return &usage
+}
+
+func (sampler *usageSampler) sample() bool {
+	metrics, err := sampler.container.Metrics()
+	if err != nil {
+		sampler.logger.Debug("failed-to-get-metrics", lager.Data{"error": err.Error()})
+		return false
+	}
+
+	sampler.available = true
+
+	sampler.usage.CPU = time.Duration(metrics.CPUStat.Usage)
+
+	if metrics.MemoryStat.TotalUsageTowardLimit > sampler.usage.PeakMemory {
+		sampler.usage.PeakMemory = metrics.MemoryStat.TotalUsageTowardLimit
+	}
+
+	return true
+}
+
+// measureDisk returns the size of the output volumes. The disk usage the
+// runtime reports only covers the container's root filesystem, and volumes
+// can't be measured through baggageclaim, so they are measured with du from
+// within the container. Their size is left out if its image has no du.
+func (sampler *usageSampler) measureDisk() uint64 {
+	if len(sampler.outputPaths) == 0 {
+		return 0
+	}
+
+	stdout := new(bytes.Buffer)
+
+	process, err := sampler.container.Run(
+		context.Background(),
+		garden.ProcessSpec{
+			Path: "du",
+			Args: append([]string{"-s", "-k"}, sampler.outputPaths...),
+		},
+		garden.ProcessIO{Stdout: stdout},
+	)
+	if err != nil {
+		sampler.logger.Debug("failed-to-measure-outputs", lager.Data{"error": err.Error()})
+		return 0
+	}
+
+	// du exits non-zero when it can't read part of a volume, but still
+	// reports what it could read
+	_, err = process.Wait()
+	if err != nil {
+		sampler.logger.Debug("failed-to-measure-outputs", lager.Data{"error": err.Error()})
+		return 0
+	}
+
+	var disk uint64
+
+	scanner := bufio.NewScanner(stdout)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		kilobytes, err := strconv.ParseUint(fields[0], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		disk += kilobytes * 1024
+	}
+
+	return disk
+}
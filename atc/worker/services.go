@@ -0,0 +1,315 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"code.cloudfoundry.org/garden"
+	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc/db"
+	"github.com/concourse/concourse/atc/runtime"
+)
+
+const serviceProcessID = "service"
+
+// ServiceSpec describes a service container, e.g. a database, which is
+// started alongside a task's container and shares its network.
+type ServiceSpec struct {
+	Name string
+
+	Owner         db.ContainerOwner
+	Metadata      db.ContainerMetadata
+	ContainerSpec ContainerSpec
+
+	// The service's process, which is expected to run in the foreground.
+	ProcessSpec runtime.ProcessSpec
+
+	// Optional probe determining whether the service is ready to be used.
+	Readiness *ReadinessSpec
+}
+
+// ReadinessSpec is either a command which is run in the service's container
+// until it succeeds or, when no command is set, ports which are checked until
+// something listens on all of them.
+type ReadinessSpec struct {
+	Path string
+	Args []string
+
+	Ports []uint16
+
+	Interval time.Duration
+	Timeout  time.Duration
+}
+
+type ServiceExitedError struct {
+	Name       string
+	ExitStatus int
+}
+
+func (err ServiceExitedError) Error() string {
+	return fmt.Sprintf("service %s exited with status %d before becoming ready", err.Name, err.ExitStatus)
+}
+
+type ServiceNotReadyError struct {
+	Name    string
+	Timeout time.Duration
+}
+
+func (err ServiceNotReadyError) Error() string {
+	return fmt.Sprintf("service %s did not become ready within %s", err.Name, err.Timeout)
+}
+
+type runningService struct {
+	spec      ServiceSpec
+	container Container
+
+	// peer is the container whose network the service joined.
+	peer Container
+
+	exited     chan struct{}
+	exitStatus int
+}
+
+type runningServices []*runningService
+
+// startServices starts the services in containers joining the network of the
+// given container and waits for all of them to become ready. The services
+// which were started are stopped if any of them fails to.
+func startServices(
+	ctx context.Context,
+	logger lager.Logger,
+	chosenWorker Worker,
+	container Container,
+	specs []ServiceSpec,
+) (runningServices, error) {
+	services, err := attachServices(ctx, logger, chosenWorker, container, specs)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, service := range services {
+		err := service.waitUntilReady(ctx, logger)
+		if err != nil {
+			services.stop(logger)
+			return nil, err
+		}
+	}
+
+	return services, nil
+}
+
+// attachServices attaches to the processes of the services running in the
+// network of the given container, starting any which aren't, without waiting
+// for them to become ready. This is all there's left to do for the services
+// of a task whose process is already running, e.g. after a restart of the
+// ATC, so that they're stopped once it exits.
+func attachServices(
+	ctx context.Context,
+	logger lager.Logger,
+	chosenWorker Worker,
+	container Container,
+	specs []ServiceSpec,
+) (runningServices, error) {
+	var services runningServices
+
+	for _, spec := range specs {
+		service, err := startService(ctx, logger, chosenWorker, container, spec)
+		if err != nil {
+			services.stop(logger)
+			return nil, fmt.Errorf("start service %s: %w", spec.Name, err)
+		}
+
+		services = append(services, service)
+	}
+
+	return services, nil
+}
+
+func startService(
+	ctx context.Context,
+	logger lager.Logger,
+	chosenWorker Worker,
+	container Container,
+	spec ServiceSpec,
+) (*runningService, error) {
+	logger = logger.Session("start-service", lager.Data{"service": spec.Name})
+
+	containerSpec := spec.ContainerSpec
+	containerSpec.JoinNetwork = container.Handle()
+	containerSpec.NetworkAlias = spec.Name
+
+	serviceContainer, err := chosenWorker.FindOrCreateContainer(
+		ctx,
+		logger,
+		spec.Owner,
+		spec.Metadata,
+		containerSpec,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	processIO := garden.ProcessIO{
+		Stdout: spec.ProcessSpec.StdoutWriter,
+		Stderr: spec.ProcessSpec.StderrWriter,
+	}
+
+	process, err := serviceContainer.Attach(context.Background(), serviceProcessID, processIO)
+	if err == nil {
+		logger.Info("already-running")
+	} else {
+		logger.Info("spawning")
+
+		process, err = serviceContainer.Run(
+			context.Background(),
+			garden.ProcessSpec{
+				ID:   serviceProcessID,
+				Path: spec.ProcessSpec.Path,
+				Args: spec.ProcessSpec.Args,
+				Dir:  spec.ProcessSpec.Dir,
+			},
+			processIO,
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	service := &runningService{
+		spec:      spec,
+		container: serviceContainer,
+		peer:      container,
+		exited:    make(chan struct{}),
+	}
+
+	go func() {
+		status, err := process.Wait()
+		if err != nil {
+			logger.Error("failed-to-wait-for-service", err)
+		}
+
+		service.exitStatus = status
+		close(service.exited)
+	}()
+
+	return service, nil
+}
+
+func (service *runningService) waitUntilReady(ctx context.Context, logger lager.Logger) error {
+	readiness := service.spec.Readiness
+	if readiness == nil {
+		return nil
+	}
+
+	logger = logger.Session("wait-for-service", lager.Data{"service": service.spec.Name})
+
+	timeout := time.NewTimer(readiness.Timeout)
+	defer timeout.Stop()
+
+	for {
+		ready := service.probe(ctx, logger, readiness)
+		if ready {
+			logger.Info("ready")
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-service.exited:
+			return ServiceExitedError{
+				Name:       service.spec.Name,
+				ExitStatus: service.exitStatus,
+			}
+		case <-timeout.C:
+			return ServiceNotReadyError{
+				Name:    service.spec.Name,
+				Timeout: readiness.Timeout,
+			}
+		case <-time.After(readiness.Interval):
+		}
+	}
+}
+
+// probe runs the readiness command once, killing it after an interval so
+// that a hanging command neither holds up the timeout nor piles up with the
+// ones run after it.
+func (service *runningService) probe(ctx context.Context, logger lager.Logger, readiness *ReadinessSpec) bool {
+	container, processSpec := service.container, garden.ProcessSpec{
+		Path: readiness.Path,
+		Args: readiness.Args,
+	}
+
+	if readiness.Path == "" {
+		// the service's image may well lack a shell, so its ports are
+		// checked from the container whose network it shares instead
+		container, processSpec = service.peer, portsProbe(readiness.Ports)
+	}
+
+	process, err := container.Run(
+		ctx,
+		processSpec,
+		garden.ProcessIO{
+			Stdout: ioutil.Discard,
+			Stderr: ioutil.Discard,
+		},
+	)
+	if err != nil {
+		logger.Error("failed-to-run-probe", err)
+		return false
+	}
+
+	done := make(chan bool, 1)
+	go func() {
+		status, err := process.Wait()
+		done <- err == nil && status == 0
+	}()
+
+	select {
+	case ready := <-done:
+		return ready
+	case <-ctx.Done():
+	case <-time.After(readiness.Interval):
+	}
+
+	err = process.Signal(garden.SignalKill)
+	if err != nil {
+		logger.Error("failed-to-kill-probe", err)
+	}
+
+	return false
+}
+
+// portsProbe checks whether something listens on all of the given TCP ports
+// using nothing but shell builtins, which show up in /proc/net/tcp(6) of any
+// of the containers sharing the network.
+func portsProbe(ports []uint16) garden.ProcessSpec {
+	var hexPorts []string
+	for _, port := range ports {
+		hexPorts = append(hexPorts, fmt.Sprintf("%04X", port))
+	}
+
+	script := fmt.Sprintf(
+		`for port in %s; do listening=; for table in /proc/net/tcp /proc/net/tcp6; do [ -r $table ] || continue; while read -r _ local _ state _; do [ "$state" = 0A ] && [ "${local##*:}" = $port ] && listening=1; done < $table; done; [ -n "$listening" ] || exit 1; done`,
+		strings.Join(hexPorts, " "),
+	)
+
+	return garden.ProcessSpec{
+		Path: "sh",
+		Args: []string{"-c", script},
+	}
+}
+
+// stop terminates the services' processes. Their containers are destroyed
+// along with the rest of the build's containers.
+func (services runningServices) stop(logger lager.Logger) {
+	for _, service := range services {
+		err := service.container.Stop(false)
+		if err != nil {
+			logger.Error("failed-to-stop-service", err, lager.Data{"service": service.spec.Name})
+		}
+	}
+}
@@ -32,6 +32,7 @@ type Volume interface {
 	InitializeResourceCache(db.UsedResourceCache) error
 	GetResourceCacheID() int
 	InitializeTaskCache(logger lager.Logger, jobID int, stepName string, path string, privileged bool) error
+	InitializeKeyedCache(logger lager.Logger, key string, privileged bool) error
 	InitializeArtifact(name string, buildID int) (db.WorkerArtifact, error)
 
 	CreateChildForContainer(db.CreatingContainer, string) (db.CreatingVolume, error)
@@ -176,6 +177,36 @@ func (v *volume) InitializeTaskCache(
 	return importVolume.InitializeTaskCache(logger, jobID, stepName, path, privileged)
 }
 
+func (v *volume) InitializeKeyedCache(
+	logger lager.Logger,
+	key string,
+	privileged bool,
+) error {
+	if v.dbVolume.ParentHandle() == "" {
+		return v.dbVolume.InitializeKeyedCache(key)
+	}
+
+	logger.Debug("creating-an-import-volume", lager.Data{"path": v.bcVolume.Path()})
+
+	// the volume is a copy-on-write child of the cache it was restored from,
+	// so import it into a volume of its own which can outlive its parent
+	importVolume, err := v.volumeClient.CreateVolume(
+		logger,
+		VolumeSpec{
+			Strategy:   baggageclaim.ImportStrategy{Path: v.bcVolume.Path()},
+			Privileged: privileged,
+		},
+		v.dbVolume.TeamID(),
+		v.dbVolume.WorkerName(),
+		db.VolumeTypeKeyedCache,
+	)
+	if err != nil {
+		return err
+	}
+
+	return importVolume.InitializeKeyedCache(logger, key, privileged)
+}
+
 func (v *volume) CreateChildForContainer(creatingContainer db.CreatingContainer, mountPath string) (db.CreatingVolume, error) {
 	return v.dbVolume.CreateChildForContainer(creatingContainer, mountPath)
 }
@@ -0,0 +1,86 @@
+package worker
+
+import "sync"
+
+// waitingTasks keeps track of the tasks waiting for a worker to become
+// available under the limit-active-tasks placement strategy, so that tasks
+// with a higher priority can be placed first.
+//
+// A task only gives way to the tasks with a higher priority which could be
+// placed on one of the workers it could be placed on itself, so that tasks
+// waiting for a busy pool of workers, e.g. for ones with certain tags, don't
+// hold up tasks which could run on other workers.
+//
+// Only the tasks waiting on this ATC are known; tasks waiting on other ATCs
+// still compete for workers through the active tasks lock.
+type waitingTasks struct {
+	lock  sync.Mutex
+	tasks map[*waitingTask]struct{}
+}
+
+type waitingTask struct {
+	priority int
+
+	// candidates are the names of the workers the task could be placed on.
+	candidates map[string]bool
+}
+
+func newWaitingTasks() *waitingTasks {
+	return &waitingTasks{
+		tasks: map[*waitingTask]struct{}{},
+	}
+}
+
+func (w *waitingTasks) add(priority int) *waitingTask {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	task := &waitingTask{
+		priority:   priority,
+		candidates: map[string]bool{},
+	}
+
+	w.tasks[task] = struct{}{}
+
+	return task
+}
+
+func (w *waitingTasks) remove(task *waitingTask) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	delete(w.tasks, task)
+}
+
+// setCandidates updates the workers the task could be placed on, which change
+// as workers come and go.
+func (w *waitingTasks) setCandidates(task *waitingTask, workers []Worker) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	task.candidates = map[string]bool{}
+	for _, worker := range workers {
+		task.candidates[worker.Name()] = true
+	}
+}
+
+// higherThan returns whether a task with a higher priority is waiting for one
+// of the workers the given task could be placed on.
+func (w *waitingTasks) higherThan(task *waitingTask) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	for other := range w.tasks {
+		if other.priority <= task.priority {
+			continue
+		}
+
+		for name := range task.candidates {
+			if other.candidates[name] {
+				return true
+			}
+		}
+	}
+
+	return false
+}
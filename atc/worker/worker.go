@@ -30,6 +30,16 @@ import (
 
 const userPropertyName = "user"
 
+// denyNetOutPropertyName holds the egress rules to reject, which garden has no
+// notion of. It matches the property read by the containerd runtime.
+const denyNetOutPropertyName = "concourse.deny-net-out"
+
+// joinNetworkPropertyName and networkAliasPropertyName tell the runtime to
+// put the container in the network namespace of another one, reachable from
+// there by the alias.
+const joinNetworkPropertyName = "concourse.join-network"
+const networkAliasPropertyName = "concourse.network-alias"
+
 var ResourceConfigCheckSessionExpiredError = errors.New("no db container was found for owner")
 
 //go:generate counterfeiter . Worker
@@ -83,6 +93,7 @@ type Worker interface {
 
 	ActiveContainers() int
 	ActiveVolumes() int
+	Resources() *atc.WorkerResources
 }
 
 type gardenWorker struct {
@@ -738,6 +749,12 @@ func (worker *gardenWorker) Satisfies(logger lager.Logger, spec WorkerSpec) bool
 		return false
 	}
 
+	for _, feature := range spec.Features {
+		if !hasFeature(worker.dbWorker, feature) {
+			return false
+		}
+	}
+
 	return true
 }
 
@@ -761,6 +778,16 @@ func (worker *gardenWorker) Uptime() time.Duration {
 	return time.Since(worker.dbWorker.StartTime())
 }
 
+func hasFeature(dbWorker db.Worker, feature string) bool {
+	for _, f := range dbWorker.Features() {
+		if f == feature {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (worker *gardenWorker) tagsMatch(tags []string) bool {
 	workerTags := worker.dbWorker.Tags()
 	if len(workerTags) > 0 && len(tags) == 0 {
@@ -798,3 +825,7 @@ func (worker *gardenWorker) ActiveContainers() int {
 func (worker *gardenWorker) ActiveVolumes() int {
 	return worker.dbWorker.ActiveVolumes()
 }
+
+func (worker *gardenWorker) Resources() *atc.WorkerResources {
+	return worker.dbWorker.Resources()
+}
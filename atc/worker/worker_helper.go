@@ -1,11 +1,13 @@
 package worker
 
 import (
+	"encoding/json"
 	"fmt"
 	"path/filepath"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
+	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/atc/db"
 	"github.com/concourse/concourse/atc/worker/gclient"
 )
@@ -47,6 +49,48 @@ func (w workerHelper) createGardenContainer(
 		env = append(env, fmt.Sprintf("no_proxy=%s", w.dbWorker.NoProxy()))
 	}
 
+	var netOut []garden.NetOutRule
+	var netIn []garden.NetIn
+	if containerSpec.Network != nil {
+		if !hasFeature(w.dbWorker, atc.WorkerFeatureNetworkRules) {
+			return nil, fmt.Errorf("worker %s does not support network rules", w.dbWorker.Name())
+		}
+
+		var err error
+		netOut, err = toGardenNetOutRules(containerSpec.Network.Allow)
+		if err != nil {
+			return nil, fmt.Errorf("convert allowed network rules: %w", err)
+		}
+
+		denyNetOut, err := toGardenNetOutRules(containerSpec.Network.Deny)
+		if err != nil {
+			return nil, fmt.Errorf("convert denied network rules: %w", err)
+		}
+
+		if len(denyNetOut) > 0 {
+			payload, err := json.Marshal(denyNetOut)
+			if err != nil {
+				return nil, fmt.Errorf("marshal denied network rules: %w", err)
+			}
+
+			gardenProperties[denyNetOutPropertyName] = string(payload)
+		}
+
+		netIn = toGardenNetIn(containerSpec.Network.Expose)
+	}
+
+	if containerSpec.JoinNetwork != "" {
+		if !hasFeature(w.dbWorker, atc.WorkerFeatureJoinNetwork) {
+			return nil, fmt.Errorf("worker %s does not support joining the network of another container", w.dbWorker.Name())
+		}
+
+		gardenProperties[joinNetworkPropertyName] = containerSpec.JoinNetwork
+
+		if containerSpec.NetworkAlias != "" {
+			gardenProperties[networkAliasPropertyName] = containerSpec.NetworkAlias
+		}
+	}
+
 	return w.gardenClient.Create(
 		garden.ContainerSpec{
 			Handle:     handleToCreate,
@@ -56,6 +100,8 @@ func (w workerHelper) createGardenContainer(
 			Limits:     containerSpec.Limits.ToGardenLimits(),
 			Env:        env,
 			Properties: gardenProperties,
+			NetOut:     netOut,
+			NetIn:      netIn,
 		})
 }
 
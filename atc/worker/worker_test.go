@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"io/ioutil"
+	"net"
 
 	"code.cloudfoundry.org/garden"
 	"code.cloudfoundry.org/lager"
@@ -665,6 +666,28 @@ var _ = Describe("Worker", func() {
 			})
 		})
 
+		Context("when the spec requires a feature", func() {
+			BeforeEach(func() {
+				spec.Features = []string{atc.WorkerFeatureNetworkRules}
+			})
+
+			Context("when the worker has the feature", func() {
+				BeforeEach(func() {
+					fakeDBWorker.FeaturesReturns([]string{atc.WorkerFeatureNetworkRules})
+				})
+
+				It("returns true", func() {
+					Expect(satisfies).To(BeTrue())
+				})
+			})
+
+			Context("when the worker does not have the feature", func() {
+				It("returns false", func() {
+					Expect(satisfies).To(BeFalse())
+				})
+			})
+		})
+
 		Context("when spec specifies team", func() {
 			BeforeEach(func() {
 				teamID = 123
@@ -865,6 +888,79 @@ var _ = Describe("Worker", func() {
 					}))
 				})
 
+				Context("when network rules are specified", func() {
+					BeforeEach(func() {
+						containerSpec.Network = &atc.TaskNetworkConfig{
+							Allow: []atc.TaskNetworkRule{
+								{
+									Network:  "10.0.0.0/24",
+									Protocol: "tcp",
+									Ports:    []atc.PortRange{{Start: 443, End: 443}},
+								},
+							},
+							Deny:   []atc.TaskNetworkRule{{Network: "0.0.0.0/0"}},
+							Expose: []atc.TaskPortMapping{{ContainerPort: 8080}},
+						}
+
+						fakeDBWorker.FeaturesReturns([]string{atc.WorkerFeatureNetworkRules})
+					})
+
+					Context("when the worker does not support network rules", func() {
+						BeforeEach(func() {
+							fakeDBWorker.FeaturesReturns(nil)
+						})
+
+						It("does not create the container in garden", func() {
+							Expect(findOrCreateErr).To(HaveOccurred())
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+					})
+
+					It("creates the container in garden with the egress rules and port mappings", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.NetOut).To(Equal([]garden.NetOutRule{
+							{
+								Protocol: garden.ProtocolTCP,
+								Networks: []garden.IPRange{
+									{Start: net.ParseIP("10.0.0.0").To4(), End: net.ParseIP("10.0.0.255").To4()},
+								},
+								Ports: []garden.PortRange{{Start: 443, End: 443}},
+							},
+						}))
+						Expect(actualSpec.NetIn).To(Equal([]garden.NetIn{{ContainerPort: 8080}}))
+						Expect(actualSpec.Properties).To(HaveKeyWithValue(
+							"concourse.deny-net-out",
+							`[{"networks":[{"start":"0.0.0.0","end":"255.255.255.255"}]}]`,
+						))
+					})
+				})
+
+				Context("when the container joins the network of another one", func() {
+					BeforeEach(func() {
+						containerSpec.JoinNetwork = "task-handle"
+						containerSpec.NetworkAlias = "postgres"
+
+						fakeDBWorker.FeaturesReturns([]string{atc.WorkerFeatureJoinNetwork})
+					})
+
+					Context("when the worker cannot join the network of another container", func() {
+						BeforeEach(func() {
+							fakeDBWorker.FeaturesReturns(nil)
+						})
+
+						It("does not create the container in garden", func() {
+							Expect(findOrCreateErr).To(HaveOccurred())
+							Expect(fakeGardenClient.CreateCallCount()).To(BeZero())
+						})
+					})
+
+					It("creates the container in garden with the network to join and its alias", func() {
+						actualSpec := fakeGardenClient.CreateArgsForCall(0)
+						Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse.join-network", "task-handle"))
+						Expect(actualSpec.Properties).To(HaveKeyWithValue("concourse.network-alias", "postgres"))
+					})
+				})
+
 				Context("when the input and output destination paths overlap", func() {
 					var (
 						fakeRemoteInputUnderInput    *workerfakes.FakeInputSource
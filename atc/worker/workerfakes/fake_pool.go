@@ -11,6 +11,20 @@ import (
 )
 
 type FakePool struct {
+	CompatibleWorkersStub        func(lager.Logger, worker.WorkerSpec) ([]worker.Worker, error)
+	compatibleWorkersMutex       sync.RWMutex
+	compatibleWorkersArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+	}
+	compatibleWorkersReturns struct {
+		result1 []worker.Worker
+		result2 error
+	}
+	compatibleWorkersReturnsOnCall map[int]struct {
+		result1 []worker.Worker
+		result2 error
+	}
 	ContainerInWorkerStub        func(lager.Logger, db.ContainerOwner, worker.WorkerSpec) (bool, error)
 	containerInWorkerMutex       sync.RWMutex
 	containerInWorkerArgsForCall []struct {
@@ -62,6 +76,70 @@ type FakePool struct {
 	invocationsMutex sync.RWMutex
 }
 
+func (fake *FakePool) CompatibleWorkers(arg1 lager.Logger, arg2 worker.WorkerSpec) ([]worker.Worker, error) {
+	fake.compatibleWorkersMutex.Lock()
+	ret, specificReturn := fake.compatibleWorkersReturnsOnCall[len(fake.compatibleWorkersArgsForCall)]
+	fake.compatibleWorkersArgsForCall = append(fake.compatibleWorkersArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 worker.WorkerSpec
+	}{arg1, arg2})
+	fake.recordInvocation("CompatibleWorkers", []interface{}{arg1, arg2})
+	fake.compatibleWorkersMutex.Unlock()
+	if fake.CompatibleWorkersStub != nil {
+		return fake.CompatibleWorkersStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.compatibleWorkersReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakePool) CompatibleWorkersCallCount() int {
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
+	return len(fake.compatibleWorkersArgsForCall)
+}
+
+func (fake *FakePool) CompatibleWorkersCalls(stub func(lager.Logger, worker.WorkerSpec) ([]worker.Worker, error)) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = stub
+}
+
+func (fake *FakePool) CompatibleWorkersArgsForCall(i int) (lager.Logger, worker.WorkerSpec) {
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
+	argsForCall := fake.compatibleWorkersArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakePool) CompatibleWorkersReturns(result1 []worker.Worker, result2 error) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = nil
+	fake.compatibleWorkersReturns = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakePool) CompatibleWorkersReturnsOnCall(i int, result1 []worker.Worker, result2 error) {
+	fake.compatibleWorkersMutex.Lock()
+	defer fake.compatibleWorkersMutex.Unlock()
+	fake.CompatibleWorkersStub = nil
+	if fake.compatibleWorkersReturnsOnCall == nil {
+		fake.compatibleWorkersReturnsOnCall = make(map[int]struct {
+			result1 []worker.Worker
+			result2 error
+		})
+	}
+	fake.compatibleWorkersReturnsOnCall[i] = struct {
+		result1 []worker.Worker
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakePool) ContainerInWorker(arg1 lager.Logger, arg2 db.ContainerOwner, arg3 worker.WorkerSpec) (bool, error) {
 	fake.containerInWorkerMutex.Lock()
 	ret, specificReturn := fake.containerInWorkerReturnsOnCall[len(fake.containerInWorkerArgsForCall)]
@@ -262,6 +340,8 @@ func (fake *FakePool) FindOrChooseWorkerForContainerReturnsOnCall(i int, result1
 func (fake *FakePool) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
+	fake.compatibleWorkersMutex.RLock()
+	defer fake.compatibleWorkersMutex.RUnlock()
 	fake.containerInWorkerMutex.RLock()
 	defer fake.containerInWorkerMutex.RUnlock()
 	fake.findOrChooseWorkerMutex.RLock()
@@ -95,6 +95,19 @@ type FakeVolume struct {
 		result1 db.WorkerArtifact
 		result2 error
 	}
+	InitializeKeyedCacheStub        func(lager.Logger, string, bool) error
+	initializeKeyedCacheMutex       sync.RWMutex
+	initializeKeyedCacheArgsForCall []struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+	}
+	initializeKeyedCacheReturns struct {
+		result1 error
+	}
+	initializeKeyedCacheReturnsOnCall map[int]struct {
+		result1 error
+	}
 	InitializeResourceCacheStub        func(db.UsedResourceCache) error
 	initializeResourceCacheMutex       sync.RWMutex
 	initializeResourceCacheArgsForCall []struct {
@@ -623,6 +636,68 @@ func (fake *FakeVolume) InitializeArtifactReturnsOnCall(i int, result1 db.Worker
 	}{result1, result2}
 }
 
+func (fake *FakeVolume) InitializeKeyedCache(arg1 lager.Logger, arg2 string, arg3 bool) error {
+	fake.initializeKeyedCacheMutex.Lock()
+	ret, specificReturn := fake.initializeKeyedCacheReturnsOnCall[len(fake.initializeKeyedCacheArgsForCall)]
+	fake.initializeKeyedCacheArgsForCall = append(fake.initializeKeyedCacheArgsForCall, struct {
+		arg1 lager.Logger
+		arg2 string
+		arg3 bool
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("InitializeKeyedCache", []interface{}{arg1, arg2, arg3})
+	fake.initializeKeyedCacheMutex.Unlock()
+	if fake.InitializeKeyedCacheStub != nil {
+		return fake.InitializeKeyedCacheStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.initializeKeyedCacheReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheCallCount() int {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	return len(fake.initializeKeyedCacheArgsForCall)
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheCalls(stub func(lager.Logger, string, bool) error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = stub
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheArgsForCall(i int) (lager.Logger, string, bool) {
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
+	argsForCall := fake.initializeKeyedCacheArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheReturns(result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	fake.initializeKeyedCacheReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeVolume) InitializeKeyedCacheReturnsOnCall(i int, result1 error) {
+	fake.initializeKeyedCacheMutex.Lock()
+	defer fake.initializeKeyedCacheMutex.Unlock()
+	fake.InitializeKeyedCacheStub = nil
+	if fake.initializeKeyedCacheReturnsOnCall == nil {
+		fake.initializeKeyedCacheReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.initializeKeyedCacheReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeVolume) InitializeResourceCache(arg1 db.UsedResourceCache) error {
 	fake.initializeResourceCacheMutex.Lock()
 	ret, specificReturn := fake.initializeResourceCacheReturnsOnCall[len(fake.initializeResourceCacheArgsForCall)]
@@ -1235,6 +1310,8 @@ func (fake *FakeVolume) Invocations() map[string][][]interface{} {
 	defer fake.handleMutex.RUnlock()
 	fake.initializeArtifactMutex.RLock()
 	defer fake.initializeArtifactMutex.RUnlock()
+	fake.initializeKeyedCacheMutex.RLock()
+	defer fake.initializeKeyedCacheMutex.RUnlock()
 	fake.initializeResourceCacheMutex.RLock()
 	defer fake.initializeResourceCacheMutex.RUnlock()
 	fake.initializeTaskCacheMutex.RLock()
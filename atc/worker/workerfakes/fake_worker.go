@@ -306,6 +306,16 @@ type FakeWorker struct {
 	resourceTypesReturnsOnCall map[int]struct {
 		result1 []atc.WorkerResourceType
 	}
+	ResourcesStub        func() *atc.WorkerResources
+	resourcesMutex       sync.RWMutex
+	resourcesArgsForCall []struct {
+	}
+	resourcesReturns struct {
+		result1 *atc.WorkerResources
+	}
+	resourcesReturnsOnCall map[int]struct {
+		result1 *atc.WorkerResources
+	}
 	SatisfiesStub        func(lager.Logger, worker.WorkerSpec) bool
 	satisfiesMutex       sync.RWMutex
 	satisfiesArgsForCall []struct {
@@ -1642,6 +1652,58 @@ func (fake *FakeWorker) ResourceTypesReturnsOnCall(i int, result1 []atc.WorkerRe
 	}{result1}
 }
 
+func (fake *FakeWorker) Resources() *atc.WorkerResources {
+	fake.resourcesMutex.Lock()
+	ret, specificReturn := fake.resourcesReturnsOnCall[len(fake.resourcesArgsForCall)]
+	fake.resourcesArgsForCall = append(fake.resourcesArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Resources", []interface{}{})
+	fake.resourcesMutex.Unlock()
+	if fake.ResourcesStub != nil {
+		return fake.ResourcesStub()
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.resourcesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeWorker) ResourcesCallCount() int {
+	fake.resourcesMutex.RLock()
+	defer fake.resourcesMutex.RUnlock()
+	return len(fake.resourcesArgsForCall)
+}
+
+func (fake *FakeWorker) ResourcesCalls(stub func() *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = stub
+}
+
+func (fake *FakeWorker) ResourcesReturns(result1 *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = nil
+	fake.resourcesReturns = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
+func (fake *FakeWorker) ResourcesReturnsOnCall(i int, result1 *atc.WorkerResources) {
+	fake.resourcesMutex.Lock()
+	defer fake.resourcesMutex.Unlock()
+	fake.ResourcesStub = nil
+	if fake.resourcesReturnsOnCall == nil {
+		fake.resourcesReturnsOnCall = make(map[int]struct {
+			result1 *atc.WorkerResources
+		})
+	}
+	fake.resourcesReturnsOnCall[i] = struct {
+		result1 *atc.WorkerResources
+	}{result1}
+}
+
 func (fake *FakeWorker) Satisfies(arg1 lager.Logger, arg2 worker.WorkerSpec) bool {
 	fake.satisfiesMutex.Lock()
 	ret, specificReturn := fake.satisfiesReturnsOnCall[len(fake.satisfiesArgsForCall)]
@@ -1854,6 +1916,8 @@ func (fake *FakeWorker) Invocations() map[string][][]interface{} {
 	defer fake.nameMutex.RUnlock()
 	fake.resourceTypesMutex.RLock()
 	defer fake.resourceTypesMutex.RUnlock()
+	fake.resourcesMutex.RLock()
+	defer fake.resourcesMutex.RUnlock()
 	fake.satisfiesMutex.RLock()
 	defer fake.satisfiesMutex.RUnlock()
 	fake.tagsMutex.RLock()
@@ -45,11 +45,15 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 		case atc.GetBuildPreparation,
 			atc.BuildEvents,
 			atc.GetBuildPlan,
-			atc.ListBuildArtifacts:
+			atc.ListBuildArtifacts,
+			atc.ListBuildStoredArtifacts,
+			atc.GetBuildStoredArtifact:
 			newHandler = wrappa.checkBuildReadAccessHandlerFactory.CheckIfPrivateJobHandler(handler, rejector)
 
 			// resource belongs to authorized team
-		case atc.AbortBuild:
+		case atc.AbortBuild,
+			atc.ApproveBuild,
+			atc.RejectBuild:
 			newHandler = wrappa.checkBuildWriteAccessHandlerFactory.HandlerFor(handler, rejector)
 
 		// requester is system, admin team, or worker owning team
@@ -115,6 +119,7 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 
 		case atc.GetLogLevel,
 			atc.ListActiveUsersSince,
+			atc.ListAllAuditEvents,
 			atc.SetLogLevel,
 			atc.GetInfoCreds,
 			atc.SetWall,
@@ -134,6 +139,8 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.UnpinResource,
 			atc.SetPinCommentOnResource,
 			atc.GetConfig,
+			atc.ListPipelineConfigVersions,
+			atc.GetPipelineConfigVersion,
 			atc.GetCC,
 			atc.GetVersionsDB,
 			atc.ListJobInputs,
@@ -150,6 +157,11 @@ func (wrappa *APIAuthWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.ClearTaskCache,
 			atc.CreateArtifact,
 			atc.ScheduleJob,
+			atc.ListAuditEvents,
+			atc.ListWebhooks,
+			atc.SetWebhook,
+			atc.DestroyWebhook,
+			atc.ListWebhookDeliveries,
 			atc.GetArtifact:
 			newHandler = auth.CheckAuthorizationHandler(handler, rejector)
 
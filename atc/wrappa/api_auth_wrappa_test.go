@@ -121,13 +121,17 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.BuildResources: doesNotCheckIfPrivateJob(inputHandlers[atc.BuildResources]),
 
 				// authorized or public pipeline and public job
-				atc.BuildEvents:         checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
-				atc.ListBuildArtifacts:  checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
-				atc.GetBuildPreparation: checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
-				atc.GetBuildPlan:        checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
+				atc.BuildEvents:              checksIfPrivateJob(inputHandlers[atc.BuildEvents]),
+				atc.ListBuildArtifacts:       checksIfPrivateJob(inputHandlers[atc.ListBuildArtifacts]),
+				atc.ListBuildStoredArtifacts: checksIfPrivateJob(inputHandlers[atc.ListBuildStoredArtifacts]),
+				atc.GetBuildStoredArtifact:   checksIfPrivateJob(inputHandlers[atc.GetBuildStoredArtifact]),
+				atc.GetBuildPreparation:      checksIfPrivateJob(inputHandlers[atc.GetBuildPreparation]),
+				atc.GetBuildPlan:             checksIfPrivateJob(inputHandlers[atc.GetBuildPlan]),
 
 				// resource belongs to authorized team
-				atc.AbortBuild: checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.AbortBuild:   checkWritePermissionForBuild(inputHandlers[atc.AbortBuild]),
+				atc.ApproveBuild: checkWritePermissionForBuild(inputHandlers[atc.ApproveBuild]),
+				atc.RejectBuild:  checkWritePermissionForBuild(inputHandlers[atc.RejectBuild]),
 
 				// resource belongs to authorized team
 				atc.PruneWorker:              checkTeamAccessForWorker(inputHandlers[atc.PruneWorker]),
@@ -191,39 +195,47 @@ var _ = Describe("APIAuthWrappa", func() {
 				atc.SetLogLevel:          authenticatedAndAdmin(inputHandlers[atc.SetLogLevel]),
 				atc.GetInfoCreds:         authenticatedAndAdmin(inputHandlers[atc.GetInfoCreds]),
 				atc.ListActiveUsersSince: authenticatedAndAdmin(inputHandlers[atc.ListActiveUsersSince]),
+				atc.ListAllAuditEvents:   authenticatedAndAdmin(inputHandlers[atc.ListAllAuditEvents]),
 				atc.SetWall:              authenticatedAndAdmin(inputHandlers[atc.SetWall]),
 				atc.ClearWall:            authenticatedAndAdmin(inputHandlers[atc.ClearWall]),
 
 				// authorized (requested team matches resource team)
-				atc.CheckResource:           authorized(inputHandlers[atc.CheckResource]),
-				atc.CheckResourceType:       authorized(inputHandlers[atc.CheckResourceType]),
-				atc.CreateJobBuild:          authorized(inputHandlers[atc.CreateJobBuild]),
-				atc.RerunJobBuild:           authorized(inputHandlers[atc.RerunJobBuild]),
-				atc.DeletePipeline:          authorized(inputHandlers[atc.DeletePipeline]),
-				atc.DisableResourceVersion:  authorized(inputHandlers[atc.DisableResourceVersion]),
-				atc.EnableResourceVersion:   authorized(inputHandlers[atc.EnableResourceVersion]),
-				atc.PinResourceVersion:      authorized(inputHandlers[atc.PinResourceVersion]),
-				atc.UnpinResource:           authorized(inputHandlers[atc.UnpinResource]),
-				atc.SetPinCommentOnResource: authorized(inputHandlers[atc.SetPinCommentOnResource]),
-				atc.GetConfig:               authorized(inputHandlers[atc.GetConfig]),
-				atc.GetCC:                   authorized(inputHandlers[atc.GetCC]),
-				atc.GetVersionsDB:           authorized(inputHandlers[atc.GetVersionsDB]),
-				atc.ListJobInputs:           authorized(inputHandlers[atc.ListJobInputs]),
-				atc.OrderPipelines:          authorized(inputHandlers[atc.OrderPipelines]),
-				atc.PauseJob:                authorized(inputHandlers[atc.PauseJob]),
-				atc.PausePipeline:           authorized(inputHandlers[atc.PausePipeline]),
-				atc.ArchivePipeline:         authorized(inputHandlers[atc.ArchivePipeline]),
-				atc.RenamePipeline:          authorized(inputHandlers[atc.RenamePipeline]),
-				atc.SaveConfig:              authorized(inputHandlers[atc.SaveConfig]),
-				atc.UnpauseJob:              authorized(inputHandlers[atc.UnpauseJob]),
-				atc.ScheduleJob:             authorized(inputHandlers[atc.ScheduleJob]),
-				atc.UnpausePipeline:         authorized(inputHandlers[atc.UnpausePipeline]),
-				atc.ExposePipeline:          authorized(inputHandlers[atc.ExposePipeline]),
-				atc.HidePipeline:            authorized(inputHandlers[atc.HidePipeline]),
-				atc.CreatePipelineBuild:     authorized(inputHandlers[atc.CreatePipelineBuild]),
-				atc.ClearTaskCache:          authorized(inputHandlers[atc.ClearTaskCache]),
-				atc.CreateArtifact:          authorized(inputHandlers[atc.CreateArtifact]),
-				atc.GetArtifact:             authorized(inputHandlers[atc.GetArtifact]),
+				atc.CheckResource:              authorized(inputHandlers[atc.CheckResource]),
+				atc.CheckResourceType:          authorized(inputHandlers[atc.CheckResourceType]),
+				atc.CreateJobBuild:             authorized(inputHandlers[atc.CreateJobBuild]),
+				atc.RerunJobBuild:              authorized(inputHandlers[atc.RerunJobBuild]),
+				atc.DeletePipeline:             authorized(inputHandlers[atc.DeletePipeline]),
+				atc.DisableResourceVersion:     authorized(inputHandlers[atc.DisableResourceVersion]),
+				atc.EnableResourceVersion:      authorized(inputHandlers[atc.EnableResourceVersion]),
+				atc.PinResourceVersion:         authorized(inputHandlers[atc.PinResourceVersion]),
+				atc.UnpinResource:              authorized(inputHandlers[atc.UnpinResource]),
+				atc.SetPinCommentOnResource:    authorized(inputHandlers[atc.SetPinCommentOnResource]),
+				atc.GetConfig:                  authorized(inputHandlers[atc.GetConfig]),
+				atc.ListPipelineConfigVersions: authorized(inputHandlers[atc.ListPipelineConfigVersions]),
+				atc.GetPipelineConfigVersion:   authorized(inputHandlers[atc.GetPipelineConfigVersion]),
+				atc.GetCC:                      authorized(inputHandlers[atc.GetCC]),
+				atc.ListAuditEvents:            authorized(inputHandlers[atc.ListAuditEvents]),
+				atc.ListWebhooks:               authorized(inputHandlers[atc.ListWebhooks]),
+				atc.SetWebhook:                 authorized(inputHandlers[atc.SetWebhook]),
+				atc.DestroyWebhook:             authorized(inputHandlers[atc.DestroyWebhook]),
+				atc.ListWebhookDeliveries:      authorized(inputHandlers[atc.ListWebhookDeliveries]),
+				atc.GetVersionsDB:              authorized(inputHandlers[atc.GetVersionsDB]),
+				atc.ListJobInputs:              authorized(inputHandlers[atc.ListJobInputs]),
+				atc.OrderPipelines:             authorized(inputHandlers[atc.OrderPipelines]),
+				atc.PauseJob:                   authorized(inputHandlers[atc.PauseJob]),
+				atc.PausePipeline:              authorized(inputHandlers[atc.PausePipeline]),
+				atc.ArchivePipeline:            authorized(inputHandlers[atc.ArchivePipeline]),
+				atc.RenamePipeline:             authorized(inputHandlers[atc.RenamePipeline]),
+				atc.SaveConfig:                 authorized(inputHandlers[atc.SaveConfig]),
+				atc.UnpauseJob:                 authorized(inputHandlers[atc.UnpauseJob]),
+				atc.ScheduleJob:                authorized(inputHandlers[atc.ScheduleJob]),
+				atc.UnpausePipeline:            authorized(inputHandlers[atc.UnpausePipeline]),
+				atc.ExposePipeline:             authorized(inputHandlers[atc.ExposePipeline]),
+				atc.HidePipeline:               authorized(inputHandlers[atc.HidePipeline]),
+				atc.CreatePipelineBuild:        authorized(inputHandlers[atc.CreatePipelineBuild]),
+				atc.ClearTaskCache:             authorized(inputHandlers[atc.ClearTaskCache]),
+				atc.CreateArtifact:             authorized(inputHandlers[atc.CreateArtifact]),
+				atc.GetArtifact:                authorized(inputHandlers[atc.GetArtifact]),
 			}
 		})
 
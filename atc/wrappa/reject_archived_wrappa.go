@@ -42,13 +42,19 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			// leave the handler as-is
 		case
 			atc.GetConfig,
+			atc.ListPipelineConfigVersions,
+			atc.GetPipelineConfigVersion,
 			atc.GetBuild,
 			atc.BuildResources,
 			atc.BuildEvents,
 			atc.ListBuildArtifacts,
+			atc.ListBuildStoredArtifacts,
+			atc.GetBuildStoredArtifact,
 			atc.GetBuildPreparation,
 			atc.GetBuildPlan,
 			atc.AbortBuild,
+			atc.ApproveBuild,
+			atc.RejectBuild,
 			atc.PruneWorker,
 			atc.LandWorker,
 			atc.ReportWorkerContainers,
@@ -84,6 +90,12 @@ func (rw *RejectArchivedWrappa) Wrap(handlers rata.Handlers) rata.Handlers {
 			atc.DeleteWorker,
 			atc.GetTeam,
 			atc.SetTeam,
+			atc.ListAuditEvents,
+			atc.ListAllAuditEvents,
+			atc.ListWebhooks,
+			atc.SetWebhook,
+			atc.DestroyWebhook,
+			atc.ListWebhookDeliveries,
 			atc.RenameTeam,
 			atc.DestroyTeam,
 			atc.GetUser,
@@ -0,0 +1,146 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+	"github.com/fatih/color"
+)
+
+type AuditLogCommand struct {
+	Count    int    `short:"c" long:"count" default:"50" description:"Number of audit events you want to limit the return to"`
+	Action   string `short:"a" long:"action" description:"Only show events for this API action, e.g. PausePipeline"`
+	User     string `short:"u" long:"user" description:"Only show events for requests made by this user"`
+	Pipeline string `short:"p" long:"pipeline" description:"Only show events for this pipeline"`
+	Since    string `long:"since" description:"Start of the range to filter audit events"`
+	Until    string `long:"until" description:"End of the range to filter audit events"`
+	Json     bool   `long:"json" description:"Print command result as JSON"`
+	Team     string `long:"team" description:"Name of the team to show the audit log of, if different from the target default"`
+	AllTeams bool   `long:"all-teams" description:"Show the audit log of every team, along with requests which did not target a team (admin only)"`
+}
+
+func (command *AuditLogCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	filter := concourse.AuditEventFilter{
+		Action:   command.Action,
+		User:     command.User,
+		Pipeline: command.Pipeline,
+	}
+
+	if command.Since != "" {
+		filter.Since, err = time.ParseInLocation(inputTimeLayout, command.Since, time.Now().Location())
+		if err != nil {
+			return errors.New("Since time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Until != "" {
+		filter.Until, err = time.ParseInLocation(inputTimeLayout, command.Until, time.Now().Location())
+		if err != nil {
+			return errors.New("Until time should be in the format: " + inputTimeLayout)
+		}
+	}
+
+	if command.Since != "" && command.Until != "" && filter.Since.After(filter.Until) {
+		return errors.New("Cannot have --since after --until")
+	}
+
+	if command.Team != "" && command.AllTeams {
+		return errors.New("Cannot specify both --all-teams and --team")
+	}
+
+	page := concourse.Page{Limit: command.Count}
+
+	var events []atc.AuditEvent
+	if command.AllTeams {
+		events, _, err = target.Client().AuditEvents(filter, page)
+	} else {
+		events, _, err = GetTeam(target, command.Team).AuditEvents(filter, page)
+	}
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(events)
+	}
+
+	return command.displayEvents(events)
+}
+
+func (command *AuditLogCommand) displayEvents(events []atc.AuditEvent) error {
+	headers := ui.TableRow{
+		{Contents: "id", Color: color.New(color.Bold)},
+		{Contents: "time", Color: color.New(color.Bold)},
+		{Contents: "user", Color: color.New(color.Bold)},
+		{Contents: "action", Color: color.New(color.Bold)},
+	}
+
+	if command.AllTeams {
+		headers = append(headers, ui.TableCell{Contents: "team", Color: color.New(color.Bold)})
+	}
+
+	table := ui.Table{
+		Headers: append(headers,
+			ui.TableCell{Contents: "pipeline", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "object", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "source ip", Color: color.New(color.Bold)},
+			ui.TableCell{Contents: "status", Color: color.New(color.Bold)},
+		),
+	}
+
+	for _, event := range events {
+		row := ui.TableRow{
+			{Contents: strconv.Itoa(event.ID)},
+			{Contents: time.Unix(event.CreatedAt, 0).Format(timeDateLayout)},
+			optionalCell(event.UserName),
+			{Contents: event.Action},
+		}
+
+		if command.AllTeams {
+			row = append(row, optionalCell(event.TeamName))
+		}
+
+		table.Data = append(table.Data, append(row,
+			optionalCell(event.PipelineName),
+			optionalCell(event.Object),
+			optionalCell(event.SourceIP),
+			statusCell(event.Status),
+		))
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func optionalCell(contents string) ui.TableCell {
+	if contents == "" {
+		return ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+	}
+
+	return ui.TableCell{Contents: contents}
+}
+
+func statusCell(status int) ui.TableCell {
+	cell := ui.TableCell{Contents: strconv.Itoa(status)}
+	if status >= 400 {
+		cell.Color = ui.FailedColor
+	}
+
+	return cell
+}
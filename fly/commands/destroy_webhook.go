@@ -0,0 +1,51 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/vito/go-interact/interact"
+)
+
+type DestroyWebhookCommand struct {
+	Webhook         string `short:"w" long:"webhook" required:"true" description:"Name of the webhook to destroy"`
+	SkipInteractive bool   `short:"n" long:"non-interactive" description:"Destroy the webhook without confirmation"`
+
+	Team string `long:"team" description:"Name of the team to which the webhook belongs, if different from the target default"`
+}
+
+func (command *DestroyWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("!!! this will remove webhook '%s' along with its delivery log\n\n", command.Webhook)
+
+	confirm := command.SkipInteractive
+	if !confirm {
+		err := interact.NewInteraction("are you sure?").Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return err
+		}
+	}
+
+	found, err := GetTeam(target, command.Team).DestroyWebhook(command.Webhook)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		fmt.Printf("webhook '%s' does not exist\n", command.Webhook)
+	} else {
+		fmt.Printf("webhook '%s' destroyed\n", command.Webhook)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package commands
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type DiffPipelineCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to diff the configuration of"`
+	From     int                      `long:"from" required:"true" description:"Configuration version to diff from"`
+	To       int                      `long:"to" description:"Configuration version to diff to (defaults to the current version)"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *DiffPipelineCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *DiffPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := GetTeam(target, command.Team)
+	pipelineRef := command.Pipeline.Ref()
+
+	fromConfig, err := pipelineConfigVersion(team, pipelineRef, command.From)
+	if err != nil {
+		return err
+	}
+
+	var toConfig atc.Config
+	if command.To != 0 {
+		toConfig, err = pipelineConfigVersion(team, pipelineRef, command.To)
+	} else {
+		toConfig, err = currentPipelineConfig(team, pipelineRef)
+	}
+	if err != nil {
+		return err
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !fromConfig.Diff(stdout, toConfig) {
+		fmt.Println("no differences")
+	}
+
+	return nil
+}
+
+func pipelineConfigVersion(team concourse.Team, pipelineRef atc.PipelineRef, version int) (atc.Config, error) {
+	configVersion, found, err := team.PipelineConfigVersion(pipelineRef, version)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	if !found || configVersion.Config == nil {
+		return atc.Config{}, fmt.Errorf("pipeline config version %d not found", version)
+	}
+
+	return *configVersion.Config, nil
+}
+
+func currentPipelineConfig(team concourse.Team, pipelineRef atc.PipelineRef) (atc.Config, error) {
+	config, _, found, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return atc.Config{}, err
+	}
+
+	if !found {
+		return atc.Config{}, fmt.Errorf("pipeline not found")
+	}
+
+	return config, nil
+}
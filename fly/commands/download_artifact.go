@@ -0,0 +1,111 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+)
+
+type DownloadArtifactCommand struct {
+	Job       flaghelpers.JobFlag `short:"j" long:"job"       value-name:"PIPELINE/JOB"   description:"Name of a job to download artifacts from"`
+	Build     string              `short:"b" long:"build"     required:"true" description:"If job is specified: build number to download artifacts from. If job not specified: build id"`
+	Artifact  string              `short:"a" long:"artifact"  value-name:"OUTPUT/PATH" description:"Name of the artifact to download. Downloads every artifact of the build if not specified"`
+	OutputDir string              `short:"o" long:"output-dir" default:"." description:"Directory to download the artifacts into"`
+}
+
+func (command *DownloadArtifactCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	var build atc.Build
+	var exists bool
+	if command.Job.PipelineRef.Name == "" && command.Job.JobName == "" {
+		build, exists, err = target.Client().Build(command.Build)
+	} else {
+		build, exists, err = target.Team().JobBuild(command.Job.PipelineRef, command.Job.JobName, command.Build)
+	}
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return fmt.Errorf("build does not exist")
+	}
+
+	buildID := strconv.Itoa(build.ID)
+
+	artifacts, err := target.Client().ListBuildStoredArtifacts(buildID)
+	if err != nil {
+		return err
+	}
+
+	var downloads []atc.StoredArtifact
+	for _, artifact := range artifacts {
+		if command.Artifact == "" || artifact.Name == command.Artifact {
+			downloads = append(downloads, artifact)
+		}
+	}
+
+	if len(downloads) == 0 {
+		if command.Artifact != "" {
+			return fmt.Errorf("build has no artifact named '%s'", command.Artifact)
+		}
+
+		return fmt.Errorf("build has no stored artifacts")
+	}
+
+	for _, artifact := range downloads {
+		dest := filepath.Join(command.OutputDir, filepath.FromSlash(artifact.Name)) + ".tgz"
+
+		err := command.download(target.Client(), buildID, artifact, dest)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("downloaded %s to %s\n", artifact.Name, dest)
+	}
+
+	return nil
+}
+
+func (command *DownloadArtifactCommand) download(client concourse.Client, buildID string, artifact atc.StoredArtifact, dest string) error {
+	content, err := client.GetBuildStoredArtifact(buildID, artifact.ID)
+	if err != nil {
+		return err
+	}
+
+	defer content.Close()
+
+	err = os.MkdirAll(filepath.Dir(dest), 0755)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(dest)
+	if err != nil {
+		return err
+	}
+
+	defer file.Close()
+
+	_, err = io.Copy(file, content)
+	if err != nil {
+		return err
+	}
+
+	return file.Close()
+}
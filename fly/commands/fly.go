@@ -31,6 +31,12 @@ type FlyCommand struct {
 	SetTeam     SetTeamCommand     `command:"set-team"  alias:"st" description:"Create or modify a team to have the given credentials"`
 	RenameTeam  RenameTeamCommand  `command:"rename-team"   alias:"rt" description:"Rename a team"`
 	DestroyTeam DestroyTeamCommand `command:"destroy-team"  alias:"dt" description:"Destroy a team and delete all of its data"`
+	AuditLog    AuditLogCommand    `command:"audit-log"     alias:"al" description:"List the audit log of a team"`
+
+	Webhooks          WebhooksCommand          `command:"webhooks"           alias:"whs" description:"List the build status webhooks of a team"`
+	SetWebhook        SetWebhookCommand        `command:"set-webhook"        alias:"swh" description:"Create or update a build status webhook"`
+	DestroyWebhook    DestroyWebhookCommand    `command:"destroy-webhook"    alias:"dwh" description:"Destroy a build status webhook"`
+	WebhookDeliveries WebhookDeliveriesCommand `command:"webhook-deliveries" alias:"whd" description:"List the recent deliveries of a build status webhook"`
 
 	Checklist ChecklistCommand `command:"checklist" alias:"cl" description:"Print a Checkfile of the given pipeline"`
 
@@ -58,6 +64,9 @@ type FlyCommand struct {
 	ValidatePipeline ValidatePipelineCommand `command:"validate-pipeline"   alias:"vp"   description:"Validate a pipeline config"`
 	FormatPipeline   FormatPipelineCommand   `command:"format-pipeline"     alias:"fp"   description:"Format a pipeline config"`
 	OrderPipelines   OrderPipelinesCommand   `command:"order-pipelines"     alias:"op"   description:"Orders pipelines"`
+	PipelineHistory  PipelineHistoryCommand  `command:"pipeline-history"    alias:"ph"   description:"List the configuration history of a pipeline"`
+	DiffPipeline     DiffPipelineCommand     `command:"diff-pipeline"       alias:"dfp"  description:"Show the differences between two versions of a pipeline's configuration"`
+	RollbackPipeline RollbackPipelineCommand `command:"rollback-pipeline"   alias:"rbp"  description:"Restore a previous version of a pipeline's configuration"`
 
 	Resources              ResourcesCommand              `command:"resources"                  alias:"rs"   description:"List the resources in the pipeline"`
 	ResourceVersions       ResourceVersionsCommand       `command:"resource-versions"          alias:"rvs"  description:"List the versions of a resource"`
@@ -71,9 +80,12 @@ type FlyCommand struct {
 
 	ClearTaskCache ClearTaskCacheCommand `command:"clear-task-cache" alias:"ctc" description:"Clears cache from a task container"`
 
-	Builds     BuildsCommand     `command:"builds"      alias:"bs" description:"List builds data"`
-	AbortBuild AbortBuildCommand `command:"abort-build" alias:"ab" description:"Abort a build"`
-	RerunBuild RerunBuildCommand `command:"rerun-build" alias:"rb" description:"Rerun a build"`
+	Builds       BuildsCommand       `command:"builds"        alias:"bs" description:"List builds data"`
+	AbortBuild   AbortBuildCommand   `command:"abort-build"   alias:"ab" description:"Abort a build"`
+	RerunBuild   RerunBuildCommand   `command:"rerun-build"   alias:"rb" description:"Rerun a build"`
+	ApproveBuild ApproveBuildCommand `command:"approve-build" alias:"apb" description:"Approve or reject a build waiting at an approval step"`
+
+	DownloadArtifact DownloadArtifactCommand `command:"download-artifact" alias:"da" description:"Download the artifacts a build uploaded to the artifact store"`
 
 	TriggerJob TriggerJobCommand `command:"trigger-job" alias:"tj" description:"Start a job in a pipeline"`
 
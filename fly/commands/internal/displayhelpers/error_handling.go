@@ -14,6 +14,11 @@ func PrintDeprecationWarningHeader() {
 	fmt.Fprintf(ui.Stderr, "%s\n", printColorFunc("DEPRECATION WARNING:"))
 }
 
+func PrintPolicyWarningHeader() {
+	printColorFunc := ui.WarnedColor.SprintFunc()
+	fmt.Fprintf(ui.Stderr, "%s\n", printColorFunc("POLICY WARNING:"))
+}
+
 func PrintWarningHeader() {
 	printColorFunc := ui.BlinkingErrorColor.SprintFunc()
 	fmt.Fprintf(ui.Stderr, "%s\n", printColorFunc("WARNING:"))
@@ -32,6 +37,32 @@ func ShowErrors(errorHeader string, errorMessages []string) {
 }
 
 func ShowWarnings(warnings []concourse.ConfigWarning) {
+	var deprecations, policyWarnings []concourse.ConfigWarning
+	for _, warning := range warnings {
+		if warning.Type == "policy" {
+			policyWarnings = append(policyWarnings, warning)
+		} else {
+			deprecations = append(deprecations, warning)
+		}
+	}
+
+	if len(deprecations) > 0 {
+		showDeprecationWarnings(deprecations)
+	}
+
+	if len(policyWarnings) > 0 {
+		fmt.Fprintln(ui.Stderr, "")
+		PrintPolicyWarningHeader()
+
+		for _, warning := range policyWarnings {
+			fmt.Fprintf(ui.Stderr, "  - %s\n", warning.Message)
+		}
+
+		fmt.Fprintln(ui.Stderr, "")
+	}
+}
+
+func showDeprecationWarnings(warnings []concourse.ConfigWarning) {
 	fmt.Fprintln(ui.Stderr, "")
 	PrintDeprecationWarningHeader()
 
@@ -69,6 +69,12 @@ func (atcConfig ATCConfig) Set(yamlTemplateWithParams templatehelpers.YamlTempla
 		})
 	}
 
+	// the existing config has its job matrices expanded already
+	newConfig, err = newConfig.ExpandJobMatrices()
+	if err != nil {
+		return fmt.Errorf("failed to expand job matrices: %w", err)
+	}
+
 	diffExists := diff(existingConfig, newConfig)
 
 	if len(atcConfig.CommandWarnings) > 0 {
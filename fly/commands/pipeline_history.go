@@ -0,0 +1,89 @@
+package commands
+
+import (
+	"errors"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type PipelineHistoryCommand struct {
+	Pipeline flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to list the configuration history of"`
+	Json     bool                     `long:"json" description:"Print command result as JSON"`
+	Team     string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *PipelineHistoryCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *PipelineHistoryCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := GetTeam(target, command.Team)
+
+	versions, found, err := team.PipelineConfigVersions(command.Pipeline.Ref())
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(versions)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "version", Color: color.New(color.Bold)},
+			{Contents: "set by", Color: color.New(color.Bold)},
+			{Contents: "set via", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "created at", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, v := range versions {
+		setByCell := ui.TableCell{Contents: v.SetBy}
+		if v.SetBy == "" {
+			setByCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		buildCell := ui.TableCell{Contents: strconv.Itoa(v.BuildID)}
+		if v.BuildID == 0 {
+			buildCell = ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.Itoa(v.Version)},
+			setByCell,
+			{Contents: v.SetVia},
+			buildCell,
+			{Contents: time.Unix(v.CreatedAt, 0).String()},
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
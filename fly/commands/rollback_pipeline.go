@@ -0,0 +1,93 @@
+package commands
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/vito/go-interact/interact"
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/commands/internal/flaghelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+type RollbackPipelineCommand struct {
+	Pipeline        flaghelpers.PipelineFlag `short:"p" long:"pipeline" required:"true" description:"Pipeline to roll back"`
+	To              int                      `long:"to" required:"true" description:"Configuration version to restore"`
+	SkipInteractive bool                     `short:"n" long:"non-interactive" description:"Skips interactions, uses default values"`
+	Team            string                   `long:"team" description:"Name of the team to which the pipeline belongs, if different from the target default"`
+}
+
+func (command *RollbackPipelineCommand) Validate() error {
+	_, err := command.Pipeline.Validate()
+	return err
+}
+
+func (command *RollbackPipelineCommand) Execute([]string) error {
+	err := command.Validate()
+	if err != nil {
+		return err
+	}
+
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	team := GetTeam(target, command.Team)
+	pipelineRef := command.Pipeline.Ref()
+
+	restoredConfig, err := pipelineConfigVersion(team, pipelineRef, command.To)
+	if err != nil {
+		return err
+	}
+
+	existingConfig, existingConfigVersion, found, err := team.PipelineConfig(pipelineRef)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		return errors.New("pipeline not found")
+	}
+
+	stdout, _ := ui.ForTTY(os.Stdout)
+	if !existingConfig.Diff(stdout, restoredConfig) {
+		fmt.Println("no changes to apply")
+		return nil
+	}
+
+	if !command.SkipInteractive {
+		confirm := false
+		err = interact.NewInteraction(fmt.Sprintf("roll back to version %d?", command.To)).Resolve(&confirm)
+		if err != nil || !confirm {
+			fmt.Println("bailing out")
+			return nil
+		}
+	}
+
+	payload, err := yaml.Marshal(restoredConfig)
+	if err != nil {
+		return err
+	}
+
+	_, _, warnings, err := team.CreateOrUpdatePipelineConfig(pipelineRef, existingConfigVersion, payload, false)
+	if err != nil {
+		return err
+	}
+
+	if len(warnings) > 0 {
+		displayhelpers.ShowWarnings(warnings)
+	}
+
+	fmt.Printf("rolled back to version %d\n", command.To)
+	return nil
+}
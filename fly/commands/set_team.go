@@ -28,10 +28,20 @@ func WireTeamConnectors(command *flags.Command) {
 type SetTeamCommand struct {
 	Team            flaghelpers.TeamFlag `short:"n" long:"team-name" required:"true" description:"The team to create or modify"`
 	SkipInteractive bool                 `long:"non-interactive" description:"Force apply configuration"`
+	MaxInFlight     int                  `long:"max-in-flight" description:"Maximum number of builds the team may run at the same time. 0 means no limit."`
+	Weight          int                  `long:"weight" description:"The team's share of the cluster-wide build capacity relative to other teams."`
 	AuthFlags       skycmd.AuthTeamFlags `group:"Authentication"`
 }
 
 func (command *SetTeamCommand) Validate() ([]concourse.ConfigWarning, error) {
+	if command.MaxInFlight < 0 {
+		return nil, atc.ErrMaxInFlightInvalid
+	}
+
+	if command.Weight < 0 {
+		return nil, atc.ErrWeightInvalid
+	}
+
 	var warnings []concourse.ConfigWarning
 	if warning := atc.ValidateIdentifier(command.Team.Name(), "team"); warning != nil {
 		warnings = append(warnings, concourse.ConfigWarning{
@@ -99,6 +109,13 @@ func (command *SetTeamCommand) Execute([]string) error {
 		}
 	}
 
+	if command.MaxInFlight > 0 || command.Weight > 0 {
+		fmt.Println()
+		fmt.Printf("fair share:\n")
+		fmt.Printf("  max in flight: %s\n", formatTeamLimit(command.MaxInFlight, "unlimited"))
+		fmt.Printf("  weight: %s\n", formatTeamLimit(command.Weight, "1"))
+	}
+
 	if len(warnings) > 0 {
 		displayhelpers.ShowWarnings(warnings)
 	}
@@ -116,7 +133,11 @@ func (command *SetTeamCommand) Execute([]string) error {
 		displayhelpers.Failf("bailing out")
 	}
 
-	team := atc.Team{Auth: authRoles}
+	team := atc.Team{
+		Auth:        authRoles,
+		MaxInFlight: command.MaxInFlight,
+		Weight:      command.Weight,
+	}
 
 	_, created, updated, warnings, err := target.Client().Team(teamName).CreateOrUpdate(team)
 	if err != nil {
@@ -135,3 +156,11 @@ func (command *SetTeamCommand) Execute([]string) error {
 
 	return nil
 }
+
+func formatTeamLimit(value int, unset string) string {
+	if value == 0 {
+		return ui.OffColor.Sprint(unset)
+	}
+
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,59 @@
+package commands
+
+import (
+	"fmt"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/rc"
+)
+
+type SetWebhookCommand struct {
+	Webhook  string   `short:"w" long:"webhook" required:"true" description:"Name of the webhook to create or update"`
+	URL      string   `long:"url" required:"true" description:"URL to which build status changes are posted"`
+	Secret   string   `long:"secret" description:"Secret with which to sign the payloads, sent as an HMAC-SHA256 in the X-Concourse-Signature header"`
+	Pipeline string   `short:"p" long:"pipeline" description:"Only notify about builds of this pipeline"`
+	Events   []string `short:"e" long:"event" description:"Only notify about builds changing to this status. Can be specified multiple times."`
+
+	Team string `long:"team" description:"Name of the team to which the webhook belongs, if different from the target default"`
+}
+
+func (command *SetWebhookCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	webhook := atc.Webhook{
+		Name:     command.Webhook,
+		URL:      command.URL,
+		Secret:   command.Secret,
+		Pipeline: command.Pipeline,
+	}
+
+	for _, event := range command.Events {
+		webhook.Events = append(webhook.Events, atc.BuildStatus(event))
+	}
+
+	err = webhook.Validate()
+	if err != nil {
+		return err
+	}
+
+	_, created, err := GetTeam(target, command.Team).SetWebhook(webhook)
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("webhook '%s' created\n", webhook.Name)
+	} else {
+		fmt.Printf("webhook '%s' updated\n", webhook.Name)
+	}
+
+	return nil
+}
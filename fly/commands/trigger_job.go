@@ -15,9 +15,10 @@ import (
 )
 
 type TriggerJobCommand struct {
-	Job   flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
-	Watch bool                `short:"w" long:"watch" description:"Start watching the build output"`
-	Team  string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Job      flaghelpers.JobFlag `short:"j" long:"job" required:"true" value-name:"PIPELINE/JOB" description:"Name of a job to trigger"`
+	Watch    bool                `short:"w" long:"watch" description:"Start watching the build output"`
+	Team     string              `long:"team" description:"Name of the team to which the job belongs, if different from the target default"`
+	Priority *int                `long:"priority" description:"Priority of the build, overriding the priority configured on the job"`
 }
 
 func (command *TriggerJobCommand) Execute(args []string) error {
@@ -47,7 +48,11 @@ func (command *TriggerJobCommand) Execute(args []string) error {
 		team = target.Team()
 	}
 
-	build, err = team.CreateJobBuild(pipelineRef, jobName)
+	if command.Priority != nil {
+		build, err = team.CreateJobBuildWithPriority(pipelineRef, jobName, *command.Priority)
+	} else {
+		build, err = team.CreateJobBuild(pipelineRef, jobName)
+	}
 	if err != nil {
 		return err
 	} else {
@@ -0,0 +1,155 @@
+package commands
+
+import (
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/commands/internal/displayhelpers"
+	"github.com/concourse/concourse/fly/rc"
+	"github.com/concourse/concourse/fly/ui"
+	"github.com/fatih/color"
+)
+
+type WebhooksCommand struct {
+	Json bool   `long:"json" description:"Print command result as JSON"`
+	Team string `long:"team" description:"Name of the team to list the webhooks of, if different from the target default"`
+}
+
+func (command *WebhooksCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	webhooks, err := GetTeam(target, command.Team).Webhooks()
+	if err != nil {
+		return err
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(webhooks)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "name", Color: color.New(color.Bold)},
+			{Contents: "url", Color: color.New(color.Bold)},
+			{Contents: "pipeline", Color: color.New(color.Bold)},
+			{Contents: "events", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, webhook := range webhooks {
+		events := make([]string, len(webhook.Events))
+		for i, event := range webhook.Events {
+			events[i] = string(event)
+		}
+
+		pipelineCell := ui.TableCell{Contents: webhook.Pipeline}
+		if webhook.Pipeline == "" {
+			pipelineCell = ui.TableCell{Contents: "all", Color: ui.OffColor}
+		}
+
+		eventsCell := ui.TableCell{Contents: strings.Join(events, ",")}
+		if len(events) == 0 {
+			eventsCell = ui.TableCell{Contents: "all", Color: ui.OffColor}
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: webhook.Name},
+			{Contents: webhook.URL},
+			pipelineCell,
+			eventsCell,
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+type WebhookDeliveriesCommand struct {
+	Webhook string `short:"w" long:"webhook" required:"true" description:"Name of the webhook"`
+	Count   int    `short:"c" long:"count" default:"50" description:"Number of deliveries you want to limit the return to"`
+	Json    bool   `long:"json" description:"Print command result as JSON"`
+	Team    string `long:"team" description:"Name of the team to which the webhook belongs, if different from the target default"`
+}
+
+func (command *WebhookDeliveriesCommand) Execute([]string) error {
+	target, err := rc.LoadTarget(Fly.Target, Fly.Verbose)
+	if err != nil {
+		return err
+	}
+
+	err = target.Validate()
+	if err != nil {
+		return err
+	}
+
+	deliveries, found, err := GetTeam(target, command.Team).WebhookDeliveries(command.Webhook, command.Count)
+	if err != nil {
+		return err
+	}
+
+	if !found {
+		displayhelpers.Failf("webhook '%s' not found", command.Webhook)
+	}
+
+	if command.Json {
+		return displayhelpers.JsonPrint(deliveries)
+	}
+
+	table := ui.Table{
+		Headers: ui.TableRow{
+			{Contents: "id", Color: color.New(color.Bold)},
+			{Contents: "time", Color: color.New(color.Bold)},
+			{Contents: "build", Color: color.New(color.Bold)},
+			{Contents: "event", Color: color.New(color.Bold)},
+			{Contents: "state", Color: color.New(color.Bold)},
+			{Contents: "attempts", Color: color.New(color.Bold)},
+			{Contents: "response", Color: color.New(color.Bold)},
+			{Contents: "error", Color: color.New(color.Bold)},
+		},
+	}
+
+	for _, delivery := range deliveries {
+		responseCell := ui.TableCell{Contents: "n/a", Color: ui.OffColor}
+		if delivery.ResponseStatus != 0 {
+			responseCell = statusCell(delivery.ResponseStatus)
+		}
+
+		table.Data = append(table.Data, ui.TableRow{
+			{Contents: strconv.FormatInt(delivery.ID, 10)},
+			{Contents: time.Unix(delivery.CreatedAt, 0).Format(timeDateLayout)},
+			{Contents: strconv.Itoa(delivery.BuildID)},
+			ui.BuildStatusCell(delivery.Status),
+			deliveryStateCell(delivery.State),
+			{Contents: strconv.Itoa(delivery.Attempts)},
+			responseCell,
+			optionalCell(delivery.Error),
+		})
+	}
+
+	return table.Render(os.Stdout, Fly.PrintTableHeaders)
+}
+
+func deliveryStateCell(state atc.WebhookDeliveryState) ui.TableCell {
+	cell := ui.TableCell{Contents: string(state)}
+
+	switch state {
+	case atc.WebhookDeliveryDelivered:
+		cell.Color = ui.SucceededColor
+	case atc.WebhookDeliveryFailed:
+		cell.Color = ui.FailedColor
+	case atc.WebhookDeliveryPending:
+		cell.Color = ui.PendingColor
+	}
+
+	return cell
+}
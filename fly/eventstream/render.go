@@ -38,7 +38,7 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 		switch e := ev.(type) {
 		case event.Log:
 			dstImpl.SetTimestamp(e.Time)
-			fmt.Fprintf(dstImpl, "%s", e.Payload)
+			fmt.Fprintf(dstImpl, "%s", servicePrefixed(e.Origin.Service, e.Payload))
 
 		case event.SelectedWorker:
 			dstImpl.SetTimestamp(e.Time)
@@ -63,12 +63,17 @@ func Render(dst io.Writer, src eventstream.EventStream, options RenderOptions) i
 			dstImpl.SetTimestamp(0)
 			fmt.Fprintf(dstImpl, "%s\n", errCol(e.Message))
 
+		case event.Warning:
+			warnCol := ui.WarnedColor.SprintFunc()
+			dstImpl.SetTimestamp(e.Time)
+			fmt.Fprintf(dstImpl, "%s\n", warnCol("WARNING: "+e.Message))
+
 		case event.Status:
 			dstImpl.SetTimestamp(e.Time)
 			var printColor *color.Color
 
 			switch e.Status {
-			case "started":
+			case "started", "waiting-approval":
 				continue
 			case "succeeded":
 				printColor = ui.SucceededColor
@@ -111,3 +116,22 @@ func isEventParseError(err error) bool {
 	}
 	return false
 }
+
+// servicePrefixed prefixes each line of the output of a task's service with
+// the name of the service, to tell it apart from the output of the task.
+func servicePrefixed(service string, payload string) string {
+	if service == "" {
+		return payload
+	}
+
+	prefix := fmt.Sprintf("\x1b[1m[%s]\x1b[0m ", service)
+
+	lines := strings.SplitAfter(payload, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+
+	return strings.Join(lines, "")
+}
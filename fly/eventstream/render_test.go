@@ -63,6 +63,20 @@ var _ = Describe("V1.0 Renderer", func() {
 			Expect(out).To(gbytes.Say("hello"))
 		})
 
+		Context("and it originates from a service of the task", func() {
+			BeforeEach(func() {
+				receivedEvents <- event.Log{
+					Payload: "starting\nready\n",
+					Time:    time.Now().Unix(),
+					Origin:  event.Origin{Service: "postgres"},
+				}
+			})
+
+			It("prefixes each line with the name of the service", func() {
+				Expect(out).To(gbytes.Say(`hello\x1b\[1m\[postgres\]\x1b\[0m starting\n\x1b\[1m\[postgres\]\x1b\[0m ready\n`))
+			})
+		})
+
 		Context("and time configuration is enabled", func() {
 			BeforeEach(func() {
 				options.ShowTimestamp = true
@@ -97,6 +111,18 @@ var _ = Describe("V1.0 Renderer", func() {
 		})
 	})
 
+	Context("when a Warning event is received", func() {
+		BeforeEach(func() {
+			receivedEvents <- event.Warning{
+				Message: "careful now",
+			}
+		})
+
+		It("prints its message in bold yellow, followed by a linebreak", func() {
+			Expect(out.Contents()).To(ContainSubstring(ui.WarnedColor.SprintFunc()("WARNING: careful now") + "\n"))
+		})
+	})
+
 	Context("when an InitializeTask event is received", func() {
 		BeforeEach(func() {
 			receivedEvents <- event.InitializeTask{
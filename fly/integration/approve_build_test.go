@@ -0,0 +1,104 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("ApproveBuild", func() {
+	var expectedBuild = atc.Build{
+		ID:      23,
+		Name:    "42",
+		Status:  "waiting-approval",
+		JobName: "myjob",
+		APIURL:  "api/v1/builds/123",
+	}
+
+	BeforeEach(func() {
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, expectedBuild),
+			),
+		)
+	})
+
+	Context("when approving", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("approves the build", func() {
+			Expect(func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("build successfully approved"))
+			}).To(Change(func() int {
+				return len(atcServer.ReceivedRequests())
+			}).By(3))
+		})
+	})
+
+	Context("when rejecting", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/reject"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("rejects the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23", "--reject")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("build successfully rejected"))
+		})
+	})
+
+	Context("when the build is not waiting for approval", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/23/approve"),
+					ghttp.RespondWith(http.StatusConflict, ""),
+				),
+			)
+		})
+
+		It("returns a helpful error message", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "approve-build", "-b", "23")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("error: build is not waiting for approval"))
+		})
+	})
+})
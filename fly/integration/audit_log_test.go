@@ -0,0 +1,232 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"strconv"
+	"time"
+
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("audit-log", func() {
+		var (
+			flyCmd *exec.Cmd
+			events []atc.AuditEvent
+		)
+
+		BeforeEach(func() {
+			events = []atc.AuditEvent{
+				{
+					ID:           2,
+					Action:       "PausePipeline",
+					UserName:     "some-user",
+					TeamName:     "main",
+					PipelineName: "some-pipeline",
+					SourceIP:     "1.2.3.4",
+					Status:       200,
+					CreatedAt:    200,
+				},
+				{
+					ID:        1,
+					Action:    "SetTeam",
+					TeamName:  "main",
+					Status:    403,
+					CreatedAt: 100,
+				},
+			}
+		})
+
+		Context("with no filters", func() {
+			BeforeEach(func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/audit_events", "limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, events),
+					),
+				)
+			})
+
+			It("prints the events in a table", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "user", Color: color.New(color.Bold)},
+						{Contents: "action", Color: color.New(color.Bold)},
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "object", Color: color.New(color.Bold)},
+						{Contents: "source ip", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: time.Unix(200, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "some-user"},
+							{Contents: "PausePipeline"},
+							{Contents: "some-pipeline"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "1.2.3.4"},
+							{Contents: "200"},
+						},
+						{
+							{Contents: "1"},
+							{Contents: time.Unix(100, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "SetTeam"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "403", Color: color.New(color.FgRed)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("with filters", func() {
+			BeforeEach(func() {
+				since := time.Date(2020, 1, 1, 0, 0, 0, 0, time.Local)
+				until := time.Date(2020, 1, 2, 0, 0, 0, 0, time.Local)
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log",
+					"--team", "other-team",
+					"--action", "PausePipeline",
+					"--user", "some-user",
+					"--pipeline", "some-pipeline",
+					"--since", "2020-01-01 00:00:00",
+					"--until", "2020-01-02 00:00:00",
+					"-c", "10",
+					"--json",
+				)
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/other-team/audit_events"),
+						ghttp.VerifyForm(map[string][]string{
+							"action":   {"PausePipeline"},
+							"user":     {"some-user"},
+							"pipeline": {"some-pipeline"},
+							"since":    {strconv.FormatInt(since.Unix(), 10)},
+							"until":    {strconv.FormatInt(until.Unix(), 10)},
+							"limit":    {"10"},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, events[:1]),
+					),
+				)
+			})
+
+			It("passes them along and prints the events as json", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out.Contents()).To(MatchJSON(`[
+					{
+						"id": 2,
+						"action": "PausePipeline",
+						"user_name": "some-user",
+						"team_name": "main",
+						"pipeline_name": "some-pipeline",
+						"source_ip": "1.2.3.4",
+						"status": 200,
+						"created_at": 200
+					}
+				]`))
+			})
+		})
+
+		Context("with --all-teams", func() {
+			BeforeEach(func() {
+				events = append(events, atc.AuditEvent{
+					ID:        3,
+					Action:    "LandWorker",
+					UserName:  "some-user",
+					Object:    "worker:some-worker",
+					Status:    200,
+					CreatedAt: 300,
+				})
+
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log", "--all-teams")
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/audit_events", "limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, events[2:]),
+					),
+				)
+			})
+
+			It("prints every event along with its team", func() {
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "user", Color: color.New(color.Bold)},
+						{Contents: "action", Color: color.New(color.Bold)},
+						{Contents: "team", Color: color.New(color.Bold)},
+						{Contents: "pipeline", Color: color.New(color.Bold)},
+						{Contents: "object", Color: color.New(color.Bold)},
+						{Contents: "source ip", Color: color.New(color.Bold)},
+						{Contents: "status", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "3"},
+							{Contents: time.Unix(300, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "some-user"},
+							{Contents: "LandWorker"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "worker:some-worker"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+							{Contents: "200"},
+						},
+					},
+				}))
+			})
+
+			It("errors when a team is given too", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log", "--all-teams", "--team", "other-team")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("Cannot specify both --all-teams and --team"))
+			})
+		})
+
+		Context("when --since is malformed", func() {
+			It("errors", func() {
+				flyCmd = exec.Command(flyPath, "-t", targetName, "audit-log", "--since", "yesterday")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("Since time should be in the format"))
+			})
+		})
+	})
+})
@@ -0,0 +1,112 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"os/exec"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+)
+
+var _ = Describe("DownloadArtifact", func() {
+	var outputDir string
+
+	BeforeEach(func() {
+		var err error
+		outputDir, err = ioutil.TempDir("", "fly-download-artifact")
+		Expect(err).NotTo(HaveOccurred())
+
+		atcServer.AppendHandlers(
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Build{ID: 23, Name: "42"}),
+			),
+			ghttp.CombineHandlers(
+				ghttp.VerifyRequest("GET", "/api/v1/builds/23/stored_artifacts"),
+				ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.StoredArtifact{
+					{ID: 1, Name: "some-output/app.tgz", Size: 11},
+					{ID: 2, Name: "some-other-output", Size: 13},
+				}),
+			),
+		)
+	})
+
+	AfterEach(func() {
+		os.RemoveAll(outputDir)
+	})
+
+	Context("when no artifact is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/stored_artifacts/1"),
+					ghttp.RespondWith(http.StatusOK, "some-tarball"),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/stored_artifacts/2"),
+					ghttp.RespondWith(http.StatusOK, "other-tarball"),
+				),
+			)
+		})
+
+		It("downloads every artifact of the build", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-o", outputDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("downloaded some-output/app.tgz"))
+			Expect(sess.Out).To(gbytes.Say("downloaded some-other-output"))
+
+			Expect(ioutil.ReadFile(filepath.Join(outputDir, "some-output", "app.tgz.tgz"))).To(Equal([]byte("some-tarball")))
+			Expect(ioutil.ReadFile(filepath.Join(outputDir, "some-other-output.tgz"))).To(Equal([]byte("other-tarball")))
+		})
+	})
+
+	Context("when an artifact is specified", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/builds/23/stored_artifacts/2"),
+					ghttp.RespondWith(http.StatusOK, "other-tarball"),
+				),
+			)
+		})
+
+		It("downloads only that artifact", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-a", "some-other-output", "-o", outputDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(filepath.Join(outputDir, "some-output")).ToNot(BeAnExistingFile())
+			Expect(ioutil.ReadFile(filepath.Join(outputDir, "some-other-output.tgz"))).To(Equal([]byte("other-tarball")))
+		})
+	})
+
+	Context("when the specified artifact does not exist", func() {
+		It("errors", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "download-artifact", "-b", "23", "-a", "bogus", "-o", outputDir)
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+
+			Eventually(sess).Should(gexec.Exit(1))
+
+			Expect(sess.Err).To(gbytes.Say("build has no artifact named 'bogus'"))
+		})
+	})
+})
@@ -0,0 +1,168 @@
+package integration_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+	"sigs.k8s.io/yaml"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+var _ = Describe("Pipeline config history", func() {
+	var (
+		oldConfig atc.Config
+		newConfig atc.Config
+	)
+
+	BeforeEach(func() {
+		oldConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+			},
+		}
+
+		newConfig = atc.Config{
+			Jobs: atc.JobConfigs{
+				{Name: "some-job"},
+				{Name: "some-other-job"},
+			},
+		}
+	})
+
+	Describe("pipeline-history", func() {
+		Context("when the pipeline exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/versions"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.PipelineConfigVersion{
+							{Version: 2, SetVia: "set_pipeline", BuildID: 42, CreatedAt: 2},
+							{Version: 1, SetBy: "some-user", SetVia: "fly", CreatedAt: 1},
+						}),
+					),
+				)
+			})
+
+			It("prints the versions in a table", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "version", Color: color.New(color.Bold)},
+						{Contents: "set by", Color: color.New(color.Bold)},
+						{Contents: "set via", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "created at", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{{Contents: "2"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: "set_pipeline"}, {Contents: "42"}, {Contents: time.Unix(2, 0).String()}},
+						{{Contents: "1"}, {Contents: "some-user"}, {Contents: "fly"}, {Contents: "n/a", Color: color.New(color.Faint)}, {Contents: time.Unix(1, 0).String()}},
+					},
+				}))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/versions"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "pipeline-history", "-p", "some-pipeline")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("error: pipeline not found"))
+			})
+		})
+	})
+
+	Describe("diff-pipeline", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/versions/1"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigVersion{Version: 1, Config: &oldConfig}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/versions/2"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigVersion{Version: 2, Config: &newConfig}),
+				),
+			)
+		})
+
+		It("prints the differences between the two versions", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "diff-pipeline", "-p", "some-pipeline", "--from", "1", "--to", "2")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("job some-other-job has been added"))
+		})
+	})
+
+	Describe("rollback-pipeline", func() {
+		var receivedConfig atc.Config
+
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config/versions/1"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.PipelineConfigVersion{Version: 1, Config: &oldConfig}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, atc.ConfigResponse{Config: newConfig}, http.Header{atc.ConfigVersionHeader: {"42"}}),
+				),
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/teams/main/pipelines/some-pipeline/config"),
+					ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "42"),
+					func(w http.ResponseWriter, r *http.Request) {
+						body, err := ioutil.ReadAll(r.Body)
+						Expect(err).NotTo(HaveOccurred())
+
+						err = yaml.Unmarshal(body, &receivedConfig)
+						Expect(err).NotTo(HaveOccurred())
+
+						w.WriteHeader(http.StatusOK)
+						w.Write([]byte(`{}`))
+					},
+				),
+			)
+		})
+
+		It("restores the old config", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "rollback-pipeline", "-p", "some-pipeline", "--to", "1", "-n")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(gbytes.Say("job some-other-job has been removed"))
+			Expect(sess.Out).To(gbytes.Say("rolled back to version 1"))
+			Expect(receivedConfig).To(Equal(oldConfig))
+		})
+	})
+})
@@ -1309,6 +1309,44 @@ this is super secure
 				})
 			})
 
+			Context("when the server returns policy warnings", func() {
+				BeforeEach(func() {
+					path, err := atc.Routes.CreatePathForRoute(atc.SaveConfig, rata.Params{"pipeline_name": "awesome-pipeline", "team_name": "main"})
+					Expect(err).NotTo(HaveOccurred())
+
+					atcServer.RouteToHandler("PUT", path, ghttp.CombineHandlers(
+						ghttp.VerifyHeaderKV(atc.ConfigVersionHeader, "42"),
+						ghttp.RespondWith(http.StatusCreated, `{"warnings":[
+							{"type":"deprecation","message":"warning-1"},
+							{"type":"policy","message":"privileged tasks will soon be denied"}
+						]}`),
+					))
+					config.Resources[0].Name = "updated-name"
+				})
+
+				It("succeeds and prints them apart from deprecations", func() {
+					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name())
+
+					stdin, err := flyCmd.StdinPipe()
+					Expect(err).NotTo(HaveOccurred())
+
+					sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+					Expect(err).NotTo(HaveOccurred())
+
+					Eventually(sess).Should(gbytes.Say(`apply configuration\? \[yN\]: `))
+					yes(stdin)
+
+					Eventually(sess.Err).Should(gbytes.Say("DEPRECATION WARNING:"))
+					Eventually(sess.Err).Should(gbytes.Say("  - warning-1"))
+					Eventually(sess.Err).Should(gbytes.Say("POLICY WARNING:"))
+					Eventually(sess.Err).Should(gbytes.Say("  - privileged tasks will soon be denied"))
+					Eventually(sess).Should(gbytes.Say("pipeline created!"))
+
+					<-sess.Exited
+					Expect(sess.ExitCode()).To(Equal(0))
+				})
+			})
+
 			Context("when there are no pipeline changes", func() {
 				It("does not ask for user interaction to apply changes", func() {
 					flyCmd := exec.Command(flyPath, "-t", targetName, "set-pipeline", "-p", "awesome-pipeline", "-c", configFile.Name())
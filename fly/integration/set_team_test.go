@@ -526,6 +526,24 @@ var _ = Describe("Fly CLI", func() {
 				})
 			})
 
+			Context("Setting a fair share", func() {
+				BeforeEach(func() {
+					cmdParams = []string{"--local-user", "brock-samson", "--max-in-flight", "10", "--weight", "2"}
+				})
+
+				It("shows the fair share", func() {
+					sess, err := gexec.Start(flyCmd, ginkgo.GinkgoWriter, ginkgo.GinkgoWriter)
+					Expect(err).ToNot(HaveOccurred())
+
+					Eventually(sess.Out).Should(gbytes.Say("setting team: venture"))
+					Eventually(sess.Out).Should(gbytes.Say("fair share:"))
+					Eventually(sess.Out).Should(gbytes.Say("max in flight: 10"))
+					Eventually(sess.Out).Should(gbytes.Say("weight: 2"))
+
+					Eventually(sess).Should(gexec.Exit(1))
+				})
+			})
+
 			Context("Setting cf auth", func() {
 				BeforeEach(func() {
 					cmdParams = []string{"--cf-org", "myorg-1", "--cf-space", "myorg-2:myspace", "--cf-user", "my-username", "--cf-space-guid", "myspace-guid"}
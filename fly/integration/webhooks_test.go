@@ -0,0 +1,270 @@
+package integration_test
+
+import (
+	"net/http"
+	"os/exec"
+	"time"
+
+	"github.com/fatih/color"
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/gbytes"
+	"github.com/onsi/gomega/gexec"
+	"github.com/onsi/gomega/ghttp"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/fly/ui"
+)
+
+var _ = Describe("Fly CLI", func() {
+	Describe("webhooks", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/main/webhooks"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.Webhook{
+						{
+							Name:     "some-webhook",
+							URL:      "https://example.com/hook",
+							Pipeline: "some-pipeline",
+							Events:   []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+						},
+						{
+							Name: "other-webhook",
+							URL:  "https://example.com/other-hook",
+						},
+					}),
+				),
+			)
+		})
+
+		It("prints the webhooks in a table", func() {
+			flyCmd := exec.Command(flyPath, "-t", targetName, "webhooks")
+
+			sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+			Expect(err).NotTo(HaveOccurred())
+			Eventually(sess).Should(gexec.Exit(0))
+
+			Expect(sess.Out).To(PrintTable(ui.Table{
+				Headers: ui.TableRow{
+					{Contents: "name", Color: color.New(color.Bold)},
+					{Contents: "url", Color: color.New(color.Bold)},
+					{Contents: "pipeline", Color: color.New(color.Bold)},
+					{Contents: "events", Color: color.New(color.Bold)},
+				},
+				Data: []ui.TableRow{
+					{
+						{Contents: "some-webhook"},
+						{Contents: "https://example.com/hook"},
+						{Contents: "some-pipeline"},
+						{Contents: "failed,errored"},
+					},
+					{
+						{Contents: "other-webhook"},
+						{Contents: "https://example.com/other-hook"},
+						{Contents: "all", Color: color.New(color.Faint)},
+						{Contents: "all", Color: color.New(color.Faint)},
+					},
+				},
+			}))
+		})
+	})
+
+	Describe("set-webhook", func() {
+		Context("with a valid webhook", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/other-team/webhooks/some-webhook"),
+						ghttp.VerifyJSONRepresenting(atc.Webhook{
+							Name:     "some-webhook",
+							URL:      "https://example.com/hook",
+							Secret:   "super-secret",
+							Pipeline: "some-pipeline",
+							Events:   []atc.BuildStatus{atc.StatusFailed, atc.StatusErrored},
+						}),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Webhook{Name: "some-webhook"}),
+					),
+				)
+			})
+
+			It("saves the webhook", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-webhook",
+					"-w", "some-webhook",
+					"--url", "https://example.com/hook",
+					"--secret", "super-secret",
+					"-p", "some-pipeline",
+					"-e", "failed",
+					"-e", "errored",
+					"--team", "other-team",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("webhook 'some-webhook' created"))
+			})
+		})
+
+		Context("with an unknown event", func() {
+			It("errors without contacting the server", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "set-webhook",
+					"-w", "some-webhook",
+					"--url", "https://example.com/hook",
+					"-e", "exploded",
+				)
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("invalid webhook event 'exploded'"))
+				for _, request := range atcServer.ReceivedRequests() {
+					Expect(request.Method).ToNot(Equal("PUT"))
+				}
+			})
+		})
+	})
+
+	Describe("destroy-webhook", func() {
+		Context("when the webhook exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/webhooks/some-webhook"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("destroys it", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "destroy-webhook", "-w", "some-webhook", "-n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("webhook 'some-webhook' destroyed"))
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/main/webhooks/some-webhook"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("says so", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "destroy-webhook", "-w", "some-webhook", "-n")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(gbytes.Say("webhook 'some-webhook' does not exist"))
+			})
+		})
+	})
+
+	Describe("webhook-deliveries", func() {
+		Context("when the webhook exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/webhooks/some-webhook/deliveries", "limit=50"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.WebhookDelivery{
+							{
+								ID:             2,
+								BuildID:        42,
+								Status:         atc.StatusFailed,
+								State:          atc.WebhookDeliveryPending,
+								Attempts:       1,
+								ResponseStatus: 502,
+								Error:          "bad gateway",
+								CreatedAt:      200,
+							},
+							{
+								ID:             1,
+								BuildID:        42,
+								Status:         atc.StatusStarted,
+								State:          atc.WebhookDeliveryDelivered,
+								Attempts:       1,
+								ResponseStatus: 200,
+								CreatedAt:      100,
+							},
+						}),
+					),
+				)
+			})
+
+			It("prints the deliveries in a table", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "webhook-deliveries", "-w", "some-webhook")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(0))
+
+				Expect(sess.Out).To(PrintTable(ui.Table{
+					Headers: ui.TableRow{
+						{Contents: "id", Color: color.New(color.Bold)},
+						{Contents: "time", Color: color.New(color.Bold)},
+						{Contents: "build", Color: color.New(color.Bold)},
+						{Contents: "event", Color: color.New(color.Bold)},
+						{Contents: "state", Color: color.New(color.Bold)},
+						{Contents: "attempts", Color: color.New(color.Bold)},
+						{Contents: "response", Color: color.New(color.Bold)},
+						{Contents: "error", Color: color.New(color.Bold)},
+					},
+					Data: []ui.TableRow{
+						{
+							{Contents: "2"},
+							{Contents: time.Unix(200, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "42"},
+							{Contents: "failed", Color: color.New(color.FgRed)},
+							{Contents: "pending", Color: color.New(color.FgWhite)},
+							{Contents: "1"},
+							{Contents: "502", Color: color.New(color.FgRed)},
+							{Contents: "bad gateway"},
+						},
+						{
+							{Contents: "1"},
+							{Contents: time.Unix(100, 0).Format("2006-01-02@15:04:05-0700")},
+							{Contents: "42"},
+							{Contents: "started", Color: color.New(color.FgYellow)},
+							{Contents: "delivered", Color: color.New(color.FgGreen)},
+							{Contents: "1"},
+							{Contents: "200"},
+							{Contents: "n/a", Color: color.New(color.Faint)},
+						},
+					},
+				}))
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/main/webhooks/some-webhook/deliveries"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				flyCmd := exec.Command(flyPath, "-t", targetName, "webhook-deliveries", "-w", "some-webhook")
+
+				sess, err := gexec.Start(flyCmd, GinkgoWriter, GinkgoWriter)
+				Expect(err).NotTo(HaveOccurred())
+				Eventually(sess).Should(gexec.Exit(1))
+
+				Expect(sess.Err).To(gbytes.Say("webhook 'some-webhook' not found"))
+			})
+		})
+	})
+})
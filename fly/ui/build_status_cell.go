@@ -11,6 +11,8 @@ func BuildStatusCell(status atc.BuildStatus) TableCell {
 		statusCell.Color = PendingColor
 	case atc.StatusStarted:
 		statusCell.Color = StartedColor
+	case atc.StatusWaitingApproval:
+		statusCell.Color = PendingColor
 	case atc.StatusSucceeded:
 		statusCell.Color = SucceededColor
 	case atc.StatusFailed:
@@ -7,6 +7,7 @@ var StartedColor = color.New(color.FgYellow)
 var SucceededColor = color.New(color.FgGreen)
 var FailedColor = color.New(color.FgRed)
 var ErroredColor = color.New(color.FgRed, color.Bold)
+var WarnedColor = color.New(color.FgYellow, color.Bold)
 var BlinkingErrorColor = color.New(color.BlinkSlow, color.FgWhite, color.BgRed, color.Bold)
 var AbortedColor = color.New(color.FgMagenta)
 var PausedColor = color.New(color.FgCyan)
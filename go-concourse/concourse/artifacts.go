@@ -50,3 +50,40 @@ func (team *team) GetArtifact(artifactID int) (io.ReadCloser, error) {
 
 	return response.Result.(io.ReadCloser), nil
 }
+
+func (client *client) ListBuildStoredArtifacts(buildID string) ([]atc.StoredArtifact, error) {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	var artifacts []atc.StoredArtifact
+
+	err := client.connection.Send(internal.Request{
+		RequestName: atc.ListBuildStoredArtifacts,
+		Params:      params,
+	}, &internal.Response{
+		Result: &artifacts,
+	})
+
+	return artifacts, err
+}
+
+func (client *client) GetBuildStoredArtifact(buildID string, artifactID int) (io.ReadCloser, error) {
+	params := rata.Params{
+		"build_id":    buildID,
+		"artifact_id": strconv.Itoa(artifactID),
+	}
+
+	response := internal.Response{}
+	err := client.connection.Send(internal.Request{
+		RequestName:        atc.GetBuildStoredArtifact,
+		Params:             params,
+		ReturnResponseBody: true,
+	}, &response)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return response.Result.(io.ReadCloser), nil
+}
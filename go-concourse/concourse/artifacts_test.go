@@ -86,4 +86,78 @@ var _ = Describe("ArtifactRepository", func() {
 			})
 		})
 	})
+
+	Describe("ListBuildStoredArtifacts", func() {
+		Context("when the build has stored artifacts", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/stored_artifacts"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, []atc.StoredArtifact{
+							{ID: 17, Name: "some-output/app.tgz", Size: 1024, CreatedAt: 42},
+						}),
+					),
+				)
+			})
+
+			It("returns them", func() {
+				artifacts, err := client.ListBuildStoredArtifacts("123")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(artifacts).To(Equal([]atc.StoredArtifact{
+					{ID: 17, Name: "some-output/app.tgz", Size: 1024, CreatedAt: 42},
+				}))
+			})
+		})
+
+		Context("when listing the artifacts fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/stored_artifacts"),
+						ghttp.RespondWith(http.StatusInternalServerError, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.ListBuildStoredArtifacts("123")
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("GetBuildStoredArtifact", func() {
+		Context("when the artifact exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/stored_artifacts/17"),
+						ghttp.RespondWith(http.StatusOK, "some-tarball"),
+					),
+				)
+			})
+
+			It("returns the contents", func() {
+				contents, err := client.GetBuildStoredArtifact("123", 17)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(ioutil.ReadAll(contents)).To(Equal([]byte("some-tarball")))
+			})
+		})
+
+		Context("when the artifact does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/builds/123/stored_artifacts/17"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("errors", func() {
+				_, err := client.GetBuildStoredArtifact("123", 17)
+				Expect(err).To(HaveOccurred())
+			})
+		})
+	})
 })
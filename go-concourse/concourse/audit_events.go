@@ -0,0 +1,74 @@
+package concourse
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+type AuditEventFilter struct {
+	Action   string
+	User     string
+	Pipeline string
+	Since    time.Time
+	Until    time.Time
+}
+
+func (team *team) AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	return listAuditEvents(team.connection, atc.ListAuditEvents, rata.Params{"team_name": team.Name()}, filter, page)
+}
+
+func (client *client) AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	return listAuditEvents(client.connection, atc.ListAllAuditEvents, nil, filter, page)
+}
+
+func listAuditEvents(connection internal.Connection, requestName string, params rata.Params, filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error) {
+	var events []atc.AuditEvent
+
+	headers := http.Header{}
+
+	query := page.QueryParams()
+
+	if filter.Action != "" {
+		query.Add(atc.AuditEventQueryAction, filter.Action)
+	}
+
+	if filter.User != "" {
+		query.Add(atc.AuditEventQueryUser, filter.User)
+	}
+
+	if filter.Pipeline != "" {
+		query.Add(atc.AuditEventQueryPipeline, filter.Pipeline)
+	}
+
+	if !filter.Since.IsZero() {
+		query.Add(atc.AuditEventQuerySince, strconv.FormatInt(filter.Since.Unix(), 10))
+	}
+
+	if !filter.Until.IsZero() {
+		query.Add(atc.AuditEventQueryUntil, strconv.FormatInt(filter.Until.Unix(), 10))
+	}
+
+	err := connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+		Query:       query,
+	}, &internal.Response{
+		Result:  &events,
+		Headers: &headers,
+	})
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	pagination, err := paginationFromHeaders(headers)
+	if err != nil {
+		return nil, Pagination{}, err
+	}
+
+	return events, pagination, nil
+}
@@ -0,0 +1,175 @@
+package concourse_test
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Audit Events", func() {
+	Describe("team.AuditEvents", func() {
+		expectedURL := "/api/v1/teams/some-team/audit_events"
+
+		var (
+			filter concourse.AuditEventFilter
+			page   concourse.Page
+
+			expectedEvents []atc.AuditEvent
+
+			events     []atc.AuditEvent
+			pagination concourse.Pagination
+			teamErr    error
+		)
+
+		BeforeEach(func() {
+			filter = concourse.AuditEventFilter{}
+			page = concourse.Page{}
+
+			expectedEvents = []atc.AuditEvent{
+				{
+					ID:           2,
+					Action:       "PausePipeline",
+					UserName:     "some-user",
+					TeamName:     "some-team",
+					PipelineName: "some-pipeline",
+					Status:       200,
+					CreatedAt:    100,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			events, pagination, teamErr = team.AuditEvents(filter, page)
+		})
+
+		Context("when no filter is given", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				Expect(teamErr).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when a filter and page are given", func() {
+			BeforeEach(func() {
+				filter = concourse.AuditEventFilter{
+					Action:   "PausePipeline",
+					User:     "some-user",
+					Pipeline: "some-pipeline",
+					Since:    time.Unix(100, 0),
+					Until:    time.Unix(200, 0),
+				}
+				page = concourse.Page{To: 10, Limit: 1}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "action=PausePipeline&limit=1&pipeline=some-pipeline&since=100&to=10&until=200&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents, http.Header{
+							"Link": []string{
+								`<https://example.com/api/v1/teams/some-team/audit_events?to=1&limit=1>; rel="next"`,
+								`<https://example.com/api/v1/teams/some-team/audit_events?from=3&limit=1>; rel="previous"`,
+							},
+						}),
+					),
+				)
+			})
+
+			It("passes them along and returns the pagination", func() {
+				Expect(teamErr).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+				Expect(pagination).To(Equal(concourse.Pagination{
+					Next:     &concourse.Page{To: 1, Limit: 1},
+					Previous: &concourse.Page{From: 3, Limit: 1},
+				}))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(teamErr).To(HaveOccurred())
+			})
+		})
+	})
+
+	Describe("client.AuditEvents", func() {
+		expectedURL := "/api/v1/audit_events"
+
+		var (
+			expectedEvents []atc.AuditEvent
+
+			events    []atc.AuditEvent
+			clientErr error
+		)
+
+		BeforeEach(func() {
+			expectedEvents = []atc.AuditEvent{
+				{
+					ID:        2,
+					Action:    "LandWorker",
+					UserName:  "some-user",
+					Object:    "worker:some-worker",
+					Status:    200,
+					CreatedAt: 100,
+				},
+			}
+		})
+
+		JustBeforeEach(func() {
+			events, _, clientErr = client.AuditEvents(concourse.AuditEventFilter{User: "some-user"}, concourse.Page{Limit: 1})
+		})
+
+		Context("when the request succeeds", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL, "limit=1&user=some-user"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedEvents),
+					),
+				)
+			})
+
+			It("returns the events", func() {
+				Expect(clientErr).NotTo(HaveOccurred())
+				Expect(events).To(Equal(expectedEvents))
+			})
+		})
+
+		Context("when the request fails", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusForbidden, ""),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				Expect(clientErr).To(HaveOccurred())
+			})
+		})
+	})
+})
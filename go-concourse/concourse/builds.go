@@ -3,8 +3,11 @@ package concourse
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 
 	"github.com/concourse/concourse/atc"
 	"github.com/concourse/concourse/go-concourse/concourse/internal"
@@ -54,6 +57,32 @@ func (team *team) CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (a
 	return build, err
 }
 
+func (team *team) CreateJobBuildWithPriority(pipelineRef atc.PipelineRef, jobName string, priority int) (atc.Build, error) {
+	params := rata.Params{
+		"job_name":      jobName,
+		"pipeline_name": pipelineRef.Name,
+		"team_name":     team.Name(),
+	}
+
+	queryParams := pipelineRef.QueryParams()
+	if queryParams == nil {
+		queryParams = url.Values{}
+	}
+
+	queryParams.Set("priority", strconv.Itoa(priority))
+
+	var build atc.Build
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.CreateJobBuild,
+		Params:      params,
+		Query:       queryParams,
+	}, &internal.Response{
+		Result: &build,
+	})
+
+	return build, err
+}
+
 func (team *team) RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error) {
 	params := rata.Params{
 		"build_name":    buildName,
@@ -160,6 +189,37 @@ func (client *client) AbortBuild(buildID string) error {
 	}, nil)
 }
 
+// ErrBuildNotWaitingForApproval is returned when approving or rejecting a
+// build which is not paused at an approval step.
+var ErrBuildNotWaitingForApproval = errors.New("build is not waiting for approval")
+
+func (client *client) ApproveBuild(buildID string) error {
+	return client.decideApproval(atc.ApproveBuild, buildID)
+}
+
+func (client *client) RejectBuild(buildID string) error {
+	return client.decideApproval(atc.RejectBuild, buildID)
+}
+
+func (client *client) decideApproval(requestName string, buildID string) error {
+	params := rata.Params{
+		"build_id": buildID,
+	}
+
+	err := client.connection.Send(internal.Request{
+		RequestName: requestName,
+		Params:      params,
+	}, nil)
+
+	if unexpectedResponseError, ok := err.(internal.UnexpectedResponseError); ok {
+		if unexpectedResponseError.StatusCode == http.StatusConflict {
+			return ErrBuildNotWaitingForApproval
+		}
+	}
+
+	return err
+}
+
 func (team *team) Builds(page Page) ([]atc.Build, Pagination, error) {
 	var builds []atc.Build
 
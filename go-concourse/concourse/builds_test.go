@@ -98,6 +98,42 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("CreateJobBuildWithPriority", func() {
+		var (
+			pipelineRef   atc.PipelineRef
+			queryParams   string
+			jobName       string
+			expectedBuild atc.Build
+		)
+		BeforeEach(func() {
+			queryParams = "instance_vars=%7B%22branch%22%3A%22master%22%7D&priority=10"
+			pipelineRef = atc.PipelineRef{Name: "mypipeline", InstanceVars: atc.InstanceVars{"branch": "master"}}
+			jobName = "myjob"
+
+			expectedBuild = atc.Build{
+				ID:      123,
+				Name:    "mybuild",
+				Status:  "succeeded",
+				JobName: "myjob",
+				APIURL:  "api/v1/builds/123",
+			}
+			expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/jobs/myjob/builds"
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("POST", expectedURL, queryParams),
+					ghttp.RespondWithJSONEncoded(http.StatusCreated, expectedBuild),
+				),
+			)
+		})
+
+		It("creates the build with the given priority", func() {
+			build, err := team.CreateJobBuildWithPriority(pipelineRef, jobName, 10)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(build).To(Equal(expectedBuild))
+		})
+	})
+
 	Describe("RerunJobBuild", func() {
 		var (
 			pipelineRef   atc.PipelineRef
@@ -468,6 +504,57 @@ var _ = Describe("ATC Handler Builds", func() {
 		})
 	})
 
+	Describe("ApproveBuild", func() {
+		var expectedStatus int
+
+		BeforeEach(func() {
+			expectedStatus = http.StatusNoContent
+		})
+
+		JustBeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/approve"),
+					ghttp.RespondWith(expectedStatus, ""),
+				),
+			)
+		})
+
+		It("sends an approve request to ATC", func() {
+			err := client.ApproveBuild("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+
+		Context("when the build is not waiting for approval", func() {
+			BeforeEach(func() {
+				expectedStatus = http.StatusConflict
+			})
+
+			It("returns an error", func() {
+				err := client.ApproveBuild("123")
+				Expect(err).To(Equal(concourse.ErrBuildNotWaitingForApproval))
+			})
+		})
+	})
+
+	Describe("RejectBuild", func() {
+		BeforeEach(func() {
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("PUT", "/api/v1/builds/123/reject"),
+					ghttp.RespondWith(http.StatusNoContent, ""),
+				),
+			)
+		})
+
+		It("sends a reject request to ATC", func() {
+			err := client.RejectBuild("123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(atcServer.ReceivedRequests()).To(HaveLen(1))
+		})
+	})
+
 	Describe("team.Builds", func() {
 		expectedURL := "/api/v1/teams/some-team/builds"
 
@@ -19,7 +19,11 @@ type Client interface {
 	BuildEvents(buildID string) (Events, error)
 	BuildResources(buildID int) (atc.BuildInputsOutputs, bool, error)
 	ListBuildArtifacts(buildID string) ([]atc.WorkerArtifact, error)
+	ListBuildStoredArtifacts(buildID string) ([]atc.StoredArtifact, error)
+	GetBuildStoredArtifact(buildID string, artifactID int) (io.ReadCloser, error)
 	AbortBuild(buildID string) error
+	ApproveBuild(buildID string) error
+	RejectBuild(buildID string) error
 	BuildPlan(buildID int) (atc.PublicBuildPlan, bool, error)
 	SaveWorker(atc.Worker, *time.Duration) (*atc.Worker, error)
 	ListWorkers() ([]atc.Worker, error)
@@ -34,6 +38,7 @@ type Client interface {
 	Team(teamName string) Team
 	UserInfo() (atc.UserInfo, error)
 	ListActiveUsersSince(since time.Time) ([]atc.User, error)
+	AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error)
 }
 
 type client struct {
@@ -23,6 +23,33 @@ type FakeClient struct {
 	abortBuildReturnsOnCall map[int]struct {
 		result1 error
 	}
+	ApproveBuildStub        func(string) error
+	approveBuildMutex       sync.RWMutex
+	approveBuildArgsForCall []struct {
+		arg1 string
+	}
+	approveBuildReturns struct {
+		result1 error
+	}
+	approveBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
+	AuditEventsStub        func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}
+	auditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	BuildStub        func(string) (atc.Build, bool, error)
 	buildMutex       sync.RWMutex
 	buildArgsForCall []struct {
@@ -109,6 +136,20 @@ type FakeClient struct {
 		result1 concourse.Team
 		result2 error
 	}
+	GetBuildStoredArtifactStub        func(string, int) (io.ReadCloser, error)
+	getBuildStoredArtifactMutex       sync.RWMutex
+	getBuildStoredArtifactArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	getBuildStoredArtifactReturns struct {
+		result1 io.ReadCloser
+		result2 error
+	}
+	getBuildStoredArtifactReturnsOnCall map[int]struct {
+		result1 io.ReadCloser
+		result2 error
+	}
 	GetCLIReaderStub        func(string, string) (io.ReadCloser, http.Header, error)
 	getCLIReaderMutex       sync.RWMutex
 	getCLIReaderArgsForCall []struct {
@@ -196,6 +237,19 @@ type FakeClient struct {
 		result1 []atc.WorkerArtifact
 		result2 error
 	}
+	ListBuildStoredArtifactsStub        func(string) ([]atc.StoredArtifact, error)
+	listBuildStoredArtifactsMutex       sync.RWMutex
+	listBuildStoredArtifactsArgsForCall []struct {
+		arg1 string
+	}
+	listBuildStoredArtifactsReturns struct {
+		result1 []atc.StoredArtifact
+		result2 error
+	}
+	listBuildStoredArtifactsReturnsOnCall map[int]struct {
+		result1 []atc.StoredArtifact
+		result2 error
+	}
 	ListPipelinesStub        func() ([]atc.Pipeline, error)
 	listPipelinesMutex       sync.RWMutex
 	listPipelinesArgsForCall []struct {
@@ -243,6 +297,17 @@ type FakeClient struct {
 	pruneWorkerReturnsOnCall map[int]struct {
 		result1 error
 	}
+	RejectBuildStub        func(string) error
+	rejectBuildMutex       sync.RWMutex
+	rejectBuildArgsForCall []struct {
+		arg1 string
+	}
+	rejectBuildReturns struct {
+		result1 error
+	}
+	rejectBuildReturnsOnCall map[int]struct {
+		result1 error
+	}
 	SaveWorkerStub        func(atc.Worker, *time.Duration) (*atc.Worker, error)
 	saveWorkerMutex       sync.RWMutex
 	saveWorkerArgsForCall []struct {
@@ -354,6 +419,133 @@ func (fake *FakeClient) AbortBuildReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) ApproveBuild(arg1 string) error {
+	fake.approveBuildMutex.Lock()
+	ret, specificReturn := fake.approveBuildReturnsOnCall[len(fake.approveBuildArgsForCall)]
+	fake.approveBuildArgsForCall = append(fake.approveBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ApproveBuild", []interface{}{arg1})
+	fake.approveBuildMutex.Unlock()
+	if fake.ApproveBuildStub != nil {
+		return fake.ApproveBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.approveBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) ApproveBuildCallCount() int {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	return len(fake.approveBuildArgsForCall)
+}
+
+func (fake *FakeClient) ApproveBuildCalls(stub func(string) error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = stub
+}
+
+func (fake *FakeClient) ApproveBuildArgsForCall(i int) string {
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	argsForCall := fake.approveBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ApproveBuildReturns(result1 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	fake.approveBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) ApproveBuildReturnsOnCall(i int, result1 error) {
+	fake.approveBuildMutex.Lock()
+	defer fake.approveBuildMutex.Unlock()
+	fake.ApproveBuildStub = nil
+	if fake.approveBuildReturnsOnCall == nil {
+		fake.approveBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.approveBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) AuditEvents(arg1 concourse.AuditEventFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if fake.AuditEventsStub != nil {
+		return fake.AuditEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.auditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeClient) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeClient) AuditEventsCalls(stub func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeClient) AuditEventsArgsForCall(i int) (concourse.AuditEventFilter, concourse.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) AuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeClient) AuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeClient) Build(arg1 string) (atc.Build, bool, error) {
 	fake.buildMutex.Lock()
 	ret, specificReturn := fake.buildReturnsOnCall[len(fake.buildArgsForCall)]
@@ -744,6 +936,70 @@ func (fake *FakeClient) FindTeamReturnsOnCall(i int, result1 concourse.Team, res
 	}{result1, result2}
 }
 
+func (fake *FakeClient) GetBuildStoredArtifact(arg1 string, arg2 int) (io.ReadCloser, error) {
+	fake.getBuildStoredArtifactMutex.Lock()
+	ret, specificReturn := fake.getBuildStoredArtifactReturnsOnCall[len(fake.getBuildStoredArtifactArgsForCall)]
+	fake.getBuildStoredArtifactArgsForCall = append(fake.getBuildStoredArtifactArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("GetBuildStoredArtifact", []interface{}{arg1, arg2})
+	fake.getBuildStoredArtifactMutex.Unlock()
+	if fake.GetBuildStoredArtifactStub != nil {
+		return fake.GetBuildStoredArtifactStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.getBuildStoredArtifactReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) GetBuildStoredArtifactCallCount() int {
+	fake.getBuildStoredArtifactMutex.RLock()
+	defer fake.getBuildStoredArtifactMutex.RUnlock()
+	return len(fake.getBuildStoredArtifactArgsForCall)
+}
+
+func (fake *FakeClient) GetBuildStoredArtifactCalls(stub func(string, int) (io.ReadCloser, error)) {
+	fake.getBuildStoredArtifactMutex.Lock()
+	defer fake.getBuildStoredArtifactMutex.Unlock()
+	fake.GetBuildStoredArtifactStub = stub
+}
+
+func (fake *FakeClient) GetBuildStoredArtifactArgsForCall(i int) (string, int) {
+	fake.getBuildStoredArtifactMutex.RLock()
+	defer fake.getBuildStoredArtifactMutex.RUnlock()
+	argsForCall := fake.getBuildStoredArtifactArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeClient) GetBuildStoredArtifactReturns(result1 io.ReadCloser, result2 error) {
+	fake.getBuildStoredArtifactMutex.Lock()
+	defer fake.getBuildStoredArtifactMutex.Unlock()
+	fake.GetBuildStoredArtifactStub = nil
+	fake.getBuildStoredArtifactReturns = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) GetBuildStoredArtifactReturnsOnCall(i int, result1 io.ReadCloser, result2 error) {
+	fake.getBuildStoredArtifactMutex.Lock()
+	defer fake.getBuildStoredArtifactMutex.Unlock()
+	fake.GetBuildStoredArtifactStub = nil
+	if fake.getBuildStoredArtifactReturnsOnCall == nil {
+		fake.getBuildStoredArtifactReturnsOnCall = make(map[int]struct {
+			result1 io.ReadCloser
+			result2 error
+		})
+	}
+	fake.getBuildStoredArtifactReturnsOnCall[i] = struct {
+		result1 io.ReadCloser
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) GetCLIReader(arg1 string, arg2 string) (io.ReadCloser, http.Header, error) {
 	fake.getCLIReaderMutex.Lock()
 	ret, specificReturn := fake.getCLIReaderReturnsOnCall[len(fake.getCLIReaderArgsForCall)]
@@ -1159,6 +1415,69 @@ func (fake *FakeClient) ListBuildArtifactsReturnsOnCall(i int, result1 []atc.Wor
 	}{result1, result2}
 }
 
+func (fake *FakeClient) ListBuildStoredArtifacts(arg1 string) ([]atc.StoredArtifact, error) {
+	fake.listBuildStoredArtifactsMutex.Lock()
+	ret, specificReturn := fake.listBuildStoredArtifactsReturnsOnCall[len(fake.listBuildStoredArtifactsArgsForCall)]
+	fake.listBuildStoredArtifactsArgsForCall = append(fake.listBuildStoredArtifactsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ListBuildStoredArtifacts", []interface{}{arg1})
+	fake.listBuildStoredArtifactsMutex.Unlock()
+	if fake.ListBuildStoredArtifactsStub != nil {
+		return fake.ListBuildStoredArtifactsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.listBuildStoredArtifactsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeClient) ListBuildStoredArtifactsCallCount() int {
+	fake.listBuildStoredArtifactsMutex.RLock()
+	defer fake.listBuildStoredArtifactsMutex.RUnlock()
+	return len(fake.listBuildStoredArtifactsArgsForCall)
+}
+
+func (fake *FakeClient) ListBuildStoredArtifactsCalls(stub func(string) ([]atc.StoredArtifact, error)) {
+	fake.listBuildStoredArtifactsMutex.Lock()
+	defer fake.listBuildStoredArtifactsMutex.Unlock()
+	fake.ListBuildStoredArtifactsStub = stub
+}
+
+func (fake *FakeClient) ListBuildStoredArtifactsArgsForCall(i int) string {
+	fake.listBuildStoredArtifactsMutex.RLock()
+	defer fake.listBuildStoredArtifactsMutex.RUnlock()
+	argsForCall := fake.listBuildStoredArtifactsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) ListBuildStoredArtifactsReturns(result1 []atc.StoredArtifact, result2 error) {
+	fake.listBuildStoredArtifactsMutex.Lock()
+	defer fake.listBuildStoredArtifactsMutex.Unlock()
+	fake.ListBuildStoredArtifactsStub = nil
+	fake.listBuildStoredArtifactsReturns = struct {
+		result1 []atc.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeClient) ListBuildStoredArtifactsReturnsOnCall(i int, result1 []atc.StoredArtifact, result2 error) {
+	fake.listBuildStoredArtifactsMutex.Lock()
+	defer fake.listBuildStoredArtifactsMutex.Unlock()
+	fake.ListBuildStoredArtifactsStub = nil
+	if fake.listBuildStoredArtifactsReturnsOnCall == nil {
+		fake.listBuildStoredArtifactsReturnsOnCall = make(map[int]struct {
+			result1 []atc.StoredArtifact
+			result2 error
+		})
+	}
+	fake.listBuildStoredArtifactsReturnsOnCall[i] = struct {
+		result1 []atc.StoredArtifact
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeClient) ListPipelines() ([]atc.Pipeline, error) {
 	fake.listPipelinesMutex.Lock()
 	ret, specificReturn := fake.listPipelinesReturnsOnCall[len(fake.listPipelinesArgsForCall)]
@@ -1384,6 +1703,66 @@ func (fake *FakeClient) PruneWorkerReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeClient) RejectBuild(arg1 string) error {
+	fake.rejectBuildMutex.Lock()
+	ret, specificReturn := fake.rejectBuildReturnsOnCall[len(fake.rejectBuildArgsForCall)]
+	fake.rejectBuildArgsForCall = append(fake.rejectBuildArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("RejectBuild", []interface{}{arg1})
+	fake.rejectBuildMutex.Unlock()
+	if fake.RejectBuildStub != nil {
+		return fake.RejectBuildStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.rejectBuildReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeClient) RejectBuildCallCount() int {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	return len(fake.rejectBuildArgsForCall)
+}
+
+func (fake *FakeClient) RejectBuildCalls(stub func(string) error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = stub
+}
+
+func (fake *FakeClient) RejectBuildArgsForCall(i int) string {
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
+	argsForCall := fake.rejectBuildArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeClient) RejectBuildReturns(result1 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	fake.rejectBuildReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeClient) RejectBuildReturnsOnCall(i int, result1 error) {
+	fake.rejectBuildMutex.Lock()
+	defer fake.rejectBuildMutex.Unlock()
+	fake.RejectBuildStub = nil
+	if fake.rejectBuildReturnsOnCall == nil {
+		fake.rejectBuildReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.rejectBuildReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeClient) SaveWorker(arg1 atc.Worker, arg2 *time.Duration) (*atc.Worker, error) {
 	fake.saveWorkerMutex.Lock()
 	ret, specificReturn := fake.saveWorkerReturnsOnCall[len(fake.saveWorkerArgsForCall)]
@@ -1620,6 +1999,10 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.invocationsMutex.RUnlock()
 	fake.abortBuildMutex.RLock()
 	defer fake.abortBuildMutex.RUnlock()
+	fake.approveBuildMutex.RLock()
+	defer fake.approveBuildMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
 	fake.buildMutex.RLock()
 	defer fake.buildMutex.RUnlock()
 	fake.buildEventsMutex.RLock()
@@ -1632,6 +2015,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.buildsMutex.RUnlock()
 	fake.findTeamMutex.RLock()
 	defer fake.findTeamMutex.RUnlock()
+	fake.getBuildStoredArtifactMutex.RLock()
+	defer fake.getBuildStoredArtifactMutex.RUnlock()
 	fake.getCLIReaderMutex.RLock()
 	defer fake.getCLIReaderMutex.RUnlock()
 	fake.getInfoMutex.RLock()
@@ -1646,6 +2031,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listAllJobsMutex.RUnlock()
 	fake.listBuildArtifactsMutex.RLock()
 	defer fake.listBuildArtifactsMutex.RUnlock()
+	fake.listBuildStoredArtifactsMutex.RLock()
+	defer fake.listBuildStoredArtifactsMutex.RUnlock()
 	fake.listPipelinesMutex.RLock()
 	defer fake.listPipelinesMutex.RUnlock()
 	fake.listTeamsMutex.RLock()
@@ -1654,6 +2041,8 @@ func (fake *FakeClient) Invocations() map[string][][]interface{} {
 	defer fake.listWorkersMutex.RUnlock()
 	fake.pruneWorkerMutex.RLock()
 	defer fake.pruneWorkerMutex.RUnlock()
+	fake.rejectBuildMutex.RLock()
+	defer fake.rejectBuildMutex.RUnlock()
 	fake.saveWorkerMutex.RLock()
 	defer fake.saveWorkerMutex.RUnlock()
 	fake.teamMutex.RLock()
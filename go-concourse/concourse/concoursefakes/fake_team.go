@@ -33,6 +33,22 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	AuditEventsStub        func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)
+	auditEventsMutex       sync.RWMutex
+	auditEventsArgsForCall []struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}
+	auditEventsReturns struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
+	auditEventsReturnsOnCall map[int]struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}
 	AuthStub        func() atc.TeamAuth
 	authMutex       sync.RWMutex
 	authArgsForCall []struct {
@@ -200,6 +216,21 @@ type FakeTeam struct {
 		result1 atc.Build
 		result2 error
 	}
+	CreateJobBuildWithPriorityStub        func(atc.PipelineRef, string, int) (atc.Build, error)
+	createJobBuildWithPriorityMutex       sync.RWMutex
+	createJobBuildWithPriorityArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}
+	createJobBuildWithPriorityReturns struct {
+		result1 atc.Build
+		result2 error
+	}
+	createJobBuildWithPriorityReturnsOnCall map[int]struct {
+		result1 atc.Build
+		result2 error
+	}
 	CreateOrUpdateStub        func(atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error)
 	createOrUpdateMutex       sync.RWMutex
 	createOrUpdateArgsForCall []struct {
@@ -277,6 +308,19 @@ type FakeTeam struct {
 	destroyTeamReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DestroyWebhookStub        func(string) (bool, error)
+	destroyWebhookMutex       sync.RWMutex
+	destroyWebhookArgsForCall []struct {
+		arg1 string
+	}
+	destroyWebhookReturns struct {
+		result1 bool
+		result2 error
+	}
+	destroyWebhookReturnsOnCall map[int]struct {
+		result1 bool
+		result2 error
+	}
 	DisableResourceVersionStub        func(atc.PipelineRef, string, int) (bool, error)
 	disableResourceVersionMutex       sync.RWMutex
 	disableResourceVersionArgsForCall []struct {
@@ -597,6 +641,37 @@ type FakeTeam struct {
 		result3 bool
 		result4 error
 	}
+	PipelineConfigVersionStub        func(atc.PipelineRef, int) (atc.PipelineConfigVersion, bool, error)
+	pipelineConfigVersionMutex       sync.RWMutex
+	pipelineConfigVersionArgsForCall []struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}
+	pipelineConfigVersionReturns struct {
+		result1 atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	pipelineConfigVersionReturnsOnCall map[int]struct {
+		result1 atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	PipelineConfigVersionsStub        func(atc.PipelineRef) ([]atc.PipelineConfigVersion, bool, error)
+	pipelineConfigVersionsMutex       sync.RWMutex
+	pipelineConfigVersionsArgsForCall []struct {
+		arg1 atc.PipelineRef
+	}
+	pipelineConfigVersionsReturns struct {
+		result1 []atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
+	pipelineConfigVersionsReturnsOnCall map[int]struct {
+		result1 []atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}
 	RenamePipelineStub        func(atc.PipelineRef, string) (bool, []concourse.ConfigWarning, error)
 	renamePipelineMutex       sync.RWMutex
 	renamePipelineArgsForCall []struct {
@@ -709,6 +784,21 @@ type FakeTeam struct {
 		result1 bool
 		result2 error
 	}
+	SetWebhookStub        func(atc.Webhook) (atc.Webhook, bool, error)
+	setWebhookMutex       sync.RWMutex
+	setWebhookArgsForCall []struct {
+		arg1 atc.Webhook
+	}
+	setWebhookReturns struct {
+		result1 atc.Webhook
+		result2 bool
+		result3 error
+	}
+	setWebhookReturnsOnCall map[int]struct {
+		result1 atc.Webhook
+		result2 bool
+		result3 error
+	}
 	UnpauseJobStub        func(atc.PipelineRef, string) (bool, error)
 	unpauseJobMutex       sync.RWMutex
 	unpauseJobArgsForCall []struct {
@@ -765,6 +855,34 @@ type FakeTeam struct {
 		result2 bool
 		result3 error
 	}
+	WebhookDeliveriesStub        func(string, int) ([]atc.WebhookDelivery, bool, error)
+	webhookDeliveriesMutex       sync.RWMutex
+	webhookDeliveriesArgsForCall []struct {
+		arg1 string
+		arg2 int
+	}
+	webhookDeliveriesReturns struct {
+		result1 []atc.WebhookDelivery
+		result2 bool
+		result3 error
+	}
+	webhookDeliveriesReturnsOnCall map[int]struct {
+		result1 []atc.WebhookDelivery
+		result2 bool
+		result3 error
+	}
+	WebhooksStub        func() ([]atc.Webhook, error)
+	webhooksMutex       sync.RWMutex
+	webhooksArgsForCall []struct {
+	}
+	webhooksReturns struct {
+		result1 []atc.Webhook
+		result2 error
+	}
+	webhooksReturnsOnCall map[int]struct {
+		result1 []atc.Webhook
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -884,6 +1002,73 @@ func (fake *FakeTeam) ArchivePipelineReturnsOnCall(i int, result1 bool, result2
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) AuditEvents(arg1 concourse.AuditEventFilter, arg2 concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error) {
+	fake.auditEventsMutex.Lock()
+	ret, specificReturn := fake.auditEventsReturnsOnCall[len(fake.auditEventsArgsForCall)]
+	fake.auditEventsArgsForCall = append(fake.auditEventsArgsForCall, struct {
+		arg1 concourse.AuditEventFilter
+		arg2 concourse.Page
+	}{arg1, arg2})
+	fake.recordInvocation("AuditEvents", []interface{}{arg1, arg2})
+	fake.auditEventsMutex.Unlock()
+	if fake.AuditEventsStub != nil {
+		return fake.AuditEventsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.auditEventsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) AuditEventsCallCount() int {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	return len(fake.auditEventsArgsForCall)
+}
+
+func (fake *FakeTeam) AuditEventsCalls(stub func(concourse.AuditEventFilter, concourse.Page) ([]atc.AuditEvent, concourse.Pagination, error)) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = stub
+}
+
+func (fake *FakeTeam) AuditEventsArgsForCall(i int) (concourse.AuditEventFilter, concourse.Page) {
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
+	argsForCall := fake.auditEventsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) AuditEventsReturns(result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	fake.auditEventsReturns = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) AuditEventsReturnsOnCall(i int, result1 []atc.AuditEvent, result2 concourse.Pagination, result3 error) {
+	fake.auditEventsMutex.Lock()
+	defer fake.auditEventsMutex.Unlock()
+	fake.AuditEventsStub = nil
+	if fake.auditEventsReturnsOnCall == nil {
+		fake.auditEventsReturnsOnCall = make(map[int]struct {
+			result1 []atc.AuditEvent
+			result2 concourse.Pagination
+			result3 error
+		})
+	}
+	fake.auditEventsReturnsOnCall[i] = struct {
+		result1 []atc.AuditEvent
+		result2 concourse.Pagination
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) Auth() atc.TeamAuth {
 	fake.authMutex.Lock()
 	ret, specificReturn := fake.authReturnsOnCall[len(fake.authArgsForCall)]
@@ -1604,6 +1789,71 @@ func (fake *FakeTeam) CreateJobBuildReturnsOnCall(i int, result1 atc.Build, resu
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) CreateJobBuildWithPriority(arg1 atc.PipelineRef, arg2 string, arg3 int) (atc.Build, error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	ret, specificReturn := fake.createJobBuildWithPriorityReturnsOnCall[len(fake.createJobBuildWithPriorityArgsForCall)]
+	fake.createJobBuildWithPriorityArgsForCall = append(fake.createJobBuildWithPriorityArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 string
+		arg3 int
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("CreateJobBuildWithPriority", []interface{}{arg1, arg2, arg3})
+	fake.createJobBuildWithPriorityMutex.Unlock()
+	if fake.CreateJobBuildWithPriorityStub != nil {
+		return fake.CreateJobBuildWithPriorityStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.createJobBuildWithPriorityReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityCallCount() int {
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
+	return len(fake.createJobBuildWithPriorityArgsForCall)
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityCalls(stub func(atc.PipelineRef, string, int) (atc.Build, error)) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = stub
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityArgsForCall(i int) (atc.PipelineRef, string, int) {
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
+	argsForCall := fake.createJobBuildWithPriorityArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityReturns(result1 atc.Build, result2 error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = nil
+	fake.createJobBuildWithPriorityReturns = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) CreateJobBuildWithPriorityReturnsOnCall(i int, result1 atc.Build, result2 error) {
+	fake.createJobBuildWithPriorityMutex.Lock()
+	defer fake.createJobBuildWithPriorityMutex.Unlock()
+	fake.CreateJobBuildWithPriorityStub = nil
+	if fake.createJobBuildWithPriorityReturnsOnCall == nil {
+		fake.createJobBuildWithPriorityReturnsOnCall = make(map[int]struct {
+			result1 atc.Build
+			result2 error
+		})
+	}
+	fake.createJobBuildWithPriorityReturnsOnCall[i] = struct {
+		result1 atc.Build
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) CreateOrUpdate(arg1 atc.Team) (atc.Team, bool, bool, []concourse.ConfigWarning, error) {
 	fake.createOrUpdateMutex.Lock()
 	ret, specificReturn := fake.createOrUpdateReturnsOnCall[len(fake.createOrUpdateArgsForCall)]
@@ -1940,6 +2190,69 @@ func (fake *FakeTeam) DestroyTeamReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeTeam) DestroyWebhook(arg1 string) (bool, error) {
+	fake.destroyWebhookMutex.Lock()
+	ret, specificReturn := fake.destroyWebhookReturnsOnCall[len(fake.destroyWebhookArgsForCall)]
+	fake.destroyWebhookArgsForCall = append(fake.destroyWebhookArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("DestroyWebhook", []interface{}{arg1})
+	fake.destroyWebhookMutex.Unlock()
+	if fake.DestroyWebhookStub != nil {
+		return fake.DestroyWebhookStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.destroyWebhookReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) DestroyWebhookCallCount() int {
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
+	return len(fake.destroyWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) DestroyWebhookCalls(stub func(string) (bool, error)) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = stub
+}
+
+func (fake *FakeTeam) DestroyWebhookArgsForCall(i int) string {
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
+	argsForCall := fake.destroyWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) DestroyWebhookReturns(result1 bool, result2 error) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = nil
+	fake.destroyWebhookReturns = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) DestroyWebhookReturnsOnCall(i int, result1 bool, result2 error) {
+	fake.destroyWebhookMutex.Lock()
+	defer fake.destroyWebhookMutex.Unlock()
+	fake.DestroyWebhookStub = nil
+	if fake.destroyWebhookReturnsOnCall == nil {
+		fake.destroyWebhookReturnsOnCall = make(map[int]struct {
+			result1 bool
+			result2 error
+		})
+	}
+	fake.destroyWebhookReturnsOnCall[i] = struct {
+		result1 bool
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) DisableResourceVersion(arg1 atc.PipelineRef, arg2 string, arg3 int) (bool, error) {
 	fake.disableResourceVersionMutex.Lock()
 	ret, specificReturn := fake.disableResourceVersionReturnsOnCall[len(fake.disableResourceVersionArgsForCall)]
@@ -3388,6 +3701,139 @@ func (fake *FakeTeam) PipelineConfigReturnsOnCall(i int, result1 atc.Config, res
 	}{result1, result2, result3, result4}
 }
 
+func (fake *FakeTeam) PipelineConfigVersion(arg1 atc.PipelineRef, arg2 int) (atc.PipelineConfigVersion, bool, error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigVersionReturnsOnCall[len(fake.pipelineConfigVersionArgsForCall)]
+	fake.pipelineConfigVersionArgsForCall = append(fake.pipelineConfigVersionArgsForCall, struct {
+		arg1 atc.PipelineRef
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("PipelineConfigVersion", []interface{}{arg1, arg2})
+	fake.pipelineConfigVersionMutex.Unlock()
+	if fake.PipelineConfigVersionStub != nil {
+		return fake.PipelineConfigVersionStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.pipelineConfigVersionReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigVersionCallCount() int {
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	return len(fake.pipelineConfigVersionArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigVersionCalls(stub func(atc.PipelineRef, int) (atc.PipelineConfigVersion, bool, error)) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigVersionArgsForCall(i int) (atc.PipelineRef, int) {
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	argsForCall := fake.pipelineConfigVersionArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) PipelineConfigVersionReturns(result1 atc.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = nil
+	fake.pipelineConfigVersionReturns = struct {
+		result1 atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersionReturnsOnCall(i int, result1 atc.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionMutex.Lock()
+	defer fake.pipelineConfigVersionMutex.Unlock()
+	fake.PipelineConfigVersionStub = nil
+	if fake.pipelineConfigVersionReturnsOnCall == nil {
+		fake.pipelineConfigVersionReturnsOnCall = make(map[int]struct {
+			result1 atc.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigVersionReturnsOnCall[i] = struct {
+		result1 atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersions(arg1 atc.PipelineRef) ([]atc.PipelineConfigVersion, bool, error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	ret, specificReturn := fake.pipelineConfigVersionsReturnsOnCall[len(fake.pipelineConfigVersionsArgsForCall)]
+	fake.pipelineConfigVersionsArgsForCall = append(fake.pipelineConfigVersionsArgsForCall, struct {
+		arg1 atc.PipelineRef
+	}{arg1})
+	fake.recordInvocation("PipelineConfigVersions", []interface{}{arg1})
+	fake.pipelineConfigVersionsMutex.Unlock()
+	if fake.PipelineConfigVersionsStub != nil {
+		return fake.PipelineConfigVersionsStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.pipelineConfigVersionsReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsCallCount() int {
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
+	return len(fake.pipelineConfigVersionsArgsForCall)
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsCalls(stub func(atc.PipelineRef) ([]atc.PipelineConfigVersion, bool, error)) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = stub
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsArgsForCall(i int) atc.PipelineRef {
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
+	argsForCall := fake.pipelineConfigVersionsArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsReturns(result1 []atc.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = nil
+	fake.pipelineConfigVersionsReturns = struct {
+		result1 []atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) PipelineConfigVersionsReturnsOnCall(i int, result1 []atc.PipelineConfigVersion, result2 bool, result3 error) {
+	fake.pipelineConfigVersionsMutex.Lock()
+	defer fake.pipelineConfigVersionsMutex.Unlock()
+	fake.PipelineConfigVersionsStub = nil
+	if fake.pipelineConfigVersionsReturnsOnCall == nil {
+		fake.pipelineConfigVersionsReturnsOnCall = make(map[int]struct {
+			result1 []atc.PipelineConfigVersion
+			result2 bool
+			result3 error
+		})
+	}
+	fake.pipelineConfigVersionsReturnsOnCall[i] = struct {
+		result1 []atc.PipelineConfigVersion
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) RenamePipeline(arg1 atc.PipelineRef, arg2 string) (bool, []concourse.ConfigWarning, error) {
 	fake.renamePipelineMutex.Lock()
 	ret, specificReturn := fake.renamePipelineReturnsOnCall[len(fake.renamePipelineArgsForCall)]
@@ -3855,6 +4301,72 @@ func (fake *FakeTeam) SetPinCommentReturnsOnCall(i int, result1 bool, result2 er
 	}{result1, result2}
 }
 
+func (fake *FakeTeam) SetWebhook(arg1 atc.Webhook) (atc.Webhook, bool, error) {
+	fake.setWebhookMutex.Lock()
+	ret, specificReturn := fake.setWebhookReturnsOnCall[len(fake.setWebhookArgsForCall)]
+	fake.setWebhookArgsForCall = append(fake.setWebhookArgsForCall, struct {
+		arg1 atc.Webhook
+	}{arg1})
+	fake.recordInvocation("SetWebhook", []interface{}{arg1})
+	fake.setWebhookMutex.Unlock()
+	if fake.SetWebhookStub != nil {
+		return fake.SetWebhookStub(arg1)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.setWebhookReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) SetWebhookCallCount() int {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	return len(fake.setWebhookArgsForCall)
+}
+
+func (fake *FakeTeam) SetWebhookCalls(stub func(atc.Webhook) (atc.Webhook, bool, error)) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = stub
+}
+
+func (fake *FakeTeam) SetWebhookArgsForCall(i int) atc.Webhook {
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
+	argsForCall := fake.setWebhookArgsForCall[i]
+	return argsForCall.arg1
+}
+
+func (fake *FakeTeam) SetWebhookReturns(result1 atc.Webhook, result2 bool, result3 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	fake.setWebhookReturns = struct {
+		result1 atc.Webhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) SetWebhookReturnsOnCall(i int, result1 atc.Webhook, result2 bool, result3 error) {
+	fake.setWebhookMutex.Lock()
+	defer fake.setWebhookMutex.Unlock()
+	fake.SetWebhookStub = nil
+	if fake.setWebhookReturnsOnCall == nil {
+		fake.setWebhookReturnsOnCall = make(map[int]struct {
+			result1 atc.Webhook
+			result2 bool
+			result3 error
+		})
+	}
+	fake.setWebhookReturnsOnCall[i] = struct {
+		result1 atc.Webhook
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
 func (fake *FakeTeam) UnpauseJob(arg1 atc.PipelineRef, arg2 string) (bool, error) {
 	fake.unpauseJobMutex.Lock()
 	ret, specificReturn := fake.unpauseJobReturnsOnCall[len(fake.unpauseJobArgsForCall)]
@@ -4112,6 +4624,128 @@ func (fake *FakeTeam) VersionedResourceTypesReturnsOnCall(i int, result1 atc.Ver
 	}{result1, result2, result3}
 }
 
+func (fake *FakeTeam) WebhookDeliveries(arg1 string, arg2 int) ([]atc.WebhookDelivery, bool, error) {
+	fake.webhookDeliveriesMutex.Lock()
+	ret, specificReturn := fake.webhookDeliveriesReturnsOnCall[len(fake.webhookDeliveriesArgsForCall)]
+	fake.webhookDeliveriesArgsForCall = append(fake.webhookDeliveriesArgsForCall, struct {
+		arg1 string
+		arg2 int
+	}{arg1, arg2})
+	fake.recordInvocation("WebhookDeliveries", []interface{}{arg1, arg2})
+	fake.webhookDeliveriesMutex.Unlock()
+	if fake.WebhookDeliveriesStub != nil {
+		return fake.WebhookDeliveriesStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2, ret.result3
+	}
+	fakeReturns := fake.webhookDeliveriesReturns
+	return fakeReturns.result1, fakeReturns.result2, fakeReturns.result3
+}
+
+func (fake *FakeTeam) WebhookDeliveriesCallCount() int {
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	return len(fake.webhookDeliveriesArgsForCall)
+}
+
+func (fake *FakeTeam) WebhookDeliveriesCalls(stub func(string, int) ([]atc.WebhookDelivery, bool, error)) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = stub
+}
+
+func (fake *FakeTeam) WebhookDeliveriesArgsForCall(i int) (string, int) {
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	argsForCall := fake.webhookDeliveriesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeTeam) WebhookDeliveriesReturns(result1 []atc.WebhookDelivery, result2 bool, result3 error) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = nil
+	fake.webhookDeliveriesReturns = struct {
+		result1 []atc.WebhookDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) WebhookDeliveriesReturnsOnCall(i int, result1 []atc.WebhookDelivery, result2 bool, result3 error) {
+	fake.webhookDeliveriesMutex.Lock()
+	defer fake.webhookDeliveriesMutex.Unlock()
+	fake.WebhookDeliveriesStub = nil
+	if fake.webhookDeliveriesReturnsOnCall == nil {
+		fake.webhookDeliveriesReturnsOnCall = make(map[int]struct {
+			result1 []atc.WebhookDelivery
+			result2 bool
+			result3 error
+		})
+	}
+	fake.webhookDeliveriesReturnsOnCall[i] = struct {
+		result1 []atc.WebhookDelivery
+		result2 bool
+		result3 error
+	}{result1, result2, result3}
+}
+
+func (fake *FakeTeam) Webhooks() ([]atc.Webhook, error) {
+	fake.webhooksMutex.Lock()
+	ret, specificReturn := fake.webhooksReturnsOnCall[len(fake.webhooksArgsForCall)]
+	fake.webhooksArgsForCall = append(fake.webhooksArgsForCall, struct {
+	}{})
+	fake.recordInvocation("Webhooks", []interface{}{})
+	fake.webhooksMutex.Unlock()
+	if fake.WebhooksStub != nil {
+		return fake.WebhooksStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.webhooksReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeTeam) WebhooksCallCount() int {
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
+	return len(fake.webhooksArgsForCall)
+}
+
+func (fake *FakeTeam) WebhooksCalls(stub func() ([]atc.Webhook, error)) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = stub
+}
+
+func (fake *FakeTeam) WebhooksReturns(result1 []atc.Webhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	fake.webhooksReturns = struct {
+		result1 []atc.Webhook
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeTeam) WebhooksReturnsOnCall(i int, result1 []atc.Webhook, result2 error) {
+	fake.webhooksMutex.Lock()
+	defer fake.webhooksMutex.Unlock()
+	fake.WebhooksStub = nil
+	if fake.webhooksReturnsOnCall == nil {
+		fake.webhooksReturnsOnCall = make(map[int]struct {
+			result1 []atc.Webhook
+			result2 error
+		})
+	}
+	fake.webhooksReturnsOnCall[i] = struct {
+		result1 []atc.Webhook
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
@@ -4119,6 +4753,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.aTCTeamMutex.RUnlock()
 	fake.archivePipelineMutex.RLock()
 	defer fake.archivePipelineMutex.RUnlock()
+	fake.auditEventsMutex.RLock()
+	defer fake.auditEventsMutex.RUnlock()
 	fake.authMutex.RLock()
 	defer fake.authMutex.RUnlock()
 	fake.buildInputsForJobMutex.RLock()
@@ -4141,6 +4777,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.createBuildMutex.RUnlock()
 	fake.createJobBuildMutex.RLock()
 	defer fake.createJobBuildMutex.RUnlock()
+	fake.createJobBuildWithPriorityMutex.RLock()
+	defer fake.createJobBuildWithPriorityMutex.RUnlock()
 	fake.createOrUpdateMutex.RLock()
 	defer fake.createOrUpdateMutex.RUnlock()
 	fake.createOrUpdatePipelineConfigMutex.RLock()
@@ -4151,6 +4789,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.deletePipelineMutex.RUnlock()
 	fake.destroyTeamMutex.RLock()
 	defer fake.destroyTeamMutex.RUnlock()
+	fake.destroyWebhookMutex.RLock()
+	defer fake.destroyWebhookMutex.RUnlock()
 	fake.disableResourceVersionMutex.RLock()
 	defer fake.disableResourceVersionMutex.RUnlock()
 	fake.enableResourceVersionMutex.RLock()
@@ -4197,6 +4837,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.pipelineBuildsMutex.RUnlock()
 	fake.pipelineConfigMutex.RLock()
 	defer fake.pipelineConfigMutex.RUnlock()
+	fake.pipelineConfigVersionMutex.RLock()
+	defer fake.pipelineConfigVersionMutex.RUnlock()
+	fake.pipelineConfigVersionsMutex.RLock()
+	defer fake.pipelineConfigVersionsMutex.RUnlock()
 	fake.renamePipelineMutex.RLock()
 	defer fake.renamePipelineMutex.RUnlock()
 	fake.renameTeamMutex.RLock()
@@ -4211,6 +4855,8 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.scheduleJobMutex.RUnlock()
 	fake.setPinCommentMutex.RLock()
 	defer fake.setPinCommentMutex.RUnlock()
+	fake.setWebhookMutex.RLock()
+	defer fake.setWebhookMutex.RUnlock()
 	fake.unpauseJobMutex.RLock()
 	defer fake.unpauseJobMutex.RUnlock()
 	fake.unpausePipelineMutex.RLock()
@@ -4219,6 +4865,10 @@ func (fake *FakeTeam) Invocations() map[string][][]interface{} {
 	defer fake.unpinResourceMutex.RUnlock()
 	fake.versionedResourceTypesMutex.RLock()
 	defer fake.versionedResourceTypesMutex.RUnlock()
+	fake.webhookDeliveriesMutex.RLock()
+	defer fake.webhookDeliveriesMutex.RUnlock()
+	fake.webhooksMutex.RLock()
+	defer fake.webhooksMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
@@ -0,0 +1,116 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Config Versions", func() {
+	var pipelineRef atc.PipelineRef
+
+	BeforeEach(func() {
+		pipelineRef = atc.PipelineRef{Name: "mypipeline"}
+	})
+
+	Describe("PipelineConfigVersions", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/versions"
+
+		Context("when the pipeline exists", func() {
+			var expectedVersions []atc.PipelineConfigVersion
+
+			BeforeEach(func() {
+				expectedVersions = []atc.PipelineConfigVersion{
+					{Version: 2, SetVia: "set_pipeline", BuildID: 42, CreatedAt: 200},
+					{Version: 1, SetBy: "some-user", SetVia: "fly", CreatedAt: 100},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersions),
+					),
+				)
+			})
+
+			It("returns the config versions", func() {
+				versions, found, err := team.PipelineConfigVersions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(versions).To(Equal(expectedVersions))
+			})
+		})
+
+		Context("when the pipeline does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.PipelineConfigVersions(pipelineRef)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("PipelineConfigVersion", func() {
+		expectedURL := "/api/v1/teams/some-team/pipelines/mypipeline/config/versions/3"
+
+		Context("when the version exists", func() {
+			var expectedVersion atc.PipelineConfigVersion
+
+			BeforeEach(func() {
+				expectedVersion = atc.PipelineConfigVersion{
+					Version:   3,
+					SetBy:     "some-user",
+					SetVia:    "fly",
+					CreatedAt: 100,
+					Config: &atc.Config{
+						Jobs: atc.JobConfigs{{Name: "some-job"}},
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedVersion),
+					),
+				)
+			})
+
+			It("returns the version with its config", func() {
+				version, found, err := team.PipelineConfigVersion(pipelineRef, 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(version).To(Equal(expectedVersion))
+			})
+		})
+
+		Context("when the version does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", expectedURL),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false and no error", func() {
+				_, found, err := team.PipelineConfigVersion(pipelineRef, 3)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -31,6 +31,8 @@ type Team interface {
 	ListPipelines() ([]atc.Pipeline, error)
 	PipelineConfig(pipelineRef atc.PipelineRef) (atc.Config, string, bool, error)
 	CreateOrUpdatePipelineConfig(pipelineRef atc.PipelineRef, configVersion string, passedConfig []byte, checkCredentials bool) (bool, bool, []ConfigWarning, error)
+	PipelineConfigVersions(pipelineRef atc.PipelineRef) ([]atc.PipelineConfigVersion, bool, error)
+	PipelineConfigVersion(pipelineRef atc.PipelineRef, version int) (atc.PipelineConfigVersion, bool, error)
 
 	CreatePipelineBuild(pipelineRef atc.PipelineRef, plan atc.Plan) (atc.Build, error)
 
@@ -40,6 +42,7 @@ type Team interface {
 	JobBuild(pipelineRef atc.PipelineRef, jobName, buildName string) (atc.Build, bool, error)
 	JobBuilds(pipelineRef atc.PipelineRef, jobName string, page Page) ([]atc.Build, Pagination, bool, error)
 	CreateJobBuild(pipelineRef atc.PipelineRef, jobName string) (atc.Build, error)
+	CreateJobBuildWithPriority(pipelineRef atc.PipelineRef, jobName string, priority int) (atc.Build, error)
 	RerunJobBuild(pipelineRef atc.PipelineRef, jobName string, buildName string) (atc.Build, error)
 	ListJobs(pipelineRef atc.PipelineRef) ([]atc.Job, error)
 	ScheduleJob(pipelineRef atc.PipelineRef, jobName string) (bool, error)
@@ -70,6 +73,12 @@ type Team interface {
 	ListVolumes() ([]atc.Volume, error)
 	CreateBuild(plan atc.Plan) (atc.Build, error)
 	Builds(page Page) ([]atc.Build, Pagination, error)
+	AuditEvents(filter AuditEventFilter, page Page) ([]atc.AuditEvent, Pagination, error)
+
+	Webhooks() ([]atc.Webhook, error)
+	SetWebhook(atc.Webhook) (atc.Webhook, bool, error)
+	DestroyWebhook(name string) (bool, error)
+	WebhookDeliveries(name string, limit int) ([]atc.WebhookDelivery, bool, error)
 	OrderingPipelines(pipelineRefs atc.OrderPipelinesRequest) error
 
 	CreateArtifact(io.Reader, string, []string) (atc.WorkerArtifact, error)
@@ -0,0 +1,105 @@
+package concourse
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/concourse/concourse/atc"
+	"github.com/concourse/concourse/go-concourse/concourse/internal"
+	"github.com/tedsuo/rata"
+)
+
+func (team *team) Webhooks() ([]atc.Webhook, error) {
+	var webhooks []atc.Webhook
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListWebhooks,
+		Params: rata.Params{
+			"team_name": team.Name(),
+		},
+	}, &internal.Response{
+		Result: &webhooks,
+	})
+
+	return webhooks, err
+}
+
+// SetWebhook creates or updates the webhook named in the given config,
+// returning whether it was created.
+func (team *team) SetWebhook(webhook atc.Webhook) (atc.Webhook, bool, error) {
+	payload, err := json.Marshal(webhook)
+	if err != nil {
+		return atc.Webhook{}, false, err
+	}
+
+	var saved atc.Webhook
+	response := internal.Response{
+		Result: &saved,
+	}
+
+	err = team.connection.Send(internal.Request{
+		RequestName: atc.SetWebhook,
+		Params: rata.Params{
+			"team_name":    team.Name(),
+			"webhook_name": webhook.Name,
+		},
+		Body: bytes.NewBuffer(payload),
+		Header: http.Header{
+			"Content-Type": {"application/json"},
+		},
+	}, &response)
+	if err != nil {
+		return atc.Webhook{}, false, err
+	}
+
+	return saved, response.Created, nil
+}
+
+func (team *team) DestroyWebhook(name string) (bool, error) {
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.DestroyWebhook,
+		Params: rata.Params{
+			"team_name":    team.Name(),
+			"webhook_name": name,
+		},
+	}, nil)
+
+	switch err.(type) {
+	case nil:
+		return true, nil
+	case internal.ResourceNotFoundError:
+		return false, nil
+	default:
+		return false, err
+	}
+}
+
+func (team *team) WebhookDeliveries(name string, limit int) ([]atc.WebhookDelivery, bool, error) {
+	query := url.Values{}
+	if limit > 0 {
+		query.Set(atc.PaginationQueryLimit, strconv.Itoa(limit))
+	}
+
+	var deliveries []atc.WebhookDelivery
+	err := team.connection.Send(internal.Request{
+		RequestName: atc.ListWebhookDeliveries,
+		Params: rata.Params{
+			"team_name":    team.Name(),
+			"webhook_name": name,
+		},
+		Query: query,
+	}, &internal.Response{
+		Result: &deliveries,
+	})
+
+	switch err.(type) {
+	case nil:
+		return deliveries, true, nil
+	case internal.ResourceNotFoundError:
+		return nil, false, nil
+	default:
+		return nil, false, err
+	}
+}
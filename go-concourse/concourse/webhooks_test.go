@@ -0,0 +1,196 @@
+package concourse_test
+
+import (
+	"net/http"
+
+	"github.com/concourse/concourse/atc"
+
+	. "github.com/onsi/ginkgo"
+	. "github.com/onsi/gomega"
+	"github.com/onsi/gomega/ghttp"
+)
+
+var _ = Describe("ATC Handler Webhooks", func() {
+	Describe("team.Webhooks", func() {
+		var expectedWebhooks []atc.Webhook
+
+		BeforeEach(func() {
+			expectedWebhooks = []atc.Webhook{
+				{
+					Name:     "some-webhook",
+					URL:      "https://example.com/hook",
+					Pipeline: "some-pipeline",
+					Events:   []atc.BuildStatus{atc.StatusFailed},
+				},
+			}
+
+			atcServer.AppendHandlers(
+				ghttp.CombineHandlers(
+					ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/webhooks"),
+					ghttp.RespondWithJSONEncoded(http.StatusOK, expectedWebhooks),
+				),
+			)
+		})
+
+		It("returns the team's webhooks", func() {
+			webhooks, err := team.Webhooks()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(webhooks).To(Equal(expectedWebhooks))
+		})
+	})
+
+	Describe("team.SetWebhook", func() {
+		var webhook atc.Webhook
+
+		BeforeEach(func() {
+			webhook = atc.Webhook{
+				Name:   "some-webhook",
+				URL:    "https://example.com/hook",
+				Secret: "super-secret",
+			}
+		})
+
+		Context("when the webhook is created", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/webhooks/some-webhook"),
+						ghttp.VerifyJSONRepresenting(webhook),
+						ghttp.RespondWithJSONEncoded(http.StatusCreated, atc.Webhook{
+							Name:      "some-webhook",
+							URL:       "https://example.com/hook",
+							CreatedAt: 100,
+						}),
+					),
+				)
+			})
+
+			It("returns the saved webhook and that it was created", func() {
+				saved, created, err := team.SetWebhook(webhook)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeTrue())
+				Expect(saved.CreatedAt).To(Equal(int64(100)))
+				Expect(saved.Secret).To(BeEmpty())
+			})
+		})
+
+		Context("when the webhook is updated", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/webhooks/some-webhook"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, atc.Webhook{Name: "some-webhook"}),
+					),
+				)
+			})
+
+			It("returns that it was not created", func() {
+				_, created, err := team.SetWebhook(webhook)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(created).To(BeFalse())
+			})
+		})
+
+		Context("when the webhook is invalid", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("PUT", "/api/v1/teams/some-team/webhooks/some-webhook"),
+						ghttp.RespondWith(http.StatusBadRequest, "invalid webhook url"),
+					),
+				)
+			})
+
+			It("returns an error", func() {
+				_, _, err := team.SetWebhook(webhook)
+				Expect(err).To(HaveOccurred())
+				Expect(err.Error()).To(ContainSubstring("invalid webhook url"))
+			})
+		})
+	})
+
+	Describe("team.DestroyWebhook", func() {
+		Context("when the webhook exists", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/webhooks/some-webhook"),
+						ghttp.RespondWith(http.StatusNoContent, ""),
+					),
+				)
+			})
+
+			It("returns true", func() {
+				found, err := team.DestroyWebhook("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("DELETE", "/api/v1/teams/some-team/webhooks/some-webhook"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				found, err := team.DestroyWebhook("some-webhook")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+
+	Describe("team.WebhookDeliveries", func() {
+		Context("when the webhook exists", func() {
+			var expectedDeliveries []atc.WebhookDelivery
+
+			BeforeEach(func() {
+				expectedDeliveries = []atc.WebhookDelivery{
+					{
+						ID:       1,
+						BuildID:  42,
+						Status:   atc.StatusFailed,
+						State:    atc.WebhookDeliveryDelivered,
+						Attempts: 1,
+					},
+				}
+
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/webhooks/some-webhook/deliveries", "limit=5"),
+						ghttp.RespondWithJSONEncoded(http.StatusOK, expectedDeliveries),
+					),
+				)
+			})
+
+			It("returns the deliveries", func() {
+				deliveries, found, err := team.WebhookDeliveries("some-webhook", 5)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeTrue())
+				Expect(deliveries).To(Equal(expectedDeliveries))
+			})
+		})
+
+		Context("when the webhook does not exist", func() {
+			BeforeEach(func() {
+				atcServer.AppendHandlers(
+					ghttp.CombineHandlers(
+						ghttp.VerifyRequest("GET", "/api/v1/teams/some-team/webhooks/some-webhook/deliveries"),
+						ghttp.RespondWith(http.StatusNotFound, ""),
+					),
+				)
+			})
+
+			It("returns false", func() {
+				_, found, err := team.WebhookDeliveries("some-webhook", 0)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(found).To(BeFalse())
+			})
+		})
+	})
+})
@@ -7,6 +7,7 @@ require (
 	code.cloudfoundry.org/lager v2.0.0+incompatible
 	code.cloudfoundry.org/localip v0.0.0-20170223024724-b88ad0dea95c
 	code.cloudfoundry.org/urljoiner v0.0.0-20170223060717-5cabba6c0a50
+	filippo.io/age v1.0.0-beta7
 	github.com/DataDog/datadog-go v3.2.0+incompatible
 	github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace v0.11.0
 	github.com/Masterminds/squirrel v1.1.0
@@ -22,7 +23,7 @@ require (
 	github.com/concourse/flag v1.1.0
 	github.com/concourse/go-archive v1.0.1
 	github.com/concourse/retryhttp v1.1.0
-	github.com/containerd/cgroups v0.0.0-20191220161829-06e718085901 // indirect
+	github.com/containerd/cgroups v0.0.0-20191220161829-06e718085901
 	github.com/containerd/containerd v1.3.4
 	github.com/containerd/continuity v0.0.0-20191214063359-1097c8bae83b // indirect
 	github.com/containerd/fifo v0.0.0-20191213151349-ff969a566b00 // indirect
@@ -35,6 +36,7 @@ require (
 	github.com/docker/go-events v0.0.0-20190806004212-e31b211e4f1c // indirect
 	github.com/fatih/color v1.9.0
 	github.com/felixge/httpsnoop v1.0.1
+	github.com/fsnotify/fsnotify v1.4.9
 	github.com/gobuffalo/packr v1.13.7
 	github.com/gobwas/glob v0.2.3
 	github.com/gogo/googleapis v1.3.1 // indirect
@@ -98,6 +100,7 @@ require (
 	google.golang.org/grpc v1.32.0
 	gopkg.in/square/go-jose.v2 v2.5.1
 	gopkg.in/yaml.v2 v2.3.0
+	gopkg.in/yaml.v3 v3.0.0-20200615113413-eeeca48fe776
 	k8s.io/api v0.18.8
 	k8s.io/apimachinery v0.18.8
 	k8s.io/client-go v0.18.8
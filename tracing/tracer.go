@@ -147,17 +147,37 @@ type WithSpanContext interface {
 	SpanContext() propagation.HTTPSupplier
 }
 
+// StartSpanFollowing creates a span as a child of the span context carried by
+// `following`. The span is additionally linked to the span contexts in
+// `links`, e.g. those of the versions a build was triggered by, so that work
+// spanning several traces can be followed from one to the other.
+//
 func StartSpanFollowing(
 	ctx context.Context,
 	following WithSpanContext,
 	component string,
 	attrs Attrs,
+	links ...propagation.HTTPSupplier,
 ) (context.Context, trace.Span) {
 	if supplier := following.SpanContext(); supplier != nil {
 		ctx = trace.TraceContext{}.Extract(ctx, supplier)
 	}
 
-	return startSpan(ctx, component, attrs)
+	var opts []trace.StartOption
+	for _, supplier := range links {
+		if supplier == nil {
+			continue
+		}
+
+		linkedSpanContext := trace.RemoteSpanContextFromContext(
+			trace.TraceContext{}.Extract(context.Background(), supplier),
+		)
+		if linkedSpanContext.IsValid() {
+			opts = append(opts, trace.LinkedTo(linkedSpanContext))
+		}
+	}
+
+	return startSpan(ctx, component, attrs, opts...)
 }
 
 func StartSpanLinkedToFollowing(
@@ -203,6 +223,18 @@ func startSpan(
 	return ctx, span
 }
 
+// SetAttrs adds attributes to the span carried by ctx. This is useful for
+// attributes only known once the span has started, e.g. the worker a step
+// ended up running on.
+//
+func SetAttrs(ctx context.Context, attrs Attrs) {
+	if !Configured || len(attrs) == 0 {
+		return
+	}
+
+	trace.SpanFromContext(ctx).SetAttributes(keyValueSlice(attrs)...)
+}
+
 func End(span trace.Span, err error) {
 	if !Configured {
 		return
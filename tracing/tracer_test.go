@@ -6,6 +6,7 @@ import (
 	"github.com/concourse/concourse/tracing"
 	"github.com/concourse/concourse/tracing/tracingfakes"
 	"go.opentelemetry.io/otel/api/global"
+	"go.opentelemetry.io/otel/api/propagation"
 	"go.opentelemetry.io/otel/api/trace"
 	"go.opentelemetry.io/otel/label"
 
@@ -16,11 +17,12 @@ import (
 var _ = Describe("Tracer", func() {
 
 	var (
-		fakeSpan *tracingfakes.FakeSpan
+		fakeTracer *tracingfakes.FakeTracer
+		fakeSpan   *tracingfakes.FakeSpan
 	)
 
 	BeforeEach(func() {
-		fakeTracer := new(tracingfakes.FakeTracer)
+		fakeTracer = new(tracingfakes.FakeTracer)
 		fakeProvider := new(tracingfakes.FakeProvider)
 		fakeSpan = new(tracingfakes.FakeSpan)
 
@@ -75,6 +77,69 @@ var _ = Describe("Tracer", func() {
 
 	})
 
+	Describe("StartSpanFollowing", func() {
+		var (
+			following spanContextCarrier
+			links     []propagation.HTTPSupplier
+		)
+
+		BeforeEach(func() {
+			following = spanContextCarrier{
+				"traceparent": "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01",
+			}
+			links = nil
+		})
+
+		JustBeforeEach(func() {
+			tracing.StartSpanFollowing(context.Background(), following, "a", nil, links...)
+		})
+
+		It("starts the span as a child of the span it follows", func() {
+			Expect(fakeTracer.StartCallCount()).To(Equal(1))
+
+			ctx, _, _ := fakeTracer.StartArgsForCall(0)
+			parent := trace.RemoteSpanContextFromContext(ctx)
+			Expect(parent.TraceID.String()).To(Equal("4bf92f3577b34da6a3ce929d0e0e4736"))
+		})
+
+		Context("with links", func() {
+			BeforeEach(func() {
+				links = []propagation.HTTPSupplier{
+					spanContextCarrier{
+						"traceparent": "00-0af7651916cd43dd8448eb211c80319c-b7ad6b7169203331-01",
+					},
+					spanContextCarrier{},
+					nil,
+				}
+			})
+
+			It("links the span to the valid span contexts", func() {
+				_, _, opts := fakeTracer.StartArgsForCall(0)
+
+				config := &trace.StartConfig{}
+				for _, opt := range opts {
+					opt(config)
+				}
+
+				Expect(config.Links).To(HaveLen(1))
+				Expect(config.Links[0].TraceID.String()).To(Equal("0af7651916cd43dd8448eb211c80319c"))
+				Expect(config.Links[0].SpanID.String()).To(Equal("b7ad6b7169203331"))
+			})
+		})
+	})
+
+	Describe("SetAttrs", func() {
+		It("sets the attributes on the span in the context", func() {
+			ctx := trace.ContextWithSpan(context.Background(), fakeSpan)
+			tracing.SetAttrs(ctx, tracing.Attrs{"worker": "some-worker"})
+
+			Expect(fakeSpan.SetAttributesCallCount()).To(Equal(1))
+			Expect(fakeSpan.SetAttributesArgsForCall(0)).To(ConsistOf(
+				label.String("worker", "some-worker"),
+			))
+		})
+	})
+
 	Describe("Prepare", func() {
 		BeforeEach(func() {
 			tracing.Configured = false
@@ -108,3 +173,9 @@ var _ = Describe("Tracer", func() {
 		})
 	})
 })
+
+type spanContextCarrier map[string]string
+
+func (c spanContextCarrier) SpanContext() propagation.HTTPSupplier { return c }
+func (c spanContextCarrier) Get(key string) string                 { return c[key] }
+func (c spanContextCarrier) Set(key, value string)                 { c[key] = value }
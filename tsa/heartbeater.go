@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"math"
 	"net/http"
 	"net/url"
 	"strings"
@@ -36,6 +37,9 @@ type Heartbeater struct {
 
 	registration atc.Worker
 	eventWriter  EventWriter
+
+	cpuUsage      map[string]uint64
+	lastCPUSample time.Time
 }
 
 func NewHeartbeater(
@@ -281,10 +285,94 @@ func (heartbeater *Heartbeater) pingWorker(logger lager.Logger) (atc.Worker, boo
 
 	registration.ActiveContainers = len(containers)
 	registration.ActiveVolumes = len(volumes)
+	registration.Resources = heartbeater.resources(logger, containers)
 
 	return registration, true
 }
 
+// resources determines the worker's free memory, free disk space and CPU
+// load from the Garden server's capacity and the metrics of its containers.
+//
+// Workers which do not register their number of CPU cores are reported as
+// having no CPU load, as it cannot be normalized.
+//
+// Runtimes which do not report their capacity are treated as not reporting
+// resources at all, rather than as having none available.
+func (heartbeater *Heartbeater) resources(logger lager.Logger, containers []gclient.Container) *atc.WorkerResources {
+	capacity, err := heartbeater.gardenClient.Capacity()
+	if err != nil {
+		logger.Debug("failed-to-fetch-capacity", lager.Data{"error": err.Error()})
+		return nil
+	}
+
+	if capacity.MemoryInBytes == 0 {
+		return nil
+	}
+
+	handles := make([]string, len(containers))
+	for i, container := range containers {
+		handles[i] = container.Handle()
+	}
+
+	metrics, err := heartbeater.gardenClient.BulkMetrics(handles)
+	if err != nil {
+		logger.Debug("failed-to-fetch-container-metrics", lager.Data{"error": err.Error()})
+		return nil
+	}
+
+	// CPU usage is cumulative per container, so the load is measured as the
+	// usage of each container since the previous heartbeat. Containers which
+	// were created in the meantime are only counted from the next heartbeat
+	// on, and containers which went away are no longer counted.
+	cpuUsage := map[string]uint64{}
+
+	var usedMemory, usedDisk, cpuTime uint64
+	for handle, entry := range metrics {
+		if entry.Err != nil {
+			continue
+		}
+
+		usedMemory += entry.Metrics.MemoryStat.TotalUsageTowardLimit
+		usedDisk += entry.Metrics.DiskStat.ExclusiveBytesUsed
+
+		usage := entry.Metrics.CPUStat.Usage
+		if previous, found := heartbeater.cpuUsage[handle]; found && usage > previous {
+			cpuTime += usage - previous
+		}
+
+		cpuUsage[handle] = usage
+	}
+
+	now := heartbeater.clock.Now()
+	cpus := heartbeater.registration.CPUs
+
+	var cpuLoad float64
+	if cpus > 0 && !heartbeater.lastCPUSample.IsZero() {
+		elapsed := now.Sub(heartbeater.lastCPUSample)
+		if elapsed > 0 {
+			cpuLoad = math.Min(1, float64(cpuTime)/float64(elapsed.Nanoseconds())/float64(cpus))
+		}
+	}
+
+	heartbeater.cpuUsage = cpuUsage
+	heartbeater.lastCPUSample = now
+
+	return &atc.WorkerResources{
+		FreeMemory:    remaining(capacity.MemoryInBytes, usedMemory),
+		FreeDiskSpace: remaining(capacity.DiskInBytes, usedDisk),
+		CPUs:          cpus,
+		CPULoad:       cpuLoad,
+	}
+}
+
+func remaining(total uint64, used uint64) uint64 {
+	if used > total {
+		return 0
+	}
+
+	return total - used
+}
+
 func (heartbeater *Heartbeater) ttl() time.Duration {
 	return heartbeater.interval * 2
 }
@@ -3,6 +3,7 @@ package tsa_test
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"net/http"
 	"time"
 
@@ -81,6 +82,7 @@ var _ = Describe("Heartbeater", func() {
 			ResourceTypes: resourceTypes,
 			Platform:      "some-platform",
 			Tags:          []string{"some", "tags"},
+			CPUs:          4,
 		}
 
 		expectedWorker = worker
@@ -273,6 +275,103 @@ var _ = Describe("Heartbeater", func() {
 			})
 		})
 
+		Context("when Garden reports its capacity and container metrics", func() {
+			BeforeEach(func() {
+				fakeGardenClient.CapacityReturns(garden.Capacity{
+					MemoryInBytes: 16 * 1024,
+					DiskInBytes:   64 * 1024,
+				}, nil)
+
+				samples := make(chan map[string]uint64, 2)
+				samples <- map[string]uint64{
+					"some-handle":         1000,
+					"some-exiting-handle": 5000,
+				}
+				samples <- map[string]uint64{
+					"some-handle":     1000 + uint64(3*interval),
+					"some-new-handle": uint64(100 * interval),
+				}
+				close(samples)
+
+				fakeGardenClient.BulkMetricsStub = func([]string) (map[string]garden.ContainerMetricsEntry, error) {
+					metrics := map[string]garden.ContainerMetricsEntry{
+						"some-other-handle": {
+							Metrics: garden.Metrics{
+								MemoryStat: garden.ContainerMemoryStat{TotalUsageTowardLimit: 2 * 1024},
+							},
+						},
+						"some-failing-handle": {
+							Err: &garden.Error{Err: errors.New("nope")},
+						},
+					}
+
+					for handle, usage := range <-samples {
+						metrics[handle] = garden.ContainerMetricsEntry{
+							Metrics: garden.Metrics{
+								CPUStat: garden.ContainerCPUStat{Usage: usage},
+							},
+						}
+					}
+
+					entry := metrics["some-handle"]
+					entry.Metrics.MemoryStat = garden.ContainerMemoryStat{TotalUsageTowardLimit: 4 * 1024}
+					entry.Metrics.DiskStat = garden.ContainerDiskStat{ExclusiveBytesUsed: 16 * 1024}
+					metrics["some-handle"] = entry
+
+					return metrics, nil
+				}
+
+				fakeATC1.AppendHandlers(verifyRegister)
+				fakeATC2.AppendHandlers(verifyHeartbeat)
+			})
+
+			It("registers with the available resources", func() {
+				expectedWorker.ActiveContainers = 2
+				expectedWorker.ActiveVolumes = 3
+				expectedWorker.Resources = &atc.WorkerResources{
+					FreeMemory:    10 * 1024,
+					FreeDiskSpace: 48 * 1024,
+					CPUs:          4,
+				}
+				Eventually(registrations).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+			})
+
+			It("heartbeats with the share of cores used by the containers since the previous heartbeat", func() {
+				Eventually(registrations).Should(Receive())
+
+				fakeClock.WaitForWatcherAndIncrement(interval)
+				expectedWorker.ActiveContainers = 5
+				expectedWorker.ActiveVolumes = 2
+				expectedWorker.Resources = &atc.WorkerResources{
+					FreeMemory:    10 * 1024,
+					FreeDiskSpace: 48 * 1024,
+					CPUs:          4,
+					CPULoad:       0.75,
+				}
+				Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+			})
+
+			Context("when the worker does not register its number of cores", func() {
+				BeforeEach(func() {
+					worker.CPUs = 0
+					expectedWorker = worker
+				})
+
+				It("heartbeats without a cpu load", func() {
+					Eventually(registrations).Should(Receive())
+
+					fakeClock.WaitForWatcherAndIncrement(interval)
+					expectedWorker.ActiveContainers = 5
+					expectedWorker.ActiveVolumes = 2
+					expectedWorker.Resources = &atc.WorkerResources{
+						FreeMemory:    10 * 1024,
+						FreeDiskSpace: 48 * 1024,
+					}
+					Eventually(heartbeats).Should(Receive(Equal(registration{expectedWorker, 2 * interval})))
+				})
+			})
+		})
+
 		Context("when heartbeat returns worker is landed", func() {
 			BeforeEach(func() {
 				heartbeated := make(chan registration, 100)
@@ -53,6 +53,8 @@ type BuildEndpoint
     | BuildPlan
     | BuildPrep
     | AbortBuild
+    | ApproveBuild
+    | RejectBuild
     | BuildResourcesList
     | BuildEventStream
 
@@ -209,6 +211,12 @@ buildEndpointToPath endpoint =
         AbortBuild ->
             [ "abort" ]
 
+        ApproveBuild ->
+            [ "approve" ]
+
+        RejectBuild ->
+            [ "reject" ]
+
         BuildResourcesList ->
             [ "resources" ]
 
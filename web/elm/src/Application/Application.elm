@@ -143,6 +143,12 @@ handleCallback callback model =
         BuildAborted (Err err) ->
             redirectToLoginIfNecessary err ( model, [] )
 
+        BuildApproved (Err err) ->
+            redirectToLoginIfNecessary err ( model, [] )
+
+        BuildRejected (Err err) ->
+            redirectToLoginIfNecessary err ( model, [] )
+
         PausedToggled (Err err) ->
             redirectToLoginIfNecessary err ( model, [] )
 
@@ -35,6 +35,7 @@ type Asset
     | PendingIcon
     | InterruptedIcon
     | CancelledIcon
+    | SkippedIcon
     | SuccessCheckIcon
     | FailureTimesIcon
     | ExclamationTriangleIcon
@@ -245,6 +246,9 @@ toPath asset =
         CancelledIcon ->
             basePath ++ [ "ic-cancelled.svg" ]
 
+        SkippedIcon ->
+            basePath ++ [ "ic-skipped.svg" ]
+
         SuccessCheckIcon ->
             basePath ++ [ "ic-success-check.svg" ]
 
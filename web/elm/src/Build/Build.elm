@@ -235,6 +235,12 @@ handleCallback action ( model, effects ) =
         BuildAborted (Ok ()) ->
             ( model, effects )
 
+        BuildApproved (Ok ()) ->
+            ( model, effects )
+
+        BuildRejected (Ok ()) ->
+            ( model, effects )
+
         BuildPrepFetched buildId (Ok buildPrep) ->
             if buildId == model.id then
                 handleBuildPrepFetched buildPrep ( model, effects )
@@ -422,6 +428,12 @@ update msg ( model, effects ) =
         Click AbortBuildButton ->
             ( model, DoAbortBuild model.id :: effects )
 
+        Click ApproveBuildButton ->
+            ( model, DoApproveBuild model.id :: effects )
+
+        Click RejectBuildButton ->
+            ( model, DoRejectBuild model.id :: effects )
+
         Click (StepHeader id) ->
             updateOutput
                 (Build.Output.Output.handleStepTreeMsg <| StepTree.toggleStep id)
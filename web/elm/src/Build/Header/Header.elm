@@ -121,11 +121,40 @@ header session model =
                     Nothing
                 )
             ]
+                ++ approvalButtons session model
     , backgroundColor = model.status
     , tabs = tabs model
     }
 
 
+approvalButtons : Session -> Model r -> List Views.Widget
+approvalButtons session model =
+    if model.status == Concourse.BuildStatus.BuildStatusWaitingApproval then
+        [ ( Views.Approve, ApproveBuildButton, Concourse.BuildStatus.BuildStatusSucceeded )
+        , ( Views.Reject, RejectBuildButton, Concourse.BuildStatus.BuildStatusFailed )
+        ]
+            |> List.map
+                (\( type_, domID, backgroundColor ) ->
+                    Views.Button
+                        (Just
+                            { type_ = type_
+                            , isClickable = True
+                            , backgroundShade =
+                                if HoverState.isHovered domID session.hovered then
+                                    Views.Dark
+
+                                else
+                                    Views.Light
+                            , backgroundColor = backgroundColor
+                            , tooltip = False
+                            }
+                        )
+                )
+
+    else
+        []
+
+
 isPipelineArchived :
     WebData (List Concourse.Pipeline)
     -> Maybe Concourse.JobIdentifier
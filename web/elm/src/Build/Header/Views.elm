@@ -102,6 +102,8 @@ type ButtonType
     = Abort
     | Trigger
     | Rerun
+    | Approve
+    | Reject
 
 
 viewHeader : Header -> Html Message
@@ -252,6 +254,12 @@ viewButton { type_, tooltip, backgroundColor, backgroundShade, isClickable } =
                 Rerun ->
                     Assets.RerunIcon
 
+                Approve ->
+                    Assets.SuccessCheckIcon
+
+                Reject ->
+                    Assets.FailureTimesIcon
+
         accessibilityLabel =
             case type_ of
                 Abort ->
@@ -263,6 +271,12 @@ viewButton { type_, tooltip, backgroundColor, backgroundShade, isClickable } =
                 Rerun ->
                     "Rerun Build"
 
+                Approve ->
+                    "Approve Build"
+
+                Reject ->
+                    "Reject Build"
+
         domID =
             case type_ of
                 Abort ->
@@ -274,6 +288,12 @@ viewButton { type_, tooltip, backgroundColor, backgroundShade, isClickable } =
                 Rerun ->
                     Message.RerunBuildButton
 
+                Approve ->
+                    Message.ApproveBuildButton
+
+                Reject ->
+                    Message.RejectBuildButton
+
         styles =
             [ style "padding" "10px"
             , style "outline" "none"
@@ -73,4 +73,5 @@ type StepHeaderType
     | StepHeaderTask
     | StepHeaderSetPipeline
     | StepHeaderLoadVar
+    | StepHeaderApproval
     | StepHeaderAcross
@@ -49,6 +49,7 @@ init highlight build =
             , eventStreamUrlPath = Nothing
             , eventSourceOpened = False
             , highlight = highlight
+            , resourceUsage = build.resourceUsage
             }
 
         fetch =
@@ -97,6 +98,7 @@ planAndResourcesFetched buildId ( plan, resources ) model =
                     model.highlight
                     resources
                     plan
+                    |> withResourceUsage model.resourceUsage
                 )
         , eventStreamUrlPath = Just url
       }
@@ -104,6 +106,21 @@ planAndResourcesFetched buildId ( plan, resources ) model =
     )
 
 
+withResourceUsage : List Concourse.StepResourceUsage -> StepTreeModel -> StepTreeModel
+withResourceUsage usages stepTree =
+    List.foldl
+        (\usage st ->
+            { st
+                | steps =
+                    Dict.update usage.planId
+                        (Maybe.map (\step -> { step | resourceUsage = Just usage }))
+                        st.steps
+            }
+        )
+        stepTree
+        usages
+
+
 handleEnvelopes :
     List BuildEventEnvelope
     -> OutputModel
@@ -156,6 +173,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        Warning origin message time ->
+            ( updateStep origin.id (appendStepLog ("\u{001B}[1;33mWARNING: " ++ message ++ "\u{001B}[0m\n") (Just time)) model
+            , effects
+            )
+
         InitializeTask origin time ->
             ( updateStep origin.id (setInitialize time) model
             , effects
@@ -221,6 +243,11 @@ handleEvent event ( model, effects ) =
             , effects
             )
 
+        Skipped origin time ->
+            ( updateStep origin.id (setSkipped time) model
+            , effects
+            )
+
         BuildStatus status _ ->
             let
                 newSt =
@@ -348,6 +375,11 @@ setStepFinish mtime step =
     { step | finish = mtime }
 
 
+setSkipped : Time.Posix -> Step -> Step
+setSkipped time step =
+    setStepFinish (Just time) (setStepState StepStateSkipped step)
+
+
 setSetPipelineChanged : Bool -> Step -> Step
 setSetPipelineChanged changed step =
     { step | changed = changed }
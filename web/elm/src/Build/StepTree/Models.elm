@@ -49,6 +49,7 @@ type StepTree
     | Put StepID
     | SetPipeline StepID
     | LoadVar StepID
+    | Approval StepID
     | ArtifactInput StepID
     | ArtifactOutput StepID
     | Aggregate (Array StepTree)
@@ -63,6 +64,7 @@ type StepTree
     | Ensure HookedStep
     | Try StepTree
     | Timeout StepTree
+    | If StepTree
 
 
 type alias HookedStep =
@@ -94,6 +96,7 @@ type alias Step =
     , initializationExpanded : Bool
     , imageCheck : Maybe StepTree
     , imageGet : Maybe StepTree
+    , resourceUsage : Maybe Concourse.StepResourceUsage
     }
 
 
@@ -109,6 +112,7 @@ type StepState
     | StepStateSucceeded
     | StepStateFailed
     | StepStateErrored
+    | StepStateSkipped
 
 
 showStepState : StepState -> String
@@ -135,6 +139,9 @@ showStepState state =
         StepStateErrored ->
             "errored"
 
+        StepStateSkipped ->
+            "skipped"
+
 
 stepStateOrdering : Ordering StepState
 stepStateOrdering =
@@ -146,6 +153,7 @@ stepStateOrdering =
         , StepStateRunning
         , StepStatePending
         , StepStateSucceeded
+        , StepStateSkipped
         ]
 
 
@@ -200,9 +208,11 @@ type BuildEvent
     | StartPut Origin Time.Posix
     | FinishPut Origin Int Concourse.Version Concourse.Metadata (Maybe Time.Posix)
     | SetPipelineChanged Origin Bool
+    | Skipped Origin Time.Posix
     | Log Origin String (Maybe Time.Posix)
     | SelectedWorker Origin String (Maybe Time.Posix)
     | Error Origin String Time.Posix
+    | Warning Origin String Time.Posix
     | ImageCheck Origin Concourse.BuildPlan
     | ImageGet Origin Concourse.BuildPlan
     | End
@@ -272,6 +282,9 @@ activeStepIds model tree =
         LoadVar stepId ->
             [ stepId ]
 
+        Approval stepId ->
+            [ stepId ]
+
         Aggregate trees ->
             List.concatMap (activeStepIds model) (Array.toList trees)
 
@@ -305,6 +318,9 @@ activeStepIds model tree =
         Timeout subTree ->
             activeStepIds model subTree
 
+        If subTree ->
+            activeStepIds model subTree
+
         Retry _ trees ->
             trees
                 |> Array.toList
@@ -336,4 +352,4 @@ lastActive model trees =
 
 isActive : StepState -> Bool
 isActive state =
-    state /= StepStatePending && state /= StepStateCancelled
+    state /= StepStatePending && state /= StepStateCancelled && state /= StepStateSkipped
@@ -102,6 +102,10 @@ init hl resources ({ id, step } as plan) =
             constructStep id name
                 |> initBottom hl resources plan LoadVar
 
+        Concourse.BuildStepApproval name ->
+            constructStep id name
+                |> initBottom hl resources plan Approval
+
         Concourse.BuildStepAggregate plans ->
             initMultiStep hl resources id Aggregate plans Nothing
 
@@ -164,6 +168,9 @@ init hl resources ({ id, step } as plan) =
         Concourse.BuildStepTimeout subPlan ->
             initWrappedStep hl resources Timeout subPlan
 
+        Concourse.BuildStepIf subPlan ->
+            initWrappedStep hl resources If subPlan
+
 
 setImageCheck : StepID -> Concourse.BuildPlan -> StepTreeModel -> StepTreeModel
 setImageCheck stepId subPlan model =
@@ -294,6 +301,7 @@ constructStep stepId name =
     , initializationExpanded = False
     , imageCheck = Nothing
     , imageGet = Nothing
+    , resourceUsage = Nothing
     }
 
 
@@ -513,6 +521,9 @@ viewTree session model tree depth =
         LoadVar stepId ->
             viewStep model session depth stepId StepHeaderLoadVar
 
+        Approval stepId ->
+            viewStep model session depth stepId StepHeaderApproval
+
         Try subTree ->
             viewTree session model subTree depth
 
@@ -578,6 +589,9 @@ viewTree session model tree depth =
         Timeout subTree ->
             viewTree session model subTree depth
 
+        If subTree ->
+            viewTree session model subTree depth
+
         Aggregate trees ->
             Html.div [ class "aggregate" ]
                 (Array.toList <| Array.map (viewSeq session model depth) trees)
@@ -802,7 +816,8 @@ viewStepWithBody model session depth step headerType body =
                 ]
             , Html.div
                 [ style "display" "flex" ]
-                [ viewVersion step.version
+                [ viewResourceUsage step.resourceUsage
+                , viewVersion step.version
                 , case Maybe.Extra.or step.imageCheck step.imageGet of
                     Just _ ->
                         viewInitializationToggle step
@@ -995,6 +1010,48 @@ viewTimestamp { id, lineNo, date, timeZone } =
         ]
 
 
+viewResourceUsage : Maybe Concourse.StepResourceUsage -> Html Message
+viewResourceUsage resourceUsage =
+    case resourceUsage of
+        Just usage ->
+            Html.div
+                (class "resource-usage" :: Styles.stepResourceUsage)
+                [ Html.text <|
+                    String.join " · "
+                        [ "cpu " ++ formatSeconds usage.cpuSeconds
+                        , "mem " ++ formatBytes usage.peakMemoryBytes
+                        , "disk " ++ formatBytes usage.diskBytes
+                        , "wall " ++ formatSeconds usage.wallSeconds
+                        ]
+                ]
+
+        Nothing ->
+            Html.text ""
+
+
+formatSeconds : Float -> String
+formatSeconds seconds =
+    Duration.format (round (seconds * 1000))
+
+
+formatBytes : Int -> String
+formatBytes bytes =
+    let
+        scale value units =
+            case units of
+                unit :: rest ->
+                    if value < 1024 || List.isEmpty rest then
+                        String.fromFloat (toFloat (round (value * 10)) / 10) ++ unit
+
+                    else
+                        scale (value / 1024) rest
+
+                [] ->
+                    ""
+    in
+    scale (toFloat bytes) [ "B", "KiB", "MiB", "GiB", "TiB" ]
+
+
 viewVersion : Maybe Version -> Html Message
 viewVersion version =
     Maybe.withDefault Dict.empty version
@@ -1078,6 +1135,16 @@ viewStepStateWithoutTooltip state =
                     ++ attributes
                 )
 
+        StepStateSkipped ->
+            Icon.icon
+                { sizePx = 28
+                , image = Assets.SkippedIcon
+                }
+                (attribute "data-step-state" "skipped"
+                    :: Styles.stepStatusIcon
+                    ++ attributes
+                )
+
         StepStateSucceeded ->
             Icon.icon
                 { sizePx = 28
@@ -1156,6 +1223,16 @@ viewStepState state stepID =
                     ++ attributes
                 )
 
+        StepStateSkipped ->
+            Icon.icon
+                { sizePx = 28
+                , image = Assets.SkippedIcon
+                }
+                (attribute "data-step-state" "skipped"
+                    :: Styles.stepStatusIcon
+                    ++ attributes
+                )
+
         StepStateSucceeded ->
             Icon.icon
                 { sizePx = 28
@@ -1230,6 +1307,9 @@ viewStepHeaderLabel headerType changed stepID =
                 StepHeaderLoadVar ->
                     "load_var:"
 
+                StepHeaderApproval ->
+                    "approval:"
+
                 StepHeaderAcross ->
                     "across:"
         ]
@@ -17,6 +17,7 @@ module Build.Styles exposing
     , retryTabList
     , stepHeader
     , stepHeaderLabel
+    , stepResourceUsage
     , stepStatusIcon
     , tab
     , triggerButton
@@ -43,6 +44,9 @@ header status =
             BuildStatusStarted ->
                 Colors.startedFaded
 
+            BuildStatusWaitingApproval ->
+                Colors.startedFaded
+
             BuildStatusPending ->
                 Colors.pending
 
@@ -90,6 +94,9 @@ historyItem currentBuildStatus isCurrent status =
                         , thinColor = Colors.started
                         }
 
+                BuildStatusWaitingApproval ->
+                    [ style "background" Colors.startedFaded ]
+
                 BuildStatusPending ->
                     [ style "background" Colors.pending ]
 
@@ -203,6 +210,9 @@ stepHeader state =
             StepStateCancelled ->
                 "transparent"
 
+            StepStateSkipped ->
+                "transparent"
+
             StepStateSucceeded ->
                 "transparent"
     ]
@@ -309,6 +319,17 @@ type MetadataCellType
     | Value
 
 
+stepResourceUsage : List (Html.Attribute msg)
+stepResourceUsage =
+    [ style "display" "flex"
+    , style "align-items" "center"
+    , style "padding" "0 10px"
+    , style "color" Colors.pending
+    , style "font-size" "12px"
+    , style "white-space" "nowrap"
+    ]
+
+
 metadataTable : List (Html.Attribute msg)
 metadataTable =
     [ style "border-collapse" "collapse"
@@ -45,6 +45,7 @@ module Colors exposing
     , pinMenuHover
     , pinTools
     , pinned
+    , policyWarning
     , resourceError
     , retryTabText
     , secondaryTopBar
@@ -355,6 +356,11 @@ resourceError =
     "#e67e22"
 
 
+policyWarning : String
+policyWarning =
+    "#fad43b"
+
+
 
 -----
 
@@ -535,6 +541,9 @@ buildStatusColor isBright status =
             BuildStatusStarted ->
                 started
 
+            BuildStatusWaitingApproval ->
+                started
+
             BuildStatusPending ->
                 pending
 
@@ -555,6 +564,9 @@ buildStatusColor isBright status =
             BuildStatusStarted ->
                 startedFaded
 
+            BuildStatusWaitingApproval ->
+                startedFaded
+
             BuildStatusPending ->
                 pendingFaded
 
@@ -32,6 +32,7 @@ module Concourse exposing
     , PipelineName
     , Resource
     , ResourceIdentifier
+    , StepResourceUsage
     , Team
     , TeamName
     , User
@@ -169,6 +170,7 @@ type alias Build =
     , status : BuildStatus
     , duration : BuildDuration
     , reapTime : Maybe Time.Posix
+    , resourceUsage : List StepResourceUsage
     }
 
 
@@ -190,6 +192,11 @@ encodeBuild build =
          , optionalField "start_time" (secondsFromDate >> Json.Encode.int) build.duration.startedAt
          , optionalField "end_time" (secondsFromDate >> Json.Encode.int) build.duration.finishedAt
          , optionalField "reap_time" (secondsFromDate >> Json.Encode.int) build.reapTime
+         , if List.isEmpty build.resourceUsage then
+            Nothing
+
+           else
+            Just ( "resource_usage", build.resourceUsage |> Json.Encode.list encodeStepResourceUsage )
          ]
             |> List.filterMap identity
         )
@@ -225,6 +232,41 @@ decodeBuild =
                 |> andMap (Json.Decode.maybe (Json.Decode.field "end_time" (Json.Decode.map dateFromSeconds Json.Decode.int)))
             )
         |> andMap (Json.Decode.maybe (Json.Decode.field "reap_time" (Json.Decode.map dateFromSeconds Json.Decode.int)))
+        |> andMap (defaultTo [] <| Json.Decode.field "resource_usage" <| Json.Decode.list decodeStepResourceUsage)
+
+
+
+-- StepResourceUsage
+
+
+type alias StepResourceUsage =
+    { planId : String
+    , cpuSeconds : Float
+    , peakMemoryBytes : Int
+    , diskBytes : Int
+    , wallSeconds : Float
+    }
+
+
+decodeStepResourceUsage : Json.Decode.Decoder StepResourceUsage
+decodeStepResourceUsage =
+    Json.Decode.succeed StepResourceUsage
+        |> andMap (Json.Decode.field "plan_id" Json.Decode.string)
+        |> andMap (defaultTo 0 <| Json.Decode.field "cpu_seconds" Json.Decode.float)
+        |> andMap (defaultTo 0 <| Json.Decode.field "peak_memory_bytes" Json.Decode.int)
+        |> andMap (defaultTo 0 <| Json.Decode.field "disk_bytes" Json.Decode.int)
+        |> andMap (defaultTo 0 <| Json.Decode.field "wall_seconds" Json.Decode.float)
+
+
+encodeStepResourceUsage : StepResourceUsage -> Json.Encode.Value
+encodeStepResourceUsage usage =
+    Json.Encode.object
+        [ ( "plan_id", usage.planId |> Json.Encode.string )
+        , ( "cpu_seconds", usage.cpuSeconds |> Json.Encode.float )
+        , ( "peak_memory_bytes", usage.peakMemoryBytes |> Json.Encode.int )
+        , ( "disk_bytes", usage.diskBytes |> Json.Encode.int )
+        , ( "wall_seconds", usage.wallSeconds |> Json.Encode.float )
+        ]
 
 
 
@@ -351,6 +393,9 @@ mapBuildPlan fn plan =
                 BuildStepLoadVar _ ->
                     []
 
+                BuildStepApproval _ ->
+                    []
+
                 BuildStepArtifactInput _ ->
                     []
 
@@ -402,6 +447,9 @@ mapBuildPlan fn plan =
 
                 BuildStepTimeout step ->
                     mapBuildPlan fn step
+
+                BuildStepIf step ->
+                    mapBuildPlan fn step
            )
 
 
@@ -413,6 +461,7 @@ type BuildStep
     = BuildStepTask StepName
     | BuildStepSetPipeline StepName
     | BuildStepLoadVar StepName
+    | BuildStepApproval StepName
     | BuildStepArtifactInput StepName
     | BuildStepCheck StepName
     | BuildStepGet StepName (Maybe Version)
@@ -430,6 +479,7 @@ type BuildStep
     | BuildStepTry BuildPlan
     | BuildStepRetry (Array BuildPlan)
     | BuildStepTimeout BuildPlan
+    | BuildStepIf BuildPlan
 
 
 type alias HookedPlan =
@@ -518,10 +568,14 @@ decodeBuildPlan =
                     lazy (\_ -> decodeBuildStepRetry)
                 , Json.Decode.field "timeout" <|
                     lazy (\_ -> decodeBuildStepTimeout)
+                , Json.Decode.field "if" <|
+                    lazy (\_ -> decodeBuildStepIf)
                 , Json.Decode.field "set_pipeline" <|
                     lazy (\_ -> decodeBuildSetPipeline)
                 , Json.Decode.field "load_var" <|
                     lazy (\_ -> decodeBuildStepLoadVar)
+                , Json.Decode.field "approval" <|
+                    lazy (\_ -> decodeBuildStepApproval)
                 , Json.Decode.field "across" <|
                     lazy (\_ -> decodeBuildStepAcross)
                 ]
@@ -646,6 +700,12 @@ decodeBuildStepTimeout =
         |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
 
 
+decodeBuildStepIf : Json.Decode.Decoder BuildStep
+decodeBuildStepIf =
+    Json.Decode.succeed BuildStepIf
+        |> andMap (Json.Decode.field "step" <| lazy (\_ -> decodeBuildPlan))
+
+
 decodeBuildSetPipeline : Json.Decode.Decoder BuildStep
 decodeBuildSetPipeline =
     Json.Decode.succeed BuildStepSetPipeline
@@ -658,6 +718,12 @@ decodeBuildStepLoadVar =
         |> andMap (Json.Decode.field "name" Json.Decode.string)
 
 
+decodeBuildStepApproval : Json.Decode.Decoder BuildStep
+decodeBuildStepApproval =
+    Json.Decode.succeed BuildStepApproval
+        |> andMap (Json.Decode.field "name" Json.Decode.string)
+
+
 decodeBuildStepAcross : Json.Decode.Decoder BuildStep
 decodeBuildStepAcross =
     Json.Decode.map BuildStepAcross
@@ -721,6 +787,7 @@ type alias Job =
     , inputs : List JobInput
     , outputs : List JobOutput
     , groups : List String
+    , dependsOn : List JobName
     }
 
 
@@ -752,6 +819,7 @@ encodeJob job =
         , ( "inputs", job.inputs |> Json.Encode.list encodeJobInput )
         , ( "outputs", job.outputs |> Json.Encode.list encodeJobOutput )
         , ( "groups", job.groups |> Json.Encode.list Json.Encode.string )
+        , ( "depends_on", job.dependsOn |> Json.Encode.list Json.Encode.string )
         ]
 
 
@@ -769,6 +837,7 @@ decodeJob =
         |> andMap (defaultTo [] <| Json.Decode.field "inputs" <| Json.Decode.list decodeJobInput)
         |> andMap (defaultTo [] <| Json.Decode.field "outputs" <| Json.Decode.list decodeJobOutput)
         |> andMap (defaultTo [] <| Json.Decode.field "groups" <| Json.Decode.list Json.Decode.string)
+        |> andMap (defaultTo [] <| Json.Decode.field "depends_on" <| Json.Decode.list Json.Decode.string)
 
 
 encodeJobInput : JobInput -> Json.Encode.Value
@@ -828,6 +897,7 @@ type alias Pipeline =
     , teamName : TeamName
     , groups : List PipelineGroup
     , backgroundImage : Maybe String
+    , policyWarnings : List String
     }
 
 
@@ -849,6 +919,7 @@ encodePipeline pipeline =
         , ( "team_name", pipeline.teamName |> Json.Encode.string )
         , ( "groups", pipeline.groups |> Json.Encode.list encodePipelineGroup )
         , ( "display", Json.Encode.object [ ( "background_image", pipeline.backgroundImage |> Json.Encode.Extra.maybe Json.Encode.string ) ] )
+        , ( "policy_warnings", pipeline.policyWarnings |> Json.Encode.list Json.Encode.string )
         ]
 
 
@@ -863,6 +934,7 @@ decodePipeline =
         |> andMap (Json.Decode.field "team_name" Json.Decode.string)
         |> andMap (defaultTo [] <| Json.Decode.field "groups" (Json.Decode.list decodePipelineGroup))
         |> andMap (Json.Decode.maybe (Json.Decode.at [ "display", "background_image" ] Json.Decode.string))
+        |> andMap (defaultTo [] <| Json.Decode.field "policy_warnings" <| Json.Decode.list Json.Decode.string)
 
 
 encodePipelineGroup : PipelineGroup -> Json.Encode.Value
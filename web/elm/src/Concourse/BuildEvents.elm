@@ -98,6 +98,15 @@ decodeBuildEvent =
                     "error" ->
                         Json.Decode.field "data" decodeErrorEvent
 
+                    "warning" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map3 Warning
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "message" Json.Decode.string)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "initialize-task" ->
                         Json.Decode.field
                             "data"
@@ -194,6 +203,14 @@ decodeBuildEvent =
                                 (Json.Decode.field "changed" Json.Decode.bool)
                             )
 
+                    "skipped" ->
+                        Json.Decode.field
+                            "data"
+                            (Json.Decode.map2 Skipped
+                                (Json.Decode.field "origin" decodeOrigin)
+                                (Json.Decode.field "time" <| Json.Decode.map dateFromSeconds Json.Decode.int)
+                            )
+
                     "image-check" ->
                         Json.Decode.field "data"
                             (Json.Decode.map2 ImageCheck
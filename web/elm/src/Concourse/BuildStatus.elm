@@ -16,6 +16,7 @@ import Ordering exposing (Ordering)
 type BuildStatus
     = BuildStatusPending
     | BuildStatusStarted
+    | BuildStatusWaitingApproval
     | BuildStatusSucceeded
     | BuildStatusFailed
     | BuildStatusErrored
@@ -42,6 +43,9 @@ show status =
         BuildStatusStarted ->
             "started"
 
+        BuildStatusWaitingApproval ->
+            "waiting-approval"
+
         BuildStatusSucceeded ->
             "succeeded"
 
@@ -72,6 +76,9 @@ decodeBuildStatus =
                     "started" ->
                         Json.Decode.succeed BuildStatusStarted
 
+                    "waiting-approval" ->
+                        Json.Decode.succeed BuildStatusWaitingApproval
+
                     "succeeded" ->
                         Json.Decode.succeed BuildStatusSucceeded
 
@@ -98,6 +105,9 @@ isRunning status =
         BuildStatusStarted ->
             True
 
+        BuildStatusWaitingApproval ->
+            True
+
         _ ->
             False
 
@@ -111,6 +121,9 @@ isBad status =
         BuildStatusStarted ->
             False
 
+        BuildStatusWaitingApproval ->
+            False
+
         BuildStatusSucceeded ->
             False
 
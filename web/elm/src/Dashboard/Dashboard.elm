@@ -571,6 +571,7 @@ toDashboardPipeline isStale jobsDisabled p =
     , archived = p.archived
     , stale = isStale
     , jobsDisabled = jobsDisabled
+    , policyWarnings = p.policyWarnings
     }
 
 
@@ -584,6 +585,7 @@ toConcoursePipeline p =
     , archived = p.archived
     , groups = []
     , backgroundImage = Maybe.Nothing
+    , policyWarnings = p.policyWarnings
     }
 
 
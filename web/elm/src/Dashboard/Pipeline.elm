@@ -24,6 +24,7 @@ import Html.Attributes
         , href
         , id
         , style
+        , title
         )
 import Html.Events exposing (onClick, onMouseEnter, onMouseLeave)
 import Message.Effects as Effects
@@ -217,6 +218,9 @@ pipelineStatus jobs pipeline =
             ( Just BuildStatusStarted, _ ) ->
                 PipelineStatus.PipelineStatusPending isRunning
 
+            ( Just BuildStatusWaitingApproval, _ ) ->
+                PipelineStatus.PipelineStatusPending isRunning
+
             ( Just BuildStatusSucceeded, Just since ) ->
                 if isRunning then
                     PipelineStatus.PipelineStatusSucceeded PipelineStatus.Running
@@ -280,10 +284,26 @@ headerView pipeline resourceError =
                     ]
                 ]
                 []
+            , policyWarningView pipeline
             ]
         ]
 
 
+policyWarningView : Pipeline -> Html Message
+policyWarningView pipeline =
+    if List.isEmpty pipeline.policyWarnings then
+        Html.text ""
+
+    else
+        Html.div
+            ([ class "dashboard-policy-warning"
+             , title <| String.join "\n" pipeline.policyWarnings
+             ]
+                ++ Styles.policyWarningTriangle
+            )
+            []
+
+
 bodyView : PipelinesSection -> HoverState.HoverState -> List (List Concourse.Job) -> Html Message
 bodyView section hovered layers =
     Html.div
@@ -41,6 +41,7 @@ module Dashboard.Styles exposing
     , pipelinePreviewGrid
     , pipelineSectionHeader
     , pipelineStatusIcon
+    , policyWarningTriangle
     , previewPlaceholder
     , resourceErrorTriangle
     , searchButton
@@ -563,6 +564,18 @@ resourceErrorTriangle =
     ]
 
 
+policyWarningTriangle : List (Html.Attribute msg)
+policyWarningTriangle =
+    [ style "position" "absolute"
+    , style "top" "0"
+    , style "left" "0"
+    , style "width" "0"
+    , style "height" "0"
+    , style "border-top" <| "20px solid " ++ Colors.policyWarning
+    , style "border-right" "20px solid transparent"
+    ]
+
+
 asciiArt : List (Html.Attribute msg)
 asciiArt =
     [ style "font-size" "16px"
@@ -55,6 +55,8 @@ type Callback
     | BuildHistoryFetched (Fetched (Paginated Concourse.Build))
     | PlanAndResourcesFetched Int (Fetched ( Concourse.BuildPlan, Concourse.BuildResources ))
     | BuildAborted (Fetched ())
+    | BuildApproved (Fetched ())
+    | BuildRejected (Fetched ())
     | VisibilityChanged VisibilityAction Concourse.PipelineIdentifier (Fetched ())
     | AllPipelinesFetched (Fetched (List Concourse.Pipeline))
     | GotViewport DomID (Result Browser.Dom.Error Browser.Dom.Viewport)
@@ -151,6 +151,8 @@ type Effect
     | DoTriggerBuild Concourse.JobIdentifier
     | RerunJobBuild Concourse.JobBuildIdentifier
     | DoAbortBuild Int
+    | DoApproveBuild Int
+    | DoRejectBuild Int
     | PauseJob Concourse.JobIdentifier
     | UnpauseJob Concourse.JobIdentifier
     | ResetPipelineFocus
@@ -563,6 +565,16 @@ runEffect effect key csrfToken =
                 |> Api.request
                 |> Task.attempt BuildAborted
 
+        DoApproveBuild buildId ->
+            Api.put (Endpoints.ApproveBuild |> Endpoints.Build buildId) csrfToken
+                |> Api.request
+                |> Task.attempt BuildApproved
+
+        DoRejectBuild buildId ->
+            Api.put (Endpoints.RejectBuild |> Endpoints.Build buildId) csrfToken
+                |> Api.request
+                |> Task.attempt BuildRejected
+
         Scroll direction id ->
             scroll direction id
 
@@ -49,6 +49,8 @@ type DomID
     = ToggleJobButton
     | TriggerBuildButton
     | AbortBuildButton
+    | ApproveBuildButton
+    | RejectBuildButton
     | RerunBuildButton
     | PreviousPageButton
     | NextPageButton
@@ -1224,6 +1224,9 @@ checkSection ({ checkStatus, build } as model) =
                 Just Concourse.BuildStatus.BuildStatusStarted ->
                     ( "check in progress", spinner )
 
+                Just Concourse.BuildStatus.BuildStatusWaitingApproval ->
+                    ( "check in progress", spinner )
+
                 Just Concourse.BuildStatus.BuildStatusSucceeded ->
                     ( "check succeeded", icon Assets.SuccessCheckIcon )
 
@@ -193,6 +193,11 @@ toStringTests =
                 CancelledIcon
                     |> toString
                     |> Expect.equal "/public/images/ic-cancelled.svg"
+        , test "SkippedIcon" <|
+            \_ ->
+                SkippedIcon
+                    |> toString
+                    |> Expect.equal "/public/images/ic-skipped.svg"
         , test "SuccessCheckIcon" <|
             \_ ->
                 SuccessCheckIcon
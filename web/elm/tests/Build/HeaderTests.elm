@@ -249,6 +249,58 @@ all =
                             |> .rightWidgets
                             |> Expect.equal []
                 ]
+            , describe "approval"
+                [ test "does not appear on a started build" <|
+                    \_ ->
+                        Header.header session
+                            { model | status = BuildStatusStarted }
+                            |> .rightWidgets
+                            |> Common.notContains
+                                (Views.Button <|
+                                    Just
+                                        { type_ = Views.Approve
+                                        , isClickable = True
+                                        , backgroundShade = Views.Light
+                                        , backgroundColor = BuildStatusSucceeded
+                                        , tooltip = False
+                                        }
+                                )
+                , test "approve button appears on a build waiting for approval" <|
+                    \_ ->
+                        Header.header session
+                            { model | status = BuildStatusWaitingApproval }
+                            |> .rightWidgets
+                            |> Common.contains
+                                (Views.Button <|
+                                    Just
+                                        { type_ = Views.Approve
+                                        , isClickable = True
+                                        , backgroundShade = Views.Light
+                                        , backgroundColor = BuildStatusSucceeded
+                                        , tooltip = False
+                                        }
+                                )
+                , test "reject button is hoverable" <|
+                    \_ ->
+                        Header.header
+                            { session
+                                | hovered =
+                                    HoverState.Hovered
+                                        Message.RejectBuildButton
+                            }
+                            { model | status = BuildStatusWaitingApproval }
+                            |> .rightWidgets
+                            |> Common.contains
+                                (Views.Button <|
+                                    Just
+                                        { type_ = Views.Reject
+                                        , isClickable = True
+                                        , backgroundShade = Views.Dark
+                                        , backgroundColor = BuildStatusFailed
+                                        , tooltip = False
+                                        }
+                                )
+                ]
             ]
         , test "stops fetching history once current build appears" <|
             \_ ->
@@ -447,6 +499,7 @@ build =
     , status = model.status
     , duration = model.duration
     , reapTime = Nothing
+    , resourceUsage = []
     }
 
 
@@ -637,6 +637,7 @@ all =
                                         , finishedAt = Nothing
                                         }
                                     , reapTime = Nothing
+                                    , resourceUsage = []
                                     }
                             )
                         |> Tuple.first
@@ -680,6 +681,7 @@ all =
                                     , finishedAt = buildTime
                                     }
                                 , reapTime = buildTime
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -717,6 +719,7 @@ all =
                                     , finishedAt = Just <| Time.millisToPosix 0
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -745,6 +748,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -784,6 +788,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -844,6 +849,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -872,6 +878,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -893,6 +900,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -909,6 +917,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -930,6 +939,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -963,6 +973,7 @@ all =
                                     , finishedAt = Nothing
                                     }
                                 , reapTime = Nothing
+                                , resourceUsage = []
                                 }
                         )
                     |> Tuple.first
@@ -1137,6 +1148,7 @@ all =
                                 , inputs = []
                                 , outputs = []
                                 , groups = []
+                                , dependsOn = []
                                 }
                         )
                     |> Tuple.first
@@ -1191,6 +1203,7 @@ all =
                                 , inputs = []
                                 , outputs = []
                                 , groups = []
+                                , dependsOn = []
                                 }
                         )
                     |> Tuple.first
@@ -1225,6 +1238,7 @@ all =
                                 , inputs = []
                                 , outputs = []
                                 , groups = []
+                                , dependsOn = []
                                 }
                         )
                     |> Tuple.first
@@ -1765,6 +1779,7 @@ all =
                                                 , finishedAt = Just <| Time.millisToPosix 0
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -1809,6 +1824,7 @@ all =
                                                 , finishedAt = Just <| Time.millisToPosix 0
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -1862,6 +1878,7 @@ all =
                                                 , finishedAt = Just <| Time.millisToPosix 0
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -1903,6 +1920,7 @@ all =
                                                 , finishedAt = Just <| Time.millisToPosix 0
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -1944,6 +1962,7 @@ all =
                                                 , finishedAt = Just <| Time.millisToPosix 0
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -2142,6 +2161,7 @@ all =
                                                 , finishedAt = Nothing
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -2245,6 +2265,7 @@ all =
                                                 , finishedAt = Nothing
                                                 }
                                           , reapTime = Nothing
+                                          , resourceUsage = []
                                           }
                                         ]
                                     }
@@ -3564,6 +3585,32 @@ all =
                                 }
                                 ++ [ style "background-size" "14px 14px" ]
                             )
+                , test "step with a warning does not have an exclamation triangle" <|
+                    fetchPlanWithGetStep
+                        >> Application.handleDelivery
+                            (EventsReceived <|
+                                Ok <|
+                                    [ { url = eventsUrl
+                                      , data =
+                                            STModels.Warning
+                                                { source = "stderr", id = "plan" }
+                                                "warning message"
+                                                (Time.millisToPosix 0)
+                                      }
+                                    ]
+                            )
+                        >> Tuple.first
+                        >> Common.queryView
+                        >> Query.find [ class "header" ]
+                        >> Query.children []
+                        >> Query.index -1
+                        >> Query.hasNot
+                            (iconSelector
+                                { size = "28px"
+                                , image = Assets.ExclamationTriangleIcon
+                                }
+                                ++ [ style "background-size" "14px 14px" ]
+                            )
                 , test "successful step has no border" <|
                     fetchPlanWithGetStep
                         >> Application.handleDelivery
@@ -3752,6 +3799,7 @@ all =
                                             , finishedAt = Nothing
                                             }
                                         , reapTime = Nothing
+                                        , resourceUsage = []
                                         }
                                 )
                             |> Tuple.first
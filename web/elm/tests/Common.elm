@@ -168,6 +168,7 @@ myBrowserFetchedTheBuild =
                         , finishedAt = Nothing
                         }
                     , reapTime = Nothing
+                    , resourceUsage = []
                     }
             )
 
@@ -265,6 +265,7 @@ job =
     , inputs = []
     , outputs = []
     , groups = []
+    , dependsOn = []
     }
 
 
@@ -279,6 +280,7 @@ withNextBuild j =
                 , status = BuildStatusStarted
                 , duration = { startedAt = Nothing, finishedAt = Nothing }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
     }
 
@@ -294,6 +296,7 @@ withStatus status j =
                 , status = status
                 , duration = { startedAt = Nothing, finishedAt = Nothing }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
     }
 
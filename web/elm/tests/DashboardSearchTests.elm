@@ -61,6 +61,7 @@ hasData =
                                         , finishedAt = Nothing
                                         }
                                     , reapTime = Nothing
+                                    , resourceUsage = []
                                     }
                           , finishedBuild = Nothing
                           , transitionBuild = Nothing
@@ -69,6 +70,7 @@ hasData =
                           , inputs = []
                           , outputs = []
                           , groups = []
+                          , dependsOn = []
                           }
                         ]
                 )
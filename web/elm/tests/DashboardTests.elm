@@ -680,6 +680,40 @@ all =
                     |> Tuple.first
                     |> Common.queryView
                     |> Query.has [ class "card", containing [ text "pipeline" ] ]
+        , test "pipeline cards flag pipelines with policy warnings" <|
+            \_ ->
+                whenOnDashboard { highDensity = False }
+                    |> Application.handleCallback
+                        (Callback.AllPipelinesFetched <|
+                            Ok
+                                [ Data.pipeline "team" 0
+                                    |> Data.withName "pipeline"
+                                    |> (\p -> { p | policyWarnings = [ "privileged tasks are discouraged", "pin your images" ] })
+                                ]
+                        )
+                    |> Tuple.first
+                    |> givenDataUnauthenticated []
+                    |> Tuple.first
+                    |> Common.queryView
+                    |> Query.find [ class "card-header" ]
+                    |> Query.find [ class "dashboard-policy-warning" ]
+                    |> Query.has
+                        [ attribute <| Attr.title "privileged tasks are discouraged\npin your images"
+                        , style "border-top" "20px solid #fad43b"
+                        ]
+        , test "pipeline cards without policy warnings are not flagged" <|
+            \_ ->
+                whenOnDashboard { highDensity = False }
+                    |> Application.handleCallback
+                        (Callback.AllPipelinesFetched <|
+                            Ok
+                                [ Data.pipeline "team" 0 |> Data.withName "pipeline" ]
+                        )
+                    |> Tuple.first
+                    |> givenDataUnauthenticated []
+                    |> Tuple.first
+                    |> Common.queryView
+                    |> Query.hasNot [ class "dashboard-policy-warning" ]
         , test "high-density pipeline cards continue to show when teams refresh" <|
             \_ ->
                 whenOnDashboard { highDensity = True }
@@ -711,6 +745,7 @@ all =
                                             , status = BuildStatusSucceeded
                                             , duration = { startedAt = Nothing, finishedAt = Nothing }
                                             , reapTime = Nothing
+                                            , resourceUsage = []
                                             }
                                   , transitionBuild = Nothing
                                   , paused = False
@@ -718,6 +753,7 @@ all =
                                   , inputs = []
                                   , outputs = []
                                   , groups = []
+                                  , dependsOn = []
                                   }
                                 ]
                         )
@@ -2224,6 +2260,7 @@ running j =
                     , finishedAt = Nothing
                     }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
     }
 
@@ -2255,6 +2292,7 @@ jobWithNameTransitionedAt jobName transitionedAt status =
                 , finishedAt = Nothing
                 }
             , reapTime = Nothing
+            , resourceUsage = []
             }
     , transitionBuild =
         transitionedAt
@@ -2269,6 +2307,7 @@ jobWithNameTransitionedAt jobName transitionedAt status =
                         , finishedAt = Just <| t
                         }
                     , reapTime = Nothing
+                    , resourceUsage = []
                     }
                 )
     , paused = False
@@ -2276,6 +2315,7 @@ jobWithNameTransitionedAt jobName transitionedAt status =
     , inputs = []
     , outputs = []
     , groups = []
+    , dependsOn = []
     }
 
 
@@ -2296,6 +2336,7 @@ circularJobs =
                     , finishedAt = Nothing
                     }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
       , transitionBuild =
             Just
@@ -2308,6 +2349,7 @@ circularJobs =
                     , finishedAt = Just <| Time.millisToPosix 0
                     }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
       , paused = False
       , disableManualTrigger = False
@@ -2320,6 +2362,7 @@ circularJobs =
             ]
       , outputs = []
       , groups = []
+      , dependsOn = []
       }
     , { name = "jobB"
       , pipelineName = "pipeline"
@@ -2336,6 +2379,7 @@ circularJobs =
                     , finishedAt = Nothing
                     }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
       , transitionBuild =
             Just
@@ -2348,6 +2392,7 @@ circularJobs =
                     , finishedAt = Just <| Time.millisToPosix 0
                     }
                 , reapTime = Nothing
+                , resourceUsage = []
                 }
       , paused = False
       , disableManualTrigger = False
@@ -2360,6 +2405,7 @@ circularJobs =
             ]
       , outputs = []
       , groups = []
+      , dependsOn = []
       }
     ]
 
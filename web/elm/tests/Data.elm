@@ -149,6 +149,7 @@ pipeline team id =
     , teamName = team
     , groups = []
     , backgroundImage = Maybe.Nothing
+    , policyWarnings = []
     }
 
 
@@ -164,6 +165,7 @@ dashboardPipeline id public =
     , archived = False
     , stale = False
     , jobsDisabled = False
+    , policyWarnings = []
     }
 
 
@@ -210,6 +212,7 @@ job pipelineID =
     , inputs = []
     , outputs = []
     , groups = []
+    , dependsOn = []
     }
 
 
@@ -378,6 +381,7 @@ build status =
                 Just <| Time.millisToPosix 0
         }
     , reapTime = Nothing
+    , resourceUsage = []
     }
 
 
@@ -403,6 +407,7 @@ jobBuild status =
                 Just <| Time.millisToPosix 0
         }
     , reapTime = Nothing
+    , resourceUsage = []
     }
 
 
@@ -886,6 +886,7 @@ someBuild =
         , finishedAt = Just <| Time.millisToPosix 0
         }
     , reapTime = Just <| Time.millisToPosix 0
+    , resourceUsage = []
     }
 
 
@@ -905,6 +906,7 @@ builds =
             , finishedAt = Nothing
             }
       , reapTime = Nothing
+      , resourceUsage = []
       }
     ]
 
@@ -932,6 +934,7 @@ someJob =
     , inputs = []
     , outputs = []
     , groups = []
+    , dependsOn = []
     }
 
 
@@ -961,6 +964,7 @@ init { disabled, paused } _ =
                     , inputs = []
                     , outputs = []
                     , groups = []
+                    , dependsOn = []
                     }
             )
         |> Tuple.first
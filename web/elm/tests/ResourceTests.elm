@@ -267,6 +267,7 @@ all =
                                         , finishedAt = Nothing
                                         }
                                     , reapTime = Nothing
+                                    , resourceUsage = []
                                     }
                                   ]
                                 )
@@ -300,6 +301,7 @@ all =
                                         , finishedAt = Nothing
                                         }
                                     , reapTime = Nothing
+                                    , resourceUsage = []
                                     }
                                   ]
                                 )
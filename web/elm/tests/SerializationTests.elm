@@ -52,6 +52,28 @@ all =
                     |> Concourse.encodeBuild
                     |> Json.Decode.decodeValue Concourse.decodeBuild
                     |> Expect.equal (Ok build)
+        , test "build resource usage encoding/decoding are inverses" <|
+            \_ ->
+                let
+                    buildWithoutUsage =
+                        Data.jobBuild BuildStatus.BuildStatusSucceeded
+
+                    build =
+                        { buildWithoutUsage
+                            | resourceUsage =
+                                [ { planId = "some-plan-id"
+                                  , cpuSeconds = 1.5
+                                  , peakMemoryBytes = 1024
+                                  , diskBytes = 2048
+                                  , wallSeconds = 10
+                                  }
+                                ]
+                        }
+                in
+                build
+                    |> Concourse.encodeBuild
+                    |> Json.Decode.decodeValue Concourse.decodeBuild
+                    |> Expect.equal (Ok build)
         , test "pipeline encoding/decoding are inverses" <|
             \_ ->
                 let
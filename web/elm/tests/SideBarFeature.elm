@@ -1829,6 +1829,7 @@ iAmLookingAtAOneOffBuildPageOnANonPhoneScreen =
                     , status = BuildStatusStarted
                     , duration = { startedAt = Nothing, finishedAt = Nothing }
                     , reapTime = Nothing
+                    , resourceUsage = []
                     }
                 )
             )
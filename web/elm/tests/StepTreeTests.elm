@@ -4,6 +4,7 @@ module StepTreeTests exposing
     , initAggregateNested
     , initEnsure
     , initGet
+    , initIf
     , initInParallel
     , initInParallelNested
     , initOnFailure
@@ -31,6 +32,7 @@ all =
         [ initTask
         , initSetPipeline
         , initLoadVar
+        , initApproval
         , initCheck
         , initGet
         , initPut
@@ -46,6 +48,7 @@ all =
         , initEnsure
         , initTry
         , initTimeout
+        , initIf
         ]
 
 
@@ -74,6 +77,7 @@ someVersionedStep version id name state =
     , initializationExpanded = False
     , imageCheck = Nothing
     , imageGet = Nothing
+    , resourceUsage = Nothing
     }
 
 
@@ -129,6 +133,26 @@ initSetPipeline =
         ]
 
 
+initApproval : Test
+initApproval =
+    let
+        { tree, steps } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "some-id"
+                , step = BuildStepApproval "some-name"
+                }
+    in
+    describe "init with Approval"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal (Models.Approval "some-id") tree
+        , test "the step" <|
+            \_ ->
+                assertSteps [ ( "some-id", someStep "some-id" "some-name" Models.StepStatePending ) ] steps
+        ]
+
+
 initLoadVar : Test
 initLoadVar =
     let
@@ -718,6 +742,34 @@ initTimeout =
         ]
 
 
+initIf : Test
+initIf =
+    let
+        { tree, steps } =
+            StepTree.init Routes.HighlightNothing
+                emptyResources
+                { id = "if-id"
+                , step =
+                    BuildStepIf { id = "task-a-id", step = BuildStepTask "task-a" }
+                }
+    in
+    describe "init with If"
+        [ test "the tree" <|
+            \_ ->
+                Expect.equal
+                    (Models.If <|
+                        Models.Task "task-a-id"
+                    )
+                    tree
+        , test "the steps" <|
+            \_ ->
+                assertSteps
+                    [ ( "task-a-id", someStep "task-a-id" "task-a" Models.StepStatePending )
+                    ]
+                    steps
+        ]
+
+
 assertSteps : List ( Routes.StepID, Models.Step ) -> Dict Routes.StepID Models.Step -> Expectation
 assertSteps expected actual =
     Expect.equalDicts (Dict.fromList expected) actual
@@ -495,6 +495,23 @@ function createGraph(svg, jobs, resources) {
     }
   }
 
+  // populate job dependency edges
+  //
+  // these also determine node ranks, linking jobs directly as there is no
+  // resource between them
+  for (var i in jobs) {
+    var job = jobs[i];
+    var id = jobNode(job.name);
+
+    for (var d in job.depends_on) {
+      var dependencyNode = jobNode(job.depends_on[d]);
+
+      if (graph.node(dependencyNode)) {
+        graph.addEdge(dependencyNode, id, "depends-on-"+job.depends_on[d], {trigger: true});
+      }
+    }
+  }
+
   // populate unconstrained job inputs
   //
   // now that we know the rank, draw one unconstrained input per rank
@@ -16,6 +16,7 @@ import (
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
 	"github.com/containerd/containerd/errdefs"
+	"github.com/opencontainers/runtime-spec/specs-go"
 )
 
 var _ garden.Backend = (*GardenBackend)(nil)
@@ -28,6 +29,7 @@ type GardenBackend struct {
 	network       Network
 	rootfsManager RootfsManager
 	userNamespace UserNamespace
+	systemInfo    SystemInfo
 	initBinPath   string
 
 	maxContainers  int
@@ -141,6 +143,10 @@ func NewGardenBackend(client libcontainerd.Client, opts ...GardenBackendOpt) (b
 		b.userNamespace = NewUserNamespace()
 	}
 
+	if b.systemInfo == nil {
+		b.systemInfo = NewSystemInfo("/")
+	}
+
 	// Because the garden server is created programmatically in the integration tests, add
 	// a sane default path
 	if b.initBinPath == "" {
@@ -163,9 +169,55 @@ func (b *GardenBackend) Start() (err error) {
 		return fmt.Errorf("setup restricted networks failed: %w", err)
 	}
 
+	err = b.reapplyNetworkRules(context.Background())
+	if err != nil {
+		return fmt.Errorf("reapply network rules: %w", err)
+	}
+
 	return
 }
 
+// reapplyNetworkRules applies the network rules of the existing containers
+// once again, as setting up the restricted networks drops the rules that
+// hook them up.
+//
+func (b *GardenBackend) reapplyNetworkRules(ctx context.Context) error {
+	containers, err := b.client.Containers(ctx)
+	if err != nil {
+		return fmt.Errorf("list containers: %w", err)
+	}
+
+	for _, container := range containers {
+		labels, err := container.Labels(ctx)
+		if err != nil {
+			return fmt.Errorf("labels retrieval: %w", err)
+		}
+
+		ip := labels[ContainerIPKey]
+		if ip == "" {
+			continue
+		}
+
+		rules, err := networkRulesFromLabels(labels)
+		if err != nil {
+			return err
+		}
+
+		for _, netIn := range rules.NetIn {
+			// a port outside of a since narrowed range can't be reserved,
+			// but it can't be handed out to another container either
+			_, _ = b.network.AllocateHostPort(container.ID(), netIn.HostPort)
+		}
+
+		err = b.network.ApplyRules(container.ID(), ip, rules)
+		if err != nil {
+			return fmt.Errorf("network apply rules: %w", err)
+		}
+	}
+
+	return nil
+}
+
 // Stop closes the client's underlying connections and frees any resources
 // associated with it.
 //
@@ -189,12 +241,24 @@ func (b *GardenBackend) Ping() (err error) {
 func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container, error) {
 	ctx := context.Background()
 
-	cont, err := b.createContainer(ctx, gdnSpec)
+	joinsNetwork := gdnSpec.Properties[JoinNetworkKey] != ""
+	if joinsNetwork && (len(gdnSpec.NetIn) > 0 || len(gdnSpec.NetOut) > 0 || gdnSpec.Properties[DenyNetOutKey] != "") {
+		return nil, ErrInvalidInput("network rules can't be set on a container joining the network of another one")
+	}
+
+	rules, err := b.networkRules(gdnSpec)
 	if err != nil {
+		b.network.ReleaseHostPorts(gdnSpec.Handle)
+		return nil, fmt.Errorf("network rules: %w", err)
+	}
+
+	cont, err := b.createContainer(ctx, gdnSpec, rules)
+	if err != nil {
+		b.network.ReleaseHostPorts(gdnSpec.Handle)
 		return nil, fmt.Errorf("new container: %w", err)
 	}
 
-	err = b.startTask(ctx, cont)
+	err = b.startTask(ctx, cont, rules, joinsNetwork)
 	if err != nil {
 		return nil, fmt.Errorf("starting task: %w", err)
 	}
@@ -203,10 +267,35 @@ func (b *GardenBackend) Create(gdnSpec garden.ContainerSpec) (garden.Container,
 		cont,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
-func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.ContainerSpec) (containerd.Container, error) {
+// networkRules gathers the port mappings and egress rules of a container
+// spec, reserving the host ports of the mappings.
+//
+func (b *GardenBackend) networkRules(gdnSpec garden.ContainerSpec) (NetworkRules, error) {
+	rules, err := networkRulesFromLabels(gdnSpec.Properties)
+	if err != nil {
+		return NetworkRules{}, err
+	}
+
+	rules.NetOut = gdnSpec.NetOut
+	rules.NetIn = nil
+
+	for _, netIn := range gdnSpec.NetIn {
+		netIn, err = resolveNetIn(b.network, gdnSpec.Handle, netIn)
+		if err != nil {
+			return NetworkRules{}, err
+		}
+
+		rules.NetIn = append(rules.NetIn, netIn)
+	}
+
+	return rules, nil
+}
+
+func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.ContainerSpec, rules NetworkRules) (containerd.Container, error) {
 	err := b.createLock.Acquire(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("acquiring create container lock: %w", err)
@@ -229,27 +318,112 @@ func (b *GardenBackend) createContainer(ctx context.Context, gdnSpec garden.Cont
 		return nil, fmt.Errorf("garden spec to oci spec: %w", err)
 	}
 
-	netMounts, err := b.network.SetupMounts(gdnSpec.Handle)
-	if err != nil {
-		return nil, fmt.Errorf("network setup mounts: %w", err)
+	var netMounts []specs.Mount
+	if peer := gdnSpec.Properties[JoinNetworkKey]; peer != "" {
+		netNsPath, err := b.networkNamespacePath(ctx, peer)
+		if err != nil {
+			return nil, fmt.Errorf("network namespace of %s: %w", peer, err)
+		}
+
+		oci.Linux.Namespaces = joinNamespace(oci.Linux.Namespaces, specs.LinuxNamespace{
+			Type: specs.NetworkNamespace,
+			Path: netNsPath,
+		})
+
+		netMounts, err = b.network.SetupSharedMounts(gdnSpec.Handle, peer, gdnSpec.Properties[NetworkAliasKey])
+		if err != nil {
+			return nil, fmt.Errorf("network setup shared mounts: %w", err)
+		}
+	} else {
+		netMounts, err = b.network.SetupMounts(gdnSpec.Handle)
+		if err != nil {
+			return nil, fmt.Errorf("network setup mounts: %w", err)
+		}
 	}
 
 	oci.Mounts = append(oci.Mounts, netMounts...)
 
-	return b.client.NewContainer(ctx, gdnSpec.Handle, gdnSpec.Properties, oci)
+	labels := gdnSpec.Properties
+	if !rules.Empty() {
+		rulesLabels, err := rules.labels()
+		if err != nil {
+			return nil, fmt.Errorf("network rules labels: %w", err)
+		}
+
+		labels = garden.Properties{}
+		for k, v := range gdnSpec.Properties {
+			labels[k] = v
+		}
+		for k, v := range rulesLabels {
+			labels[k] = v
+		}
+	}
+
+	return b.client.NewContainer(ctx, gdnSpec.Handle, labels, oci)
 }
 
-func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container) error {
+// networkNamespacePath retrieves the path to the network namespace of the
+// task of the container with the given handle.
+//
+func (b *GardenBackend) networkNamespacePath(ctx context.Context, handle string) (string, error) {
+	cont, err := b.client.GetContainer(ctx, handle)
+	if err != nil {
+		return "", fmt.Errorf("get container: %w", err)
+	}
+
+	task, err := cont.Task(ctx, nil)
+	if err != nil {
+		return "", fmt.Errorf("task lookup: %w", err)
+	}
+
+	return netNsPath(task), nil
+}
+
+// joinNamespace replaces the namespace of the same type in a list of
+// namespaces, leaving the list itself untouched as it may be shared.
+//
+func joinNamespace(namespaces []specs.LinuxNamespace, namespace specs.LinuxNamespace) []specs.LinuxNamespace {
+	joined := make([]specs.LinuxNamespace, 0, len(namespaces))
+	for _, ns := range namespaces {
+		if ns.Type == namespace.Type {
+			continue
+		}
+
+		joined = append(joined, ns)
+	}
+
+	return append(joined, namespace)
+}
+
+// startTask starts the container's task once it's been added to the network,
+// with its network rules in place. Containers joining the network of another
+// one are already part of it.
+//
+func (b *GardenBackend) startTask(ctx context.Context, cont containerd.Container, rules NetworkRules, joinsNetwork bool) error {
 	task, err := cont.NewTask(ctx, cio.NullIO, containerd.WithNoNewKeyring)
 	if err != nil {
 		return fmt.Errorf("new task: %w", err)
 	}
 
-	err = b.network.Add(ctx, task)
+	if joinsNetwork {
+		return task.Start(ctx)
+	}
+
+	ip, err := b.network.Add(ctx, task)
 	if err != nil {
 		return fmt.Errorf("network add: %w", err)
 	}
 
+	_, err = cont.SetLabels(ctx, map[string]string{ContainerIPKey: ip})
+	if err != nil {
+		return fmt.Errorf("set ip label: %w", err)
+	}
+
+	err = b.network.ApplyRules(cont.ID(), ip, rules)
+	if err != nil {
+		return fmt.Errorf("network apply rules: %w", err)
+	}
+
 	return task.Start(ctx)
 }
 
@@ -278,6 +452,8 @@ func (b *GardenBackend) Destroy(handle string) error {
 			return fmt.Errorf("deleting container: %w", err)
 		}
 
+		b.network.ReleaseHostPorts(handle)
+
 		return nil
 	}
 
@@ -286,9 +462,17 @@ func (b *GardenBackend) Destroy(handle string) error {
 		return fmt.Errorf("gracefully killing task: %w", err)
 	}
 
-	err = b.network.Remove(ctx, task)
+	labels, err := container.Labels(ctx)
 	if err != nil {
-		return fmt.Errorf("network remove: %w", err)
+		return fmt.Errorf("container labels: %w", err)
+	}
+
+	// the network belongs to the container that was joined
+	if labels[JoinNetworkKey] == "" {
+		err = b.network.Remove(ctx, task)
+		if err != nil {
+			return fmt.Errorf("network remove: %w", err)
+		}
 	}
 
 	_, err = task.Delete(ctx, containerd.WithProcessKill)
@@ -325,6 +509,7 @@ func (b *GardenBackend) Containers(properties garden.Properties) (containers []g
 			containerdContainer,
 			b.killer,
 			b.rootfsManager,
+			b.network,
 		)
 	}
 
@@ -347,6 +532,7 @@ func (b *GardenBackend) Lookup(handle string) (garden.Container, error) {
 		containerdContainer,
 		b.killer,
 		b.rootfsManager,
+		b.network,
 	), nil
 }
 
@@ -366,25 +552,73 @@ func (b *GardenBackend) GraceTime(container garden.Container) (duration time.Dur
 	return duration
 }
 
-// Capacity - Not Implemented
+// Capacity returns the memory and disk space of the host along with the
+// maximum number of containers allowed.
 //
-func (b *GardenBackend) Capacity() (capacity garden.Capacity, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) Capacity() (garden.Capacity, error) {
+	memory, err := b.systemInfo.TotalMemory()
+	if err != nil {
+		return garden.Capacity{}, fmt.Errorf("total memory: %w", err)
+	}
+
+	disk, err := b.systemInfo.AvailableDisk()
+	if err != nil {
+		return garden.Capacity{}, fmt.Errorf("available disk: %w", err)
+	}
+
+	return garden.Capacity{
+		MemoryInBytes: memory,
+		DiskInBytes:   disk,
+		MaxContainers: uint64(b.maxContainers),
+	}, nil
 }
 
-// BulkInfo - Not Implemented
+// BulkInfo returns the info of each of the containers with the specified
+// handles. Failures are reported per container.
 //
-func (b *GardenBackend) BulkInfo(handles []string) (info map[string]garden.ContainerInfoEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkInfo(handles []string) (map[string]garden.ContainerInfoEntry, error) {
+	info := make(map[string]garden.ContainerInfoEntry, len(handles))
+
+	for _, handle := range handles {
+		var entry garden.ContainerInfoEntry
+
+		container, err := b.Lookup(handle)
+		if err == nil {
+			entry.Info, err = container.Info()
+		}
+
+		if err != nil {
+			entry.Err = garden.NewError(err.Error())
+		}
+
+		info[handle] = entry
+	}
+
+	return info, nil
 }
 
-// BulkMetrics - Not Implemented
+// BulkMetrics returns the metrics of each of the containers with the
+// specified handles. Failures are reported per container.
 //
-func (b *GardenBackend) BulkMetrics(handles []string) (metrics map[string]garden.ContainerMetricsEntry, err error) {
-	err = ErrNotImplemented
-	return
+func (b *GardenBackend) BulkMetrics(handles []string) (map[string]garden.ContainerMetricsEntry, error) {
+	metrics := make(map[string]garden.ContainerMetricsEntry, len(handles))
+
+	for _, handle := range handles {
+		var entry garden.ContainerMetricsEntry
+
+		container, err := b.Lookup(handle)
+		if err == nil {
+			entry.Metrics, err = container.Metrics()
+		}
+
+		if err != nil {
+			entry.Err = garden.NewError(err.Error())
+		}
+
+		metrics[handle] = entry
+	}
+
+	return metrics, nil
 }
 
 // checkContainerCapacity ensures that Garden.MaxContainers is respected
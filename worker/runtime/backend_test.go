@@ -28,6 +28,7 @@ type BackendSuite struct {
 	network *runtimefakes.FakeNetwork
 	userns  *runtimefakes.FakeUserNamespace
 	killer  *runtimefakes.FakeKiller
+	sysinfo *runtimefakes.FakeSystemInfo
 }
 
 func (s *BackendSuite) SetupTest() {
@@ -35,12 +36,14 @@ func (s *BackendSuite) SetupTest() {
 	s.killer = new(runtimefakes.FakeKiller)
 	s.network = new(runtimefakes.FakeNetwork)
 	s.userns = new(runtimefakes.FakeUserNamespace)
+	s.sysinfo = new(runtimefakes.FakeSystemInfo)
 
 	var err error
 	s.backend, err = runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
 		runtime.WithNetwork(s.network),
 		runtime.WithUserNamespace(s.userns),
+		runtime.WithSystemInfo(s.sysinfo),
 	)
 	s.NoError(err)
 }
@@ -146,6 +149,147 @@ func (s *BackendSuite) TestCreateContainerSetsHandle() {
 	s.Equal("handle", cont.Handle())
 }
 
+func (s *BackendSuite) TestCreateContainerAppliesNetworkRules() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.IDReturns("handle")
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.AddReturns("10.80.0.2", nil)
+	s.network.AllocateHostPortReturns(8080, nil)
+
+	netOut := garden.NetOutRule{Protocol: garden.ProtocolTCP}
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		NetOut:     []garden.NetOutRule{netOut},
+		NetIn:      []garden.NetIn{{HostPort: 8080}},
+		Properties: garden.Properties{
+			runtime.DenyNetOutKey: `[{"protocol":1}]`,
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.network.AllocateHostPortCallCount())
+	handle, port := s.network.AllocateHostPortArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal(uint32(8080), port)
+
+	_, _, labels, _ := s.client.NewContainerArgsForCall(0)
+	s.Equal(`[{"host_port":8080,"container_port":8080}]`, labels[runtime.NetInKey])
+	s.Equal(`[{"protocol":1}]`, labels[runtime.NetOutKey])
+	s.Equal(`[{"protocol":1}]`, labels[runtime.DenyNetOutKey])
+
+	s.Equal(1, fakeContainer.SetLabelsCallCount())
+	_, ipLabels := fakeContainer.SetLabelsArgsForCall(0)
+	s.Equal(map[string]string{runtime.ContainerIPKey: "10.80.0.2"}, ipLabels)
+
+	s.Equal(1, s.network.ApplyRulesCallCount())
+	handle, ip, rules := s.network.ApplyRulesArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal(runtime.NetworkRules{
+		NetIn:      []garden.NetIn{{HostPort: 8080, ContainerPort: 8080}},
+		NetOut:     []garden.NetOutRule{netOut},
+		DenyNetOut: []garden.NetOutRule{netOut},
+	}, rules)
+
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerApplyRulesFailure() {
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+	s.network.ApplyRulesReturns(errors.New("apply-err"))
+
+	_, err := s.backend.Create(minimumValidGdnSpec)
+	s.EqualError(errors.Unwrap(errors.Unwrap(err)), "apply-err")
+
+	s.Equal(0, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerJoiningNetwork() {
+	peerTask := new(libcontainerdfakes.FakeTask)
+	peerTask.PidReturns(123)
+	peerContainer := new(libcontainerdfakes.FakeContainer)
+	peerContainer.TaskReturns(peerTask, nil)
+	s.client.GetContainerReturns(peerContainer, nil)
+
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.NewTaskReturns(fakeTask, nil)
+	s.client.NewContainerReturns(fakeContainer, nil)
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.JoinNetworkKey:  "peer-handle",
+			runtime.NetworkAliasKey: "postgres",
+		},
+	})
+	s.NoError(err)
+
+	_, peerHandle := s.client.GetContainerArgsForCall(0)
+	s.Equal("peer-handle", peerHandle)
+
+	s.Equal(0, s.network.SetupMountsCallCount())
+	s.Equal(1, s.network.SetupSharedMountsCallCount())
+	handle, peerHandle, alias := s.network.SetupSharedMountsArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("peer-handle", peerHandle)
+	s.Equal("postgres", alias)
+
+	_, _, _, oci := s.client.NewContainerArgsForCall(0)
+	s.Contains(oci.Linux.Namespaces, specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+		Path: "/proc/123/ns/net",
+	})
+	s.NotContains(oci.Linux.Namespaces, specs.LinuxNamespace{
+		Type: specs.NetworkNamespace,
+	})
+
+	s.Equal(0, s.network.AddCallCount())
+	s.Equal(0, s.network.ApplyRulesCallCount())
+	s.Equal(0, fakeContainer.SetLabelsCallCount())
+	s.Equal(1, fakeTask.StartCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerJoiningMissingNetwork() {
+	s.client.GetContainerReturns(nil, errors.New("not-found"))
+
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		Properties: garden.Properties{
+			runtime.JoinNetworkKey: "peer-handle",
+		},
+	})
+	s.Error(err)
+	s.Contains(err.Error(), "not-found")
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
+func (s *BackendSuite) TestCreateContainerJoiningNetworkWithRules() {
+	_, err := s.backend.Create(garden.ContainerSpec{
+		Handle:     "handle",
+		RootFSPath: "raw:///rootfs",
+		NetIn:      []garden.NetIn{{HostPort: 8080}},
+		Properties: garden.Properties{
+			runtime.JoinNetworkKey: "peer-handle",
+		},
+	})
+	s.True(errors.Is(err, runtime.ErrInvalidInput("network rules can't be set on a container joining the network of another one")))
+
+	s.Equal(0, s.client.NewContainerCallCount())
+}
+
 func (s *BackendSuite) TestCreateMaxContainersReached() {
 	backend, err := runtime.NewGardenBackend(s.client,
 		runtime.WithKiller(s.killer),
@@ -484,6 +628,22 @@ func (s *BackendSuite) TestDestroySucceeds() {
 	s.NoError(err)
 }
 
+func (s *BackendSuite) TestDestroyContainerJoiningNetworkLeavesNetwork() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	s.client.GetContainerReturns(fakeContainer, nil)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeContainer.LabelsReturns(map[string]string{
+		runtime.JoinNetworkKey: "peer-handle",
+	}, nil)
+
+	err := s.backend.Destroy("some handle")
+	s.NoError(err)
+
+	s.Equal(0, s.network.RemoveCallCount())
+	s.Equal(1, fakeTask.DeleteCallCount())
+}
+
 func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	err := s.backend.Start()
 	s.NoError(err)
@@ -491,6 +651,29 @@ func (s *BackendSuite) TestStartInitsClientAndSetsUpRestrictedNetworks() {
 	s.Equal(1, s.network.SetupRestrictedNetworksCallCount())
 }
 
+func (s *BackendSuite) TestStartReappliesNetworkRules() {
+	withRules := new(libcontainerdfakes.FakeContainer)
+	withRules.IDReturns("with-rules")
+	withRules.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.NetInKey:       `[{"host_port":8080,"container_port":80}]`,
+	}, nil)
+
+	withoutIP := new(libcontainerdfakes.FakeContainer)
+	withoutIP.LabelsReturns(map[string]string{}, nil)
+
+	s.client.ContainersReturns([]containerd.Container{withRules, withoutIP}, nil)
+
+	err := s.backend.Start()
+	s.NoError(err)
+
+	s.Equal(1, s.network.ApplyRulesCallCount())
+	handle, ip, rules := s.network.ApplyRulesArgsForCall(0)
+	s.Equal("with-rules", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal([]garden.NetIn{{HostPort: 8080, ContainerPort: 80}}, rules.NetIn)
+}
+
 func (s *BackendSuite) TestStartInitError() {
 	s.client.InitReturns(errors.New("init failed"))
 	err := s.backend.Start()
@@ -522,3 +705,76 @@ func (s *BackendSuite) TestGraceTimeReturnsDuration() {
 	result := s.backend.GraceTime(fakeContainer)
 	s.Equal(time.Duration(123), result)
 }
+
+func (s *BackendSuite) TestCapacityTotalMemoryFails() {
+	s.sysinfo.TotalMemoryReturns(0, errors.New("meminfo-err"))
+
+	_, err := s.backend.Capacity()
+	s.EqualError(errors.Unwrap(err), "meminfo-err")
+}
+
+func (s *BackendSuite) TestCapacityAvailableDiskFails() {
+	s.sysinfo.AvailableDiskReturns(0, errors.New("statfs-err"))
+
+	_, err := s.backend.Capacity()
+	s.EqualError(errors.Unwrap(err), "statfs-err")
+}
+
+func (s *BackendSuite) TestCapacity() {
+	backend, err := runtime.NewGardenBackend(s.client,
+		runtime.WithNetwork(s.network),
+		runtime.WithSystemInfo(s.sysinfo),
+		runtime.WithMaxContainers(250),
+	)
+	s.NoError(err)
+
+	s.sysinfo.TotalMemoryReturns(1024, nil)
+	s.sysinfo.AvailableDiskReturns(2048, nil)
+
+	capacity, err := backend.Capacity()
+	s.NoError(err)
+	s.Equal(garden.Capacity{
+		MemoryInBytes: 1024,
+		DiskInBytes:   2048,
+		MaxContainers: 250,
+	}, capacity)
+}
+
+func (s *BackendSuite) TestBulkInfo() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeContainer.LabelsReturns(map[string]string{"foo": "bar"}, nil)
+	fakeContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	s.client.GetContainerStub = func(_ context.Context, handle string) (containerd.Container, error) {
+		if handle == "missing" {
+			return nil, errors.New("not found")
+		}
+		return fakeContainer, nil
+	}
+
+	info, err := s.backend.BulkInfo([]string{"handle", "missing"})
+	s.NoError(err)
+	s.Len(info, 2)
+
+	s.Nil(info["handle"].Err)
+	s.Equal("stopped", info["handle"].Info.State)
+	s.Equal(garden.Properties{"foo": "bar"}, info["handle"].Info.Properties)
+
+	s.NotNil(info["missing"].Err)
+	s.Contains(info["missing"].Err.Error(), "not found")
+}
+
+func (s *BackendSuite) TestBulkMetrics() {
+	fakeContainer := new(libcontainerdfakes.FakeContainer)
+	fakeTask := new(libcontainerdfakes.FakeTask)
+	fakeContainer.TaskReturns(fakeTask, nil)
+	fakeTask.MetricsReturns(nil, errors.New("metrics-err"))
+
+	s.client.GetContainerReturns(fakeContainer, nil)
+
+	metrics, err := s.backend.BulkMetrics([]string{"handle"})
+	s.NoError(err)
+	s.Len(metrics, 1)
+	s.NotNil(metrics["handle"].Err)
+	s.Contains(metrics["handle"].Err.Error(), "metrics-err")
+}
@@ -2,10 +2,14 @@ package runtime
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"path/filepath"
+	"strconv"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime/iptables"
 	"github.com/containerd/containerd"
 	"github.com/containerd/go-cni"
@@ -45,6 +49,16 @@ const (
 	binariesDir = "/usr/local/concourse/bin"
 
 	ipTablesAdminChainName = "CONCOURSE-OPERATOR"
+
+	// containerChainPrefix prefixes the chains holding the rules of a
+	// single container, both in the filter and in the nat table.
+	//
+	containerChainPrefix = "CONCOURSE-"
+
+	// containerInterface is the name of the interface CNI sets up in the
+	// container's network namespace.
+	//
+	containerInterface = cni.DefaultPrefix + "0"
 )
 
 var (
@@ -158,6 +172,15 @@ func WithIptables(ipt iptables.Iptables) CNINetworkOpt {
 	}
 }
 
+// WithHostPortRange defines the range of ports of the host which can be
+// forwarded to containers.
+//
+func WithHostPortRange(start, end uint32) CNINetworkOpt {
+	return func(n *cniNetwork) {
+		n.hostPorts = newHostPorts(start, end)
+	}
+}
+
 type cniNetwork struct {
 	client             cni.CNI
 	store              FileStore
@@ -166,6 +189,7 @@ type cniNetwork struct {
 	binariesDir        string
 	restrictedNetworks []string
 	ipt                iptables.Iptables
+	hostPorts          *hostPorts
 }
 
 var _ Network = (*cniNetwork)(nil)
@@ -204,6 +228,10 @@ func NewCNINetwork(opts ...CNINetworkOpt) (*cniNetwork, error) {
 		}
 	}
 
+	if n.hostPorts == nil {
+		n.hostPorts = newHostPorts(DefaultHostPortRangeStart, DefaultHostPortRangeEnd)
+	}
+
 	if n.ipt == nil {
 		n.ipt, err = iptables.New()
 
@@ -228,9 +256,48 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 		return nil, fmt.Errorf("creating /etc/hosts: %w", err)
 	}
 
+	resolvConf, err := n.createResolvConf(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkMounts(etcHosts, resolvConf), nil
+}
+
+// SetupSharedMounts shares the /etc/hosts of the container whose network is
+// joined, adding an entry for the alias so that it resolves to the loopback
+// interface they share.
+//
+func (n cniNetwork) SetupSharedMounts(handle, peerHandle, alias string) ([]specs.Mount, error) {
+	if handle == "" || peerHandle == "" {
+		return nil, ErrInvalidInput("empty handle")
+	}
+
+	var entry []byte
+	if alias != "" {
+		entry = []byte("\n127.0.0.1 " + alias)
+	}
+
+	etcHosts, err := n.store.Append(
+		filepath.Join(peerHandle, "/hosts"),
+		entry,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("appending to /etc/hosts: %w", err)
+	}
+
+	resolvConf, err := n.createResolvConf(handle)
+	if err != nil {
+		return nil, err
+	}
+
+	return networkMounts(etcHosts, resolvConf), nil
+}
+
+func (n cniNetwork) createResolvConf(handle string) (string, error) {
 	resolvContents, err := n.generateResolvConfContents()
 	if err != nil {
-		return nil, fmt.Errorf("generating resolv.conf: %w", err)
+		return "", fmt.Errorf("generating resolv.conf: %w", err)
 	}
 
 	resolvConf, err := n.store.Create(
@@ -238,9 +305,13 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 		resolvContents,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("creating /etc/resolv.conf: %w", err)
+		return "", fmt.Errorf("creating /etc/resolv.conf: %w", err)
 	}
 
+	return resolvConf, nil
+}
+
+func networkMounts(etcHosts, resolvConf string) []specs.Mount {
 	return []specs.Mount{
 		{
 			Destination: "/etc/hosts",
@@ -253,7 +324,7 @@ func (n cniNetwork) SetupMounts(handle string) ([]specs.Mount, error) {
 			Source:      resolvConf,
 			Options:     []string{"bind", "rw"},
 		},
-	}, nil
+	}
 }
 
 func (n cniNetwork) SetupRestrictedNetworks() error {
@@ -293,16 +364,125 @@ func (n cniNetwork) generateResolvConfContents() ([]byte, error) {
 	return []byte(contents), err
 }
 
-func (n cniNetwork) Add(ctx context.Context, task containerd.Task) error {
+func (n cniNetwork) Add(ctx context.Context, task containerd.Task) (string, error) {
 	if task == nil {
-		return ErrInvalidInput("nil task")
+		return "", ErrInvalidInput("nil task")
 	}
 
 	id, netns := netId(task), netNsPath(task)
 
-	_, err := n.client.Setup(ctx, id, netns)
+	result, err := n.client.Setup(ctx, id, netns)
+	if err != nil {
+		return "", fmt.Errorf("cni net setup: %w", err)
+	}
+
+	ip, err := containerIP(result)
+	if err != nil {
+		return "", fmt.Errorf("cni net setup: %w", err)
+	}
+
+	return ip, nil
+}
+
+// ApplyRules sets up a chain for the container in the filter table, jumped
+// to from the admin chain, so that egress is evaluated after the restricted
+// networks set up by the operator. When ports are mapped, a chain in the nat
+// table is set up as well, jumped to for traffic destined to the host.
+//
+func (n cniNetwork) ApplyRules(handle, ip string, rules NetworkRules) error {
+	if rules.Empty() {
+		return nil
+	}
+
+	const (
+		filterTable = "filter"
+		natTable    = "nat"
+	)
+
+	chain := containerChainName(handle)
+
+	err := n.ipt.CreateChainOrFlushIfExists(filterTable, chain)
+	if err != nil {
+		return fmt.Errorf("create chain or flush if exists failed: %w", err)
+	}
+
+	for _, netIn := range rules.NetIn {
+		err = n.ipt.AppendRule(filterTable, chain,
+			"-d", ip, "-p", "tcp", "--dport", strconv.Itoa(int(netIn.ContainerPort)), "-j", "ACCEPT",
+		)
+		if err != nil {
+			return fmt.Errorf("appending accept rule for port %d failed: %w", netIn.ContainerPort, err)
+		}
+	}
+
+	// allowed egress is evaluated first so that it can carve exceptions out
+	// of the denied one
+	err = n.appendNetOutRules(filterTable, chain, ip, rules.NetOut, "ACCEPT")
+	if err != nil {
+		return err
+	}
+
+	err = n.appendNetOutRules(filterTable, chain, ip, rules.DenyNetOut, "REJECT")
+	if err != nil {
+		return err
+	}
+
+	// allowing some egress without denying any would otherwise restrict
+	// nothing at all, so it's taken to mean that anything else is denied
+	if len(rules.NetOut) > 0 && len(rules.DenyNetOut) == 0 {
+		err = n.ipt.AppendRule(filterTable, chain, "-s", ip, "-j", "REJECT")
+		if err != nil {
+			return fmt.Errorf("appending reject rule failed: %w", err)
+		}
+	}
+
+	err = n.ipt.AppendUniqueRule(filterTable, ipTablesAdminChainName, "-j", chain)
 	if err != nil {
-		return fmt.Errorf("cni net setup: %w", err)
+		return fmt.Errorf("appending jump rule to %s failed: %w", chain, err)
+	}
+
+	if len(rules.NetIn) == 0 {
+		return nil
+	}
+
+	err = n.ipt.CreateChainOrFlushIfExists(natTable, chain)
+	if err != nil {
+		return fmt.Errorf("create chain or flush if exists failed: %w", err)
+	}
+
+	for _, netIn := range rules.NetIn {
+		err = n.ipt.AppendRule(natTable, chain,
+			"-p", "tcp", "--dport", strconv.Itoa(int(netIn.HostPort)),
+			"-j", "DNAT", "--to-destination", fmt.Sprintf("%s:%d", ip, netIn.ContainerPort),
+		)
+		if err != nil {
+			return fmt.Errorf("appending dnat rule for port %d failed: %w", netIn.HostPort, err)
+		}
+	}
+
+	for _, parent := range []string{"PREROUTING", "OUTPUT"} {
+		err = n.ipt.AppendUniqueRule(natTable, parent, natJumpRule(chain)...)
+		if err != nil {
+			return fmt.Errorf("appending jump rule to %s failed: %w", chain, err)
+		}
+	}
+
+	return nil
+}
+
+func (n cniNetwork) appendNetOutRules(table, chain, ip string, rules []garden.NetOutRule, target string) error {
+	for _, rule := range rules {
+		rulespecs, err := netOutRulespecs(ip, rule)
+		if err != nil {
+			return err
+		}
+
+		for _, rulespec := range rulespecs {
+			err = n.ipt.AppendRule(table, chain, append(rulespec, "-j", target)...)
+			if err != nil {
+				return fmt.Errorf("appending %s rule failed: %w", strings.ToLower(target), err)
+			}
+		}
 	}
 
 	return nil
@@ -320,9 +500,47 @@ func (n cniNetwork) Remove(ctx context.Context, task containerd.Task) error {
 		return fmt.Errorf("cni net teardown: %w", err)
 	}
 
+	err = n.removeRules(id)
+	if err != nil {
+		return fmt.Errorf("remove rules: %w", err)
+	}
+
+	n.ReleaseHostPorts(id)
+
 	return nil
 }
 
+func (n cniNetwork) AllocateHostPort(handle string, port uint32) (uint32, error) {
+	return n.hostPorts.acquire(handle, port)
+}
+
+func (n cniNetwork) ReleaseHostPorts(handle string) {
+	n.hostPorts.release(handle)
+}
+
+func (n cniNetwork) removeRules(handle string) error {
+	chain := containerChainName(handle)
+
+	err := n.ipt.DeleteRuleIfExists("filter", ipTablesAdminChainName, "-j", chain)
+	if err != nil {
+		return err
+	}
+
+	err = n.ipt.DeleteChainIfExists("filter", chain)
+	if err != nil {
+		return err
+	}
+
+	for _, parent := range []string{"PREROUTING", "OUTPUT"} {
+		err = n.ipt.DeleteRuleIfExists("nat", parent, natJumpRule(chain)...)
+		if err != nil {
+			return err
+		}
+	}
+
+	return n.ipt.DeleteChainIfExists("nat", chain)
+}
+
 func netId(task containerd.Task) string {
 	return task.ID()
 }
@@ -330,3 +548,102 @@ func netId(task containerd.Task) string {
 func netNsPath(task containerd.Task) string {
 	return fmt.Sprintf("/proc/%d/ns/net", task.Pid())
 }
+
+// containerChainName derives the name of the chains holding the rules of a
+// container from its handle, keeping it under the 28 characters iptables
+// allows for chain names.
+//
+func containerChainName(handle string) string {
+	sum := sha256.Sum256([]byte(handle))
+	return containerChainPrefix + strings.ToUpper(hex.EncodeToString(sum[:])[:16])
+}
+
+func natJumpRule(chain string) []string {
+	return []string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", chain}
+}
+
+func containerIP(result *cni.CNIResult) (string, error) {
+	if result == nil {
+		return "", fmt.Errorf("no result")
+	}
+
+	config, found := result.Interfaces[containerInterface]
+	if !found || config == nil || len(config.IPConfigs) == 0 {
+		return "", fmt.Errorf("no ip assigned to %s", containerInterface)
+	}
+
+	return config.IPConfigs[0].IP.String(), nil
+}
+
+// netOutRulespecs converts a garden egress rule to iptables rulespecs (minus
+// the target) matching the traffic from the container with the given IP
+// address, one for each combination of network and port range.
+//
+func netOutRulespecs(ip string, rule garden.NetOutRule) ([][]string, error) {
+	base := []string{"-s", ip}
+
+	switch rule.Protocol {
+	case garden.ProtocolAll:
+		if len(rule.Ports) > 0 {
+			return nil, ErrInvalidInput("ports require protocol tcp or udp")
+		}
+	case garden.ProtocolTCP:
+		base = append(base, "-p", "tcp")
+	case garden.ProtocolUDP:
+		base = append(base, "-p", "udp")
+	case garden.ProtocolICMP:
+		base = append(base, "-p", "icmp")
+
+		if rule.ICMPs != nil {
+			icmpType := strconv.Itoa(int(rule.ICMPs.Type))
+			if rule.ICMPs.Code != nil {
+				icmpType += "/" + strconv.Itoa(int(*rule.ICMPs.Code))
+			}
+
+			base = append(base, "--icmp-type", icmpType)
+		}
+	default:
+		return nil, ErrInvalidInput(fmt.Sprintf("unknown protocol %d", rule.Protocol))
+	}
+
+	networks := [][]string{nil}
+	if len(rule.Networks) > 0 {
+		networks = nil
+		for _, network := range rule.Networks {
+			end := network.End
+			if end == nil {
+				end = network.Start
+			}
+
+			networks = append(networks, []string{
+				"-m", "iprange", "--dst-range", network.Start.String() + "-" + end.String(),
+			})
+		}
+	}
+
+	ports := [][]string{nil}
+	if len(rule.Ports) > 0 && rule.Protocol != garden.ProtocolICMP {
+		ports = nil
+		for _, port := range rule.Ports {
+			portRange := strconv.Itoa(int(port.Start))
+			if port.End > port.Start {
+				portRange += ":" + strconv.Itoa(int(port.End))
+			}
+
+			ports = append(ports, []string{"--dport", portRange})
+		}
+	}
+
+	var rulespecs [][]string
+	for _, network := range networks {
+		for _, port := range ports {
+			rulespec := append([]string{}, base...)
+			rulespec = append(rulespec, network...)
+			rulespec = append(rulespec, port...)
+
+			rulespecs = append(rulespecs, rulespec)
+		}
+	}
+
+	return rulespecs, nil
+}
@@ -3,12 +3,15 @@ package runtime_test
 import (
 	"context"
 	"errors"
+	"net"
 	"strings"
 
+	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/iptables/iptablesfakes"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	"github.com/containerd/go-cni"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -139,6 +142,58 @@ func (s *CNINetworkSuite) TestSetupMountsCallsStoreWithoutNameServers() {
 	s.Equal(resolvConfContents, []byte(contents))
 }
 
+func (s *CNINetworkSuite) TestSetupSharedMountsEmptyHandle() {
+	_, err := s.network.SetupSharedMounts("handle", "", "alias")
+	s.EqualError(err, "empty handle")
+}
+
+func (s *CNINetworkSuite) TestSetupSharedMountsFailToAppendHosts() {
+	s.store.AppendReturns("", errors.New("append-hosts-err"))
+
+	_, err := s.network.SetupSharedMounts("handle", "peer-handle", "alias")
+	s.EqualError(errors.Unwrap(err), "append-hosts-err")
+}
+
+func (s *CNINetworkSuite) TestSetupSharedMountsReturnsMountpoints() {
+	s.store.AppendReturns("/tmp/peer-handle/etc/hosts", nil)
+	s.store.CreateReturns("/tmp/handle/etc/resolv.conf", nil)
+
+	mounts, err := s.network.SetupSharedMounts("handle", "peer-handle", "alias")
+	s.NoError(err)
+
+	s.Equal(1, s.store.AppendCallCount())
+	fname, content := s.store.AppendArgsForCall(0)
+	s.Equal("peer-handle/hosts", fname)
+	s.Equal("\n127.0.0.1 alias", string(content))
+
+	s.Equal(1, s.store.CreateCallCount())
+	fname, _ = s.store.CreateArgsForCall(0)
+	s.Equal("handle/resolv.conf", fname)
+
+	s.Equal(mounts, []specs.Mount{
+		{
+			Destination: "/etc/hosts",
+			Type:        "bind",
+			Source:      "/tmp/peer-handle/etc/hosts",
+			Options:     []string{"bind", "rw"},
+		},
+		{
+			Destination: "/etc/resolv.conf",
+			Type:        "bind",
+			Source:      "/tmp/handle/etc/resolv.conf",
+			Options:     []string{"bind", "rw"},
+		},
+	})
+}
+
+func (s *CNINetworkSuite) TestSetupSharedMountsWithoutAlias() {
+	_, err := s.network.SetupSharedMounts("handle", "peer-handle", "")
+	s.NoError(err)
+
+	_, content := s.store.AppendArgsForCall(0)
+	s.Empty(content)
+}
+
 func (s *CNINetworkSuite) TestSetupRestrictedNetworksCreatesEmptyAdminChain() {
 	network, err := runtime.NewCNINetwork(
 		runtime.WithRestrictedNetworks([]string{"1.1.1.1", "8.8.8.8"}),
@@ -169,7 +224,7 @@ func (s *CNINetworkSuite) TestSetupRestrictedNetworksCreatesEmptyAdminChain() {
 }
 
 func (s *CNINetworkSuite) TestAddNilTask() {
-	err := s.network.Add(context.Background(), nil)
+	_, err := s.network.Add(context.Background(), nil)
 	s.EqualError(err, "nil task")
 }
 
@@ -177,17 +232,34 @@ func (s *CNINetworkSuite) TestAddSetupErrors() {
 	s.cni.SetupReturns(nil, errors.New("setup-err"))
 	task := new(libcontainerdfakes.FakeTask)
 
-	err := s.network.Add(context.Background(), task)
+	_, err := s.network.Add(context.Background(), task)
 	s.EqualError(errors.Unwrap(err), "setup-err")
 }
 
+func (s *CNINetworkSuite) TestAddWithoutIPErrors() {
+	s.cni.SetupReturns(&cni.CNIResult{}, nil)
+	task := new(libcontainerdfakes.FakeTask)
+
+	_, err := s.network.Add(context.Background(), task)
+	s.EqualError(errors.Unwrap(err), "no ip assigned to eth0")
+}
+
 func (s *CNINetworkSuite) TestAdd() {
+	s.cni.SetupReturns(&cni.CNIResult{
+		Interfaces: map[string]*cni.Config{
+			"eth0": {
+				IPConfigs: []*cni.IPConfig{{IP: net.ParseIP("10.80.0.2")}},
+			},
+		},
+	}, nil)
+
 	task := new(libcontainerdfakes.FakeTask)
 	task.PidReturns(123)
 	task.IDReturns("id")
 
-	err := s.network.Add(context.Background(), task)
+	ip, err := s.network.Add(context.Background(), task)
 	s.NoError(err)
+	s.Equal("10.80.0.2", ip)
 
 	s.Equal(1, s.cni.SetupCallCount())
 	_, id, netns, _ := s.cni.SetupArgsForCall(0)
@@ -195,6 +267,214 @@ func (s *CNINetworkSuite) TestAdd() {
 	s.Equal("/proc/123/ns/net", netns)
 }
 
+func (s *CNINetworkSuite) TestApplyRulesWithoutRules() {
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{})
+	s.NoError(err)
+
+	s.Equal(0, s.iptables.CreateChainOrFlushIfExistsCallCount())
+	s.Equal(0, s.iptables.AppendRuleCallCount())
+}
+
+func (s *CNINetworkSuite) TestApplyRulesNetOut() {
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{
+		NetOut: []garden.NetOutRule{
+			{
+				Protocol: garden.ProtocolTCP,
+				Networks: []garden.IPRange{
+					{Start: net.ParseIP("10.0.0.0"), End: net.ParseIP("10.0.0.255")},
+				},
+				Ports: []garden.PortRange{
+					{Start: 443, End: 443},
+					{Start: 8000, End: 8100},
+				},
+			},
+		},
+		DenyNetOut: []garden.NetOutRule{
+			{
+				Protocol: garden.ProtocolAll,
+				Networks: []garden.IPRange{
+					{Start: net.ParseIP("0.0.0.0"), End: net.ParseIP("255.255.255.255")},
+				},
+			},
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(1, s.iptables.CreateChainOrFlushIfExistsCallCount())
+	table, chain := s.iptables.CreateChainOrFlushIfExistsArgsForCall(0)
+	s.Equal("filter", table)
+	s.True(strings.HasPrefix(chain, "CONCOURSE-"))
+	s.LessOrEqual(len(chain), 28)
+
+	s.Equal(3, s.iptables.AppendRuleCallCount())
+
+	table, ruleChain, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal(chain, ruleChain)
+	s.Equal([]string{
+		"-s", "10.80.0.2", "-p", "tcp",
+		"-m", "iprange", "--dst-range", "10.0.0.0-10.0.0.255",
+		"--dport", "443",
+		"-j", "ACCEPT",
+	}, rulespec)
+
+	_, _, rulespec = s.iptables.AppendRuleArgsForCall(1)
+	s.Equal([]string{
+		"-s", "10.80.0.2", "-p", "tcp",
+		"-m", "iprange", "--dst-range", "10.0.0.0-10.0.0.255",
+		"--dport", "8000:8100",
+		"-j", "ACCEPT",
+	}, rulespec)
+
+	_, _, rulespec = s.iptables.AppendRuleArgsForCall(2)
+	s.Equal([]string{
+		"-s", "10.80.0.2",
+		"-m", "iprange", "--dst-range", "0.0.0.0-255.255.255.255",
+		"-j", "REJECT",
+	}, rulespec)
+
+	s.Equal(1, s.iptables.AppendUniqueRuleCallCount())
+	table, parent, rulespec := s.iptables.AppendUniqueRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", parent)
+	s.Equal([]string{"-j", chain}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestApplyRulesPortsWithoutProtocol() {
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{
+		NetOut: []garden.NetOutRule{
+			{Ports: []garden.PortRange{{Start: 443, End: 443}}},
+		},
+	})
+	s.EqualError(err, "ports require protocol tcp or udp")
+}
+
+func (s *CNINetworkSuite) TestApplyRulesNetIn() {
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{
+		NetIn: []garden.NetIn{{HostPort: 8080, ContainerPort: 80}},
+	})
+	s.NoError(err)
+
+	s.Equal(2, s.iptables.CreateChainOrFlushIfExistsCallCount())
+	_, chain := s.iptables.CreateChainOrFlushIfExistsArgsForCall(0)
+	table, natChain := s.iptables.CreateChainOrFlushIfExistsArgsForCall(1)
+	s.Equal("nat", table)
+	s.Equal(chain, natChain)
+
+	s.Equal(2, s.iptables.AppendRuleCallCount())
+
+	table, _, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal([]string{"-d", "10.80.0.2", "-p", "tcp", "--dport", "80", "-j", "ACCEPT"}, rulespec)
+
+	table, _, rulespec = s.iptables.AppendRuleArgsForCall(1)
+	s.Equal("nat", table)
+	s.Equal([]string{"-p", "tcp", "--dport", "8080", "-j", "DNAT", "--to-destination", "10.80.0.2:80"}, rulespec)
+
+	s.Equal(3, s.iptables.AppendUniqueRuleCallCount())
+	for i, parent := range []string{"PREROUTING", "OUTPUT"} {
+		table, parentChain, rulespec := s.iptables.AppendUniqueRuleArgsForCall(i + 1)
+		s.Equal("nat", table)
+		s.Equal(parent, parentChain)
+		s.Equal([]string{"-m", "addrtype", "--dst-type", "LOCAL", "-j", chain}, rulespec)
+	}
+}
+
+func (s *CNINetworkSuite) TestApplyRulesAllowOnlyRejectsOtherEgress() {
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{
+		NetOut: []garden.NetOutRule{
+			{
+				Protocol: garden.ProtocolTCP,
+				Networks: []garden.IPRange{
+					{Start: net.ParseIP("10.0.0.0"), End: net.ParseIP("10.0.0.255")},
+				},
+			},
+		},
+	})
+	s.NoError(err)
+
+	s.Equal(2, s.iptables.AppendRuleCallCount())
+
+	_, _, rulespec := s.iptables.AppendRuleArgsForCall(0)
+	s.Equal([]string{
+		"-s", "10.80.0.2", "-p", "tcp",
+		"-m", "iprange", "--dst-range", "10.0.0.0-10.0.0.255",
+		"-j", "ACCEPT",
+	}, rulespec)
+
+	_, _, rulespec = s.iptables.AppendRuleArgsForCall(1)
+	s.Equal([]string{"-s", "10.80.0.2", "-j", "REJECT"}, rulespec)
+}
+
+func (s *CNINetworkSuite) TestAllocateHostPort() {
+	port, err := s.network.AllocateHostPort("handle", 61234)
+	s.NoError(err)
+	s.Equal(uint32(61234), port)
+
+	port, err = s.network.AllocateHostPort("handle", 61234)
+	s.NoError(err)
+	s.Equal(uint32(61234), port)
+
+	_, err = s.network.AllocateHostPort("other-handle", 61234)
+	s.EqualError(err, "host port 61234 is already in use")
+
+	port, err = s.network.AllocateHostPort("other-handle", 0)
+	s.NoError(err)
+	s.Equal(runtime.DefaultHostPortRangeStart, port)
+}
+
+func (s *CNINetworkSuite) TestAllocateHostPortOutsideOfRange() {
+	_, err := s.network.AllocateHostPort("handle", 22)
+	s.EqualError(err, "host port 22 is outside of the allowed range 61001-65534")
+}
+
+func (s *CNINetworkSuite) TestAllocateHostPortExhausted() {
+	network, err := runtime.NewCNINetwork(
+		runtime.WithCNIFileStore(s.store),
+		runtime.WithCNIClient(s.cni),
+		runtime.WithIptables(s.iptables),
+		runtime.WithHostPortRange(9000, 9001),
+	)
+	s.NoError(err)
+
+	_, err = network.AllocateHostPort("handle", 0)
+	s.NoError(err)
+	_, err = network.AllocateHostPort("handle", 0)
+	s.NoError(err)
+
+	_, err = network.AllocateHostPort("other-handle", 0)
+	s.EqualError(err, "no free host ports left in the range 9000-9001")
+
+	network.ReleaseHostPorts("handle")
+
+	port, err := network.AllocateHostPort("other-handle", 9001)
+	s.NoError(err)
+	s.Equal(uint32(9001), port)
+}
+
+func (s *CNINetworkSuite) TestRemoveReleasesHostPorts() {
+	task := new(libcontainerdfakes.FakeTask)
+	task.IDReturns("id")
+
+	_, err := s.network.AllocateHostPort("id", 61234)
+	s.NoError(err)
+
+	err = s.network.Remove(context.Background(), task)
+	s.NoError(err)
+
+	_, err = s.network.AllocateHostPort("other-handle", 61234)
+	s.NoError(err)
+}
+
+func (s *CNINetworkSuite) TestApplyRulesAppendFails() {
+	s.iptables.AppendRuleReturns(errors.New("append-err"))
+
+	err := s.network.ApplyRules("handle", "10.80.0.2", runtime.NetworkRules{
+		NetOut: []garden.NetOutRule{{Protocol: garden.ProtocolUDP}},
+	})
+	s.EqualError(errors.Unwrap(err), "append-err")
+}
+
 func (s *CNINetworkSuite) TestRemoveNilTask() {
 	err := s.network.Remove(context.Background(), nil)
 	s.EqualError(err, "nil task")
@@ -220,4 +500,27 @@ func (s *CNINetworkSuite) TestRemove() {
 	_, id, netns, _ := s.cni.RemoveArgsForCall(0)
 	s.Equal("id", id)
 	s.Equal("/proc/123/ns/net", netns)
+
+	s.Equal(3, s.iptables.DeleteRuleIfExistsCallCount())
+	s.Equal(2, s.iptables.DeleteChainIfExistsCallCount())
+
+	table, parent, rulespec := s.iptables.DeleteRuleIfExistsArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal("CONCOURSE-OPERATOR", parent)
+
+	table, chain := s.iptables.DeleteChainIfExistsArgsForCall(0)
+	s.Equal("filter", table)
+	s.Equal([]string{"-j", chain}, rulespec)
+
+	table, natChain := s.iptables.DeleteChainIfExistsArgsForCall(1)
+	s.Equal("nat", table)
+	s.Equal(chain, natChain)
+}
+
+func (s *CNINetworkSuite) TestRemoveRulesErrors() {
+	s.iptables.DeleteChainIfExistsReturns(errors.New("delete-err"))
+	task := new(libcontainerdfakes.FakeTask)
+
+	err := s.network.Remove(context.Background(), task)
+	s.EqualError(errors.Unwrap(err), "delete-err")
 }
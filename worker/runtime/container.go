@@ -10,6 +10,7 @@ import (
 	"code.cloudfoundry.org/garden"
 	"github.com/containerd/containerd"
 	"github.com/containerd/containerd/cio"
+	"github.com/containerd/containerd/errdefs"
 	uuid "github.com/nu7hatch/gouuid"
 	"github.com/opencontainers/runtime-spec/specs-go"
 )
@@ -28,17 +29,20 @@ type Container struct {
 	container     containerd.Container
 	killer        Killer
 	rootfsManager RootfsManager
+	network       Network
 }
 
 func NewContainer(
 	container containerd.Container,
 	killer Killer,
 	rootfsManager RootfsManager,
+	network Network,
 ) *Container {
 	return &Container{
 		container:     container,
 		killer:        killer,
 		rootfsManager: rootfsManager,
+		network:       network,
 	}
 }
 
@@ -222,16 +226,90 @@ func (c *Container) RemoveProperty(name string) (err error) {
 	return
 }
 
-// Info - Not Implemented
-func (c *Container) Info() (info garden.ContainerInfo, err error) {
-	err = ErrNotImplemented
-	return
+// Info returns the state, properties, path, IP address and port mappings of
+// the container.
+//
+// Process IDs are not populated.
+//
+func (c *Container) Info() (garden.ContainerInfo, error) {
+	ctx := context.Background()
+
+	labels, err := c.container.Labels(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	spec, err := c.container.Spec(ctx)
+	if err != nil {
+		return garden.ContainerInfo{}, fmt.Errorf("container spec: %w", err)
+	}
+
+	rules, err := networkRulesFromLabels(labels)
+	if err != nil {
+		return garden.ContainerInfo{}, err
+	}
+
+	var containerPath string
+	if spec != nil && spec.Root != nil {
+		containerPath = spec.Root.Path
+	}
+
+	state := "stopped"
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		if !errdefs.IsNotFound(err) {
+			return garden.ContainerInfo{}, fmt.Errorf("task lookup: %w", err)
+		}
+	} else {
+		status, err := task.Status(ctx)
+		if err != nil {
+			return garden.ContainerInfo{}, fmt.Errorf("task status: %w", err)
+		}
+
+		if status.Status == containerd.Running {
+			state = "active"
+		}
+	}
+
+	return garden.ContainerInfo{
+		State:         state,
+		ContainerIP:   labels[ContainerIPKey],
+		ContainerPath: containerPath,
+		Properties:    labels,
+		MappedPorts:   rules.mappedPorts(),
+	}, nil
 }
 
-// Metrics - Not Implemented
-func (c *Container) Metrics() (metrics garden.Metrics, err error) {
-	err = ErrNotImplemented
-	return
+// Metrics returns the memory, cpu and pid usage of the container as reported
+// by its task's cgroup, along with how long ago the container was created.
+//
+func (c *Container) Metrics() (garden.Metrics, error) {
+	ctx := context.Background()
+
+	task, err := c.container.Task(ctx, cio.Load)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task lookup: %w", err)
+	}
+
+	taskMetrics, err := task.Metrics(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("task metrics: %w", err)
+	}
+
+	metrics, err := gardenMetrics(taskMetrics)
+	if err != nil {
+		return garden.Metrics{}, err
+	}
+
+	info, err := c.container.Info(ctx)
+	if err != nil {
+		return garden.Metrics{}, fmt.Errorf("container info: %w", err)
+	}
+
+	metrics.Age = time.Since(info.CreatedAt)
+
+	return metrics, nil
 }
 
 // StreamIn - Not Implemented
@@ -307,22 +385,86 @@ func (c *Container) CurrentMemoryLimits() (limits garden.MemoryLimits, err error
 	}, nil
 }
 
-// NetIn - Not Implemented
-func (c *Container) NetIn(hostPort, containerPort uint32) (a, b uint32, err error) {
-	err = ErrNotImplemented
-	return
+// NetIn forwards traffic arriving at hostPort on the host to containerPort
+// of the container.
+//
+// If hostPort is 0, a free port of the host port range is picked. If
+// containerPort is 0, it defaults to the host port.
+//
+func (c *Container) NetIn(hostPort, containerPort uint32) (uint32, uint32, error) {
+	netIn, err := resolveNetIn(c.network, c.container.ID(), garden.NetIn{
+		HostPort:      hostPort,
+		ContainerPort: containerPort,
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	err = c.updateNetworkRules(func(rules *NetworkRules) {
+		rules.NetIn = append(rules.NetIn, netIn)
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return netIn.HostPort, netIn.ContainerPort, nil
 }
 
-// NetOut - Not Implemented
-func (c *Container) NetOut(netOutRule garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// NetOut allows egress from the container matching the rule, even if denied
+// by the rules the container was created with.
+//
+func (c *Container) NetOut(netOutRule garden.NetOutRule) error {
+	return c.BulkNetOut([]garden.NetOutRule{netOutRule})
 }
 
-// BulkNetOut - Not Implemented
-func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) (err error) {
-	err = ErrNotImplemented
-	return
+// BulkNetOut allows egress from the container matching any of the rules, even
+// if denied by the rules the container was created with.
+//
+func (c *Container) BulkNetOut(netOutRules []garden.NetOutRule) error {
+	return c.updateNetworkRules(func(rules *NetworkRules) {
+		rules.NetOut = append(rules.NetOut, netOutRules...)
+	})
+}
+
+// updateNetworkRules applies the container's network rules as modified by
+// update, storing them as labels once in place.
+//
+func (c *Container) updateNetworkRules(update func(rules *NetworkRules)) error {
+	ctx := context.Background()
+
+	labels, err := c.container.Labels(ctx)
+	if err != nil {
+		return fmt.Errorf("labels retrieval: %w", err)
+	}
+
+	ip := labels[ContainerIPKey]
+	if ip == "" {
+		return ErrNotFound(ContainerIPKey)
+	}
+
+	rules, err := networkRulesFromLabels(labels)
+	if err != nil {
+		return err
+	}
+
+	update(&rules)
+
+	err = c.network.ApplyRules(c.container.ID(), ip, rules)
+	if err != nil {
+		return fmt.Errorf("network apply rules: %w", err)
+	}
+
+	rulesLabels, err := rules.labels()
+	if err != nil {
+		return err
+	}
+
+	_, err = c.container.SetLabels(ctx, rulesLabels)
+	if err != nil {
+		return fmt.Errorf("set label: %w", err)
+	}
+
+	return nil
 }
 
 func procID(gdnProcSpec garden.ProcessSpec) string {
@@ -2,12 +2,20 @@ package runtime_test
 
 import (
 	"errors"
+	"time"
 
 	"code.cloudfoundry.org/garden"
 	"github.com/concourse/concourse/worker/runtime"
 	"github.com/concourse/concourse/worker/runtime/libcontainerd/libcontainerdfakes"
 	"github.com/concourse/concourse/worker/runtime/runtimefakes"
+	v1 "github.com/containerd/cgroups/stats/v1"
 	"github.com/containerd/containerd"
+	"github.com/containerd/containerd/api/types"
+	"github.com/containerd/containerd/containers"
+	"github.com/containerd/containerd/errdefs"
+	"github.com/containerd/typeurl"
+	"github.com/gogo/protobuf/proto"
+	prototypes "github.com/gogo/protobuf/types"
 	"github.com/opencontainers/runtime-spec/specs-go"
 	"github.com/stretchr/testify/require"
 	"github.com/stretchr/testify/suite"
@@ -23,6 +31,7 @@ type ContainerSuite struct {
 	containerdTask      *libcontainerdfakes.FakeTask
 	rootfsManager       *runtimefakes.FakeRootfsManager
 	killer              *runtimefakes.FakeKiller
+	network             *runtimefakes.FakeNetwork
 }
 
 func (s *ContainerSuite) SetupTest() {
@@ -31,11 +40,13 @@ func (s *ContainerSuite) SetupTest() {
 	s.containerdTask = new(libcontainerdfakes.FakeTask)
 	s.rootfsManager = new(runtimefakes.FakeRootfsManager)
 	s.killer = new(runtimefakes.FakeKiller)
+	s.network = new(runtimefakes.FakeNetwork)
 
 	s.container = runtime.NewContainer(
 		s.containerdContainer,
 		s.killer,
 		s.rootfsManager,
+		s.network,
 	)
 }
 
@@ -381,3 +392,278 @@ func (s *ContainerSuite) TestCurrentMemoryLimitsReturnsLimit() {
 	s.NoError(err)
 	s.Equal(garden.MemoryLimits{LimitInBytes: uint64(limitBytes)}, limits)
 }
+
+func (s *ContainerSuite) TestInfoLabelsFails() {
+	expectedErr := errors.New("labels-err")
+	s.containerdContainer.LabelsReturns(nil, expectedErr)
+
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoTaskLookupFails() {
+	expectedErr := errors.New("task-err")
+	s.containerdContainer.TaskReturns(nil, expectedErr)
+
+	_, err := s.container.Info()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestInfoWithoutTaskIsStopped() {
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("stopped", info.State)
+}
+
+func (s *ContainerSuite) TestInfoReturnsInfo() {
+	s.containerdContainer.LabelsReturns(map[string]string{"foo": "bar"}, nil)
+	s.containerdContainer.SpecReturns(&specs.Spec{
+		Root: &specs.Root{Path: "/rootfs"},
+	}, nil)
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.StatusReturns(containerd.Status{Status: containerd.Running}, nil)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal(garden.ContainerInfo{
+		State:         "active",
+		ContainerPath: "/rootfs",
+		Properties:    garden.Properties{"foo": "bar"},
+	}, info)
+}
+
+func (s *ContainerSuite) TestInfoReturnsNetworkDetails() {
+	labels := map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.NetInKey:       `[{"host_port":8080,"container_port":80}]`,
+	}
+	s.containerdContainer.LabelsReturns(labels, nil)
+	s.containerdContainer.TaskReturns(nil, errdefs.ErrNotFound)
+
+	info, err := s.container.Info()
+	s.NoError(err)
+	s.Equal("10.80.0.2", info.ContainerIP)
+	s.Equal([]garden.PortMapping{{HostPort: 8080, ContainerPort: 80}}, info.MappedPorts)
+}
+
+func (s *ContainerSuite) TestNetInWithoutIP() {
+	s.containerdContainer.LabelsReturns(map[string]string{}, nil)
+
+	_, _, err := s.container.NetIn(8080, 80)
+	s.EqualError(err, "not found: "+runtime.ContainerIPKey)
+	s.Equal(0, s.network.ApplyRulesCallCount())
+}
+
+func (s *ContainerSuite) TestNetInAppliesRules() {
+	s.containerdContainer.IDReturns("handle")
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.NetInKey:       `[{"host_port":8080,"container_port":80}]`,
+	}, nil)
+
+	s.network.AllocateHostPortReturns(9090, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(9090, 0)
+	s.NoError(err)
+	s.Equal(uint32(9090), hostPort)
+	s.Equal(uint32(9090), containerPort)
+
+	s.Equal(1, s.network.AllocateHostPortCallCount())
+	allocatedFor, requestedPort := s.network.AllocateHostPortArgsForCall(0)
+	s.Equal("handle", allocatedFor)
+	s.Equal(uint32(9090), requestedPort)
+
+	s.Equal(1, s.network.ApplyRulesCallCount())
+	handle, ip, rules := s.network.ApplyRulesArgsForCall(0)
+	s.Equal("handle", handle)
+	s.Equal("10.80.0.2", ip)
+	s.Equal([]garden.NetIn{
+		{HostPort: 8080, ContainerPort: 80},
+		{HostPort: 9090, ContainerPort: 9090},
+	}, rules.NetIn)
+
+	s.Equal(1, s.containerdContainer.SetLabelsCallCount())
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(`[{"host_port":8080,"container_port":80},{"host_port":9090,"container_port":9090}]`, labels[runtime.NetInKey])
+}
+
+func (s *ContainerSuite) TestNetInPicksHostPort() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+
+	s.network.AllocateHostPortReturns(61001, nil)
+
+	hostPort, containerPort, err := s.container.NetIn(0, 80)
+	s.NoError(err)
+	s.Equal(uint32(61001), hostPort)
+	s.Equal(uint32(80), containerPort)
+
+	_, requestedPort := s.network.AllocateHostPortArgsForCall(0)
+	s.Zero(requestedPort)
+}
+
+func (s *ContainerSuite) TestNetInHostPortNotAllowed() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+	s.network.AllocateHostPortReturns(0, errors.New("outside of the allowed range"))
+
+	_, _, err := s.container.NetIn(7777, 0)
+	s.Error(err)
+	s.Equal(0, s.network.ApplyRulesCallCount())
+}
+
+func (s *ContainerSuite) TestNetOutApplyRulesFails() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+	}, nil)
+	s.network.ApplyRulesReturns(errors.New("apply-err"))
+
+	err := s.container.NetOut(garden.NetOutRule{})
+	s.EqualError(errors.Unwrap(err), "apply-err")
+	s.Equal(0, s.containerdContainer.SetLabelsCallCount())
+}
+
+func (s *ContainerSuite) TestBulkNetOutAppliesRules() {
+	s.containerdContainer.LabelsReturns(map[string]string{
+		runtime.ContainerIPKey: "10.80.0.2",
+		runtime.DenyNetOutKey:  `[{}]`,
+	}, nil)
+
+	rules := []garden.NetOutRule{
+		{Protocol: garden.ProtocolTCP},
+		{Protocol: garden.ProtocolUDP},
+	}
+
+	err := s.container.BulkNetOut(rules)
+	s.NoError(err)
+
+	_, _, applied := s.network.ApplyRulesArgsForCall(0)
+	s.Equal(rules, applied.NetOut)
+	s.Equal([]garden.NetOutRule{{}}, applied.DenyNetOut)
+
+	_, labels := s.containerdContainer.SetLabelsArgsForCall(0)
+	s.Equal(`[{"protocol":1},{"protocol":2}]`, labels[runtime.NetOutKey])
+	s.NotContains(labels, runtime.DenyNetOutKey)
+}
+
+func (s *ContainerSuite) TestMetricsTaskMetricsFails() {
+	expectedErr := errors.New("metrics-err")
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(nil, expectedErr)
+
+	_, err := s.container.Metrics()
+	s.True(errors.Is(err, expectedErr))
+}
+
+func (s *ContainerSuite) TestMetricsUnsupportedType() {
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{
+		Data: &prototypes.Any{TypeUrl: "io.containerd.unknown.Metrics"},
+	}, nil)
+
+	_, err := s.container.Metrics()
+	s.EqualError(err, "unsupported metrics type 'io.containerd.unknown.Metrics'")
+}
+
+func (s *ContainerSuite) TestMetricsCgroupsV1() {
+	data, err := typeurl.MarshalAny(&v1.Metrics{
+		Pids: &v1.PidsStat{Current: 3, Limit: 100},
+		CPU: &v1.CPUStat{
+			Usage: &v1.CPUUsage{Total: 1000, Kernel: 300, User: 700},
+		},
+		Memory: &v1.MemoryStat{
+			RSS:                     10,
+			Cache:                   20,
+			HierarchicalMemoryLimit: 4096,
+			TotalRSS:                100,
+			TotalCache:              200,
+			TotalInactiveFile:       50,
+			Swap:                    &v1.MemoryEntry{Usage: 5},
+		},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{Data: data}, nil)
+	s.containerdContainer.InfoReturns(containers.Container{
+		CreatedAt: time.Now().Add(-time.Hour),
+	}, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+
+	s.Equal(garden.ContainerCPUStat{Usage: 1000, User: 700, System: 300}, metrics.CPUStat)
+	s.Equal(garden.ContainerPidStat{Current: 3, Max: 100}, metrics.PidStat)
+	s.Equal(uint64(10), metrics.MemoryStat.Rss)
+	s.Equal(uint64(20), metrics.MemoryStat.Cache)
+	s.Equal(uint64(4096), metrics.MemoryStat.HierarchicalMemoryLimit)
+	s.Equal(uint64(5), metrics.MemoryStat.Swap)
+	s.Equal(uint64(250), metrics.MemoryStat.TotalUsageTowardLimit)
+	s.InDelta(time.Hour, metrics.Age, float64(time.Minute))
+}
+
+type cgroupsV2Metrics struct {
+	CPU    *cgroupsV2CPUStat    `protobuf:"bytes,2,opt,name=cpu,proto3"`
+	Memory *cgroupsV2MemoryStat `protobuf:"bytes,4,opt,name=memory,proto3"`
+}
+
+func (m *cgroupsV2Metrics) Reset()         { *m = cgroupsV2Metrics{} }
+func (m *cgroupsV2Metrics) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2Metrics) ProtoMessage()    {}
+
+type cgroupsV2CPUStat struct {
+	UsageUsec  uint64 `protobuf:"varint,1,opt,name=usage_usec,proto3"`
+	UserUsec   uint64 `protobuf:"varint,2,opt,name=user_usec,proto3"`
+	SystemUsec uint64 `protobuf:"varint,3,opt,name=system_usec,proto3"`
+}
+
+func (m *cgroupsV2CPUStat) Reset()         { *m = cgroupsV2CPUStat{} }
+func (m *cgroupsV2CPUStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2CPUStat) ProtoMessage()    {}
+
+type cgroupsV2MemoryStat struct {
+	Anon         uint64 `protobuf:"varint,1,opt,name=anon,proto3"`
+	File         uint64 `protobuf:"varint,2,opt,name=file,proto3"`
+	InactiveFile uint64 `protobuf:"varint,13,opt,name=inactive_file,proto3"`
+	Usage        uint64 `protobuf:"varint,32,opt,name=usage,proto3"`
+	UsageLimit   uint64 `protobuf:"varint,33,opt,name=usage_limit,proto3"`
+}
+
+func (m *cgroupsV2MemoryStat) Reset()         { *m = cgroupsV2MemoryStat{} }
+func (m *cgroupsV2MemoryStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2MemoryStat) ProtoMessage()    {}
+
+func (s *ContainerSuite) TestMetricsCgroupsV2() {
+	value, err := proto.Marshal(&cgroupsV2Metrics{
+		CPU: &cgroupsV2CPUStat{UsageUsec: 10, UserUsec: 7, SystemUsec: 3},
+		Memory: &cgroupsV2MemoryStat{
+			Anon:         100,
+			File:         200,
+			InactiveFile: 50,
+			Usage:        300,
+			UsageLimit:   4096,
+		},
+	})
+	s.NoError(err)
+
+	s.containerdContainer.TaskReturns(s.containerdTask, nil)
+	s.containerdTask.MetricsReturns(&types.Metric{
+		Data: &prototypes.Any{
+			TypeUrl: "io.containerd.cgroups.v2.Metrics",
+			Value:   value,
+		},
+	}, nil)
+
+	metrics, err := s.container.Metrics()
+	s.NoError(err)
+
+	s.Equal(garden.ContainerCPUStat{Usage: 10000, User: 7000, System: 3000}, metrics.CPUStat)
+	s.Equal(uint64(100), metrics.MemoryStat.TotalRss)
+	s.Equal(uint64(200), metrics.MemoryStat.TotalCache)
+	s.Equal(uint64(4096), metrics.MemoryStat.HierarchicalMemoryLimit)
+	s.Equal(uint64(250), metrics.MemoryStat.TotalUsageTowardLimit)
+}
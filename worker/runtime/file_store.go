@@ -16,6 +16,10 @@ type FileStore interface {
 	//
 	Create(name string, content []byte) (absPath string, err error)
 
+	// Append appends content to a file previously created in the store.
+	//
+	Append(name string, content []byte) (absPath string, err error)
+
 	// DeleteFile removes a file previously created in the store.
 	//
 	Delete(name string) (err error)
@@ -50,6 +54,24 @@ func (f fileStore) Create(name string, content []byte) (string, error) {
 	return absPath, nil
 }
 
+func (f fileStore) Append(name string, content []byte) (string, error) {
+	absPath := filepath.Join(f.root, name)
+
+	file, err := os.OpenFile(absPath, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		return "", fmt.Errorf("open file: %w", err)
+	}
+
+	defer file.Close()
+
+	_, err = file.Write(content)
+	if err != nil {
+		return "", fmt.Errorf("write file: %w", err)
+	}
+
+	return absPath, nil
+}
+
 func (f fileStore) Delete(path string) error {
 	absPath := filepath.Join(f.root, path)
 
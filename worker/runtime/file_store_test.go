@@ -49,6 +49,23 @@ func (s *FileStoreSuite) TestCreateFileInDir() {
 	s.Equal("hey", string(content))
 }
 
+func (s *FileStoreSuite) TestAppendFile() {
+	_, err := s.store.Create("dir/name", []byte("hey"))
+	s.NoError(err)
+
+	fpath, err := s.store.Append("dir/name", []byte(" there"))
+	s.NoError(err)
+
+	content, err := ioutil.ReadFile(fpath)
+	s.NoError(err)
+	s.Equal("hey there", string(content))
+}
+
+func (s *FileStoreSuite) TestAppendMissingFile() {
+	_, err := s.store.Append("missing", []byte("hey"))
+	s.Error(err)
+}
+
 func (s *FileStoreSuite) TestDeleteFile() {
 	fpath, err := s.store.Create("dir/name", []byte("hey"))
 	s.NoError(err)
@@ -0,0 +1,94 @@
+package runtime
+
+import (
+	"fmt"
+	"sync"
+)
+
+const (
+	// DefaultHostPortRangeStart and DefaultHostPortRangeEnd bound the ports
+	// of the host which can be forwarded to containers, the same ones as
+	// Guardian's port pool.
+	//
+	DefaultHostPortRangeStart uint32 = 61001
+	DefaultHostPortRangeEnd   uint32 = 65534
+)
+
+// hostPorts keeps track of the ports of the host forwarded to containers, so
+// that none is handed out twice and none outside of the range configured by
+// the operator is handed out at all.
+//
+type hostPorts struct {
+	start, end uint32
+
+	mu    sync.Mutex
+	owner map[uint32]string
+	next  uint32
+}
+
+func newHostPorts(start, end uint32) *hostPorts {
+	return &hostPorts{
+		start: start,
+		end:   end,
+		owner: map[uint32]string{},
+		next:  start,
+	}
+}
+
+// acquire reserves port for the container with the given handle, or a free
+// one if port is 0.
+//
+func (p *hostPorts) acquire(handle string, port uint32) (uint32, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if port == 0 {
+		return p.acquireFree(handle)
+	}
+
+	if port < p.start || port > p.end {
+		return 0, fmt.Errorf("host port %d is outside of the allowed range %d-%d", port, p.start, p.end)
+	}
+
+	if owner, taken := p.owner[port]; taken && owner != handle {
+		return 0, fmt.Errorf("host port %d is already in use", port)
+	}
+
+	p.owner[port] = handle
+
+	return port, nil
+}
+
+func (p *hostPorts) acquireFree(handle string) (uint32, error) {
+	size := p.end - p.start + 1
+
+	for i := uint32(0); i < size; i++ {
+		port := p.next
+
+		p.next++
+		if p.next > p.end {
+			p.next = p.start
+		}
+
+		if _, taken := p.owner[port]; !taken {
+			p.owner[port] = handle
+			return port, nil
+		}
+	}
+
+	return 0, fmt.Errorf("no free host ports left in the range %d-%d", p.start, p.end)
+}
+
+// release frees all of the ports reserved for the container with the given
+// handle.
+//
+func (p *hostPorts) release(handle string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for port, owner := range p.owner {
+		if owner == handle {
+			delete(p.owner, port)
+		}
+	}
+}
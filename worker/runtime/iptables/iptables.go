@@ -8,7 +8,10 @@ import (
 
 type Iptables interface {
 	CreateChainOrFlushIfExists(table string, chain string) error
+	DeleteChainIfExists(table string, chain string) error
 	AppendRule(table string, chain string, rulespec ...string) error
+	AppendUniqueRule(table string, chain string, rulespec ...string) error
+	DeleteRuleIfExists(table string, chain string, rulespec ...string) error
 }
 
 type iptables struct {
@@ -38,4 +41,40 @@ func (ipt *iptables) CreateChainOrFlushIfExists(table string, chain string) erro
 func (ipt *iptables) AppendRule(table string, chain string, rulespec ...string) error {
 	err := ipt.goipt.Append(table, chain, rulespec...)
 	return err
-}
\ No newline at end of file
+}
+
+func (ipt *iptables) DeleteChainIfExists(table string, chain string) error {
+	chains, err := ipt.goipt.ListChains(table)
+	if err != nil {
+		return err
+	}
+
+	for _, c := range chains {
+		if c != chain {
+			continue
+		}
+
+		err = ipt.goipt.ClearChain(table, chain)
+		if err != nil {
+			return err
+		}
+
+		return ipt.goipt.DeleteChain(table, chain)
+	}
+
+	return nil
+}
+
+func (ipt *iptables) AppendUniqueRule(table string, chain string, rulespec ...string) error {
+	err := ipt.goipt.AppendUnique(table, chain, rulespec...)
+	return err
+}
+
+func (ipt *iptables) DeleteRuleIfExists(table string, chain string, rulespec ...string) error {
+	exists, err := ipt.goipt.Exists(table, chain, rulespec...)
+	if err != nil || !exists {
+		return err
+	}
+
+	return ipt.goipt.Delete(table, chain, rulespec...)
+}
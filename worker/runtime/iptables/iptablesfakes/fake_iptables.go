@@ -21,6 +21,19 @@ type FakeIptables struct {
 	appendRuleReturnsOnCall map[int]struct {
 		result1 error
 	}
+	AppendUniqueRuleStub        func(string, string, ...string) error
+	appendUniqueRuleMutex       sync.RWMutex
+	appendUniqueRuleArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	appendUniqueRuleReturns struct {
+		result1 error
+	}
+	appendUniqueRuleReturnsOnCall map[int]struct {
+		result1 error
+	}
 	CreateChainOrFlushIfExistsStub        func(string, string) error
 	createChainOrFlushIfExistsMutex       sync.RWMutex
 	createChainOrFlushIfExistsArgsForCall []struct {
@@ -33,6 +46,31 @@ type FakeIptables struct {
 	createChainOrFlushIfExistsReturnsOnCall map[int]struct {
 		result1 error
 	}
+	DeleteChainIfExistsStub        func(string, string) error
+	deleteChainIfExistsMutex       sync.RWMutex
+	deleteChainIfExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+	}
+	deleteChainIfExistsReturns struct {
+		result1 error
+	}
+	deleteChainIfExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
+	DeleteRuleIfExistsStub        func(string, string, ...string) error
+	deleteRuleIfExistsMutex       sync.RWMutex
+	deleteRuleIfExistsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}
+	deleteRuleIfExistsReturns struct {
+		result1 error
+	}
+	deleteRuleIfExistsReturnsOnCall map[int]struct {
+		result1 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
@@ -99,6 +137,68 @@ func (fake *FakeIptables) AppendRuleReturnsOnCall(i int, result1 error) {
 	}{result1}
 }
 
+func (fake *FakeIptables) AppendUniqueRule(arg1 string, arg2 string, arg3 ...string) error {
+	fake.appendUniqueRuleMutex.Lock()
+	ret, specificReturn := fake.appendUniqueRuleReturnsOnCall[len(fake.appendUniqueRuleArgsForCall)]
+	fake.appendUniqueRuleArgsForCall = append(fake.appendUniqueRuleArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("AppendUniqueRule", []interface{}{arg1, arg2, arg3})
+	fake.appendUniqueRuleMutex.Unlock()
+	if fake.AppendUniqueRuleStub != nil {
+		return fake.AppendUniqueRuleStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.appendUniqueRuleReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) AppendUniqueRuleCallCount() int {
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
+	return len(fake.appendUniqueRuleArgsForCall)
+}
+
+func (fake *FakeIptables) AppendUniqueRuleCalls(stub func(string, string, ...string) error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = stub
+}
+
+func (fake *FakeIptables) AppendUniqueRuleArgsForCall(i int) (string, string, []string) {
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
+	argsForCall := fake.appendUniqueRuleArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIptables) AppendUniqueRuleReturns(result1 error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = nil
+	fake.appendUniqueRuleReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) AppendUniqueRuleReturnsOnCall(i int, result1 error) {
+	fake.appendUniqueRuleMutex.Lock()
+	defer fake.appendUniqueRuleMutex.Unlock()
+	fake.AppendUniqueRuleStub = nil
+	if fake.appendUniqueRuleReturnsOnCall == nil {
+		fake.appendUniqueRuleReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.appendUniqueRuleReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIptables) CreateChainOrFlushIfExists(arg1 string, arg2 string) error {
 	fake.createChainOrFlushIfExistsMutex.Lock()
 	ret, specificReturn := fake.createChainOrFlushIfExistsReturnsOnCall[len(fake.createChainOrFlushIfExistsArgsForCall)]
@@ -160,13 +260,142 @@ func (fake *FakeIptables) CreateChainOrFlushIfExistsReturnsOnCall(i int, result1
 	}{result1}
 }
 
+func (fake *FakeIptables) DeleteChainIfExists(arg1 string, arg2 string) error {
+	fake.deleteChainIfExistsMutex.Lock()
+	ret, specificReturn := fake.deleteChainIfExistsReturnsOnCall[len(fake.deleteChainIfExistsArgsForCall)]
+	fake.deleteChainIfExistsArgsForCall = append(fake.deleteChainIfExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+	}{arg1, arg2})
+	fake.recordInvocation("DeleteChainIfExists", []interface{}{arg1, arg2})
+	fake.deleteChainIfExistsMutex.Unlock()
+	if fake.DeleteChainIfExistsStub != nil {
+		return fake.DeleteChainIfExistsStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteChainIfExistsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsCallCount() int {
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	return len(fake.deleteChainIfExistsArgsForCall)
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsCalls(stub func(string, string) error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = stub
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsArgsForCall(i int) (string, string) {
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	argsForCall := fake.deleteChainIfExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsReturns(result1 error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = nil
+	fake.deleteChainIfExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteChainIfExistsReturnsOnCall(i int, result1 error) {
+	fake.deleteChainIfExistsMutex.Lock()
+	defer fake.deleteChainIfExistsMutex.Unlock()
+	fake.DeleteChainIfExistsStub = nil
+	if fake.deleteChainIfExistsReturnsOnCall == nil {
+		fake.deleteChainIfExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteChainIfExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteRuleIfExists(arg1 string, arg2 string, arg3 ...string) error {
+	fake.deleteRuleIfExistsMutex.Lock()
+	ret, specificReturn := fake.deleteRuleIfExistsReturnsOnCall[len(fake.deleteRuleIfExistsArgsForCall)]
+	fake.deleteRuleIfExistsArgsForCall = append(fake.deleteRuleIfExistsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 []string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("DeleteRuleIfExists", []interface{}{arg1, arg2, arg3})
+	fake.deleteRuleIfExistsMutex.Unlock()
+	if fake.DeleteRuleIfExistsStub != nil {
+		return fake.DeleteRuleIfExistsStub(arg1, arg2, arg3...)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.deleteRuleIfExistsReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsCallCount() int {
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
+	return len(fake.deleteRuleIfExistsArgsForCall)
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsCalls(stub func(string, string, ...string) error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = stub
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsArgsForCall(i int) (string, string, []string) {
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
+	argsForCall := fake.deleteRuleIfExistsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsReturns(result1 error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = nil
+	fake.deleteRuleIfExistsReturns = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeIptables) DeleteRuleIfExistsReturnsOnCall(i int, result1 error) {
+	fake.deleteRuleIfExistsMutex.Lock()
+	defer fake.deleteRuleIfExistsMutex.Unlock()
+	fake.DeleteRuleIfExistsStub = nil
+	if fake.deleteRuleIfExistsReturnsOnCall == nil {
+		fake.deleteRuleIfExistsReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.deleteRuleIfExistsReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
 func (fake *FakeIptables) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.appendRuleMutex.RLock()
 	defer fake.appendRuleMutex.RUnlock()
+	fake.appendUniqueRuleMutex.RLock()
+	defer fake.appendUniqueRuleMutex.RUnlock()
 	fake.createChainOrFlushIfExistsMutex.RLock()
 	defer fake.createChainOrFlushIfExistsMutex.RUnlock()
+	fake.deleteChainIfExistsMutex.RLock()
+	defer fake.deleteChainIfExistsMutex.RUnlock()
+	fake.deleteRuleIfExistsMutex.RLock()
+	defer fake.deleteRuleIfExistsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
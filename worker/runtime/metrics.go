@@ -0,0 +1,227 @@
+package runtime
+
+import (
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+	v1 "github.com/containerd/cgroups/stats/v1"
+	"github.com/containerd/containerd/api/types"
+	"github.com/gogo/protobuf/proto"
+)
+
+const (
+	cgroupsV1MetricsType = "io.containerd.cgroups.v1.Metrics"
+	cgroupsV2MetricsType = "io.containerd.cgroups.v2.Metrics"
+)
+
+// cgroupsV2Metrics mirrors the subset of containerd's cgroups v2 metrics
+// message (github.com/containerd/cgroups/v2/stats) that we map onto garden
+// metrics. The vendored cgroups package predates v2 support, so the fields
+// are declared here with their wire tags.
+//
+type cgroupsV2Metrics struct {
+	Pids   *cgroupsV2PidsStat   `protobuf:"bytes,1,opt,name=pids,proto3"`
+	CPU    *cgroupsV2CPUStat    `protobuf:"bytes,2,opt,name=cpu,proto3"`
+	Memory *cgroupsV2MemoryStat `protobuf:"bytes,4,opt,name=memory,proto3"`
+}
+
+func (m *cgroupsV2Metrics) Reset()         { *m = cgroupsV2Metrics{} }
+func (m *cgroupsV2Metrics) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2Metrics) ProtoMessage()    {}
+
+type cgroupsV2PidsStat struct {
+	Current uint64 `protobuf:"varint,1,opt,name=current,proto3"`
+	Limit   uint64 `protobuf:"varint,2,opt,name=limit,proto3"`
+}
+
+func (m *cgroupsV2PidsStat) Reset()         { *m = cgroupsV2PidsStat{} }
+func (m *cgroupsV2PidsStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2PidsStat) ProtoMessage()    {}
+
+type cgroupsV2CPUStat struct {
+	UsageUsec  uint64 `protobuf:"varint,1,opt,name=usage_usec,proto3"`
+	UserUsec   uint64 `protobuf:"varint,2,opt,name=user_usec,proto3"`
+	SystemUsec uint64 `protobuf:"varint,3,opt,name=system_usec,proto3"`
+}
+
+func (m *cgroupsV2CPUStat) Reset()         { *m = cgroupsV2CPUStat{} }
+func (m *cgroupsV2CPUStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2CPUStat) ProtoMessage()    {}
+
+type cgroupsV2MemoryStat struct {
+	Anon         uint64 `protobuf:"varint,1,opt,name=anon,proto3"`
+	File         uint64 `protobuf:"varint,2,opt,name=file,proto3"`
+	FileMapped   uint64 `protobuf:"varint,7,opt,name=file_mapped,proto3"`
+	InactiveAnon uint64 `protobuf:"varint,11,opt,name=inactive_anon,proto3"`
+	ActiveAnon   uint64 `protobuf:"varint,12,opt,name=active_anon,proto3"`
+	InactiveFile uint64 `protobuf:"varint,13,opt,name=inactive_file,proto3"`
+	ActiveFile   uint64 `protobuf:"varint,14,opt,name=active_file,proto3"`
+	Unevictable  uint64 `protobuf:"varint,15,opt,name=unevictable,proto3"`
+	Pgfault      uint64 `protobuf:"varint,18,opt,name=pgfault,proto3"`
+	Pgmajfault   uint64 `protobuf:"varint,19,opt,name=pgmajfault,proto3"`
+	Usage        uint64 `protobuf:"varint,32,opt,name=usage,proto3"`
+	UsageLimit   uint64 `protobuf:"varint,33,opt,name=usage_limit,proto3"`
+	SwapUsage    uint64 `protobuf:"varint,34,opt,name=swap_usage,proto3"`
+}
+
+func (m *cgroupsV2MemoryStat) Reset()         { *m = cgroupsV2MemoryStat{} }
+func (m *cgroupsV2MemoryStat) String() string { return proto.CompactTextString(m) }
+func (*cgroupsV2MemoryStat) ProtoMessage()    {}
+
+// gardenMetrics converts the metrics reported by a containerd task into the
+// subset of garden metrics that can be derived from cgroups.
+//
+// Disk usage is not reported: container data lives in baggageclaim volumes,
+// which are accounted for separately.
+//
+func gardenMetrics(metric *types.Metric) (garden.Metrics, error) {
+	if metric == nil || metric.Data == nil {
+		return garden.Metrics{}, fmt.Errorf("empty task metrics")
+	}
+
+	switch metric.Data.TypeUrl {
+	case cgroupsV1MetricsType:
+		var m v1.Metrics
+		err := proto.Unmarshal(metric.Data.Value, &m)
+		if err != nil {
+			return garden.Metrics{}, fmt.Errorf("unmarshal cgroups v1 metrics: %w", err)
+		}
+
+		return cgroupsV1ToGarden(&m), nil
+
+	case cgroupsV2MetricsType:
+		var m cgroupsV2Metrics
+		err := proto.Unmarshal(metric.Data.Value, &m)
+		if err != nil {
+			return garden.Metrics{}, fmt.Errorf("unmarshal cgroups v2 metrics: %w", err)
+		}
+
+		return cgroupsV2ToGarden(&m), nil
+
+	default:
+		return garden.Metrics{}, fmt.Errorf("unsupported metrics type '%s'", metric.Data.TypeUrl)
+	}
+}
+
+func cgroupsV1ToGarden(m *v1.Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	if m.CPU != nil && m.CPU.Usage != nil {
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.Usage.Total,
+			User:   m.CPU.Usage.User,
+			System: m.CPU.Usage.Kernel,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	if mem := m.Memory; mem != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              mem.ActiveAnon,
+			ActiveFile:              mem.ActiveFile,
+			Cache:                   mem.Cache,
+			HierarchicalMemoryLimit: mem.HierarchicalMemoryLimit,
+			InactiveAnon:            mem.InactiveAnon,
+			InactiveFile:            mem.InactiveFile,
+			MappedFile:              mem.MappedFile,
+			Pgfault:                 mem.PgFault,
+			Pgmajfault:              mem.PgMajFault,
+			Pgpgin:                  mem.PgPgIn,
+			Pgpgout:                 mem.PgPgOut,
+			Rss:                     mem.RSS,
+			TotalActiveAnon:         mem.TotalActiveAnon,
+			TotalActiveFile:         mem.TotalActiveFile,
+			TotalCache:              mem.TotalCache,
+			TotalInactiveAnon:       mem.TotalInactiveAnon,
+			TotalInactiveFile:       mem.TotalInactiveFile,
+			TotalMappedFile:         mem.TotalMappedFile,
+			TotalPgfault:            mem.TotalPgFault,
+			TotalPgmajfault:         mem.TotalPgMajFault,
+			TotalPgpgin:             mem.TotalPgPgIn,
+			TotalPgpgout:            mem.TotalPgPgOut,
+			TotalRss:                mem.TotalRSS,
+			TotalUnevictable:        mem.TotalUnevictable,
+			Unevictable:             mem.Unevictable,
+			HierarchicalMemswLimit:  mem.HierarchicalSwapLimit,
+			TotalUsageTowardLimit:   usageTowardLimit(mem.TotalRSS+mem.TotalCache, mem.TotalInactiveFile),
+		}
+
+		if mem.Swap != nil {
+			metrics.MemoryStat.Swap = mem.Swap.Usage
+			metrics.MemoryStat.TotalSwap = mem.Swap.Usage
+		}
+	}
+
+	return metrics
+}
+
+func cgroupsV2ToGarden(m *cgroupsV2Metrics) garden.Metrics {
+	var metrics garden.Metrics
+
+	// cgroups v2 reports cpu time in microseconds whereas garden (following
+	// cgroups v1) reports it in nanoseconds.
+	if m.CPU != nil {
+		metrics.CPUStat = garden.ContainerCPUStat{
+			Usage:  m.CPU.UsageUsec * 1000,
+			User:   m.CPU.UserUsec * 1000,
+			System: m.CPU.SystemUsec * 1000,
+		}
+	}
+
+	if m.Pids != nil {
+		metrics.PidStat = garden.ContainerPidStat{
+			Current: m.Pids.Current,
+			Max:     m.Pids.Limit,
+		}
+	}
+
+	// cgroups v2 has no separate hierarchical counters; every stat already
+	// accounts for descendant cgroups, so the totals mirror the local values.
+	if mem := m.Memory; mem != nil {
+		metrics.MemoryStat = garden.ContainerMemoryStat{
+			ActiveAnon:              mem.ActiveAnon,
+			ActiveFile:              mem.ActiveFile,
+			Cache:                   mem.File,
+			HierarchicalMemoryLimit: mem.UsageLimit,
+			InactiveAnon:            mem.InactiveAnon,
+			InactiveFile:            mem.InactiveFile,
+			MappedFile:              mem.FileMapped,
+			Pgfault:                 mem.Pgfault,
+			Pgmajfault:              mem.Pgmajfault,
+			Rss:                     mem.Anon,
+			TotalActiveAnon:         mem.ActiveAnon,
+			TotalActiveFile:         mem.ActiveFile,
+			TotalCache:              mem.File,
+			TotalInactiveAnon:       mem.InactiveAnon,
+			TotalInactiveFile:       mem.InactiveFile,
+			TotalMappedFile:         mem.FileMapped,
+			TotalPgfault:            mem.Pgfault,
+			TotalPgmajfault:         mem.Pgmajfault,
+			TotalRss:                mem.Anon,
+			TotalUnevictable:        mem.Unevictable,
+			Unevictable:             mem.Unevictable,
+			Swap:                    mem.SwapUsage,
+			TotalSwap:               mem.SwapUsage,
+			TotalUsageTowardLimit:   usageTowardLimit(mem.Usage, mem.InactiveFile),
+		}
+	}
+
+	return metrics
+}
+
+// usageTowardLimit excludes inactive file cache from the usage, as the kernel
+// reclaims it before enforcing the memory limit (same as Guardian).
+//
+func usageTowardLimit(usage, inactiveFile uint64) uint64 {
+	if inactiveFile > usage {
+		return 0
+	}
+
+	return usage - inactiveFile
+}
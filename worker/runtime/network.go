@@ -15,16 +15,42 @@ type Network interface {
 	//
 	SetupMounts(handle string) (mounts []specs.Mount, err error)
 
+	// SetupSharedMounts prepares the mounts of a container which joins the
+	// network of the container with handle `peerHandle`, making it
+	// reachable from there by `alias`.
+	//
+	SetupSharedMounts(handle, peerHandle, alias string) (mounts []specs.Mount, err error)
+
 	// SetupRestrictedNetworks sets up networking rules to prevent
 	// container access to specified network ranges
 	//
 	SetupRestrictedNetworks() (err error)
 
-	// Add adds a task to the network.
+	// Add adds a task to the network, returning the IP address assigned to
+	// it.
+	//
+	Add(ctx context.Context, task containerd.Task) (ip string, err error)
+
+	// ApplyRules sets up the port mappings and egress rules of the
+	// container with the given handle and IP address, replacing any
+	// previously applied to it.
+	//
+	ApplyRules(handle, ip string, rules NetworkRules) (err error)
+
+	// AllocateHostPort reserves a port of the host to be forwarded to the
+	// container with the given handle: `port` if set, as long as it lies
+	// within the range of ports allowed by the operator and isn't used by
+	// another container, or a free one from that range otherwise.
+	//
+	AllocateHostPort(handle string, port uint32) (allocated uint32, err error)
+
+	// ReleaseHostPorts frees the ports of the host reserved for the
+	// container with the given handle.
 	//
-	Add(ctx context.Context, task containerd.Task) (err error)
+	ReleaseHostPorts(handle string)
 
-	// Removes a task from the network.
+	// Removes a task from the network, along with any rules applied to it
+	// and any ports of the host reserved for it.
 	//
 	Remove(ctx context.Context, task containerd.Task) (err error)
 }
@@ -0,0 +1,144 @@
+package runtime
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"code.cloudfoundry.org/garden"
+)
+
+const (
+	// ContainerIPKey is the label holding the IP address assigned to the
+	// container when it was added to the network.
+	//
+	ContainerIPKey = "garden.network.ip"
+
+	// NetInKey is the label holding the JSON-encoded port mappings
+	// ([]garden.NetIn) of the container.
+	//
+	NetInKey = "garden.network.net-in"
+
+	// NetOutKey is the label holding the JSON-encoded egress rules
+	// ([]garden.NetOutRule) allowed for the container.
+	//
+	NetOutKey = "garden.network.net-out"
+
+	// DenyNetOutKey is the property holding the JSON-encoded egress rules
+	// ([]garden.NetOutRule) denied for the container. Garden has no notion of
+	// deny rules, so the ATC passes them as a property.
+	//
+	DenyNetOutKey = "concourse.deny-net-out"
+
+	// JoinNetworkKey is the property holding the handle of a container whose
+	// network namespace the container joins instead of being added to the
+	// network on its own, e.g. for the service containers of a task.
+	//
+	JoinNetworkKey = "concourse.join-network"
+
+	// NetworkAliasKey is the property holding the hostname a container that
+	// joins the network of another one can be reached at from there.
+	//
+	NetworkAliasKey = "concourse.network-alias"
+)
+
+// NetworkRules are the rules applied to the traffic of a single container.
+//
+// Egress matching one of the NetOut rules is accepted, otherwise egress
+// matching one of the DenyNetOut rules is rejected. Egress matching neither
+// is subject only to the restricted networks configured by the operator,
+// unless there are NetOut rules but no DenyNetOut ones, in which case it is
+// rejected.
+//
+type NetworkRules struct {
+	NetIn      []garden.NetIn
+	NetOut     []garden.NetOutRule
+	DenyNetOut []garden.NetOutRule
+}
+
+// Empty returns whether there are no rules at all.
+//
+func (r NetworkRules) Empty() bool {
+	return len(r.NetIn) == 0 && len(r.NetOut) == 0 && len(r.DenyNetOut) == 0
+}
+
+// networkRulesFromLabels decodes the network rules stored in the labels of a
+// container.
+//
+func networkRulesFromLabels(labels map[string]string) (NetworkRules, error) {
+	var rules NetworkRules
+
+	for key, dest := range map[string]interface{}{
+		NetInKey:      &rules.NetIn,
+		NetOutKey:     &rules.NetOut,
+		DenyNetOutKey: &rules.DenyNetOut,
+	} {
+		value, found := labels[key]
+		if !found || value == "" {
+			continue
+		}
+
+		err := json.Unmarshal([]byte(value), dest)
+		if err != nil {
+			return NetworkRules{}, fmt.Errorf("unmarshal %s: %w", key, err)
+		}
+	}
+
+	return rules, nil
+}
+
+// labels encodes the port mappings and allowed egress rules so that they can
+// be stored as labels of a container. The denied egress rules are left out as
+// they're set by the ATC on creation and never change afterwards.
+//
+func (r NetworkRules) labels() (map[string]string, error) {
+	labels := map[string]string{}
+
+	for key, value := range map[string]interface{}{
+		NetInKey:  r.NetIn,
+		NetOutKey: r.NetOut,
+	} {
+		payload, err := json.Marshal(value)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s: %w", key, err)
+		}
+
+		labels[key] = string(payload)
+	}
+
+	return labels, nil
+}
+
+// mappedPorts converts the port mappings to the form reported in the
+// container's info.
+//
+func (r NetworkRules) mappedPorts() []garden.PortMapping {
+	var mappings []garden.PortMapping
+	for _, netIn := range r.NetIn {
+		mappings = append(mappings, garden.PortMapping{
+			HostPort:      netIn.HostPort,
+			ContainerPort: netIn.ContainerPort,
+		})
+	}
+
+	return mappings
+}
+
+// resolveNetIn fills in the ports omitted from a port mapping the same way
+// Guardian does: an unset container port defaults to the host port, and an
+// unset host port is picked from the free ports of the host port range. The
+// host port is reserved for the container with the given handle.
+//
+func resolveNetIn(network Network, handle string, netIn garden.NetIn) (garden.NetIn, error) {
+	port, err := network.AllocateHostPort(handle, netIn.HostPort)
+	if err != nil {
+		return garden.NetIn{}, fmt.Errorf("allocate host port: %w", err)
+	}
+
+	netIn.HostPort = port
+
+	if netIn.ContainerPort == 0 {
+		netIn.ContainerPort = netIn.HostPort
+	}
+
+	return netIn, nil
+}
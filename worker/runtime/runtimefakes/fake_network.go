@@ -11,18 +11,52 @@ import (
 )
 
 type FakeNetwork struct {
-	AddStub        func(context.Context, containerd.Task) error
+	AddStub        func(context.Context, containerd.Task) (string, error)
 	addMutex       sync.RWMutex
 	addArgsForCall []struct {
 		arg1 context.Context
 		arg2 containerd.Task
 	}
 	addReturns struct {
-		result1 error
+		result1 string
+		result2 error
 	}
 	addReturnsOnCall map[int]struct {
+		result1 string
+		result2 error
+	}
+	AllocateHostPortStub        func(string, uint32) (uint32, error)
+	allocateHostPortMutex       sync.RWMutex
+	allocateHostPortArgsForCall []struct {
+		arg1 string
+		arg2 uint32
+	}
+	allocateHostPortReturns struct {
+		result1 uint32
+		result2 error
+	}
+	allocateHostPortReturnsOnCall map[int]struct {
+		result1 uint32
+		result2 error
+	}
+	ApplyRulesStub        func(string, string, runtime.NetworkRules) error
+	applyRulesMutex       sync.RWMutex
+	applyRulesArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 runtime.NetworkRules
+	}
+	applyRulesReturns struct {
 		result1 error
 	}
+	applyRulesReturnsOnCall map[int]struct {
+		result1 error
+	}
+	ReleaseHostPortsStub        func(string)
+	releaseHostPortsMutex       sync.RWMutex
+	releaseHostPortsArgsForCall []struct {
+		arg1 string
+	}
 	RemoveStub        func(context.Context, containerd.Task) error
 	removeMutex       sync.RWMutex
 	removeArgsForCall []struct {
@@ -58,11 +92,26 @@ type FakeNetwork struct {
 	setupRestrictedNetworksReturnsOnCall map[int]struct {
 		result1 error
 	}
+	SetupSharedMountsStub        func(string, string, string) ([]specs.Mount, error)
+	setupSharedMountsMutex       sync.RWMutex
+	setupSharedMountsArgsForCall []struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}
+	setupSharedMountsReturns struct {
+		result1 []specs.Mount
+		result2 error
+	}
+	setupSharedMountsReturnsOnCall map[int]struct {
+		result1 []specs.Mount
+		result2 error
+	}
 	invocations      map[string][][]interface{}
 	invocationsMutex sync.RWMutex
 }
 
-func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
+func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) (string, error) {
 	fake.addMutex.Lock()
 	ret, specificReturn := fake.addReturnsOnCall[len(fake.addArgsForCall)]
 	fake.addArgsForCall = append(fake.addArgsForCall, struct {
@@ -75,10 +124,10 @@ func (fake *FakeNetwork) Add(arg1 context.Context, arg2 containerd.Task) error {
 		return fake.AddStub(arg1, arg2)
 	}
 	if specificReturn {
-		return ret.result1
+		return ret.result1, ret.result2
 	}
 	fakeReturns := fake.addReturns
-	return fakeReturns.result1
+	return fakeReturns.result1, fakeReturns.result2
 }
 
 func (fake *FakeNetwork) AddCallCount() int {
@@ -87,7 +136,7 @@ func (fake *FakeNetwork) AddCallCount() int {
 	return len(fake.addArgsForCall)
 }
 
-func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) error) {
+func (fake *FakeNetwork) AddCalls(stub func(context.Context, containerd.Task) (string, error)) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = stub
@@ -100,29 +149,189 @@ func (fake *FakeNetwork) AddArgsForCall(i int) (context.Context, containerd.Task
 	return argsForCall.arg1, argsForCall.arg2
 }
 
-func (fake *FakeNetwork) AddReturns(result1 error) {
+func (fake *FakeNetwork) AddReturns(result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	fake.addReturns = struct {
-		result1 error
-	}{result1}
+		result1 string
+		result2 error
+	}{result1, result2}
 }
 
-func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 error) {
+func (fake *FakeNetwork) AddReturnsOnCall(i int, result1 string, result2 error) {
 	fake.addMutex.Lock()
 	defer fake.addMutex.Unlock()
 	fake.AddStub = nil
 	if fake.addReturnsOnCall == nil {
 		fake.addReturnsOnCall = make(map[int]struct {
-			result1 error
+			result1 string
+			result2 error
 		})
 	}
 	fake.addReturnsOnCall[i] = struct {
+		result1 string
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) AllocateHostPort(arg1 string, arg2 uint32) (uint32, error) {
+	fake.allocateHostPortMutex.Lock()
+	ret, specificReturn := fake.allocateHostPortReturnsOnCall[len(fake.allocateHostPortArgsForCall)]
+	fake.allocateHostPortArgsForCall = append(fake.allocateHostPortArgsForCall, struct {
+		arg1 string
+		arg2 uint32
+	}{arg1, arg2})
+	fake.recordInvocation("AllocateHostPort", []interface{}{arg1, arg2})
+	fake.allocateHostPortMutex.Unlock()
+	if fake.AllocateHostPortStub != nil {
+		return fake.AllocateHostPortStub(arg1, arg2)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.allocateHostPortReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNetwork) AllocateHostPortCallCount() int {
+	fake.allocateHostPortMutex.RLock()
+	defer fake.allocateHostPortMutex.RUnlock()
+	return len(fake.allocateHostPortArgsForCall)
+}
+
+func (fake *FakeNetwork) AllocateHostPortCalls(stub func(string, uint32) (uint32, error)) {
+	fake.allocateHostPortMutex.Lock()
+	defer fake.allocateHostPortMutex.Unlock()
+	fake.AllocateHostPortStub = stub
+}
+
+func (fake *FakeNetwork) AllocateHostPortArgsForCall(i int) (string, uint32) {
+	fake.allocateHostPortMutex.RLock()
+	defer fake.allocateHostPortMutex.RUnlock()
+	argsForCall := fake.allocateHostPortArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2
+}
+
+func (fake *FakeNetwork) AllocateHostPortReturns(result1 uint32, result2 error) {
+	fake.allocateHostPortMutex.Lock()
+	defer fake.allocateHostPortMutex.Unlock()
+	fake.AllocateHostPortStub = nil
+	fake.allocateHostPortReturns = struct {
+		result1 uint32
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) AllocateHostPortReturnsOnCall(i int, result1 uint32, result2 error) {
+	fake.allocateHostPortMutex.Lock()
+	defer fake.allocateHostPortMutex.Unlock()
+	fake.AllocateHostPortStub = nil
+	if fake.allocateHostPortReturnsOnCall == nil {
+		fake.allocateHostPortReturnsOnCall = make(map[int]struct {
+			result1 uint32
+			result2 error
+		})
+	}
+	fake.allocateHostPortReturnsOnCall[i] = struct {
+		result1 uint32
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) ApplyRules(arg1 string, arg2 string, arg3 runtime.NetworkRules) error {
+	fake.applyRulesMutex.Lock()
+	ret, specificReturn := fake.applyRulesReturnsOnCall[len(fake.applyRulesArgsForCall)]
+	fake.applyRulesArgsForCall = append(fake.applyRulesArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 runtime.NetworkRules
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("ApplyRules", []interface{}{arg1, arg2, arg3})
+	fake.applyRulesMutex.Unlock()
+	if fake.ApplyRulesStub != nil {
+		return fake.ApplyRulesStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1
+	}
+	fakeReturns := fake.applyRulesReturns
+	return fakeReturns.result1
+}
+
+func (fake *FakeNetwork) ApplyRulesCallCount() int {
+	fake.applyRulesMutex.RLock()
+	defer fake.applyRulesMutex.RUnlock()
+	return len(fake.applyRulesArgsForCall)
+}
+
+func (fake *FakeNetwork) ApplyRulesCalls(stub func(string, string, runtime.NetworkRules) error) {
+	fake.applyRulesMutex.Lock()
+	defer fake.applyRulesMutex.Unlock()
+	fake.ApplyRulesStub = stub
+}
+
+func (fake *FakeNetwork) ApplyRulesArgsForCall(i int) (string, string, runtime.NetworkRules) {
+	fake.applyRulesMutex.RLock()
+	defer fake.applyRulesMutex.RUnlock()
+	argsForCall := fake.applyRulesArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNetwork) ApplyRulesReturns(result1 error) {
+	fake.applyRulesMutex.Lock()
+	defer fake.applyRulesMutex.Unlock()
+	fake.ApplyRulesStub = nil
+	fake.applyRulesReturns = struct {
 		result1 error
 	}{result1}
 }
 
+func (fake *FakeNetwork) ApplyRulesReturnsOnCall(i int, result1 error) {
+	fake.applyRulesMutex.Lock()
+	defer fake.applyRulesMutex.Unlock()
+	fake.ApplyRulesStub = nil
+	if fake.applyRulesReturnsOnCall == nil {
+		fake.applyRulesReturnsOnCall = make(map[int]struct {
+			result1 error
+		})
+	}
+	fake.applyRulesReturnsOnCall[i] = struct {
+		result1 error
+	}{result1}
+}
+
+func (fake *FakeNetwork) ReleaseHostPorts(arg1 string) {
+	fake.releaseHostPortsMutex.Lock()
+	fake.releaseHostPortsArgsForCall = append(fake.releaseHostPortsArgsForCall, struct {
+		arg1 string
+	}{arg1})
+	fake.recordInvocation("ReleaseHostPorts", []interface{}{arg1})
+	fake.releaseHostPortsMutex.Unlock()
+	if fake.ReleaseHostPortsStub != nil {
+		fake.ReleaseHostPortsStub(arg1)
+	}
+}
+
+func (fake *FakeNetwork) ReleaseHostPortsCallCount() int {
+	fake.releaseHostPortsMutex.RLock()
+	defer fake.releaseHostPortsMutex.RUnlock()
+	return len(fake.releaseHostPortsArgsForCall)
+}
+
+func (fake *FakeNetwork) ReleaseHostPortsCalls(stub func(string)) {
+	fake.releaseHostPortsMutex.Lock()
+	defer fake.releaseHostPortsMutex.Unlock()
+	fake.ReleaseHostPortsStub = stub
+}
+
+func (fake *FakeNetwork) ReleaseHostPortsArgsForCall(i int) string {
+	fake.releaseHostPortsMutex.RLock()
+	defer fake.releaseHostPortsMutex.RUnlock()
+	argsForCall := fake.releaseHostPortsArgsForCall[i]
+	return argsForCall.arg1
+}
+
 func (fake *FakeNetwork) Remove(arg1 context.Context, arg2 containerd.Task) error {
 	fake.removeMutex.Lock()
 	ret, specificReturn := fake.removeReturnsOnCall[len(fake.removeArgsForCall)]
@@ -299,17 +508,90 @@ func (fake *FakeNetwork) SetupRestrictedNetworksReturnsOnCall(i int, result1 err
 	}{result1}
 }
 
+func (fake *FakeNetwork) SetupSharedMounts(arg1 string, arg2 string, arg3 string) ([]specs.Mount, error) {
+	fake.setupSharedMountsMutex.Lock()
+	ret, specificReturn := fake.setupSharedMountsReturnsOnCall[len(fake.setupSharedMountsArgsForCall)]
+	fake.setupSharedMountsArgsForCall = append(fake.setupSharedMountsArgsForCall, struct {
+		arg1 string
+		arg2 string
+		arg3 string
+	}{arg1, arg2, arg3})
+	fake.recordInvocation("SetupSharedMounts", []interface{}{arg1, arg2, arg3})
+	fake.setupSharedMountsMutex.Unlock()
+	if fake.SetupSharedMountsStub != nil {
+		return fake.SetupSharedMountsStub(arg1, arg2, arg3)
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.setupSharedMountsReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeNetwork) SetupSharedMountsCallCount() int {
+	fake.setupSharedMountsMutex.RLock()
+	defer fake.setupSharedMountsMutex.RUnlock()
+	return len(fake.setupSharedMountsArgsForCall)
+}
+
+func (fake *FakeNetwork) SetupSharedMountsCalls(stub func(string, string, string) ([]specs.Mount, error)) {
+	fake.setupSharedMountsMutex.Lock()
+	defer fake.setupSharedMountsMutex.Unlock()
+	fake.SetupSharedMountsStub = stub
+}
+
+func (fake *FakeNetwork) SetupSharedMountsArgsForCall(i int) (string, string, string) {
+	fake.setupSharedMountsMutex.RLock()
+	defer fake.setupSharedMountsMutex.RUnlock()
+	argsForCall := fake.setupSharedMountsArgsForCall[i]
+	return argsForCall.arg1, argsForCall.arg2, argsForCall.arg3
+}
+
+func (fake *FakeNetwork) SetupSharedMountsReturns(result1 []specs.Mount, result2 error) {
+	fake.setupSharedMountsMutex.Lock()
+	defer fake.setupSharedMountsMutex.Unlock()
+	fake.SetupSharedMountsStub = nil
+	fake.setupSharedMountsReturns = struct {
+		result1 []specs.Mount
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeNetwork) SetupSharedMountsReturnsOnCall(i int, result1 []specs.Mount, result2 error) {
+	fake.setupSharedMountsMutex.Lock()
+	defer fake.setupSharedMountsMutex.Unlock()
+	fake.SetupSharedMountsStub = nil
+	if fake.setupSharedMountsReturnsOnCall == nil {
+		fake.setupSharedMountsReturnsOnCall = make(map[int]struct {
+			result1 []specs.Mount
+			result2 error
+		})
+	}
+	fake.setupSharedMountsReturnsOnCall[i] = struct {
+		result1 []specs.Mount
+		result2 error
+	}{result1, result2}
+}
+
 func (fake *FakeNetwork) Invocations() map[string][][]interface{} {
 	fake.invocationsMutex.RLock()
 	defer fake.invocationsMutex.RUnlock()
 	fake.addMutex.RLock()
 	defer fake.addMutex.RUnlock()
+	fake.allocateHostPortMutex.RLock()
+	defer fake.allocateHostPortMutex.RUnlock()
+	fake.applyRulesMutex.RLock()
+	defer fake.applyRulesMutex.RUnlock()
+	fake.releaseHostPortsMutex.RLock()
+	defer fake.releaseHostPortsMutex.RUnlock()
 	fake.removeMutex.RLock()
 	defer fake.removeMutex.RUnlock()
 	fake.setupMountsMutex.RLock()
 	defer fake.setupMountsMutex.RUnlock()
 	fake.setupRestrictedNetworksMutex.RLock()
 	defer fake.setupRestrictedNetworksMutex.RUnlock()
+	fake.setupSharedMountsMutex.RLock()
+	defer fake.setupSharedMountsMutex.RUnlock()
 	copiedInvocations := map[string][][]interface{}{}
 	for key, value := range fake.invocations {
 		copiedInvocations[key] = value
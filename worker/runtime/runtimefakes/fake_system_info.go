@@ -0,0 +1,175 @@
+// Code generated by counterfeiter. DO NOT EDIT.
+package runtimefakes
+
+import (
+	"sync"
+
+	"github.com/concourse/concourse/worker/runtime"
+)
+
+type FakeSystemInfo struct {
+	AvailableDiskStub        func() (uint64, error)
+	availableDiskMutex       sync.RWMutex
+	availableDiskArgsForCall []struct {
+	}
+	availableDiskReturns struct {
+		result1 uint64
+		result2 error
+	}
+	availableDiskReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	TotalMemoryStub        func() (uint64, error)
+	totalMemoryMutex       sync.RWMutex
+	totalMemoryArgsForCall []struct {
+	}
+	totalMemoryReturns struct {
+		result1 uint64
+		result2 error
+	}
+	totalMemoryReturnsOnCall map[int]struct {
+		result1 uint64
+		result2 error
+	}
+	invocations      map[string][][]interface{}
+	invocationsMutex sync.RWMutex
+}
+
+func (fake *FakeSystemInfo) AvailableDisk() (uint64, error) {
+	fake.availableDiskMutex.Lock()
+	ret, specificReturn := fake.availableDiskReturnsOnCall[len(fake.availableDiskArgsForCall)]
+	fake.availableDiskArgsForCall = append(fake.availableDiskArgsForCall, struct {
+	}{})
+	fake.recordInvocation("AvailableDisk", []interface{}{})
+	fake.availableDiskMutex.Unlock()
+	if fake.AvailableDiskStub != nil {
+		return fake.AvailableDiskStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.availableDiskReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSystemInfo) AvailableDiskCallCount() int {
+	fake.availableDiskMutex.RLock()
+	defer fake.availableDiskMutex.RUnlock()
+	return len(fake.availableDiskArgsForCall)
+}
+
+func (fake *FakeSystemInfo) AvailableDiskCalls(stub func() (uint64, error)) {
+	fake.availableDiskMutex.Lock()
+	defer fake.availableDiskMutex.Unlock()
+	fake.AvailableDiskStub = stub
+}
+
+func (fake *FakeSystemInfo) AvailableDiskReturns(result1 uint64, result2 error) {
+	fake.availableDiskMutex.Lock()
+	defer fake.availableDiskMutex.Unlock()
+	fake.AvailableDiskStub = nil
+	fake.availableDiskReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSystemInfo) AvailableDiskReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.availableDiskMutex.Lock()
+	defer fake.availableDiskMutex.Unlock()
+	fake.AvailableDiskStub = nil
+	if fake.availableDiskReturnsOnCall == nil {
+		fake.availableDiskReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.availableDiskReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSystemInfo) TotalMemory() (uint64, error) {
+	fake.totalMemoryMutex.Lock()
+	ret, specificReturn := fake.totalMemoryReturnsOnCall[len(fake.totalMemoryArgsForCall)]
+	fake.totalMemoryArgsForCall = append(fake.totalMemoryArgsForCall, struct {
+	}{})
+	fake.recordInvocation("TotalMemory", []interface{}{})
+	fake.totalMemoryMutex.Unlock()
+	if fake.TotalMemoryStub != nil {
+		return fake.TotalMemoryStub()
+	}
+	if specificReturn {
+		return ret.result1, ret.result2
+	}
+	fakeReturns := fake.totalMemoryReturns
+	return fakeReturns.result1, fakeReturns.result2
+}
+
+func (fake *FakeSystemInfo) TotalMemoryCallCount() int {
+	fake.totalMemoryMutex.RLock()
+	defer fake.totalMemoryMutex.RUnlock()
+	return len(fake.totalMemoryArgsForCall)
+}
+
+func (fake *FakeSystemInfo) TotalMemoryCalls(stub func() (uint64, error)) {
+	fake.totalMemoryMutex.Lock()
+	defer fake.totalMemoryMutex.Unlock()
+	fake.TotalMemoryStub = stub
+}
+
+func (fake *FakeSystemInfo) TotalMemoryReturns(result1 uint64, result2 error) {
+	fake.totalMemoryMutex.Lock()
+	defer fake.totalMemoryMutex.Unlock()
+	fake.TotalMemoryStub = nil
+	fake.totalMemoryReturns = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSystemInfo) TotalMemoryReturnsOnCall(i int, result1 uint64, result2 error) {
+	fake.totalMemoryMutex.Lock()
+	defer fake.totalMemoryMutex.Unlock()
+	fake.TotalMemoryStub = nil
+	if fake.totalMemoryReturnsOnCall == nil {
+		fake.totalMemoryReturnsOnCall = make(map[int]struct {
+			result1 uint64
+			result2 error
+		})
+	}
+	fake.totalMemoryReturnsOnCall[i] = struct {
+		result1 uint64
+		result2 error
+	}{result1, result2}
+}
+
+func (fake *FakeSystemInfo) Invocations() map[string][][]interface{} {
+	fake.invocationsMutex.RLock()
+	defer fake.invocationsMutex.RUnlock()
+	fake.availableDiskMutex.RLock()
+	defer fake.availableDiskMutex.RUnlock()
+	fake.totalMemoryMutex.RLock()
+	defer fake.totalMemoryMutex.RUnlock()
+	copiedInvocations := map[string][][]interface{}{}
+	for key, value := range fake.invocations {
+		copiedInvocations[key] = value
+	}
+	return copiedInvocations
+}
+
+func (fake *FakeSystemInfo) recordInvocation(key string, args []interface{}) {
+	fake.invocationsMutex.Lock()
+	defer fake.invocationsMutex.Unlock()
+	if fake.invocations == nil {
+		fake.invocations = map[string][][]interface{}{}
+	}
+	if fake.invocations[key] == nil {
+		fake.invocations[key] = [][]interface{}{}
+	}
+	fake.invocations[key] = append(fake.invocations[key], args)
+}
+
+var _ runtime.SystemInfo = new(FakeSystemInfo)
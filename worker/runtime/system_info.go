@@ -0,0 +1,109 @@
+package runtime
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"syscall"
+)
+
+const memInfo = "/proc/meminfo"
+
+//go:generate go run github.com/maxbrunsfeld/counterfeiter/v6 . SystemInfo
+
+// SystemInfo reports the resources of the host that containers are placed
+// on.
+//
+type SystemInfo interface {
+	// TotalMemory returns the amount of physical memory in bytes.
+	//
+	TotalMemory() (uint64, error)
+
+	// AvailableDisk returns the amount of disk space in bytes available for
+	// container data.
+	//
+	AvailableDisk() (uint64, error)
+}
+
+// WithSystemInfo configures the SystemInfo used to report capacity.
+//
+func WithSystemInfo(s SystemInfo) GardenBackendOpt {
+	return func(b *GardenBackend) {
+		b.systemInfo = s
+	}
+}
+
+type systemInfo struct {
+	diskPath string
+}
+
+// NewSystemInfo instantiates a SystemInfo that reads memory from
+// /proc/meminfo and disk space from the filesystem holding diskPath.
+//
+func NewSystemInfo(diskPath string) SystemInfo {
+	return &systemInfo{
+		diskPath: diskPath,
+	}
+}
+
+func (s *systemInfo) TotalMemory() (uint64, error) {
+	f, err := os.Open(memInfo)
+	if err != nil {
+		return 0, fmt.Errorf("open %s: %w", memInfo, err)
+	}
+	defer f.Close()
+
+	return MemTotal(f)
+}
+
+func (s *systemInfo) AvailableDisk() (uint64, error) {
+	var stat syscall.Statfs_t
+
+	err := syscall.Statfs(s.diskPath, &stat)
+	if err != nil {
+		return 0, fmt.Errorf("statfs %s: %w", s.diskPath, err)
+	}
+
+	return stat.Bavail * uint64(stat.Bsize), nil
+}
+
+// MemTotal parses the total amount of memory in bytes out of the contents of
+// /proc/meminfo.
+//
+// For example, given the following line:
+//
+// 	MemTotal:        8167848 kB
+//
+// 8363876352 (8167848 * 1024) is returned.
+//
+func MemTotal(reader io.Reader) (uint64, error) {
+	scanner := bufio.NewScanner(reader)
+
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || fields[0] != "MemTotal:" {
+			continue
+		}
+
+		total, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return 0, fmt.Errorf("parse MemTotal '%s': %w", fields[1], err)
+		}
+
+		if len(fields) == 3 && fields[2] == "kB" {
+			total *= 1024
+		}
+
+		return total, nil
+	}
+
+	err := scanner.Err()
+	if err != nil {
+		return 0, fmt.Errorf("scan: %w", err)
+	}
+
+	return 0, fmt.Errorf("MemTotal not found")
+}
@@ -0,0 +1,55 @@
+package runtime_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/concourse/concourse/worker/runtime"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type SystemInfoSuite struct {
+	suite.Suite
+	*require.Assertions
+}
+
+func (s *SystemInfoSuite) TestMemTotal() {
+	for _, tc := range []struct {
+		desc      string
+		input     string
+		shouldErr bool
+		val       uint64
+	}{
+		{
+			desc:      "empty input",
+			shouldErr: true,
+		},
+		{
+			desc:      "invalid value",
+			input:     "MemTotal:        abc kB",
+			shouldErr: true,
+		},
+		{
+			desc:  "in kB",
+			input: "MemTotal:        8167848 kB",
+			val:   8167848 * 1024,
+		},
+		{
+			desc:  "among other fields",
+			input: "MemFree:         1000 kB\nMemTotal:        2 kB\nMemAvailable:    3 kB",
+			val:   2048,
+		},
+	} {
+		s.T().Run(tc.desc, func(t *testing.T) {
+			res, err := runtime.MemTotal(bytes.NewBufferString(tc.input))
+			if tc.shouldErr {
+				s.Error(err)
+				return
+			}
+
+			s.NoError(err)
+			s.Equal(tc.val, res)
+		})
+	}
+}
@@ -8,6 +8,8 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
+	"strings"
 	"syscall"
 
 	"code.cloudfoundry.org/garden/server"
@@ -77,6 +79,13 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 			}))
 	}
 
+	hostPortStart, hostPortEnd, err := parseHostPortRange(cmd.Containerd.HostPortRange)
+	if err != nil {
+		return nil, fmt.Errorf("host port range: %w", err)
+	}
+
+	networkOpts = append(networkOpts, runtime.WithHostPortRange(hostPortStart, hostPortEnd))
+
 	cniNetwork, err := runtime.NewCNINetwork(networkOpts...)
 	if err != nil {
 		return nil, fmt.Errorf("new cni network: %w", err)
@@ -87,6 +96,9 @@ func (cmd *WorkerCommand) containerdGardenServerRunner(
 		runtime.WithRequestTimeout(cmd.Containerd.RequestTimeout),
 		runtime.WithMaxContainers(cmd.Containerd.MaxContainers),
 		runtime.WithInitBinPath(cmd.Containerd.InitBin),
+		// container data lives in baggageclaim volumes, so that's the disk
+		// whose capacity is worth reporting.
+		runtime.WithSystemInfo(runtime.NewSystemInfo(filepath.Join(cmd.WorkDir.Path(), "volumes"))),
 	)
 
 	gardenBackend, err := runtime.NewGardenBackend(
@@ -195,3 +207,27 @@ func (cmd *WorkerCommand) containerdRunner(logger lager.Logger) (ifrit.Runner, e
 	// Using the Ordered strategy to ensure containerd is up before the garden server is started
 	return grouper.NewOrdered(os.Interrupt, members), nil
 }
+
+// parseHostPortRange parses a range of ports of the form `start-end`.
+func parseHostPortRange(portRange string) (uint32, uint32, error) {
+	parts := strings.Split(portRange, "-")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid range %q: expected start-end", portRange)
+	}
+
+	start, err := strconv.ParseUint(parts[0], 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid start of range %q: %w", portRange, err)
+	}
+
+	end, err := strconv.ParseUint(parts[1], 10, 16)
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid end of range %q: %w", portRange, err)
+	}
+
+	if start == 0 || start > end {
+		return 0, 0, fmt.Errorf("invalid range %q", portRange)
+	}
+
+	return uint32(start), uint32(end), nil
+}
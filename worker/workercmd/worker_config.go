@@ -1,6 +1,7 @@
 package workercmd
 
 import (
+	"runtime"
 	"time"
 
 	"github.com/concourse/concourse/atc"
@@ -26,6 +27,7 @@ func (c WorkerConfig) Worker() atc.Worker {
 		Team:          c.TeamName,
 		Name:          c.Name,
 		StartTime:     time.Now().Unix(),
+		CPUs:          runtime.NumCPU(),
 		Version:       c.Version,
 		HTTPProxyURL:  c.HTTPProxy,
 		HTTPSProxyURL: c.HTTPSProxy,
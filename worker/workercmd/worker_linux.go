@@ -49,6 +49,7 @@ type ContainerdRuntime struct {
 	RestrictedNetworks []string  `long:"restricted-network" description:"Network ranges to which traffic from containers will be restricted. Can be specified multiple times."`
 	MaxContainers      int       `long:"max-containers" default:"250" description:"Max container capacity. 0 means no limit."`
 	NetworkPool        string    `long:"network-pool" default:"10.80.0.0/16" description:"Network range to use for dynamically allocated container subnets."`
+	HostPortRange      string    `long:"host-port-range" default:"61001-65534" description:"Range of host ports which can be forwarded to containers, e.g. for tasks exposing ports."`
 }
 
 const containerdRuntime = "containerd"
@@ -101,6 +102,10 @@ func (cmd *WorkerCommand) gardenServerRunner(logger lager.Logger) (atc.Worker, i
 		runner, err = cmd.houdiniRunner(logger)
 	case cmd.Runtime == containerdRuntime:
 		runner, err = cmd.containerdRunner(logger)
+		worker.Features = append(worker.Features,
+			atc.WorkerFeatureNetworkRules,
+			atc.WorkerFeatureJoinNetwork,
+		)
 	case cmd.Runtime == guardianRuntime:
 		runner, err = cmd.guardianRunner(logger)
 	default: